@@ -27,10 +27,13 @@ func main() {
 		commands.IndexCommand,
 		commands.ImportCommand,
 		commands.PurgeCommand,
+		commands.ColdCommand,
 		commands.CopyCommand,
 		commands.ConvertCommand,
 		commands.ResampleCommand,
 		commands.MigrateCommand,
+		commands.PartitionCommand,
+		commands.SecretsCommand,
 		commands.ConfigCommand,
 		commands.VersionCommand,
 		commands.StatusCommand,
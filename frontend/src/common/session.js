@@ -1,7 +1,7 @@
 import Api from "./api";
 import Event from "pubsub-js";
 import User from "../model/user";
-import Socket from "./websocket";
+import Socket, {lastEventSeq} from "./websocket";
 
 export default class Session {
     /**
@@ -139,6 +139,7 @@ export default class Session {
             "js": window.__CONFIG__.jsHash,
             "css": window.__CONFIG__.cssHash,
             "version": window.__CONFIG__.version,
+            "since": lastEventSeq(),
         };
 
         try {
@@ -5,6 +5,13 @@ const host = window.location.host;
 const prot = ("https:" === document.location.protocol ? "wss://" : "ws://");
 const url = prot + host + "/api/v1/ws";
 
+// Sequence number of the last event received, so that missed events can be replayed after reconnecting.
+let lastSeq = 0;
+
+export function lastEventSeq() {
+    return lastSeq;
+}
+
 const Socket = new Sockette(url, {
     timeout: 5e3,
     onopen: e => {
@@ -13,6 +20,11 @@ const Socket = new Sockette(url, {
     },
     onmessage: e => {
         const m = JSON.parse(e.data);
+
+        if (m.data && m.data.seq > lastSeq) {
+            lastSeq = m.data.seq;
+        }
+
         Event.publish(m.event, m.data);
     },
     onreconnect: e => console.log("websocket: reconnecting", e),
@@ -10,6 +10,7 @@ export class Album extends RestModel {
             Cover: "",
             Parent: "",
             Folder: "",
+            ParentUID: "",
             Slug: "",
             Type: "",
             Title: "",
@@ -17,18 +18,22 @@ export class Album extends RestModel {
             Category: "",
             Description: "",
             Notes: "",
+            Story: "",
+            Location: "",
             Filter: "",
             Order: "",
             Template: "",
             Country: "",
             Year: 0,
             Month: 0,
+            Day: 0,
             Favorite: true,
             Private: false,
             PhotoCount: 0,
             Links: [],
             CreatedAt: "",
             UpdatedAt: "",
+            Version: 0,
         };
     }
 
@@ -35,6 +35,7 @@ export class File extends RestModel {
             Luminance: "",
             Diff: 0,
             Chroma: 0,
+            Mono: false,
             Notes: "",
             Error: "",
             Links: [],
@@ -1,14 +1,20 @@
 import RestModel from "model/rest";
+import Api from "common/api";
 
 export class Link extends RestModel {
     getDefaults() {
         return {
             Token: "",
             Password: "",
+            HasPassword: false,
             Expires: "",
+            Renewal: 0,
             ShareUID: "",
             CanComment: false,
             CanEdit: false,
+            Subtree: false,
+            AllowedIPs: "",
+            AllowedOrigins: "",
             CreatedAt: "",
             UpdatedAt: "",
             Links: [],
@@ -19,6 +25,14 @@ export class Link extends RestModel {
         return this.Token;
     }
 
+    renew(expires) {
+        return Api.post(this.getEntityResource() + "/renew", expires ? {Expires: expires} : {}).then((resp) => Promise.resolve(this.setValues(resp.data)));
+    }
+
+    setRenewal(renewal) {
+        return Api.put(this.getEntityResource() + "/renewal", {Renewal: renewal}).then((resp) => Promise.resolve(this.setValues(resp.data)));
+    }
+
     static getCollectionResource() {
         return "links";
     }
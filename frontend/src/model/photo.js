@@ -11,6 +11,8 @@ export const TypeJpeg = "jpg";
 export const TypeImage = "image";
 export const YearUnknown = -1;
 export const MonthUnknown = -1;
+export const ApproxYear = "year";
+export const ApproxDecade = "decade";
 
 export class Photo extends RestModel {
     getDefaults() {
@@ -23,6 +25,7 @@ export class Photo extends RestModel {
             TakenAt: "",
             TakenAtLocal: "",
             TakenSrc: "",
+            TakenApprox: "",
             TimeZone: "",
             Path: "",
             Color: "",
@@ -31,11 +34,14 @@ export class Photo extends RestModel {
             TitleSrc: "",
             Description: "",
             DescriptionSrc: "",
+            AlbumCaption: "",
             Resolution: 0,
             Quality: 0,
             Lat: 0.0,
             Lng: 0.0,
             Altitude: 0,
+            Direction: 0.0,
+            Speed: 0.0,
             Iso: 0,
             FocalLength: 0,
             FNumber: 0.0,
@@ -78,6 +84,7 @@ export class Photo extends RestModel {
             Hash: "",
             Width: "",
             Height: "",
+            Version: 0,
             // Date fields.
             CreatedAt: "",
             UpdatedAt: "",
@@ -291,6 +298,10 @@ export class Photo extends RestModel {
             return "Unknown";
         }
 
+        if (this.TakenApprox) {
+            return this.approxDateString();
+        }
+
         if (this.TimeZone) {
             return DateTime.fromISO(this.TakenAt).setZone(this.TimeZone).toLocaleString(DateTime.DATETIME_FULL);
         }
@@ -303,6 +314,10 @@ export class Photo extends RestModel {
             return "Unknown";
         }
 
+        if (this.TakenApprox) {
+            return this.approxDateString();
+        }
+
 
         if (this.TimeZone) {
             return DateTime.fromISO(this.TakenAt).setZone(this.TimeZone).toLocaleString(DateTime.DATE_MED);
@@ -311,6 +326,14 @@ export class Photo extends RestModel {
         return DateTime.fromISO(this.TakenAt).setZone("UTC").toLocaleString(DateTime.DATE_MED);
     }
 
+    approxDateString() {
+        if (this.TakenApprox === ApproxDecade) {
+            return "circa " + Math.floor(this.Year / 10) * 10 + "s";
+        }
+
+        return "circa " + this.Year;
+    }
+
     hasLocation() {
         return this.Lat !== 0 || this.Lng !== 0;
     }
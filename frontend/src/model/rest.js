@@ -28,7 +28,10 @@ export class Rest extends Model {
     }
 
     update() {
-        return Api.put(this.getEntityResource(), this.getValues(true)).then((response) => Promise.resolve(this.setValues(response.data)));
+        // Versioned models are only updated if nobody else changed them in the meantime.
+        const config = this.Version ? {headers: {"If-Match": `"${this.Version}"`}} : {};
+
+        return Api.put(this.getEntityResource(), this.getValues(true), config).then((response) => Promise.resolve(this.setValues(response.data)));
     }
 
     remove() {
@@ -101,6 +101,15 @@ describe("model/photo", () => {
         assert.equal(result, "July 8, 2012, 2:45 PM UTC");
     });
 
+    it("should get approximate date string",  () => {
+        const values = {ID: 5, Title: "Old Print", TakenAt: "1985-01-01T00:00:00Z", Year: 1985, TakenApprox: "decade"};
+        const photo = new Photo(values);
+        assert.equal(photo.getDateString(), "circa 1980s");
+        assert.equal(photo.shortDateString(), "circa 1980s");
+        photo.TakenApprox = "year";
+        assert.equal(photo.getDateString(), "circa 1985");
+    });
+
     it("should test whether photo has location",  () => {
         const values = {ID: 5, Title: "Crazy Cat", Lat: 36.442881666666665, Lng: 28.229493333333334};
         const photo = new Photo(values);
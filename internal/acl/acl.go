@@ -0,0 +1,83 @@
+/*
+Package acl contains the access control policy that defines what users may change depending on their role.
+
+Permissions are checked centrally by the API for all mutating and admin-only requests, see api.Policy.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package acl
+
+// User roles.
+const (
+	RoleAdmin       = "admin"
+	RoleContributor = "contributor"
+	RoleGuest       = "guest"
+)
+
+// Permission represents a kind of change that can be granted to roles.
+type Permission string
+
+// Permissions required by mutating and admin-only API requests.
+const (
+	PhotoUpload Permission = "photo.upload"
+	PhotoEdit   Permission = "photo.edit"
+	PhotoDate   Permission = "photo.date"
+	PhotoDelete Permission = "photo.delete"
+	LabelEdit   Permission = "label.edit"
+	LabelDelete Permission = "label.delete"
+	AlbumEdit   Permission = "album.edit"
+	AlbumDelete Permission = "album.delete"
+	Share       Permission = "share"
+	Library     Permission = "library"
+	Settings    Permission = "settings"
+)
+
+// Permissions lists all permissions.
+var Permissions = []Permission{
+	PhotoUpload,
+	PhotoEdit,
+	PhotoDate,
+	PhotoDelete,
+	LabelEdit,
+	LabelDelete,
+	AlbumEdit,
+	AlbumDelete,
+	Share,
+	Library,
+	Settings,
+}
+
+// Policy maps roles to the permissions granted to them.
+type Policy map[string][]Permission
+
+// Default is the policy used by the API: admins may change everything, while contributors may add
+// and edit photos, labels and albums, but may not delete photos or change when they were taken.
+var Default = Policy{
+	RoleAdmin:       Permissions,
+	RoleContributor: {PhotoUpload, PhotoEdit, LabelEdit, AlbumEdit},
+}
+
+// Allowed returns true if the role has the permission. Unknown roles have no permissions.
+func (p Policy) Allowed(role string, perm Permission) bool {
+	for _, granted := range p[role] {
+		if granted == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Known returns true if the policy defines the permissions of the role.
+func (p Policy) Known(role string) bool {
+	_, ok := p[role]
+
+	return ok
+}
+
+// Allowed returns true if the role has the permission according to the default policy.
+func Allowed(role string, perm Permission) bool {
+	return Default.Allowed(role, perm)
+}
@@ -0,0 +1,34 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		for _, perm := range Permissions {
+			assert.True(t, Allowed(RoleAdmin, perm), string(perm))
+		}
+	})
+	t.Run("contributor", func(t *testing.T) {
+		assert.True(t, Allowed(RoleContributor, PhotoUpload))
+		assert.True(t, Allowed(RoleContributor, PhotoEdit))
+		assert.True(t, Allowed(RoleContributor, LabelEdit))
+		assert.True(t, Allowed(RoleContributor, AlbumEdit))
+		assert.False(t, Allowed(RoleContributor, PhotoDelete))
+		assert.False(t, Allowed(RoleContributor, PhotoDate))
+		assert.False(t, Allowed(RoleContributor, Settings))
+	})
+	t.Run("guest", func(t *testing.T) {
+		assert.False(t, Allowed(RoleGuest, PhotoEdit))
+		assert.False(t, Allowed("", PhotoEdit))
+	})
+}
+
+func TestPolicy_Known(t *testing.T) {
+	assert.True(t, Default.Known(RoleAdmin))
+	assert.True(t, Default.Known(RoleContributor))
+	assert.False(t, Default.Known(RoleGuest))
+}
@@ -1,24 +1,23 @@
 package api
 
 import (
-	"archive/zip"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
-	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/archive"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
-	"github.com/photoprism/photoprism/pkg/rnd"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -27,6 +26,11 @@ import (
 )
 
 // GET /api/v1/albums
+//
+// The X-Count header contains the total number of matching albums, regardless of count and offset.
+//
+// Parameters:
+//   fields: string Comma separated list of fields to return, e.g. "UID,Title"
 func GetAlbums(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/albums", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -43,23 +47,34 @@ func GetAlbums(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		result, err := query.AlbumSearch(f)
+		result, total, err := query.AlbumSearchTotal(f)
+
 		if err != nil {
 			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
 			return
 		}
 
-		// TODO c.Header("X-Count", strconv.Itoa(count))
+		c.Header("X-Count", strconv.Itoa(total))
 		c.Header("X-Limit", strconv.Itoa(f.Count))
 		c.Header("X-Offset", strconv.Itoa(f.Offset))
 
-		c.JSON(http.StatusOK, result)
+		fieldsJSON(c, http.StatusOK, result)
 	})
 }
 
 // GET /api/v1/albums/:uid
+//
+// Parameters:
+//   uid:    string Album UID
+//   fields: string Comma separated list of fields to return, e.g. "UID,Title"
 func GetAlbum(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/albums/:uid", func(c *gin.Context) {
+		// Static routes can't be registered next to the :uid wildcard.
+		if c.Param("uid") == "autocomplete" {
+			albumAutocomplete(c, conf)
+			return
+		}
+
 		id := c.Param("uid")
 		m, err := query.AlbumByUID(id)
 
@@ -68,7 +83,12 @@ func GetAlbum(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		c.JSON(http.StatusOK, m)
+		// Clients may use their cached copy if the album hasn't been updated since.
+		if notModified(c, m.VersionTag(), CacheRevalidate) {
+			return
+		}
+
+		fieldsJSON(c, http.StatusOK, m)
 	})
 }
 
@@ -90,6 +110,24 @@ func CreateAlbum(router *gin.RouterGroup, conf *config.Config) {
 		m := entity.NewAlbum(f.AlbumTitle, entity.TypeDefault)
 		m.AlbumFavorite = f.AlbumFavorite
 
+		// Albums with filter are smart albums containing all matching photos.
+		if f.AlbumFilter != "" {
+			if !entity.ValidAlbumFilter(f.AlbumFilter) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrFilterInvalid)
+				return
+			}
+
+			m.AlbumType = entity.TypeMoment
+			m.AlbumFilter = f.AlbumFilter
+		}
+
+		if !m.ValidParent(f.ParentUID) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrParentInvalid)
+			return
+		}
+
+		m.ParentUID = f.ParentUID
+
 		log.Debugf("create album: %+v %+v", f, m)
 
 		if res := entity.Db().Create(m); res.Error != nil {
@@ -109,6 +147,8 @@ func CreateAlbum(router *gin.RouterGroup, conf *config.Config) {
 }
 
 // PUT /api/v1/albums/:uid
+//
+// Responds with 409 Conflict and the current album if the If-Match header doesn't match its ETag.
 func UpdateAlbum(router *gin.RouterGroup, conf *config.Config) {
 	router.PUT("/albums/:uid", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -124,6 +164,11 @@ func UpdateAlbum(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		if versionConflict(c, m.VersionTag()) {
+			abortConflict(c, m.VersionTag(), m)
+			return
+		}
+
 		f, err := form.NewAlbum(m)
 
 		if err != nil {
@@ -138,18 +183,39 @@ func UpdateAlbum(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		if err := m.SaveForm(f); err != nil {
+		coverUID, filter, title := m.CoverUID, m.AlbumFilter, m.AlbumTitle
+
+		if err := m.SaveForm(f); err == entity.ErrAlbumParentInvalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrParentInvalid)
+			return
+		} else if err == entity.ErrAlbumFilterInvalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrFilterInvalid)
+			return
+		} else if err == entity.ErrAlbumDateInvalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrDateInvalid)
+			return
+		} else if err != nil {
 			log.Error(err)
 			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
 			return
 		}
 
+		if m.CoverUID != coverUID || m.AlbumFilter != filter {
+			flushAlbumThumbs(m.AlbumUID)
+		}
+
+		if m.AlbumTitle != title {
+			addAlbumEvent(c, m.AlbumUID, entity.AlbumEventRenamed, 0, title)
+		}
+
 		UpdateClientConfig(conf)
 
 		event.Success("album saved")
 
 		PublishAlbumEvent(EntityUpdated, uid, c)
 
+		c.Header("ETag", m.VersionTag())
+
 		c.JSON(http.StatusOK, m)
 	})
 }
@@ -241,6 +307,15 @@ func DislikeAlbum(router *gin.RouterGroup, conf *config.Config) {
 }
 
 // POST /api/v1/albums/:uid/photos
+//
+// Adds the selected photos to an album. Instead of a selection, the request may contain a search
+// filter like "label:beach year:2022", so that all matching photos are added without paging through
+// them on the client.
+//
+// Parameters:
+//   uid: string Album UID
+//   photos: []string Photo UIDs (optional)
+//   filter: string Photo search filter (optional)
 func AddPhotosToAlbum(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/albums/:uid/photos", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -248,7 +323,7 @@ func AddPhotosToAlbum(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		var f form.Selection
+		var f form.AlbumPhotos
 
 		if err := c.BindJSON(&f); err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
@@ -261,26 +336,51 @@ func AddPhotosToAlbum(router *gin.RouterGroup, conf *config.Config) {
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
 			return
+		} else if a.HasFilter() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrAlbumHasFilter)
+			return
 		}
 
-		photos, err := query.PhotoSelection(f)
+		var uids []string
 
-		if err != nil {
-			log.Errorf("album: %s", err)
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
-			return
+		if f.Filter != "" {
+			uids, err = query.PhotoSearchUIDs(form.NewPhotoSearch(f.Filter))
+
+			if err != nil {
+				log.Errorf("album: %s", err)
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		if !f.Selection.Empty() || f.Filter == "" {
+			photos, err := query.PhotoSelection(f.Selection)
+
+			if err != nil {
+				log.Errorf("album: %s", err)
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			for _, p := range photos {
+				uids = append(uids, p.PhotoUID)
+			}
 		}
 
 		var added []*entity.PhotoAlbum
 
-		for _, p := range photos {
-			val := entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(p.PhotoUID, a.AlbumUID))
+		for _, uid := range uids {
+			val := entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(uid, a.AlbumUID))
 
 			if val != nil {
 				added = append(added, val)
 			}
 		}
 
+		if len(added) > 0 {
+			addAlbumEvent(c, a.AlbumUID, entity.AlbumEventAdded, len(added), "")
+		}
+
 		if len(added) == 1 {
 			event.Success(fmt.Sprintf("one photo added to %s", txt.Quote(a.AlbumTitle)))
 		} else {
@@ -321,7 +421,18 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		entity.Db().Where("album_uid = ? AND photo_uid IN (?)", a.AlbumUID, f.Photos).Delete(&entity.PhotoAlbum{})
+		removed := entity.Db().Where("album_uid = ? AND photo_uid IN (?)", a.AlbumUID, f.Photos).Delete(&entity.PhotoAlbum{})
+
+		if removed.Error != nil {
+			log.Errorf("album: %s", removed.Error)
+		} else if removed.RowsAffected > 0 {
+			addAlbumEvent(c, a.AlbumUID, entity.AlbumEventRemoved, int(removed.RowsAffected), "")
+		}
+
+		// Removed photos can't be tracked otherwise, sync clients refresh albums updated since their last sync.
+		if err := a.Update("UpdatedAt", time.Now()); err != nil {
+			log.Errorf("album: %s", err)
+		}
 
 		event.Success(fmt.Sprintf("photos removed from %s", a.AlbumTitle))
 
@@ -331,7 +442,89 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// PUT /api/v1/albums/:uid/photos/order
+//
+// Sets the manual order of photos in an album, which is used when searching with order=album.
+// Photos that aren't listed are shown after the listed photos.
+//
+// Parameters:
+//   uid: string Album UID
+func SetAlbumPhotoOrder(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uid/photos/order", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.AlbumPhotoOrder
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		uids, err := query.AlbumPhotoUIDs(a.AlbumUID)
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		found := make(map[string]bool, len(uids))
+
+		for _, uid := range uids {
+			found[uid] = true
+		}
+
+		for _, uid := range f.Photos {
+			if !found[uid] {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(fmt.Sprintf("photo %s is not in this album", uid))})
+				return
+			}
+
+			// Photos must not be listed twice.
+			found[uid] = false
+		}
+
+		if err := entity.SetAlbumPhotoOrder(a.AlbumUID, f.Photos); err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		if err := a.Update("UpdatedAt", time.Now()); err != nil {
+			log.Errorf("album: %s", err)
+		}
+
+		event.Success("album order saved")
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, gin.H{"message": "album order saved", "album": a, "photos": f.Photos})
+	})
+}
+
 // GET /albums/:uid/dl
+//
+// Streams a zip archive containing the originals of all photos in the album, or resized JPEGs for sharing
+// with people who don't need full resolution images.
+//
+// Parameters:
+//   uid: string Album UID
+//   favorite: bool Favorites only (optional)
+//   quality: int Minimum quality score (optional)
+//   label: string Label slug, e.g. the name of a person (optional)
+//   size: string Use "original" (default) or a thumbnail type like "fit_2048" or "fit_1280" for resized JPEGs
+//   raw: string Use "include" to add RAW originals, "exclude" by default
+//   convert: string Use "jpeg" to download JPEG versions of formats like HEIC and RAW (query)
 func DownloadAlbum(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/albums/:uid/dl", func(c *gin.Context) {
 		if InvalidDownloadToken(c, conf) {
@@ -348,77 +541,274 @@ func DownloadAlbum(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		p, _, err := query.PhotoSearch(form.PhotoSearch{
-			Album:  a.AlbumUID,
-			Count:  10000,
-			Offset: 0,
-		})
+		var f form.AlbumDownload
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		opt, err := newAlbumDownload(c, conf, f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		entries, err := albumEntries(conf, f.PhotoSearch(a.AlbumUID), opt)
 
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
 			return
 		}
 
-		zipPath := path.Join(conf.TempPath(), "album")
-		zipToken := rnd.Token(3)
-		zipBaseName := fmt.Sprintf("%s-%s.zip", strings.Title(a.AlbumSlug), zipToken)
-		zipFileName := path.Join(zipPath, zipBaseName)
+		zipBaseName := albumZipName(a)
 
-		if err := os.MkdirAll(zipPath, 0700); err != nil {
-			log.Error(err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst("failed to create zip folder")})
+		countUsage(conf, entity.UsageDownload)
+
+		// Stream the archive to the client, so that the download starts immediately and no temp file
+		// is needed. The response is sent with chunked transfer encoding as the size isn't known in advance.
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", zipBaseName))
+		c.Status(http.StatusOK)
+
+		if err := archive.Write(c.Writer, entries, conf.Workers()); err != nil {
+			// Headers have already been sent, so the client only notices an incomplete download.
+			log.Errorf("album: failed streaming %s (%s)", txt.Quote(zipBaseName), err)
+			c.Abort()
 			return
 		}
 
-		newZipFile, err := os.Create(zipFileName)
+		log.Infof("album: streamed %s with %d files in %s", txt.Quote(zipBaseName), len(entries), time.Since(start))
+	})
+}
+
+// albumZipName returns the file name of album zip archives.
+func albumZipName(a entity.Album) string {
+	return fmt.Sprintf("%s.zip", strings.Title(a.AlbumSlug))
+}
+
+// albumDownload contains the validated options of an album download.
+type albumDownload struct {
+	convert bool
+	raw     bool
+	thumb   *thumb.Type
+}
+
+// newAlbumDownload returns the download options for the request. If a size other than "original" is
+// requested, photos are replaced by resized JPEGs of this thumbnail type.
+func newAlbumDownload(c *gin.Context, conf *config.Config, f form.AlbumDownload) (result albumDownload, err error) {
+	result.convert = convertJpeg(c)
+	result.raw = f.IncludeRaw()
+
+	if f.Raw != "" && f.Raw != form.RawExclude && f.Raw != form.RawInclude {
+		return result, fmt.Errorf("invalid raw option %s", txt.Quote(f.Raw))
+	}
+
+	if f.Original() {
+		return result, nil
+	}
+
+	t, ok := thumb.Types[f.Size]
+
+	if !ok || !t.Public || t.Width > conf.ThumbSize() {
+		return result, fmt.Errorf("invalid size %s", txt.Quote(f.Size))
+	}
+
+	result.thumb = &t
+
+	return result, nil
+}
+
+// albumEntries returns the archive entries for downloading all photos in an album matching the search
+// form. RAW originals are added with the same name as the photo if requested.
+func albumEntries(conf *config.Config, s form.PhotoSearch, opt albumDownload) (entries []archive.Entry, err error) {
+	p, _, err := query.PhotoSearch(s)
+
+	if err != nil {
+		return entries, err
+	}
+
+	aliases := make(map[string]string, len(p))
+	uids := make([]string, 0, len(p))
+
+	for _, f := range p {
+		entry, err := albumEntry(conf, f, opt)
 
 		if err != nil {
-			log.Error(err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			log.Errorf("album: %s", err)
+			continue
+		}
+
+		entries = append(entries, entry)
+
+		if _, ok := aliases[f.PhotoUID]; !ok {
+			aliases[f.PhotoUID] = strings.TrimSuffix(entry.Alias, filepath.Ext(entry.Alias))
+			uids = append(uids, f.PhotoUID)
+		}
+	}
+
+	if !opt.raw {
+		return entries, nil
+	}
+
+	files, err := query.RawFiles(uids)
+
+	if err != nil {
+		return entries, err
+	}
+
+	for _, f := range files {
+		fileName, err := photoprism.OriginalFileName(conf, f)
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+		} else if !fs.FileExists(fileName) {
+			log.Errorf("album: file %s is missing", txt.Quote(f.FileName))
+		} else {
+			entries = append(entries, archive.Entry{FileName: fileName, Alias: aliases[f.PhotoUID] + strings.ToLower(filepath.Ext(f.FileName))})
+		}
+	}
+
+	return entries, nil
+}
+
+// albumEntry returns the archive entry of a search result, either the original or a resized JPEG.
+// Videos are always added as original.
+func albumEntry(conf *config.Config, f query.PhotoResult, opt albumDownload) (entry archive.Entry, err error) {
+	t := opt.thumb
+
+	if t != nil && !f.FileVideo {
+		// Cached thumbnails can be used without fetching originals from cold storage.
+		if thumbName, err := thumb.FromCache(f.FileName, f.FileHash, conf.ThumbPath(), t.Width, t.Height, t.Options...); err == nil {
+			return archive.Entry{FileName: thumbName, Alias: f.ShareFileName()}, nil
+		}
+	}
+
+	fileName, err := photoprism.OriginalFileName(conf, entity.File{FileUID: f.FileUID, FileName: f.FileName, FileCold: f.FileCold})
+
+	if err != nil {
+		return entry, err
+	} else if !fs.FileExists(fileName) {
+		return entry, fmt.Errorf("file %s is missing", txt.Quote(f.FileName))
+	}
+
+	if t != nil && !f.FileVideo {
+		thumbName, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), t.Width, t.Height, t.Options...)
+
+		if err == nil {
+			return archive.Entry{FileName: thumbName, Alias: f.ShareFileName()}, nil
+		}
+
+		log.Warnf("album: %s, using original", err)
+	}
+
+	return archiveEntry(opt.convert, fileName, f.FileHash, f.ShareFileName()), nil
+}
+
+// PUT /api/v1/albums/:uid/cover
+//
+// Selects the photo that represents an album in previews.
+//
+// Parameters:
+//   uid: string Album UID
+func SetAlbumCover(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uid/cover", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
 			return
 		}
 
-		defer newZipFile.Close()
+		var f form.AlbumCover
 
-		zipWriter := zip.NewWriter(newZipFile)
-		defer func() { _ = zipWriter.Close() }()
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
 
-		for _, f := range p {
-			fileName := path.Join(conf.OriginalsPath(), f.FileName)
-			fileAlias := f.ShareFileName()
+		uid := c.Param("uid")
+		m, err := query.AlbumByUID(uid)
 
-			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, fileAlias); err != nil {
-					log.Error(err)
-					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst("failed to create zip file")})
-					return
-				}
-				log.Infof("album: added %s as %s", txt.Quote(f.FileName), txt.Quote(fileAlias))
-			} else {
-				log.Errorf("album: file %s is missing", txt.Quote(f.FileName))
-			}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
 		}
 
-		log.Infof("album: archive %s created in %s", txt.Quote(zipBaseName), time.Since(start))
-		_ = zipWriter.Close()
-		newZipFile.Close()
+		p, err := query.PhotoByUID(f.Photo)
 
-		if !fs.FileExists(zipFileName) {
-			log.Errorf("could not find zip file: %s", zipFileName)
-			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
 			return
 		}
 
-		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", zipBaseName))
+		if p.PhotoPrivate {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("private photos can't be used as cover")})
+			return
+		}
+
+		if !query.AlbumHasPhoto(m.AlbumUID, p.PhotoUID) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("photo is not in this album")})
+			return
+		}
+
+		if err := m.SetCover(p.PhotoUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		flushAlbumThumbs(m.AlbumUID)
+
+		event.Success("album cover saved")
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/albums/:uid/cover
+//
+// Resets the album cover, so that the best photo is used again.
+//
+// Parameters:
+//   uid: string Album UID
+func ResetAlbumCover(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/albums/:uid/cover", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
 
-		c.File(zipFileName)
+		uid := c.Param("uid")
+		m, err := query.AlbumByUID(uid)
 
-		if err := os.Remove(zipFileName); err != nil {
-			log.Errorf("album: could not remove %s (%s)", txt.Quote(zipFileName), err.Error())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		if err := m.SetCover(""); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
 		}
+
+		flushAlbumThumbs(m.AlbumUID)
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, m)
 	})
 }
 
+// flushAlbumThumbs removes the cached thumbnails of an album, e.g. after its cover was changed.
+func flushAlbumThumbs(albumUID string) {
+	cache := service.ThumbCache()
+
+	for typeName := range thumb.Types {
+		cache.Delete(fmt.Sprintf("album-thumbnail:%s:%s", albumUID, typeName))
+	}
+}
+
 // GET /api/v1/albums/:uid/t/:token/:type
 //
 // Parameters:
@@ -427,7 +817,7 @@ func DownloadAlbum(router *gin.RouterGroup, conf *config.Config) {
 func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/albums/:uid/t/:token/:type", func(c *gin.Context) {
 		if InvalidToken(c, conf) {
-			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			svgIcon(c, http.StatusForbidden, brokenIconSvg)
 			return
 		}
 
@@ -439,16 +829,16 @@ func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if !ok {
 			log.Errorf("album: invalid thumb type %s", typeName)
-			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			svgIcon(c, http.StatusBadRequest, photoIconSvg)
 			return
 		}
 
-		gc := service.Cache()
+		cache := service.ThumbCache()
 		cacheKey := fmt.Sprintf("album-thumbnail:%s:%s", uid, typeName)
 
-		if cacheData, ok := gc.Get(cacheKey); ok {
+		if cacheData, ok := cache.Get(cacheKey); ok {
 			log.Debugf("cache hit for %s [%s]", cacheKey, time.Since(start))
-			c.Data(http.StatusOK, "image/jpeg", cacheData.([]byte))
+			coverJpeg(c, cacheData)
 			return
 		}
 
@@ -456,15 +846,21 @@ func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if err != nil {
 			log.Debugf("album: no photos yet, using generic image for %s", uid)
-			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			svgPlaceholder(c, albumIconSvg)
 			return
 		}
 
-		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+		fileName, err := thumbSource(conf, f, thumbType)
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
+			return
+		}
 
-		if !fs.FileExists(fileName) {
+		if !f.FileCold && !fs.FileExists(fileName) {
 			log.Errorf("album: could not find original for %s", fileName)
-			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
 
 			// Set missing flag so that the file doesn't show up in search results anymore.
 			log.Warnf("album: %s is missing", txt.Quote(f.FileName))
@@ -475,6 +871,7 @@ func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
 		if thumbType.ExceedsLimit() && c.Query("download") == "" {
 			log.Debugf("album: using original, thumbnail size exceeds limit (width %d, height %d)", thumbType.Width, thumbType.Height)
+			c.Header("Cache-Control", CacheRevalidate)
 			c.File(fileName)
 			return
 		}
@@ -482,14 +879,14 @@ func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		var thumbnail string
 
 		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err = thumbFromFile(conf, f, fileName, typeName, thumbType)
 		} else {
 			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
 		}
 
 		if err != nil {
 			log.Errorf("album: %s", err)
-			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
 			return
 		}
 
@@ -501,14 +898,14 @@ func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if err != nil {
 			log.Errorf("album: %s", err)
-			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
 			return
 		}
 
-		gc.Set(cacheKey, thumbData, time.Hour)
+		cache.Set(cacheKey, thumbnail, thumbData, time.Hour)
 
 		log.Debugf("cached %s [%s]", cacheKey, time.Since(start))
 
-		c.Data(http.StatusOK, "image/jpeg", thumbData)
+		coverJpeg(c, thumbData)
 	})
 }
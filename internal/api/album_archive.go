@@ -0,0 +1,188 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/archive"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Finished archives are removed after this time.
+const archiveMaxAge = 6 * time.Hour
+
+// archiveJob represents the status of an archive job in API responses and events.
+type archiveJob struct {
+	archive.Job
+	Progress int    `json:"Progress"`
+	URL      string `json:"URL,omitempty"`
+}
+
+// newArchiveJob returns the status of an archive job including the download URL once it is done.
+func newArchiveJob(job archive.Job) archiveJob {
+	result := archiveJob{Job: job, Progress: job.Percent()}
+
+	if job.Status == archive.JobDone {
+		result.URL = fmt.Sprintf("/api/v1/archives/%s/dl", job.ID)
+	}
+
+	return result
+}
+
+// publishArchiveJob publishes the status of an archive job, so that clients can show the progress.
+func publishArchiveJob(job archive.Job, albumUID string) {
+	ev := "archive.progress"
+
+	switch job.Status {
+	case archive.JobDone:
+		ev = "archive.completed"
+	case archive.JobFailed:
+		ev = "archive.failed"
+	}
+
+	event.Publish(ev, event.Data{"album": albumUID, "job": newArchiveJob(job)})
+}
+
+// POST /api/v1/albums/:uid/archive
+//
+// Creates a zip archive containing the originals of all photos in the album in the background. Progress is
+// published with "archive.*" events, and the archive can be downloaded once the job is done. If the same
+// archive is already being created, its job is returned, and too many pending jobs are rejected.
+//
+// Parameters:
+//   uid: string Album UID
+//   favorite: bool Favorites only (optional)
+//   quality: int Minimum quality score (optional)
+//   label: string Label slug, e.g. the name of a person (optional)
+//   size: string Use "original" (default) or a thumbnail type like "fit_2048" or "fit_1280" for resized JPEGs
+//   raw: string Use "include" to add RAW originals, "exclude" by default
+//   convert: string Use "jpeg" to download JPEG versions of formats like HEIC and RAW (query)
+func CreateAlbumArchive(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/albums/:uid/archive", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if !conf.Settings().Features.Download {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumDownload
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		opt, err := newAlbumDownload(c, conf, f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		jobs := service.Archives()
+
+		if n := jobs.Cleanup(archiveMaxAge); n > 0 {
+			log.Infof("archive: removed %d expired archives", n)
+		}
+
+		prepare := func() ([]archive.Entry, error) {
+			return albumEntries(conf, f.PhotoSearch(a.AlbumUID), opt)
+		}
+
+		notify := func(job archive.Job) {
+			publishArchiveJob(job, a.AlbumUID)
+		}
+
+		// Requests for the same album with the same filters get the job that is already running.
+		key := fmt.Sprintf("%s %+v %t", a.AlbumUID, f, opt.convert)
+
+		job, err := jobs.Start(key, albumZipName(a), prepare, notify)
+
+		if err == archive.ErrTooManyJobs {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrTooManyRequests)
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("archive: creating %s in background", txt.Quote(job.Name))
+
+		c.JSON(http.StatusOK, newArchiveJob(job))
+	})
+}
+
+// GET /api/v1/archives/:id
+//
+// Returns the status of an archive job.
+//
+// Parameters:
+//   id: string Job ID
+func GetArchive(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/archives/:id", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		job, err := service.Archives().Get(c.Param("id"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrArchiveNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, newArchiveJob(job))
+	})
+}
+
+// GET /api/v1/archives/:id/dl
+//
+// Downloads the zip archive of a finished job, archives are removed after some time.
+//
+// Parameters:
+//   id: string Job ID
+func DownloadArchive(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/archives/:id/dl", func(c *gin.Context) {
+		if InvalidDownloadToken(c, conf) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		fileName, job, err := service.Archives().FileName(c.Param("id"))
+
+		if err == archive.ErrJobNotDone {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(fmt.Sprintf("archive is %s", job.Status))})
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrArchiveNotFound)
+			return
+		}
+
+		countUsage(conf, entity.UsageDownload)
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", job.Name))
+
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/archive"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateAlbumArchive(t *testing.T) {
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumArchive(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/albums/5678/archive")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumArchive(router, conf)
+		GetArchive(router, conf)
+		DownloadArchive(router, conf)
+
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/archive")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Holiday-2030.zip", gjson.Get(r.Body.String(), "Name").String())
+
+		id := gjson.Get(r.Body.String(), "ID").String()
+
+		for i := 0; i < 500; i++ {
+			if job, err := service.Archives().Get(id); err != nil {
+				t.Fatal(err)
+			} else if job.Finished() {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		r = PerformRequest(app, "GET", "/api/v1/archives/"+id)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, archive.JobDone, gjson.Get(r.Body.String(), "Status").String())
+		assert.Equal(t, int64(100), gjson.Get(r.Body.String(), "Progress").Int())
+		assert.Equal(t, "/api/v1/archives/"+id+"/dl", gjson.Get(r.Body.String(), "URL").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/archives/"+id+"/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "attachment; filename=Holiday-2030.zip", r.Header().Get("Content-Disposition"))
+	})
+	t.Run("too many jobs", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumArchive(router, conf)
+
+		release := make(chan bool)
+		prepare := func() ([]archive.Entry, error) {
+			<-release
+			return nil, errors.New("canceled")
+		}
+
+		var ids []string
+
+		for i := 0; i < archive.JobLimit; i++ {
+			job, err := service.Archives().Start(fmt.Sprintf("test-%d", i), "Test.zip", prepare, nil)
+
+			if err != nil {
+				break
+			}
+
+			ids = append(ids, job.ID)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/archive")
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+
+		close(release)
+
+		for _, id := range ids {
+			for i := 0; i < 500; i++ {
+				if job, err := service.Archives().Get(id); err != nil {
+					t.Fatal(err)
+				} else if job.Finished() {
+					break
+				}
+
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	})
+}
+
+func TestGetArchive(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetArchive(router, conf)
+	r := PerformRequest(app, "GET", "/api/v1/archives/zxxx")
+	assert.Equal(t, http.StatusNotFound, r.Code)
+	assert.Equal(t, ErrArchiveNotFound["error"], gjson.Get(r.Body.String(), "error").String())
+}
+
+func TestDownloadArchive(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadArchive(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/archives/zxxx/dl?t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadArchive(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/archives/zxxx/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
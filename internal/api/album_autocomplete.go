@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Album suggestions returned by default and at most.
+const (
+	albumAutocompleteCount = 10
+	albumAutocompleteMax   = 50
+)
+
+// GET /api/v1/albums/autocomplete
+//
+// Returns albums matching all words of the query in their title, e.g. for the "add to album" dialog.
+// Registered by GetAlbum, as gin doesn't support static routes next to wildcards.
+//
+// Parameters:
+//   q: string Title search query, empty for recently updated albums
+//   count: int Max result count, 10 by default
+func albumAutocomplete(c *gin.Context, conf *config.Config) {
+	if Unauthorized(c, conf) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	count, err := strconv.Atoi(c.Query("count"))
+
+	if err != nil || count <= 0 {
+		count = albumAutocompleteCount
+	} else if count > albumAutocompleteMax {
+		count = albumAutocompleteMax
+	}
+
+	results, err := query.AlbumAutocomplete(txt.Clip(c.Query("q"), txt.ClipKeyword), count)
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// POST /api/v1/photos/:uid/albums
+//
+// Adds a photo to an existing album, or to a new album with the given title if no album UID is provided.
+// An existing album with the same title is used instead of creating a duplicate.
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func AddPhotoToAlbum(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/photos/:uid/albums", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		p, err := query.PhotoByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		var f form.AlbumAdd
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		var a entity.Album
+		created := false
+
+		if f.AlbumUID != "" {
+			if a, err = query.AlbumByUID(f.AlbumUID); err != nil {
+				c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+				return
+			} else if a.HasFilter() {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrAlbumHasFilter)
+				return
+			}
+		} else if strings.TrimSpace(f.Title) == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrAlbumTitleEmpty)
+			return
+		} else if a, err = query.AlbumByTitle(f.Title); err != nil {
+			m := entity.NewAlbum(f.Title, entity.TypeDefault)
+
+			if !m.ValidParent(f.ParentUID) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrParentInvalid)
+				return
+			}
+
+			m.ParentUID = f.ParentUID
+
+			if err := m.Create(); err != nil {
+				log.Errorf("album: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+
+			a = *m
+			created = true
+
+			event.Success("album created")
+
+			UpdateClientConfig(conf)
+
+			PublishAlbumEvent(EntityCreated, a.AlbumUID, c)
+		}
+
+		if entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(p.PhotoUID, a.AlbumUID)) == nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		addAlbumEvent(c, a.AlbumUID, entity.AlbumEventAdded, 1, "")
+
+		event.Success(fmt.Sprintf("one photo added to %s", txt.Quote(a.AlbumTitle)))
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, gin.H{"Album": a, "Created": created})
+	})
+}
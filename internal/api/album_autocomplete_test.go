@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAlbumAutocomplete(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbum(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/autocomplete?q=holiday&count=5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "0.UID").String())
+	})
+}
+
+func TestAddPhotoToAlbum(t *testing.T) {
+	t.Run("existing album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotoToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/albums", `{"AlbumUID": "at9lxuqxpogaaba9"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba9", gjson.Get(r.Body.String(), "Album.UID").String())
+		assert.False(t, gjson.Get(r.Body.String(), "Created").Bool())
+	})
+	t.Run("new album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotoToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/albums", `{"Title": "Quick Add 2031"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Quick Add 2031", gjson.Get(r.Body.String(), "Album.Title").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Created").Bool())
+		r = PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/albums", `{"Title": "quick add 2031"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "Created").Bool())
+	})
+	t.Run("title missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotoToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/albums", `{"Title": " "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotoToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh8/albums", `{"AlbumUID": "xxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotoToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/xxx/albums", `{"Title": "Foo"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PUT /api/v1/albums/:uid/captions/:photo
+//
+// Changes the caption of a photo in an album without changing the photo description, so that the same
+// photo can have a different caption in each album. Photo search results include the caption as
+// "AlbumCaption" when browsing the album. An empty caption removes it.
+//
+// Parameters:
+//   uid: string Album UID
+//   photo: string Photo UID
+func UpdateAlbumCaption(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uid/captions/:photo", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.PhotoAlbumCaption
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		photoUID := c.Param("photo")
+		caption := txt.Clip(f.Caption, txt.ClipDescription)
+
+		if err := entity.SetPhotoAlbumCaption(a.AlbumUID, photoUID, caption); err == gorm.ErrRecordNotFound {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrNotInAlbum)
+			return
+		} else if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("caption saved")
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, gin.H{"AlbumUID": a.AlbumUID, "PhotoUID": photoUID, "Caption": caption})
+	})
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpdateAlbumCaption(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumCaption(router, conf)
+		GetPhotos(router, conf)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/captions/pt9jtdre2lvl0yh7", `{"Caption": "Our first day at the beach"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Our first day at the beach", gjson.Get(r.Body.String(), "Caption").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/photos?count=10&album=at9lxuqxpogaaba8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Our first day at the beach", gjson.Get(r.Body.String(), `#(UID=="pt9jtdre2lvl0yh7").AlbumCaption`).String())
+
+		r = PerformRequest(app, "GET", "/api/v1/photos?count=10&id=pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "0.AlbumCaption").Exists())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/captions/pt9jtdre2lvl0yh7", `{"Caption": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumCaption(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/captions/pt9jtdre2lvl0yh7", `{"Caption": "Hello"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("photo not in album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumCaption(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/captions/pt9jtdre2lvl0yxx", `{"Caption": "Hello"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "Photo not found in album", gjson.Get(r.Body.String(), "error").String())
+	})
+}
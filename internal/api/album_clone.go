@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/albums/:uid/clone
+//
+// Creates a copy of an album with the same photos, e.g. as a starting point for a "best of" selection.
+//
+// Parameters:
+//   uid: string Album UID
+func CloneAlbum(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/albums/:uid/clone", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		m, err := a.Clone()
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		addAlbumEvent(c, m.AlbumUID, entity.AlbumEventCloned, 0, a.AlbumTitle)
+
+		event.Success(fmt.Sprintf("album %s created", txt.Quote(m.AlbumTitle)))
+
+		UpdateClientConfig(conf)
+
+		PublishAlbumEvent(EntityCreated, m.AlbumUID, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCloneAlbum(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CloneAlbum(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba9/clone")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Berlin2019 (Copy)", gjson.Get(r.Body.String(), "Title").String())
+		assert.NotEqual(t, "at9lxuqxpogaaba9", gjson.Get(r.Body.String(), "UID").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CloneAlbum(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/albums/xxx/clone")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Max size of uploaded album CSV files.
+const albumCsvMaxSize = 16 * 1024 * 1024
+
+// GET /api/v1/albums/:uid/csv
+//
+// Exports the file hash, originals path and photo UID of all photos in the album as CSV file.
+//
+// Parameters:
+//   uid: string Album UID
+func ExportAlbumCsv(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/csv", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", a.AlbumSlug))
+		c.Status(http.StatusOK)
+
+		if err := photoprism.ExportAlbumCsv(c.Writer, a.AlbumUID); err != nil {
+			log.Errorf("album: %s", err)
+			c.Abort()
+		}
+	})
+}
+
+// POST /api/v1/albums/:uid/csv
+//
+// Adds the photos listed in a CSV file to the album, matched by file hash, originals path or photo UID.
+// The file may be sent as request body or as multipart form file named "file".
+//
+// Parameters:
+//   uid: string Album UID
+//   replace: bool Remove photos not listed in the file (optional)
+func ImportAlbumCsv(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/albums/:uid/csv", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.AlbumCsv
+
+		if err := c.BindQuery(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		// Limit the request body before it is parsed, so that multipart uploads are limited as well.
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, albumCsvMaxSize)
+
+		var r io.Reader = c.Request.Body
+
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			file, err := c.FormFile("file")
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			mf, err := file.Open()
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			defer mf.Close()
+
+			r = mf
+		}
+
+		result, err := photoprism.ImportAlbumCsv(r, a.AlbumUID, f.Replace)
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		// Removed photos can't be tracked otherwise, sync clients refresh albums updated since their last sync.
+		if err := a.Update("UpdatedAt", time.Now()); err != nil {
+			log.Errorf("album: %s", err)
+		}
+
+		if len(result.Added) > 0 {
+			addAlbumEvent(c, a.AlbumUID, entity.AlbumEventAdded, len(result.Added), entity.AlbumEventCsv)
+		}
+
+		if len(result.Removed) > 0 {
+			addAlbumEvent(c, a.AlbumUID, entity.AlbumEventRemoved, len(result.Removed), entity.AlbumEventCsv)
+		}
+
+		event.Success(fmt.Sprintf("%d photos added to %s, %d removed", len(result.Added), txt.Quote(a.AlbumTitle), len(result.Removed)))
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, gin.H{"message": "album imported from csv", "album": a, "added": result.Added, "removed": result.Removed, "unmatched": result.Unmatched})
+	})
+}
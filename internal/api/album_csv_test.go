@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestExportAlbumCsv(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ExportAlbumCsv(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/csv")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "attachment")
+		assert.Contains(t, r.Body.String(), "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818,exampleFileName.jpg,pt9jtdre2lvl0yh7")
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ExportAlbumCsv(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/csv")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, ErrAlbumNotFound["error"], val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestImportAlbumCsv(t *testing.T) {
+	app, router, conf := NewApiTest()
+	CreateAlbum(router, conf)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "CSV Import", "Description": "", "Notes": "", "Favorite": false}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImportAlbumCsv(router, conf)
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/albums/"+uid+"/csv", "Hash,Path\n,bridge.jpg\n,notfound.jpg\n", map[string]string{"Content-Type": "text/csv"})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "album imported from csv", gjson.Get(r.Body.String(), "message").String())
+		assert.Equal(t, `["pt9jtdre2lvl0y11"]`, gjson.Get(r.Body.String(), "added").Raw)
+		assert.Equal(t, `["notfound.jpg"]`, gjson.Get(r.Body.String(), "unmatched").Raw)
+	})
+	t.Run("replace without match", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImportAlbumCsv(router, conf)
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/albums/"+uid+"/csv?replace=true", "notfound.jpg\n", map[string]string{"Content-Type": "text/csv"})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "No photos found in csv file", gjson.Get(r.Body.String(), "error").String())
+	})
+	t.Run("multipart too large", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+		part, err := w.CreateFormFile("file", "album.csv")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := part.Write([]byte(strings.Repeat("notfound.jpg\n", albumCsvMaxSize/12))); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		ImportAlbumCsv(router, conf)
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/albums/"+uid+"/csv", body.String(), map[string]string{"Content-Type": w.FormDataContentType()})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImportAlbumCsv(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/csv", "bridge.jpg\n")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Number of files listed as largest in a download estimate.
+const estimateLargest = 5
+
+// DownloadFile represents a single file in a download estimate.
+type DownloadFile struct {
+	PhotoUID string `json:"PhotoUID"`
+	Name     string `json:"Name"`
+	Size     int64  `json:"Size"`
+}
+
+// DownloadEstimate contains the number of files and expected bytes per size option before downloading.
+type DownloadEstimate struct {
+	Files   int              `json:"Files"`
+	Missing int              `json:"Missing"`
+	Sizes   map[string]int64 `json:"Sizes"`
+	Largest []DownloadFile   `json:"Largest"`
+}
+
+// NewDownloadEstimate returns the estimated download size of the given files for originals and
+// public thumbnail types up to maxSize pixels. Thumbnail sizes are approximated by pixel ratio.
+func NewDownloadEstimate(files query.PhotoResults, maxSize int) DownloadEstimate {
+	result := DownloadEstimate{
+		Sizes:   map[string]int64{"original": 0},
+		Largest: []DownloadFile{},
+	}
+
+	types := make(map[string]thumb.Type)
+
+	for name, t := range thumb.Types {
+		if t.Public && t.Width <= maxSize {
+			types[name] = t
+			result.Sizes[name] = 0
+		}
+	}
+
+	for _, f := range files {
+		if f.FileMissing {
+			result.Missing++
+			continue
+		}
+
+		result.Files++
+		result.Sizes["original"] += f.FileSize
+		result.Largest = append(result.Largest, DownloadFile{PhotoUID: f.PhotoUID, Name: f.FileName, Size: f.FileSize})
+
+		pixels := float64(f.FileWidth * f.FileHeight)
+
+		for name, t := range types {
+			ratio := 1.0
+
+			if pixels > 0 {
+				if r := float64(t.Width*t.Height) / pixels; r < 1 {
+					ratio = r
+				}
+			}
+
+			result.Sizes[name] += int64(float64(f.FileSize) * ratio)
+		}
+	}
+
+	sort.Slice(result.Largest, func(i, j int) bool {
+		return result.Largest[i].Size > result.Largest[j].Size
+	})
+
+	if len(result.Largest) > estimateLargest {
+		result.Largest = result.Largest[:estimateLargest]
+	}
+
+	return result
+}
+
+// GET /api/v1/albums/:uid/dl/estimate
+//
+// Accepts the same filters as GET /api/v1/albums/:uid/dl.
+//
+// Parameters:
+//   uid: string Album UID
+func AlbumDownloadEstimate(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/dl/estimate", func(c *gin.Context) {
+		if InvalidDownloadToken(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumDownload
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		p, _, err := query.PhotoSearch(f.PhotoSearch(a.AlbumUID))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, NewDownloadEstimate(p, conf.ThumbSize()))
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestNewDownloadEstimate(t *testing.T) {
+	files := query.PhotoResults{
+		{PhotoUID: "pt9jtdre2lvl0yh7", FileName: "small.jpg", FileSize: 100000, FileWidth: 640, FileHeight: 480},
+		{PhotoUID: "pt9jtdre2lvl0yh8", FileName: "large.jpg", FileSize: 8000000, FileWidth: 4000, FileHeight: 3000},
+		{PhotoUID: "pt9jtdre2lvl0yh9", FileName: "missing.jpg", FileMissing: true},
+	}
+
+	result := NewDownloadEstimate(files, 2048)
+
+	assert.Equal(t, 2, result.Files)
+	assert.Equal(t, 1, result.Missing)
+	assert.Equal(t, int64(8100000), result.Sizes["original"])
+	assert.Equal(t, int64(100000+2796202), result.Sizes["fit_2048"])
+	assert.NotContains(t, result.Sizes, "fit_2560")
+	assert.NotContains(t, result.Sizes, "tile_500")
+	assert.Len(t, result.Largest, 2)
+	assert.Equal(t, "large.jpg", result.Largest[0].Name)
+}
+
+func TestAlbumDownloadEstimate(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AlbumDownloadEstimate(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl/estimate?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Sizes.original").Exists())
+	})
+	t.Run("favorites only", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AlbumDownloadEstimate(router, conf)
+		all := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl/estimate?t="+conf.DownloadToken())
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl/estimate?favorite=true&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, gjson.Get(r.Body.String(), "Files").Int(), gjson.Get(all.Body.String(), "Files").Int())
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AlbumDownloadEstimate(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/5678/dl/estimate?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AlbumDownloadEstimate(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl/estimate?t=xxx")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
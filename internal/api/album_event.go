@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// addAlbumEvent records a change of an album by the user who sent the request in the album activity log.
+func addAlbumEvent(c *gin.Context, albumUID, eventType string, photoCount int, info string) {
+	if _, err := entity.AddAlbumEvent(albumUID, sessionUserID(c), eventType, photoCount, info); err != nil {
+		log.Errorf("album: %s (activity log)", err)
+	}
+}
+
+// GET /api/v1/albums/:uid/events
+//
+// Returns the activity log of an album, most recent changes first. Each event contains the user
+// who added or removed photos, renamed, shared, merged or cloned the album. Photos added by CSV import
+// or album rules are recorded with "csv" or "album rules" as info.
+// Guest reactions to photos shared with album links are included as "reaction" events with ID 0.
+//
+// Parameters:
+//   uid: string Album UID
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetAlbumEvents(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/events", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumEvents
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.AlbumEvents(a.AlbumUID, f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumEvents(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Activity Log"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		AddPhotosToAlbum(router, conf)
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/photos", `{"photos": ["pt9jtdre2lvl0y12", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		RemovePhotosFromAlbum(router, conf)
+		r = PerformRequestWithBody(app, "DELETE", "/api/v1/albums/"+uid+"/photos", `{"photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		UpdateAlbum(router, conf)
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+uid, `{"Title": "Activity Log 2"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		LinkAlbum(router, conf)
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/link", `{"Password": "", "Expires": 0}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		GetAlbumEvents(router, conf)
+		r = PerformRequest(app, "GET", "/api/v1/albums/"+uid+"/events?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "4", r.Header().Get("X-Count"))
+
+		body := r.Body.String()
+		assert.Equal(t, "shared", gjson.Get(body, "0.Type").String())
+		assert.Equal(t, "renamed", gjson.Get(body, "1.Type").String())
+		assert.Equal(t, "Activity Log", gjson.Get(body, "1.Info").String())
+		assert.Equal(t, "removed", gjson.Get(body, "2.Type").String())
+		assert.Equal(t, int64(1), gjson.Get(body, "2.PhotoCount").Int())
+		assert.Equal(t, "added", gjson.Get(body, "3.Type").String())
+		assert.Equal(t, int64(2), gjson.Get(body, "3.PhotoCount").Int())
+	})
+	t.Run("bulk changes", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbum(router, conf)
+		AddPhotosToAlbum(router, conf)
+		CloneAlbum(router, conf)
+		MergeAlbums(router, conf)
+		GetAlbumEvents(router, conf)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Bulk Source"}`)
+		source := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Bulk Target"}`)
+		target := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+source+"/photos", `{"photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "POST", "/api/v1/albums/"+source+"/clone")
+		assert.Equal(t, http.StatusOK, r.Code)
+		clone := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/"+clone+"/events?count=10")
+		assert.Equal(t, "cloned", gjson.Get(r.Body.String(), "0.Type").String())
+		assert.Equal(t, "Bulk Source", gjson.Get(r.Body.String(), "0.Info").String())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+target+"/merge", `{"albums": ["`+source+`"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/"+target+"/events?count=10")
+		assert.Equal(t, "merged", gjson.Get(r.Body.String(), "0.Type").String())
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "0.PhotoCount").Int())
+		assert.Equal(t, "Bulk Source", gjson.Get(r.Body.String(), "0.Info").String())
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumEvents(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/events?count=10")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumEvents(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/events")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/feed"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Max number of photos in album feeds.
+const albumFeedEntries = 50
+
+// Thumbnail type of images in album feeds.
+const albumFeedThumb = "fit_720"
+
+// GET /api/v1/albums/:uid/feed
+//
+// Returns an Atom feed of the photos most recently added to an album, with thumbnails as enclosures.
+// Feed readers can't log in, so the feed url contains either the token of a share link or the preview
+// token. Shared feeds only contain public photos and link to the share page.
+//
+// Parameters:
+//   uid: string Album UID
+//   s: string Share link token (optional)
+//   t: string Preview token (optional)
+func GetAlbumFeed(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/feed", func(c *gin.Context) {
+		albumUID := c.Param("uid")
+
+		var link *entity.Link
+
+		if token := c.Query("s"); token != "" {
+			m, ok := shareLinkByToken(c, token)
+
+			if !ok || !query.LinkSharesAlbum(m, albumUID) {
+				c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+				return
+			} else if !shareUnlocked(c, m) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+				return
+			}
+
+			link = &m
+		} else if Unauthorized(c, conf) && InvalidToken(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(albumUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		items, err := query.AlbumFeed(a, link != nil, albumFeedEntries)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		data, err := albumFeed(conf, a, link, items).Bytes()
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Data(http.StatusOK, feed.ContentType, data)
+	})
+}
+
+// albumFeed returns the Atom feed of an album. Urls point to the share page and shared thumbnails
+// if a link is given.
+func albumFeed(conf *config.Config, a entity.Album, link *entity.Link, items []query.AlbumFeedItem) *feed.Feed {
+	siteUrl := strings.TrimRight(conf.Url(), "/")
+
+	updated := a.UpdatedAt
+
+	if len(items) > 0 && items[0].AddedAt.After(updated) {
+		updated = items[0].AddedAt
+	}
+
+	result := feed.New(fmt.Sprintf("%s/go/%s", siteUrl, a.AlbumUID), a.AlbumTitle, updated)
+
+	selfUrl := fmt.Sprintf("%s/api/v1/albums/%s/feed", siteUrl, a.AlbumUID)
+	pageUrl := fmt.Sprintf("%s/albums/%s", siteUrl, a.AlbumUID)
+
+	if link != nil {
+		selfUrl = fmt.Sprintf("%s?s=%s", selfUrl, link.LinkToken)
+		pageUrl = link.URL(conf.Url())
+	}
+
+	result.Links = []feed.Link{
+		{Rel: "self", Type: feed.ContentType, Href: selfUrl},
+		{Rel: "alternate", Type: "text/html", Href: pageUrl},
+	}
+
+	for _, item := range items {
+		thumbUrl := fmt.Sprintf("%s/api/v1/t/%s/%s/%s", siteUrl, item.FileHash, conf.PreviewToken(), albumFeedThumb)
+		photoUrl := fmt.Sprintf("%s/go/%s", siteUrl, item.PhotoUID)
+
+		if link != nil {
+			thumbUrl = fmt.Sprintf("%s/api/v1/s/%s/t/%s/%s", siteUrl, link.LinkToken, item.FileHash, albumFeedThumb)
+			photoUrl = pageUrl
+		}
+
+		title := item.PhotoTitle
+
+		if title == "" {
+			title = item.TakenAt.Format("January 2, 2006")
+		}
+
+		body := fmt.Sprintf(`<p><a href="%s"><img src="%s" alt="%s"></a></p>`, html.EscapeString(photoUrl), html.EscapeString(thumbUrl), html.EscapeString(title))
+
+		if item.PhotoDescription != "" {
+			body += fmt.Sprintf("<p>%s</p>", html.EscapeString(item.PhotoDescription))
+		}
+
+		result.Entries = append(result.Entries, feed.Entry{
+			ID:        fmt.Sprintf("%s/go/%s", siteUrl, item.PhotoUID),
+			Title:     title,
+			Updated:   feed.Time(item.AddedAt),
+			Published: feed.Time(item.AddedAt),
+			Links: []feed.Link{
+				{Rel: "alternate", Type: "text/html", Href: photoUrl},
+				{Rel: "enclosure", Type: "image/jpeg", Href: thumbUrl},
+			},
+			Summary: item.PhotoDescription,
+			Content: &feed.Content{Type: "html", Body: body},
+		})
+	}
+
+	return result
+}
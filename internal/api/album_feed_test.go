@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAlbumFeed(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumFeed(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/feed")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Body.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		assert.Contains(t, r.Body.String(), "/go/pt9jtdre2lvl0yh7</id>")
+		assert.Contains(t, r.Body.String(), `rel="enclosure"`)
+		assert.Contains(t, r.Body.String(), "/"+conf.PreviewToken()+"/fit_720")
+	})
+	t.Run("share link", func(t *testing.T) {
+		link := entity.NewLink("", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		GetAlbumFeed(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/feed?s="+link.LinkToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "/api/v1/s/"+link.LinkToken+"/t/")
+		assert.Contains(t, r.Body.String(), "/"+link.Path()+`"`)
+		assert.NotContains(t, r.Body.String(), conf.PreviewToken())
+	})
+	t.Run("album not shared", func(t *testing.T) {
+		link := entity.NewLink("", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		GetAlbumFeed(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba9/feed?s="+link.LinkToken)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumFeed(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/feed")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Default and maximum number of photos in a highlights album.
+const (
+	highlightsDefault = 24
+	highlightsMax     = 500
+)
+
+// POST /api/v1/albums/:uid/highlights
+//
+// Creates a new album containing the best photos of an existing album.
+//
+// Parameters:
+//   uid: string Album UID
+func CreateAlbumHighlights(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/albums/:uid/highlights", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.AlbumHighlights
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		if f.Count <= 0 {
+			f.Count = highlightsDefault
+		} else if f.Count > highlightsMax {
+			f.Count = highlightsMax
+		}
+
+		if f.Title == "" {
+			f.Title = fmt.Sprintf("%s Highlights", a.AlbumTitle)
+		}
+
+		candidates, err := query.AlbumHighlightCandidates(a.AlbumUID, conf.ExcludeCategories())
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m := entity.NewAlbum(f.Title, entity.TypeDefault)
+
+		if err := m.Create(); err != nil {
+			log.Errorf("highlights: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s already exists", txt.Quote(m.AlbumTitle))})
+			return
+		}
+
+		for i, p := range photoprism.Highlights(candidates, f.Count) {
+			pa := entity.NewPhotoAlbum(p.PhotoUID, m.AlbumUID)
+			pa.Order = i
+
+			if err := pa.Create(); err != nil {
+				log.Errorf("highlights: %s", err)
+			}
+		}
+
+		event.Success(fmt.Sprintf("%s created", txt.Quote(m.AlbumTitle)))
+
+		UpdateClientConfig(conf)
+
+		PublishAlbumEvent(EntityCreated, m.AlbumUID, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateAlbumHighlights(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumHighlights(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/highlights", `{"Title": "Berlin Best Of", "Count": 5}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "berlin-best-of", gjson.Get(r.Body.String(), "Slug").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumHighlights(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/highlights", `{"Count": "five"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumHighlights(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/highlights", `{"Count": 5}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "Album not found", gjson.Get(r.Body.String(), "error").String())
+	})
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/albums/:uid/merge
+//
+// Moves the photos, sub albums and rules of the source albums to the album and deletes the sources.
+// Photos that are in more than one album are added once, and share links of the sources are deleted.
+//
+// Parameters:
+//   uid: string Album UID
+func MergeAlbums(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/albums/:uid/merge", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		} else if a.HasFilter() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrAlbumHasFilter)
+			return
+		}
+
+		var f form.AlbumMerge
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Albums) == 0 {
+			log.Error("no albums selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no albums selected")})
+			return
+		}
+
+		var sources []entity.Album
+
+		seen := make(map[string]bool, len(f.Albums))
+
+		for _, uid := range f.Albums {
+			if seen[uid] {
+				continue
+			}
+
+			seen[uid] = true
+
+			src, err := query.AlbumByUID(uid)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+				return
+			}
+
+			// Sub albums of a source are moved to the album, so it must not be one of them.
+			if src.AlbumUID == a.AlbumUID || !src.ValidParent(a.AlbumUID) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrMergeInvalid)
+				return
+			} else if src.HasFilter() {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrAlbumHasFilter)
+				return
+			}
+
+			sources = append(sources, src)
+		}
+
+		// Deleted albums are not found anymore, so event data must be loaded first.
+		deleted := make([]query.AlbumResult, 0, len(sources))
+
+		for _, src := range sources {
+			results, err := query.AlbumSearch(form.AlbumSearch{ID: src.AlbumUID})
+
+			if err != nil {
+				log.Errorf("album: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+				return
+			}
+
+			deleted = append(deleted, results...)
+		}
+
+		added, err := a.Merge(sources)
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.PublishEntities("albums", string(EntityDeleted), deleted)
+
+		titles := make([]string, len(sources))
+
+		for i, src := range sources {
+			titles[i] = src.AlbumTitle
+		}
+
+		addAlbumEvent(c, a.AlbumUID, entity.AlbumEventMerged, added, strings.Join(titles, ", "))
+
+		flushAlbumThumbs(a.AlbumUID)
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		UpdateClientConfig(conf)
+		event.Success(fmt.Sprintf("%d albums merged into %s", len(sources), txt.Quote(a.AlbumTitle)))
+
+		c.JSON(http.StatusOK, gin.H{"message": "albums merged", "album": a, "added": added})
+	})
+}
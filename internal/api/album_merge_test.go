@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMergeAlbums(t *testing.T) {
+	app, router, conf := NewApiTest()
+	MergeAlbums(router, conf)
+
+	target := entity.NewAlbum("Christmas", entity.TypeDefault)
+	source := entity.NewAlbum("Christmas 2", entity.TypeDefault)
+
+	for _, a := range []*entity.Album{target, source} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, uid := range []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"} {
+		if err := entity.NewPhotoAlbum(uid, source.AlbumUID).Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("into itself", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+target.AlbumUID+"/merge", `{"albums": ["`+target.AlbumUID+`"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no albums", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+target.AlbumUID+"/merge", `{"albums": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("source not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+target.AlbumUID+"/merge", `{"albums": ["at9lxuqxpogaxxx"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/merge", `{"albums": ["`+source.AlbumUID+`"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+target.AlbumUID+"/merge", `{"albums": ["`+source.AlbumUID+`", "`+source.AlbumUID+`"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "added").Int())
+		assert.Equal(t, target.AlbumUID, gjson.Get(r.Body.String(), "album.UID").String())
+	})
+	t.Run("source deleted", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+target.AlbumUID+"/merge", `{"albums": ["`+source.AlbumUID+`"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
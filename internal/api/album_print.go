@@ -0,0 +1,211 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/prints"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// printService returns the configured print service.
+func printService(conf *config.Config) (prints.Service, error) {
+	switch name := conf.PrintService(); name {
+	case "":
+		return nil, prints.ErrService
+	case prints.HttpServiceName:
+		return prints.NewHttpService(conf.PrintServiceUrl(), conf.PrintServiceKey()), nil
+	default:
+		return prints.Find(name)
+	}
+}
+
+// exportAlbumPrints exports all photos of an album in the print size to a zip archive in the temp path.
+func exportAlbumPrints(conf *config.Config, a entity.Album, f form.AlbumPrint) (o *prints.Order, zipName string, err error) {
+	o, err = prints.NewOrder(a.AlbumUID, a.AlbumTitle, f.Size, f.Copies)
+
+	if err != nil {
+		return o, "", err
+	}
+
+	photos, _, err := query.PhotoSearch(form.PhotoSearch{Album: a.AlbumUID, Count: 10000})
+
+	if err != nil {
+		return o, "", err
+	}
+
+	var sources []prints.Source
+	seen := make(map[string]bool)
+
+	for _, p := range photos {
+		if p.PhotoType == entity.TypeVideo || p.FileVideo || seen[p.PhotoUID] {
+			continue
+		}
+
+		fileName, err := photoprism.OriginalFileName(conf, entity.File{FileUID: p.FileUID, FileName: p.FileName, FileCold: p.FileCold})
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			continue
+		}
+
+		if !fs.FileExists(fileName) {
+			log.Errorf("album: file %s is missing", txt.Quote(p.FileName))
+			continue
+		}
+
+		seen[p.PhotoUID] = true
+		sources = append(sources, prints.Source{FileName: fileName, PhotoUID: p.PhotoUID})
+	}
+
+	if len(sources) == 0 {
+		return o, "", prints.ErrEmpty
+	}
+
+	zipPath := path.Join(conf.TempPath(), "prints")
+
+	if err := os.MkdirAll(zipPath, 0700); err != nil {
+		return o, "", err
+	}
+
+	zipName = path.Join(zipPath, fmt.Sprintf("%s-%s-%s.zip", strings.Title(a.AlbumSlug), o.Size.Name, rnd.Token(3)))
+
+	if err := o.Export(sources, zipName); err != nil {
+		_ = os.Remove(zipName)
+		return o, "", err
+	}
+
+	log.Infof("album: exported %d photos of %s for printing in %s", len(o.Items), txt.Quote(a.AlbumTitle), o.Size.Name)
+
+	return o, zipName, nil
+}
+
+// albumPrintError aborts the request with a status code matching the error.
+func albumPrintError(c *gin.Context, err error) {
+	switch err {
+	case prints.ErrSize:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Unknown print size"})
+	case prints.ErrEmpty:
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "No photos to print"})
+	default:
+		log.Errorf("album: %s", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+	}
+}
+
+// GET /api/v1/albums/:uid/print
+//
+// Returns a zip archive with all photos resized to the print size and an order sheet for print labs.
+//
+// Parameters:
+//   uid: string Album UID
+//   size: string Print size, see prints.Sizes
+//   copies: int Number of copies per photo
+func DownloadAlbumPrints(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/print", func(c *gin.Context) {
+		if InvalidDownloadToken(c, conf) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumPrint
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		_, zipName, err := exportAlbumPrints(conf, a, f)
+
+		if err != nil {
+			albumPrintError(c, err)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", path.Base(zipName)))
+
+		c.File(zipName)
+
+		if err := os.Remove(zipName); err != nil {
+			log.Errorf("album: could not remove %s (%s)", txt.Quote(zipName), err.Error())
+		}
+	})
+}
+
+// POST /api/v1/albums/:uid/print
+//
+// Submits a print order for all photos of an album to the configured print service.
+//
+// Parameters:
+//   uid: string Album UID
+func OrderAlbumPrints(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/albums/:uid/print", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		service, err := printService(conf)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumPrint
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		o, zipName, err := exportAlbumPrints(conf, a, f)
+
+		if err != nil {
+			albumPrintError(c, err)
+			return
+		}
+
+		defer func() {
+			if err := os.Remove(zipName); err != nil {
+				log.Errorf("album: could not remove %s (%s)", txt.Quote(zipName), err.Error())
+			}
+		}()
+
+		if err := service.Submit(o, zipName); err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "prints: "))})
+			return
+		}
+
+		log.Infof("album: print order for %s submitted to %s", txt.Quote(a.AlbumTitle), conf.PrintService())
+
+		c.JSON(http.StatusOK, o)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestDownloadAlbumPrints(t *testing.T) {
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadAlbumPrints(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/5678/print?size=10x15&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("size missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadAlbumPrints(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/print?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("unknown size", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadAlbumPrints(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/print?size=1x1&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Unknown print size", gjson.Get(r.Body.String(), "error").String())
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadAlbumPrints(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/print?size=10x15&t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestOrderAlbumPrints(t *testing.T) {
+	t.Run("no print service", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		OrderAlbumPrints(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/print", `{"Size": "10x15", "Copies": 1}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
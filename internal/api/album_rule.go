@@ -0,0 +1,152 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/album-rules
+func GetAlbumRules(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/album-rules", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		results, err := query.AlbumRules()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/album-rules
+//
+// Creates a rule that adds imported photos to an album if all conditions match, e.g. a folder name
+// pattern like "dji*", a date range, the serial number of a camera, or the uploader.
+func CreateAlbumRule(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/album-rules", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.AlbumRule
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, err := entity.NewAlbumRule(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if _, err := query.AlbumByUID(m.AlbumUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		if err := m.Create(); err != nil {
+			log.Errorf("album rule: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("album rule %s created", txt.Quote(m.RuleName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// PUT /api/v1/album-rules/:uid
+//
+// Parameters:
+//   uid: string Album rule UID
+func UpdateAlbumRule(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/album-rules/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.AlbumRuleByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrRuleNotFound)
+			return
+		}
+
+		var f form.AlbumRule
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.SetForm(f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if _, err := query.AlbumByUID(m.AlbumUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("album rule: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("album rule %s saved", txt.Quote(m.RuleName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/album-rules/:uid
+//
+// Parameters:
+//   uid: string Album rule UID
+func DeleteAlbumRule(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/album-rules/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.AlbumRuleByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrRuleNotFound)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("album rule: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("album rule %s deleted", txt.Quote(m.RuleName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
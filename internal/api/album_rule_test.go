@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateAlbumRule(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumRule(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/album-rules", `{"Name": "Aerial", "AlbumUID": "at9lxuqxpogaaba8", "Folder": "DJI*", "CameraSerial": "0K1234"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Aerial", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "dji*", gjson.Get(r.Body.String(), "Folder").String())
+	})
+	t.Run("no conditions", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumRule(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/album-rules", `{"Name": "All", "AlbumUID": "at9lxuqxpogaaba8"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbumRule(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/album-rules", `{"AlbumUID": "at9lxuqxpogaxxxx", "Folder": "dji*"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateAlbumRule(t *testing.T) {
+	app, router, conf := NewApiTest()
+	CreateAlbumRule(router, conf)
+	UpdateAlbumRule(router, conf)
+	GetAlbumRules(router, conf)
+	DeleteAlbumRule(router, conf)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/album-rules", `{"Name": "Summer", "AlbumUID": "at9lxuqxpogaaba9", "After": "2020-06-01T00:00:00Z", "Before": "2020-09-01T00:00:00Z"}`)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("update", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/album-rules/"+uid, `{"Name": "Summer", "AlbumUID": "at9lxuqxpogaaba9", "Uploader": "jane@example.com"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "jane@example.com", gjson.Get(r.Body.String(), "Uploader").String())
+		assert.Equal(t, gjson.Null, gjson.Get(r.Body.String(), "After").Type)
+	})
+	t.Run("invalid pattern", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/album-rules/"+uid, `{"AlbumUID": "at9lxuqxpogaaba9", "Folder": "dji["}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("list", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/album-rules")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#").Int() > 0)
+	})
+	t.Run("delete", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/album-rules/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequest(app, "DELETE", "/api/v1/album-rules/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/story"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// storyThumb is the thumbnail type of photos embedded in stories.
+const storyThumb = "fit_1280"
+
+// storyEmbed returns the thumbnail url of an embedded photo, or false if the photo has no primary file.
+func storyEmbed(conf *config.Config) story.Embed {
+	return func(photoUID string) (string, bool) {
+		f, err := query.FileByPhotoUID(photoUID)
+
+		if err != nil {
+			return "", false
+		}
+
+		return fmt.Sprintf("/api/v1/t/%s/%s/%s", f.FileHash, conf.PreviewToken(), storyThumb), true
+	}
+}
+
+// shareStoryEmbed returns the share thumbnail url of an embedded photo, or false if the photo is private
+// or not shared by the link.
+func shareStoryEmbed(link entity.Link) story.Embed {
+	return func(photoUID string) (string, bool) {
+		f, err := query.FileByPhotoUID(photoUID)
+
+		if err != nil || f.Photo == nil || f.Photo.PhotoPrivate || !query.LinkSharesPhoto(link, photoUID) {
+			return "", false
+		}
+
+		return fmt.Sprintf("/api/v1/s/%s/t/%s/%s", link.LinkToken, f.FileHash, storyThumb), true
+	}
+}
+
+// GET /api/v1/albums/:uid/story
+//
+// Returns the Markdown story of an album and the rendered HTML.
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumStory(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/story", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Markdown": m.AlbumStory, "HTML": story.Render(m.AlbumStory, storyEmbed(conf)), "Photos": story.Photos(m.AlbumStory)})
+	})
+}
+
+// PUT /api/v1/albums/:uid/story
+//
+// Changes the Markdown story of an album. Photos can be embedded by UID, e.g. ![Caption](photo:pt9jtdre2lvl0yh7).
+//
+// Parameters:
+//   uid: string Album UID
+func UpdateAlbumStory(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uid/story", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		uid := c.Param("uid")
+		m, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumStory
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Markdown) > story.MaxLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrStoryTooLong)
+			return
+		}
+
+		if err := m.SetStory(f.Markdown); err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("album story saved")
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, gin.H{"Markdown": m.AlbumStory, "HTML": story.Render(m.AlbumStory, storyEmbed(conf)), "Photos": story.Photos(m.AlbumStory)})
+	})
+}
+
+// shareStory returns the rendered story of the album shared by a link, or of a nested album if the
+// link has subtree scope. Returns false if the album isn't shared.
+func shareStory(link entity.Link, albumUID string) (result template.HTML, ok bool) {
+	if albumUID == "" {
+		albumUID = link.ShareUID
+	}
+
+	if !rnd.IsPPID(albumUID, 'a') || !query.LinkSharesAlbum(link, albumUID) {
+		return "", false
+	}
+
+	m, err := query.AlbumByUID(albumUID)
+
+	if err != nil {
+		return "", false
+	}
+
+	return story.Render(m.AlbumStory, shareStoryEmbed(link)), true
+}
+
+// GET /api/v1/s/:token/story
+//
+// Returns the rendered story of a shared album. Embedded photos that are private or not shared are omitted.
+//
+// Parameters:
+//   token: string Share link token
+//   album: string Nested album UID (optional)
+func GetShareStory(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/story", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+			return
+		}
+
+		html, ok := shareStory(link, c.Query("album"))
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"HTML": html})
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpdateAlbumStory(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumStory(router, conf)
+		GetAlbumStory(router, conf)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/story", `{"Markdown": "# Day 1\n\nWe arrived *late*."}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "# Day 1\n\nWe arrived *late*.", gjson.Get(r.Body.String(), "Markdown").String())
+		assert.Contains(t, gjson.Get(r.Body.String(), "HTML").String(), "<h1>Day 1</h1>")
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/story")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "HTML").String(), "<em>late</em>")
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumStory(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/story", `{"Markdown": "Hello"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetShareStory(t *testing.T) {
+	t.Run("link not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareStory(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/xxx/story")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
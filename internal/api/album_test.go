@@ -1,9 +1,14 @@
 package api
 
 import (
+	"archive/zip"
+	"bytes"
 	"net/http"
+	"strconv"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
@@ -18,6 +23,16 @@ func TestGetAlbums(t *testing.T) {
 		assert.LessOrEqual(t, int64(3), count.Int())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("total count", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbums(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums?count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		total, err := strconv.Atoi(r.Header().Get("X-Count"))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, 3, total)
+	})
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetAlbums(router, conf)
@@ -35,6 +50,15 @@ func TestGetAlbum(t *testing.T) {
 		assert.Equal(t, "holiday-2030", val.String())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("fields", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbum(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8?fields=UID,slug")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "holiday-2030", gjson.Get(r.Body.String(), "Slug").String())
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "UID").String())
+		assert.False(t, gjson.Get(r.Body.String(), "Title").Exists())
+	})
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetAlbum(router, conf)
@@ -62,6 +86,25 @@ func TestCreateAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": 333, "Description": "Created via unit test", "Notes": "", "Favorite": true}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("smart album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbum(router, conf)
+		AddPhotosToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Flowers", "Filter": "label:flower"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "moment", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "label:flower", gjson.Get(r.Body.String(), "Filter").String())
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/photos", `{"photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Invalid", "Filter": "foo:bar"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 func TestUpdateAlbum(t *testing.T) {
 	app, router, conf := NewApiTest()
@@ -81,6 +124,42 @@ func TestUpdateAlbum(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("location and date", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbum(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+uid, `{"Location": "Lake Garda", "Year": 2019, "Month": 8, "Day": 12}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Lake Garda", gjson.Get(r.Body.String(), "Location").String())
+		assert.Equal(t, int64(12), gjson.Get(r.Body.String(), "Day").Int())
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbum(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+uid, `{"Month": 8, "Day": 32}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Invalid date", gjson.Get(r.Body.String(), "error").String())
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbum(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+uid, `{"Title": "Updated02"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		etag := r.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		r = PerformRequestWithHeaders(app, "PUT", "/api/v1/albums/"+uid, `{"Title": "Updated03"}`, map[string]string{"If-Match": etag})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Updated03", gjson.Get(r.Body.String(), "Title").String())
+
+		// Stale version, e.g. changed by someone else after the first request.
+		r = PerformRequestWithHeaders(app, "PUT", "/api/v1/albums/"+uid, `{"Title": "Updated04"}`, map[string]string{"If-Match": etag})
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, "Updated03", gjson.Get(r.Body.String(), "current.Title").String())
+		assert.NotEqual(t, etag, r.Header().Get("ETag"))
+	})
+
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		UpdateAlbum(router, conf)
@@ -205,6 +284,67 @@ func TestAddPhotosToAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/xxx/photos", `{"photos": ["pt9jtdre2lvl0yxx"]}`)
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+	t.Run("filter", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotosToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/photos", `{"filter": "favorite:true"}`)
+		assert.Equal(t, "photos added to album", gjson.Get(r.Body.String(), "message").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		photos, _, err := query.PhotoSearch(form.PhotoSearch{Album: uid, Favorite: true, Count: 1000, Merged: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		favorites, _, err := query.PhotoSearch(form.PhotoSearch{Favorite: true, Count: 1000, Merged: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(favorites))
+		assert.Equal(t, len(favorites), len(photos))
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotosToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/photos", `{"filter": "near:foo"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("nothing selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddPhotosToAlbum(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/"+uid+"/photos", `{}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestSetAlbumPhotoOrder(t *testing.T) {
+	app, router, conf := NewApiTest()
+	SetAlbumPhotoOrder(router, conf)
+
+	t.Run("successful request", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/photos/order", `{"photos": ["pt9jtdre2lvl0yh8", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "album order saved", gjson.Get(r.Body.String(), "message").String())
+	})
+	t.Run("photo not in album", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/photos/order", `{"photos": ["pt9jtdre2lvl0yh7"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("duplicate photo", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/photos/order", `{"photos": ["pt9jtdre2lvl0y11", "pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba9/photos/order", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/photos/order", `{"photos": ["pt9jtdre2lvl0y11"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
 }
 
 func TestRemovePhotosFromAlbum(t *testing.T) {
@@ -263,6 +403,86 @@ func TestDownloadAlbum(t *testing.T) {
 
 		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+conf.DownloadToken())
 		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/zip", r.Header().Get("Content-Type"))
+		assert.Empty(t, r.Header().Get("Content-Length"))
+
+		_, err := zip.NewReader(bytes.NewReader(r.Body.Bytes()), int64(r.Body.Len()))
+		assert.NoError(t, err)
+	})
+	t.Run("download favorites", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbum(router, conf)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?favorite=true&quality=3&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbum(router, conf)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?quality=best&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("resized jpegs", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbum(router, conf)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?size=fit_1280&raw=exclude&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/zip", r.Header().Get("Content-Type"))
+	})
+	t.Run("invalid size", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbum(router, conf)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?size=tile_50&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid raw option", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		DownloadAlbum(router, conf)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?raw=only&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Invalid raw option only", gjson.Get(r.Body.String(), "error").String())
+	})
+}
+
+func TestSetAlbumCover(t *testing.T) {
+	app, router, conf := NewApiTest()
+	SetAlbumCover(router, conf)
+	ResetAlbumCover(router, conf)
+
+	t.Run("photo", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{"Photo": "pt9jtdre2lvl0yh7"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "CoverUID").String())
+	})
+	t.Run("photo not in album", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{"Photo": "pt9jtdre2lvl0y11"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{"Photo": "pt9jtdre2lvl0xxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("missing photo", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/cover", `{}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/cover", `{"Photo": "pt9jtdre2lvl0yh7"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("reset", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/cover")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "CoverUID").String())
 	})
 }
 
@@ -272,18 +492,20 @@ func TestAlbumThumbnail(t *testing.T) {
 		AlbumThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba7/t/"+conf.PreviewToken()+"/xxx")
 
-		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 	t.Run("album has no photo (because is not existing)", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		AlbumThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/albums/987-986435/t/"+conf.PreviewToken()+"/tile_500")
 		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, albumIconSvg, r.Body.Bytes())
+		assert.Equal(t, CacheRevalidate, r.Header().Get("Cache-Control"))
 	})
 	t.Run("album: could not find original", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		AlbumThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/t/"+conf.PreviewToken()+"/tile_500")
-		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/albums/:uid/timeline
+//
+// Returns the photos of an album grouped by the day they were taken, with the number of photos
+// and videos as well as the places and countries visited on each day.
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumTimeline(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/timeline", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		photos, err := query.AlbumTimelinePhotos(a.AlbumUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, photos.Days())
+	})
+}
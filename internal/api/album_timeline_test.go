@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumTimeline(t *testing.T) {
+	t.Run("existing album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumTimeline(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/timeline")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("not existing album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumTimeline(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/timeline")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
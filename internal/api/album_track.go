@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+
+	geojson "github.com/paulmach/go.geojson"
+)
+
+// GET /api/v1/albums/:uid/track.geojson
+//
+// Returns the route of a trip as GeoJSON line connecting the positions of the photos in an album in the
+// order they were taken. The "coordTimes" property contains the time of each position and "Photos" the
+// photo UIDs. The feature collection is empty if less than two photos have GPS coordinates.
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumTrack(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/track.geojson", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		points, err := query.AlbumTrack(a)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		fc := geojson.NewFeatureCollection()
+
+		if len(points) > 1 {
+			coords := make([][]float64, len(points))
+			times := make([]string, len(points))
+			photos := make([]string, len(points))
+
+			bbox := []float64{points[0].Lng(), points[0].Lat(), points[0].Lng(), points[0].Lat()}
+
+			for i, p := range points {
+				coords[i] = []float64{p.Lng(), p.Lat()}
+				times[i] = p.TakenAt.UTC().Format("2006-01-02T15:04:05Z")
+				photos[i] = p.PhotoUID
+
+				if p.Lng() < bbox[0] {
+					bbox[0] = p.Lng()
+				} else if p.Lng() > bbox[2] {
+					bbox[2] = p.Lng()
+				}
+
+				if p.Lat() < bbox[1] {
+					bbox[1] = p.Lat()
+				} else if p.Lat() > bbox[3] {
+					bbox[3] = p.Lat()
+				}
+			}
+
+			feat := geojson.NewLineStringFeature(coords)
+			feat.ID = a.AlbumUID
+			feat.Properties = gin.H{
+				"Title":      a.AlbumTitle,
+				"coordTimes": times,
+				"Photos":     photos,
+			}
+
+			fc.AddFeature(feat)
+			fc.BoundingBox = bbox
+		}
+
+		resp, err := fc.MarshalJSON()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/geo+json", resp)
+	})
+}
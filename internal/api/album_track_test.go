@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumTrack(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumTrack(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba9/track.geojson")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/geo+json", r.Header().Get("Content-Type"))
+		assert.Equal(t, "LineString", gjson.Get(r.Body.String(), "features.0.geometry.type").String())
+
+		coords := gjson.Get(r.Body.String(), "features.0.geometry.coordinates.#").Int()
+		assert.LessOrEqual(t, int64(2), coords)
+		assert.Equal(t, coords, gjson.Get(r.Body.String(), "features.0.properties.coordTimes.#").Int())
+		assert.Equal(t, coords, gjson.Get(r.Body.String(), "features.0.properties.Photos.#").Int())
+		assert.Equal(t, int64(4), gjson.Get(r.Body.String(), "bbox.#").Int())
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumTrack(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/track.geojson")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/albums/:uid/children
+//
+// Returns the albums nested in an album.
+//
+// Parameters:
+//   uid: string Album UID
+//   tree: bool Include all nested albums as children (optional)
+//   count: int Max number of results, default 1000 (optional)
+//   offset: int Result offset (optional)
+//   order: string Sort order, e.g. "slug" (optional)
+func GetAlbumChildren(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/children", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		f := form.AlbumSearch{Count: 1000}
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		f.Parent = a.AlbumUID
+
+		result, err := query.AlbumSearch(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		fieldsJSON(c, http.StatusOK, result)
+	})
+}
+
+// PUT /api/v1/albums/:uid/parent
+//
+// Moves an album into another album, or to the top level if the parent UID is empty.
+//
+// Parameters:
+//   uid: string Album UID
+func MoveAlbum(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uid/parent", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.AlbumParent
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		uid := c.Param("uid")
+		m, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		if err := m.SetParent(f.ParentUID); err == entity.ErrAlbumParentInvalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrParentInvalid)
+			return
+		} else if err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		UpdateClientConfig(conf)
+
+		event.Success("album moved")
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		c.Header("ETag", m.VersionTag())
+
+		c.JSON(http.StatusOK, m)
+	})
+}
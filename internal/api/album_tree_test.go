@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMoveAlbum(t *testing.T) {
+	app, router, conf := NewApiTest()
+	MoveAlbum(router, conf)
+	GetAlbumChildren(router, conf)
+
+	parent := entity.NewAlbum("Vacations", entity.TypeDefault)
+	child := entity.NewAlbum("Italy", entity.TypeDefault)
+
+	for _, a := range []*entity.Album{parent, child} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("move", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+child.AlbumUID+"/parent", `{"ParentUID": "`+parent.AlbumUID+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, parent.AlbumUID, gjson.Get(r.Body.String(), "ParentUID").String())
+	})
+	t.Run("children", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/albums/"+parent.AlbumUID+"/children")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, child.AlbumUID, gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("into itself", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+parent.AlbumUID+"/parent", `{"ParentUID": "`+child.AlbumUID+`"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("parent not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+child.AlbumUID+"/parent", `{"ParentUID": "at9lxuqxpogaxxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/parent", `{"ParentUID": ""}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/xxx/children")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("top level", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+child.AlbumUID+"/parent", `{"ParentUID": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "ParentUID").String())
+	})
+}
@@ -41,11 +41,26 @@ func PerformRequestWithBody(r http.Handler, method, path, body string) *httptest
 	return w
 }
 
+// Performs API request with body and headers.
+func PerformRequestWithHeaders(r http.Handler, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	reader := strings.NewReader(body)
+	req, _ := http.NewRequest(method, path, reader)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 func TestMain(m *testing.M) {
 	log = logrus.StandardLogger()
 	log.SetLevel(logrus.DebugLevel)
 
 	c := config.TestConfig()
+	service.SetConfig(c)
 
 	code := m.Run()
 
@@ -0,0 +1,350 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/backup"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// The mobile backup API is a stable contract for auto-backup apps, served at /api/backup/v1.
+// Unlike the API used by the web interface, existing endpoints and fields won't change or be removed.
+// Requests must be authenticated with a session token in the X-Session-Token header. Apps should also send
+// the token of a registered device in the X-Device-Token header, so that uploads are attributed to it.
+//
+//   POST  /check                          {"Hashes": ["sha1", ...]} returns {"Existing": [...], "Missing": [...]}
+//   POST  /batches                        creates a new batch of uploads
+//   GET   /batches/:batch                 returns the batch status including all uploads
+//   POST  /batches/:batch/uploads         {"Name": "IMG_0001.HEIC", "Size": 1234, "Hash": "sha1"} creates an upload
+//   GET   /batches/:batch/uploads/:upload returns the upload status, use Offset to resume an upload
+//   PATCH /batches/:batch/uploads/:upload appends the request body at the offset in the Upload-Offset header
+//   POST  /batches/:batch/commit          imports all complete uploads of the batch
+//
+// Files that already exist are rejected with status 409, so are chunks with an unexpected offset and
+// commits while another import or index is running, which should be retried later.
+// Uploads are verified using their SHA1 hash once complete and must be restarted at offset 0 if it doesn't match.
+
+// Limits for mobile backup requests.
+const (
+	backupMaxHashes = 1000
+	backupMaxAge    = 7 * 24 * time.Hour
+)
+
+// backupEnabled aborts the request and returns false if uploads are not possible or the client is not authorized.
+func backupEnabled(c *gin.Context, conf *config.Config) bool {
+	if Unauthorized(c, conf) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+		return false
+	}
+
+	if conf.ReadOnly() || !conf.Settings().Features.Upload {
+		c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+		return false
+	}
+
+	return true
+}
+
+// backupDevice returns the UID of the registered device sending the request, or an empty string if no
+// device token was sent. Requests with unknown device tokens are aborted with status 401.
+func backupDevice(c *gin.Context) (string, bool) {
+	token := c.GetHeader("X-Device-Token")
+
+	if token == "" {
+		return "", true
+	}
+
+	d, err := query.DeviceByToken(token)
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrDeviceUnknown)
+		return "", false
+	}
+
+	return d.DeviceUID, true
+}
+
+// backupError aborts the request with a status code matching the backup error.
+func backupError(c *gin.Context, err error) {
+	switch err {
+	case backup.ErrNotFound:
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case backup.ErrOffset, backup.ErrComplete, backup.ErrCommitted:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "backup: "))})
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "backup: "))})
+	}
+}
+
+// verifyBackupFiles checks committed files like uploads, see VerifyUpload, and returns the number of files
+// that may be imported. Suspicious files are moved to quarantine, files that can't be checked are removed.
+func verifyBackupFiles(conf *config.Config, dir, uploader string) (count int) {
+	files, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		log.Errorf("backup: %s", err)
+		return 0
+	}
+
+	for _, info := range files {
+		if info.IsDir() {
+			continue
+		}
+
+		fileName := filepath.Join(dir, info.Name())
+
+		if quarantined, err := photoprism.VerifyUpload(conf, fileName, entity.QuarantineBackup, uploader); err != nil {
+			log.Errorf("backup: %s", err)
+
+			if err := os.Remove(fileName); err != nil {
+				log.Errorf("backup: could not delete %s", txt.Quote(info.Name()))
+			}
+		} else if !quarantined {
+			count++
+		}
+	}
+
+	return count
+}
+
+// POST /api/backup/v1/check
+func BackupCheck(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/check", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		var f form.BackupCheck
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Hashes) > backupMaxHashes {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Too many hashes"})
+			return
+		}
+
+		hashes := make([]string, len(f.Hashes))
+
+		for i, h := range f.Hashes {
+			hashes[i] = strings.ToLower(h)
+		}
+
+		existing, err := query.ExistingHashes(hashes)
+
+		if err != nil {
+			log.Errorf("backup: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		found := make(map[string]bool, len(existing))
+
+		for _, h := range existing {
+			found[h] = true
+		}
+
+		missing := []string{}
+
+		for _, h := range hashes {
+			if !found[h] {
+				missing = append(missing, h)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Existing": existing, "Missing": missing})
+	})
+}
+
+// POST /api/backup/v1/batches
+func BackupCreateBatch(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batches", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		device, ok := backupDevice(c)
+
+		if !ok {
+			return
+		}
+
+		store := service.Backup()
+
+		if n := store.Cleanup(backupMaxAge); n > 0 {
+			log.Infof("backup: removed %d expired batches", n)
+		}
+
+		b, err := store.NewBatch(device)
+
+		if err != nil {
+			log.Errorf("backup: %s", err)
+			backupError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, b)
+	})
+}
+
+// GET /api/backup/v1/batches/:batch
+func BackupGetBatch(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/batches/:batch", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		b, err := service.Backup().Batch(c.Param("batch"))
+
+		if err != nil {
+			backupError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, b)
+	})
+}
+
+// POST /api/backup/v1/batches/:batch/uploads
+func BackupCreateUpload(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batches/:batch/uploads", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		var f form.BackupUpload
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if conf.OriginalsLimit() > 0 && f.Size > conf.OriginalsLimit() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "File too large"})
+			return
+		}
+
+		if existing, err := query.ExistingHashes([]string{strings.ToLower(f.Hash)}); err == nil && len(existing) > 0 {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "File already exists"})
+			return
+		}
+
+		u, err := service.Backup().Create(c.Param("batch"), f.Name, f.Size, f.Hash)
+
+		if err != nil {
+			backupError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, u)
+	})
+}
+
+// GET /api/backup/v1/batches/:batch/uploads/:upload
+func BackupGetUpload(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/batches/:batch/uploads/:upload", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		u, err := service.Backup().Upload(c.Param("batch"), c.Param("upload"))
+
+		if err != nil {
+			backupError(c, err)
+			return
+		}
+
+		c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+		c.JSON(http.StatusOK, u)
+	})
+}
+
+// PATCH /api/backup/v1/batches/:batch/uploads/:upload
+func BackupWriteUpload(router *gin.RouterGroup, conf *config.Config) {
+	router.PATCH("/batches/:batch/uploads/:upload", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Upload-Offset header"})
+			return
+		}
+
+		u, err := service.Backup().Write(c.Param("batch"), c.Param("upload"), offset, c.Request.Body)
+
+		c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+
+		if err != nil {
+			backupError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, u)
+	})
+}
+
+// POST /api/backup/v1/batches/:batch/commit
+func BackupCommitBatch(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batches/:batch/commit", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		// Apps retry commits that fail, so don't commit a batch that can't be imported right now.
+		if mutex.MainWorker.Busy() {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "Busy, please try again later"})
+			return
+		}
+
+		batchID := c.Param("batch")
+		dest := filepath.Join(conf.ImportPath(), "backup", batchID)
+
+		b, count, err := service.Backup().Commit(batchID, dest)
+
+		if err != nil {
+			backupError(c, err)
+			return
+		}
+
+		if count > 0 {
+			count = verifyBackupFiles(conf, dest, sessionUserID(c))
+		}
+
+		if count > 0 {
+			log.Infof("backup: importing %d files from batch %s", count, batchID)
+
+			opt := photoprism.ImportOptionsMove(dest)
+			opt.Device = b.Device
+
+			go func() {
+				if done := service.Import().Start(opt); len(done) > 0 {
+					event.Publish("backup.imported", event.Data{"batch": batchID, "files": count})
+				} else {
+					log.Errorf("backup: batch %s was not imported, files remain in %s", batchID, txt.Quote(dest))
+				}
+			}()
+		}
+
+		c.JSON(http.StatusOK, b)
+	})
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestBackupCheck(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupCheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/check", `{"Hashes": ["2CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818", "xxx"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", gjson.Get(r.Body.String(), "Existing.0").String())
+		assert.Equal(t, "xxx", gjson.Get(r.Body.String(), "Missing.0").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupCheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/check", `{"Hashes": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBackupGetBatch(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupGetBatch(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/batches/bqzq1xxxxxxxxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupGetBatch(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/batches/..")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestBackupCommitBatch(t *testing.T) {
+	t.Run("worker busy", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupCommitBatch(router, conf)
+
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		r := PerformRequest(app, "POST", "/api/v1/batches/bqzq1xxxxxxxxxxx/commit")
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupCommitBatch(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/batches/bqzq1xxxxxxxxxxx/commit")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestBackupCreateUpload(t *testing.T) {
+	t.Run("file exists", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupCreateBatch(router, conf)
+		BackupCreateUpload(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/batches")
+		assert.Equal(t, http.StatusOK, r.Code)
+		batchID := gjson.Get(r.Body.String(), "ID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batches/"+batchID+"/uploads", `{"Name": "IMG_0001.jpg", "Size": 100, "Hash": "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}`)
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("invalid hash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BackupCreateBatch(router, conf)
+		BackupCreateUpload(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/batches")
+		batchID := gjson.Get(r.Body.String(), "ID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batches/"+batchID+"/uploads", `{"Name": "IMG_0001.jpg", "Size": 100, "Hash": "abc"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBackupWriteUpload(t *testing.T) {
+	app, router, conf := NewApiTest()
+	BackupCreateBatch(router, conf)
+	BackupGetBatch(router, conf)
+	BackupCreateUpload(router, conf)
+	BackupGetUpload(router, conf)
+	BackupWriteUpload(router, conf)
+
+	data := "resumable backup test data"
+	sum := sha1.Sum([]byte(data))
+	hash := hex.EncodeToString(sum[:])
+
+	r := PerformRequest(app, "POST", "/api/v1/batches")
+	assert.Equal(t, http.StatusOK, r.Code)
+	batchID := gjson.Get(r.Body.String(), "ID").String()
+
+	r = PerformRequestWithBody(app, "POST", "/api/v1/batches/"+batchID+"/uploads", `{"Name": "backup.txt", "Size": 26, "Hash": "`+hash+`"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uploadURL := "/api/v1/batches/" + batchID + "/uploads/" + gjson.Get(r.Body.String(), "ID").String()
+
+	write := func(offset, chunk string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", uploadURL, strings.NewReader(chunk))
+		req.Header.Set("Upload-Offset", offset)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	r = write("0", data[:10])
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, int64(10), gjson.Get(r.Body.String(), "Offset").Int())
+
+	r = write("0", data[:10])
+	assert.Equal(t, http.StatusConflict, r.Code)
+	assert.Equal(t, "10", r.Header().Get("Upload-Offset"))
+
+	r = PerformRequest(app, "GET", uploadURL)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "10", r.Header().Get("Upload-Offset"))
+
+	r = write("10", data[10:])
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, int64(26), gjson.Get(r.Body.String(), "Offset").Int())
+
+	r = PerformRequest(app, "GET", "/api/v1/batches/"+batchID)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Complete").Int())
+}
+
+func TestVerifyBackupFiles(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	dir, err := ioutil.TempDir("", "backup")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"IMG_0001.txt", "IMG_0002.txt"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("backup"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "sub"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, verifyBackupFiles(conf, dir, "admin"))
+	assert.Equal(t, 0, verifyBackupFiles(conf, filepath.Join(dir, "missing"), "admin"))
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -10,7 +11,9 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -110,6 +113,8 @@ func BatchPhotosRestore(router *gin.RouterGroup, conf *config.Config) {
 }
 
 // POST /api/v1/batch/albums/delete
+//
+// Deletes the selected albums in a single transaction, so that many albums can be removed at once.
 func BatchAlbumsDelete(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/batch/albums/delete", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -132,14 +137,24 @@ func BatchAlbumsDelete(router *gin.RouterGroup, conf *config.Config) {
 
 		log.Infof("albums: deleting %#v", f.Albums)
 
-		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
-		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.PhotoAlbum{})
+		deleted, err := entity.DeleteAlbums(f.Albums)
+
+		if err != nil {
+			log.Errorf("albums: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		for _, uid := range deleted {
+			flushAlbumThumbs(uid)
+		}
 
 		UpdateClientConfig(conf)
 
-		event.EntitiesDeleted("albums", f.Albums)
+		event.EntitiesDeleted("albums", deleted)
+		event.Success(fmt.Sprintf("%d albums deleted", len(deleted)))
 
-		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("albums deleted")})
+		c.JSON(http.StatusOK, gin.H{"message": "albums deleted", "albums": deleted})
 	})
 }
 
@@ -191,6 +206,249 @@ func BatchPhotosPrivate(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// POST /api/v1/batch/photos/license
+func BatchPhotosLicense(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batch/photos/license", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.PhotoLicense
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			log.Error("no photos selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no photos selected")})
+			return
+		}
+
+		if f.Empty() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no license details provided")})
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("updating license of %d photos", len(photos))
+
+		for _, p := range photos {
+			details := entity.FirstOrCreateDetails(&entity.Details{PhotoID: p.ID})
+
+			if details == nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+
+			details.SetLicense(f.License, f.Attribution, f.Credit)
+
+			if err := details.Save(); err != nil {
+				log.Errorf("photos: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+		}
+
+		event.EntitiesUpdated("photos", photos)
+
+		elapsed := time.Since(start)
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("license of %d photos updated in %s", len(photos), elapsed)})
+	})
+}
+
+// POST /api/v1/batch/photos/rotate
+//
+// Rotates the selected photos clockwise by updating the Exif orientation of their primary JPEG,
+// which is indexed again to create new thumbnails.
+//
+// Parameters:
+//   photos: []string Photo UIDs
+//   angle: int Clockwise rotation in degrees, either 90, 180 or 270
+func BatchPhotosRotate(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batch/photos/rotate", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.PhotoRotate
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			log.Error("no photos selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no photos selected")})
+			return
+		}
+
+		if !photoprism.ValidRotation(f.Angle) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(strings.TrimPrefix(photoprism.ErrRotateAngle.Error(), "rotate: "))})
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("photos: rotating %d photos by %d°", len(photos), f.Angle)
+
+		ind := service.Index()
+		rotated, failed := 0, 0
+
+		for _, p := range photos {
+			file, err := query.FileByPhotoUID(p.PhotoUID)
+
+			if err != nil {
+				log.Errorf("photos: no primary file found for %s", p.PhotoUID)
+				failed++
+				continue
+			}
+
+			if err := photoprism.Rotate(conf, ind, file, f.Angle); err != nil {
+				log.Errorf("photos: can't rotate %s (%s)", txt.Quote(file.FileName), err)
+				failed++
+				continue
+			}
+
+			rotated++
+
+			PublishPhotoEvent(EntityUpdated, p.PhotoUID, c)
+		}
+
+		elapsed := time.Since(start)
+
+		if failed > 0 {
+			event.Warning(fmt.Sprintf("%d photos could not be rotated", failed))
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d photos rotated in %s", rotated, elapsed), "rotated": rotated, "failed": failed})
+	})
+}
+
+// POST /api/v1/batch/photos/subjects
+//
+// Tags a person in all selected photos with a marker, or removes the tag, e.g. for scans where faces
+// can't be detected automatically. The person is created if needed.
+//
+// Parameters:
+//   photos: []string Photo UIDs
+//   subject: string Person name
+//   remove: bool Remove the person from the photos instead (optional)
+func BatchPhotosSubjects(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batch/photos/subjects", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.PhotoSubjects
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			log.Error("no photos selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no photos selected")})
+			return
+		}
+
+		if strings.TrimSpace(f.Subject) == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no person selected")})
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		uids := make([]string, len(photos))
+
+		for i, p := range photos {
+			uids[i] = p.PhotoUID
+		}
+
+		var subj *entity.Subject
+
+		if f.Remove {
+			subj = entity.FindSubject(f.Subject, entity.SubjPerson)
+		} else {
+			subj = entity.FirstOrCreateSubject(entity.NewSubject(f.Subject, entity.SubjPerson))
+		}
+
+		if subj == nil && f.Remove {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrSubjectNotFound)
+			return
+		} else if subj == nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		var message string
+
+		if f.Remove {
+			removed, err := subj.RemovePhotos(uids)
+
+			if err != nil {
+				log.Errorf("photos: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+
+			message = fmt.Sprintf("%s removed from %s", subj.SubjName, txt.Plural(removed, "photo", "photos"))
+		} else {
+			added, err := subj.AddPhotos(uids)
+
+			if err != nil {
+				log.Errorf("photos: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+
+			message = fmt.Sprintf("%s added to %s", subj.SubjName, txt.Plural(len(added), "photo", "photos"))
+		}
+
+		log.Infof("photos: %s", message)
+
+		event.EntitiesUpdated("photos", photos)
+
+		elapsed := time.Since(start)
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%s in %s", message, elapsed), "subject": subj})
+	})
+}
+
 // POST /api/v1/batch/labels/delete
 func BatchLabelsDelete(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/batch/labels/delete", func(c *gin.Context) {
@@ -223,3 +481,79 @@ func BatchLabelsDelete(router *gin.RouterGroup, conf *config.Config) {
 		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("labels deleted")})
 	})
 }
+
+// POST /api/v1/batch/photos/date
+//
+// Assigns an approximate date to photos without Exif data, e.g. scans of old prints. Photos are
+// sorted as if taken in the middle of the year or decade and displayed like "circa 1987".
+//
+// Parameters:
+//   photos: []string Photo UIDs
+//   Year: int Estimated year
+//   Approx: string Precision, "year" or "decade"
+func BatchPhotosDate(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batch/photos/date", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.PhotoApproxDate
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			log.Error("no photos selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no photos selected")})
+			return
+		}
+
+		// Validate year and precision before changing anything.
+		if err := (&entity.Photo{}).SetApproxDate(f.Year, f.Approx); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("setting approximate date of %d photos", len(photos))
+
+		for i := range photos {
+			p := &photos[i]
+
+			if err := p.SetApproxDate(f.Year, f.Approx); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			if err := p.Updates(map[string]interface{}{
+				"TakenAt":      p.TakenAt,
+				"TakenAtLocal": p.TakenAtLocal,
+				"TakenSrc":     p.TakenSrc,
+				"TakenApprox":  p.TakenApprox,
+				"PhotoYear":    p.PhotoYear,
+				"PhotoMonth":   p.PhotoMonth,
+			}); err != nil {
+				log.Errorf("photos: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+		}
+
+		event.EntitiesUpdated("photos", photos)
+
+		elapsed := time.Since(start)
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("date of %d photos updated in %s", len(photos), elapsed)})
+	})
+}
@@ -107,6 +107,8 @@ func TestBatchAlbumsDelete(t *testing.T) {
 		val2 := gjson.Get(r2.Body.String(), "message")
 		assert.Contains(t, val2.String(), "albums deleted")
 		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, int64(1), gjson.Get(r2.Body.String(), "albums.#").Int())
+		assert.Equal(t, uid, gjson.Get(r2.Body.String(), "albums.0").String())
 
 		r3 := PerformRequest(app, "GET", "/api/v1/albums/"+uid)
 		val3 := gjson.Get(r3.Body.String(), "error")
@@ -165,6 +167,68 @@ func TestBatchPhotosPrivate(t *testing.T) {
 	})
 }
 
+func TestBatchPhotosLicense(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosLicense(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/license", `{"photos": ["pt9jtdre2lvl0yh8", "pt9jtdre2lvl0ycc"], "License": "CC BY 4.0", "Attribution": "Photo by Hans"}`)
+		val := gjson.Get(r.Body.String(), "message")
+		assert.Contains(t, val.String(), "license of 1 photos updated")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		GetPhoto(router, conf)
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8")
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, "CC BY 4.0", gjson.Get(r2.Body.String(), "Details.License").String())
+		assert.Equal(t, "Photo by Hans", gjson.Get(r2.Body.String(), "Details.Attribution").String())
+	})
+	t.Run("no photos selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosLicense(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/license", `{"photos": [], "License": "CC0"}`)
+		assert.Equal(t, "No photos selected", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no license details", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosLicense(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/license", `{"photos": ["pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, "No license details provided", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchPhotosDate(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosDate(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/date", `{"photos": ["pt9jtdre2lvl0y14", "pt9jtdre2lvl0ycc"], "Year": 1987, "Approx": "decade"}`)
+		assert.Contains(t, gjson.Get(r.Body.String(), "message").String(), "date of 1 photos updated")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		GetPhoto(router, conf)
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y14")
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, "decade", gjson.Get(r2.Body.String(), "TakenApprox").String())
+		assert.Equal(t, "manual", gjson.Get(r2.Body.String(), "TakenSrc").String())
+		assert.Equal(t, int64(1985), gjson.Get(r2.Body.String(), "Year").Int())
+		assert.Equal(t, int64(-1), gjson.Get(r2.Body.String(), "Month").Int())
+	})
+	t.Run("no photos selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosDate(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/date", `{"photos": [], "Year": 1987, "Approx": "year"}`)
+		assert.Equal(t, "No photos selected", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid precision", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosDate(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/date", `{"photos": ["pt9jtdre2lvl0y14"], "Year": 1987, "Approx": "month"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestBatchLabelsDelete(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, conf := NewApiTest()
@@ -198,3 +262,73 @@ func TestBatchLabelsDelete(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestBatchPhotosRotate(t *testing.T) {
+	t.Run("no photos selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosRotate(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rotate", `{"photos": [], "angle": 90}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "No photos selected", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid angle", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosRotate(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rotate", `{"photos": ["pt9jtdre2lvl0yh7"], "angle": 45}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "Angle must be 90, 180 or 270 degrees", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosRotate(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rotate", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchPhotosSubjects(t *testing.T) {
+	t.Run("add and remove person", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosSubjects(router, conf)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/subjects", `{"photos": ["pt9jtdre2lvl0y17", "pt9jtdre2lvl0y18"], "subject": "Jane Subjects"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "message").String(), "Jane Subjects added to 2 photos")
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "subject.PhotoCount").Int())
+
+		r2 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/subjects", `{"photos": ["pt9jtdre2lvl0y17"], "subject": "Jane Subjects", "remove": true}`)
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Contains(t, gjson.Get(r2.Body.String(), "message").String(), "Jane Subjects removed from 1 photo in")
+		assert.Equal(t, int64(1), gjson.Get(r2.Body.String(), "subject.PhotoCount").Int())
+	})
+	t.Run("unknown person", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosSubjects(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/subjects", `{"photos": ["pt9jtdre2lvl0y17"], "subject": "Nobody Known", "remove": true}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("no person selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosSubjects(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/subjects", `{"photos": ["pt9jtdre2lvl0y17"], "subject": " "}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "No person selected", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no photos selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosSubjects(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/subjects", `{"photos": [], "subject": "Jane Subjects"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "No photos selected", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosSubjects(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/subjects", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
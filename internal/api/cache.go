@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache-Control header values.
+const (
+	// CacheImmutable is used for content addressed by file hash, e.g. photo thumbnails.
+	CacheImmutable = "private, max-age=31536000, immutable"
+
+	// CacheStatic is used for static content like SVG icons that only change with updates.
+	CacheStatic = "public, max-age=86400"
+
+	// CacheRevalidate is used for content that may change, e.g. metadata and cover images,
+	// clients may store it but must validate their copy using the ETag before reuse.
+	CacheRevalidate = "private, no-cache"
+
+	// CacheNone is used for errors, so that clients retry.
+	CacheNone = "no-store"
+)
+
+// entityTag returns a strong entity tag for a list of values, e.g. file hash and thumbnail type.
+func entityTag(values ...string) string {
+	return fmt.Sprintf("%q", strings.Join(values, "-"))
+}
+
+// dataTag returns a strong entity tag for the checksum of data.
+func dataTag(data []byte) string {
+	return fmt.Sprintf("\"%08x-%d\"", crc32.ChecksumIEEE(data), len(data))
+}
+
+// matchETag returns true if the If-None-Match header contains the entity tag. Weak comparison is used
+// as defined in RFC 7232, so W/"1" matches "1".
+func matchETag(header, etag string) bool {
+	if header == "" || etag == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notModified sets the ETag and Cache-Control headers, and returns true after responding with
+// 304 Not Modified if the client already has the current version.
+func notModified(c *gin.Context, etag, cacheControl string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", cacheControl)
+
+	if !matchETag(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+
+	return true
+}
+
+// svgIcon responds with an SVG icon. Icons with status 200 are static and may be cached, while all others
+// are placeholders in case of errors and must not be cached, so that clients retry.
+func svgIcon(c *gin.Context, code int, icon []byte) {
+	if code != http.StatusOK {
+		c.Writer.Header().Del("ETag")
+		c.Header("Cache-Control", CacheNone)
+		c.Data(code, "image/svg+xml", icon)
+		return
+	}
+
+	if notModified(c, dataTag(icon), CacheStatic) {
+		return
+	}
+
+	c.Data(code, "image/svg+xml", icon)
+}
+
+// svgPlaceholder responds with an SVG icon as placeholder for an image that doesn't exist yet,
+// e.g. the cover of an empty album.
+func svgPlaceholder(c *gin.Context, icon []byte) {
+	if notModified(c, dataTag(icon), CacheRevalidate) {
+		return
+	}
+
+	c.Data(http.StatusOK, "image/svg+xml", icon)
+}
+
+// coverJpeg responds with a JPEG image that may change, e.g. the cover of an album.
+func coverJpeg(c *gin.Context, data []byte) {
+	if notModified(c, dataTag(data), CacheRevalidate) {
+		return
+	}
+
+	c.Data(http.StatusOK, "image/jpeg", data)
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityTag(t *testing.T) {
+	assert.Equal(t, `"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818-tile_500"`, entityTag("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "tile_500"))
+}
+
+func TestDataTag(t *testing.T) {
+	assert.Equal(t, dataTag([]byte("foo")), dataTag([]byte("foo")))
+	assert.NotEqual(t, dataTag([]byte("foo")), dataTag([]byte("bar")))
+}
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, matchETag(`"1"`, `"1"`))
+	assert.True(t, matchETag(`W/"1"`, `"1"`))
+	assert.True(t, matchETag(`"2", "1"`, `"1"`))
+	assert.True(t, matchETag(`*`, `"1"`))
+	assert.False(t, matchETag(`"2"`, `"1"`))
+	assert.False(t, matchETag("", `"1"`))
+	assert.False(t, matchETag(`"1"`, ""))
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/chat"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// chatDefaultSize is the default thumbnail type of images posted to a chat.
+const chatDefaultSize = "fit_1280"
+
+// chatService returns the configured chat service.
+func chatService(conf *config.Config) (chat.Service, error) {
+	return chat.New(conf.ChatService(), conf.ChatServiceUrl(), conf.ChatServiceKey(), conf.ChatServiceTarget())
+}
+
+// chatImages returns resized images of the photos in the given order, photos without image are skipped.
+func chatImages(conf *config.Config, photoUIDs []string, thumbType thumb.Type) (images []string) {
+	for _, photoUID := range photoUIDs {
+		f, err := query.FileByPhotoUID(photoUID)
+
+		if err != nil {
+			log.Errorf("chat: no image found for %s", photoUID)
+			continue
+		}
+
+		fileName, err := thumbSource(conf, f, thumbType)
+
+		if err != nil {
+			log.Errorf("chat: %s", err)
+			continue
+		}
+
+		thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+
+		if err != nil {
+			log.Errorf("chat: %s", err)
+			continue
+		}
+
+		images = append(images, thumbnail)
+	}
+
+	return images
+}
+
+// POST /api/v1/chat
+//
+// Posts the selected photos resized, together with a new share link, to the configured chat group.
+// A snapshot of the photos is created, so that the link keeps showing the same photos.
+func ShareWithChat(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/chat", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		service, err := chatService(conf)
+
+		if err == chat.ErrService {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		} else if err != nil {
+			log.Errorf("chat: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		var f form.ChatShare
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) > chat.MaxImages {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many photos, up to %d can be sent at once", chat.MaxImages)})
+			return
+		}
+
+		if f.Size == "" {
+			f.Size = chatDefaultSize
+		}
+
+		thumbType, ok := thumb.Types[f.Size]
+
+		if !ok || thumbType.ExceedsLimit() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid size"})
+			return
+		}
+
+		photoUIDs, err := query.SnapshotPhotos(f.Photos)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if len(photoUIDs) == 0 {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		images := chatImages(conf, photoUIDs, thumbType)
+
+		if len(images) == 0 {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(chat.ErrEmpty.Error())})
+			return
+		}
+
+		title := strings.TrimSpace(strings.SplitN(f.Message, "\n", 2)[0])
+
+		if title == "" {
+			title = fmt.Sprintf("Sent to %s on %s", conf.ChatService(), time.Now().Format("2006-01-02 15:04"))
+		}
+
+		snapshot := entity.NewSnapshot(title, "", photoUIDs)
+
+		if err := snapshot.Create(); err != nil {
+			log.Errorf("chat: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		link := entity.NewLink("", false, false)
+
+		if f.Expires > 0 {
+			expires := time.Now().Add(time.Duration(f.Expires) * time.Second)
+			link.LinkExpires = &expires
+		}
+
+		if err := entity.Db().Model(snapshot).Association("Links").Append(&link).Error; err != nil {
+			log.Errorf("chat: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		m := chat.Message{
+			Text:   f.Message,
+			Link:   link.URL(conf.Url()),
+			Images: images,
+		}
+
+		if err := service.Send(m); err != nil {
+			log.Errorf("chat: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success(fmt.Sprintf("sent %d photos to %s", len(images), conf.ChatService()))
+
+		countUsage(conf, entity.UsageShare)
+
+		c.JSON(http.StatusOK, gin.H{"Snapshot": snapshot, "Link": m.Link, "Count": len(images)})
+	})
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareWithChat(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ShareWithChat(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/chat", `{"Photos": ["pt9jtdre2lvl0yh7"]}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestChatImages(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	t.Run("not found", func(t *testing.T) {
+		images := chatImages(conf, []string{"pt9jtdre2lvl0xxx"}, thumb.Types[chatDefaultSize])
+		assert.Empty(t, images)
+	})
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// checkpoint returns the checkpoint with the uid in the request or aborts with status 404.
+func checkpoint(c *gin.Context) (result entity.Checkpoint, ok bool) {
+	result, err := query.CheckpointByUID(c.Param("uid"))
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrNoCheckpoint)
+		return result, false
+	}
+
+	return result, true
+}
+
+// GET /api/v1/checkpoints
+//
+// Returns library metadata checkpoints, most recent first.
+//
+// Parameters:
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetCheckpoints(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/checkpoints", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.CheckpointSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.Checkpoints(f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/checkpoints
+//
+// Saves the current metadata of all photos and albums in a new checkpoint, e.g. before a batch edit.
+func CreateCheckpoint(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/checkpoints", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.Checkpoint
+
+		if c.Request.ContentLength > 0 {
+			if err := c.BindJSON(&f); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		m, err := photoprism.CreateCheckpoint(conf, f.Note)
+
+		if err != nil {
+			log.Errorf("checkpoint: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("checkpoint created")
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GET /api/v1/checkpoints/:uid/diff
+//
+// Returns the fields of photos and albums that have been changed since the checkpoint was created,
+// including their value at that time (Before) and now (After).
+//
+// Parameters:
+//   uid: string Checkpoint UID as returned by the API
+//   field: string Limit results to a field, e.g. title, description, favorite, private, labels or albums
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetCheckpointDiff(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/checkpoints/:uid/diff", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.CheckpointDiff
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, ok := checkpoint(c)
+
+		if !ok {
+			return
+		}
+
+		changes, err := photoprism.CheckpointChanges(conf, m)
+
+		if err != nil {
+			log.Errorf("checkpoint: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		results := make([]entity.CheckpointChange, 0, len(changes))
+
+		for _, change := range changes {
+			if f.Field == "" || f.Field == change.Field {
+				results = append(results, change)
+			}
+		}
+
+		if f.Offset >= len(results) {
+			results = results[:0]
+		} else {
+			results = results[f.Offset:]
+		}
+
+		if f.Count > 0 && f.Count < len(results) {
+			results = results[:f.Count]
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/checkpoints/:uid/restore
+//
+// Restores selected fields of photos and albums to the values saved in the checkpoint. All changed
+// photos, albums and fields are restored if none are selected. A new checkpoint is created first,
+// so that a restore can be undone.
+//
+// Parameters:
+//   uid: string Checkpoint UID as returned by the API
+//   photos: []string Photo UIDs
+//   albums: []string Album UIDs
+//   fields: []string Fields, e.g. title, description, favorite, private, labels or albums
+func RestoreCheckpoint(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/checkpoints/:uid/restore", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.CheckpointRestore
+
+		if c.Request.ContentLength > 0 {
+			if err := c.BindJSON(&f); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		m, ok := checkpoint(c)
+
+		if !ok {
+			return
+		}
+
+		restored, err := photoprism.RestoreCheckpoint(conf, m, f)
+
+		if err != nil {
+			log.Errorf("checkpoint: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		published := make(map[string]bool)
+
+		for _, change := range restored {
+			if published[change.UID] {
+				continue
+			}
+
+			published[change.UID] = true
+
+			switch change.Type {
+			case entity.CheckpointPhoto:
+				PublishPhotoEvent(EntityUpdated, change.UID, c)
+			case entity.CheckpointAlbum:
+				PublishAlbumEvent(EntityUpdated, change.UID, c)
+			}
+		}
+
+		if len(restored) > 0 {
+			UpdateClientConfig(conf)
+			event.Success("metadata restored")
+		}
+
+		c.JSON(http.StatusOK, restored)
+	})
+}
+
+// DELETE /api/v1/checkpoints/:uid
+//
+// Deletes a checkpoint, photos and albums are not changed.
+//
+// Parameters:
+//   uid: string Checkpoint UID as returned by the API
+func DeleteCheckpoint(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/checkpoints/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := checkpoint(c)
+
+		if !ok {
+			return
+		}
+
+		if err := photoprism.DeleteCheckpoint(conf, m); err != nil {
+			log.Errorf("checkpoint: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		event.Success("checkpoint deleted")
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetCheckpoints(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetCheckpoints(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/checkpoints?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetCheckpoints(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/checkpoints")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRestoreCheckpoint(t *testing.T) {
+	t.Run("restore album description", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateCheckpoint(router, conf)
+		GetCheckpointDiff(router, conf)
+		RestoreCheckpoint(router, conf)
+		DeleteCheckpoint(router, conf)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/checkpoints", `{"Note": "Before batch edit"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Before batch edit", gjson.Get(r.Body.String(), "Note").String())
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		if err := entity.Db().Model(&entity.Album{}).Where("album_uid = ?", "at9lxuqxpogaaba8").
+			UpdateColumn("album_description", "Changed by accident").Error; err != nil {
+			t.Fatal(err)
+		}
+
+		r = PerformRequest(app, "GET", fmt.Sprintf("/api/v1/checkpoints/%s/diff?count=10&field=description", uid))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "album", gjson.Get(r.Body.String(), "0.Type").String())
+		assert.Equal(t, "Wonderful christmas", gjson.Get(r.Body.String(), "0.Before").String())
+		assert.Equal(t, "Changed by accident", gjson.Get(r.Body.String(), "0.After").String())
+
+		r = PerformRequestWithBody(app, "POST", fmt.Sprintf("/api/v1/checkpoints/%s/restore", uid), `{"albums": ["at9lxuqxpogaaba8"], "fields": ["description"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+
+		if a, err := query.AlbumByUID("at9lxuqxpogaaba8"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "Wonderful christmas", a.AlbumDescription)
+		}
+
+		r = PerformRequest(app, "DELETE", "/api/v1/checkpoints/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RestoreCheckpoint(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/checkpoints/kxxxxxxxxxxxxxxx/restore")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteCheckpoint(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DeleteCheckpoint(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/checkpoints/kxxxxxxxxxxxxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
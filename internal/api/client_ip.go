@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// clientIP returns the IP address of the client. Forwarding headers like X-Forwarded-For are only honored
+// if the request was sent by a trusted proxy, as any client could set them to bypass restrictions and limits.
+func clientIP(c *gin.Context) string {
+	return requestIP(c.Request, service.Config().TrustedProxies())
+}
+
+// requestIP returns the client IP address of the request, taking forwarding headers of trusted proxies into account.
+func requestIP(r *http.Request, proxies []string) string {
+	ip, _, err := net.SplitHostPort(strings.TrimSpace(r.RemoteAddr))
+
+	if err != nil || !trustedProxy(ip, proxies) {
+		return ip
+	}
+
+	if header := r.Header.Get("X-Forwarded-For"); header != "" {
+		addrs := strings.Split(header, ",")
+
+		// Proxies append the address they received the request from, so the
+		// client is the last address that wasn't added by a trusted proxy.
+		for i := len(addrs) - 1; i >= 0; i-- {
+			addr := strings.TrimSpace(addrs[i])
+
+			if net.ParseIP(addr) == nil {
+				break
+			}
+
+			ip = addr
+
+			if !trustedProxy(addr, proxies) {
+				break
+			}
+		}
+	} else if addr := strings.TrimSpace(r.Header.Get("X-Real-Ip")); net.ParseIP(addr) != nil {
+		ip = addr
+	}
+
+	return ip
+}
+
+// trustedProxy returns true if the IP address matches one of the proxy addresses or ranges.
+func trustedProxy(addr string, proxies []string) bool {
+	ip := net.ParseIP(addr)
+
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range proxies {
+		if _, network, err := net.ParseCIDR(proxy); err == nil && network.Contains(ip) {
+			return true
+		} else if p := net.ParseIP(proxy); p != nil && p.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIP(t *testing.T) {
+	request := func(remoteAddr string, headers map[string]string) *http.Request {
+		r, _ := http.NewRequest("GET", "/api/v1/s/xxx/albums", nil)
+		r.RemoteAddr = remoteAddr
+
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+
+		return r
+	}
+
+	proxies := []string{"172.16.0.0/12", "10.0.0.1"}
+
+	t.Run("remote address", func(t *testing.T) {
+		assert.Equal(t, "203.0.113.7", requestIP(request("203.0.113.7:4711", nil), proxies))
+	})
+	t.Run("untrusted forwarded for", func(t *testing.T) {
+		r := request("203.0.113.7:4711", map[string]string{"X-Forwarded-For": "10.0.0.2", "X-Real-Ip": "10.0.0.2"})
+		assert.Equal(t, "203.0.113.7", requestIP(r, proxies))
+		assert.Equal(t, "203.0.113.7", requestIP(r, nil))
+	})
+	t.Run("trusted proxy", func(t *testing.T) {
+		r := request("172.16.0.5:4711", map[string]string{"X-Forwarded-For": "192.168.0.1, 203.0.113.7, 10.0.0.1"})
+		assert.Equal(t, "203.0.113.7", requestIP(r, proxies))
+	})
+	t.Run("trusted real ip", func(t *testing.T) {
+		r := request("10.0.0.1:4711", map[string]string{"X-Real-Ip": "203.0.113.7"})
+		assert.Equal(t, "203.0.113.7", requestIP(r, proxies))
+	})
+	t.Run("invalid forwarded for", func(t *testing.T) {
+		r := request("10.0.0.1:4711", map[string]string{"X-Forwarded-For": "unknown"})
+		assert.Equal(t, "10.0.0.1", requestIP(r, proxies))
+	})
+	t.Run("no remote address", func(t *testing.T) {
+		assert.Equal(t, "", requestIP(request("", nil), proxies))
+	})
+}
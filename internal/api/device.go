@@ -0,0 +1,175 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// deviceToken represents a newly registered device including the token apps must send in the
+// X-Device-Token header. The token is only returned once.
+type deviceToken struct {
+	entity.Device
+	Token string `json:"Token"`
+}
+
+// GET /api/v1/devices
+//
+// Returns all devices registered to upload photos, e.g. the phones of family members running a mobile backup app.
+func GetDevices(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/devices", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		results, err := query.Devices()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// GET /api/v1/devices/:uid
+//
+// Parameters:
+//   uid: string Device UID
+func GetDevice(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/devices/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.DeviceByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrDeviceNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/devices
+//
+// Registers a device and returns its token. Photos uploaded with the token in the X-Device-Token header
+// are attributed to the device, and optionally added to an album or label named after it.
+func CreateDevice(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/devices", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.Device
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, err := entity.NewDevice(f, sessionUserID(c))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Create(); err != nil {
+			log.Errorf("device: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("device %s registered", txt.Quote(m.DeviceName)))
+
+		c.JSON(http.StatusOK, deviceToken{Device: *m, Token: m.DeviceToken})
+	})
+}
+
+// PUT /api/v1/devices/:uid
+//
+// Parameters:
+//   uid: string Device UID
+func UpdateDevice(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/devices/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.DeviceByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrDeviceNotFound)
+			return
+		}
+
+		f := form.Device{Name: m.DeviceName, Album: m.DeviceAlbum, Label: m.DeviceLabel}
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.SetForm(f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("device: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("device %s saved", txt.Quote(m.DeviceName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/devices/:uid
+//
+// Removes a device, so that its token can't be used anymore. Photos uploaded by it are kept.
+//
+// Parameters:
+//   uid: string Device UID
+func DeleteDevice(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/devices/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.DeviceByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrDeviceNotFound)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("device: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("device %s deleted", txt.Quote(m.DeviceName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
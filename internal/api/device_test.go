@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestDevices(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetDevices(router, conf)
+	GetDevice(router, conf)
+	CreateDevice(router, conf)
+	UpdateDevice(router, conf)
+	DeleteDevice(router, conf)
+	BackupCreateBatch(router, conf)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/devices", `{"Name": "Anna's iPhone", "Album": true}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "Anna's iPhone", gjson.Get(r.Body.String(), "Name").String())
+	assert.True(t, gjson.Get(r.Body.String(), "Album").Bool())
+	uid := gjson.Get(r.Body.String(), "UID").String()
+	token := gjson.Get(r.Body.String(), "Token").String()
+	assert.NotEmpty(t, token)
+
+	t.Run("list", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/devices")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Parse(r.Body.String()).Array())
+		assert.False(t, gjson.Get(r.Body.String(), "0.Token").Exists())
+	})
+	t.Run("update", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/devices/"+uid, `{"Label": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Anna's iPhone", gjson.Get(r.Body.String(), "Name").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Album").Bool())
+		assert.True(t, gjson.Get(r.Body.String(), "Label").Bool())
+	})
+	t.Run("name empty", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/devices", `{"Name": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("backup batch", func(t *testing.T) {
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/batches", "", map[string]string{"X-Device-Token": token})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, uid, gjson.Get(r.Body.String(), "Device").String())
+
+		r = PerformRequestWithHeaders(app, "POST", "/api/v1/batches", "", map[string]string{"X-Device-Token": "invalid"})
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("delete", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/devices/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/devices/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
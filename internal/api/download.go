@@ -3,10 +3,15 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"path"
+	"path/filepath"
+	"strings"
 
+	"github.com/photoprism/photoprism/internal/archive"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -17,10 +22,45 @@ import (
 // TODO: GET /api/v1/dl/photo/:uid
 // TODO: GET /api/v1/dl/album/:uid
 
+// convertJpeg returns true if JPEG versions of originals were requested with "?convert=jpeg".
+func convertJpeg(c *gin.Context) bool {
+	switch strings.ToLower(c.Query("convert")) {
+	case "jpeg", "jpg":
+		return true
+	default:
+		return false
+	}
+}
+
+// downloadEntry returns the file name and alias of an original for download. If requested with
+// "?convert=jpeg", formats like HEIC and RAW are replaced by a cached JPEG version. The original is
+// used if it can't be converted.
+func downloadEntry(c *gin.Context, fileName, fileHash, alias string) archive.Entry {
+	return archiveEntry(convertJpeg(c), fileName, fileHash, alias)
+}
+
+// archiveEntry returns the file name and alias of an original for download, see downloadEntry.
+func archiveEntry(convert bool, fileName, fileHash, alias string) archive.Entry {
+	if !convert {
+		return archive.Entry{FileName: fileName, Alias: alias}
+	}
+
+	jpegName, converted, err := service.Convert().DownloadJpeg(fileName, fileHash)
+
+	if err != nil {
+		log.Warnf("download: %s", err)
+	} else if converted {
+		return archive.Entry{FileName: jpegName, Alias: strings.TrimSuffix(alias, filepath.Ext(alias)) + fs.JpegExt}
+	}
+
+	return archive.Entry{FileName: fileName, Alias: alias}
+}
+
 // GET /api/v1/dl/:hash
 //
 // Parameters:
 //   hash: string The file hash as returned by the search API
+//   convert: string Use "jpeg" to download a JPEG version of formats like HEIC and RAW (query)
 func GetDownload(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/dl/:hash", func(c *gin.Context) {
 		if InvalidDownloadToken(c, conf) {
@@ -37,7 +77,13 @@ func GetDownload(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+		fileName, err := photoprism.OriginalFileName(conf, f)
+
+		if err != nil {
+			log.Errorf("download: %s", err)
+			c.Data(http.StatusNotFound, "image/svg+xml", brokenIconSvg)
+			return
+		}
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("download: file %s is missing", txt.Quote(f.FileName))
@@ -49,10 +95,12 @@ func GetDownload(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		downloadFileName := f.ShareFileName()
+		download := downloadEntry(c, fileName, f.FileHash, f.ShareFileName())
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", download.Alias))
 
-		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", downloadFileName))
+		countUsage(conf, entity.UsageDownload)
 
-		c.File(fileName)
+		c.File(download.FileName)
 	})
 }
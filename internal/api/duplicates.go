@@ -0,0 +1,135 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// duplicatesError aborts the request with a status code matching the duplicates error.
+func duplicatesError(c *gin.Context, err error) {
+	switch err {
+	case gorm.ErrRecordNotFound:
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case photoprism.ErrDuplicateUndone:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "duplicates: "))})
+	case photoprism.ErrDuplicateAction, photoprism.ErrDuplicateGroup:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "duplicates: "))})
+	default:
+		log.Errorf("duplicates: %s", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+	}
+}
+
+// GET /api/v1/duplicates
+//
+// Returns groups of photos taken with the same camera at the same time.
+//
+// Parameters:
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetDuplicates(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/duplicates", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.Duplicates
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		groups, err := query.DuplicateGroups(f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, groups)
+	})
+}
+
+// POST /api/v1/duplicates/resolve
+//
+// Resolves a group of duplicates with one of the actions keep-best, merge, or link.
+// Returns the planned changes without applying them if DryRun is true.
+func ResolveDuplicates(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/duplicates/resolve", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.DuplicateResolve
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		changes, res, err := photoprism.ResolveDuplicates(f.Action, f.Photos, f.DryRun)
+
+		if err != nil {
+			duplicatesError(c, err)
+			return
+		}
+
+		if res == nil {
+			c.JSON(http.StatusOK, gin.H{"Action": f.Action, "DryRun": true, "Changes": changes})
+			return
+		}
+
+		if len(changes.Archived) > 0 {
+			UpdateClientConfig(conf)
+			event.EntitiesArchived("photos", changes.Archived)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Action": f.Action, "DryRun": false, "Changes": changes, "Resolution": res})
+	})
+}
+
+// POST /api/v1/duplicates/undo/:uid
+//
+// Reverts the changes of a duplicate resolution.
+//
+// Parameters:
+//   uid: string Resolution UID
+func UndoDuplicates(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/duplicates/undo/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		res, err := photoprism.UndoDuplicates(c.Param("uid"))
+
+		if err != nil {
+			duplicatesError(c, err)
+			return
+		}
+
+		if changes, err := res.DuplicateChanges(); err == nil && len(changes.Archived) > 0 {
+			UpdateClientConfig(conf)
+			event.EntitiesRestored("photos", changes.Archived)
+		}
+
+		c.JSON(http.StatusOK, res)
+	})
+}
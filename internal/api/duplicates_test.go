@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetDuplicates(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetDuplicates(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#").Int() > 0)
+		assert.True(t, gjson.Get(r.Body.String(), "0.Photos.#").Int() > 1)
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetDuplicates(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestResolveDuplicates(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/resolve", `{"Action": "link", "Photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh9"], "DryRun": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "DryRun").Bool())
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Changes.Archived.#").Int())
+		assert.False(t, gjson.Get(r.Body.String(), "Resolution").Exists())
+	})
+	t.Run("unknown action", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/resolve", `{"Action": "xxx", "Photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh9"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Unknown action", gjson.Get(r.Body.String(), "error").String())
+	})
+	t.Run("single photo", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/resolve", `{"Action": "merge", "Photos": ["pt9jtdre2lvl0yh7"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestUndoDuplicates(t *testing.T) {
+	t.Run("resolve and undo", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		UndoDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/resolve", `{"Action": "merge", "Photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh9"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "Resolution.UID").String()
+		assert.NotEmpty(t, uid)
+		r = PerformRequest(app, "POST", "/api/v1/duplicates/undo/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "UndoneAt").String())
+		r = PerformRequest(app, "POST", "/api/v1/duplicates/undo/"+uid)
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UndoDuplicates(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/duplicates/undo/rt9lxuqxpogaaxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
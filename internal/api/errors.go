@@ -22,4 +22,35 @@ var (
 	ErrSaveFailed       = gin.H{"code": http.StatusInternalServerError, "error": "Changes could not be saved"}
 	ErrFormInvalid      = gin.H{"code": http.StatusBadRequest, "error": "Changes could not be saved"}
 	ErrFeatureDisabled  = gin.H{"code": http.StatusForbidden, "error": "Feature disabled"}
+	ErrLinkNotFound     = gin.H{"code": http.StatusNotFound, "error": "Link not found"}
+	ErrReactionNotFound = gin.H{"code": http.StatusNotFound, "error": "Reaction not found"}
+	ErrSubjectNotFound  = gin.H{"code": http.StatusNotFound, "error": "Person not found"}
+	ErrGuestNotFound    = gin.H{"code": http.StatusNotFound, "error": "Guest not found"}
+	ErrSnapshotNotFound = gin.H{"code": http.StatusNotFound, "error": "Snapshot not found"}
+	ErrArchiveNotFound  = gin.H{"code": http.StatusNotFound, "error": "Archive not found"}
+	ErrPresetNotFound   = gin.H{"code": http.StatusNotFound, "error": "Preset not found"}
+	ErrRuleNotFound     = gin.H{"code": http.StatusNotFound, "error": "Album rule not found"}
+	ErrTooManyRequests  = gin.H{"code": http.StatusTooManyRequests, "error": "Too many requests"}
+	ErrPermissionDenied = gin.H{"code": http.StatusForbidden, "error": "Permission denied"}
+	ErrVersionConflict  = gin.H{"code": http.StatusConflict, "error": "Changed by someone else in the meantime"}
+	ErrImportFailed     = gin.H{"code": http.StatusInternalServerError, "error": "Import failed"}
+	ErrNotAnAlbum       = gin.H{"code": http.StatusBadRequest, "error": "Only album links can share nested albums"}
+	ErrParentInvalid    = gin.H{"code": http.StatusBadRequest, "error": "Album can't be nested in itself or its sub albums"}
+	ErrStoryTooLong     = gin.H{"code": http.StatusBadRequest, "error": "Story is too long"}
+	ErrQueryNotFound    = gin.H{"code": http.StatusNotFound, "error": "Query not found"}
+	ErrJobNotFound      = gin.H{"code": http.StatusNotFound, "error": "Job not running"}
+	ErrIndexErrNotFound = gin.H{"code": http.StatusNotFound, "error": "Index error not found"}
+	ErrAlbumTitleEmpty  = gin.H{"code": http.StatusBadRequest, "error": "Album title must not be empty"}
+	ErrNotQuarantined   = gin.H{"code": http.StatusNotFound, "error": "File not found in quarantine"}
+	ErrNoCheckpoint     = gin.H{"code": http.StatusNotFound, "error": "Checkpoint not found"}
+	ErrSearchNotFound   = gin.H{"code": http.StatusNotFound, "error": "Search not found in history"}
+	ErrFilterInvalid    = gin.H{"code": http.StatusBadRequest, "error": "Invalid album filter"}
+	ErrDateInvalid      = gin.H{"code": http.StatusBadRequest, "error": "Invalid date"}
+	ErrAlbumHasFilter   = gin.H{"code": http.StatusBadRequest, "error": "Photos can't be added to smart albums"}
+	ErrDeviceNotFound   = gin.H{"code": http.StatusNotFound, "error": "Device not found"}
+	ErrDeviceUnknown    = gin.H{"code": http.StatusUnauthorized, "error": "Unknown device"}
+	ErrThumbErrNotFound = gin.H{"code": http.StatusNotFound, "error": "Thumbnail error not found"}
+	ErrMergeInvalid     = gin.H{"code": http.StatusBadRequest, "error": "Album can't be merged into itself or its sub albums"}
+	ErrLinkLocked       = gin.H{"code": http.StatusUnauthorized, "error": "Password required"}
+	ErrNotInAlbum       = gin.H{"code": http.StatusNotFound, "error": "Photo not found in album"}
 )
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// jsonFields represents the fields of a JSON response selected with the "fields" query parameter,
+// e.g. "UID,Title,Files.Hash". Nested fields are separated by dots, names are not case-sensitive.
+type jsonFields map[string]jsonFields
+
+// parseFields parses a comma separated list of field names.
+func parseFields(s string) jsonFields {
+	result := make(jsonFields)
+
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "" {
+			continue
+		}
+
+		f := result
+
+		for _, key := range strings.Split(name, ".") {
+			if key == "" {
+				break
+			}
+
+			if _, ok := f[key]; !ok {
+				f[key] = make(jsonFields)
+			}
+
+			f = f[key]
+		}
+	}
+
+	return result
+}
+
+// Select returns the data with the selected fields only. Fields are selected from each
+// element of arrays, values without selected sub fields are returned completely.
+func (f jsonFields) Select(data interface{}) interface{} {
+	if len(f) == 0 {
+		return data
+	}
+
+	switch d := data.(type) {
+	case []interface{}:
+		result := make([]interface{}, len(d))
+
+		for i, v := range d {
+			result[i] = f.Select(v)
+		}
+
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(f))
+
+		for key, v := range d {
+			if sub, ok := f[strings.ToLower(key)]; ok {
+				result[key] = sub.Select(v)
+			}
+		}
+
+		return result
+	default:
+		return data
+	}
+}
+
+// Apply returns the result with the selected fields only, or the result itself if no fields are selected.
+func (f jsonFields) Apply(result interface{}) (interface{}, error) {
+	if len(f) == 0 {
+		return result, nil
+	}
+
+	data, err := json.Marshal(result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var values interface{}
+
+	// Keep numbers as they are, e.g. large IDs.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	if err := d.Decode(&values); err != nil {
+		return nil, err
+	}
+
+	return f.Select(values), nil
+}
+
+// fieldsJSON renders the result as JSON with the fields selected by the "fields" query parameter, if any.
+func fieldsJSON(c *gin.Context, code int, result interface{}) {
+	values, err := parseFields(c.Query("fields")).Apply(result)
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+		return
+	}
+
+	c.JSON(code, values)
+}
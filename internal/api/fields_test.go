@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFields(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		result := parseFields(" UID, Title,Files.Hash,files.Name,,Files.")
+		assert.Equal(t, jsonFields{"uid": {}, "title": {}, "files": {"hash": {}, "name": {}}}, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, parseFields(""))
+	})
+}
+
+func TestJsonFields_Select(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{
+			"UID":   "pt9jtdre2lvl0yh7",
+			"Title": "Lake",
+			"Files": []interface{}{map[string]interface{}{"Hash": "2cad9168", "Name": "lake.jpg"}},
+		},
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		result := parseFields("uid,files.hash").Select(data)
+		expected := []interface{}{
+			map[string]interface{}{
+				"UID":   "pt9jtdre2lvl0yh7",
+				"Files": []interface{}{map[string]interface{}{"Hash": "2cad9168"}},
+			},
+		}
+		assert.Equal(t, expected, result)
+	})
+	t.Run("complete value", func(t *testing.T) {
+		result := parseFields("Files").Select(data[0])
+		assert.Equal(t, map[string]interface{}{"Files": data[0].(map[string]interface{})["Files"]}, result)
+	})
+	t.Run("no fields", func(t *testing.T) {
+		assert.Equal(t, data, jsonFields{}.Select(data))
+	})
+}
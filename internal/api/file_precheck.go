@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Maximum number of files per precheck request.
+const precheckMaxFiles = 1000
+
+// POST /api/v1/files/precheck
+//
+// Returns which of the given files already exist in the library, so that upload clients can skip them.
+// Files are matched by SHA1 hash and, if a size is given, by size in bytes.
+//
+// The route is registered as /files/:uid because static and wildcard segments at the same
+// position conflict with POST /api/v1/files/:uid/link.
+func FilePrecheck(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/files/:uid", func(c *gin.Context) {
+		if c.Param("uid") != "precheck" {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			return
+		}
+
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.FilePrecheck
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Files) > precheckMaxFiles {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Too many files"})
+			return
+		}
+
+		hashes := make([]string, len(f.Files))
+
+		for i := range f.Files {
+			f.Files[i].Hash = strings.ToLower(f.Files[i].Hash)
+			hashes[i] = f.Files[i].Hash
+		}
+
+		found, err := query.FileHashSizes(hashes)
+
+		if err != nil {
+			log.Errorf("precheck: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		sizes := make(map[string]map[int64]bool, len(found))
+
+		for _, r := range found {
+			if sizes[r.FileHash] == nil {
+				sizes[r.FileHash] = make(map[int64]bool)
+			}
+
+			sizes[r.FileHash][r.FileSize] = true
+		}
+
+		existing := []form.FilePrecheckItem{}
+		missing := []form.FilePrecheckItem{}
+
+		for _, file := range f.Files {
+			if s, ok := sizes[file.Hash]; ok && (file.Size == 0 || s[file.Size]) {
+				existing = append(existing, file)
+			} else {
+				missing = append(missing, file)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Existing": existing, "Missing": missing})
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestFilePrecheck(t *testing.T) {
+	t.Run("existing and missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		FilePrecheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/files/precheck", `{"Files": [
+			{"Hash": "2CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818", "Size": 4278906},
+			{"Hash": "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"},
+			{"Hash": "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "Size": 1},
+			{"Hash": "xxx", "Size": 100}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "Existing.#").Int())
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "Missing.#").Int())
+		assert.Equal(t, "xxx", gjson.Get(r.Body.String(), "Missing.1.Hash").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		FilePrecheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/files/precheck", `{"Files": "xxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		FilePrecheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/files/xxx", `{"Files": []}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -46,7 +46,8 @@ func TestLinkFile(t *testing.T) {
 
 		link := label.Links[0]
 
-		assert.Equal(t, "foobar123", link.LinkPassword)
+		assert.True(t, link.HasPassword)
+		assert.Empty(t, link.LinkPassword)
 		assert.Nil(t, link.LinkExpires)
 		assert.False(t, link.CanComment)
 		assert.True(t, link.CanEdit)
@@ -82,6 +82,14 @@ func GetGeo(router *gin.RouterGroup, conf *config.Config) {
 				props["Favorite"] = true
 			}
 
+			if p.PhotoAltitude != 0 {
+				props["Altitude"] = p.PhotoAltitude
+			}
+
+			if p.PhotoDirection != 0 {
+				props["Direction"] = p.PhotoDirection
+			}
+
 			feat := geojson.NewPointFeature([]float64{p.Lng(), p.Lat()})
 			feat.ID = p.ID
 			feat.Properties = props
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/heatmap"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// heatmapVersionTTL is the time the heatmap version is cached, so that tile requests don't
+// scan all photos every time.
+const heatmapVersionTTL = 30 * time.Second
+
+var heatmapVersion = struct {
+	sync.Mutex
+	value   string
+	updated time.Time
+}{}
+
+// currentHeatmapVersion returns the current version of photo locations and removes outdated tiles when it changes.
+func currentHeatmapVersion(conf *config.Config) (string, error) {
+	heatmapVersion.Lock()
+	defer heatmapVersion.Unlock()
+
+	if heatmapVersion.value != "" && time.Since(heatmapVersion.updated) < heatmapVersionTTL {
+		return heatmapVersion.value, nil
+	}
+
+	version, err := query.GeoHeatmapVersion()
+
+	if err != nil {
+		return "", err
+	}
+
+	if version != heatmapVersion.value {
+		if n, err := heatmap.Cleanup(conf.CachePath(), version); err != nil {
+			log.Warnf("heatmap: %s", err)
+		} else if n > 0 {
+			log.Debugf("heatmap: removed %d outdated tile versions", n)
+		}
+	}
+
+	heatmapVersion.value = version
+	heatmapVersion.updated = time.Now()
+
+	return version, nil
+}
+
+// GET /api/v1/geo/heatmap/:z/:x/:y.png
+//
+// Returns a transparent PNG map tile showing the density of public photo locations.
+//
+// Parameters:
+//   z: int Zoom level
+//   x: int Tile column
+//   y: int Tile row, optionally with .png extension
+//   t: string Preview token (query)
+func GetGeoHeatmap(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/geo/heatmap/:z/:x/:y", func(c *gin.Context) {
+		if InvalidToken(c, conf) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrPermissionDenied)
+			return
+		}
+
+		tile, err := heatmap.NewTile(c.Param("z"), c.Param("x"), c.Param("y"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid tile"})
+			return
+		}
+
+		version, err := currentHeatmapVersion(conf)
+
+		if err != nil {
+			log.Errorf("heatmap: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		fileName := tile.FileName(conf.CachePath(), version)
+
+		c.Header("Cache-Control", "private, max-age=300")
+
+		if fs.FileExists(fileName) {
+			c.File(fileName)
+			return
+		}
+
+		start := time.Now()
+
+		latMin, latMax, lngMin, lngMax := tile.Bounds(heatmap.Radius)
+		cells, err := query.GeoHeatmap(latMin, latMax, lngMin, lngMax, tile.Step())
+
+		if err != nil {
+			log.Errorf("heatmap: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		points := make([]heatmap.Point, len(cells))
+
+		for i, cell := range cells {
+			points[i] = heatmap.Point{Lat: cell.Lat, Lng: cell.Lng, Weight: cell.Count}
+		}
+
+		data, err := heatmap.Encode(heatmap.Render(tile, points))
+
+		if err != nil {
+			log.Errorf("heatmap: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		if err := heatmap.Save(data, fileName); err != nil {
+			log.Warnf("heatmap: %s", err)
+		}
+
+		log.Debugf("heatmap: rendered tile %s with %d cells in %s", tile.String(), len(cells), time.Since(start))
+
+		c.Data(http.StatusOK, "image/png", data)
+	})
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGeoHeatmap(t *testing.T) {
+	t.Run("world tile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGeoHeatmap(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/geo/heatmap/0/0/0.png?t="+conf.PreviewToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/png", r.Header().Get("Content-Type"))
+		assert.Equal(t, "\x89PNG", r.Body.String()[:4])
+	})
+	t.Run("cached tile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGeoHeatmap(router, conf)
+		PerformRequest(app, "GET", "/api/v1/geo/heatmap/1/1/0.png?t="+conf.PreviewToken())
+		r := PerformRequest(app, "GET", "/api/v1/geo/heatmap/1/1/0.png?t="+conf.PreviewToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "\x89PNG", r.Body.String()[:4])
+	})
+	t.Run("invalid tile", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGeoHeatmap(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/geo/heatmap/1/5/0.png?t="+conf.PreviewToken())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGeoHeatmap(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/geo/heatmap/0/0/0.png?t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// geometryError aborts the request with a status code matching the geometry error.
+func geometryError(c *gin.Context, err error) {
+	switch err {
+	case photoprism.ErrGeometryReviewed:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "geometry: "))})
+	case photoprism.ErrGeometryFile:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "geometry: "))})
+	default:
+		log.Errorf("geometry: %s", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+	}
+}
+
+// GET /api/v1/geometry
+//
+// Returns photos that are probably rotated sideways or have a tilted horizon, including the suggested fixes.
+//
+// Parameters:
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetGeometryReview(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/geometry", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.GeometryReview
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.GeometryReview(f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/geometry/:uid/apply
+//
+// Rotates and straightens the original as suggested and indexes it again.
+// The unchanged original is kept as backup in the hidden sidecar folder.
+//
+// Parameters:
+//   uid: string Fix UID as returned by the API
+func ApplyGeometryFix(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/geometry/:uid/apply", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		fix, err := query.GeometryFixByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			return
+		}
+
+		if err := service.Geometry().Apply(&fix); err != nil {
+			geometryError(c, err)
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, fix.PhotoUID, c)
+
+		event.Success("photo fixed")
+
+		c.JSON(http.StatusOK, fix)
+	})
+}
+
+// POST /api/v1/geometry/:uid/dismiss
+//
+// Removes a suggested fix from review without changing the photo.
+//
+// Parameters:
+//   uid: string Fix UID as returned by the API
+func DismissGeometryFix(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/geometry/:uid/dismiss", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		fix, err := query.GeometryFixByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			return
+		} else if !fix.Pending() {
+			geometryError(c, photoprism.ErrGeometryReviewed)
+			return
+		}
+
+		if err := fix.SetStatus(entity.GeometryDismissed); err != nil {
+			geometryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, fix)
+	})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetGeometryReview(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGeometryReview(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/geometry?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGeometryReview(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/geometry")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDismissGeometryFix(t *testing.T) {
+	fix := entity.NewGeometryFix("pt9jtdre2lvl0yh7", "ft8es39w45bnlqdz", 90, 0, 0.9)
+
+	if err := fix.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("pending", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DismissGeometryFix(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/geometry/"+fix.FixUID+"/dismiss")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.GeometryDismissed, gjson.Get(r.Body.String(), "Status").String())
+	})
+	t.Run("already dismissed", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DismissGeometryFix(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/geometry/"+fix.FixUID+"/dismiss")
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DismissGeometryFix(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/geometry/ox0000000000000/dismiss")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestApplyGeometryFix(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ApplyGeometryFix(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/geometry/ox0000000000000/apply")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
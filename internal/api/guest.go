@@ -0,0 +1,268 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Share link visitors may create up to guestCreateLimit guest accounts per client ip and up to
+// guestCreateLinkLimit accounts per link within guestCreatePeriod.
+const (
+	guestCreateLimit     = 5
+	guestCreateLinkLimit = 100
+	guestCreatePeriod    = time.Hour
+)
+
+// guestSession returns the guest account of the current session, if any.
+func guestSession(c *gin.Context) (guest entity.Guest, ok bool) {
+	data, ok := service.Session().Get(c.GetHeader("X-Session-Token"))
+
+	if !ok || sessionValue(data, "Role") != config.RoleGuest {
+		return guest, false
+	}
+
+	guest, err := query.GuestByUID(sessionValue(data, "GuestUID"))
+
+	if err != nil || guest.Expired() {
+		return guest, false
+	}
+
+	return guest, true
+}
+
+// POST /api/v1/s/:token/guest
+//
+// Creates a guest account with a bookmarkable login for a share link visitor. Repeated wrong passwords
+// and new accounts are throttled per client ip and link.
+//
+// Parameters:
+//   token: string Share link token
+func CreateGuest(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/s/:token/guest", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.Guest
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if sharePasswordThrottled(c, link) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrTooManyRequests)
+			return
+		} else if !checkSharePassword(c, link, f.Password) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
+			return
+		} else if guestCreateLimited(c, link) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrTooManyRequests)
+			return
+		}
+
+		guest := entity.NewGuest(link, f.Name)
+
+		if err := guest.Create(link); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("guest: created account for %s", txt.Quote(guest.GuestName))
+
+		event.Publish("guests.created", event.Data{"uid": guest.GuestUID, "name": guest.GuestName})
+
+		c.JSON(http.StatusOK, guest)
+	})
+}
+
+// guestCreateLimited counts a new guest account for the client and the link, and returns true if
+// either limit is exceeded.
+func guestCreateLimited(c *gin.Context, link entity.Link) bool {
+	clientLimited := rateLimited("guest-create:"+clientIP(c), guestCreateLimit, guestCreatePeriod)
+	linkLimited := rateLimited("guest-create:"+link.LinkToken, guestCreateLinkLimit, guestCreatePeriod)
+
+	if clientLimited || linkLimited {
+		log.Warnf("guest: too many accounts created for %s from %s", link.LinkToken, clientIP(c))
+		return true
+	}
+
+	return false
+}
+
+// POST /api/v1/guest/session
+//
+// Creates a guest session using the login token of a guest account.
+func CreateGuestSession(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/guest/session", func(c *gin.Context) {
+		var f form.GuestLogin
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		guest, err := query.GuestByLoginToken(f.Token)
+
+		if err != nil || guest.Expired() {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid login"})
+			return
+		}
+
+		shares, err := query.GuestShares(guest.GuestUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := guest.Login(); err != nil {
+			log.Errorf("guest: %s", err)
+		}
+
+		user := gin.H{"ID": guest.ID, "FirstName": guest.GuestName, "LastName": "", "Role": config.RoleGuest, "GuestUID": guest.GuestUID}
+
+		token := service.Session().Create(user)
+
+		c.Header("X-Session-Token", token)
+
+		c.JSON(http.StatusOK, gin.H{"token": token, "user": user, "guest": guest, "shares": shares, "config": conf.PublicClientConfig()})
+	})
+}
+
+// GET /api/v1/guest
+//
+// Returns the guest account of the current session and the share links it has access to.
+func GetGuest(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/guest", func(c *gin.Context) {
+		guest, ok := guestSession(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		shares, err := query.GuestShares(guest.GuestUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"guest": guest, "shares": shares})
+	})
+}
+
+// GET /api/v1/guests
+func GetGuests(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/guests", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		guests, err := query.Guests()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, guests)
+	})
+}
+
+// POST /api/v1/guests/:uid/upgrade
+//
+// Turns a guest account into a permanent account with access to additional albums.
+//
+// Parameters:
+//   uid: string Guest UID
+func UpgradeGuest(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/guests/:uid/upgrade", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		guest, err := query.GuestByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrGuestNotFound)
+			return
+		}
+
+		var f form.GuestUpgrade
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		for _, uid := range f.Albums {
+			if _, err := query.AlbumByUID(uid); err != nil {
+				c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+				return
+			}
+		}
+
+		for _, uid := range f.Albums {
+			if err := guest.AddAlbum(uid); err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		if err := guest.Upgrade(); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("guest: upgraded account of %s", txt.Quote(guest.GuestName))
+
+		event.Success("guest account upgraded")
+
+		c.JSON(http.StatusOK, guest)
+	})
+}
+
+// DELETE /api/v1/guests/:uid
+//
+// Parameters:
+//   uid: string Guest UID
+func DeleteGuest(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/guests/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		guest, err := query.GuestByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrGuestNotFound)
+			return
+		}
+
+		if err := guest.Delete(); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("guest account deleted")
+
+		c.JSON(http.StatusOK, guest)
+	})
+}
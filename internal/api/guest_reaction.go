@@ -0,0 +1,255 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Visitors may leave up to guestReactionLimit reactions per guestReactionPeriod.
+const (
+	guestReactionLimit  = 10
+	guestReactionPeriod = 10 * time.Minute
+)
+
+// shareLink returns the share link for the token or vanity slug in the request or false if it is invalid, expired,
+// or restricted to other networks or sites.
+func shareLink(c *gin.Context) (link entity.Link, ok bool) {
+	return shareLinkByToken(c, c.Param("token"))
+}
+
+// shareLinkByToken returns the share link for the token or vanity slug like shareLink, e.g. if it was
+// sent as query parameter.
+func shareLinkByToken(c *gin.Context, token string) (link entity.Link, ok bool) {
+	link, err := query.LinkByToken(token)
+
+	if err != nil {
+		link, err = query.LinkBySlug(token)
+	}
+
+	if err != nil || link.Expired() {
+		return link, false
+	}
+
+	if !link.AllowsIP(clientIP(c)) {
+		log.Warnf("share: %s denied for %s", link.LinkToken, clientIP(c))
+		return link, false
+	} else if origin := requestOrigin(c); !link.AllowsOrigin(origin) {
+		log.Warnf("share: %s denied for %s", link.LinkToken, txt.Quote(origin))
+		return link, false
+	}
+
+	return link, true
+}
+
+// GET /api/v1/s/:token/reactions
+//
+// Parameters:
+//   token: string Share link token
+func GetGuestReactions(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/reactions", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+			return
+		}
+
+		results, err := query.GuestReactions(link.ShareUID, false)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/s/:token/reactions
+//
+// Parameters:
+//   token: string Share link token
+func AddGuestReaction(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/s/:token/reactions", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok || !link.CanComment {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+			return
+		}
+
+		if rateLimited("guest-reaction:"+clientIP(c), guestReactionLimit, guestReactionPeriod) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrTooManyRequests)
+			return
+		}
+
+		var f form.GuestReaction
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if !query.LinkSharesPhoto(link, f.PhotoUID) {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		m := entity.NewGuestReaction(link, f.PhotoUID, f.Name, f.Emoji, f.Note)
+		m.GuestAddr = clientIP(c)
+
+		if err := m.Create(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.PublishEntities("reactions", string(EntityCreated), []entity.GuestReaction{*m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GET /api/v1/albums/:uid/reactions
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumReactions(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/reactions", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		results, err := query.GuestReactions(a.AlbumUID, true)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// guestReactionByParam returns the reaction with the ID in the request path.
+func guestReactionByParam(c *gin.Context) (m entity.GuestReaction, err error) {
+	id, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil {
+		return m, err
+	}
+
+	return query.GuestReactionByID(uint(id))
+}
+
+// POST /api/v1/reactions/:id/hide
+//
+// Parameters:
+//   id: int Reaction ID
+func HideGuestReaction(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/reactions/:id/hide", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := guestReactionByParam(c)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrReactionNotFound)
+			return
+		}
+
+		if err := m.Update("Hidden", true); err != nil {
+			log.Errorf("reaction: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.PublishEntities("reactions", string(EntityUpdated), []entity.GuestReaction{m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/reactions/:id/hide
+//
+// Parameters:
+//   id: int Reaction ID
+func ShowGuestReaction(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/reactions/:id/hide", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := guestReactionByParam(c)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrReactionNotFound)
+			return
+		}
+
+		if err := m.Update("Hidden", false); err != nil {
+			log.Errorf("reaction: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.PublishEntities("reactions", string(EntityUpdated), []entity.GuestReaction{m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/reactions/:id
+//
+// Parameters:
+//   id: int Reaction ID
+func DeleteGuestReaction(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/reactions/:id", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := guestReactionByParam(c)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrReactionNotFound)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("reaction: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.PublishEntities("reactions", string(EntityDeleted), []entity.GuestReaction{m})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAddGuestReaction(t *testing.T) {
+	link := entity.NewLink("", true, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddGuestReaction(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/reactions", `{"PhotoUID": "pt9jtdre2lvl0yh7", "Name": "Grandma", "Emoji": "❤️"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Grandma", gjson.Get(r.Body.String(), "Name").String())
+
+		GetGuestReactions(router, conf)
+		r = PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/reactions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("photo not shared", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddGuestReaction(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/reactions", `{"PhotoUID": "pt9jtdre2lvl0y11", "Emoji": "❤️"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddGuestReaction(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/xxx/reactions", `{"PhotoUID": "pt9jtdre2lvl0yh7", "Emoji": "❤️"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "Link not found", gjson.Get(r.Body.String(), "error").String())
+	})
+}
+
+func TestShareLink(t *testing.T) {
+	link := entity.NewLink("", true, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+	link.LinkIPs = "10.0.0.0/8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, conf := NewApiTest()
+	GetGuestReactions(router, conf)
+
+	request := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/s/"+link.LinkToken+"/reactions", nil)
+		req.RemoteAddr = remoteAddr
+
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("allowed network", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request("10.0.0.1:4711", "").Code)
+	})
+	t.Run("other network", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, request("203.0.113.7:4711", "").Code)
+	})
+	t.Run("spoofed forwarded for", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, request("203.0.113.7:4711", "10.0.0.1").Code)
+	})
+}
+
+func TestModerateGuestReaction(t *testing.T) {
+	link := entity.Link{LinkToken: "reactiontest", ShareUID: "at9lxuqxpogaaba8"}
+	m := entity.NewGuestReaction(link, "pt9jtdre2lvl0yh7", "Bob", "", "Great photo")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	uri := fmt.Sprintf("/api/v1/reactions/%d", m.ID)
+
+	t.Run("hide", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		HideGuestReaction(router, conf)
+		r := PerformRequest(app, "POST", uri+"/hide")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("album reactions", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetAlbumReactions(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/reactions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "Great photo")
+	})
+	t.Run("show", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ShowGuestReaction(router, conf)
+		r := PerformRequest(app, "DELETE", uri+"/hide")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("delete", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DeleteGuestReaction(router, conf)
+		r := PerformRequest(app, "DELETE", uri)
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequest(app, "DELETE", uri)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateGuest(t *testing.T) {
+	link := entity.NewLink("secret", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/guest", `{"Name": "Grandpa", "Password": "secret"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Grandpa", gjson.Get(r.Body.String(), "Name").String())
+		assert.Len(t, gjson.Get(r.Body.String(), "LoginToken").String(), 16)
+	})
+	t.Run("invalid password", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/guest", `{"Name": "Grandpa", "Password": "xxx"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("too many wrong passwords", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuest(router, conf)
+		request := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/v1/s/"+link.LinkToken+"/guest", strings.NewReader(body))
+			req.RemoteAddr = "10.20.0.1:4711"
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			return w
+		}
+
+		for i := 0; i < sharePasswordLimit; i++ {
+			r := request(`{"Name": "Grandpa", "Password": "xxx"}`)
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		}
+
+		r := request(`{"Name": "Grandpa", "Password": "secret"}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+	t.Run("too many accounts", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuest(router, conf)
+		request := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/api/v1/s/"+link.LinkToken+"/guest", strings.NewReader(`{"Name": "Grandpa", "Password": "secret"}`))
+			req.RemoteAddr = "10.30.0.1:4711"
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			return w
+		}
+
+		for i := 0; i < guestCreateLimit; i++ {
+			r := request()
+			assert.Equal(t, http.StatusOK, r.Code)
+		}
+
+		r := request()
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+	t.Run("name missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/guest", `{"Password": "secret"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/xxx/guest", `{"Name": "Grandpa"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCreateGuestSession(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuestSession(router, conf)
+		GetGuest(router, conf)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/guest/session", `{"Token": "4jxf3jfn2k9a8b7c"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, config.RoleGuest, gjson.Get(r.Body.String(), "user.Role").String())
+		assert.Equal(t, "1jxf3jfn2k", gjson.Get(r.Body.String(), "shares.0.Token").String())
+
+		token := gjson.Get(r.Body.String(), "token").String()
+
+		req, _ := http.NewRequest("GET", "/api/v1/guest", nil)
+		req.Header.Set("X-Session-Token", token)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Grandma", gjson.Get(w.Body.String(), "guest.Name").String())
+	})
+	t.Run("invalid login", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateGuestSession(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/guest/session", `{"Token": "xxx"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestGetGuest(t *testing.T) {
+	t.Run("no session", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetGuest(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/guest")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestGetGuests(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetGuests(router, conf)
+	r := PerformRequest(app, "GET", "/api/v1/guests")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+}
+
+func TestUpgradeGuest(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		link := entity.LinkFixtures["1jxf3jfn2k"]
+		guest := entity.NewGuest(link, "Cousin")
+
+		if err := guest.Create(link); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		UpgradeGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/guests/"+guest.GuestUID+"/upgrade", `{"Albums": ["at9lxuqxpogaaba8"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Upgraded").Bool())
+
+		DeleteGuest(router, conf)
+		r = PerformRequest(app, "DELETE", "/api/v1/guests/"+guest.GuestUID)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpgradeGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/guests/gt9k8pkzkqrv8hlw/upgrade", `{"Albums": ["at9lxuqxpogxxxxx"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("guest not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpgradeGuest(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/guests/xxx/upgrade", `{"Albums": []}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestSessionValue(t *testing.T) {
+	assert.Equal(t, config.RoleGuest, sessionValue(gin.H{"Role": config.RoleGuest}, "Role"))
+	assert.Equal(t, config.RoleAdmin, sessionValue(map[string]interface{}{"Role": config.RoleAdmin}, "Role"))
+	assert.Equal(t, "", sessionValue(gin.H{"ID": 1}, "ID"))
+	assert.Equal(t, "", sessionValue(nil, "Role"))
+}
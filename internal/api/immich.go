@@ -0,0 +1,285 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/ingest"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// The Immich compatibility API implements the subset of the Immich server API used by its mobile app for
+// backups, so that the app can upload photos without a custom plugin. It is served at /immich/api, which
+// must be entered as server endpoint url in the app. Apps authenticate with the password of this server,
+// the access token is then sent in the Authorization or x-api-key header.
+//
+//   GET  /server-info/ping         returns {"res": "pong"}
+//   GET  /server-info/version      returns the implemented API version
+//   POST /auth/login               {"email": "", "password": ""} returns an access token
+//   POST /asset/bulk-upload-check  {"assets": [{"id": "", "checksum": "base64 sha1"}]} returns the files to upload
+//   POST /asset/upload             multipart form with the file in "assetData"
+//
+// Uploaded files are imported by the ingest worker. Favorites and the creation date shown in the app are
+// applied to the imported photos, the date only if the file doesn't contain one.
+
+// immichVersion is the Immich server version whose API subset is implemented.
+var immichVersion = gin.H{"major": 1, "minor": 91, "patch": 0}
+
+// ImmichAuth passes the access token sent by Immich clients on as session token, so that the default
+// authorization and policy checks apply.
+func ImmichAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("X-Session-Token") == "" {
+			if token := c.GetHeader("x-api-key"); token != "" {
+				c.Request.Header.Set("X-Session-Token", token)
+			} else if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+				c.Request.Header.Set("X-Session-Token", strings.TrimPrefix(auth, "Bearer "))
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// GET /immich/api/server-info/ping
+func ImmichPing(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/server-info/ping", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"res": "pong"})
+	})
+}
+
+// GET /immich/api/server-info/version
+func ImmichVersion(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/server-info/version", func(c *gin.Context) {
+		c.JSON(http.StatusOK, immichVersion)
+	})
+}
+
+// POST /immich/api/auth/login
+//
+// Returns an access token if the password is correct, the email address is ignored.
+func ImmichLogin(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/auth/login", func(c *gin.Context) {
+		var f form.ImmichLogin
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if !conf.CheckPassword(f.Password) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
+			return
+		}
+
+		token, user := newAdminSession()
+
+		c.JSON(http.StatusCreated, gin.H{
+			"accessToken":          token,
+			"userId":               user["ID"],
+			"userEmail":            user["Email"],
+			"name":                 user["FirstName"],
+			"isAdmin":              true,
+			"profileImagePath":     "",
+			"shouldChangePassword": false,
+		})
+	})
+}
+
+// POST /immich/api/asset/bulk-upload-check
+//
+// Rejects files that already exist, so that the app only uploads new files.
+func ImmichUploadCheck(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/asset/bulk-upload-check", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		var f form.ImmichUploadCheck
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Assets) > backupMaxHashes {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Too many assets"})
+			return
+		}
+
+		hashes := make([]string, 0, len(f.Assets))
+
+		for _, a := range f.Assets {
+			if hash := ingest.Hash(a.Checksum); hash != "" {
+				hashes = append(hashes, hash)
+			}
+		}
+
+		existing, err := query.ExistingHashes(hashes)
+
+		if err != nil {
+			log.Errorf("immich: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		found := make(map[string]bool, len(existing))
+
+		for _, h := range existing {
+			found[h] = true
+		}
+
+		results := make([]gin.H, len(f.Assets))
+
+		for i, a := range f.Assets {
+			if found[ingest.Hash(a.Checksum)] {
+				results[i] = gin.H{"id": a.ID, "action": "reject", "reason": "duplicate"}
+			} else {
+				results[i] = gin.H{"id": a.ID, "action": "accept"}
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"results": results})
+	})
+}
+
+// POST /immich/api/asset/upload
+//
+// Saves an uploaded file in the ingest folder. Files that already exist are not saved again and returned
+// with "duplicate": true.
+func ImmichUpload(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/asset/upload", func(c *gin.Context) {
+		if !backupEnabled(c, conf) {
+			return
+		}
+
+		device, ok := backupDevice(c)
+
+		if !ok {
+			return
+		}
+
+		if err := photoprism.CheckDiskSpace(conf, c.Request.ContentLength, conf.ImportPath()); err != nil {
+			abortDiskFull(c, err)
+			return
+		}
+
+		var f form.ImmichUpload
+
+		if err := c.ShouldBindWith(&f, binding.FormMultipart); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		file, err := c.FormFile("assetData")
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if conf.OriginalsLimit() > 0 && file.Size > conf.OriginalsLimit() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "File too large"})
+			return
+		}
+
+		name := filepath.Base(file.Filename)
+
+		if name == "." || name == string(filepath.Separator) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
+			return
+		}
+
+		// Save the file in a hidden folder first, so that incomplete files are never imported.
+		tmpPath := filepath.Join(conf.BackupPath(), "immich-"+rnd.UUID())
+		tmpName := filepath.Join(tmpPath, name)
+
+		defer os.RemoveAll(tmpPath)
+
+		if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+			log.Errorf("immich: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		if err := c.SaveUploadedFile(file, tmpName); err != nil {
+			log.Errorf("immich: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		hash := fs.Hash(tmpName)
+
+		if existing, err := query.FileByHash(hash); err == nil {
+			c.JSON(http.StatusOK, gin.H{"id": existing.PhotoUID, "duplicate": true})
+			return
+		}
+
+		// Suspicious files are moved to quarantine instead of being imported, see upload-quarantine.
+		if quarantined, err := photoprism.VerifyUpload(conf, tmpName, entity.QuarantineUpload, sessionUserID(c)); err != nil {
+			log.Errorf("immich: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if quarantined {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "File moved to quarantine"})
+			return
+		}
+
+		if !conf.UploadNSFW() {
+			if labels, err := service.NsfwDetector().File(tmpName); err != nil {
+				log.Debug(err)
+			} else if !labels.IsSafe() {
+				log.Infof("nsfw: %s might be offensive", txt.Quote(name))
+				c.AbortWithStatusJSON(http.StatusForbidden, ErrUploadNSFW)
+				return
+			}
+		}
+
+		takenAt := f.TakenAt()
+
+		// Files without Exif data are dated by their modification time when indexed.
+		if !takenAt.IsZero() {
+			if err := os.Chtimes(tmpName, takenAt, takenAt); err != nil {
+				log.Warnf("immich: %s", err)
+			}
+		}
+
+		destPath := filepath.Join(conf.IngestPath(), ingest.AppImmich, hash)
+
+		if err := os.MkdirAll(destPath, os.ModePerm); err != nil {
+			log.Errorf("immich: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		meta := ingest.Meta{App: ingest.AppImmich, Name: name, Device: device, TakenAt: takenAt, Favorite: f.IsFavorite}
+
+		if err := service.Ingest().Add(hash, meta); err != nil {
+			log.Errorf("immich: %s", err)
+		}
+
+		if err := os.Rename(tmpName, filepath.Join(destPath, name)); err != nil {
+			log.Errorf("immich: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		log.Infof("immich: received %s", txt.Quote(name))
+
+		countUsage(conf, entity.UsageUpload)
+
+		c.JSON(http.StatusCreated, gin.H{"id": hash, "duplicate": false})
+	})
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestImmichAuth(t *testing.T) {
+	handler := func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetHeader("X-Session-Token"))
+	}
+
+	t.Run("api key", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		router.GET("/immich", ImmichAuth(), handler)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/immich", "", map[string]string{"x-api-key": "abc"})
+		assert.Equal(t, "abc", r.Body.String())
+	})
+	t.Run("bearer token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		router.GET("/immich", ImmichAuth(), handler)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/immich", "", map[string]string{"Authorization": "Bearer xyz"})
+		assert.Equal(t, "xyz", r.Body.String())
+	})
+	t.Run("session token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		router.GET("/immich", ImmichAuth(), handler)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/immich", "", map[string]string{"X-Session-Token": "123", "x-api-key": "abc"})
+		assert.Equal(t, "123", r.Body.String())
+	})
+}
+
+func TestImmichPing(t *testing.T) {
+	app, router, conf := NewApiTest()
+	ImmichPing(router, conf)
+	r := PerformRequest(app, "GET", "/api/v1/server-info/ping")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "pong", gjson.Get(r.Body.String(), "res").String())
+}
+
+func TestImmichVersion(t *testing.T) {
+	app, router, conf := NewApiTest()
+	ImmichVersion(router, conf)
+	r := PerformRequest(app, "GET", "/api/v1/server-info/version")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "major").Int())
+}
+
+func TestImmichLogin(t *testing.T) {
+	t.Run("invalid password", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImmichLogin(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/auth/login", `{"email": "admin@example.com", "password": "xxx"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImmichLogin(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/auth/login", `{"password": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestImmichUploadCheck(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImmichUploadCheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/asset/bulk-upload-check", `{"assets": [{"id": "a", "checksum": "LK2RaPpqzFxcKWXd9uxGXKQv2Bg="}, {"id": "b", "checksum": "3cad9168fa6acc5c5c2965ddf6ec465ca42fd999"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "a", gjson.Get(r.Body.String(), "results.0.id").String())
+		assert.Equal(t, "reject", gjson.Get(r.Body.String(), "results.0.action").String())
+		assert.Equal(t, "duplicate", gjson.Get(r.Body.String(), "results.0.reason").String())
+		assert.Equal(t, "b", gjson.Get(r.Body.String(), "results.1.id").String())
+		assert.Equal(t, "accept", gjson.Get(r.Body.String(), "results.1.action").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImmichUploadCheck(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/asset/bulk-upload-check", `{"assets": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestImmichUpload(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImmichUpload(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/asset/upload")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
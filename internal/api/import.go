@@ -19,6 +19,8 @@ import (
 )
 
 // POST /api/v1/import*
+//
+// Imports files from the import folder.
 func StartImport(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/import/*path", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -53,6 +55,11 @@ func StartImport(router *gin.RouterGroup, conf *config.Config) {
 
 		path = filepath.Clean(path)
 
+		if err := photoprism.CheckDiskSpace(conf, photoprism.PathSize(path)); err != nil {
+			abortDiskFull(c, err)
+			return
+		}
+
 		imp := service.Import()
 
 		var opt photoprism.ImportOptions
@@ -65,6 +72,8 @@ func StartImport(router *gin.RouterGroup, conf *config.Config) {
 			opt = photoprism.ImportOptionsCopy(path)
 		}
 
+		opt.Uploader = sessionUserID(c)
+
 		imp.Start(opt)
 
 		if subPath != "" && path != conf.ImportPath() && fs.IsEmpty(path) {
@@ -87,6 +96,11 @@ func StartImport(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// abortDiskFull aborts the request with 507 Insufficient Storage, see photoprism.CheckDiskSpace.
+func abortDiskFull(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusInsufficientStorage, gin.H{"code": http.StatusInsufficientStorage, "error": txt.UcFirst(err.Error())})
+}
+
 // DELETE /api/v1/import
 func CancelImport(router *gin.RouterGroup, conf *config.Config) {
 	router.DELETE("/import", func(c *gin.Context) {
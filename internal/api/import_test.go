@@ -18,3 +18,12 @@ func TestCancelImport(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestImportUrl(t *testing.T) {
+	t.Run("import from url disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ImportUrl(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/import-url", `{"url": "https://example.com/elephants.jpg"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/import-url
+//
+// Downloads a remote image or video and imports it, e.g. to save photos shared via links.
+// Hosts must be allowed with the import-url-hosts config option.
+func ImportUrl(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/import-url", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() || !conf.Settings().Features.Import {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		importUrl(c, conf)
+	})
+}
+
+// importUrl downloads and imports the file in the request, see ImportUrl.
+func importUrl(c *gin.Context, conf *config.Config) {
+	hosts := conf.ImportUrlHosts()
+
+	if len(hosts) == 0 {
+		c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+		return
+	}
+
+	var f form.ImportUrl
+
+	if err := c.BindJSON(&f); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+		return
+	}
+
+	start := time.Now()
+	dir := filepath.Join(conf.ImportPath(), "upload", rnd.Token(8))
+
+	fileName, err := photoprism.DownloadMediaFile(f.Url, dir, photoprism.DownloadOptions{
+		Hosts:    hosts,
+		MaxSize:  conf.OriginalsLimit(),
+		Checksum: f.Checksum,
+		DiskCheck: func(size int64) error {
+			return photoprism.CheckDiskSpace(conf, size, conf.ImportPath())
+		},
+	})
+
+	if _, ok := err.(photoprism.DiskSpaceError); ok {
+		removeEmptyDir(dir)
+		abortDiskFull(c, err)
+		return
+	} else if err != nil {
+		log.Error(err)
+		removeEmptyDir(dir)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+		return
+	}
+
+	defer removeEmptyDir(dir)
+
+	// Suspicious files are moved to quarantine instead of being imported, see upload-quarantine.
+	if quarantined, err := photoprism.VerifyUpload(conf, fileName, entity.QuarantineUrl, sessionUserID(c)); err != nil {
+		log.Errorf("import: %s", err)
+
+		if err := os.Remove(fileName); err != nil {
+			log.Errorf("import: could not delete %s", txt.Quote(filepath.Base(fileName)))
+		}
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+		return
+	} else if quarantined {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "File moved to quarantine"})
+		return
+	}
+
+	if !conf.UploadNSFW() {
+		if labels, err := service.NsfwDetector().File(fileName); err != nil {
+			log.Debug(err)
+		} else if !labels.IsSafe() {
+			log.Infof("nsfw: %s might be offensive", txt.Quote(fileName))
+
+			if err := os.Remove(fileName); err != nil {
+				log.Errorf("nsfw: could not delete %s", txt.Quote(fileName))
+			}
+
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrUploadNSFW)
+			return
+		}
+	}
+
+	hash := fs.Hash(fileName)
+
+	event.Info(fmt.Sprintf("importing %s", txt.Quote(filepath.Base(fileName))))
+
+	opt := photoprism.ImportOptionsMove(dir)
+
+	opt.Uploader = sessionUserID(c)
+
+	service.Import().Start(opt)
+
+	file, err := query.FileByHash(hash)
+
+	if err != nil {
+		log.Errorf("import: %s not imported", txt.Quote(filepath.Base(fileName)))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrImportFailed)
+		return
+	}
+
+	elapsed := int(time.Since(start).Seconds())
+
+	event.Publish("import.completed", event.Data{"path": dir, "seconds": elapsed})
+	event.Publish("index.completed", event.Data{"path": dir, "seconds": elapsed})
+
+	UpdateClientConfig(conf)
+
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("import completed in %d s", elapsed), "hash": hash, "photo": file.PhotoUID})
+}
+
+// removeEmptyDir deletes a directory if it is empty.
+func removeEmptyDir(dir string) {
+	if !fs.IsEmpty(dir) {
+		return
+	}
+
+	if err := os.Remove(dir); err != nil {
+		log.Errorf("import: could not delete empty folder %s: %s", txt.Quote(dir), err)
+	}
+}
@@ -90,3 +90,42 @@ func CancelIndexing(router *gin.RouterGroup, conf *config.Config) {
 		c.JSON(http.StatusOK, gin.H{"message": "indexing canceled"})
 	})
 }
+
+// POST /api/v1/index/pause
+func PauseIndexing(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/index/pause", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		ind := service.Index()
+
+		if err := ind.Pause(); err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Publish("index.paused", event.Data{})
+
+		c.JSON(http.StatusOK, gin.H{"message": "indexing paused"})
+	})
+}
+
+// POST /api/v1/index/resume
+func ResumeIndexing(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/index/resume", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		ind := service.Index()
+
+		ind.Resume()
+
+		event.Publish("index.resumed", event.Data{})
+
+		c.JSON(http.StatusOK, gin.H{"message": "indexing resumed"})
+	})
+}
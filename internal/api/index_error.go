@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// indexError returns the index error with the id in the request or aborts with status 404.
+func indexError(c *gin.Context) (result entity.IndexError, ok bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if err == nil {
+		result, err = query.IndexErrorByID(uint(id))
+	}
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrIndexErrNotFound)
+		return result, false
+	}
+
+	return result, true
+}
+
+// GET /api/v1/errors
+//
+// Returns files that failed to index, e.g. because of corrupt metadata, unsupported codecs, or crashed converters.
+//
+// Parameters:
+//   kind: string Error kind, e.g. metadata, codec, convert, thumbnail, or index
+//   ignored: bool Return ignored errors instead
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetIndexErrors(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/errors", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.IndexErrors
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.IndexErrors(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/errors/:id/retry
+//
+// Indexes the file again. The error is removed on success, otherwise it is updated.
+//
+// Parameters:
+//   id: int Error ID as returned by the API
+func RetryIndexError(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/errors/:id/retry", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := indexError(c)
+
+		if !ok {
+			return
+		}
+
+		res := service.Index().Retry(path.Join(conf.OriginalsPath(), m.FileName))
+
+		if res.Error != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(res.Error.Error())})
+			return
+		}
+
+		if res.PhotoUID != "" {
+			PublishPhotoEvent(EntityUpdated, res.PhotoUID, c)
+		}
+
+		event.Success("file indexed")
+
+		c.JSON(http.StatusOK, gin.H{"FileName": m.FileName, "Status": res.String()})
+	})
+}
+
+// POST /api/v1/errors/:id/ignore
+//
+// Hides the error from the list of unresolved errors, e.g. if the file is known to be broken.
+//
+// Parameters:
+//   id: int Error ID as returned by the API
+func IgnoreIndexError(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/errors/:id/ignore", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := indexError(c)
+
+		if !ok {
+			return
+		}
+
+		if err := m.Ignore(); err != nil {
+			log.Errorf("index: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIndexErrors(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetIndexErrors(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/errors?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetIndexErrors(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/errors")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRetryIndexError(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RetryIndexError(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/errors/999999/retry")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestIgnoreIndexError(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		IgnoreIndexError(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/errors/xxx/ignore")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
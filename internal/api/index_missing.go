@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/index/missing
+//
+// Returns files that can't be found, including quarantined files that are not flagged as missing yet.
+func GetMissingFiles(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/index/missing", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.MissingFiles
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		files, err := query.MissingFiles(f.Count, f.Offset, strings.Trim(f.Path, "/"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, files)
+	})
+}
+
+// POST /api/v1/index/missing/purge
+//
+// Flags all files that can't be found as missing without waiting for the grace period to end.
+func PurgeMissingFiles(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/index/missing/purge", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		files, photos, err := service.Purge().Start(photoprism.PurgeOptions{Force: true})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success(fmt.Sprintf("removed %d files and %d photos", len(files), len(photos)))
+
+		UpdateClientConfig(conf)
+
+		c.JSON(http.StatusOK, gin.H{"files": len(files), "photos": len(photos)})
+	})
+}
+
+// POST /api/v1/index/missing/relocate
+//
+// Changes the folder of files that can't be found if they exist in the new folder, for example
+// after a drive was mounted elsewhere.
+func RelocateMissingFiles(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/index/missing/relocate", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.RelocateFiles
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		from := strings.Trim(path.Clean("/"+f.From), "/")
+		to := strings.Trim(path.Clean("/"+f.To), "/")
+
+		if from == "" || from == to {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid folder"})
+			return
+		}
+
+		files, err := query.MissingFiles(10000, 0, from)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		relocated := 0
+
+		for _, file := range files {
+			fileName := path.Join(to, strings.TrimPrefix(file.FileName, from+"/"))
+
+			if !fs.FileExists(path.Join(conf.OriginalsPath(), fileName)) {
+				continue
+			}
+
+			if err := file.Relocate(fileName); err != nil {
+				log.Errorf("index: %s", err)
+				continue
+			}
+
+			relocated++
+		}
+
+		if relocated > 0 {
+			if err := entity.UpdatePhotoCounts(); err != nil {
+				log.Errorf("index: %s", err)
+			}
+
+			UpdateClientConfig(conf)
+		}
+
+		event.Success(fmt.Sprintf("relocated %d files", relocated))
+
+		c.JSON(http.StatusOK, gin.H{"files": relocated})
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetMissingFiles(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetMissingFiles(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/index/missing?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#(Name==\"missing.jpg\")").Exists())
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetMissingFiles(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/index/missing")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRelocateMissingFiles(t *testing.T) {
+	t.Run("no files", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RelocateMissingFiles(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/index/missing/relocate", `{"From": "Drive", "To": "Other"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "files").Int())
+	})
+	t.Run("invalid folder", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RelocateMissingFiles(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/index/missing/relocate", `{"From": "/", "To": "Other"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -17,3 +17,25 @@ func TestCancelIndex(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestPauseIndexing(t *testing.T) {
+	t.Run("not running", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		PauseIndexing(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/index/pause")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "Not running", val.String())
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
+
+func TestResumeIndexing(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResumeIndexing(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/index/resume")
+		val := gjson.Get(r.Body.String(), "message")
+		assert.Equal(t, "indexing resumed", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
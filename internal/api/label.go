@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"path"
 	"strconv"
 	"time"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/thumb"
@@ -155,6 +155,145 @@ func DislikeLabel(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// PUT /api/v1/labels/:uid/cover
+//
+// Selects the photo and optional image region, e.g. the face of a person, that represent a label in previews.
+//
+// Parameters:
+//   uid: string Label UID
+func SetLabelCover(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/labels/:uid/cover", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.LabelCover
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		id := c.Param("uid")
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+			return
+		}
+
+		p, err := query.PhotoByUID(f.Photo)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		if p.PhotoPrivate {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("private photos can't be used as cover")})
+			return
+		}
+
+		if pl, err := query.PhotoLabel(p.ID, m.ID); err != nil || pl.Uncertainty >= 100 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("photo doesn't have this label")})
+			return
+		}
+
+		var crop string
+
+		if f.Crop != "" {
+			region, err := thumb.ParseCrop(f.Crop)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			crop = region.String()
+		}
+
+		if err := m.SetCover(p.PhotoUID, crop); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		flushLabelThumbs(m.LabelUID)
+
+		event.Success("label cover saved")
+
+		PublishLabelEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/labels/:uid/cover
+//
+// Resets the label cover, so that the best matching photo is used again.
+//
+// Parameters:
+//   uid: string Label UID
+func ResetLabelCover(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/labels/:uid/cover", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uid")
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+			return
+		}
+
+		if err := m.SetCover("", ""); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		flushLabelThumbs(m.LabelUID)
+
+		PublishLabelEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// flushLabelThumbs removes the cached thumbnails of a label, e.g. after its cover was changed.
+func flushLabelThumbs(labelUID string) {
+	cache := service.ThumbCache()
+
+	for typeName := range thumb.Types {
+		cache.Delete(fmt.Sprintf("label-thumbnail:%s:%s", labelUID, typeName))
+	}
+}
+
+// cropThumb returns a square thumbnail of an image region, the original is only needed if it's not cached yet.
+func cropThumb(conf *config.Config, f entity.File, region string, size int) (string, error) {
+	crop, err := thumb.ParseCrop(region)
+
+	if err != nil {
+		return "", err
+	}
+
+	if fileName, err := thumb.CropFilename(f.FileHash, conf.ThumbPath(), size, crop); err != nil {
+		return "", err
+	} else if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	fileName, err := photoprism.OriginalFileName(conf, f)
+
+	if err != nil {
+		return "", err
+	}
+
+	return thumb.FromCrop(fileName, f.FileHash, conf.ThumbPath(), size, crop)
+}
+
 // GET /api/v1/labels/:uid/t/:token/:type
 //
 // Parameters:
@@ -163,7 +302,7 @@ func DislikeLabel(router *gin.RouterGroup, conf *config.Config) {
 func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/labels/:uid/t/:token/:type", func(c *gin.Context) {
 		if InvalidToken(c, conf) {
-			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			svgIcon(c, http.StatusForbidden, brokenIconSvg)
 			return
 		}
 
@@ -175,16 +314,16 @@ func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if !ok {
 			log.Errorf("label: invalid thumb type %s", txt.Quote(typeName))
-			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
+			svgIcon(c, http.StatusBadRequest, labelIconSvg)
 			return
 		}
 
-		gc := service.Cache()
+		cache := service.ThumbCache()
 		cacheKey := fmt.Sprintf("label-thumbnail:%s:%s", labelUID, typeName)
 
-		if cacheData, ok := gc.Get(cacheKey); ok {
+		if cacheData, ok := cache.Get(cacheKey); ok {
 			log.Debugf("cache hit for %s [%s]", cacheKey, time.Since(start))
-			c.Data(http.StatusOK, "image/jpeg", cacheData.([]byte))
+			coverJpeg(c, cacheData)
 			return
 		}
 
@@ -192,15 +331,34 @@ func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if err != nil {
 			log.Errorf(err.Error())
-			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
+			svgPlaceholder(c, labelIconSvg)
 			return
 		}
 
-		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+		// Square thumbnails show the selected image region of the cover, e.g. the face of a person.
+		if thumbType.Width == thumbType.Height && !thumbType.ExceedsLimit() {
+			if m, err := query.LabelByUID(labelUID); err == nil && m.LabelCrop != "" && m.LabelCover == f.PhotoUID {
+				if thumbnail, err := cropThumb(conf, f, m.LabelCrop, thumbType.Width); err != nil {
+					log.Errorf("label: %s", err)
+				} else if thumbData, err := ioutil.ReadFile(thumbnail); err == nil {
+					cache.Set(cacheKey, thumbnail, thumbData, time.Hour*4)
+					coverJpeg(c, thumbData)
+					return
+				}
+			}
+		}
+
+		fileName, err := thumbSource(conf, f, thumbType)
+
+		if err != nil {
+			log.Errorf("label: %s", err)
+			svgIcon(c, http.StatusNotFound, labelIconSvg)
+			return
+		}
 
-		if !fs.FileExists(fileName) {
+		if !f.FileCold && !fs.FileExists(fileName) {
 			log.Errorf("label: file %s is missing", txt.Quote(f.FileName))
-			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
+			svgIcon(c, http.StatusNotFound, labelIconSvg)
 
 			// Set missing flag so that the file doesn't show up in search results anymore.
 			report("label", f.Update("FileMissing", true))
@@ -212,6 +370,7 @@ func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		if thumbType.ExceedsLimit() {
 			log.Debugf("label: using original, thumbnail size exceeds limit (width %d, height %d)", thumbType.Width, thumbType.Height)
 
+			c.Header("Cache-Control", CacheRevalidate)
 			c.File(fileName)
 
 			return
@@ -220,14 +379,14 @@ func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		var thumbnail string
 
 		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err = thumbFromFile(conf, f, fileName, typeName, thumbType)
 		} else {
 			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
 		}
 
 		if err != nil {
 			log.Errorf("label: %s", err)
-			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
 			return
 		}
 
@@ -235,14 +394,14 @@ func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if err != nil {
 			log.Errorf("label: %s", err)
-			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
 			return
 		}
 
-		gc.Set(cacheKey, thumbData, time.Hour*4)
+		cache.Set(cacheKey, thumbnail, thumbData, time.Hour*4)
 
 		log.Debugf("cached %s [%s]", cacheKey, time.Since(start))
 
-		c.Data(http.StatusOK, "image/jpeg", thumbData)
+		coverJpeg(c, thumbData)
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
@@ -113,7 +114,7 @@ func TestLabelThumbnail(t *testing.T) {
 		app, router, conf := NewApiTest()
 		LabelThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c2/t/"+conf.PreviewToken()+"/xxx")
-		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 	t.Run("invalid label", func(t *testing.T) {
 		app, router, conf := NewApiTest()
@@ -126,6 +127,55 @@ func TestLabelThumbnail(t *testing.T) {
 		app, router, conf := NewApiTest()
 		LabelThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c3/t/"+conf.PreviewToken()+"/tile_500")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestSetLabelCover(t *testing.T) {
+	app, router, conf := NewApiTest()
+	SetLabelCover(router, conf)
+	ResetLabelCover(router, conf)
+	LabelThumbnail(router, conf)
+
+	label := entity.NewLabel("Cover Person", 0)
+
+	if err := label.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(1000000, label.ID, 0, entity.SrcManual))
+
+	t.Run("photo and crop", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/"+label.LabelUID+"/cover", `{"Photo": "pt9jtdre2lvl0yh7", "Crop": "0.25,0.1,0.2,0.3"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "Cover").String())
+		assert.Equal(t, "0.250,0.100,0.200,0.300", gjson.Get(r.Body.String(), "Crop").String())
+	})
+	t.Run("thumbnail", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/labels/"+label.LabelUID+"/t/"+conf.PreviewToken()+"/tile_224")
+		// The original is missing in test data.
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid crop", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/"+label.LabelUID+"/cover", `{"Photo": "pt9jtdre2lvl0yh7", "Crop": "0.9,0.1,0.2,0.3"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("photo without label", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/"+label.LabelUID+"/cover", `{"Photo": "pt9jtdre2lvl0yh8"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/"+label.LabelUID+"/cover", `{"Photo": "pt9jtdre2lvl0xxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("label not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/xxx/cover", `{"Photo": "pt9jtdre2lvl0yh7"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("reset", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/labels/"+label.LabelUID+"/cover")
 		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Cover").String())
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Crop").String())
 	})
 }
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// rateLimited increments the request counter for key and returns true if it exceeds limit within period.
+func rateLimited(key string, limit int, period time.Duration) bool {
+	gc := service.Cache()
+	cacheKey := "rate-limit:" + key
+
+	if err := gc.Add(cacheKey, 1, period); err == nil {
+		return false
+	}
+
+	n, err := gc.IncrementInt(cacheKey, 1)
+
+	if err != nil {
+		log.Errorf("rate limit: %s", err)
+		return false
+	}
+
+	return n > limit
+}
+
+// rateExceeded returns true if the request counter for key has reached limit, without incrementing it.
+func rateExceeded(key string, limit int) bool {
+	if n, ok := service.Cache().Get("rate-limit:" + key); ok {
+		if i, ok := n.(int); ok {
+			return i >= limit
+		}
+	}
+
+	return false
+}
@@ -1,7 +1,11 @@
 package api
 
 import (
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,9 +14,18 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// Wrong share link passwords are accepted up to sharePasswordLimit times per client ip and up to
+// sharePasswordTokenLimit times per link within sharePasswordPeriod, so that they can't be guessed.
+const (
+	sharePasswordLimit      = 10
+	sharePasswordTokenLimit = 50
+	sharePasswordPeriod     = 15 * time.Minute
+)
+
 // newLink returns a new link entity initialized with request data
 func newLink(c *gin.Context) (link entity.Link, err error) {
 	var f form.NewLink
@@ -22,6 +35,11 @@ func newLink(c *gin.Context) (link entity.Link, err error) {
 	}
 
 	link = entity.NewLink(f.Password, f.CanComment, f.CanEdit)
+	link.CanDownload = f.CanDownload
+	link.WmText = txt.Clip(f.WatermarkText, txt.ClipDefault)
+	link.WmImage = txt.Clip(f.WatermarkImage, txt.ClipDefault)
+	link.WmPosition = txt.Clip(f.WatermarkPosition, 16)
+	link.WmOpacity = f.WatermarkOpacity
 
 	if f.Expires > 0 {
 		expires := time.Now().Add(time.Duration(f.Expires) * time.Second)
@@ -53,8 +71,12 @@ func LinkAlbum(router *gin.RouterGroup, conf *config.Config) {
 			entity.Db().Model(&m).Association("Links").Append(link)
 		}
 
+		addAlbumEvent(c, m.AlbumUID, entity.AlbumEventShared, 0, "")
+
 		event.Success("created album share link")
 
+		countUsage(conf, entity.UsageShare)
+
 		c.JSON(http.StatusOK, m)
 	})
 }
@@ -83,6 +105,8 @@ func LinkPhoto(router *gin.RouterGroup, conf *config.Config) {
 
 		event.Success("created photo share link")
 
+		countUsage(conf, entity.UsageShare)
+
 		c.JSON(http.StatusOK, m)
 	})
 }
@@ -111,6 +135,341 @@ func LinkLabel(router *gin.RouterGroup, conf *config.Config) {
 
 		event.Success("created label share link")
 
+		countUsage(conf, entity.UsageShare)
+
 		c.JSON(http.StatusOK, m)
 	})
 }
+
+// PUT /api/v1/links/:token/url
+//
+// Sets a human-readable slug like "summer-2024" and an optional custom domain for a share link.
+// Returns 409 Conflict with a suggestion if the slug is already in use.
+//
+// Parameters:
+//   token: string Share link token
+func UpdateLinkUrl(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/links/:token/url", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		link, err := query.LinkByToken(c.Param("token"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.LinkUrl
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := link.SetSlug(f.Slug); err == entity.ErrLinkSlugTaken {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error()), "Suggestion": entity.UniqueLinkSlug(f.Slug, link.LinkToken)})
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := link.SetDomain(f.Domain); err == entity.ErrLinkDomainTaken {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("share link updated")
+
+		c.JSON(http.StatusOK, gin.H{"Link": link, "URL": link.URL(conf.Url())})
+	})
+}
+
+// PUT /api/v1/links/:token/scope
+//
+// Changes whether an album share link also grants access to all nested albums, e.g. to share a
+// "Grandkids 2024" album tree as a unit. Excluded albums are not shared, including their descendants.
+//
+// Parameters:
+//   token: string Share link token
+func UpdateLinkScope(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/links/:token/scope", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		link, err := query.LinkByToken(c.Param("token"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.LinkScope
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Subtree && !rnd.IsPPID(link.ShareUID, 'a') {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrNotAnAlbum)
+			return
+		}
+
+		if err := link.SetScope(f.Subtree, f.Exclude); err != nil {
+			log.Errorf("link: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		albums, err := query.LinkAlbums(link)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("share link updated")
+
+		c.JSON(http.StatusOK, gin.H{"Link": link, "Albums": albums})
+	})
+}
+
+// PUT /api/v1/links/:token/restrictions
+//
+// Limits the IP ranges a share link can be used from, e.g. the office network, and the sites that may
+// embed or link to it. Empty lists remove the restrictions.
+//
+// Parameters:
+//   token: string Share link token
+func UpdateLinkRestrictions(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/links/:token/restrictions", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		link, err := query.LinkByToken(c.Param("token"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.LinkRestrictions
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := link.SetRestrictions(f.AllowedIPs, f.AllowedOrigins); err == entity.ErrLinkIPInvalid || err == entity.ErrLinkOriginInvalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if err != nil {
+			log.Errorf("link: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("share link updated")
+
+		c.JSON(http.StatusOK, link)
+	})
+}
+
+// DELETE /api/v1/links/:token
+//
+// Revokes a share link, so that visitors and guests can't use it anymore.
+//
+// Parameters:
+//   token: string Share link token
+func DeleteLink(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/links/:token", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		link, err := query.LinkByToken(c.Param("token"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		if err := link.Delete(); err != nil {
+			log.Errorf("link: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		if rnd.IsPPID(link.ShareUID, 'a') {
+			addAlbumEvent(c, link.ShareUID, entity.AlbumEventUnshared, 0, "")
+		}
+
+		event.Success("share link revoked")
+
+		c.JSON(http.StatusOK, link)
+	})
+}
+
+// sharePasswordThrottled returns true if too many wrong passwords were sent from the client or for the link.
+func sharePasswordThrottled(c *gin.Context, link entity.Link) bool {
+	return rateExceeded("share-password:"+clientIP(c), sharePasswordLimit) ||
+		rateExceeded("share-password:"+link.LinkToken, sharePasswordTokenLimit)
+}
+
+// checkSharePassword returns true if the password of the link matches and counts failed attempts otherwise.
+func checkSharePassword(c *gin.Context, link entity.Link, password string) bool {
+	if link.CheckPassword(password) {
+		return true
+	}
+
+	rateLimited("share-password:"+clientIP(c), sharePasswordLimit, sharePasswordPeriod)
+	rateLimited("share-password:"+link.LinkToken, sharePasswordTokenLimit, sharePasswordPeriod)
+
+	log.Warnf("share: wrong password for %s from %s", link.LinkToken, clientIP(c))
+
+	return false
+}
+
+// shareUnlocked returns true if the visitor may see the content of a share link, either because it has
+// no password, a valid unlock token was sent, see UnlockShare, or a guest has access to the link. API clients
+// may also send the password in the X-Share-Password header, which is ignored while the client or link is
+// throttled after too many wrong attempts.
+func shareUnlocked(c *gin.Context, link entity.Link) bool {
+	if link.LinkPassword == "" {
+		return true
+	}
+
+	if token := shareUnlockToken(c, link); token != "" && validShareUnlockToken(link, token) {
+		return true
+	}
+
+	if password := c.GetHeader("X-Share-Password"); password != "" {
+		if sharePasswordThrottled(c, link) {
+			c.Header("Retry-After", strconv.Itoa(int(sharePasswordPeriod.Seconds())))
+		} else if checkSharePassword(c, link, password) {
+			return true
+		}
+	}
+
+	guest, ok := guestSession(c)
+
+	if !ok {
+		return false
+	}
+
+	shares, err := query.GuestShares(guest.GuestUID)
+
+	if err != nil {
+		log.Errorf("share: %s", err)
+		return false
+	}
+
+	for _, share := range shares {
+		if share.LinkToken == link.LinkToken {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GET /api/v1/s/:token/albums
+//
+// Returns the albums shared by a link, including nested albums if the link has subtree scope.
+//
+// Parameters:
+//   token: string Share link token
+func GetShareAlbums(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/albums", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+			return
+		}
+
+		results, err := query.LinkAlbums(link)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// requestOrigin returns the origin of the page a request was sent from, based on the Origin or Referer header.
+// Requests from the site itself return an empty string.
+func requestOrigin(c *gin.Context) string {
+	origin := c.GetHeader("Origin")
+
+	if origin == "" {
+		if u, err := url.Parse(c.GetHeader("Referer")); err == nil && u.Host != "" {
+			origin = u.Scheme + "://" + u.Host
+		}
+	}
+
+	if u, err := url.Parse(origin); err != nil || u.Host == "" || strings.EqualFold(u.Host, c.Request.Host) {
+		return ""
+	}
+
+	return origin
+}
+
+// frameAncestors returns the value of the Content-Security-Policy header that limits which sites may embed
+// the share page, or an empty string if embedding is not restricted.
+func frameAncestors(link entity.Link) string {
+	origins := link.AllowedOrigins()
+
+	if len(origins) == 0 {
+		return ""
+	}
+
+	return "frame-ancestors 'self' " + strings.Join(origins, " ")
+}
+
+// ShareDomain redirects requests for the root of a custom share link domain to the share page.
+func ShareDomain(conf *config.Config) gin.HandlerFunc {
+	siteHost := ""
+
+	if u, err := url.Parse(conf.Url()); err == nil {
+		siteHost = u.Hostname()
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || c.Request.URL.Path != "/" {
+			return
+		}
+
+		host := c.Request.Host
+
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		if host == "" || strings.EqualFold(host, siteHost) {
+			return
+		}
+
+		if link, err := query.LinkByDomain(host); err == nil && !link.Expired() {
+			c.Redirect(http.StatusFound, "/"+link.Path())
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// linkRenewDefault is the renewal period of links without auto-renewal period.
+const linkRenewDefault = 30 * 24 * time.Hour
+
+// POST /api/v1/links/:token/renew
+//
+// Extends the expiration date of a share link, e.g. after receiving an expiry reminder. Links are renewed
+// by their auto-renewal period, or by 30 days, unless a period in seconds is provided.
+//
+// Parameters:
+//   token: string Share link token
+func RenewLink(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/links/:token/renew", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		link, err := query.LinkByToken(c.Param("token"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.LinkRenew
+
+		// The request body is optional.
+		if c.Request.ContentLength > 0 {
+			if err := c.BindJSON(&f); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		d := linkRenewDefault
+
+		if f.Expires > 0 {
+			d = time.Duration(f.Expires) * time.Second
+		} else if link.LinkRenewal > 0 {
+			d = time.Duration(link.LinkRenewal) * time.Second
+		}
+
+		if err := link.Renew(d); err != nil {
+			log.Errorf("link: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("share link renewed")
+
+		c.JSON(http.StatusOK, link)
+	})
+}
+
+// PUT /api/v1/links/:token/renewal
+//
+// Sets the period in seconds by which a share link is renewed automatically before it expires, e.g. for
+// long-lived family shares. A period of 0 disables auto-renewal.
+//
+// Parameters:
+//   token: string Share link token
+func UpdateLinkRenewal(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/links/:token/renewal", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		link, err := query.LinkByToken(c.Param("token"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.LinkRenewal
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := link.SetRenewal(f.Renewal); err == entity.ErrLinkRenewalInvalid {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if err != nil {
+			log.Errorf("link: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("share link updated")
+
+		c.JSON(http.StatusOK, link)
+	})
+}
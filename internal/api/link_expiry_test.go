@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestRenewLink(t *testing.T) {
+	t.Run("default period", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RenewLink(router, conf)
+
+		expires := time.Now().Add(time.Hour)
+		link := entity.NewLink("", false, false)
+		link.LinkExpires = &expires
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "POST", "/api/v1/links/"+link.LinkToken+"/renew")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		renewed := gjson.Get(r.Body.String(), "Expires").Time()
+		assert.WithinDuration(t, expires.Add(linkRenewDefault), renewed, time.Second)
+	})
+	t.Run("custom period", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RenewLink(router, conf)
+
+		expires := time.Now().Add(time.Hour)
+		link := entity.NewLink("", false, false)
+		link.LinkExpires = &expires
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links/"+link.LinkToken+"/renew", `{"Expires": 86400}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		renewed := gjson.Get(r.Body.String(), "Expires").Time()
+		assert.WithinDuration(t, expires.Add(24*time.Hour), renewed, time.Second)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RenewLink(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/links/xxx/renew")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateLinkRenewal(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateLinkRenewal(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/1jxf3jfn2k/renewal", `{"Renewal": 31536000}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(31536000), gjson.Get(r.Body.String(), "Renewal").Int())
+	})
+	t.Run("period too short", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateLinkRenewal(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/1jxf3jfn2k/renewal", `{"Renewal": 60}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateLinkRenewal(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/xxx/renewal", `{"Renewal": 0}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
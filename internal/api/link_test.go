@@ -2,10 +2,14 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/tidwall/gjson"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
+
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,8 +39,9 @@ func TestLinkAlbum(t *testing.T) {
 		assert.Nil(t, link.LinkExpires)
 		assert.False(t, link.CanComment)
 		assert.True(t, link.CanEdit)
+		assert.False(t, link.CanDownload)
 
-		result2 := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/link", `{"Password": "", "Expires": 3600}`)
+		result2 := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/link", `{"Password": "", "Expires": 3600, "CanDownload": true}`)
 
 		assert.Equal(t, http.StatusOK, result2.Code)
 
@@ -50,6 +55,8 @@ func TestLinkAlbum(t *testing.T) {
 		if len(album.Links) != 2 {
 			t.Fatal("two links expected")
 		}
+
+		assert.NotEqual(t, album.Links[0].CanDownload, album.Links[1].CanDownload)
 	})
 	t.Run("album not found", func(t *testing.T) {
 		app, router, ctx := NewApiTest()
@@ -88,10 +95,12 @@ func TestLinkPhoto(t *testing.T) {
 
 		link := photo.Links[0]
 
-		assert.Equal(t, "foobar", link.LinkPassword)
+		assert.True(t, link.HasPassword)
+		assert.Empty(t, link.LinkPassword)
 		assert.Nil(t, link.LinkExpires)
 		assert.False(t, link.CanComment)
 		assert.True(t, link.CanEdit)
+		assert.False(t, link.CanDownload)
 
 		result2 := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/link", `{"Password": "", "Expires": 3600}`)
 
@@ -142,10 +151,12 @@ func TestLinkLabel(t *testing.T) {
 
 		link := label.Links[0]
 
-		assert.Equal(t, "foobar", link.LinkPassword)
+		assert.True(t, link.HasPassword)
+		assert.Empty(t, link.LinkPassword)
 		assert.Nil(t, link.LinkExpires)
 		assert.False(t, link.CanComment)
 		assert.True(t, link.CanEdit)
+		assert.False(t, link.CanDownload)
 
 		result2 := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c2/link", `{"Password": "", "Expires": 3600}`)
 
@@ -174,3 +185,196 @@ func TestLinkLabel(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestUpdateLinkUrl(t *testing.T) {
+	first := entity.NewLink("", false, false)
+	first.ShareUID = "at9lxuqxpogaaba7"
+
+	if err := entity.Db().Create(&first).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	second := entity.NewLink("", false, false)
+	second.ShareUID = "at9lxuqxpogaaba7"
+
+	if err := entity.Db().Create(&second).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, conf := NewApiTest()
+	UpdateLinkUrl(router, conf)
+
+	t.Run("slug and domain", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/"+first.LinkToken+"/url", `{"Slug": "Summer 2031", "Domain": "summer.example.com"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "summer-2031", gjson.Get(r.Body.String(), "Link.Slug").String())
+		assert.Equal(t, "summer.example.com", gjson.Get(r.Body.String(), "Link.Domain").String())
+		assert.Equal(t, "http://summer.example.com/s/summer-2031", gjson.Get(r.Body.String(), "URL").String())
+	})
+	t.Run("slug taken", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/"+second.LinkToken+"/url", `{"Slug": "summer-2031"}`)
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, "summer-2031-2", gjson.Get(r.Body.String(), "Suggestion").String())
+	})
+	t.Run("domain taken", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/"+second.LinkToken+"/url", `{"Domain": "summer.example.com"}`)
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("invalid domain", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/"+second.LinkToken+"/url", `{"Domain": "summer example"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("link not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/xxx/url", `{"Slug": "summer"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestShareDomain(t *testing.T) {
+	link := entity.NewLink("", false, false)
+	link.ShareUID = "at9lxuqxpogaaba7"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := link.SetDomain("winter.example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	app, _, conf := NewApiTest()
+	app.Use(ShareDomain(conf))
+	app.GET("/", func(c *gin.Context) { c.String(http.StatusOK, "home") })
+
+	t.Run("custom domain", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Host = "winter.example.com:2342"
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/s/"+link.LinkToken, w.Header().Get("Location"))
+	})
+	t.Run("site", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Host = "localhost:2342"
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestUpdateLinkScope(t *testing.T) {
+	t.Run("link not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateLinkScope(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/xxx/scope", `{"Subtree": true}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateLinkRestrictions(t *testing.T) {
+	t.Run("link not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateLinkRestrictions(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/xxx/restrictions", `{"AllowedIPs": ["10.0.0.0/8"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteLink(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		link := entity.NewLink("", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		DeleteLink(router, conf)
+		GetShareAlbums(router, conf)
+
+		r := PerformRequest(app, "DELETE", "/api/v1/links/"+link.LinkToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, link.LinkToken, gjson.Get(r.Body.String(), "Token").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/albums")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("link not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DeleteLink(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/links/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetShareAlbums(t *testing.T) {
+	t.Run("link not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareAlbums(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/xxx/albums")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("password required", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareAlbums(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/1jxf3jfn2k/albums")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Equal(t, "Password required", gjson.Get(r.Body.String(), "error").String())
+
+		r = PerformRequestWithHeaders(app, "GET", "/api/v1/s/1jxf3jfn2k/albums", "", map[string]string{"X-Share-Password": "wrong"})
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("password sent", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareAlbums(router, conf)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/s/1jxf3jfn2k/albums", "", map[string]string{"X-Share-Password": "somepassword"})
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestShareUnlocked(t *testing.T) {
+	unlock := func(link entity.Link, password, ip string) (bool, *gin.Context) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("GET", "/api/v1/s/"+link.LinkToken+"/albums", nil)
+		c.Request.RemoteAddr = ip + ":12345"
+		c.Request.Header.Set("X-Share-Password", password)
+
+		return shareUnlocked(c, link), c
+	}
+
+	t.Run("no password", func(t *testing.T) {
+		ok, _ := unlock(entity.NewLink("", false, false), "", "10.10.0.1")
+		assert.True(t, ok)
+	})
+	t.Run("throttled by client ip", func(t *testing.T) {
+		link := entity.NewLink("secret", false, false)
+
+		ok, _ := unlock(link, "secret", "10.10.1.1")
+		assert.True(t, ok)
+
+		for i := 0; i < sharePasswordLimit; i++ {
+			ok, _ := unlock(link, "wrong", "10.10.1.1")
+			assert.False(t, ok)
+		}
+
+		ok, c := unlock(link, "secret", "10.10.1.1")
+		assert.False(t, ok)
+		assert.Equal(t, "900", c.Writer.Header().Get("Retry-After"))
+
+		ok, _ = unlock(link, "secret", "10.10.1.2")
+		assert.True(t, ok)
+	})
+	t.Run("throttled by link", func(t *testing.T) {
+		link := entity.NewLink("secret", false, false)
+
+		for i := 0; i < sharePasswordTokenLimit; i++ {
+			ok, _ := unlock(link, "wrong", fmt.Sprintf("10.10.2.%d", i))
+			assert.False(t, ok)
+		}
+
+		ok, _ := unlock(link, "secret", "10.10.3.1")
+		assert.False(t, ok)
+	})
+}
@@ -18,7 +18,7 @@ func GetMomentsTime(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		result, err := query.GetMomentsTime()
+		result, err := query.GetMomentsTime(conf.ExcludeCategories())
 
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/nsfw
+//
+// Returns photos in review because they may be offensive, including their confidence scores.
+func GetNSFWReview(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/nsfw", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.NSFWReview
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.NSFWReview(f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/nsfw/:uid/approve
+//
+// Removes a photo from review, so that it is shown in search results again.
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func ApproveNSFW(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/nsfw/:uid/approve", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		uid := c.Param("uid")
+		m, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		if err := m.ApproveNSFW(); err != nil {
+			log.Errorf("photo: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, gin.H{"photo": m})
+	})
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetNSFWReview(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetNSFWReview(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/nsfw?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetNSFWReview(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/nsfw")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestApproveNSFW(t *testing.T) {
+	t.Run("existing photo", func(t *testing.T) {
+		photo := entity.Photo{PhotoNSFW: 0.99, NSFWReview: true, PhotoQuality: -1}
+
+		if err := entity.Db().Create(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		ApproveNSFW(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/nsfw/"+photo.PhotoUID+"/approve")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "false", gjson.Get(r.Body.String(), "photo.NSFWReview").String())
+	})
+	t.Run("not existing photo", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ApproveNSFW(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/nsfw/xxx/approve")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/openapi"
+)
+
+// openApiForms maps routes to the forms used for binding query parameters or request bodies.
+var openApiForms = map[string]interface{}{
+	"POST /api/v1/session":                       form.Login{},
+	"GET /api/v1/photos":                         form.PhotoSearch{},
+	"PUT /api/v1/photos/:uid":                    form.Photo{},
+	"POST /api/v1/photos/:uid/link":              form.NewLink{},
+	"POST /api/v1/photos/:uid/unlock":            form.PhotoUnlock{},
+	"POST /api/v1/photos/:uid/albums":            form.AlbumAdd{},
+	"GET /api/v1/geo":                            form.GeoSearch{},
+	"POST /api/v1/files/:uid":                    form.FilePrecheck{},
+	"POST /api/v1/files/:uid/link":               form.NewLink{},
+	"GET /api/v1/labels":                         form.LabelSearch{},
+	"PUT /api/v1/labels/:uid":                    form.Label{},
+	"POST /api/v1/labels/:uid/link":              form.NewLink{},
+	"PUT /api/v1/labels/:uid/cover":              form.LabelCover{},
+	"GET /api/v1/albums":                         form.AlbumSearch{},
+	"POST /api/v1/albums":                        form.Album{},
+	"PUT /api/v1/albums/:uid":                    form.Album{},
+	"PUT /api/v1/albums/:uid/cover":              form.AlbumCover{},
+	"PUT /api/v1/albums/:uid/parent":             form.AlbumParent{},
+	"PUT /api/v1/albums/:uid/photos/order":       form.AlbumPhotoOrder{},
+	"PUT /api/v1/albums/:uid/captions/:photo":    form.PhotoAlbumCaption{},
+	"POST /api/v1/albums/:uid/merge":             form.AlbumMerge{},
+	"GET /api/v1/albums/:uid/children":           form.AlbumSearch{},
+	"GET /api/v1/albums/:uid/dl":                 form.AlbumDownload{},
+	"GET /api/v1/albums/:uid/dl/estimate":        form.AlbumDownload{},
+	"POST /api/v1/albums/:uid/archive":           form.AlbumDownload{},
+	"GET /api/v1/s/:token/dl":                    form.AlbumDownload{},
+	"POST /api/v1/albums/:uid/highlights":        form.AlbumHighlights{},
+	"GET /api/v1/albums/:uid/print":              form.AlbumPrint{},
+	"POST /api/v1/s/:token/guest":                form.Guest{},
+	"POST /api/v1/guest/session":                 form.GuestLogin{},
+	"POST /api/v1/guests/:uid/upgrade":           form.GuestUpgrade{},
+	"POST /api/v1/duplicates/resolve":            form.DuplicateResolve{},
+	"POST /api/v1/presets":                       form.Preset{},
+	"PUT /api/v1/presets/:uid":                   form.Preset{},
+	"POST /api/v1/presets/:uid/apply":            form.Selection{},
+	"POST /api/v1/album-rules":                   form.AlbumRule{},
+	"PUT /api/v1/album-rules/:uid":               form.AlbumRule{},
+	"PUT /api/v1/albums/:uid/story":              form.AlbumStory{},
+	"POST /api/v1/albums/:uid/print":             form.AlbumPrint{},
+	"POST /api/v1/albums/:uid/csv":               form.AlbumCsv{},
+	"POST /api/v1/chat":                          form.ChatShare{},
+	"POST /api/v1/albums/:uid/link":              form.NewLink{},
+	"PUT /api/v1/links/:token/url":               form.LinkUrl{},
+	"PUT /api/v1/links/:token/scope":             form.LinkScope{},
+	"PUT /api/v1/links/:token/restrictions":      form.LinkRestrictions{},
+	"POST /api/v1/links/:token/renew":            form.LinkRenew{},
+	"PUT /api/v1/links/:token/renewal":           form.LinkRenewal{},
+	"POST /api/v1/albums/:uid/photos":            form.AlbumPhotos{},
+	"DELETE /api/v1/albums/:uid/photos":          form.Selection{},
+	"POST /api/v1/s/:token/reactions":            form.GuestReaction{},
+	"POST /api/v1/s/:token/unlock":               form.ShareUnlock{},
+	"POST /api/v1/index":                         form.IndexOptions{},
+	"POST /api/v1/import/*path":                  form.ImportOptions{},
+	"POST /api/v1/import-url":                    form.ImportUrl{},
+	"GET /api/v1/geometry":                       form.GeometryReview{},
+	"GET /api/v1/nsfw":                           form.NSFWReview{},
+	"GET /api/v1/index/missing":                  form.MissingFiles{},
+	"POST /api/v1/index/missing/relocate":        form.RelocateFiles{},
+	"GET /api/v1/errors":                         form.IndexErrors{},
+	"GET /api/v1/thumbs/errors":                  form.ThumbErrors{},
+	"GET /api/v1/quarantine":                     form.QuarantineFiles{},
+	"GET /api/v1/checkpoints":                    form.CheckpointSearch{},
+	"GET /api/v1/searches/history":               form.SearchHistory{},
+	"GET /api/v1/searches/history/:id/photos":    form.PhotoSearch{},
+	"GET /api/v1/searches/suggestions":           form.SearchSuggestions{},
+	"POST /api/v1/devices":                       form.Device{},
+	"PUT /api/v1/devices/:uid":                   form.Device{},
+	"POST /api/v1/checkpoints":                   form.Checkpoint{},
+	"GET /api/v1/checkpoints/:uid/diff":          form.CheckpointDiff{},
+	"POST /api/v1/checkpoints/:uid/restore":      form.CheckpointRestore{},
+	"POST /api/v1/batch/photos/archive":          form.Selection{},
+	"POST /api/v1/batch/photos/restore":          form.Selection{},
+	"POST /api/v1/batch/photos/private":          form.Selection{},
+	"POST /api/v1/batch/photos/license":          form.PhotoLicense{},
+	"POST /api/v1/batch/photos/subjects":         form.PhotoSubjects{},
+	"POST /api/v1/batch/photos/date":             form.PhotoApproxDate{},
+	"POST /api/v1/batch/photos/rotate":           form.PhotoRotate{},
+	"POST /api/v1/batch/albums/delete":           form.Selection{},
+	"POST /api/v1/batch/labels/delete":           form.Selection{},
+	"POST /api/v1/zip":                           form.Selection{},
+	"GET /api/v1/sync/manifest":                  form.SyncManifest{},
+	"POST /api/v1/stats/display":                 form.DisplaySize{},
+	"GET /api/v1/stats/usage":                    form.UsageStats{},
+	"GET /api/v1/accounts":                       form.AccountSearch{},
+	"POST /api/v1/accounts":                      form.Account{},
+	"PUT /api/v1/accounts/:id":                   form.Account{},
+	"POST /api/v1/accounts/:id/share":            form.AccountShare{},
+	"GET /api/v2/photos":                         form.ApiV2List{},
+	"GET /api/v2/albums":                         form.ApiV2List{},
+	"GET /api/v2/albums/:uid/photos":             form.ApiV2List{},
+	"POST /api/backup/v1/check":                  form.BackupCheck{},
+	"POST /api/backup/v1/batches/:batch/uploads": form.BackupUpload{},
+}
+
+// NewOpenApi returns an OpenAPI document describing all API routes.
+func NewOpenApi(conf *config.Config, routes gin.RoutesInfo) *openapi.Document {
+	return newOpenApi(conf, routes, "/api/")
+}
+
+// newOpenApi returns an OpenAPI document describing the API routes starting with prefix.
+func newOpenApi(conf *config.Config, routes gin.RoutesInfo, prefix string) *openapi.Document {
+	doc := openapi.New(conf.Name(), conf.Version(), conf.Url())
+	doc.Info.Description = conf.Description()
+
+	for _, r := range routes {
+		if !strings.HasPrefix(r.Path, prefix) {
+			continue
+		}
+
+		doc.Add(r.Method, r.Path, r.Handler, openApiForms[r.Method+" "+r.Path])
+	}
+
+	return doc
+}
+
+// GET /api/v1/openapi.json
+//
+// Returns an OpenAPI 3 specification of all API routes. The routes function is called once on the first request,
+// after all routes have been registered.
+func GetOpenApi(router *gin.RouterGroup, conf *config.Config, routes func() gin.RoutesInfo) {
+	var once sync.Once
+	var doc *openapi.Document
+
+	router.GET("/openapi.json", func(c *gin.Context) {
+		once.Do(func() {
+			doc = NewOpenApi(conf, routes())
+		})
+
+		c.JSON(http.StatusOK, doc)
+	})
+}
+
+// GET /api/v2/openapi.json
+//
+// Returns an OpenAPI 3 specification of the v2 API routes, e.g. for generating client SDKs.
+func GetOpenApiV2(router *gin.RouterGroup, conf *config.Config, routes func() gin.RoutesInfo) {
+	var once sync.Once
+	var doc *openapi.Document
+
+	router.GET("/openapi.json", func(c *gin.Context) {
+		once.Do(func() {
+			doc = newOpenApi(conf, routes(), "/api/v2/")
+			doc.Info.Version = "2"
+		})
+
+		c.JSON(http.StatusOK, doc)
+	})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetOpenApi(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		GetStatus(router, conf)
+		GetAlbums(router, conf)
+		UpdateAlbum(router, conf)
+		GetOpenApi(router, conf, app.Routes)
+
+		r := PerformRequest(app, "GET", "/api/v1/openapi.json")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		val := gjson.Get(r.Body.String(), "openapi")
+		assert.Equal(t, "3.0.3", val.String())
+
+		val = gjson.Get(r.Body.String(), `paths./api/v1/status.get.operationId`)
+		assert.Equal(t, "GetStatus", val.String())
+
+		val = gjson.Get(r.Body.String(), `paths./api/v1/albums.get.parameters.#(name=="count").required`)
+		assert.True(t, val.Bool())
+
+		val = gjson.Get(r.Body.String(), `paths./api/v1/albums/{uid}.put.requestBody.content.application/json.schema.$ref`)
+		assert.Equal(t, "#/components/schemas/Album", val.String())
+
+		val = gjson.Get(r.Body.String(), "components.schemas.Album.properties.Title.type")
+		assert.Equal(t, "string", val.String())
+
+		val = gjson.Get(r.Body.String(), "components.securitySchemes.session.name")
+		assert.Equal(t, "X-Session-Token", val.String())
+	})
+}
+
+func TestGetOpenApiV2(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		v2 := app.Group("/api/v2")
+
+		GetStatus(router, conf)
+		GetPhotosV2(v2, conf)
+		GetOpenApiV2(v2, conf, app.Routes)
+
+		r := PerformRequest(app, "GET", "/api/v2/openapi.json")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		val := gjson.Get(r.Body.String(), "info.version")
+		assert.Equal(t, "2", val.String())
+
+		val = gjson.Get(r.Body.String(), `paths./api/v2/photos.get.operationId`)
+		assert.Equal(t, "GetPhotosV2", val.String())
+
+		val = gjson.Get(r.Body.String(), `paths./api/v1/status`)
+		assert.False(t, val.Exists())
+	})
+}
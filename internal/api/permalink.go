@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// GET /go/:uid
+//
+// Redirects the permalink of a photo or album to its page. Permalinks keep working if the UID changed,
+// e.g. because duplicates were stacked, albums merged, or photos imported again.
+//
+// Parameters:
+//   uid: string Photo or album UID
+func Permalink(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/go/:uid", func(c *gin.Context) {
+		uid := query.RedirectUID(c.Param("uid"))
+
+		if rnd.IsUID(uid, 'a') {
+			if _, err := query.AlbumByUID(uid); err == nil {
+				c.Redirect(http.StatusFound, "/albums/"+uid)
+				return
+			}
+		}
+
+		if rnd.IsUID(uid, 'p') {
+			if _, err := query.PhotoByUID(uid); err == nil {
+				c.Redirect(http.StatusFound, "/photos?q=id:"+uid)
+				return
+			}
+		}
+
+		c.HTML(http.StatusNotFound, conf.HttpDefaultTemplate(), gin.H{"config": conf.PublicClientConfig()})
+	})
+}
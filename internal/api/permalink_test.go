@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermalink(t *testing.T) {
+	if err := entity.AddRedirect("at9lxuqxpogar002", "at9lxuqxpogaaba9"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("album", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		Permalink(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/go/at9lxuqxpogaaba8")
+		assert.Equal(t, http.StatusFound, r.Code)
+		assert.Equal(t, "/albums/at9lxuqxpogaaba8", r.Header().Get("Location"))
+	})
+	t.Run("redirected album", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		Permalink(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/go/at9lxuqxpogar002")
+		assert.Equal(t, http.StatusFound, r.Code)
+		assert.Equal(t, "/albums/at9lxuqxpogaaba9", r.Header().Get("Location"))
+	})
+	t.Run("photo", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		Permalink(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/go/pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusFound, r.Code)
+		assert.Equal(t, "/photos?q=id:pt9jtdre2lvl0yh7", r.Header().Get("Location"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		Permalink(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/go/pt9jtdre2lvlxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
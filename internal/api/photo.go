@@ -3,13 +3,14 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"path"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -19,9 +20,11 @@ import (
 func SavePhotoAsYaml(p entity.Photo, conf *config.Config) {
 	// Write YAML sidecar file (optional).
 	if conf.SidecarYaml() {
-		yamlFile := p.YamlFileName(conf.OriginalsPath(), conf.SidecarHidden())
+		yamlFile := p.YamlFileName(conf.OriginalsPath())
 
-		if err := p.SaveAsYaml(yamlFile); err != nil {
+		if yamlFile == "" {
+			return
+		} else if err := p.SaveAsYaml(yamlFile); err != nil {
 			log.Errorf("photo: %s (update yaml)", err)
 		} else {
 			log.Infof("photo: updated yaml file %s", txt.Quote(fs.RelativeName(yamlFile, conf.OriginalsPath())))
@@ -32,7 +35,8 @@ func SavePhotoAsYaml(p entity.Photo, conf *config.Config) {
 // GET /api/v1/photos/:uid
 //
 // Parameters:
-//   uid: string PhotoUID as returned by the API
+//   uid:    string PhotoUID as returned by the API
+//   fields: string Comma separated list of fields to return, e.g. "UID,Title,Files.Hash"
 func GetPhoto(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/photos/:uid", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -47,11 +51,18 @@ func GetPhoto(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		c.IndentedJSON(http.StatusOK, p)
+		// Clients may use their cached copy if the photo hasn't been updated since.
+		if notModified(c, p.VersionTag(), CacheRevalidate) {
+			return
+		}
+
+		fieldsJSON(c, http.StatusOK, p)
 	})
 }
 
 // PUT /api/v1/photos/:uid
+//
+// Responds with 409 Conflict and the current photo if the If-Match header doesn't match its ETag.
 func UpdatePhoto(router *gin.RouterGroup, conf *config.Config) {
 	router.PUT("/photos/:uid", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -67,6 +78,12 @@ func UpdatePhoto(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		if versionConflict(c, m.VersionTag()) {
+			p, _ := query.PhotoPreloadByUID(uid)
+			abortConflict(c, m.VersionTag(), p)
+			return
+		}
+
 		// TODO: Proof-of-concept for form handling - might need refactoring
 		// 1) Init form with model values
 		f, err := form.NewPhoto(m)
@@ -84,6 +101,11 @@ func UpdatePhoto(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		// Keep the date unless the user may change when photos were taken.
+		if !policyAllowed(c, acl.PhotoDate) {
+			keepPhotoDate(&f, m)
+		}
+
 		// 3) Save model with values from form
 		if err := entity.SavePhotoForm(m, f, conf.GeoCodingApi()); err != nil {
 			log.Error(err)
@@ -104,14 +126,31 @@ func UpdatePhoto(router *gin.RouterGroup, conf *config.Config) {
 
 		SavePhotoAsYaml(p, conf)
 
+		c.Header("ETag", p.VersionTag())
+
 		c.JSON(http.StatusOK, p)
 	})
 }
 
+// keepPhotoDate resets the date fields of a photo form to the stored values.
+func keepPhotoDate(f *form.Photo, m entity.Photo) {
+	if f.TakenAt.Equal(m.TakenAt) && f.TakenAtLocal.Equal(m.TakenAtLocal) && f.TakenSrc == m.TakenSrc && f.TimeZone == m.TimeZone {
+		return
+	}
+
+	log.Infof("photo: date of %s may not be changed", m.PhotoUID)
+
+	f.TakenAt = m.TakenAt
+	f.TakenAtLocal = m.TakenAtLocal
+	f.TakenSrc = m.TakenSrc
+	f.TimeZone = m.TimeZone
+}
+
 // GET /api/v1/photos/:uid/dl
 //
 // Parameters:
 //   uid: string PhotoUID as returned by the API
+//   convert: string Use "jpeg" to download a JPEG version of formats like HEIC and RAW (query)
 func GetPhotoDownload(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/photos/:uid/dl", func(c *gin.Context) {
 		if InvalidDownloadToken(c, conf) {
@@ -126,7 +165,13 @@ func GetPhotoDownload(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+		fileName, err := photoprism.OriginalFileName(conf, f)
+
+		if err != nil {
+			log.Errorf("photo: %s", err)
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		}
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("photo: file %s is missing", txt.Quote(f.FileName))
@@ -138,11 +183,11 @@ func GetPhotoDownload(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		downloadFileName := f.ShareFileName()
+		download := downloadEntry(c, fileName, f.FileHash, f.ShareFileName())
 
-		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", downloadFileName))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", download.Alias))
 
-		c.File(fileName)
+		c.File(download.FileName)
 	})
 }
 
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/s/:token/credits
+//
+// Parameters:
+//   token: string Share link token
+func GetShareCredits(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/credits", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+			return
+		}
+
+		results, err := query.PhotoCredits(link)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetShareCredits(t *testing.T) {
+	link := entity.NewLink("", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareCredits(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/credits")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "MIT", gjson.Get(r.Body.String(), `#(UID=="pt9jtdre2lvl0yh7").License`).String())
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareCredits(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/xxx/credits")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("password required", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareCredits(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/1jxf3jfn2k/credits")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -27,6 +28,13 @@ import (
 //   before:    date   Find photos taken before (format: "2006-01-02")
 //   after:     date   Find photos taken after (format: "2006-01-02")
 //   favorite:  bool   Find favorites only
+//   fields:    string Comma separated list of fields to return, e.g. "UID,Title,Files.Hash"
+//
+// The X-Count header contains the total number of matching results regardless of count and offset, which
+// like these refer to files if results are merged.
+//
+// Results are streamed as newline delimited JSON if the Accept header contains "application/x-ndjson".
+// The count may then be larger than 1000, e.g. to export all photos matching a search.
 func GetPhotos(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/photos", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -43,17 +51,31 @@ func GetPhotos(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		result, count, err := query.PhotoSearch(f)
+		if acceptsNDJSON(c) {
+			if f.Offset == 0 {
+				countUsage(conf, entity.UsageSearch)
+			}
+
+			streamPhotos(c, f)
+			return
+		}
+
+		result, _, total, err := query.PhotoSearchTotal(f)
 
 		if err != nil {
 			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
 			return
 		}
 
-		c.Header("X-Count", strconv.Itoa(count))
+		c.Header("X-Count", strconv.Itoa(total))
 		c.Header("X-Limit", strconv.Itoa(f.Count))
 		c.Header("X-Offset", strconv.Itoa(f.Offset))
 
-		c.JSON(http.StatusOK, result)
+		if f.Offset == 0 {
+			countUsage(conf, entity.UsageSearch)
+			addSearchHistory(c, f.Query)
+		}
+
+		fieldsJSON(c, http.StatusOK, result)
 	})
 }
@@ -3,6 +3,8 @@ package api
 import (
 	"github.com/tidwall/gjson"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,74 @@ func TestGetPhotos(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("total count", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+
+		GetPhotos(router, ctx)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		total, err := strconv.Atoi(r.Header().Get("X-Count"))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, 2, total)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+
+		GetPhotos(router, ctx)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&fields=UID,Title,Files.Hash")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.UID").String())
+		assert.True(t, gjson.Get(r.Body.String(), "0.Title").Exists())
+		assert.True(t, gjson.Get(r.Body.String(), "0.Files.0.Hash").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "0.Files.0.Name").Exists())
+		assert.False(t, gjson.Get(r.Body.String(), "0.TakenAt").Exists())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+
+		GetPhotos(router, ctx)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/photos?count=3&fields=UID", "", map[string]string{"Accept": "application/x-ndjson"})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/x-ndjson", r.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(r.Body.String()), "\n")
+		assert.Len(t, lines, 3)
+
+		for _, line := range lines {
+			assert.NotEmpty(t, gjson.Get(line, "UID").String())
+			assert.False(t, gjson.Get(line, "Title").Exists())
+		}
+	})
+
+	t.Run("ndjson merged", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+
+		GetPhotos(router, ctx)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/photos?count=5000&merged=true", "", map[string]string{"Accept": "application/x-ndjson"})
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uids := make(map[string]bool)
+
+		for _, line := range strings.Split(strings.TrimSpace(r.Body.String()), "\n") {
+			uid := gjson.Get(line, "UID").String()
+			assert.False(t, uids[uid], uid)
+			assert.True(t, gjson.Get(line, "Files.0").Exists())
+			uids[uid] = true
+		}
+
+		assert.LessOrEqual(t, 2, len(uids))
+	})
+
+	t.Run("ndjson invalid request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPhotos(router, ctx)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/photos?count=10&near=xxx", "", map[string]string{"Accept": "application/x-ndjson"})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, ctx := NewApiTest()
 		GetPhotos(router, ctx)
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ContentTypeNDJSON is the media type of newline delimited JSON, one value per line.
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// Photos fetched from the database at once when streaming search results.
+const photoStreamBatch = 1000
+
+// acceptsNDJSON returns true if the client asked for newline delimited JSON in the Accept header.
+func acceptsNDJSON(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), ContentTypeNDJSON)
+}
+
+// streamPhotos writes photo search results as newline delimited JSON, one photo per line. Results are
+// fetched and sent in batches, so that large result sets don't need to be kept in memory. The count in
+// the form limits the total number of results and may be larger than for regular searches.
+func streamPhotos(c *gin.Context, f form.PhotoSearch) {
+	fields := parseFields(c.Query("fields"))
+	limit := f.Count
+	merged := f.Merged
+
+	// Files of the same photo are merged here, as they may be split across batches.
+	f.Merged = false
+
+	var pending *query.PhotoResult
+	var sent int
+	var started bool
+
+	enc := json.NewEncoder(c.Writer)
+
+	start := func() {
+		if started {
+			return
+		}
+
+		c.Header("Content-Type", ContentTypeNDJSON)
+		c.Header("X-Limit", strconv.Itoa(limit))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+		c.Status(http.StatusOK)
+
+		started = true
+	}
+
+	write := func(result query.PhotoResult) bool {
+		values, err := fields.Apply(result)
+
+		if err == nil {
+			err = enc.Encode(values)
+		}
+
+		if err != nil {
+			log.Errorf("photos: failed streaming results (%s)", err)
+			return false
+		}
+
+		return true
+	}
+
+	for {
+		f.Count = photoStreamBatch
+
+		if limit-sent < f.Count {
+			f.Count = limit - sent
+		}
+
+		if f.Count <= 0 {
+			break
+		}
+
+		results, _, err := query.PhotoSearch(f)
+
+		if err != nil && !started {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if err != nil {
+			// Headers have already been sent, so the client only notices an incomplete result.
+			log.Errorf("photos: failed streaming results (%s)", err)
+			c.Abort()
+			return
+		}
+
+		start()
+
+		sent += len(results)
+		f.Offset += len(results)
+
+		if !merged {
+			for _, result := range results {
+				if !write(result) {
+					c.Abort()
+					return
+				}
+			}
+		} else if len(results) > 0 {
+			batch, _, err := query.PhotoResults(results).Merged()
+
+			if err != nil {
+				log.Errorf("photos: failed streaming results (%s)", err)
+				c.Abort()
+				return
+			}
+
+			if pending != nil && batch[0].ID == pending.ID {
+				pending.Files = append(pending.Files, batch[0].Files...)
+				pending.Merged = true
+				batch = batch[1:]
+			}
+
+			// The last photo is sent with the next batch, which may contain more of its files.
+			if len(batch) > 0 {
+				if pending != nil && !write(*pending) {
+					c.Abort()
+					return
+				}
+
+				for _, result := range batch[:len(batch)-1] {
+					if !write(result) {
+						c.Abort()
+						return
+					}
+				}
+
+				last := batch[len(batch)-1]
+				pending = &last
+			}
+		}
+
+		c.Writer.Flush()
+
+		if len(results) < f.Count {
+			break
+		}
+	}
+
+	start()
+
+	if pending != nil && !write(*pending) {
+		c.Abort()
+		return
+	}
+
+	c.Writer.Flush()
+}
@@ -17,6 +17,18 @@ func TestGetPhoto(t *testing.T) {
 		val := gjson.Get(r.Body.String(), "Lat")
 		assert.Equal(t, "48.519234", val.String())
 	})
+	t.Run("not modified", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPhoto(router, ctx)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7")
+		etag := r.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+		assert.Equal(t, CacheRevalidate, r.Header().Get("Cache-Control"))
+
+		r = PerformRequestWithHeaders(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7", "", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, r.Code)
+		assert.Empty(t, r.Body.String())
+	})
 	t.Run("search for not existing photo", func(t *testing.T) {
 		app, router, ctx := NewApiTest()
 		GetPhoto(router, ctx)
@@ -44,6 +56,15 @@ func TestUpdatePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 
+	t.Run("version conflict", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdatePhoto(router, conf)
+		r := PerformRequestWithHeaders(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", `{"Title": "Updated02"}`, map[string]string{"If-Match": `"0"`})
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0y13", gjson.Get(r.Body.String(), "current.UID").String())
+		assert.Equal(t, "Changed by someone else in the meantime", gjson.Get(r.Body.String(), "error").String())
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		UpdatePhoto(router, conf)
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -15,13 +17,16 @@ import (
 
 // GET /api/v1/t/:hash/:token/:type
 //
+// Returns a thumbnail image. Thumbnails are addressed by file hash and may be cached by clients forever,
+// an SVG icon is returned with an error status if no thumbnail is available.
+//
 // Parameters:
 //   hash: string The file hash as returned by the search API
 //   type: string Thumbnail type, see photoprism.ThumbnailTypes
 func GetThumbnail(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/t/:hash/:token/:type", func(c *gin.Context) {
 		if InvalidToken(c, conf) {
-			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			svgIcon(c, http.StatusForbidden, brokenIconSvg)
 			return
 		}
 
@@ -32,14 +37,14 @@ func GetThumbnail(router *gin.RouterGroup, conf *config.Config) {
 
 		if !ok {
 			log.Errorf("photo: invalid thumb type %s", txt.Quote(typeName))
-			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			svgIcon(c, http.StatusBadRequest, photoIconSvg)
 			return
 		}
 
 		f, err := query.FileByHash(fileHash)
 
 		if err != nil {
-			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
 			return
 		}
 
@@ -48,22 +53,33 @@ func GetThumbnail(router *gin.RouterGroup, conf *config.Config) {
 			f, err = query.FileByPhotoUID(f.PhotoUID)
 
 			if err != nil {
-				c.Data(http.StatusOK, "image/svg+xml", fileIconSvg)
+				svgIcon(c, http.StatusNotFound, fileIconSvg)
 				return
 			}
 		}
 
 		// Return SVG icon as placeholder if file has errors.
 		if f.FileError != "" {
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			svgIcon(c, http.StatusUnprocessableEntity, brokenIconSvg)
+			return
+		}
+
+		// The client already has this thumbnail if the entity tag matches, no need to render it.
+		if notModified(c, entityTag(f.FileHash, typeName), CacheImmutable) {
 			return
 		}
 
-		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+		fileName, err := thumbSource(conf, f, thumbType)
 
-		if !fs.FileExists(fileName) {
+		if err != nil {
+			log.Errorf("photo: %s", err)
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
+			return
+		}
+
+		if !f.FileCold && !fs.FileExists(fileName) {
 			log.Errorf("photo: file %s is missing", txt.Quote(f.FileName))
-			c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
 
 			// Set missing flag so that the file doesn't show up in search results anymore.
 			report("photo", f.Update("FileMissing", true))
@@ -89,18 +105,18 @@ func GetThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		var thumbnail string
 
 		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err = thumbFromFile(conf, f, fileName, typeName, thumbType)
 		} else {
 			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
 		}
 
 		if err != nil {
 			log.Errorf("photo: %s", err)
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
 			return
 		} else if thumbnail == "" {
 			log.Errorf("photo: thumbnail name for %s is empty - bug?", filepath.Base(fileName))
-			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
 			return
 		}
 
@@ -111,3 +127,21 @@ func GetThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		}
 	})
 }
+
+// thumbSource returns the file name thumbnails are rendered from. Originals in cold storage are only fetched
+// if the thumbnail isn't cached yet or the original is served because the thumb size exceeds the limit.
+func thumbSource(conf *config.Config, f entity.File, thumbType thumb.Type) (string, error) {
+	fileName := path.Join(conf.OriginalsPath(), f.FileName)
+
+	if !f.FileCold || fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	if !thumbType.ExceedsLimit() {
+		if _, err := thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...); err == nil {
+			return fileName, nil
+		}
+	}
+
+	return photoprism.OriginalFileName(conf, f)
+}
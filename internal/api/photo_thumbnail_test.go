@@ -2,8 +2,11 @@ package api
 
 import (
 	"net/http"
+	"path"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,19 +16,52 @@ func TestGetThumbnail(t *testing.T) {
 		GetThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/t/1/"+conf.PreviewToken()+"/xxx")
 
-		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, CacheNone, r.Header().Get("Cache-Control"))
 	})
 	t.Run("invalid hash", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/t/1/"+conf.PreviewToken()+"/tile_500")
 
-		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("not modified", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumbnail(router, conf)
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/tile_500", "",
+			map[string]string{"If-None-Match": `"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818-tile_500"`})
+		assert.Equal(t, http.StatusNotModified, r.Code)
+		assert.Equal(t, CacheImmutable, r.Header().Get("Cache-Control"))
+		assert.Empty(t, r.Body.Bytes())
 	})
 	t.Run("could not find original", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetThumbnail(router, conf)
 		r := PerformRequest(app, "GET", "/api/v1/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/tile_500")
-		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+		assert.Empty(t, r.Header().Get("ETag"))
+	})
+}
+
+func TestThumbSource(t *testing.T) {
+	_, _, conf := NewApiTest()
+	thumbType := thumb.Types["tile_224"]
+
+	t.Run("hot", func(t *testing.T) {
+		f := entity.File{FileUID: "ft9es39w45bnlxxx", FileName: "2020/05/IMG_1234.jpg", FileHash: "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}
+
+		fileName, err := thumbSource(conf, f, thumbType)
+
+		assert.NoError(t, err)
+		assert.Equal(t, path.Join(conf.OriginalsPath(), f.FileName), fileName)
+	})
+	t.Run("cold without cached thumbnail", func(t *testing.T) {
+		f := entity.File{FileUID: "ft9es39w45bnlxxx", FileName: "2020/05/IMG_1234.jpg", FileHash: "ffffffffffffffffffffffffffffffffffffffff", FileCold: true}
+
+		_, err := thumbSource(conf, f, thumbType)
+
+		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/photos/:uid/unlock
+//
+// Unlocks manually edited fields and indexes the photo again to adopt file metadata.
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func UnlockPhoto(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/photos/:uid/unlock", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.PhotoUnlock
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		uid := c.Param("uid")
+		m, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		unlocked := m.Unlock(f.Fields...)
+
+		if len(unlocked) > 0 {
+			if err := m.Save(); err != nil {
+				log.Errorf("photo: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+
+			log.Infof("photo: unlocked %s of %s", strings.Join(unlocked, ", "), uid)
+		}
+
+		// Read metadata from the primary file again.
+		if file, err := query.FileByPhotoUID(uid); err != nil {
+			log.Warnf("photo: %s has no primary file", uid)
+		} else if res := service.Index().SingleFile(path.Join(conf.OriginalsPath(), file.FileName)); res.Error != nil {
+			log.Errorf("photo: %s", res.Error)
+		}
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		event.Success("photo unlocked")
+
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		SavePhotoAsYaml(p, conf)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUnlockPhoto(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UnlockPhoto(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/unlock", `{"fields": ["title"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "UID").String())
+		assert.NotEqual(t, "manual", gjson.Get(r.Body.String(), "TitleSrc").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UnlockPhoto(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/unlock", `{"fields": "title"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UnlockPhoto(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/xxx/unlock", `{}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
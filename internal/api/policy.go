@@ -0,0 +1,244 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// routePermissions maps mutating and admin-only API routes to the permission required, see acl.Default.
+// Read-only routes that aren't listed may be used by all users who are logged in, see openRoutes.
+var routePermissions = map[string]acl.Permission{
+	"GET /api/v1/stats/usage":                             acl.Settings,
+	"GET /api/v1/admin/queries":                           acl.Settings,
+	"GET /api/v1/accounts":                                acl.Settings,
+	"GET /api/v1/accounts/:id":                            acl.Settings,
+	"GET /api/v1/accounts/:id/dirs":                       acl.Settings,
+	"GET /api/v1/guests":                                  acl.Share,
+	"POST /api/v1/upload/:path":                           acl.PhotoUpload,
+	"POST /api/v1/import/*path":                           acl.PhotoUpload,
+	"POST /api/v1/import-url":                             acl.PhotoUpload,
+	"POST /api/v1/files/:uid":                             acl.PhotoUpload,
+	"POST /api/backup/v1/batches":                         acl.PhotoUpload,
+	"POST /api/backup/v1/batches/:batch/uploads":          acl.PhotoUpload,
+	"PATCH /api/backup/v1/batches/:batch/uploads/:upload": acl.PhotoUpload,
+	"POST /api/backup/v1/batches/:batch/commit":           acl.PhotoUpload,
+	"POST /immich/api/asset/upload":                       acl.PhotoUpload,
+	"POST /api/v1/devices":                                acl.PhotoUpload,
+	"PUT /api/v1/devices/:uid":                            acl.PhotoUpload,
+	"DELETE /api/v1/devices/:uid":                         acl.PhotoUpload,
+	"PUT /api/v1/photos/:uid":                             acl.PhotoEdit,
+	"POST /api/v1/photos/:uid/unlock":                     acl.PhotoEdit,
+	"POST /api/v1/photos/:uid/like":                       acl.PhotoEdit,
+	"DELETE /api/v1/photos/:uid/like":                     acl.PhotoEdit,
+	"POST /api/v1/photos/:uid/primary/:file_uid":          acl.PhotoEdit,
+	"POST /api/v1/batch/photos/private":                   acl.PhotoEdit,
+	"POST /api/v1/batch/photos/license":                   acl.PhotoEdit,
+	"POST /api/v1/batch/photos/subjects":                  acl.PhotoEdit,
+	"POST /api/v1/batch/photos/date":                      acl.PhotoDate,
+	"POST /api/v1/batch/photos/rotate":                    acl.PhotoEdit,
+	"POST /api/v1/presets/:uid/apply":                     acl.PhotoEdit,
+	"POST /api/v1/geometry/:uid/apply":                    acl.PhotoEdit,
+	"POST /api/v1/geometry/:uid/dismiss":                  acl.PhotoEdit,
+	"POST /api/v1/nsfw/:uid/approve":                      acl.PhotoEdit,
+	"POST /api/v1/batch/photos/archive":                   acl.PhotoDelete,
+	"POST /api/v1/batch/photos/restore":                   acl.PhotoDelete,
+	"POST /api/v1/duplicates/resolve":                     acl.PhotoDelete,
+	"POST /api/v1/duplicates/undo/:uid":                   acl.PhotoDelete,
+	"POST /api/v1/index/missing/purge":                    acl.PhotoDelete,
+	"DELETE /api/v1/quarantine/:id":                       acl.PhotoDelete,
+	"POST /api/v1/photos/:uid/label":                      acl.LabelEdit,
+	"PUT /api/v1/photos/:uid/label/:id":                   acl.LabelEdit,
+	"DELETE /api/v1/photos/:uid/label/:id":                acl.LabelEdit,
+	"PUT /api/v1/labels/:uid":                             acl.LabelEdit,
+	"POST /api/v1/labels/:uid/like":                       acl.LabelEdit,
+	"DELETE /api/v1/labels/:uid/like":                     acl.LabelEdit,
+	"PUT /api/v1/labels/:uid/cover":                       acl.LabelEdit,
+	"DELETE /api/v1/labels/:uid/cover":                    acl.LabelEdit,
+	"POST /api/v1/batch/labels/delete":                    acl.LabelDelete,
+	"POST /api/v1/albums":                                 acl.AlbumEdit,
+	"PUT /api/v1/albums/:uid":                             acl.AlbumEdit,
+	"PUT /api/v1/albums/:uid/parent":                      acl.AlbumEdit,
+	"PUT /api/v1/albums/:uid/story":                       acl.AlbumEdit,
+	"PUT /api/v1/albums/:uid/cover":                       acl.AlbumEdit,
+	"DELETE /api/v1/albums/:uid/cover":                    acl.AlbumEdit,
+	"POST /api/v1/albums/:uid/like":                       acl.AlbumEdit,
+	"DELETE /api/v1/albums/:uid/like":                     acl.AlbumEdit,
+	"POST /api/v1/albums/:uid/photos":                     acl.AlbumEdit,
+	"DELETE /api/v1/albums/:uid/photos":                   acl.AlbumEdit,
+	"PUT /api/v1/albums/:uid/photos/order":                acl.AlbumEdit,
+	"PUT /api/v1/albums/:uid/captions/:photo":             acl.AlbumEdit,
+	"POST /api/v1/albums/:uid/csv":                        acl.AlbumEdit,
+	"POST /api/v1/albums/:uid/clone":                      acl.AlbumEdit,
+	"POST /api/v1/albums/:uid/highlights":                 acl.AlbumEdit,
+	"POST /api/v1/photos/:uid/albums":                     acl.AlbumEdit,
+	"POST /api/v1/album-rules":                            acl.AlbumEdit,
+	"PUT /api/v1/album-rules/:uid":                        acl.AlbumEdit,
+	"DELETE /api/v1/album-rules/:uid":                     acl.AlbumEdit,
+	"DELETE /api/v1/albums/:uid":                          acl.AlbumDelete,
+	"POST /api/v1/albums/:uid/merge":                      acl.AlbumDelete,
+	"POST /api/v1/batch/albums/delete":                    acl.AlbumDelete,
+	"POST /api/v1/photos/:uid/link":                       acl.Share,
+	"POST /api/v1/files/:uid/link":                        acl.Share,
+	"POST /api/v1/labels/:uid/link":                       acl.Share,
+	"POST /api/v1/albums/:uid/link":                       acl.Share,
+	"POST /api/v1/albums/:uid/print":                      acl.Share,
+	"POST /api/v1/snapshots":                              acl.Share,
+	"DELETE /api/v1/snapshots/:uid":                       acl.Share,
+	"POST /api/v1/snapshots/:uid/link":                    acl.Share,
+	"POST /api/v1/chat":                                   acl.Share,
+	"POST /api/v1/accounts/:id/share":                     acl.Share,
+	"PUT /api/v1/links/:token/url":                        acl.Share,
+	"PUT /api/v1/links/:token/scope":                      acl.Share,
+	"PUT /api/v1/links/:token/restrictions":               acl.Share,
+	"PUT /api/v1/links/:token/renewal":                    acl.Share,
+	"POST /api/v1/links/:token/renew":                     acl.Share,
+	"DELETE /api/v1/links/:token":                         acl.Share,
+	"POST /api/v1/reactions/:id/hide":                     acl.Share,
+	"DELETE /api/v1/reactions/:id/hide":                   acl.Share,
+	"DELETE /api/v1/reactions/:id":                        acl.Share,
+	"POST /api/v1/guests/:uid/upgrade":                    acl.Share,
+	"DELETE /api/v1/guests/:uid":                          acl.Share,
+	"POST /api/v1/index":                                  acl.Library,
+	"DELETE /api/v1/index":                                acl.Library,
+	"POST /api/v1/index/pause":                            acl.Library,
+	"POST /api/v1/index/resume":                           acl.Library,
+	"DELETE /api/v1/import":                               acl.Library,
+	"POST /api/v1/index/missing/relocate":                 acl.Library,
+	"POST /api/v1/errors/:id/retry":                       acl.Library,
+	"POST /api/v1/errors/:id/ignore":                      acl.Library,
+	"DELETE /api/v1/thumbs/errors/:id":                    acl.Library,
+	"POST /api/v1/quarantine/:id/release":                 acl.Library,
+	"POST /api/v1/checkpoints":                            acl.Library,
+	"POST /api/v1/checkpoints/:uid/restore":               acl.Library,
+	"DELETE /api/v1/checkpoints/:uid":                     acl.Library,
+	"POST /api/v1/settings":                               acl.Settings,
+	"POST /api/v1/presets":                                acl.Settings,
+	"PUT /api/v1/presets/:uid":                            acl.Settings,
+	"DELETE /api/v1/presets/:uid":                         acl.Settings,
+	"POST /api/v1/accounts":                               acl.Settings,
+	"PUT /api/v1/accounts/:id":                            acl.Settings,
+	"DELETE /api/v1/accounts/:id":                         acl.Settings,
+	"DELETE /api/v1/admin/queries/:id":                    acl.Settings,
+	"DELETE /api/v1/admin/jobs/:name":                     acl.Settings,
+}
+
+// openRoutes lists mutating routes that may be used by all users who are logged in, e.g. to log in or to
+// create downloads. Other mutating routes that aren't listed in routePermissions are restricted to admins.
+var openRoutes = map[string]bool{
+	"POST /api/v1/session":                     true,
+	"DELETE /api/v1/session/:token":            true,
+	"POST /api/v1/saml/acs":                    true,
+	"POST /api/v1/guest/session":               true,
+	"POST /api/v1/s/:token/guest":              true,
+	"POST /api/v1/s/:token/reactions":          true,
+	"POST /api/v1/s/:token/unlock":             true,
+	"POST /api/v1/zip":                         true,
+	"POST /api/v1/albums/:uid/archive":         true,
+	"POST /api/v1/stats/display":               true,
+	"DELETE /api/v1/searches/history":          true,
+	"DELETE /api/v1/searches/history/:id":      true,
+	"POST /api/v1/searches/history/:id/pin":    true,
+	"DELETE /api/v1/searches/history/:id/pin":  true,
+	"POST /api/backup/v1/check":                true,
+	"POST /immich/api/auth/login":              true,
+	"POST /immich/api/asset/bulk-upload-check": true,
+}
+
+// policyRole is the context key of the role checked by the policy, see policyAllowed.
+const policyRole = "policy.role"
+
+// RouteMapped returns true if the policy explicitly allows or restricts a route like "POST /api/v1/zip".
+func RouteMapped(route string) bool {
+	_, ok := routePermissions[route]
+
+	return ok || openRoutes[route]
+}
+
+// mutating returns true if the request method may change data.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// sessionRole returns the role of the user who sent the request, or an empty string if not logged in.
+func sessionRole(c *gin.Context) string {
+	data, ok := service.Session().Get(c.GetHeader("X-Session-Token"))
+
+	if !ok {
+		return ""
+	}
+
+	return sessionValue(data, "Role")
+}
+
+// Policy returns a middleware that checks if the user has permission to perform a mutating or admin-only
+// request, based on the role and acl.Default. Requests without session and guest requests are passed on,
+// so that handlers can respond with 401 Unauthorized or grant access by share token. Other roles that
+// aren't defined by the policy are rejected.
+func Policy(conf *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Everybody has full access if the site is public.
+		if conf.Public() {
+			c.Next()
+			return
+		}
+
+		enforcePolicy(c, sessionRole(c))
+	}
+}
+
+// enforcePolicy aborts the request with 403 Forbidden if the role doesn't have the permission required
+// by the route. Mutating routes that aren't mapped may only be used by admins.
+func enforcePolicy(c *gin.Context, role string) {
+	if role == "" || role == acl.RoleGuest {
+		c.Next()
+		return
+	} else if !acl.Default.Known(role) {
+		log.Warnf("policy: unknown role %s", txt.Quote(role))
+		policyDenied(c)
+		return
+	}
+
+	route := c.Request.Method + " " + c.FullPath()
+
+	if perm, ok := routePermissions[route]; ok && !acl.Allowed(role, perm) {
+		log.Warnf("policy: %s may not %s (%s)", role, route, perm)
+		policyDenied(c)
+		return
+	} else if !ok && mutating(c.Request.Method) && !openRoutes[route] && role != acl.RoleAdmin {
+		log.Warnf("policy: %s may not %s (not mapped)", role, route)
+		policyDenied(c)
+		return
+	}
+
+	c.Set(policyRole, role)
+
+	c.Next()
+}
+
+// policyDenied aborts the request with 403 Forbidden, using the error envelope of the API version.
+func policyDenied(c *gin.Context) {
+	if strings.HasPrefix(c.FullPath(), "/api/v2/") {
+		v2AbortWith(c, ErrPermissionDenied)
+	} else {
+		c.AbortWithStatusJSON(http.StatusForbidden, ErrPermissionDenied)
+	}
+}
+
+// policyAllowed returns true if the role checked by the policy has the permission, e.g. to change
+// single form fields. Everybody has full access if the site is public.
+func policyAllowed(c *gin.Context, perm acl.Permission) bool {
+	role := c.GetString(policyRole)
+
+	return role == "" || acl.Allowed(role, perm)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestEnforcePolicy(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	router.Use(func(c *gin.Context) {
+		enforcePolicy(c, c.GetHeader("X-Test-Role"))
+	})
+
+	GetPhoto(router, conf)
+	UpdatePhoto(router, conf)
+	AddPhotoLabel(router, conf)
+	BatchPhotosArchive(router, conf)
+	GetUsageStats(router, conf)
+	GetQueries(router, conf)
+	GetGuests(router, conf)
+
+	router.POST("/policy/unmapped", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	contributor := map[string]string{"X-Test-Role": acl.RoleContributor}
+
+	t.Run("contributor may not delete photos", func(t *testing.T) {
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/batch/photos/archive", `{"photos": ["pt9jtdre2lvl0y13"]}`, contributor)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("contributor may add labels", func(t *testing.T) {
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y13/label", `{"Name": "contributed", "Uncertainty": 10, "Priority": 2}`, contributor)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("contributor may not change dates", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13")
+		takenAt := gjson.Get(r.Body.String(), "TakenAt").String()
+
+		r = PerformRequestWithHeaders(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", `{"Title": "Contributed", "TakenAt": "2001-01-01T00:00:00Z", "TakenAtLocal": "2001-01-01T00:00:00Z"}`, contributor)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Contributed", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, takenAt, gjson.Get(r.Body.String(), "TakenAt").String())
+	})
+	t.Run("contributor may not change dates with lowercase keys", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13")
+		takenAt := gjson.Get(r.Body.String(), "TakenAt").String()
+
+		r = PerformRequestWithHeaders(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13", `{"takenat": "2001-01-01T00:00:00Z", "TAKENATLOCAL": "2001-01-01T00:00:00Z"}`, contributor)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, takenAt, gjson.Get(r.Body.String(), "TakenAt").String())
+	})
+	t.Run("contributor may not use unmapped routes", func(t *testing.T) {
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/policy/unmapped", "", contributor)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("admin may use unmapped routes", func(t *testing.T) {
+		r := PerformRequestWithHeaders(app, "POST", "/api/v1/policy/unmapped", "", map[string]string{"X-Test-Role": acl.RoleAdmin})
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("contributor may not read admin pages", func(t *testing.T) {
+		for _, route := range []string{"/api/v1/stats/usage", "/api/v1/admin/queries", "/api/v1/guests"} {
+			r := PerformRequestWithHeaders(app, "GET", route, "", contributor)
+			assert.Equal(t, http.StatusForbidden, r.Code, route)
+		}
+	})
+	t.Run("unknown role", func(t *testing.T) {
+		r := PerformRequestWithHeaders(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13", "", map[string]string{"X-Test-Role": "visitor"})
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("no session", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/archive", `{"photos": ["pt9jtdre2lvl0yxx"]}`)
+		assert.NotEqual(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestPolicyDenied(t *testing.T) {
+	app, _, conf := NewApiTest()
+
+	v2 := app.Group("/api/v2", func(c *gin.Context) {
+		enforcePolicy(c, c.GetHeader("X-Test-Role"))
+	})
+
+	GetAlbumV2(v2, conf)
+
+	r := PerformRequestWithHeaders(app, "GET", "/api/v2/albums/at9lxuqxpogaaba8", "", map[string]string{"X-Test-Role": "visitor"})
+	assert.Equal(t, http.StatusForbidden, r.Code)
+	assert.Equal(t, int64(http.StatusForbidden), gjson.Get(r.Body.String(), "error.code").Int())
+	assert.Equal(t, "Permission denied", gjson.Get(r.Body.String(), "error.message").String())
+}
+
+func TestRoutePermissions(t *testing.T) {
+	for route, perm := range routePermissions {
+		assert.Contains(t, acl.Permissions, perm, route)
+		assert.Regexp(t, `^(GET|POST|PUT|PATCH|DELETE) /(immich/)?api/`, route)
+	}
+
+	for route := range openRoutes {
+		assert.NotContains(t, routePermissions, route)
+		assert.Regexp(t, `^(POST|PUT|PATCH|DELETE) /(immich/)?api/`, route)
+	}
+}
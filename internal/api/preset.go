@@ -0,0 +1,200 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/presets
+func GetPresets(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/presets", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		results, err := query.Presets()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/presets
+//
+// Creates a named list of actions, e.g. adding a label and setting photos private,
+// that can be applied to a selection of photos at once.
+func CreatePreset(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/presets", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.Preset
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, err := entity.NewPreset(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Create(); err != nil {
+			log.Errorf("preset: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("preset %s created", txt.Quote(m.PresetName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// PUT /api/v1/presets/:uid
+//
+// Parameters:
+//   uid: string Preset UID
+func UpdatePreset(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/presets/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.PresetByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPresetNotFound)
+			return
+		}
+
+		var f form.Preset
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.SetForm(f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("preset: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("preset %s saved", txt.Quote(m.PresetName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/presets/:uid
+//
+// Parameters:
+//   uid: string Preset UID
+func DeletePreset(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/presets/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.PresetByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPresetNotFound)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("preset: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("preset %s deleted", txt.Quote(m.PresetName)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/presets/:uid/apply
+//
+// Applies all actions of a preset to the selected photos.
+//
+// Parameters:
+//   uid: string Preset UID
+func ApplyPreset(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/presets/:uid/apply", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.PresetByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPresetNotFound)
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		photos, err := query.PhotoSelection(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if len(photos) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No photos selected"})
+			return
+		}
+
+		if err := photoprism.ApplyPreset(m, photos); err != nil {
+			log.Error(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if results, err := query.PhotoSelection(f); err != nil {
+			log.Errorf("preset: %s", err)
+		} else {
+			event.EntitiesUpdated("photos", results)
+		}
+
+		UpdateClientConfig(conf)
+
+		event.Success(fmt.Sprintf("preset %s applied to %d photos", txt.Quote(m.PresetName), len(photos)))
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("preset applied to %d photos", len(photos)), "photos": len(photos)})
+	})
+}
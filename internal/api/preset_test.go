@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreatePreset(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreatePreset(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/presets", `{"Name": "Family", "Actions": [{"Action": "add-label", "Value": "family"}, {"Action": "private"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Family", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "private", gjson.Get(r.Body.String(), "Actions.1.Action").String())
+	})
+	t.Run("unknown action", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreatePreset(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/presets", `{"Name": "Invalid", "Actions": [{"Action": "xxx"}]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("name missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreatePreset(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/presets", `{"Actions": [{"Action": "private"}]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestApplyPreset(t *testing.T) {
+	app, router, conf := NewApiTest()
+	CreatePreset(router, conf)
+	UpdatePreset(router, conf)
+	GetPresets(router, conf)
+	ApplyPreset(router, conf)
+	DeletePreset(router, conf)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/presets", `{"Name": "Favorites", "Actions": [{"Action": "favorite", "Value": "false"}]}`)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("update", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/presets/"+uid, `{"Name": "Favorites", "Actions": [{"Action": "favorite"}]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Actions.0.Value").String())
+	})
+	t.Run("list", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/presets")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#").Int() > 0)
+	})
+	t.Run("apply", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/presets/"+uid+"/apply", `{"photos": ["pt9jtdre2lvl0y24"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "photos").Int())
+	})
+	t.Run("no selection", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/presets/"+uid+"/apply", `{"photos": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("delete", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/presets/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequestWithBody(app, "POST", "/api/v1/presets/"+uid+"/apply", `{"photos": ["pt9jtdre2lvl0y24"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -65,7 +65,8 @@ func GetPreview(router *gin.RouterGroup, conf *config.Config) {
 		for _, f := range p {
 			fileName := path.Join(conf.OriginalsPath(), f.FileName)
 
-			if !fs.FileExists(fileName) {
+			// Thumbnails of originals in cold storage are kept, so they can still be used if cached.
+			if !f.FileCold && !fs.FileExists(fileName) {
 				log.Errorf("preview: file %s is missing", txt.Quote(f.FileName))
 				c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
 				return
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// quarantineFile returns the quarantined file with the id in the request or aborts with status 404.
+func quarantineFile(c *gin.Context) (result entity.QuarantineFile, ok bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if err == nil {
+		result, err = query.QuarantineFileByID(uint(id))
+	}
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrNotQuarantined)
+		return result, false
+	}
+
+	return result, true
+}
+
+// GET /api/v1/quarantine
+//
+// Returns suspicious uploads that were moved to quarantine, see upload-quarantine.
+//
+// Parameters:
+//   source: string Upload source, e.g. upload or webdav
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetQuarantine(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/quarantine", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.QuarantineFiles
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.QuarantineFiles(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/quarantine/:id/release
+//
+// Moves a reviewed file to the "quarantine" folder in the import path, so that it can be imported.
+//
+// Parameters:
+//   id: int Quarantine ID as returned by the API
+func ReleaseQuarantine(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/quarantine/:id/release", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		m, ok := quarantineFile(c)
+
+		if !ok {
+			return
+		}
+
+		fileName, err := photoprism.ReleaseQuarantined(conf, m)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("file released from quarantine")
+
+		c.JSON(http.StatusOK, gin.H{"FileName": fileName})
+	})
+}
+
+// DELETE /api/v1/quarantine/:id
+//
+// Deletes a quarantined file permanently.
+//
+// Parameters:
+//   id: int Quarantine ID as returned by the API
+func DeleteQuarantine(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/quarantine/:id", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := quarantineFile(c)
+
+		if !ok {
+			return
+		}
+
+		if err := photoprism.DeleteQuarantined(conf, m); err != nil {
+			log.Errorf("upload: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		event.Success("file deleted from quarantine")
+
+		c.JSON(http.StatusOK, m)
+	})
+}
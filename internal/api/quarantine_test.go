@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQuarantine(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetQuarantine(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/quarantine?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetQuarantine(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/quarantine")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestReleaseQuarantine(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ReleaseQuarantine(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/quarantine/999999/release")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteQuarantine(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DeleteQuarantine(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/quarantine/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
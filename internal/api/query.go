@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Job represents a running background worker.
+type Job struct {
+	Name     string    `json:"Name"`
+	Started  time.Time `json:"Started"`
+	Seconds  int       `json:"Seconds"`
+	Canceled bool      `json:"Canceled"`
+}
+
+// runningJobs returns the background workers that are busy, sorted by name.
+func runningJobs() (results []Job) {
+	results = []Job{}
+
+	for name, w := range mutex.Workers {
+		started := w.Started()
+
+		if started.IsZero() {
+			continue
+		}
+
+		results = append(results, Job{
+			Name:     name,
+			Started:  started,
+			Seconds:  int(time.Since(started).Seconds()),
+			Canceled: w.Canceled(),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return results
+}
+
+// GET /api/v1/admin/queries
+//
+// Returns the statements running in the database, longest running first, and busy background workers.
+func GetQueries(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/admin/queries", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		queries, err := query.Processes()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Queries": queries, "Jobs": runningJobs()})
+	})
+}
+
+// DELETE /api/v1/admin/queries/:id
+//
+// Cancels a statement running in the database, e.g. a pathological search.
+//
+// Parameters:
+//   id: int Process ID as returned by GetQueries
+func CancelQuery(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/admin/queries/:id", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid process id"})
+			return
+		}
+
+		if err := query.KillQuery(id, conf.DatabaseDriver() == config.DriverTidb); err != nil {
+			log.Errorf("admin: %s", err)
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrQueryNotFound)
+			return
+		}
+
+		log.Infof("admin: canceled query %d", id)
+
+		c.JSON(http.StatusOK, gin.H{"message": "query canceled", "ID": id})
+	})
+}
+
+// DELETE /api/v1/admin/jobs/:name
+//
+// Cancels a running background worker, see mutex.Workers.
+//
+// Parameters:
+//   name: string Worker name, e.g. main or sync
+func CancelJob(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/admin/jobs/:name", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		name := c.Param("name")
+		w, ok := mutex.Workers[name]
+
+		if !ok || !w.Busy() {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrJobNotFound)
+			return
+		}
+
+		w.Cancel()
+
+		event.Info("job " + name + " canceled")
+
+		c.JSON(http.StatusOK, gin.H{"message": "job canceled", "Name": name})
+	})
+}
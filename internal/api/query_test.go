@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelQuery(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CancelQuery(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/admin/queries/abc")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestCancelJob(t *testing.T) {
+	t.Run("not running", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CancelJob(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/admin/jobs/sync")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CancelJob(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/admin/jobs/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
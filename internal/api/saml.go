@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/saml"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Pending authentication requests expire after samlRequestExpires.
+const samlRequestExpires = 10 * time.Minute
+
+// samlLoginPage stores the session token in the browser and redirects to the app.
+var samlLoginPage = template.Must(template.New("saml").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body><script>
+window.localStorage.setItem("session_storage", "false");
+window.localStorage.setItem("session_token", {{.Token}});
+window.localStorage.setItem("user", {{.User}});
+window.location.replace({{.Url}});
+</script></body></html>
+`))
+
+// samlProvider returns the SAML service provider based on the current config.
+func samlProvider(conf *config.Config) (*saml.ServiceProvider, error) {
+	cert, err := ioutil.ReadFile(conf.SamlIdpCert())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return saml.NewServiceProvider(conf.SamlEntityID(), conf.SamlAcsUrl(), conf.SamlIdpUrl(), cert)
+}
+
+// GET /api/v1/saml/metadata
+func SamlMetadata(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/saml/metadata", func(c *gin.Context) {
+		if !conf.SamlEnabled() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		sp, err := samlProvider(conf)
+
+		if err != nil {
+			log.Errorf("saml: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		data, err := sp.Metadata()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/samlmetadata+xml", data)
+	})
+}
+
+// GET /api/v1/saml/login
+func SamlLogin(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/saml/login", func(c *gin.Context) {
+		if !conf.SamlEnabled() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		sp, err := samlProvider(conf)
+
+		if err != nil {
+			log.Errorf("saml: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		redirect, id, err := sp.AuthnRequestURL("")
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		service.Cache().Set("saml-request:"+id, true, samlRequestExpires)
+
+		c.Redirect(http.StatusFound, redirect)
+	})
+}
+
+// POST /api/v1/saml/acs
+func SamlAcs(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/saml/acs", func(c *gin.Context) {
+		if !conf.SamlEnabled() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		sp, err := samlProvider(conf)
+
+		if err != nil {
+			log.Errorf("saml: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+			return
+		}
+
+		a, err := sp.ParseResponse(c.PostForm("SAMLResponse"))
+
+		if err != nil {
+			log.Errorf("%s", err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		gc := service.Cache()
+
+		// Only accept responses to pending requests.
+		if _, ok := gc.Get("saml-request:" + a.InResponseTo); !ok || a.InResponseTo == "" {
+			log.Errorf("saml: unknown request %s", txt.Quote(a.InResponseTo))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		gc.Delete("saml-request:" + a.InResponseTo)
+
+		// Assertions must not be replayed.
+		if err := gc.Add("saml-assertion:"+a.ID, true, time.Until(a.Expires)+saml.MaxClockSkew); err != nil {
+			log.Errorf("saml: assertion %s has already been used", txt.Quote(a.ID))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var role string
+
+		if a.HasValue(conf.SamlRoleAttr(), conf.SamlAdminRole()) {
+			role = config.RoleAdmin
+		} else if a.HasValue(conf.SamlRoleAttr(), conf.SamlContributorRole()) {
+			role = config.RoleContributor
+		} else {
+			log.Warnf("saml: %s is not allowed to log in", txt.Quote(a.NameID))
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrPermissionDenied)
+			return
+		}
+
+		email := a.Attribute("mail")
+
+		if email == "" {
+			email = a.Attribute("email")
+		}
+
+		firstName := a.Attribute("givenName")
+
+		if firstName == "" {
+			firstName = a.NameID
+		}
+
+		// The name id identifies users without email address, see sessionUserID.
+		user := gin.H{"ID": 1, "FirstName": firstName, "LastName": a.Attribute("sn"), "Role": role, "Email": email, "NameID": a.NameID}
+
+		token := service.Session().Create(user)
+
+		log.Infof("saml: %s logged in as %s", txt.Quote(a.NameID), role)
+
+		userJson, err := json.Marshal(user)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+
+		if err := samlLoginPage.Execute(c.Writer, gin.H{"Title": conf.Title(), "Token": token, "User": string(userJson), "Url": conf.Url()}); err != nil {
+			log.Errorf("saml: %s", err)
+		}
+	})
+}
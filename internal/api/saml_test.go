@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestSamlMetadata(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SamlMetadata(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/saml/metadata")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+		assert.Equal(t, "Feature disabled", gjson.Get(r.Body.String(), "error").String())
+	})
+}
+
+func TestSamlLogin(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SamlLogin(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/saml/login")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestSamlAcs(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SamlAcs(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/saml/acs", "SAMLResponse=foo")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Search suggestions returned by default and at most.
+const (
+	searchSuggestionsCount = 10
+	searchSuggestionsMax   = 50
+)
+
+// searchHistory returns the search with the id in the request if it belongs to the user, or aborts with status 404.
+func searchHistory(c *gin.Context) (result entity.SearchHistory, ok bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if err == nil {
+		result, err = query.SearchHistoryByID(sessionUserID(c), uint(id))
+	}
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrSearchNotFound)
+		return result, false
+	}
+
+	return result, true
+}
+
+// addSearchHistory adds a photo search query to the history of the user who sent the request.
+func addSearchHistory(c *gin.Context, q string) {
+	if _, err := entity.AddSearchHistory(sessionUserID(c), q); err != nil {
+		log.Errorf("search: %s", err)
+	}
+}
+
+// GET /api/v1/searches/history
+//
+// Returns recent photo searches of the current user, pinned searches first.
+//
+// Parameters:
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetSearchHistory(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/searches/history", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.SearchHistory
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.SearchHistory(sessionUserID(c), f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// DELETE /api/v1/searches/history
+//
+// Removes all searches of the current user that are not pinned.
+func ClearSearchHistory(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/searches/history", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if err := entity.ClearSearchHistory(sessionUserID(c)); err != nil {
+			log.Errorf("search: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "search history cleared"})
+	})
+}
+
+// DELETE /api/v1/searches/history/:id
+//
+// Removes a search from the history of the current user.
+//
+// Parameters:
+//   id: int Search ID
+func DeleteSearchHistory(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/searches/history/:id", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := searchHistory(c)
+
+		if !ok {
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("search: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/searches/history/:id/pin
+//
+// Pins a search, so that it is listed and suggested first and kept in the history.
+//
+// Parameters:
+//   id: int Search ID
+func PinSearch(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/searches/history/:id/pin", func(c *gin.Context) {
+		pinSearch(c, conf, true)
+	})
+}
+
+// DELETE /api/v1/searches/history/:id/pin
+//
+// Unpins a search.
+//
+// Parameters:
+//   id: int Search ID
+func UnpinSearch(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/searches/history/:id/pin", func(c *gin.Context) {
+		pinSearch(c, conf, false)
+	})
+}
+
+// pinSearch pins or unpins the search with the id in the request.
+func pinSearch(c *gin.Context, conf *config.Config, pinned bool) {
+	if Unauthorized(c, conf) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	m, ok := searchHistory(c)
+
+	if !ok {
+		return
+	}
+
+	if err := m.SetPinned(pinned); err != nil {
+		log.Errorf("search: %s", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+		return
+	}
+
+	c.JSON(http.StatusOK, m)
+}
+
+// GET /api/v1/searches/history/:id/photos
+//
+// Runs a search from the history again and returns the photos found, like GET /api/v1/photos.
+//
+// Parameters:
+//   id: int Search ID
+//   count: int Max result count (required)
+//   offset: int Result offset
+//   order: string Sort order
+func RunSearch(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/searches/history/:id/photos", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := searchHistory(c)
+
+		if !ok {
+			return
+		}
+
+		var f form.PhotoSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		f.Query = m.SearchQuery
+
+		result, _, total, err := query.PhotoSearchTotal(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(total))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		if f.Offset == 0 {
+			countUsage(conf, entity.UsageSearch)
+			addSearchHistory(c, m.SearchQuery)
+		}
+
+		fieldsJSON(c, http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/searches/suggestions
+//
+// Returns queries to suggest while typing a photo search, based on the search history of the
+// current user and label names.
+//
+// Parameters:
+//   q: string Beginning of the query, empty for pinned and frequent searches
+//   count: int Max result count, 10 by default
+func GetSearchSuggestions(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/searches/suggestions", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.SearchSuggestions
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Count <= 0 {
+			f.Count = searchSuggestionsCount
+		} else if f.Count > searchSuggestionsMax {
+			f.Count = searchSuggestionsMax
+		}
+
+		results, err := query.SearchSuggestions(sessionUserID(c), f.Query, f.Count)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestSearchHistory(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetPhotos(router, conf)
+	GetSearchHistory(router, conf)
+	PinSearch(router, conf)
+	UnpinSearch(router, conf)
+	RunSearch(router, conf)
+	DeleteSearchHistory(router, conf)
+
+	r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q=history+test")
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "GET", "/api/v1/searches/history?count=100")
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	var id int64
+
+	for _, s := range gjson.Parse(r.Body.String()).Array() {
+		if s.Get("Query").String() == "history test" {
+			id = s.Get("ID").Int()
+			assert.Equal(t, int64(1), s.Get("Count").Int())
+		}
+	}
+
+	if id == 0 {
+		t.Fatal("search not found in history")
+	}
+
+	t.Run("pin", func(t *testing.T) {
+		r := PerformRequest(app, "POST", fmt.Sprintf("/api/v1/searches/history/%d/pin", id))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Pinned").Bool())
+
+		r = PerformRequest(app, "GET", "/api/v1/searches/history?count=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "history test", gjson.Get(r.Body.String(), "0.Query").String())
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/searches/history/%d/pin", id))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "Pinned").Bool())
+	})
+	t.Run("run again", func(t *testing.T) {
+		r := PerformRequest(app, "GET", fmt.Sprintf("/api/v1/searches/history/%d/photos?count=10", id))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("delete", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/searches/history/%d", id))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "Count").Int())
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/searches/history/%d", id))
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("count missing", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/searches/history")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/searches/history/xxx/pin")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetSearchSuggestions(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetPhotos(router, conf)
+	GetSearchSuggestions(router, conf)
+
+	r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q=landscape+sunset")
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "GET", "/api/v1/searches/suggestions?q=lands")
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	results := gjson.Parse(r.Body.String()).Array()
+
+	if assert.GreaterOrEqual(t, len(results), 2) {
+		assert.Equal(t, "landscape sunset", results[0].Get("Query").String())
+		assert.Equal(t, "history", results[0].Get("Source").String())
+		assert.Equal(t, "Landscape", results[1].Get("Query").String())
+		assert.Equal(t, "label", results[1].Get("Source").String())
+	}
+}
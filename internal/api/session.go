@@ -25,9 +25,7 @@ func CreateSession(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		user := gin.H{"ID": 1, "FirstName": "Admin", "LastName": "", "Role": "admin", "Email": "photoprism@localhost"}
-
-		token := service.Session().Create(user)
+		token, user := newAdminSession()
 
 		c.Header("X-Session-Token", token)
 
@@ -37,6 +35,14 @@ func CreateSession(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// newAdminSession creates a session for the admin user after the password was checked, and returns
+// its token and the user data.
+func newAdminSession() (token string, user gin.H) {
+	user = gin.H{"ID": 1, "FirstName": "Admin", "LastName": "", "Role": config.RoleAdmin, "Email": "photoprism@localhost"}
+
+	return service.Session().Create(user), user
+}
+
 // DELETE /api/v1/session/
 func DeleteSession(router *gin.RouterGroup, conf *config.Config) {
 	router.DELETE("/session/:token", func(c *gin.Context) {
@@ -58,8 +64,51 @@ func Unauthorized(c *gin.Context, conf *config.Config) bool {
 	// Get session token from HTTP header
 	token := c.GetHeader("X-Session-Token")
 
-	// Check if session token is valid
-	return !service.Session().Exists(token)
+	// Check if session token is valid, guests only have access to the links they were given
+	data, ok := service.Session().Get(token)
+
+	return !ok || sessionValue(data, "Role") == config.RoleGuest
+}
+
+// sessionValue returns a string value of the session data.
+func sessionValue(data interface{}, key string) string {
+	var values map[string]interface{}
+
+	switch d := data.(type) {
+	case gin.H:
+		values = d
+	case map[string]interface{}:
+		values = d
+	default:
+		return ""
+	}
+
+	if s, ok := values[key].(string); ok {
+		return s
+	}
+
+	return ""
+}
+
+// sessionUserID returns a key identifying the user who sent the request, e.g. to store searches per user.
+// SAML users without email address are identified by their name id. Users of public sites without session
+// share an empty key.
+func sessionUserID(c *gin.Context) string {
+	data, ok := service.Session().Get(c.GetHeader("X-Session-Token"))
+
+	if !ok {
+		return ""
+	}
+
+	if uid := sessionValue(data, "GuestUID"); uid != "" {
+		return "guest:" + uid
+	} else if email := sessionValue(data, "Email"); email != "" {
+		return email
+	} else if nameID := sessionValue(data, "NameID"); nameID != "" {
+		return "saml:" + nameID
+	}
+
+	return ""
 }
 
 // InvalidToken returns true if the token is invalid.
@@ -1,10 +1,14 @@
 package api
 
 import (
-	"github.com/stretchr/testify/assert"
-	"github.com/tidwall/gjson"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestCreateSession(t *testing.T) {
@@ -45,3 +49,31 @@ func TestDeleteSession(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestSessionUserID(t *testing.T) {
+	NewApiTest()
+
+	userID := func(user gin.H) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("GET", "/api/v1/searches/history", nil)
+		c.Request.Header.Set("X-Session-Token", service.Session().Create(user))
+
+		return sessionUserID(c)
+	}
+
+	t.Run("email", func(t *testing.T) {
+		assert.Equal(t, "jane@example.com", userID(gin.H{"Email": "jane@example.com", "NameID": "jane"}))
+	})
+	t.Run("saml name id", func(t *testing.T) {
+		assert.Equal(t, "saml:jane", userID(gin.H{"Email": "", "NameID": "jane"}))
+		assert.NotEqual(t, userID(gin.H{"NameID": "jane"}), userID(gin.H{"NameID": "john"}))
+	})
+	t.Run("guest", func(t *testing.T) {
+		assert.Equal(t, "guest:gt9jtdre2lvl0y11", userID(gin.H{"GuestUID": "gt9jtdre2lvl0y11"}))
+	})
+	t.Run("no session", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("GET", "/api/v1/searches/history", nil)
+		assert.Equal(t, "", sessionUserID(c))
+	})
+}
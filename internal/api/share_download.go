@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/archive"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/s/:token/dl
+//
+// Streams a zip archive containing the originals of all public photos in a shared album, if the
+// link permits downloads. Password protected links must be unlocked first, see UnlockShare.
+//
+// Parameters:
+//   token: string Share link token
+//   album: string Nested album UID (optional)
+//   favorite: bool Favorites only (optional)
+//   quality: int Minimum quality score (optional)
+//   label: string Label slug, e.g. the name of a person (optional)
+//   size: string Use "original" (default) or a thumbnail type like "fit_2048" or "fit_1280" for resized JPEGs
+//   raw: string Use "include" to add RAW originals, "exclude" by default
+//   convert: string Use "jpeg" to download JPEG versions of formats like HEIC and RAW (query)
+func DownloadShare(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/dl", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+			return
+		} else if !link.CanDownload {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrPermissionDenied)
+			return
+		}
+
+		start := time.Now()
+
+		albumUID := c.Query("album")
+
+		if albumUID == "" {
+			albumUID = link.ShareUID
+		}
+
+		if !rnd.IsPPID(albumUID, 'a') || !query.LinkSharesAlbum(link, albumUID) {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		a, err := query.AlbumByUID(albumUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		var f form.AlbumDownload
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		// Private photos are never shared.
+		s := f.PhotoSearch(a.AlbumUID)
+		s.Public = true
+
+		opt, err := newAlbumDownload(c, conf, f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		entries, err := albumEntries(conf, s, opt)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		zipBaseName := albumZipName(a)
+
+		countUsage(conf, entity.UsageDownload)
+
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", zipBaseName))
+		c.Status(http.StatusOK)
+
+		if err := archive.Write(c.Writer, entries, conf.Workers()); err != nil {
+			log.Errorf("share: failed streaming %s (%s)", txt.Quote(zipBaseName), err)
+			c.Abort()
+			return
+		}
+
+		log.Infof("share: streamed %s with %d files in %s", txt.Quote(zipBaseName), len(entries), time.Since(start))
+	})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadShare(t *testing.T) {
+	t.Run("link not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadShare(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/xxx/dl")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("password required", func(t *testing.T) {
+		link := entity.NewLink("foobar", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+		link.CanDownload = true
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		DownloadShare(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/dl")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = PerformRequestWithHeaders(app, "GET", "/api/v1/s/"+link.LinkToken+"/dl", "", map[string]string{"X-Share-Password": "foobar"})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/zip", r.Header().Get("Content-Type"))
+	})
+	t.Run("download not permitted", func(t *testing.T) {
+		link := entity.NewLink("", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		DownloadShare(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/dl")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("album not shared", func(t *testing.T) {
+		link := entity.NewLink("", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+		link.CanDownload = true
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		DownloadShare(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/dl?album=at9lxuqxpogaaba9")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/disintegration/imaging"
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Size of share link preview images as recommended for Open Graph and Twitter Cards.
+const (
+	sharePreviewWidth  = 1200
+	sharePreviewHeight = 630
+	sharePreviewPhotos = 4
+)
+
+// SharePreview contains the title, description and photos shown when a share link is pasted into a chat.
+type SharePreview struct {
+	Title       string
+	Description string
+	Photos      query.PhotoResults
+}
+
+// NewSharePreview returns the preview of a share link. Private or archived photos are never included.
+// Callers must check if password protected links are unlocked, see shareUnlocked.
+func NewSharePreview(link entity.Link) (result SharePreview) {
+	f := form.PhotoSearch{Count: sharePreviewPhotos * 2, Public: true}
+
+	if rnd.IsPPID(link.ShareUID, 'a') {
+		album, err := query.AlbumByUID(link.ShareUID)
+
+		if err != nil {
+			return result
+		}
+
+		result.Title = album.AlbumTitle
+		result.Description = album.AlbumDescription
+		f.Album = album.AlbumUID
+	} else if rnd.IsPPID(link.ShareUID, 's') {
+		snapshot, err := query.SnapshotByUID(link.ShareUID)
+
+		if err != nil {
+			return result
+		}
+
+		result.Title = snapshot.SnapshotTitle
+		f.Snapshot = snapshot.SnapshotUID
+	} else {
+		f.ID = link.ShareUID
+	}
+
+	photos, _, err := query.PhotoSearch(f)
+
+	if err != nil {
+		log.Errorf("share: %s", err)
+		return result
+	}
+
+	seen := make(map[string]bool)
+
+	for _, p := range photos {
+		if p.PhotoPrivate || !p.DeletedAt.IsZero() || p.FileVideo || seen[p.PhotoUID] {
+			continue
+		}
+
+		seen[p.PhotoUID] = true
+		result.Photos = append(result.Photos, p)
+
+		if len(result.Photos) >= sharePreviewPhotos {
+			break
+		}
+	}
+
+	if f.ID != "" && len(result.Photos) > 0 {
+		result.Title = result.Photos[0].PhotoTitle
+		result.Description = result.Photos[0].PhotoDescription
+	}
+
+	return result
+}
+
+// Hash returns a hash of the photos and watermark, so that cached preview images are replaced when they change.
+func (p SharePreview) Hash(w thumb.Watermark) string {
+	h := sha1.New()
+
+	for _, photo := range p.Photos {
+		h.Write([]byte(photo.FileHash))
+	}
+
+	h.Write([]byte(w.Hash()))
+
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// Image creates the preview image if needed and returns its file name.
+func (p SharePreview) Image(link entity.Link, conf *config.Config) (fileName string, err error) {
+	w := linkWatermark(link, conf)
+	previewPath := path.Join(conf.ThumbPath(), "share")
+	fileName = path.Join(previewPath, fmt.Sprintf("%s_%s.jpg", link.LinkToken, p.Hash(w)))
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	thumbType := thumb.Types["fit_720"]
+	var images []image.Image
+
+	for _, photo := range p.Photos {
+		originalName := filepath.Join(conf.OriginalsPath(), photo.FileName)
+
+		if !photo.FileCold && !fs.FileExists(originalName) {
+			continue
+		}
+
+		thumbName, err := thumb.FromFile(originalName, photo.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			continue
+		}
+
+		img, err := imaging.Open(thumbName)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			continue
+		}
+
+		images = append(images, img)
+	}
+
+	if len(images) == 0 {
+		return "", fmt.Errorf("share: no photos for preview of %s", link.LinkToken)
+	}
+
+	result, err := w.Apply(thumb.Collage(images, sharePreviewWidth, sharePreviewHeight))
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(previewPath, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := imaging.Save(result, fileName, imaging.JPEGQuality(thumb.JpegQuality)); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// GET /api/v1/s/:token/preview
+//
+// Returns a preview image of the shared photos for Open Graph and Twitter Card meta tags. Password
+// protected links only get a generic image unless they are unlocked.
+//
+// Parameters:
+//   token: string Share link token
+func GetSharePreview(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/preview", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		} else if !shareUnlocked(c, link) {
+			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			return
+		}
+
+		preview := NewSharePreview(link)
+
+		if len(preview.Photos) == 0 {
+			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			return
+		}
+
+		fileName, err := preview.Image(link, conf)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		c.File(fileName)
+	})
+}
+
+// GET /s/:token
+//
+// Renders the default HTML page with Open Graph and Twitter Card meta tags describing the share link.
+// Password protected links are described generically unless they are unlocked. Previous vanity slugs
+// are redirected to the current url.
+//
+// Parameters:
+//   token: string Share link token or vanity slug
+func SharePage(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token", func(c *gin.Context) {
+		clientConfig := conf.PublicClientConfig()
+		link, ok := shareLink(c)
+
+		if !ok {
+			if alias, err := query.LinkByAlias(c.Param("token")); err == nil && !alias.Expired() {
+				c.Redirect(http.StatusMovedPermanently, "/"+alias.Path())
+				return
+			}
+
+			c.HTML(http.StatusNotFound, conf.HttpDefaultTemplate(), gin.H{"config": clientConfig})
+			return
+		}
+
+		if csp := frameAncestors(link); csp != "" {
+			c.Header("Content-Security-Policy", csp)
+		}
+
+		var preview SharePreview
+
+		unlocked := shareUnlocked(c, link)
+
+		if unlocked {
+			preview = NewSharePreview(link)
+		}
+
+		title := preview.Title
+
+		if title == "" {
+			title = clientConfig.Title
+		}
+
+		share := gin.H{
+			"Title":       title,
+			"Description": preview.Description,
+			"URL":         link.URL(clientConfig.URL),
+		}
+
+		if len(preview.Photos) > 0 {
+			share["Image"] = fmt.Sprintf("%sapi/v1/s/%s/preview", clientConfig.URL, link.LinkToken)
+		}
+
+		// Stories of password protected links are loaded by the client after unlocking.
+		if unlocked {
+			if html, ok := shareStory(link, ""); ok && html != "" {
+				share["Story"] = html
+			}
+		}
+
+		c.HTML(http.StatusOK, conf.HttpDefaultTemplate(), gin.H{"config": clientConfig, "share": share})
+	})
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSharePreview(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		link := entity.NewLink("", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		preview := NewSharePreview(link)
+
+		assert.Equal(t, "Holiday2030", preview.Title)
+		assert.Equal(t, "Wonderful christmas", preview.Description)
+		assert.LessOrEqual(t, len(preview.Photos), sharePreviewPhotos)
+
+		for _, p := range preview.Photos {
+			assert.False(t, p.PhotoPrivate)
+		}
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		snapshot := entity.NewSnapshot("Family Chat", "", []string{"pt9jtdre2lvl0yh7"})
+
+		if err := snapshot.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		link := entity.NewLink("", false, false)
+		link.ShareUID = snapshot.SnapshotUID
+
+		preview := NewSharePreview(link)
+
+		assert.Equal(t, "Family Chat", preview.Title)
+		assert.LessOrEqual(t, len(preview.Photos), 1)
+	})
+	t.Run("password protected", func(t *testing.T) {
+		link := entity.NewLink("secret", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		preview := NewSharePreview(link)
+
+		assert.Equal(t, "Holiday2030", preview.Title)
+	})
+}
+
+func TestGetSharePreview(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetSharePreview(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/xxx/preview")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("password protected", func(t *testing.T) {
+		link := entity.NewLink("secret", false, false)
+		link.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		GetSharePreview(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/preview")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+		assert.Equal(t, string(albumIconSvg), r.Body.String())
+	})
+}
+
+func TestSharePage(t *testing.T) {
+	link := entity.NewLink("", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		SharePage(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/s/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("album", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		SharePage(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/s/"+link.LinkToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `<meta property="og:title" content="Holiday2030"/>`)
+		assert.Contains(t, r.Body.String(), "s/"+link.LinkToken+`"/>`)
+	})
+	t.Run("password protected", func(t *testing.T) {
+		locked := entity.NewLink("secret", false, false)
+		locked.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&locked).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		SharePage(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/s/"+locked.LinkToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotContains(t, r.Body.String(), "Holiday2030")
+		assert.NotContains(t, r.Body.String(), "Wonderful christmas")
+		assert.NotContains(t, r.Body.String(), "/preview")
+	})
+	t.Run("password protected and unlocked", func(t *testing.T) {
+		locked := entity.NewLink("secret", false, false)
+		locked.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&locked).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		SharePage(app.Group("/"), conf)
+		UnlockShare(app.Group("/api/v1"), conf)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+locked.LinkToken+"/unlock", `{"password": "secret"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithHeaders(app, "GET", "/s/"+locked.LinkToken, "", map[string]string{"Cookie": r.Header().Get("Set-Cookie")})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `<meta property="og:title" content="Holiday2030"/>`)
+	})
+	t.Run("slug", func(t *testing.T) {
+		if err := link.SetSlug("holiday-2030"); err != nil {
+			t.Fatal(err)
+		}
+
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		SharePage(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/s/holiday-2030")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `s/holiday-2030"/>`)
+	})
+	t.Run("previous slug", func(t *testing.T) {
+		if err := link.SetSlug("holidays-2030"); err != nil {
+			t.Fatal(err)
+		}
+
+		app, _, conf := NewApiTest()
+		app.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
+		SharePage(app.Group("/"), conf)
+		r := PerformRequest(app, "GET", "/s/holiday-2030")
+		assert.Equal(t, http.StatusMovedPermanently, r.Code)
+		assert.Equal(t, "/s/holidays-2030", r.Header().Get("Location"))
+	})
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Thumbnails smaller than watermarkMinSize are served without watermark.
+const watermarkMinSize = 224
+
+// linkWatermark returns the watermark settings of a share link.
+func linkWatermark(link entity.Link, conf *config.Config) (w thumb.Watermark) {
+	w.Text = link.WmText
+	w.Position = link.WmPosition
+	w.Opacity = link.WmOpacity
+
+	if link.WmImage != "" {
+		w.Image = filepath.Join(conf.WatermarksPath(), filepath.Base(link.WmImage))
+	}
+
+	return w
+}
+
+// GET /api/v1/s/:token/t/:hash/:type
+//
+// Returns a thumbnail of a shared photo, with the watermark of the share link applied if configured.
+//
+// Parameters:
+//   token: string Share link token
+//   hash: string The file hash as returned by the search API
+//   type: string Thumbnail type, see photoprism.ThumbnailTypes
+func GetShareThumbnail(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/s/:token/t/:hash/:type", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			svgIcon(c, http.StatusForbidden, brokenIconSvg)
+			return
+		} else if !shareUnlocked(c, link) {
+			svgIcon(c, http.StatusUnauthorized, brokenIconSvg)
+			return
+		}
+
+		typeName := c.Param("type")
+		thumbType, ok := thumb.Types[typeName]
+
+		if !ok {
+			log.Errorf("share: invalid thumb type %s", txt.Quote(typeName))
+			svgIcon(c, http.StatusBadRequest, photoIconSvg)
+			return
+		}
+
+		f, err := query.FileByHash(c.Param("hash"))
+
+		if err != nil || !query.LinkSharesPhoto(link, f.PhotoUID) {
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
+			return
+		}
+
+		// Find fallback if file is not a JPEG image.
+		if f.NoJPEG() {
+			f, err = query.FileByPhotoUID(f.PhotoUID)
+
+			if err != nil {
+				svgIcon(c, http.StatusNotFound, fileIconSvg)
+				return
+			}
+		}
+
+		if f.FileError != "" {
+			svgIcon(c, http.StatusUnprocessableEntity, brokenIconSvg)
+			return
+		}
+
+		fileName, err := thumbSource(conf, f, thumbType)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
+			return
+		}
+
+		if !f.FileCold && !fs.FileExists(fileName) {
+			log.Errorf("share: file %s is missing", txt.Quote(f.FileName))
+			svgIcon(c, http.StatusNotFound, photoIconSvg)
+			return
+		}
+
+		// Never serve originals through share links, even if the thumb size exceeds the limit.
+		if thumbType.ExceedsLimit() {
+			svgIcon(c, http.StatusForbidden, photoIconSvg)
+			return
+		}
+
+		thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+
+		if err != nil {
+			log.Errorf("share: %s", err)
+			svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
+			return
+		}
+
+		// Tiny thumbnails are not worth protecting and too small for a readable watermark.
+		if link.HasWatermark() && thumbType.Width >= watermarkMinSize {
+			if thumbnail, err = thumb.Watermarked(thumbnail, linkWatermark(link, conf)); err != nil {
+				log.Errorf("share: %s", err)
+				svgIcon(c, http.StatusInternalServerError, brokenIconSvg)
+				return
+			}
+		}
+
+		// Watermark settings may change, so clients must revalidate using the modification time.
+		c.Header("Cache-Control", CacheRevalidate)
+
+		if c.Query("download") != "" {
+			c.FileAttachment(thumbnail, f.ShareFileName())
+		} else {
+			c.File(thumbnail)
+		}
+	})
+}
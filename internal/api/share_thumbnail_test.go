@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetShareThumbnail(t *testing.T) {
+	link := entity.NewLink("", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+	link.WmText = "Proof"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareThumbnail(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/xxx/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/fit_720")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("invalid type", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareThumbnail(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
+	t.Run("file not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetShareThumbnail(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/t/xxx/fit_720")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("password required", func(t *testing.T) {
+		locked := entity.NewLink("secret", false, false)
+		locked.ShareUID = "at9lxuqxpogaaba8"
+
+		if err := entity.Db().Create(&locked).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		GetShareThumbnail(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/s/"+locked.LinkToken+"/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/fit_720")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		r = PerformRequestWithHeaders(app, "GET", "/api/v1/s/"+locked.LinkToken+"/t/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/xxx", "", map[string]string{"X-Share-Password": "secret"})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestLinkWatermark(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	link := entity.NewLink("", false, false)
+	link.WmImage = "../../logo.png"
+	link.WmOpacity = 0.3
+
+	w := linkWatermark(link, conf)
+
+	assert.Equal(t, conf.WatermarksPath()+"/logo.png", w.Image)
+	assert.Equal(t, 0.3, w.Opacity)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/secret"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Visitors may see a password protected share link for shareUnlockTTL after sending the password once.
+const shareUnlockTTL = 12 * time.Hour
+
+// shareUnlockKey signs unlock tokens, so that they become invalid when the server is restarted.
+var shareUnlockKey = func() []byte {
+	key, err := secret.NewKey()
+
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}()
+
+// shareUnlockCookie returns the name of the cookie containing the unlock token of a share link.
+func shareUnlockCookie(link entity.Link) string {
+	return "share_" + link.LinkToken
+}
+
+// shareUnlockSignature returns the signature of an unlock token. It includes the password hash, so that
+// tokens become invalid when the password is changed.
+func shareUnlockSignature(link entity.Link, expires string) string {
+	mac := hmac.New(sha256.New, shareUnlockKey)
+	mac.Write([]byte(link.LinkToken + "\x00" + expires + "\x00" + link.LinkPassword))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newShareUnlockToken returns a signed token that unlocks the link until it expires.
+func newShareUnlockToken(link entity.Link, expires time.Time) string {
+	s := strconv.FormatInt(expires.Unix(), 10)
+
+	return s + "." + shareUnlockSignature(link, s)
+}
+
+// validShareUnlockToken returns true if the token unlocks the link and has not expired yet.
+func validShareUnlockToken(link entity.Link, token string) bool {
+	parts := strings.SplitN(token, ".", 2)
+
+	if len(parts) != 2 {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(parts[1]), []byte(shareUnlockSignature(link, parts[0])))
+}
+
+// shareUnlockToken returns the unlock token sent with the request, either as cookie or as "unlock"
+// query parameter, e.g. for clients that don't support cookies.
+func shareUnlockToken(c *gin.Context, link entity.Link) string {
+	if token := c.Query("unlock"); token != "" {
+		return token
+	}
+
+	if token, err := c.Cookie(shareUnlockCookie(link)); err == nil {
+		return token
+	}
+
+	return ""
+}
+
+// POST /api/v1/s/:token/unlock
+//
+// Checks the password of a share link and returns a signed unlock token, which is also set as cookie,
+// so that browsers can load thumbnails and downloads without sending the password again.
+//
+// Parameters:
+//   token: string Share link token
+func UnlockShare(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/s/:token/unlock", func(c *gin.Context) {
+		link, ok := shareLink(c)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLinkNotFound)
+			return
+		}
+
+		var f form.ShareUnlock
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if link.LinkPassword != "" {
+			if sharePasswordThrottled(c, link) {
+				c.Header("Retry-After", strconv.Itoa(int(sharePasswordPeriod.Seconds())))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrTooManyRequests)
+				return
+			} else if !checkSharePassword(c, link, f.Password) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, ErrLinkLocked)
+				return
+			}
+		}
+
+		expires := time.Now().Add(shareUnlockTTL)
+		token := newShareUnlockToken(link, expires)
+
+		http.SetCookie(c.Writer, &http.Cookie{
+			Name:     shareUnlockCookie(link),
+			Value:    token,
+			Path:     "/",
+			Expires:  expires,
+			MaxAge:   int(shareUnlockTTL.Seconds()),
+			Secure:   c.Request.TLS != nil,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		c.JSON(http.StatusOK, gin.H{"token": token, "expires": expires.UTC()})
+	})
+}
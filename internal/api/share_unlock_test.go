@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUnlockShare(t *testing.T) {
+	link := entity.NewLink("secret", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, conf := NewApiTest()
+	UnlockShare(router, conf)
+	GetShareAlbums(router, conf)
+
+	t.Run("link not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/xxx/unlock", `{"password": "secret"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("wrong password", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/unlock", `{"password": "wrong"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Empty(t, r.Header().Get("Set-Cookie"))
+	})
+	t.Run("cookie", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/unlock", `{"password": "secret"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		cookie := r.Header().Get("Set-Cookie")
+		assert.Contains(t, cookie, "share_"+link.LinkToken+"=")
+		assert.Contains(t, cookie, "HttpOnly")
+
+		r = PerformRequestWithHeaders(app, "GET", "/api/v1/s/"+link.LinkToken+"/albums", "", map[string]string{"Cookie": cookie})
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("query parameter", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/s/"+link.LinkToken+"/unlock", `{"password": "secret"}`)
+		token := gjson.Get(r.Body.String(), "token").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/albums?unlock="+token)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/s/"+link.LinkToken+"/albums?unlock=1"+token)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestValidShareUnlockToken(t *testing.T) {
+	link := entity.NewLink("secret", false, false)
+	token := newShareUnlockToken(link, time.Now().Add(time.Hour))
+
+	t.Run("valid", func(t *testing.T) {
+		assert.True(t, validShareUnlockToken(link, token))
+	})
+	t.Run("expired", func(t *testing.T) {
+		assert.False(t, validShareUnlockToken(link, newShareUnlockToken(link, time.Now().Add(-time.Second))))
+	})
+	t.Run("other link", func(t *testing.T) {
+		assert.False(t, validShareUnlockToken(entity.NewLink("secret", false, false), token))
+	})
+	t.Run("password changed", func(t *testing.T) {
+		changed := link
+		changed.LinkPassword = "changed"
+		assert.False(t, validShareUnlockToken(changed, token))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.False(t, validShareUnlockToken(link, ""))
+		assert.False(t, validShareUnlockToken(link, "xxx"))
+	})
+}
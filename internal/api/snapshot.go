@@ -0,0 +1,259 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/archive"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/snapshots
+func GetSnapshots(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/snapshots", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		results, err := query.Snapshots()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// GET /api/v1/snapshots/:uid
+//
+// Returns the snapshot including the UIDs of all photos in their original order.
+//
+// Parameters:
+//   uid: string Snapshot UID
+func GetSnapshot(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/snapshots/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.SnapshotByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrSnapshotNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Snapshot": m, "Photos": m.PhotoUIDs()})
+	})
+}
+
+// POST /api/v1/snapshots
+//
+// Freezes the selected photos or the result of a search query into an immutable snapshot.
+func CreateSnapshot(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/snapshots", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.Snapshot
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		var photoUIDs []string
+		var err error
+
+		if len(f.Photos) > query.MaxSnapshotPhotos {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Too many photos"})
+			return
+		} else if len(f.Photos) > 0 {
+			photoUIDs, err = query.SnapshotPhotos(f.Photos)
+		} else {
+			search := form.NewPhotoSearch(f.Query)
+			search.Order = f.Order
+			photoUIDs, err = query.SnapshotSearch(search)
+		}
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if len(photoUIDs) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No photos found"})
+			return
+		}
+
+		m := entity.NewSnapshot(f.Title, f.Query, photoUIDs)
+
+		if err := m.Create(); err != nil {
+			log.Errorf("snapshot: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("snapshot %s created", txt.Quote(m.SnapshotTitle)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/snapshots/:uid
+//
+// Parameters:
+//   uid: string Snapshot UID
+func DeleteSnapshot(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/snapshots/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.SnapshotByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrSnapshotNotFound)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("snapshot: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success(fmt.Sprintf("snapshot %s deleted", txt.Quote(m.SnapshotTitle)))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/snapshots/:uid/link
+//
+// Parameters:
+//   uid: string Snapshot UID
+func LinkSnapshot(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/snapshots/:uid/link", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.SnapshotByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrSnapshotNotFound)
+			return
+		}
+
+		if link, err := newLink(c); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else {
+			entity.Db().Model(&m).Association("Links").Append(link)
+		}
+
+		event.Success("created snapshot share link")
+
+		countUsage(conf, entity.UsageShare)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GET /api/v1/snapshots/:uid/dl
+//
+// Exports the original files of all photos in a snapshot as zip archive.
+//
+// Parameters:
+//   uid: string Snapshot UID
+//   t: string Download token (query)
+//   convert: string Use "jpeg" to download JPEG versions of formats like HEIC and RAW (query)
+func DownloadSnapshot(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/snapshots/:uid/dl", func(c *gin.Context) {
+		if InvalidDownloadToken(c, conf) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		start := time.Now()
+
+		m, err := query.SnapshotByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrSnapshotNotFound)
+			return
+		}
+
+		files, err := query.SnapshotFiles(m.SnapshotUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		zipPath := path.Join(conf.TempPath(), "snapshot")
+		zipBaseName := fmt.Sprintf("%s-%s.zip", strings.Title(slug.Make(m.SnapshotTitle)), rnd.Token(3))
+		zipFileName := path.Join(zipPath, zipBaseName)
+
+		if err := os.MkdirAll(zipPath, 0700); err != nil {
+			log.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst("failed to create zip folder")})
+			return
+		}
+
+		var entries []archive.Entry
+
+		for _, f := range files {
+			fileName, err := photoprism.OriginalFileName(conf, f)
+
+			if err != nil {
+				log.Errorf("snapshot: %s", err)
+				continue
+			}
+
+			if fs.FileExists(fileName) {
+				entries = append(entries, downloadEntry(c, fileName, f.FileHash, f.ShareFileName()))
+			} else {
+				log.Errorf("snapshot: file %s is missing", txt.Quote(f.FileName))
+			}
+		}
+
+		if err := createArchive(conf, zipFileName, entries); err != nil {
+			archiveError(c, err)
+			return
+		}
+
+		log.Infof("snapshot: archive %s created in %s", txt.Quote(zipBaseName), time.Since(start))
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", zipBaseName))
+
+		countUsage(conf, entity.UsageDownload)
+
+		c.File(zipFileName)
+
+		if err := os.Remove(zipFileName); err != nil {
+			log.Errorf("snapshot: could not remove %s (%s)", txt.Quote(zipFileName), err.Error())
+		}
+	})
+}
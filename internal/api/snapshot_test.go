@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	t.Run("selection", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateSnapshot(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/snapshots", `{"Title": "Yearbook", "Photos": ["pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Yearbook", gjson.Get(r.Body.String(), "Title").String())
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "PhotoCount").Int())
+	})
+	t.Run("search", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateSnapshot(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/snapshots", `{"Title": "Everything", "Query": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "PhotoCount").Int() > 2)
+	})
+	t.Run("no photos found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateSnapshot(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/snapshots", `{"Title": "Nothing", "Photos": ["pt9jtdre2lvl0xxx"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("title missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateSnapshot(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/snapshots", `{"Photos": ["pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetSnapshot(t *testing.T) {
+	app, router, conf := NewApiTest()
+	CreateSnapshot(router, conf)
+	GetSnapshot(router, conf)
+	GetSnapshots(router, conf)
+	LinkSnapshot(router, conf)
+	DeleteSnapshot(router, conf)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/snapshots", `{"Title": "Yearbook", "Photos": ["pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"]}`)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("existing", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/snapshots/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", gjson.Get(r.Body.String(), "Photos.0").String())
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "Photos.1").String())
+	})
+	t.Run("list", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/snapshots")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#").Int() > 0)
+	})
+	t.Run("link", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/snapshots/"+uid+"/link", `{"Password": "", "CanComment": false, "CanEdit": false}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Links.#").Int())
+	})
+	t.Run("delete", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/snapshots/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequest(app, "GET", "/api/v1/snapshots/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDownloadSnapshot(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadSnapshot(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/snapshots/st9lxuqxpogaaxxx/dl?t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadSnapshot(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/snapshots/st9lxuqxpogaaxxx/dl?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Clients may report up to displayReportLimit display sizes per displayReportPeriod.
+const (
+	displayReportLimit  = 20
+	displayReportPeriod = time.Hour
+)
+
+// ThumbStats contains display size reports and the projected disk space savings of adaptive thumbnail sizes.
+type ThumbStats struct {
+	Adaptive    bool           `json:"Adaptive"`
+	Size        int            `json:"Size"`
+	MaxSize     int            `json:"MaxSize"`
+	Recommended int            `json:"Recommended"`
+	Reports     map[string]int `json:"Reports"`
+	OnDemand    []string       `json:"OnDemand"`
+	Files       int            `json:"Files"`
+	Savings     int64          `json:"Savings"`
+}
+
+// NewThumbStats returns thumbnail stats including the bytes saved by rendering default thumbnail types
+// larger than the recommended size on demand instead of in advance.
+func NewThumbStats(conf *config.Config, reports map[string]int, files []query.FileDimension) ThumbStats {
+	result := ThumbStats{
+		Adaptive:    conf.ThumbAdaptive(),
+		Size:        thumb.Size,
+		MaxSize:     conf.ThumbSize(),
+		Recommended: thumb.AdaptiveSize(reports, conf.ThumbSize()),
+		Reports:     reports,
+		OnDemand:    []string{},
+		Files:       len(files),
+	}
+
+	for _, name := range thumb.DefaultTypes {
+		t := thumb.Types[name]
+
+		if t.Width <= result.Recommended && t.Height <= result.Recommended {
+			continue
+		} else if t.Width > result.MaxSize || t.Height > result.MaxSize {
+			continue
+		}
+
+		result.OnDemand = append(result.OnDemand, name)
+
+		for _, f := range files {
+			result.Savings += t.EstimateBytes(f.FileWidth, f.FileHeight)
+		}
+	}
+
+	return result
+}
+
+// POST /api/v1/stats/display
+//
+// Reports the size of the area in which a client displays photos, in physical pixels.
+func ReportDisplaySize(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/stats/display", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if rateLimited("display-report:"+clientIP(c), displayReportLimit, displayReportPeriod) {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrTooManyRequests)
+			return
+		}
+
+		var f form.DisplaySize
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		thumbType := thumb.Fit(f.Width, f.Height)
+
+		if err := entity.ReportThumbUsage(thumbType); err != nil {
+			log.Errorf("stats: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		conf.UpdateThumbSize()
+
+		c.JSON(http.StatusOK, gin.H{"type": thumbType})
+	})
+}
+
+// GET /api/v1/stats/thumbs
+func GetThumbStats(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/stats/thumbs", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		reports, err := entity.ThumbUsageReports()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		files, err := query.PrimaryFileDimensions()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, NewThumbStats(conf, reports, files))
+	})
+}
+
+// UsageStats contains the feature usage counted per day if local usage statistics are enabled.
+type UsageStats struct {
+	Enabled bool                  `json:"Enabled"`
+	Days    int                   `json:"Days"`
+	Totals  map[string]int        `json:"Totals"`
+	Daily   []entity.FeatureUsage `json:"Daily"`
+}
+
+// countUsage increments the usage counter of a feature if local usage statistics are enabled.
+func countUsage(conf *config.Config, feature string) {
+	if !conf.UsageStats() {
+		return
+	}
+
+	if err := entity.CountFeatureUsage(feature); err != nil {
+		log.Errorf("stats: %s", err)
+	}
+}
+
+// GET /api/v1/stats/usage
+//
+// Returns how often features like search, sharing, and downloads were used per day. The numbers are
+// only counted if enabled with --usage-stats and never leave the instance.
+//
+// Parameters:
+//   days: int Number of days, 30 by default (optional)
+func GetUsageStats(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/stats/usage", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.UsageStats
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Days <= 0 {
+			f.Days = 30
+		} else if f.Days > 366 {
+			f.Days = 366
+		}
+
+		daily, err := entity.FeatureUsageSince(time.Now().AddDate(0, 0, 1-f.Days))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		result := UsageStats{
+			Enabled: conf.UsageStats(),
+			Days:    f.Days,
+			Totals:  make(map[string]int),
+			Daily:   daily,
+		}
+
+		for _, d := range daily {
+			result.Totals[d.Feature] += d.UsageCount
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
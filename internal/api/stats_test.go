@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestNewThumbStats(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	reports := map[string]int{"fit_720": 150, "fit_1280": 50}
+	files := []query.FileDimension{{FileWidth: 4000, FileHeight: 3000}, {FileWidth: 0, FileHeight: 0}}
+
+	result := NewThumbStats(conf, reports, files)
+
+	assert.Equal(t, 2, result.Files)
+
+	if conf.ThumbSize() > 1280 {
+		assert.Equal(t, 1280, result.Recommended)
+		assert.Contains(t, result.OnDemand, "fit_2048")
+		assert.Less(t, int64(0), result.Savings)
+	} else {
+		assert.Equal(t, conf.ThumbSize(), result.Recommended)
+		assert.Empty(t, result.OnDemand)
+	}
+
+	assert.NotContains(t, result.OnDemand, "fit_1280")
+	assert.NotContains(t, result.OnDemand, "tile_500")
+}
+
+func TestReportDisplaySize(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ReportDisplaySize(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/stats/display", `{"Width": 1920, "Height": 1080}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "fit_1920", gjson.Get(r.Body.String(), "type").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ReportDisplaySize(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/stats/display", `{"Width": "wide"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetThumbStats(t *testing.T) {
+	app, router, conf := NewApiTest()
+	GetThumbStats(router, conf)
+	r := PerformRequest(app, "GET", "/api/v1/stats/thumbs")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, int64(conf.ThumbSize()), gjson.Get(r.Body.String(), "MaxSize").Int())
+}
+
+func TestGetUsageStats(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetUsageStats(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/stats/usage")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(30), gjson.Get(r.Body.String(), "Days").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "Enabled").Exists())
+	})
+	t.Run("days", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetUsageStats(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/stats/usage?days=7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(7), gjson.Get(r.Body.String(), "Days").Int())
+	})
+}
@@ -5,11 +5,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
 )
 
 // GET /api/v1/status
 func GetStatus(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/status", func(c *gin.Context) {
+		if mutex.Originals.Offline() {
+			c.JSON(http.StatusOK, gin.H{"status": "degraded", "message": "Originals folder is not available"})
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{"status": "operational"})
 	})
 }
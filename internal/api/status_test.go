@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 	"net/http"
@@ -16,4 +17,14 @@ func TestGetStatus(t *testing.T) {
 		assert.Equal(t, "operational", val.String())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("originals offline", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetStatus(router, conf)
+		mutex.Originals.SetOffline(true)
+		defer mutex.Originals.SetOffline(false)
+		r := PerformRequest(app, "GET", "/api/v1/status")
+		val := gjson.Get(r.Body.String(), "status")
+		assert.Equal(t, "degraded", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
 }
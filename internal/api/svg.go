@@ -39,40 +39,42 @@ var uncachedIconSvg = []byte(`
 <path d="M21 19V5c0-1.1-.9-2-2-2H5c-1.1 0-2 .9-2 2v14c0 1.1.9 2 2 2h14c1.1 0 2-.9 2-2zM8.5 13.5l2.5 3.01L14.5 12l4.5 6H5l3.5-4.5z"/></svg>`)
 
 // GET /api/v1/svg/*
+//
+// Returns static SVG icons, e.g. as fallback for images that can't be displayed.
 func GetSvg(router *gin.RouterGroup) {
 	router.GET("/svg/photo", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+		svgIcon(c, http.StatusOK, photoIconSvg)
 	})
 
 	router.GET("/svg/raw", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", rawIconSvg)
+		svgIcon(c, http.StatusOK, rawIconSvg)
 	})
 
 	router.GET("/svg/file", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", fileIconSvg)
+		svgIcon(c, http.StatusOK, fileIconSvg)
 	})
 
 	router.GET("/svg/video", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+		svgIcon(c, http.StatusOK, videoIconSvg)
 	})
 
 	router.GET("/svg/label", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
+		svgIcon(c, http.StatusOK, labelIconSvg)
 	})
 
 	router.GET("/svg/album", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+		svgIcon(c, http.StatusOK, albumIconSvg)
 	})
 
 	router.GET("/svg/folder", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+		svgIcon(c, http.StatusOK, albumIconSvg)
 	})
 
 	router.GET("/svg/broken", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+		svgIcon(c, http.StatusOK, brokenIconSvg)
 	})
 
 	router.GET("/svg/uncached", func(c *gin.Context) {
-		c.Data(http.StatusOK, "image/svg+xml", uncachedIconSvg)
+		svgIcon(c, http.StatusOK, uncachedIconSvg)
 	})
 }
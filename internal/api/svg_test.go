@@ -14,6 +14,10 @@ func TestGetSvg(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/svg/photo")
 		assert.Equal(t, photoIconSvg, r.Body.Bytes())
 		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, CacheStatic, r.Header().Get("Cache-Control"))
+
+		r = PerformRequestWithHeaders(app, "GET", "/api/v1/svg/photo", "", map[string]string{"If-None-Match": r.Header().Get("ETag")})
+		assert.Equal(t, http.StatusNotModified, r.Code)
 	})
 	t.Run("label", func(t *testing.T) {
 		app, router, conf := NewApiTest()
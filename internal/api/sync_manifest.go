@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Default and max number of photos and albums per sync manifest page.
+const (
+	syncLimitDefault = 1000
+	syncLimitMax     = 5000
+)
+
+// GET /api/v1/sync/manifest
+//
+// Query:
+//   since: string Time of the last sync as unix timestamp or RFC 3339 (optional)
+//   cursor: string Cursor of the next page as returned with the previous page (optional)
+//   limit: int Max number of photos and albums per page (optional)
+func GetSyncManifest(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/sync/manifest", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.SyncManifest
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		since, err := f.SinceTime()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Limit <= 0 {
+			f.Limit = syncLimitDefault
+		} else if f.Limit > syncLimitMax {
+			f.Limit = syncLimitMax
+		}
+
+		result, err := query.NewSyncManifest(since, f.Cursor, f.Limit)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetSyncManifest(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetSyncManifest(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/sync/manifest?since=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "Added.#").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "Until").Exists())
+	})
+	t.Run("pages", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetSyncManifest(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/sync/manifest?since=0&limit=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Added.#").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "HasMore").Bool())
+
+		until := gjson.Get(r.Body.String(), "Until").String()
+		cursor := gjson.Get(r.Body.String(), "Cursor").String()
+
+		r = PerformRequest(app, "GET", "/api/v1/sync/manifest?since=0&limit=1&cursor="+cursor)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, until, gjson.Get(r.Body.String(), "Until").String())
+		assert.NotEqual(t, cursor, gjson.Get(r.Body.String(), "Cursor").String())
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetSyncManifest(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/sync/manifest?cursor=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid since", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetSyncManifest(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/sync/manifest?since=yesterday")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Invalid time yesterday", gjson.Get(r.Body.String(), "error").String())
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// thumbFromFile renders a thumbnail like thumb.FromFile, unless rendering it failed before and the next
+// attempt isn't due yet. Failures are recorded, so that broken files don't slow down every grid load.
+func thumbFromFile(conf *config.Config, f entity.File, fileName, typeName string, thumbType thumb.Type) (string, error) {
+	failed, err := query.ThumbError(f.FileHash, typeName)
+
+	// Cached thumbnails are returned even if a previous attempt failed.
+	if err == nil && !failed.Retry() {
+		if thumbnail, err := thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...); err == nil {
+			return thumbnail, nil
+		}
+
+		return "", failed.Err()
+	}
+
+	thumbnail, thumbErr := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+
+	if thumbErr != nil {
+		if _, err := entity.SaveThumbError(f.FileHash, typeName, f.FileName, thumbErr.Error()); err != nil {
+			log.Errorf("thumb: %s", err)
+		}
+	} else if err == nil {
+		if err := entity.ClearThumbError(f.FileHash, typeName); err != nil {
+			log.Errorf("thumb: %s", err)
+		}
+	}
+
+	return thumbnail, thumbErr
+}
+
+// thumbError returns the thumbnail error with the id in the request or aborts with status 404.
+func thumbError(c *gin.Context) (result entity.ThumbError, ok bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if err == nil {
+		result, err = query.ThumbErrorByID(uint(id))
+	}
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrThumbErrNotFound)
+		return result, false
+	}
+
+	return result, true
+}
+
+// GET /api/v1/thumbs/errors
+//
+// Returns thumbnails that could not be rendered, including the reason and when they are retried.
+//
+// Parameters:
+//   permanent: bool Only return thumbnails that aren't retried automatically anymore
+//   count: int Max result count (required)
+//   offset: int Result offset
+func GetThumbErrors(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/thumbs/errors", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.ThumbErrors
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		results, err := query.ThumbErrors(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// DELETE /api/v1/thumbs/errors/:id
+//
+// Removes a thumbnail error, so that the thumbnail is rendered again the next time it is requested.
+//
+// Parameters:
+//   id: int Error ID as returned by the API
+func DeleteThumbError(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/thumbs/errors/:id", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, ok := thumbError(c)
+
+		if !ok {
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("thumb: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetThumbErrors(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumbErrors(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/thumbs/errors?count=10&permanent=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumbErrors(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/thumbs/errors")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDeleteThumbError(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := entity.SaveThumbError("d9168fa6acc5c5c2965ddf6ec465ca42fd81823", "tile_500", "2020/broken/empty.jpg", "EOF")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		DeleteThumbError(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/thumbs/errors/"+strconv.Itoa(int(m.ID)))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/thumbs/errors/"+strconv.Itoa(int(m.ID)))
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DeleteThumbError(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/thumbs/errors/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -29,6 +31,12 @@ func Upload(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		// Uploaded files are stored in the import path first, and then moved to originals.
+		if err := photoprism.CheckDiskSpace(conf, c.Request.ContentLength, conf.ImportPath()); err != nil {
+			abortDiskFull(c, err)
+			return
+		}
+
 		start := time.Now()
 		subPath := c.Param("path")
 
@@ -43,7 +51,9 @@ func Upload(router *gin.RouterGroup, conf *config.Config) {
 
 		files := f.File["files"]
 		uploaded := len(files)
+		quarantined := 0
 		var uploads []string
+		uploader := sessionUserID(c)
 
 		p := path.Join(conf.ImportPath(), "upload", subPath)
 
@@ -62,6 +72,21 @@ func Upload(router *gin.RouterGroup, conf *config.Config) {
 				return
 			}
 
+			// Suspicious files are moved to quarantine instead of being imported, see upload-quarantine.
+			if ok, err := photoprism.VerifyUpload(conf, filename, entity.QuarantineUpload, uploader); err != nil {
+				log.Errorf("upload: %s", err)
+
+				if err := os.Remove(filename); err != nil {
+					log.Errorf("upload: could not delete %s", txt.Quote(filename))
+				}
+
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			} else if ok {
+				quarantined++
+				continue
+			}
+
 			uploads = append(uploads, filename)
 		}
 
@@ -103,6 +128,13 @@ func Upload(router *gin.RouterGroup, conf *config.Config) {
 
 		log.Infof("%d files uploaded in %s", uploaded, elapsed)
 
+		countUsage(conf, entity.UsageUpload)
+
+		if quarantined > 0 {
+			c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d files uploaded in %s, %d moved to quarantine", uploaded, elapsed, quarantined), "quarantined": quarantined})
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d files uploaded in %s", uploaded, elapsed)})
 	})
 }
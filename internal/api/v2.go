@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Default and max number of items in v2 list responses.
+const (
+	v2LimitDefault = 100
+	v2LimitMax     = 1000
+)
+
+// V2Pagination contains the paging information of v2 list responses. Total is the number of items
+// matching the request regardless of limit and offset.
+type V2Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+	Total  int `json:"total"`
+}
+
+// V2List is the response envelope of v2 list endpoints.
+type V2List struct {
+	Data       interface{}  `json:"data"`
+	Pagination V2Pagination `json:"pagination"`
+}
+
+// V2Item is the response envelope of v2 endpoints returning a single resource.
+type V2Item struct {
+	Data interface{} `json:"data"`
+}
+
+// V2ErrorDetails describes why a v2 request failed.
+type V2ErrorDetails struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// V2Error is the response envelope of failed v2 requests.
+type V2Error struct {
+	Error V2ErrorDetails `json:"error"`
+}
+
+// v2Abort aborts a v2 request with an error envelope.
+func v2Abort(c *gin.Context, code int, message string) {
+	c.AbortWithStatusJSON(code, V2Error{Error: V2ErrorDetails{
+		Code:    code,
+		Status:  http.StatusText(code),
+		Message: txt.UcFirst(message),
+	}})
+}
+
+// v2AbortWith aborts a v2 request with one of the v1 errors like ErrAlbumNotFound, so that both
+// versions use the same error messages.
+func v2AbortWith(c *gin.Context, err gin.H) {
+	code, _ := err["code"].(int)
+	message, _ := err["error"].(string)
+
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+
+	v2Abort(c, code, message)
+}
+
+// v2Unauthorized aborts with status 401 and returns true if the request isn't authorized.
+func v2Unauthorized(c *gin.Context, conf *config.Config) bool {
+	if Unauthorized(c, conf) {
+		v2AbortWith(c, ErrUnauthorized)
+		return true
+	}
+
+	return false
+}
+
+// v2BindList binds the query parameters of a list request and applies the default limit.
+func v2BindList(c *gin.Context) (f form.ApiV2List, ok bool) {
+	if err := c.ShouldBindWith(&f, binding.Form); err != nil {
+		v2Abort(c, http.StatusBadRequest, err.Error())
+		return f, false
+	}
+
+	if f.Limit <= 0 {
+		f.Limit = v2LimitDefault
+	} else if f.Limit > v2LimitMax {
+		f.Limit = v2LimitMax
+	}
+
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+
+	return f, true
+}
+
+// v2List responds with a list of items in the v2 envelope.
+func v2List(c *gin.Context, f form.ApiV2List, data interface{}, count, total int) {
+	c.JSON(http.StatusOK, V2List{
+		Data:       data,
+		Pagination: V2Pagination{Limit: f.Limit, Offset: f.Offset, Count: count, Total: total},
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GET /api/v2/albums
+//
+// Returns albums matching a search.
+//
+// Parameters:
+//   q: string Search query with optional filters, e.g. "favorite:true"
+//   limit: int Max number of results, 100 by default and at most 1000
+//   offset: int Result offset
+//   order: string Sort order, e.g. "name" or "newest"
+func GetAlbumsV2(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums", func(c *gin.Context) {
+		if v2Unauthorized(c, conf) {
+			return
+		}
+
+		f, ok := v2BindList(c)
+
+		if !ok {
+			return
+		}
+
+		results, total, err := query.AlbumSearchTotal(form.AlbumSearch{
+			Query:  f.Query,
+			Order:  f.Order,
+			Count:  f.Limit,
+			Offset: f.Offset,
+		})
+
+		if err != nil {
+			v2Abort(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		v2List(c, f, results, len(results), total)
+	})
+}
+
+// GET /api/v2/albums/:uid
+//
+// Returns an album.
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumV2(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid", func(c *gin.Context) {
+		if v2Unauthorized(c, conf) {
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			v2AbortWith(c, ErrAlbumNotFound)
+			return
+		}
+
+		if notModified(c, a.VersionTag(), CacheRevalidate) {
+			return
+		}
+
+		c.JSON(http.StatusOK, V2Item{Data: a})
+	})
+}
+
+// GET /api/v2/albums/:uid/photos
+//
+// Returns the photos in an album, one result per photo. Smart albums return the photos matching their filter.
+//
+// Parameters:
+//   uid: string Album UID
+//   q: string Search query with optional filters, e.g. "label:cat"
+//   limit: int Max number of results, 100 by default and at most 1000
+//   offset: int Result offset
+//   order: string Sort order, the album order by default
+func GetAlbumPhotosV2(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/albums/:uid/photos", func(c *gin.Context) {
+		if v2Unauthorized(c, conf) {
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			v2AbortWith(c, ErrAlbumNotFound)
+			return
+		}
+
+		f, ok := v2BindList(c)
+
+		if !ok {
+			return
+		}
+
+		if f.Order == "" {
+			f.Order = a.AlbumOrder
+		}
+
+		v2Photos(c, f, a.AlbumUID)
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumsV2(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetAlbumsV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/albums?limit=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+		assert.Equal(t, int64(1), gjson.Get(body, "data.#").Int())
+		assert.Equal(t, int64(1), gjson.Get(body, "pagination.count").Int())
+		assert.Greater(t, gjson.Get(body, "pagination.total").Int(), int64(1))
+	})
+}
+
+func TestGetAlbumV2(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetAlbumV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/albums/at9lxuqxpogaaba8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "data.UID").String())
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetAlbumV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/albums/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "Album not found", gjson.Get(r.Body.String(), "error.message").String())
+	})
+}
+
+func TestGetAlbumPhotosV2(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetAlbumPhotosV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/albums/at9lxuqxpogaaba8/photos")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(body, "data.0.UID").String())
+		assert.Equal(t, gjson.Get(body, "data.#").Int(), gjson.Get(body, "pagination.total").Int())
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetAlbumPhotosV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/albums/xxx/photos")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, int64(http.StatusNotFound), gjson.Get(r.Body.String(), "error.code").Int())
+	})
+}
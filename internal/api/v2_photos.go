@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// v2Photos searches photos with one result per photo and responds with a v2 list.
+func v2Photos(c *gin.Context, f form.ApiV2List, albumUID string) {
+	results, count, total, err := query.PhotoSearchTotal(form.PhotoSearch{
+		Query:   f.Query,
+		Album:   albumUID,
+		Order:   f.Order,
+		Count:   f.Limit,
+		Offset:  f.Offset,
+		Primary: true,
+		Merged:  true,
+	})
+
+	if err != nil {
+		v2Abort(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	v2List(c, f, results, count, total)
+}
+
+// GET /api/v2/photos
+//
+// Returns photos matching a search, one result per photo.
+//
+// Parameters:
+//   q: string Search query with optional filters, e.g. "label:cat year:2020"
+//   limit: int Max number of results, 100 by default and at most 1000
+//   offset: int Result offset
+//   order: string Sort order, e.g. "newest", "oldest", or "imported"
+func GetPhotosV2(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/photos", func(c *gin.Context) {
+		if v2Unauthorized(c, conf) {
+			return
+		}
+
+		f, ok := v2BindList(c)
+
+		if !ok {
+			return
+		}
+
+		v2Photos(c, f, "")
+	})
+}
+
+// GET /api/v2/photos/:uid
+//
+// Returns a photo with all its files and details.
+//
+// Parameters:
+//   uid: string Photo UID
+func GetPhotoV2(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/photos/:uid", func(c *gin.Context) {
+		if v2Unauthorized(c, conf) {
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(c.Param("uid"))
+
+		if err != nil {
+			v2AbortWith(c, ErrPhotoNotFound)
+			return
+		}
+
+		if notModified(c, p.VersionTag(), CacheRevalidate) {
+			return
+		}
+
+		c.JSON(http.StatusOK, V2Item{Data: p})
+	})
+}
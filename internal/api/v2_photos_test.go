@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotosV2(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetPhotosV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/photos?limit=2&offset=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		body := r.Body.String()
+		assert.Equal(t, int64(2), gjson.Get(body, "data.#").Int())
+		assert.Equal(t, int64(2), gjson.Get(body, "pagination.limit").Int())
+		assert.Equal(t, int64(1), gjson.Get(body, "pagination.offset").Int())
+		assert.Equal(t, int64(2), gjson.Get(body, "pagination.count").Int())
+		assert.Greater(t, gjson.Get(body, "pagination.total").Int(), int64(2))
+	})
+	t.Run("default limit", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetPhotosV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/photos")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(v2LimitDefault), gjson.Get(r.Body.String(), "pagination.limit").Int())
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetPhotosV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/photos?limit=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, int64(http.StatusBadRequest), gjson.Get(r.Body.String(), "error.code").Int())
+		assert.Equal(t, "Bad Request", gjson.Get(r.Body.String(), "error.status").String())
+	})
+}
+
+func TestGetPhotoV2(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetPhotoV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/photos/pt9jtdre2lvl0yh7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "data.UID").String())
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		app, _, conf := NewApiTest()
+		GetPhotoV2(app.Group("/api/v2"), conf)
+		r := PerformRequest(app, "GET", "/api/v2/photos/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, int64(http.StatusNotFound), gjson.Get(r.Body.String(), "error.code").Int())
+		assert.Equal(t, "Photo not found", gjson.Get(r.Body.String(), "error.message").String())
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionConflict returns true if the If-Match request header doesn't contain the entity tag of the
+// current version. Requests without If-Match header are not checked to remain backwards compatible.
+func versionConflict(c *gin.Context, tag string) bool {
+	match := strings.TrimSpace(c.GetHeader("If-Match"))
+
+	if match == "" || match == "*" {
+		return false
+	}
+
+	for _, t := range strings.Split(match, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(t), "W/") == tag {
+			return false
+		}
+	}
+
+	return true
+}
+
+// abortConflict responds with 409 Conflict and the current state, so that clients can show
+// the changes made by someone else instead of silently overwriting them.
+func abortConflict(c *gin.Context, tag string, current interface{}) {
+	c.Header("ETag", tag)
+	c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+		"code":    http.StatusConflict,
+		"error":   ErrVersionConflict["error"],
+		"current": current,
+	})
+}
@@ -2,10 +2,10 @@ package api
 
 import (
 	"net/http"
-	"path"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -43,7 +43,8 @@ func GetVideo(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		if !f.FileVideo {
+		// Legacy formats like AVCHD are played using the transcoded MP4 version if available.
+		if !f.FileVideo || video.Legacy[fs.FileType(f.FileType)] {
 			f, err = query.VideoByPhotoUID(f.PhotoUID)
 
 			if err != nil {
@@ -59,7 +60,13 @@ func GetVideo(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+		fileName, err := photoprism.OriginalFileName(conf, f)
+
+		if err != nil {
+			log.Errorf("video: %s", err)
+			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+			return
+		}
 
 		if !fs.FileExists(fileName) {
 			log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ type clientInfo struct {
 	JsHash       string `json:"js"`
 	CssHash      string `json:"css"`
 	Version      string `json:"version"`
+	Since        uint64 `json:"since"`
 }
 
 var wsAuth = struct {
@@ -33,7 +35,20 @@ var wsAuth = struct {
 	mutex         sync.RWMutex
 }{authenticated: make(map[string]bool)}
 
-func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *config.Config) {
+// wsReplay requests missed events after the given sequence number, see event.History.
+func wsReplay(replay chan<- uint64, since uint64) {
+	if since == 0 {
+		return
+	}
+
+	select {
+	case replay <- since:
+	default:
+		log.Debug("websocket: replay already requested")
+	}
+}
+
+func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *config.Config, replay chan<- uint64, since uint64) {
 	defer ws.Close()
 
 	ws.SetReadLimit(512)
@@ -68,14 +83,25 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 					log.Error(err)
 				}
 				writeMutex.Unlock()
+
+				if info.Since > 0 {
+					since = info.Since
+				}
+
+				wsReplay(replay, since)
+			} else if conf.Public() {
+				wsReplay(replay, info.Since)
 			}
 		}
 	}
 }
 
-func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
+func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, replay <-chan uint64) {
+	// Sequence number of the last event sent, to skip events that have already been replayed.
+	var lastSeq uint64
+
 	pingTicker := time.NewTicker(15 * time.Second)
-	s := event.Subscribe("log.*", "notify.*", "index.*", "upload.*", "import.*", "config.*", "count.*", "photos.*", "albums.*", "labels.*", "sync.*")
+	s := event.Subscribe("log.*", "notify.*", "index.*", "upload.*", "import.*", "config.*", "count.*", "photos.*", "albums.*", "labels.*", "sync.*", "archive.*")
 
 	defer func() {
 		pingTicker.Stop()
@@ -94,12 +120,53 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 			if err := ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
 				return
 			}
+		case since := <-replay:
+			if since < lastSeq {
+				since = lastSeq
+			}
+
+			messages, complete := event.SharedHistory().Since(since)
+
+			if !complete {
+				log.Debugf("websocket: can't replay events since %d", since)
+
+				writeMutex.Lock()
+				ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
+
+				// Clients must fetch the current state instead.
+				if err := ws.WriteJSON(gin.H{"event": "replay.incomplete", "data": event.Data{"since": since, event.SeqKey: event.SharedHistory().Seq()}}); err != nil {
+					writeMutex.Unlock()
+					log.Debug(err)
+					return
+				}
+				writeMutex.Unlock()
+
+				continue
+			}
+
+			log.Debugf("websocket: replaying %d events since %d", len(messages), since)
+
+			for _, msg := range messages {
+				writeMutex.Lock()
+				ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
+
+				if err := ws.WriteJSON(gin.H{"event": msg.Name, "data": msg.Fields}); err != nil {
+					writeMutex.Unlock()
+					log.Debug(err)
+					return
+				}
+				writeMutex.Unlock()
+
+				lastSeq = event.Seq(msg)
+			}
 		case msg := <-s.Receiver:
 			wsAuth.mutex.RLock()
 			auth := wsAuth.authenticated[connId]
 			wsAuth.mutex.RUnlock()
 
-			if auth {
+			seq := event.Seq(msg)
+
+			if auth && (seq == 0 || seq > lastSeq) {
 				writeMutex.Lock()
 				ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
 
@@ -109,12 +176,19 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 					return
 				}
 				writeMutex.Unlock()
+
+				if seq > 0 {
+					lastSeq = seq
+				}
 			}
 		}
 	}
 }
 
 // GET /api/v1/ws
+//
+// Parameters:
+//   since: uint Sequence number of the last event received, to replay missed events after reconnecting
 func Websocket(router *gin.RouterGroup, conf *config.Config) {
 	if router == nil {
 		log.Error("websocket: router is nil")
@@ -141,17 +215,21 @@ func Websocket(router *gin.RouterGroup, conf *config.Config) {
 		defer ws.Close()
 
 		connId := rnd.UUID()
+		since, _ := strconv.ParseUint(c.Query("since"), 10, 64)
+		replay := make(chan uint64, 1)
 
 		if conf.Public() {
 			wsAuth.mutex.Lock()
 			wsAuth.authenticated[connId] = true
 			wsAuth.mutex.Unlock()
+
+			wsReplay(replay, since)
 		}
 
 		log.Debug("websocket: connected")
 
-		go wsWriter(ws, &writeMutex, connId)
+		go wsWriter(ws, &writeMutex, connId, replay)
 
-		wsReader(ws, &writeMutex, connId, conf)
+		wsReader(ws, &writeMutex, connId, conf, replay, since)
 	})
 }
@@ -1,17 +1,19 @@
 package api
 
 import (
-	"archive/zip"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/archive"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -21,6 +23,9 @@ import (
 )
 
 // POST /api/v1/zip
+//
+// Parameters:
+//   convert: string Use "jpeg" to download JPEG versions of formats like HEIC and RAW (query)
 func CreateZip(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/zip", func(c *gin.Context) {
 		if Unauthorized(c, conf) {
@@ -68,36 +73,29 @@ func CreateZip(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		newZipFile, err := os.Create(zipFileName)
-
-		if err != nil {
-			log.Error(err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
-			return
-		}
-
-		defer newZipFile.Close()
-
-		zipWriter := zip.NewWriter(newZipFile)
-		defer zipWriter.Close()
+		var entries []archive.Entry
 
 		for _, f := range files {
-			fileName := path.Join(conf.OriginalsPath(), f.FileName)
-			fileAlias := f.ShareFileName()
+			fileName, err := photoprism.OriginalFileName(conf, f)
+
+			if err != nil {
+				log.Errorf("zip: %s", err)
+				continue
+			}
 
 			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, fileAlias); err != nil {
-					log.Error(err)
-					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst("failed to create zip file")})
-					return
-				}
-				log.Infof("zip: added %s as %s", txt.Quote(f.FileName), txt.Quote(fileAlias))
+				entries = append(entries, downloadEntry(c, fileName, f.FileHash, f.ShareFileName()))
 			} else {
 				log.Warnf("zip: file %s is missing", txt.Quote(f.FileName))
 				report("zip", f.Update("FileMissing", true))
 			}
 		}
 
+		if err := createArchive(conf, zipFileName, entries); err != nil {
+			archiveError(c, err)
+			return
+		}
+
 		elapsed := int(time.Since(start).Seconds())
 
 		log.Infof("zip: archive %s created in %s", txt.Quote(zipBaseName), time.Since(start))
@@ -126,6 +124,8 @@ func DownloadZip(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		countUsage(conf, entity.UsageDownload)
+
 		c.File(zipFileName)
 
 		if err := os.Remove(zipFileName); err != nil {
@@ -134,34 +134,27 @@ func DownloadZip(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
-func addFileToZip(zipWriter *zip.Writer, fileName, fileAlias string) error {
-	fileToZip, err := os.Open(fileName)
-	if err != nil {
-		return err
-	}
-	defer fileToZip.Close()
+// createArchive reserves temp space and creates a zip archive, reading files with one worker per core.
+func createArchive(conf *config.Config, zipName string, entries []archive.Entry) error {
+	release, err := archive.Reserve(conf.TempPath(), archive.Size(entries), conf.TempLimit())
 
-	// Get the file information
-	info, err := fileToZip.Stat()
 	if err != nil {
 		return err
 	}
 
-	header, err := zip.FileInfoHeader(info)
-	if err != nil {
-		return err
-	}
-
-	header.Name = fileAlias
+	defer release()
 
-	// Change to deflate to gain better compression
-	// see http://golang.org/pkg/archive/zip/#pkg-constants
-	header.Method = zip.Deflate
+	return archive.Zip(zipName, entries, conf.Workers())
+}
 
-	writer, err := zipWriter.CreateHeader(header)
-	if err != nil {
-		return err
+// archiveError aborts the request with a status code matching the archive error.
+func archiveError(c *gin.Context, err error) {
+	switch err {
+	case archive.ErrTempLimit, archive.ErrDiskFull:
+		log.Warnf("zip: %s", strings.TrimPrefix(err.Error(), "archive: "))
+		c.AbortWithStatusJSON(http.StatusInsufficientStorage, gin.H{"error": txt.UcFirst(strings.TrimPrefix(err.Error(), "archive: "))})
+	default:
+		log.Errorf("zip: %s", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst("failed to create zip file")})
 	}
-	_, err = io.Copy(writer, fileToZip)
-	return err
 }
@@ -0,0 +1,25 @@
+/*
+This package creates zip archives for downloads, either in the temp folder or streamed directly to the client.
+
+Files are read by a bounded number of workers in parallel, so that slow disks and network drives don't
+stall the archive writer. Temp space is reserved before an archive is created, so that requests fail fast
+with a clear error instead of filling up the disk.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package archive
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+var (
+	ErrTempLimit = errors.New("archive: temp space limit exceeded")
+	ErrDiskFull  = errors.New("archive: not enough free disk space")
+)
@@ -0,0 +1,303 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const jobPrefix = 'z'
+
+// JobLimit is the maximum number of jobs that may be pending or running at the same time.
+const JobLimit = 10
+
+// Job status values.
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+var (
+	ErrJobNotFound = errors.New("archive: job not found")
+	ErrJobNotDone  = errors.New("archive: job not done")
+	ErrTooManyJobs = errors.New("archive: too many jobs")
+)
+
+// Job represents an archive that is created in the background.
+type Job struct {
+	ID        string    `json:"ID"`
+	Name      string    `json:"Name"`
+	Status    string    `json:"Status"`
+	Files     int       `json:"Files"`
+	Done      int       `json:"Done"`
+	Size      int64     `json:"Size"`
+	Error     string    `json:"Error,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+	key       string
+}
+
+// Percent returns the percentage of files added to the archive.
+func (j Job) Percent() int {
+	if j.Status == JobDone {
+		return 100
+	} else if j.Files == 0 {
+		return 0
+	}
+
+	return j.Done * 100 / j.Files
+}
+
+// Finished returns true if the job is done or failed.
+func (j Job) Finished() bool {
+	return j.Status == JobDone || j.Status == JobFailed
+}
+
+// Jobs creates archives in a folder in the background, one at a time, so that large downloads don't
+// depend on long running requests. Archives are kept until they are removed or expire, see Cleanup.
+type Jobs struct {
+	path    string
+	limit   int64
+	workers int
+	maxJobs int
+	mutex   sync.Mutex
+	jobs    map[string]*Job
+	queue   chan bool
+}
+
+// NewJobs returns a new job queue that creates archives in the given path, using the given number of workers
+// to read files. The temp space limit in bytes is ignored if 0, see Reserve.
+func NewJobs(path string, limit int64, workers int) *Jobs {
+	return &Jobs{
+		path:    path,
+		limit:   limit,
+		workers: workers,
+		maxJobs: JobLimit,
+		jobs:    make(map[string]*Job),
+		queue:   make(chan bool, 1),
+	}
+}
+
+// Start adds a job that creates an archive and returns it. The key identifies the content of the archive,
+// if a job with the same key is still pending or running, it is returned instead of adding another one.
+// The name is used when downloading the archive, e.g. "Holiday.zip". The prepare function is called by the
+// worker to get the entries, so that slow preparations like converting files don't block the caller. The
+// notify function is called from the worker whenever the status or the progress percentage changes, unless
+// it is nil. Returns ErrTooManyJobs if JobLimit jobs are already waiting to be finished.
+func (q *Jobs) Start(key, name string, prepare func() ([]Entry, error), notify func(Job)) (Job, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	active := 0
+
+	for _, job := range q.jobs {
+		if job.Finished() {
+			continue
+		} else if key != "" && job.key == key {
+			return *job, nil
+		}
+
+		active++
+	}
+
+	if active >= q.maxJobs {
+		return Job{}, ErrTooManyJobs
+	}
+
+	now := time.Now().UTC()
+
+	job := &Job{
+		ID:        rnd.PPID(jobPrefix),
+		Name:      name,
+		Status:    JobPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+		key:       key,
+	}
+
+	q.jobs[job.ID] = job
+
+	go q.run(job, prepare, notify)
+
+	return *job, nil
+}
+
+// run creates the archive of a job once no other job is running.
+func (q *Jobs) run(job *Job, prepare func() ([]Entry, error), notify func(Job)) {
+	q.queue <- true
+	defer func() { <-q.queue }()
+
+	update := func(f func(j *Job)) {
+		q.mutex.Lock()
+		f(job)
+		job.UpdatedAt = time.Now().UTC()
+		result := *job
+		q.mutex.Unlock()
+
+		if notify != nil {
+			notify(result)
+		}
+	}
+
+	fail := func(err error) {
+		log.Errorf("archive: job %s failed (%s)", job.ID, err)
+
+		update(func(j *Job) {
+			j.Status = JobFailed
+			j.Error = err.Error()
+		})
+	}
+
+	start := time.Now()
+
+	update(func(j *Job) { j.Status = JobRunning })
+
+	entries, err := prepare()
+
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	update(func(j *Job) { j.Files = len(entries) })
+
+	if err := os.MkdirAll(q.path, 0700); err != nil {
+		fail(err)
+		return
+	}
+
+	release, err := Reserve(q.path, Size(entries), q.limit)
+
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	defer release()
+
+	percent := 0
+
+	err = zipProgress(q.fileName(job.ID), entries, q.workers, func(done int) {
+		q.mutex.Lock()
+		job.Done = done
+		q.mutex.Unlock()
+
+		if p := done * 100 / len(entries); p > percent {
+			percent = p
+			update(func(j *Job) {})
+		}
+	})
+
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	var size int64
+
+	if info, err := os.Stat(q.fileName(job.ID)); err == nil {
+		size = info.Size()
+	}
+
+	update(func(j *Job) {
+		j.Status = JobDone
+		j.Size = size
+	})
+
+	log.Infof("archive: created %s with %d files in %s", txt.Quote(job.Name), len(entries), time.Since(start))
+}
+
+// fileName returns the archive file name of a job.
+func (q *Jobs) fileName(id string) string {
+	return filepath.Join(q.path, id+".zip")
+}
+
+// Get returns the job with the given id.
+func (q *Jobs) Get(id string) (Job, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if job, ok := q.jobs[id]; ok {
+		return *job, nil
+	}
+
+	return Job{}, ErrJobNotFound
+}
+
+// List returns all jobs, oldest first.
+func (q *Jobs) List() (result []Job) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	result = make([]Job, 0, len(q.jobs))
+
+	for _, job := range q.jobs {
+		result = append(result, *job)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result
+}
+
+// FileName returns the archive file name of a job that is done.
+func (q *Jobs) FileName(id string) (string, Job, error) {
+	job, err := q.Get(id)
+
+	if err != nil {
+		return "", job, err
+	} else if job.Status != JobDone {
+		return "", job, ErrJobNotDone
+	}
+
+	return q.fileName(id), job, nil
+}
+
+// Remove removes a finished job and its archive.
+func (q *Jobs) Remove(id string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	job, ok := q.jobs[id]
+
+	if !ok {
+		return ErrJobNotFound
+	} else if !job.Finished() {
+		return ErrJobNotDone
+	}
+
+	delete(q.jobs, id)
+
+	if err := os.Remove(q.fileName(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Cleanup removes finished jobs that haven't been changed for longer than maxAge.
+func (q *Jobs) Cleanup(maxAge time.Duration) (removed int) {
+	for _, job := range q.List() {
+		if !job.Finished() || time.Since(job.UpdatedAt) < maxAge {
+			continue
+		}
+
+		if err := q.Remove(job.ID); err != nil {
+			log.Errorf("archive: %s", err)
+		} else {
+			removed++
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wait returns the job once it is finished.
+func wait(t *testing.T, q *Jobs, id string) Job {
+	for i := 0; i < 500; i++ {
+		job, err := q.Get(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if job.Finished() {
+			return job
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("job not finished")
+
+	return Job{}
+}
+
+func TestJobs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	var entries []Entry
+
+	for i := 0; i < 3; i++ {
+		fileName := filepath.Join(dir, fmt.Sprintf("%d.jpg", i))
+
+		if err := ioutil.WriteFile(fileName, []byte(fmt.Sprintf("image %d", i)), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		entries = append(entries, Entry{FileName: fileName, Alias: fmt.Sprintf("photo-%d.jpg", i)})
+	}
+
+	q := NewJobs(filepath.Join(dir, "jobs"), 0, 2)
+
+	t.Run("done", func(t *testing.T) {
+		var mutex sync.Mutex
+		var events []Job
+
+		job, err := q.Start("holiday", "Holiday.zip", func() ([]Entry, error) { return entries, nil }, func(j Job) {
+			mutex.Lock()
+			events = append(events, j)
+			mutex.Unlock()
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Holiday.zip", job.Name)
+		assert.Equal(t, JobPending, job.Status)
+
+		job = wait(t, q, job.ID)
+
+		assert.Equal(t, JobDone, job.Status)
+		assert.Equal(t, 3, job.Files)
+		assert.Equal(t, 3, job.Done)
+		assert.Equal(t, 100, job.Percent())
+		assert.True(t, job.Size > 0)
+
+		mutex.Lock()
+
+		if assert.NotEmpty(t, events) {
+			assert.Equal(t, JobRunning, events[0].Status)
+			assert.Equal(t, JobDone, events[len(events)-1].Status)
+		}
+
+		mutex.Unlock()
+
+		fileName, _, err := q.FileName(job.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := zip.OpenReader(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r.File, 3)
+		r.Close()
+
+		assert.Equal(t, 0, q.Cleanup(time.Hour))
+		assert.Equal(t, 1, q.Cleanup(0))
+		assert.NoFileExists(t, fileName)
+
+		_, err = q.Get(job.ID)
+		assert.Equal(t, ErrJobNotFound, err)
+	})
+	t.Run("failed", func(t *testing.T) {
+		job, err := q.Start("failed", "Failed.zip", func() ([]Entry, error) { return nil, errors.New("album not found") }, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		job = wait(t, q, job.ID)
+
+		assert.Equal(t, JobFailed, job.Status)
+		assert.Equal(t, "album not found", job.Error)
+
+		_, _, err = q.FileName(job.ID)
+		assert.Equal(t, ErrJobNotDone, err)
+
+		assert.NoError(t, q.Remove(job.ID))
+		assert.Equal(t, ErrJobNotFound, q.Remove(job.ID))
+	})
+	t.Run("missing file", func(t *testing.T) {
+		missing := append(entries[:1:1], Entry{FileName: filepath.Join(dir, "xxx.jpg"), Alias: "xxx.jpg"})
+
+		job, err := q.Start("missing", "Missing.zip", func() ([]Entry, error) { return missing, nil }, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		job = wait(t, q, job.ID)
+
+		assert.Equal(t, JobFailed, job.Status)
+		assert.NoFileExists(t, filepath.Join(dir, "jobs", job.ID+".zip"))
+	})
+}
+
+func TestJobs_Start(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	q := NewJobs(filepath.Join(dir, "jobs"), 0, 1)
+	q.maxJobs = 2
+
+	release := make(chan bool)
+
+	prepare := func() ([]Entry, error) {
+		<-release
+		return nil, errors.New("canceled")
+	}
+
+	first, err := q.Start("album-1", "First.zip", prepare, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("same key", func(t *testing.T) {
+		job, err := q.Start("album-1", "First.zip", prepare, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, first.ID, job.ID)
+		assert.Len(t, q.List(), 1)
+	})
+	t.Run("too many jobs", func(t *testing.T) {
+		if _, err := q.Start("album-2", "Second.zip", prepare, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := q.Start("album-3", "Third.zip", prepare, nil)
+		assert.Equal(t, ErrTooManyJobs, err)
+	})
+
+	close(release)
+
+	for _, job := range q.List() {
+		assert.Equal(t, JobFailed, wait(t, q, job.ID).Status)
+	}
+
+	t.Run("finished", func(t *testing.T) {
+		job, err := q.Start("album-1", "First.zip", prepare, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, first.ID, job.ID)
+		wait(t, q, job.ID)
+	})
+}
+
+func TestJob_Percent(t *testing.T) {
+	assert.Equal(t, 0, Job{Status: JobPending}.Percent())
+	assert.Equal(t, 50, Job{Status: JobRunning, Files: 4, Done: 2}.Percent())
+	assert.Equal(t, 100, Job{Status: JobDone}.Percent())
+}
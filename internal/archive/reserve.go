@@ -0,0 +1,64 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/sysinfo"
+)
+
+// DiskReserve is the free disk space in bytes that is never used for archives.
+const DiskReserve = 256 * sysinfo.MB
+
+var reserved int64
+var reservedMutex = sync.Mutex{}
+
+// Reserved returns the number of bytes reserved for archives that are being created.
+func Reserved() int64 {
+	reservedMutex.Lock()
+	defer reservedMutex.Unlock()
+
+	return reserved
+}
+
+// Reserve reserves temp space for an archive of the given size and returns a function to release it once
+// the archive is complete. Existing files in the temp path count towards the limit in bytes, which is
+// ignored if 0. An error is returned if the limit would be exceeded or the disk doesn't have enough space.
+func Reserve(tempPath string, size, limit int64) (release func(), err error) {
+	reservedMutex.Lock()
+	defer reservedMutex.Unlock()
+
+	if limit > 0 && Used(tempPath)+reserved+size > limit {
+		return nil, ErrTempLimit
+	}
+
+	if free := sysinfo.DiskFree(tempPath); free > 0 && uint64(reserved+size)+DiskReserve > free {
+		return nil, ErrDiskFull
+	}
+
+	reserved += size
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			reservedMutex.Lock()
+			reserved -= size
+			reservedMutex.Unlock()
+		})
+	}, nil
+}
+
+// Used returns the total size of files in the temp path.
+func Used(tempPath string) (size int64) {
+	_ = filepath.Walk(tempPath, func(fileName string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
@@ -0,0 +1,82 @@
+package archive
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "existing.zip"), make([]byte, 1000), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		release, err := Reserve(dir, 500, 2000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(500), Reserved())
+
+		_, err = Reserve(dir, 600, 2000)
+
+		assert.Equal(t, ErrTempLimit, err)
+
+		release()
+		release()
+
+		assert.Equal(t, int64(0), Reserved())
+	})
+	t.Run("no limit", func(t *testing.T) {
+		release, err := Reserve(dir, 5000, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		release()
+	})
+	t.Run("disk full", func(t *testing.T) {
+		_, err := Reserve(dir, 1<<62, 0)
+
+		assert.Equal(t, ErrDiskFull, err)
+	})
+}
+
+func TestUsed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "zip"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "zip", "a.zip"), make([]byte, 100), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "b.zip"), make([]byte, 50), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(150), Used(dir))
+	assert.Equal(t, int64(0), Used(filepath.Join(dir, "xxx")))
+}
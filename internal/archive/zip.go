@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BufferLimit is the max size of files read in advance by workers, larger files are read while writing.
+const BufferLimit = 32 * 1024 * 1024
+
+// storeExt contains extensions of compressed formats that are stored without compression to save CPU time.
+var storeExt = map[string]bool{
+	".jpg": true, ".jpeg": true, ".heic": true, ".heif": true, ".png": true, ".gif": true, ".webp": true,
+	".mp4": true, ".m4v": true, ".mov": true, ".avi": true, ".mkv": true, ".webm": true, ".3gp": true,
+	".mts": true, ".m2ts": true, ".dv": true, ".zip": true,
+}
+
+// Entry represents a file to be added to an archive.
+type Entry struct {
+	FileName string
+	Alias    string
+}
+
+// Size returns the total size of all files in bytes, missing files are ignored.
+func Size(entries []Entry) (size int64) {
+	for _, e := range entries {
+		if info, err := os.Stat(e.FileName); err == nil {
+			size += info.Size()
+		}
+	}
+
+	return size
+}
+
+// file represents a file read by a worker.
+type file struct {
+	info os.FileInfo
+	data []byte
+	err  error
+}
+
+// read returns the file info and the content of small files.
+func read(e Entry) (result file) {
+	result.info, result.err = os.Stat(e.FileName)
+
+	if result.err != nil || result.info.Size() > BufferLimit {
+		return result
+	}
+
+	result.data, result.err = ioutil.ReadFile(e.FileName)
+
+	return result
+}
+
+// Zip creates a zip archive with all entries, using the given number of workers to read files in parallel.
+// The archive is removed if an error occurs.
+func Zip(zipName string, entries []Entry, workers int) error {
+	return zipProgress(zipName, entries, workers, nil)
+}
+
+// zipProgress creates a zip archive like Zip and reports progress like WriteProgress.
+func zipProgress(zipName string, entries []Entry, workers int, progress func(done int)) (err error) {
+	f, err := os.Create(zipName)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			_ = os.Remove(zipName)
+		}
+	}()
+
+	return WriteProgress(f, entries, workers, progress)
+}
+
+// Write writes a zip archive with all entries to w, e.g. an HTTP response, using the given number of workers
+// to read files in parallel. No more than workers files are buffered at any time.
+func Write(out io.Writer, entries []Entry, workers int) error {
+	return WriteProgress(out, entries, workers, nil)
+}
+
+// WriteProgress works like Write and calls progress with the number of entries written so far
+// after each entry, unless it is nil.
+func WriteProgress(out io.Writer, entries []Entry, workers int, progress func(done int)) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]chan file, len(entries))
+
+	for i := range results {
+		results[i] = make(chan file, 1)
+	}
+
+	jobs := make(chan int)
+	ahead := make(chan bool, workers)
+	done := make(chan bool)
+
+	defer close(done)
+
+	// Dispatch jobs, but not more than workers files ahead of the writer.
+	go func() {
+		defer close(jobs)
+
+		for i := range entries {
+			select {
+			case ahead <- true:
+				jobs <- i
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				results[i] <- read(entries[i])
+			}
+		}()
+	}
+
+	w := zip.NewWriter(out)
+
+	for i, e := range entries {
+		r := <-results[i]
+
+		if r.err != nil {
+			return r.err
+		}
+
+		if err := write(w, e, r); err != nil {
+			return err
+		}
+
+		log.Debugf("archive: added %s as %s", txt.Quote(filepath.Base(e.FileName)), txt.Quote(e.Alias))
+
+		<-ahead
+
+		if progress != nil {
+			progress(i + 1)
+		}
+	}
+
+	return w.Close()
+}
+
+// write adds a file to the archive.
+func write(w *zip.Writer, e Entry, f file) error {
+	header, err := zip.FileInfoHeader(f.info)
+
+	if err != nil {
+		return err
+	}
+
+	header.Name = e.Alias
+
+	if storeExt[strings.ToLower(filepath.Ext(e.FileName))] {
+		header.Method = zip.Store
+	} else {
+		header.Method = zip.Deflate
+	}
+
+	writer, err := w.CreateHeader(header)
+
+	if err != nil {
+		return err
+	}
+
+	if f.data != nil {
+		_, err = writer.Write(f.data)
+		return err
+	}
+
+	src, err := os.Open(e.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	_, err = io.Copy(writer, src)
+
+	return err
+}
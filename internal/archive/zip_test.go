@@ -0,0 +1,164 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	var entries []Entry
+
+	for i := 0; i < 10; i++ {
+		fileName := filepath.Join(dir, fmt.Sprintf("%d.jpg", i))
+
+		if err := ioutil.WriteFile(fileName, []byte(fmt.Sprintf("image %d", i)), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		entries = append(entries, Entry{FileName: fileName, Alias: fmt.Sprintf("photo-%d.jpg", i)})
+	}
+
+	xmpName := filepath.Join(dir, "0.xmp")
+
+	if err := ioutil.WriteFile(xmpName, []byte("<x:xmpmeta></x:xmpmeta>"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries = append(entries, Entry{FileName: xmpName, Alias: "photo-0.xmp"})
+
+	t.Run("success", func(t *testing.T) {
+		zipName := filepath.Join(dir, "success.zip")
+
+		if err := Zip(zipName, entries, 3); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := zip.OpenReader(zipName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer r.Close()
+
+		assert.Len(t, r.File, 11)
+
+		for i, f := range r.File[:10] {
+			assert.Equal(t, fmt.Sprintf("photo-%d.jpg", i), f.Name)
+			assert.Equal(t, zip.Store, f.Method)
+		}
+
+		assert.Equal(t, zip.Deflate, r.File[10].Method)
+
+		rc, err := r.File[3].Open()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadAll(rc)
+		rc.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image 3", string(data))
+	})
+	t.Run("missing file", func(t *testing.T) {
+		zipName := filepath.Join(dir, "missing.zip")
+		missing := append([]Entry{{FileName: filepath.Join(dir, "xxx.jpg"), Alias: "xxx.jpg"}}, entries...)
+
+		err := Zip(zipName, missing, 2)
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, zipName)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	var entries []Entry
+
+	for i := 0; i < 5; i++ {
+		fileName := filepath.Join(dir, fmt.Sprintf("%d.jpg", i))
+
+		if err := ioutil.WriteFile(fileName, []byte(fmt.Sprintf("image %d", i)), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		entries = append(entries, Entry{FileName: fileName, Alias: fmt.Sprintf("photo-%d.jpg", i)})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		if err := Write(&buf, entries, 2); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, r.File, 5) {
+			assert.Equal(t, "photo-4.jpg", r.File[4].Name)
+		}
+	})
+	t.Run("progress", func(t *testing.T) {
+		var buf bytes.Buffer
+		var progress []int
+
+		if err := WriteProgress(&buf, entries, 2, func(done int) { progress = append(progress, done) }); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, progress)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		missing := append(entries[:2:2], Entry{FileName: filepath.Join(dir, "xxx.jpg"), Alias: "xxx.jpg"})
+
+		assert.Error(t, Write(&buf, missing, 2))
+	})
+}
+
+func TestSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "a.jpg")
+
+	if err := ioutil.WriteFile(fileName, make([]byte, 123), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(123), Size([]Entry{{FileName: fileName}, {FileName: filepath.Join(dir, "xxx.jpg")}}))
+}
@@ -0,0 +1,30 @@
+/*
+This package stores resumable uploads from mobile backup apps until they are imported.
+
+Uploads are grouped in batches. Each upload is created with the file name, size and SHA1 hash
+announced by the client, data is then appended in chunks at the current offset. Interrupted
+uploads can be resumed by requesting the current offset. Complete files are verified using their
+hash and moved to the import folder when the batch is committed.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package backup
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+var (
+	ErrNotFound  = errors.New("backup: not found")
+	ErrInvalid   = errors.New("backup: invalid name, size or hash")
+	ErrOffset    = errors.New("backup: offset does not match")
+	ErrComplete  = errors.New("backup: upload already complete")
+	ErrChecksum  = errors.New("backup: checksum does not match")
+	ErrCommitted = errors.New("backup: batch already committed")
+)
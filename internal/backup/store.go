@@ -0,0 +1,353 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	batchPrefix  = 'b'
+	uploadPrefix = 'u'
+	batchFile    = "batch.json"
+	metaExt      = ".json"
+	dataExt      = ".part"
+)
+
+// Store keeps batches and uploads in a directory on disk, so that uploads survive restarts.
+type Store struct {
+	path  string
+	mutex sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// NewStore returns a new upload store in the given directory.
+func NewStore(path string) *Store {
+	return &Store{path: path, locks: make(map[string]*sync.Mutex)}
+}
+
+// lock returns the mutex for an upload id.
+func (s *Store) lock(id string) *sync.Mutex {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if l, ok := s.locks[id]; ok {
+		return l
+	}
+
+	l := &sync.Mutex{}
+	s.locks[id] = l
+
+	return l
+}
+
+// batchPath returns the directory of a batch or an error if the id is invalid.
+func (s *Store) batchPath(batchID string) (string, error) {
+	if !rnd.IsPPID(batchID, batchPrefix) || strings.ContainsAny(batchID, "./\\") {
+		return "", ErrNotFound
+	}
+
+	return filepath.Join(s.path, batchID), nil
+}
+
+// uploadName returns the base file name of an upload or an error if the ids are invalid.
+func (s *Store) uploadName(batchID, uploadID string) (string, error) {
+	p, err := s.batchPath(batchID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !rnd.IsPPID(uploadID, uploadPrefix) || strings.ContainsAny(uploadID, "./\\") {
+		return "", ErrNotFound
+	}
+
+	return filepath.Join(p, uploadID), nil
+}
+
+// writeJson saves a value as JSON file.
+func writeJson(fileName string, v interface{}) error {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, data, os.ModePerm)
+}
+
+// readJson loads a value from a JSON file.
+func readJson(fileName string, v interface{}) error {
+	data, err := ioutil.ReadFile(fileName)
+
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// NewBatch creates a new empty batch, the device is the UID of the registered device uploading it, if any.
+func (s *Store) NewBatch(device string) (b Batch, err error) {
+	b = Batch{ID: rnd.PPID(batchPrefix), Device: device, CreatedAt: time.Now().UTC(), Uploads: []Upload{}}
+
+	p, err := s.batchPath(b.ID)
+
+	if err != nil {
+		return b, err
+	}
+
+	if err := os.MkdirAll(p, os.ModePerm); err != nil {
+		return b, err
+	}
+
+	return b, writeJson(filepath.Join(p, batchFile), b)
+}
+
+// Batch returns a batch including all its uploads.
+func (s *Store) Batch(batchID string) (b Batch, err error) {
+	p, err := s.batchPath(batchID)
+
+	if err != nil {
+		return b, err
+	}
+
+	if err := readJson(filepath.Join(p, batchFile), &b); err != nil {
+		return b, err
+	}
+
+	b.Uploads = []Upload{}
+
+	matches, err := filepath.Glob(filepath.Join(p, string(uploadPrefix)+"*"+metaExt))
+
+	if err != nil {
+		return b, err
+	}
+
+	for _, fileName := range matches {
+		var u Upload
+
+		if err := readJson(fileName, &u); err != nil {
+			log.Errorf("backup: %s", err)
+			continue
+		}
+
+		b.Uploads = append(b.Uploads, u)
+
+		if u.Complete() {
+			b.Complete++
+		}
+	}
+
+	sort.Slice(b.Uploads, func(i, j int) bool {
+		return b.Uploads[i].CreatedAt.Before(b.Uploads[j].CreatedAt)
+	})
+
+	b.Files = len(b.Uploads)
+
+	return b, nil
+}
+
+// Create adds a new upload to a batch.
+func (s *Store) Create(batchID, name string, size int64, hash string) (u Upload, err error) {
+	name = filepath.Base(name)
+	hash = strings.ToLower(hash)
+
+	if name == "" || name == "." || name == string(filepath.Separator) || size <= 0 || len(hash) != 40 {
+		return u, ErrInvalid
+	}
+
+	b, err := s.Batch(batchID)
+
+	if err != nil {
+		return u, err
+	} else if b.Committed() {
+		return u, ErrCommitted
+	}
+
+	now := time.Now().UTC()
+
+	u = Upload{
+		ID:        rnd.PPID(uploadPrefix),
+		BatchID:   b.ID,
+		Name:      name,
+		Size:      size,
+		Hash:      hash,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	baseName, err := s.uploadName(b.ID, u.ID)
+
+	if err != nil {
+		return u, err
+	}
+
+	return u, writeJson(baseName+metaExt, u)
+}
+
+// Upload returns an existing upload.
+func (s *Store) Upload(batchID, uploadID string) (u Upload, err error) {
+	baseName, err := s.uploadName(batchID, uploadID)
+
+	if err != nil {
+		return u, err
+	}
+
+	err = readJson(baseName+metaExt, &u)
+
+	return u, err
+}
+
+// Write appends data to an upload, starting at offset. The offset must match the number of bytes already
+// received. Data exceeding the announced file size is ignored. Once complete, the data is verified using
+// its SHA1 hash and discarded if it doesn't match.
+func (s *Store) Write(batchID, uploadID string, offset int64, r io.Reader) (u Upload, err error) {
+	l := s.lock(uploadID)
+	l.Lock()
+	defer l.Unlock()
+
+	if u, err = s.Upload(batchID, uploadID); err != nil {
+		return u, err
+	} else if u.Committed || u.Complete() {
+		return u, ErrComplete
+	} else if offset != u.Offset {
+		return u, ErrOffset
+	}
+
+	baseName, _ := s.uploadName(batchID, uploadID)
+	dataName := baseName + dataExt
+
+	f, err := os.OpenFile(dataName, os.O_WRONLY|os.O_CREATE, os.ModePerm)
+
+	if err != nil {
+		return u, err
+	}
+
+	// Discard data after the offset that was not confirmed.
+	if err := f.Truncate(u.Offset); err != nil {
+		f.Close()
+		return u, err
+	}
+
+	if _, err := f.Seek(u.Offset, io.SeekStart); err != nil {
+		f.Close()
+		return u, err
+	}
+
+	n, copyErr := io.Copy(f, io.LimitReader(r, u.Size-u.Offset))
+
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+
+	u.Offset += n
+	u.UpdatedAt = time.Now().UTC()
+
+	if u.Complete() && copyErr == nil {
+		if hash := fs.Hash(dataName); hash != u.Hash {
+			log.Warnf("backup: checksum of %s does not match (expected %s, got %s)", txt.Quote(u.Name), u.Hash, hash)
+
+			u.Offset = 0
+			copyErr = ErrChecksum
+
+			if err := os.Remove(dataName); err != nil {
+				log.Errorf("backup: %s", err)
+			}
+		}
+	}
+
+	if err := writeJson(baseName+metaExt, u); err != nil {
+		return u, err
+	}
+
+	return u, copyErr
+}
+
+// Commit moves all complete uploads of a batch to the destination directory and returns the number of files.
+func (s *Store) Commit(batchID, dest string) (b Batch, count int, err error) {
+	if b, err = s.Batch(batchID); err != nil {
+		return b, 0, err
+	} else if b.Committed() {
+		return b, 0, ErrCommitted
+	}
+
+	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
+		return b, 0, err
+	}
+
+	for i, u := range b.Uploads {
+		if !u.Complete() {
+			continue
+		}
+
+		l := s.lock(u.ID)
+		l.Lock()
+
+		baseName, _ := s.uploadName(b.ID, u.ID)
+		fileName := filepath.Join(dest, u.Name)
+
+		if fs.FileExists(fileName) {
+			fileName = filepath.Join(dest, fmt.Sprintf("%s_%s", u.ID, u.Name))
+		}
+
+		if err := os.Rename(baseName+dataExt, fileName); err != nil {
+			log.Errorf("backup: can't move %s (%s)", txt.Quote(u.Name), err)
+		} else {
+			u.Committed = true
+			b.Uploads[i] = u
+			count++
+
+			if err := writeJson(baseName+metaExt, u); err != nil {
+				log.Errorf("backup: %s", err)
+			}
+		}
+
+		l.Unlock()
+	}
+
+	now := time.Now().UTC()
+	b.CommittedAt = &now
+
+	p, _ := s.batchPath(b.ID)
+
+	return b, count, writeJson(filepath.Join(p, batchFile), Batch{ID: b.ID, Device: b.Device, CreatedAt: b.CreatedAt, CommittedAt: b.CommittedAt})
+}
+
+// Cleanup removes batches that haven't been changed for longer than maxAge.
+func (s *Store) Cleanup(maxAge time.Duration) (removed int) {
+	matches, err := filepath.Glob(filepath.Join(s.path, string(batchPrefix)+"*"))
+
+	if err != nil {
+		return 0
+	}
+
+	for _, p := range matches {
+		b, err := s.Batch(filepath.Base(p))
+
+		if err != nil || time.Since(b.UpdatedAt()) < maxAge {
+			continue
+		}
+
+		if err := os.RemoveAll(p); err != nil {
+			log.Errorf("backup: %s", err)
+		} else {
+			removed++
+		}
+	}
+
+	return removed
+}
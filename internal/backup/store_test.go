@@ -0,0 +1,216 @@
+package backup
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func testHash(data string) string {
+	sum := sha1.Sum([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestStore_NewBatch(t *testing.T) {
+	s := NewStore(filepath.Join(os.TempDir(), "photoprism-backup-test"))
+	defer os.RemoveAll(s.path)
+
+	b, err := s.NewBatch("cqzyqhn2m6l3sxyz")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, byte('b'), b.ID[0])
+	assert.False(t, b.Committed())
+
+	found, err := s.Batch(b.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, b.ID, found.ID)
+	assert.Equal(t, "cqzyqhn2m6l3sxyz", found.Device)
+	assert.Empty(t, found.Uploads)
+}
+
+func TestStore_Batch(t *testing.T) {
+	s := NewStore(filepath.Join(os.TempDir(), "photoprism-backup-test"))
+	defer os.RemoveAll(s.path)
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := s.Batch("bqzq1xxxxxxxxxxx")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := s.Batch("../../etc")
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestStore_Create(t *testing.T) {
+	s := NewStore(filepath.Join(os.TempDir(), "photoprism-backup-test"))
+	defer os.RemoveAll(s.path)
+
+	b, err := s.NewBatch("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		u, err := s.Create(b.ID, "../IMG_0001.jpg", 10, strings.ToUpper(testHash("0123456789")))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "IMG_0001.jpg", u.Name)
+		assert.Equal(t, testHash("0123456789"), u.Hash)
+		assert.Equal(t, int64(0), u.Offset)
+	})
+	t.Run("invalid hash", func(t *testing.T) {
+		_, err := s.Create(b.ID, "IMG_0001.jpg", 10, "abc")
+		assert.Equal(t, ErrInvalid, err)
+	})
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := s.Create(b.ID, "IMG_0001.jpg", 0, testHash("0123456789"))
+		assert.Equal(t, ErrInvalid, err)
+	})
+	t.Run("batch not found", func(t *testing.T) {
+		_, err := s.Create("bqzq1xxxxxxxxxxx", "IMG_0001.jpg", 10, testHash("0123456789"))
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestStore_Write(t *testing.T) {
+	s := NewStore(filepath.Join(os.TempDir(), "photoprism-backup-test"))
+	defer os.RemoveAll(s.path)
+
+	data := "0123456789"
+
+	b, err := s.NewBatch("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		u, err := s.Create(b.ID, "resume.txt", int64(len(data)), testHash(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		u, err = s.Write(b.ID, u.ID, 0, strings.NewReader(data[:4]))
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), u.Offset)
+
+		u, err = s.Write(b.ID, u.ID, 0, strings.NewReader(data[:4]))
+
+		assert.Equal(t, ErrOffset, err)
+		assert.Equal(t, int64(4), u.Offset)
+
+		u, err = s.Write(b.ID, u.ID, 4, strings.NewReader(data[4:]+"ignored"))
+
+		assert.Nil(t, err)
+		assert.True(t, u.Complete())
+		assert.Equal(t, int64(10), u.Offset)
+
+		_, err = s.Write(b.ID, u.ID, 10, strings.NewReader("more"))
+
+		assert.Equal(t, ErrComplete, err)
+	})
+	t.Run("checksum mismatch", func(t *testing.T) {
+		u, err := s.Create(b.ID, "mismatch.txt", int64(len(data)), testHash("9876543210"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		u, err = s.Write(b.ID, u.ID, 0, strings.NewReader(data))
+
+		assert.Equal(t, ErrChecksum, err)
+		assert.Equal(t, int64(0), u.Offset)
+	})
+}
+
+func TestStore_Commit(t *testing.T) {
+	s := NewStore(filepath.Join(os.TempDir(), "photoprism-backup-test"))
+	defer os.RemoveAll(s.path)
+
+	dest := filepath.Join(os.TempDir(), "photoprism-backup-test-dest")
+	defer os.RemoveAll(dest)
+
+	data := "0123456789"
+
+	b, err := s.NewBatch("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	complete, err := s.Create(b.ID, "complete.txt", int64(len(data)), testHash(data))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Write(b.ID, complete.ID, 0, strings.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Create(b.ID, "incomplete.txt", int64(len(data)), testHash(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	b, count, err := s.Commit(b.ID, dest)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, count)
+	assert.True(t, b.Committed())
+	assert.True(t, fs.FileExists(filepath.Join(dest, "complete.txt")))
+	assert.False(t, fs.FileExists(filepath.Join(dest, "incomplete.txt")))
+
+	_, _, err = s.Commit(b.ID, dest)
+
+	assert.Equal(t, ErrCommitted, err)
+
+	_, err = s.Create(b.ID, "late.txt", int64(len(data)), testHash(data))
+
+	assert.Equal(t, ErrCommitted, err)
+}
+
+func TestStore_Cleanup(t *testing.T) {
+	s := NewStore(filepath.Join(os.TempDir(), "photoprism-backup-test"))
+	defer os.RemoveAll(s.path)
+
+	b, err := s.NewBatch("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, s.Cleanup(time.Hour))
+
+	_, err = s.Batch(b.ID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, s.Cleanup(0))
+
+	_, err = s.Batch(b.ID)
+
+	assert.Equal(t, ErrNotFound, err)
+}
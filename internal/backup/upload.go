@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"time"
+)
+
+// Upload represents a single file upload that can be resumed at Offset.
+type Upload struct {
+	ID        string    `json:"ID"`
+	BatchID   string    `json:"BatchID"`
+	Name      string    `json:"Name"`
+	Size      int64     `json:"Size"`
+	Hash      string    `json:"Hash"`
+	Offset    int64     `json:"Offset"`
+	Committed bool      `json:"Committed"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+// Complete returns true if all data has been received.
+func (u Upload) Complete() bool {
+	return u.Offset >= u.Size
+}
+
+// Batch represents a group of uploads that are imported together.
+type Batch struct {
+	ID          string     `json:"ID"`
+	Device      string     `json:"Device,omitempty"`
+	CreatedAt   time.Time  `json:"CreatedAt"`
+	CommittedAt *time.Time `json:"CommittedAt"`
+	Files       int        `json:"Files"`
+	Complete    int        `json:"Complete"`
+	Uploads     []Upload   `json:"Uploads"`
+}
+
+// Committed returns true if the batch has been committed for import.
+func (b Batch) Committed() bool {
+	return b.CommittedAt != nil
+}
+
+// UpdatedAt returns the time of the last change.
+func (b Batch) UpdatedAt() (result time.Time) {
+	result = b.CreatedAt
+
+	if b.CommittedAt != nil && b.CommittedAt.After(result) {
+		result = *b.CommittedAt
+	}
+
+	for _, u := range b.Uploads {
+		if u.UpdatedAt.After(result) {
+			result = u.UpdatedAt
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,42 @@
+/*
+This package posts shared photos to chat groups using bot integrations.
+
+Photos are sent as resized JPEG images together with a text message containing the share link, so that
+sending a selection to the family group is a single action. Built-in services are Telegram, Matrix,
+Signal (via signal-cli-rest-api) and a generic webhook, see Service.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package chat
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// MaxImages is the maximum number of images posted at once, chat groups are easily flooded.
+const MaxImages = 30
+
+var (
+	ErrService = errors.New("chat: unknown service")
+	ErrEmpty   = errors.New("chat: no photos")
+	ErrTarget  = errors.New("chat: target chat not configured")
+)
+
+// Message represents resized images and a share link posted to a chat.
+type Message struct {
+	Text   string   `json:"text"`
+	Link   string   `json:"link"`
+	Images []string `json:"-"`
+}
+
+// Caption returns the message text followed by the share link.
+func (m Message) Caption() string {
+	return strings.TrimSpace(strings.TrimSpace(m.Text) + "\n" + m.Link)
+}
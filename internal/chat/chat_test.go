@@ -0,0 +1,46 @@
+package chat
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImages creates small JPEG images in a temporary directory that is removed by the returned function.
+func testImages(t *testing.T, count int) (images []string, cleanup func()) {
+	dir := filepath.Join(os.TempDir(), t.Name())
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < count; i++ {
+		fileName := filepath.Join(dir, string(rune('a'+i))+".jpg")
+
+		if err := imaging.Save(image.NewRGBA(image.Rect(0, 0, 40, 30)), fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		images = append(images, fileName)
+	}
+
+	return images, func() { os.RemoveAll(dir) }
+}
+
+func TestMessage_Caption(t *testing.T) {
+	t.Run("text and link", func(t *testing.T) {
+		m := Message{Text: " Summer 2020 ", Link: "https://photos.example.com/s/abc"}
+		assert.Equal(t, "Summer 2020\nhttps://photos.example.com/s/abc", m.Caption())
+	})
+	t.Run("link only", func(t *testing.T) {
+		m := Message{Link: "https://photos.example.com/s/abc"}
+		assert.Equal(t, "https://photos.example.com/s/abc", m.Caption())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", Message{}.Caption())
+	})
+}
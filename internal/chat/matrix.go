@@ -0,0 +1,126 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// MatrixServiceName is the name of the built-in Matrix bot service.
+const MatrixServiceName = "matrix"
+
+// MatrixService uploads images to the content repository of a Matrix homeserver and posts them to a room.
+type MatrixService struct {
+	Url    string
+	Token  string
+	RoomID string
+	Client *http.Client
+}
+
+// NewMatrixService returns a new service that posts to the room with the given id using a bot access token.
+func NewMatrixService(homeserverUrl, token, roomID string) (*MatrixService, error) {
+	if roomID == "" {
+		return nil, ErrTarget
+	}
+
+	if homeserverUrl == "" {
+		return nil, fmt.Errorf("chat: matrix homeserver url not configured")
+	}
+
+	return &MatrixService{
+		Url:    strings.TrimRight(homeserverUrl, "/"),
+		Token:  token,
+		RoomID: roomID,
+		Client: &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Send posts each image as m.image event, followed by the caption with the share link.
+func (s *MatrixService) Send(m Message) error {
+	for _, fileName := range m.Images {
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		contentUri, err := s.upload(filepath.Base(fileName), data)
+
+		if err != nil {
+			return err
+		}
+
+		event := map[string]interface{}{
+			"msgtype": "m.image",
+			"body":    filepath.Base(fileName),
+			"url":     contentUri,
+			"info":    map[string]interface{}{"mimetype": "image/jpeg", "size": len(data)},
+		}
+
+		if err := s.sendEvent(event); err != nil {
+			return err
+		}
+	}
+
+	if caption := m.Caption(); caption != "" {
+		return s.sendEvent(map[string]interface{}{"msgtype": "m.text", "body": caption})
+	}
+
+	return nil
+}
+
+// upload stores an image in the content repository and returns its mxc:// uri.
+func (s *MatrixService) upload(name string, data []byte) (string, error) {
+	uploadUrl := fmt.Sprintf("%s/_matrix/media/r0/upload?filename=%s", s.Url, url.QueryEscape(name))
+	req, err := http.NewRequest(http.MethodPost, uploadUrl, bytes.NewReader(data))
+
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+
+	var result struct {
+		ContentUri string `json:"content_uri"`
+	}
+
+	if err := do(s.Client, MatrixServiceName, req, &result); err != nil {
+		return "", err
+	}
+
+	if result.ContentUri == "" {
+		return "", fmt.Errorf("chat: matrix returned no content uri")
+	}
+
+	return result.ContentUri, nil
+}
+
+// sendEvent posts a room message event.
+func (s *MatrixService) sendEvent(event map[string]interface{}) error {
+	body, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	eventUrl := fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message/%s", s.Url, url.PathEscape(s.RoomID), rnd.Token(10))
+	req, err := http.NewRequest(http.MethodPut, eventUrl, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+
+	return do(s.Client, MatrixServiceName, req, nil)
+}
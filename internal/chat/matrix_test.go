@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixService_Send(t *testing.T) {
+	images, cleanup := testImages(t, 2)
+
+	defer cleanup()
+
+	t.Run("success", func(t *testing.T) {
+		var events []map[string]interface{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+			if r.URL.Path == "/_matrix/media/r0/upload" {
+				assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+				_, _ = w.Write([]byte(`{"content_uri":"mxc://example.com/abc"}`))
+				return
+			}
+
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.True(t, strings.HasPrefix(r.URL.Path, "/_matrix/client/r0/rooms/!room:example.com/send/m.room.message/"))
+
+			var event map[string]interface{}
+
+			if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+				t.Error(err)
+			}
+
+			events = append(events, event)
+
+			_, _ = w.Write([]byte(`{"event_id":"$abc"}`))
+		}))
+
+		defer server.Close()
+
+		s, err := NewMatrixService(server.URL, "token", "!room:example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, s.Send(Message{Link: "https://photos.example.com/s/abc", Images: images}))
+
+		if assert.Len(t, events, 3) {
+			assert.Equal(t, "m.image", events[0]["msgtype"])
+			assert.Equal(t, "mxc://example.com/abc", events[0]["url"])
+			assert.Equal(t, "m.text", events[2]["msgtype"])
+			assert.Equal(t, "https://photos.example.com/s/abc", events[2]["body"])
+		}
+	})
+	t.Run("upload failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+
+		defer server.Close()
+
+		s, err := NewMatrixService(server.URL, "token", "!room:example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.EqualError(t, s.Send(Message{Images: images}), "chat: matrix returned status 403")
+	})
+}
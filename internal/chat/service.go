@@ -0,0 +1,106 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Service posts messages to a chat.
+type Service interface {
+	Send(m Message) error
+}
+
+var services = make(map[string]Service)
+var servicesMutex = sync.RWMutex{}
+
+// Register adds a chat service that can be selected by name.
+func Register(name string, s Service) {
+	servicesMutex.Lock()
+	defer servicesMutex.Unlock()
+
+	services[name] = s
+}
+
+// Find returns the chat service with the given name.
+func Find(name string) (Service, error) {
+	servicesMutex.RLock()
+	defer servicesMutex.RUnlock()
+
+	if s, ok := services[name]; ok {
+		return s, nil
+	}
+
+	return nil, ErrService
+}
+
+// New returns a built-in chat service, or a registered service if the name is unknown.
+func New(name, url, key, target string) (Service, error) {
+	switch name {
+	case "":
+		return nil, ErrService
+	case TelegramServiceName:
+		return NewTelegramService(url, key, target)
+	case MatrixServiceName:
+		return NewMatrixService(url, key, target)
+	case SignalServiceName:
+		return NewSignalService(url, key, target)
+	case WebhookServiceName:
+		return NewWebhookService(url, key)
+	default:
+		return Find(name)
+	}
+}
+
+// do sends a request and decodes the JSON response if result is not nil. The request url isn't
+// part of returned errors as it may contain the bot token.
+func do(client *http.Client, service string, req *http.Request, result interface{}) error {
+	resp, err := client.Do(req)
+
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+
+		return fmt.Errorf("chat: can't connect to %s (%s)", service, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("chat: %s returned status %d", service, resp.StatusCode)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// writeFile adds a file to a multipart form.
+func writeFile(w *multipart.Writer, field, fileName string) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	part, err := w.CreateFormFile(field, filepath.Base(fileName))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, f)
+
+	return err
+}
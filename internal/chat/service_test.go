@@ -0,0 +1,82 @@
+package chat
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	s, err := NewWebhookService("http://localhost/", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Register("test", s)
+
+	result, err := Find("test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, s, result)
+
+	_, err = Find("xxx")
+
+	assert.Equal(t, ErrService, err)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("telegram", func(t *testing.T) {
+		s, err := New(TelegramServiceName, "", "123:abc", "-100123")
+
+		assert.NoError(t, err)
+		assert.IsType(t, &TelegramService{}, s)
+		assert.Equal(t, TelegramApiUrl, s.(*TelegramService).Url)
+	})
+	t.Run("matrix", func(t *testing.T) {
+		s, err := New(MatrixServiceName, "https://matrix.example.com/", "token", "!room:example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://matrix.example.com", s.(*MatrixService).Url)
+	})
+	t.Run("signal", func(t *testing.T) {
+		s, err := New(SignalServiceName, "http://signal:8080", "+4912345", "group.abc")
+
+		assert.NoError(t, err)
+		assert.IsType(t, &SignalService{}, s)
+	})
+	t.Run("webhook", func(t *testing.T) {
+		s, err := New(WebhookServiceName, "https://bot.example.com/photos", "", "")
+
+		assert.NoError(t, err)
+		assert.IsType(t, &WebhookService{}, s)
+	})
+	t.Run("target missing", func(t *testing.T) {
+		_, err := New(TelegramServiceName, "", "123:abc", "")
+		assert.Equal(t, ErrTarget, err)
+	})
+	t.Run("not configured", func(t *testing.T) {
+		_, err := New("", "", "", "")
+		assert.Equal(t, ErrService, err)
+	})
+}
+
+func TestDo(t *testing.T) {
+	t.Run("error hides url", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/botsecret/getMe", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = do(http.DefaultClient, "telegram", req, nil)
+
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret")
+	})
+}
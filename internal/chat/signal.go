@@ -0,0 +1,78 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SignalServiceName is the name of the built-in Signal service.
+const SignalServiceName = "signal"
+
+// SignalService posts images with the caption in a single message using signal-cli-rest-api,
+// see https://github.com/bbernhard/signal-cli-rest-api.
+type SignalService struct {
+	Url       string
+	Number    string
+	Recipient string
+	Client    *http.Client
+}
+
+// NewSignalService returns a new service that posts from the registered number to a group id or phone number.
+func NewSignalService(apiUrl, number, recipient string) (*SignalService, error) {
+	if recipient == "" {
+		return nil, ErrTarget
+	}
+
+	if apiUrl == "" {
+		return nil, fmt.Errorf("chat: signal api url not configured")
+	}
+
+	return &SignalService{
+		Url:       strings.TrimRight(apiUrl, "/"),
+		Number:    number,
+		Recipient: recipient,
+		Client:    &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Send posts the images as attachments of a single message.
+func (s *SignalService) Send(m Message) error {
+	attachments := make([]string, 0, len(m.Images))
+
+	for _, fileName := range m.Images {
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		attachments = append(attachments, base64.StdEncoding.EncodeToString(data))
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"message":            m.Caption(),
+		"number":             s.Number,
+		"recipients":         []string{s.Recipient},
+		"base64_attachments": attachments,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.Url+"/v2/send", bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return do(s.Client, SignalServiceName, req, nil)
+}
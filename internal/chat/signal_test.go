@@ -0,0 +1,59 @@
+package chat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalService_Send(t *testing.T) {
+	images, cleanup := testImages(t, 3)
+
+	defer cleanup()
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/send", r.URL.Path)
+
+			var body struct {
+				Message     string   `json:"message"`
+				Number      string   `json:"number"`
+				Recipients  []string `json:"recipients"`
+				Attachments []string `json:"base64_attachments"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+
+			assert.Equal(t, "Family\nhttps://photos.example.com/s/abc", body.Message)
+			assert.Equal(t, "+4912345", body.Number)
+			assert.Equal(t, []string{"group.abc"}, body.Recipients)
+			assert.Len(t, body.Attachments, 3)
+
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		defer server.Close()
+
+		s, err := NewSignalService(server.URL, "+4912345", "group.abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, s.Send(Message{Text: "Family", Link: "https://photos.example.com/s/abc", Images: images}))
+	})
+	t.Run("file missing", func(t *testing.T) {
+		s, err := NewSignalService("http://localhost:8080", "+4912345", "group.abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, s.Send(Message{Images: []string{"testdata/xxx.jpg"}}))
+	})
+}
@@ -0,0 +1,142 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TelegramServiceName is the name of the built-in Telegram bot service.
+const TelegramServiceName = "telegram"
+
+// TelegramApiUrl is the default Telegram Bot API url.
+const TelegramApiUrl = "https://api.telegram.org"
+
+// telegramGroupSize is the maximum number of images in a Telegram media group.
+const telegramGroupSize = 10
+
+// TelegramService posts images as media groups using the Telegram Bot API.
+type TelegramService struct {
+	Url    string
+	Token  string
+	ChatID string
+	Client *http.Client
+}
+
+// NewTelegramService returns a new service that posts to the chat with the given id, the url is optional.
+func NewTelegramService(apiUrl, token, chatID string) (*TelegramService, error) {
+	if chatID == "" {
+		return nil, ErrTarget
+	}
+
+	if apiUrl == "" {
+		apiUrl = TelegramApiUrl
+	}
+
+	return &TelegramService{
+		Url:    strings.TrimRight(apiUrl, "/"),
+		Token:  token,
+		ChatID: chatID,
+		Client: &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// method returns the url of a bot api method.
+func (s *TelegramService) method(name string) string {
+	return fmt.Sprintf("%s/bot%s/%s", s.Url, s.Token, name)
+}
+
+// Send posts the images in groups of up to ten, followed by the caption with the share link.
+func (s *TelegramService) Send(m Message) error {
+	for i := 0; i < len(m.Images); i += telegramGroupSize {
+		end := i + telegramGroupSize
+
+		if end > len(m.Images) {
+			end = len(m.Images)
+		}
+
+		if err := s.sendImages(m.Images[i:end]); err != nil {
+			return err
+		}
+	}
+
+	if caption := m.Caption(); caption != "" {
+		values := url.Values{"chat_id": {s.ChatID}, "text": {caption}}
+		req, err := http.NewRequest(http.MethodPost, s.method("sendMessage"), strings.NewReader(values.Encode()))
+
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return do(s.Client, TelegramServiceName, req, nil)
+	}
+
+	return nil
+}
+
+// sendImages uploads a single photo or a media group, as groups must have at least two items.
+func (s *TelegramService) sendImages(images []string) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	if err := w.WriteField("chat_id", s.ChatID); err != nil {
+		return err
+	}
+
+	method := "sendPhoto"
+
+	if len(images) == 1 {
+		if err := writeFile(w, "photo", images[0]); err != nil {
+			return err
+		}
+	} else {
+		method = "sendMediaGroup"
+
+		type inputMedia struct {
+			Type  string `json:"type"`
+			Media string `json:"media"`
+		}
+
+		media := make([]inputMedia, len(images))
+
+		for i, fileName := range images {
+			name := fmt.Sprintf("photo%d", i)
+			media[i] = inputMedia{Type: "photo", Media: "attach://" + name}
+
+			if err := writeFile(w, name, fileName); err != nil {
+				return err
+			}
+		}
+
+		mediaJson, err := json.Marshal(media)
+
+		if err != nil {
+			return err
+		}
+
+		if err := w.WriteField("media", string(mediaJson)); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.method(method), body)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return do(s.Client, TelegramServiceName, req, nil)
+}
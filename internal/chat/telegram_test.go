@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelegramService_Send(t *testing.T) {
+	images, cleanup := testImages(t, 12)
+
+	defer cleanup()
+
+	t.Run("success", func(t *testing.T) {
+		var methods []string
+		var groups []int
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.URL.Path)
+
+			switch r.URL.Path {
+			case "/bot123:abc/sendMediaGroup":
+				var media []map[string]string
+
+				if err := json.Unmarshal([]byte(r.FormValue("media")), &media); err != nil {
+					t.Error(err)
+				}
+
+				groups = append(groups, len(media))
+				assert.Equal(t, "attach://photo0", media[0]["media"])
+				assert.Equal(t, "-100123", r.FormValue("chat_id"))
+			case "/bot123:abc/sendPhoto":
+				_, _, err := r.FormFile("photo")
+				assert.NoError(t, err)
+			case "/bot123:abc/sendMessage":
+				assert.Equal(t, "Family\nhttps://photos.example.com/s/abc", r.FormValue("text"))
+			}
+
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}))
+
+		defer server.Close()
+
+		s, err := NewTelegramService(server.URL, "123:abc", "-100123")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Two groups are required for 12 images, one with 10 and one with 2 images.
+		err = s.Send(Message{Text: "Family", Link: "https://photos.example.com/s/abc", Images: images})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/bot123:abc/sendMediaGroup", "/bot123:abc/sendMediaGroup", "/bot123:abc/sendMessage"}, methods)
+		assert.Equal(t, []int{10, 2}, groups)
+	})
+	t.Run("single image", func(t *testing.T) {
+		var methods []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.URL.Path)
+		}))
+
+		defer server.Close()
+
+		s, err := NewTelegramService(server.URL, "123:abc", "-100123")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, s.Send(Message{Images: images[:1]}))
+		assert.Equal(t, []string{"/bot123:abc/sendPhoto"}, methods)
+	})
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+
+		defer server.Close()
+
+		s, err := NewTelegramService(server.URL, "123:abc", "-100123")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = s.Send(Message{Images: images[:2]})
+
+		assert.EqualError(t, err, "chat: telegram returned status 401")
+	})
+}
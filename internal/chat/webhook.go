@@ -0,0 +1,70 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// WebhookServiceName is the name of the built-in service that posts messages to a web API.
+const WebhookServiceName = "webhook"
+
+// WebhookService posts messages as multipart form with the message as JSON and the images,
+// e.g. to a custom bot or an automation server.
+type WebhookService struct {
+	Url    string
+	Key    string
+	Client *http.Client
+}
+
+// NewWebhookService returns a new service that posts messages to the given url.
+func NewWebhookService(url, key string) (*WebhookService, error) {
+	if url == "" {
+		return nil, fmt.Errorf("chat: webhook url not configured")
+	}
+
+	return &WebhookService{Url: url, Key: key, Client: &http.Client{Timeout: 5 * time.Minute}}, nil
+}
+
+// Send uploads the message and the images.
+func (s *WebhookService) Send(m Message) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	messageJson, err := json.Marshal(m)
+
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteField("message", string(messageJson)); err != nil {
+		return err
+	}
+
+	for _, fileName := range m.Images {
+		if err := writeFile(w, "files", fileName); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.Url, body)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	if s.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Key)
+	}
+
+	return do(s.Client, WebhookServiceName, req, nil)
+}
@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookService_Send(t *testing.T) {
+	images, cleanup := testImages(t, 2)
+
+	defer cleanup()
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+			var received Message
+
+			if err := json.Unmarshal([]byte(r.FormValue("message")), &received); err != nil {
+				t.Error(err)
+			}
+
+			assert.Equal(t, "Family", received.Text)
+			assert.Equal(t, "https://photos.example.com/s/abc", received.Link)
+			assert.Len(t, r.MultipartForm.File["files"], 2)
+		}))
+
+		defer server.Close()
+
+		s, err := NewWebhookService(server.URL, "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, s.Send(Message{Text: "Family", Link: "https://photos.example.com/s/abc", Images: images}))
+	})
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+
+		defer server.Close()
+
+		s, err := NewWebhookService(server.URL, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.EqualError(t, s.Send(Message{Images: images}), "chat: webhook returned status 502")
+	})
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// ColdCommand is used to register the cold cli command
+var ColdCommand = cli.Command{
+	Name:   "cold",
+	Usage:  "Moves old originals to cold storage, thumbnails and metadata are kept",
+	Flags:  coldFlags,
+	Action: coldAction,
+}
+
+var coldFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "years",
+		Usage: "move originals of photos taken more than this number of years ago (overrides cold-after)",
+	},
+	cli.BoolFlag{
+		Name:  "restore",
+		Usage: "move all originals in cold storage back to the originals folder",
+	},
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, don't actually move anything",
+	},
+}
+
+// coldAction moves old originals to or from cold storage
+func coldAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	defer conf.Shutdown()
+
+	worker := photoprism.NewTiering(conf)
+
+	opt := photoprism.TieringOptions{
+		Years: ctx.Int("years"),
+		Dry:   ctx.Bool("dry"),
+	}
+
+	if ctx.Bool("restore") {
+		log.Infof("restoring originals from cold storage")
+
+		restored, err := worker.Restore(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("restored %d files in %s", restored, time.Since(start))
+
+		return nil
+	}
+
+	log.Infof("moving old originals to cold storage")
+
+	moved, err := worker.Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("moved %d files in %s", moved, time.Since(start))
+
+	return nil
+}
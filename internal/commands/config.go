@@ -2,9 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/sysinfo"
 	"github.com/urfave/cli"
 )
 
@@ -36,6 +38,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "read-only", conf.ReadOnly())
 	fmt.Printf("%-25s %t\n", "public", conf.Public())
 	fmt.Printf("%-25s %t\n", "experimental", conf.Experimental())
+	fmt.Printf("%-25s %t\n", "usage-stats", conf.UsageStats())
+	fmt.Printf("%-25s %s\n", "feature-flags", conf.FeatureFlags())
 	fmt.Printf("%-25s %t\n", "disable-settings", conf.DisableSettings())
 
 	// TensorFlow
@@ -43,15 +47,30 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "tf-version", conf.TensorFlowVersion())
 	fmt.Printf("%-25s %s\n", "tf-model-path", conf.TensorFlowModelPath())
 	fmt.Printf("%-25s %t\n", "detect-nsfw", conf.DetectNSFW())
+	fmt.Printf("%-25s %t\n", "detect-geometry", conf.DetectGeometry())
+	fmt.Printf("%-25s %t\n", "exclude-categories", conf.ExcludeCategories())
 	fmt.Printf("%-25s %t\n", "upload-nsfw", conf.UploadNSFW())
+	fmt.Printf("%-25s %s\n", "nsfw-policy", conf.NSFWPolicy())
 
 	// Passwords
 	fmt.Printf("%-25s %s\n", "admin-password", conf.AdminPassword())
 	fmt.Printf("%-25s %s\n", "webdav-password", conf.WebDAVPassword())
+	fmt.Printf("%-25s %s\n", "secret-keyfile", conf.SecretKeyFile())
+	fmt.Printf("%-25s %s\n", "saml-idp-url", conf.SamlIdpUrl())
+	fmt.Printf("%-25s %s\n", "saml-idp-cert", conf.SamlIdpCert())
+	fmt.Printf("%-25s %s\n", "saml-role-attr", conf.SamlRoleAttr())
+	fmt.Printf("%-25s %s\n", "saml-admin-role", conf.SamlAdminRole())
+	fmt.Printf("%-25s %s\n", "saml-contributor-role", conf.SamlContributorRole())
 
 	// Background workers and logging
 	fmt.Printf("%-25s %d\n", "workers", conf.Workers())
+	fmt.Printf("%-25s %d\n", "worker-memory", conf.WorkerMemory()/sysinfo.MB)
+	fmt.Printf("%-25s %d\n", "memory-reserve", conf.MemoryReserve()/sysinfo.MB)
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
+	fmt.Printf("%-25s %s\n", "index-schedule", conf.IndexSchedule())
+	fmt.Printf("%-25s %d\n", "missing-grace", conf.MissingGrace()/time.Hour)
+	fmt.Printf("%-25s %s\n", "cold-storage", conf.ColdStorage())
+	fmt.Printf("%-25s %d\n", "cold-after", conf.ColdAfter())
 	fmt.Printf("%-25s %s\n", "log-level", conf.LogLevel())
 
 	// Path and file names
@@ -62,8 +81,15 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "assets-path", conf.AssetsPath())
 	fmt.Printf("%-25s %s\n", "originals-path", conf.OriginalsPath())
 	fmt.Printf("%-25s %d\n", "originals-limit", conf.OriginalsLimit())
+	fmt.Printf("%-25s %d\n", "disk-reserve", conf.DiskReserve())
 	fmt.Printf("%-25s %s\n", "import-path", conf.ImportPath())
+	fmt.Printf("%-25s %s\n", "import-url-hosts", strings.Join(conf.ImportUrlHosts(), ","))
+	fmt.Printf("%-25s %t\n", "upload-quarantine", conf.UploadQuarantine())
+	fmt.Printf("%-25s %t\n", "upload-reencode", conf.UploadReencode())
+	fmt.Printf("%-25s %d\n", "upload-max-resolution", conf.UploadMaxResolution())
+	fmt.Printf("%-25s %s\n", "quarantine-path", conf.QuarantinePath())
 	fmt.Printf("%-25s %s\n", "temp-path", conf.TempPath())
+	fmt.Printf("%-25s %d\n", "temp-limit", conf.TempLimit())
 	fmt.Printf("%-25s %s\n", "cache-path", conf.CachePath())
 	fmt.Printf("%-25s %s\n", "resources-path", conf.ResourcesPath())
 
@@ -76,6 +102,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "http-host", conf.HttpServerHost())
 	fmt.Printf("%-25s %d\n", "http-port", conf.HttpServerPort())
 	fmt.Printf("%-25s %s\n", "http-mode", conf.HttpServerMode())
+	fmt.Printf("%-25s %s\n", "trusted-proxy", strings.Join(conf.TrustedProxies(), ","))
+	fmt.Printf("%-25s %d\n", "grpc-port", conf.GrpcServerPort())
 
 	// Built-in TiDB server config
 	fmt.Printf("%-25s %s\n", "tidb-host", conf.TidbServerHost())
@@ -86,25 +114,51 @@ func configAction(ctx *cli.Context) error {
 	// Database config
 	fmt.Printf("%-25s %s\n", "database-driver", conf.DatabaseDriver())
 	fmt.Printf("%-25s %s\n", "database-dsn", conf.DatabaseDsn())
+	fmt.Printf("%-25s %s\n", "database-backup-path", conf.DatabaseBackupPath())
+	fmt.Printf("%-25s %t\n", "migrate-manual", conf.MigrateManual())
 
 	// External binaries
 	fmt.Printf("%-25s %s\n", "sips-bin", conf.SipsBin())
 	fmt.Printf("%-25s %s\n", "darktable-bin", conf.DarktableBin())
 	fmt.Printf("%-25s %s\n", "heifconvert-bin", conf.HeifConvertBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-bin", conf.FFmpegBin())
+	fmt.Printf("%-25s %s\n", "ffprobe-bin", conf.FFprobeBin())
+	fmt.Printf("%-25s %s\n", "video-poster", conf.VideoPoster())
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
 	fmt.Printf("%-25s %t\n", "sidecar-json", conf.SidecarJson())
 	fmt.Printf("%-25s %t\n", "sidecar-yaml", conf.SidecarYaml())
 	fmt.Printf("%-25s %t\n", "sidecar-hidden", conf.SidecarHidden())
+	fmt.Printf("%-25s %s\n", "sidecar-strategy", conf.SidecarStrategy())
+	fmt.Printf("%-25s %s\n", "sidecar-roots", conf.SidecarRoots())
+	fmt.Printf("%-25s %s\n", "sidecar-path", conf.SidecarPath())
+	fmt.Printf("%-25s %s\n", "search-languages", strings.Join(conf.SearchLanguages(), ","))
+	fmt.Printf("%-25s %s\n", "search-timeout", conf.SearchTimeout())
+	fmt.Printf("%-25s %s\n", "synonyms-path", conf.SynonymsPath())
 
 	// Places / Geocoding API
 	fmt.Printf("%-25s %s\n", "geocoding-api", conf.GeoCodingApi())
+	fmt.Printf("%-25s %s\n", "print-service", conf.PrintService())
+	fmt.Printf("%-25s %s\n", "print-service-url", conf.PrintServiceUrl())
+	fmt.Printf("%-25s %s\n", "print-service-key", conf.PrintServiceKey())
+	fmt.Printf("%-25s %s\n", "chat-service", conf.ChatService())
+	fmt.Printf("%-25s %s\n", "chat-service-url", conf.ChatServiceUrl())
+	fmt.Printf("%-25s %s\n", "chat-service-key", conf.ChatServiceKey())
+	fmt.Printf("%-25s %s\n", "chat-service-target", conf.ChatServiceTarget())
+	fmt.Printf("%-25s %s\n", "share-reminder", conf.ShareReminder())
+	fmt.Printf("%-25s %s\n", "notify-email", conf.NotifyEmail())
+	fmt.Printf("%-25s %s\n", "smtp-url", conf.SmtpUrl())
+	fmt.Printf("%-25s %s\n", "checkpoint-interval", conf.CheckpointInterval())
+	fmt.Printf("%-25s %d\n", "checkpoint-keep", conf.CheckpointKeep())
+	fmt.Printf("%-25s %s\n", "checkpoint-path", conf.CheckpointPath())
+	fmt.Printf("%-25s %s\n", "semantic-service-url", conf.SemanticServiceUrl())
+	fmt.Printf("%-25s %s\n", "semantic-service-key", conf.SemanticServiceKey())
 
 	// Thumbnails
 	fmt.Printf("%-25s %s\n", "download-token", conf.DownloadToken())
 	fmt.Printf("%-25s %s\n", "thumb-token", conf.PreviewToken())
 	fmt.Printf("%-25s %s\n", "thumb-filter", conf.ThumbFilter())
 	fmt.Printf("%-25s %t\n", "thumb-uncached", conf.ThumbUncached())
+	fmt.Printf("%-25s %t\n", "thumb-adaptive", conf.ThumbAdaptive())
 	fmt.Printf("%-25s %d\n", "thumb-size", conf.ThumbSize())
 	fmt.Printf("%-25s %d\n", "thumb-limit", conf.ThumbLimit())
 	fmt.Printf("%-25s %s\n", "thumb-path", conf.ThumbPath())
@@ -74,6 +74,7 @@ func importAction(ctx *cli.Context) error {
 	elapsed := time.Since(start)
 
 	log.Infof("import completed in %s", elapsed)
+	service.Convert().Shutdown()
 	conf.Shutdown()
 	return nil
 }
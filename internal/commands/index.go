@@ -87,6 +87,7 @@ func indexAction(ctx *cli.Context) error {
 
 	log.Infof("indexed %d files in %s", len(indexed), elapsed)
 
+	service.Convert().Shutdown()
 	conf.Shutdown()
 
 	return nil
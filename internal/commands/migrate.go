@@ -2,39 +2,199 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/urfave/cli"
 )
 
+var migrateBackupFlag = cli.BoolFlag{
+	Name:  "no-backup",
+	Usage: "don't create a database backup first",
+}
+
 // MigrateCommand is used to register the migrate cli command
 var MigrateCommand = cli.Command{
 	Name:   "migrate",
-	Usage:  "Automatically initializes and migrates the database",
-	Action: migrateAction,
+	Usage:  "Applies or rolls back database schema migrations",
+	Flags:  migrateUpFlags,
+	Action: migrateUpAction,
+	Subcommands: []cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Lists schema migrations and whether they have been applied",
+			Action: migrateStatusAction,
+		},
+		{
+			Name:   "up",
+			Usage:  "Applies pending schema migrations, a backup is created first",
+			Flags:  migrateUpFlags,
+			Action: migrateUpAction,
+		},
+		{
+			Name:   "down",
+			Usage:  "Rolls back the most recently applied schema migrations, a backup is created first",
+			Flags:  migrateDownFlags,
+			Action: migrateDownAction,
+		},
+	},
 }
 
-// migrateAction automatically migrates or initializes database
-func migrateAction(ctx *cli.Context) error {
-	start := time.Now()
+var migrateUpFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "target, t",
+		Usage: "version to migrate to, all pending migrations are applied by default",
+	},
+	migrateBackupFlag,
+}
 
+var migrateDownFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "steps, s",
+		Usage: "number of migrations to roll back",
+		Value: 1,
+	},
+	migrateBackupFlag,
+}
+
+// migrateConfig returns a config connected to the database without applying migrations.
+func migrateConfig(ctx *cli.Context) (*config.Config, error) {
 	conf := config.NewConfig(ctx)
 	cctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+
 	if err := conf.Init(cctx); err != nil {
+		return conf, err
+	}
+
+	entity.SetDbProvider(conf)
+
+	return conf, nil
+}
+
+// migrateBackup creates a database backup unless disabled.
+func migrateBackup(ctx *cli.Context, conf *config.Config) error {
+	if ctx.Bool("no-backup") {
+		log.Warn("migrate: skipping database backup")
+		return nil
+	}
+
+	fileName, err := conf.BackupDb()
+
+	if err != nil {
+		return fmt.Errorf("migrate: can't create backup (%s)", err)
+	} else if fileName != "" {
+		log.Infof("migrate: created backup %s", fileName)
+	}
+
+	return nil
+}
+
+// migrateStatusAction lists all migrations and whether they have been applied
+func migrateStatusAction(ctx *cli.Context) error {
+	conf, err := migrateConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	status, err := conf.Migrator().Status()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-8s %-32s %-8s %s\n", "VERSION", "NAME", "STATUS", "APPLIED")
+
+	for _, s := range status {
+		state := "pending"
+		appliedAt := ""
+
+		if s.Dirty {
+			state = "dirty"
+		} else if s.Applied {
+			state = "applied"
+		}
+
+		if s.Applied {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Printf("%-8d %-32s %-8s %s\n", s.Version, s.Name, state, appliedAt)
+	}
+
+	return nil
+}
+
+// migrateUpAction applies pending migrations
+func migrateUpAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := migrateConfig(ctx)
+
+	if err != nil {
 		return err
 	}
 
-	log.Infoln("migrating database")
+	defer conf.Shutdown()
 
-	conf.InitDb()
+	m := conf.Migrator()
 
-	elapsed := time.Since(start)
+	pending, err := m.Pending()
+
+	if err != nil {
+		return err
+	} else if len(pending) == 0 {
+		log.Infof("migrate: database schema is up to date")
+		return nil
+	}
+
+	if err := migrateBackup(ctx, conf); err != nil {
+		return err
+	}
+
+	applied, err := m.Up(ctx.Int("target"))
+
+	if err != nil {
+		return err
+	}
 
-	log.Infof("database migration completed in %s", elapsed)
+	entity.CreateDefaultFixtures()
 
-	conf.Shutdown()
+	log.Infof("migrate: applied %d migrations in %s", len(applied), time.Since(start))
 
 	return nil
 }
+
+// migrateDownAction rolls back the most recently applied migrations
+func migrateDownAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	steps := ctx.Int("steps")
+
+	if steps < 1 {
+		return fmt.Errorf("migrate: steps must be at least 1")
+	}
+
+	conf, err := migrateConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	if err := migrateBackup(ctx, conf); err != nil {
+		return err
+	}
+
+	reverted, err := conf.Migrator().Down(steps)
+
+	log.Infof("migrate: rolled back %d migrations in %s", len(reverted), time.Since(start))
+
+	return err
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// PartitionCommand is used to register the partition cli command
+var PartitionCommand = cli.Command{
+	Name:  "partition",
+	Usage: "Partitions the photos table by year for very large libraries (MySQL only)",
+	Description: "The unique index of photo UIDs is extended by the year, as MySQL requires it for partitioning. " +
+		"Duplicate UIDs are then rejected by PhotoPrism before photos are created, but not by the database. " +
+		"The files table is not partitioned: it has no year column, and adding one to its unique indexes of " +
+		"file UIDs and file names would let the database accept duplicate files. Searches filtering by year " +
+		"only read matching partitions of photos and join files by photo id.",
+	Flags:  partitionFlags,
+	Action: partitionAction,
+}
+
+var partitionFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "from",
+		Usage: "first year with a separate partition",
+		Value: txt.YearMin,
+	},
+	cli.IntFlag{
+		Name:  "to",
+		Usage: "last year with a separate partition",
+		Value: time.Now().Year() + 1,
+	},
+}
+
+// partitionAction partitions the photos table by year
+func partitionAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+
+	if conf.DatabaseDriver() != config.DriverMysql {
+		return errors.New("partitioning is only supported by MySQL and MariaDB")
+	}
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	if err := entity.PartitionPhotos(ctx.Int("from"), ctx.Int("to")); err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("partitioning completed in %s", elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
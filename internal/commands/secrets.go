@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/secret"
+	"github.com/urfave/cli"
+)
+
+// SecretsCommand is used to register the secrets cli command
+var SecretsCommand = cli.Command{
+	Name:  "secrets",
+	Usage: "Encrypts stored passwords and config values",
+	Subcommands: []cli.Command{
+		{
+			Name:   "migrate",
+			Usage:  "Encrypts passwords that are still stored in plaintext",
+			Action: secretsMigrateAction,
+		},
+		{
+			Name:   "encrypt",
+			Usage:  "Encrypts a value read from stdin for use in config files or environment variables",
+			Action: secretsEncryptAction,
+		},
+	},
+}
+
+// secretsMigrateAction encrypts plaintext passwords in existing databases
+func secretsMigrateAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	count, err := entity.EncryptSecrets()
+
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("encrypted %d passwords in %s", count, elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
+
+// secretsEncryptAction prints the encrypted value read from stdin. Values are not accepted as
+// argument, so that they don't end up in the shell history or the process list.
+func secretsEncryptAction(ctx *cli.Context) error {
+	if ctx.NArg() > 0 {
+		return errors.New("value must be sent to stdin, e.g. photoprism secrets encrypt < value.txt")
+	}
+
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Value: ")
+	}
+
+	value, err := readSecret(os.Stdin)
+
+	if err != nil {
+		return err
+	}
+
+	conf := config.NewConfig(ctx)
+
+	if err := conf.InitSecrets(); err != nil {
+		return err
+	}
+
+	result, err := secret.Encrypt(value)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result)
+
+	return nil
+}
+
+// readSecret returns the first line read from r without surrounding whitespace.
+func readSecret(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	if line = strings.TrimSpace(line); line == "" {
+		return "", errors.New("value must not be empty")
+	}
+
+	return line, nil
+}
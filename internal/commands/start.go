@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/rpc"
 	"github.com/photoprism/photoprism/internal/server"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumbcache"
 	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -117,9 +119,25 @@ func startAction(ctx *cli.Context) error {
 		log.Infof("read-only mode enabled")
 	}
 
+	// load photo embeddings for natural language search (optional)
+	go service.Semantic()
+
+	// load recently accessed album and label covers, so that they don't need to be regenerated after a restart
+	go func() {
+		if n := service.ThumbCache().WarmUp(thumbcache.WarmUpLimit); n > 0 {
+			log.Infof("thumbcache: loaded %d recently used covers", n)
+		}
+	}()
+
+	// save thumbnail cache metadata in the background until shutdown
+	go service.ThumbCache().Run(cctx, time.Minute)
+
 	// start web server
 	go server.Start(cctx, conf)
 
+	// start gRPC server (optional)
+	go rpc.Start(cctx, conf)
+
 	// start share & sync workers
 	workers.Start(conf)
 
@@ -133,6 +151,7 @@ func startAction(ctx *cli.Context) error {
 	workers.Stop()
 
 	log.Info("shutting down...")
+	service.Convert().Shutdown()
 	conf.Shutdown()
 	cancel()
 	err := dctx.Release()
@@ -0,0 +1,23 @@
+package config
+
+import "strings"
+
+// ChatService returns the name of the chat service photos can be shared with, or an empty string if disabled.
+func (c *Config) ChatService() string {
+	return strings.ToLower(strings.TrimSpace(c.params.ChatService))
+}
+
+// ChatServiceUrl returns the chat service api url.
+func (c *Config) ChatServiceUrl() string {
+	return c.params.ChatServiceUrl
+}
+
+// ChatServiceKey returns the chat bot token.
+func (c *Config) ChatServiceKey() string {
+	return c.params.ChatServiceKey
+}
+
+// ChatServiceTarget returns the id of the chat, room or group photos are posted to.
+func (c *Config) ChatServiceTarget() string {
+	return strings.TrimSpace(c.params.ChatServiceTarget)
+}
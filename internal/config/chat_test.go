@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ChatService(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ChatService())
+
+	c.params.ChatService = " Telegram "
+	assert.Equal(t, "telegram", c.ChatService())
+
+	c.params.ChatService = ""
+}
+
+func TestConfig_ChatServiceTarget(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ChatServiceUrl())
+	assert.Equal(t, "", c.ChatServiceKey())
+
+	c.params.ChatServiceTarget = " -100123 "
+	assert.Equal(t, "-100123", c.ChatServiceTarget())
+
+	c.params.ChatServiceTarget = ""
+}
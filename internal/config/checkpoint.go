@@ -0,0 +1,29 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// CheckpointInterval returns the time between metadata checkpoints, 0 if disabled.
+func (c *Config) CheckpointInterval() time.Duration {
+	if c.params.CheckpointInterval <= 0 {
+		return 0
+	}
+
+	return time.Duration(c.params.CheckpointInterval) * time.Hour
+}
+
+// CheckpointKeep returns the max number of metadata checkpoints to keep.
+func (c *Config) CheckpointKeep() int {
+	if c.params.CheckpointKeep < 1 {
+		return 1
+	}
+
+	return c.params.CheckpointKeep
+}
+
+// CheckpointPath returns the path to metadata checkpoint files.
+func (c *Config) CheckpointPath() string {
+	return filepath.Join(c.AssetsPath(), "checkpoints")
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_CheckpointInterval(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.params.CheckpointInterval = 0
+	assert.Equal(t, time.Duration(0), c.CheckpointInterval())
+
+	c.params.CheckpointInterval = 24
+	assert.Equal(t, 24*time.Hour, c.CheckpointInterval())
+}
+
+func TestConfig_CheckpointKeep(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.params.CheckpointKeep = 0
+	assert.Equal(t, 1, c.CheckpointKeep())
+
+	c.params.CheckpointKeep = 30
+	assert.Equal(t, 30, c.CheckpointKeep())
+}
+
+func TestConfig_CheckpointPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.AssetsPath()+"/checkpoints", c.CheckpointPath())
+}
@@ -28,6 +28,7 @@ type ClientConfig struct {
 	UploadNSFW      bool                `json:"uploadNSFW"`
 	Public          bool                `json:"public"`
 	Experimental    bool                `json:"experimental"`
+	FeatureFlags    map[string]bool     `json:"featureFlags"`
 	DisableSettings bool                `json:"disableSettings"`
 	Albums          []entity.Album      `json:"albums"`
 	Cameras         []entity.Camera     `json:"cameras"`
@@ -102,6 +103,10 @@ func (c *Config) Flags() (flags []string) {
 		flags = append(flags, "settings")
 	}
 
+	if c.SamlEnabled() {
+		flags = append(flags, "saml")
+	}
+
 	return flags
 }
 
@@ -128,6 +133,7 @@ func (c *Config) PublicClientConfig() ClientConfig {
 		ReadOnly:      c.ReadOnly(),
 		Public:        c.Public(),
 		Experimental:  c.Experimental(),
+		FeatureFlags:  c.FeatureFlags().Role(RoleGuest),
 		Thumbnails:    Thumbnails,
 		Colors:        colors.All.List(),
 		JSHash:        fs.Checksum(c.HttpStaticBuildPath() + "/app.js"),
@@ -161,6 +167,7 @@ func (c *Config) ClientConfig() ClientConfig {
 		DisableSettings: c.DisableSettings(),
 		Public:          c.Public(),
 		Experimental:    c.Experimental(),
+		FeatureFlags:    c.FeatureFlags().Role(RoleAdmin),
 		Colors:          colors.All.List(),
 		Thumbnails:      Thumbnails,
 		DownloadToken:   c.DownloadToken(),
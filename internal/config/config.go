@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,8 +13,12 @@ import (
 	gc "github.com/patrickmn/go-cache"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/sidecar"
+	"github.com/photoprism/photoprism/internal/synonyms"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sysinfo"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
@@ -79,12 +84,26 @@ func (c *Config) Propagate() {
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
 
+	sidecar.OriginalsPath = c.OriginalsPath()
+	sidecar.TreePath = c.SidecarPath()
+	sidecar.Default = c.SidecarStrategy()
+	sidecar.Roots = c.SidecarRoots()
+
+	synonyms.Default = c.Synonyms()
+
+	query.SearchTimeout = c.SearchTimeout()
+
 	c.Settings().Propagate()
 }
 
 // Init initialises the database connection and dependencies.
 func (c *Config) Init(ctx context.Context) error {
 	c.Propagate()
+
+	if err := c.InitSecrets(); err != nil {
+		return err
+	}
+
 	return c.connectToDatabase(ctx)
 }
 
@@ -151,6 +170,11 @@ func (c *Config) Experimental() bool {
 	return c.params.Experimental
 }
 
+// UsageStats returns true if feature usage should be counted for local statistics.
+func (c *Config) UsageStats() bool {
+	return c.params.UsageStats
+}
+
 // ReadOnly returns true if photo directories are write protected.
 func (c *Config) ReadOnly() bool {
 	return c.params.ReadOnly
@@ -161,23 +185,53 @@ func (c *Config) DetectNSFW() bool {
 	return c.params.DetectNSFW
 }
 
+// DetectGeometry returns true if photos should be checked for a wrong orientation and tilted horizon.
+func (c *Config) DetectGeometry() bool {
+	return c.params.DetectGeometry && !c.ReadOnly()
+}
+
+// ExcludeCategories returns true if screenshots, receipts, whiteboards and documents should be excluded
+// from moments and automatically created albums.
+func (c *Config) ExcludeCategories() bool {
+	return c.params.ExcludeCategories
+}
+
 // UploadNSFW returns true if NSFW photos can be uploaded.
 func (c *Config) UploadNSFW() bool {
 	return c.params.UploadNSFW
 }
 
+// UploadQuarantine returns true if uploaded files should be verified, and suspicious files quarantined.
+func (c *Config) UploadQuarantine() bool {
+	return c.params.UploadQuarantine
+}
+
+// UploadReencode returns true if uploaded JPEG and PNG images should be re-encoded.
+func (c *Config) UploadReencode() bool {
+	return c.params.UploadReencode
+}
+
+// UploadMaxResolution returns the max width and height of uploaded images in pixels, 0 if unlimited.
+func (c *Config) UploadMaxResolution() int {
+	if c.params.UploadMaxRes < 0 {
+		return 0
+	}
+
+	return c.params.UploadMaxRes
+}
+
 // AdminPassword returns the admin password.
 func (c *Config) AdminPassword() string {
 	if c.params.AdminPassword == "" {
 		return "photoprism"
 	}
 
-	return c.params.AdminPassword
+	return c.reveal(c.params.AdminPassword)
 }
 
 // WebDAVPassword returns the WebDAV password for remote access.
 func (c *Config) WebDAVPassword() string {
-	return c.params.WebDAVPassword
+	return c.reveal(c.params.WebDAVPassword)
 }
 
 // LogLevel returns the logrus log level.
@@ -216,19 +270,49 @@ func (c *Config) Shutdown() {
 	}
 }
 
-// Workers returns the number of workers e.g. for indexing files.
+// Workers returns the number of workers e.g. for indexing files. Unless configured, it depends on the
+// number of CPU cores and the memory available to the container or host.
 func (c *Config) Workers() int {
-	numCPU := runtime.NumCPU()
-
-	if c.params.Workers > 0 && c.params.Workers <= numCPU {
+	if c.params.Workers > 0 && c.params.Workers <= runtime.NumCPU() {
 		return c.params.Workers
 	}
 
-	if numCPU > 1 {
-		return numCPU - 1
+	result := 1
+
+	if numCPU := sysinfo.CPUs(); numCPU > 1 {
+		result = numCPU - 1
+	}
+
+	// Start fewer workers if memory is low, so that they don't get killed.
+	if total := sysinfo.MemoryTotal(); total > c.MemoryReserve() {
+		if n := int((total - c.MemoryReserve()) / c.WorkerMemory()); n < result {
+			result = n
+		}
+	}
+
+	if result < 1 {
+		return 1
 	}
 
-	return 1
+	return result
+}
+
+// WorkerMemory returns the approximate memory needed by a single worker in bytes.
+func (c *Config) WorkerMemory() uint64 {
+	if c.params.WorkerMemory <= 0 {
+		return 512 * sysinfo.MB
+	}
+
+	return uint64(c.params.WorkerMemory) * sysinfo.MB
+}
+
+// MemoryReserve returns the memory in bytes that should remain available, workers wait while it is exceeded.
+func (c *Config) MemoryReserve() uint64 {
+	if c.params.MemoryReserve <= 0 {
+		return 256 * sysinfo.MB
+	}
+
+	return uint64(c.params.MemoryReserve) * sysinfo.MB
 }
 
 // WakeupInterval returns the background worker wakeup interval.
@@ -240,6 +324,15 @@ func (c *Config) WakeupInterval() time.Duration {
 	return time.Duration(c.params.WakeupInterval) * time.Second
 }
 
+// MissingGrace returns the time after which files that can't be found are flagged as missing.
+func (c *Config) MissingGrace() time.Duration {
+	if c.params.MissingGrace <= 0 {
+		return 0
+	}
+
+	return time.Duration(c.params.MissingGrace) * time.Hour
+}
+
 // GeoCodingApi returns the preferred geo coding api (none, osm or places).
 func (c *Config) GeoCodingApi() string {
 	switch c.params.GeoCodingApi {
@@ -251,6 +344,16 @@ func (c *Config) GeoCodingApi() string {
 	return ""
 }
 
+// TempLimit returns the temp space limit for download archives in bytes, or 0 for no limit.
+func (c *Config) TempLimit() int64 {
+	if c.params.TempLimit <= 0 {
+		return 0
+	}
+
+	// Megabyte.
+	return c.params.TempLimit * 1024 * 1024
+}
+
 // OriginalsLimit returns the file size limit for originals.
 func (c *Config) OriginalsLimit() int64 {
 	if c.params.OriginalsLimit <= 0 || c.params.OriginalsLimit > 100000 {
@@ -260,3 +363,24 @@ func (c *Config) OriginalsLimit() int64 {
 	// Megabyte.
 	return c.params.OriginalsLimit * 1024 * 1024
 }
+
+// DiskReserve returns the free disk space in bytes that must remain after importing files.
+func (c *Config) DiskReserve() uint64 {
+	if c.params.DiskReserve <= 0 {
+		return 0
+	}
+
+	// Megabyte.
+	return uint64(c.params.DiskReserve) * 1024 * 1024
+}
+
+// ImportUrlHosts returns the hosts files may be imported from by URL, none if empty.
+func (c *Config) ImportUrlHosts() (result []string) {
+	for _, host := range strings.Split(c.params.ImportUrlHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			result = append(result, host)
+		}
+	}
+
+	return result
+}
@@ -2,9 +2,13 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/sidecar"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -94,6 +98,27 @@ func TestConfig_ConfigPath(t *testing.T) {
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/config", configPath)
 }
 
+func TestConfig_BackupPath(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.True(t, strings.HasSuffix(c.BackupPath(), "/import/.backup"))
+}
+
+func TestConfig_IngestPath(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.True(t, strings.HasSuffix(c.IngestPath(), "/import/ingest"))
+}
+
+func TestConfig_WatermarksPath(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/config/watermarks", c.WatermarksPath())
+}
+
 func TestConfig_PIDFilename(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -166,6 +191,23 @@ func TestConfig_HttpServerPort(t *testing.T) {
 	assert.Equal(t, 2342, port)
 }
 
+func TestConfig_GrpcServerPort(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 0, c.GrpcServerPort())
+}
+
+func TestConfig_TrustedProxies(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Empty(t, c.TrustedProxies())
+
+	c.params.TrustedProxy = "172.16.0.0/12, 10.0.0.1,"
+	assert.Equal(t, []string{"172.16.0.0/12", "10.0.0.1"}, c.TrustedProxies())
+}
+
 func TestConfig_HttpServerMode(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -200,6 +242,35 @@ func TestConfig_ImportPath(t *testing.T) {
 	assert.True(t, strings.HasSuffix(result, "assets/testdata/import"))
 }
 
+func TestConfig_DiskReserve(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.params.DiskReserve = 0
+	assert.Equal(t, uint64(0), c.DiskReserve())
+
+	c.params.DiskReserve = 512
+	assert.Equal(t, uint64(512*1024*1024), c.DiskReserve())
+}
+
+func TestConfig_UploadMaxResolution(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.params.UploadMaxRes = -1
+	assert.Equal(t, 0, c.UploadMaxResolution())
+
+	c.params.UploadMaxRes = 8000
+	assert.Equal(t, 8000, c.UploadMaxResolution())
+}
+
+func TestConfig_ImportUrlHosts(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ImportUrlHosts())
+
+	c.params.ImportUrlHosts = "Example.com, *.foo.org,"
+	assert.Equal(t, []string{"example.com", "*.foo.org"}, c.ImportUrlHosts())
+}
+
 func TestConfig_SipsBin(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -255,6 +326,12 @@ func TestConfig_CachePath(t *testing.T) {
 	assert.True(t, strings.HasSuffix(c.CachePath(), "assets/testdata/cache"))
 }
 
+func TestConfig_ConvertCachePath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasSuffix(c.ConvertCachePath(), "assets/testdata/cache/convert"))
+}
+
 func TestConfig_ThumbnailsPath(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -287,6 +364,66 @@ func TestConfig_DetectNSFW(t *testing.T) {
 	assert.Equal(t, true, result)
 }
 
+func TestConfig_DetectGeometry(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DetectGeometry())
+
+	c.params.DetectGeometry = true
+	assert.True(t, c.DetectGeometry())
+
+	c.params.ReadOnly = true
+	assert.False(t, c.DetectGeometry())
+}
+
+func TestConfig_ExcludeCategories(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.ExcludeCategories())
+
+	c.params.ExcludeCategories = true
+	assert.True(t, c.ExcludeCategories())
+}
+
+func TestConfig_SidecarStrategy(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, sidecar.Inline, c.SidecarStrategy())
+
+	c.params.SidecarHidden = true
+	assert.Equal(t, sidecar.Hidden, c.SidecarStrategy())
+
+	c.params.SidecarStrategy = "none"
+	assert.Equal(t, sidecar.None, c.SidecarStrategy())
+
+	c.params.SidecarStrategy = "cloud"
+	assert.Equal(t, sidecar.Hidden, c.SidecarStrategy())
+
+	c.params.ReadOnly = true
+	assert.Equal(t, sidecar.Tree, c.SidecarStrategy())
+}
+
+func TestConfig_SidecarRoots(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.SidecarRoots())
+
+	c.params.SidecarRoots = "nas=tree,scans=inline,old=none"
+	assert.Equal(t, sidecar.Strategies{"nas": sidecar.Tree, "scans": sidecar.Inline, "old": sidecar.None}, c.SidecarRoots())
+
+	c.params.ReadOnly = true
+	assert.Equal(t, sidecar.Strategies{"nas": sidecar.Tree, "scans": sidecar.Tree, "old": sidecar.None}, c.SidecarRoots())
+}
+
+func TestConfig_SidecarPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.AssetsPath(), "sidecar"), c.SidecarPath())
+
+	c.params.SidecarPath = "/mnt/sidecar"
+	assert.Equal(t, "/mnt/sidecar", c.SidecarPath())
+}
+
 func TestConfig_AdminPassword(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -373,3 +510,56 @@ func TestConfig_Workers(t *testing.T) {
 
 	assert.GreaterOrEqual(t, c.Workers(), 1)
 }
+
+func TestConfig_ThumbAdaptive(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.False(t, c.ThumbAdaptive())
+
+	c.UpdateThumbSize()
+
+	assert.Equal(t, c.ThumbSize(), thumb.Size)
+}
+
+func TestConfig_MissingGrace(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, time.Duration(0), c.MissingGrace())
+
+	c.params.MissingGrace = 24
+	assert.Equal(t, 24*time.Hour, c.MissingGrace())
+
+	c.params.MissingGrace = -1
+	assert.Equal(t, time.Duration(0), c.MissingGrace())
+}
+
+func TestConfig_WorkerMemory(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, uint64(512*1024*1024), c.WorkerMemory())
+
+	c.params.WorkerMemory = 256
+	assert.Equal(t, uint64(256*1024*1024), c.WorkerMemory())
+}
+
+func TestConfig_MemoryReserve(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, uint64(256*1024*1024), c.MemoryReserve())
+
+	c.params.MemoryReserve = 1024
+	assert.Equal(t, uint64(1024*1024*1024), c.MemoryReserve())
+}
+
+func TestConfig_TempLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(0), c.TempLimit())
+
+	c.params.TempLimit = 500
+	assert.Equal(t, int64(500*1024*1024), c.TempLimit())
+
+	c.params.TempLimit = -1
+	assert.Equal(t, int64(0), c.TempLimit())
+}
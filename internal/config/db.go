@@ -58,7 +58,9 @@ func (c *Config) CloseDb() error {
 // InitDb will initialize the database connection and schema.
 func (c *Config) InitDb() {
 	entity.SetDbProvider(c)
-	entity.MigrateDb()
+	c.MigrateDb()
+	entity.CreateDefaultFixtures()
+	c.UpdateThumbSize()
 	go entity.SaveErrorMessages()
 }
 
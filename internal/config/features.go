@@ -0,0 +1,122 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+// Roles that features can be enabled for.
+const (
+	RoleAdmin       = acl.RoleAdmin
+	RoleContributor = acl.RoleContributor
+	RoleGuest       = acl.RoleGuest
+)
+
+// Experimental features that can be enabled with feature flags.
+const (
+	FeatureFaces      = "faces"
+	FeatureMoments    = "moments"
+	FeatureFederation = "federation"
+)
+
+// Roles lists all roles.
+var Roles = []string{RoleAdmin, RoleGuest}
+
+// Features lists all known experimental features.
+var Features = []string{FeatureFaces, FeatureMoments, FeatureFederation}
+
+// FeatureFlags maps features to the roles they are enabled for.
+type FeatureFlags map[string]map[string]bool
+
+// Parse applies a list of feature flags like "faces:admin,-moments" and returns the result.
+// Features without role are enabled for all roles, a leading minus disables a feature.
+func (f FeatureFlags) Parse(s string) FeatureFlags {
+	for _, flag := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ',' || r == ' ' }) {
+		disable := strings.HasPrefix(flag, "-")
+		flag = strings.TrimLeft(flag, "-+")
+
+		feature, role := flag, ""
+
+		if i := strings.Index(flag, ":"); i >= 0 {
+			feature, role = flag[:i], flag[i+1:]
+		}
+
+		if feature == "" {
+			continue
+		}
+
+		roles := Roles
+
+		if role != "" {
+			roles = []string{role}
+		}
+
+		if f[feature] == nil {
+			f[feature] = make(map[string]bool)
+		}
+
+		for _, r := range roles {
+			f[feature][r] = !disable
+		}
+	}
+
+	return f
+}
+
+// Enabled returns true if the feature is enabled for the role.
+func (f FeatureFlags) Enabled(feature, role string) bool {
+	return f[feature][role]
+}
+
+// Role returns the status of all known features for a role, as used by the client config.
+func (f FeatureFlags) Role(role string) map[string]bool {
+	result := make(map[string]bool, len(Features))
+
+	for _, feature := range Features {
+		result[feature] = f.Enabled(feature, role)
+	}
+
+	for feature := range f {
+		result[feature] = f.Enabled(feature, role)
+	}
+
+	return result
+}
+
+// String returns the enabled features and roles in the format used by the feature-flags option.
+func (f FeatureFlags) String() string {
+	var result []string
+
+	for feature, roles := range f {
+		for role, enabled := range roles {
+			if enabled {
+				result = append(result, feature+":"+role)
+			}
+		}
+	}
+
+	sort.Strings(result)
+
+	return strings.Join(result, ",")
+}
+
+// FeatureFlags returns the experimental features enabled for each role. If experimental features are
+// enabled in general, all known features are enabled for admins unless disabled with the feature-flags option.
+func (c *Config) FeatureFlags() FeatureFlags {
+	result := make(FeatureFlags)
+
+	if c.Experimental() {
+		for _, feature := range Features {
+			result[feature] = map[string]bool{RoleAdmin: true}
+		}
+	}
+
+	return result.Parse(c.params.FeatureFlags)
+}
+
+// FeatureEnabled returns true if the feature is enabled for the role.
+func (c *Config) FeatureEnabled(feature, role string) bool {
+	return c.FeatureFlags().Enabled(feature, role)
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureFlags_Parse(t *testing.T) {
+	t.Run("all roles", func(t *testing.T) {
+		f := make(FeatureFlags).Parse("faces")
+
+		assert.True(t, f.Enabled(FeatureFaces, RoleAdmin))
+		assert.True(t, f.Enabled(FeatureFaces, RoleGuest))
+		assert.False(t, f.Enabled(FeatureMoments, RoleAdmin))
+	})
+	t.Run("single role", func(t *testing.T) {
+		f := make(FeatureFlags).Parse("Faces:admin, federation:guest")
+
+		assert.True(t, f.Enabled(FeatureFaces, RoleAdmin))
+		assert.False(t, f.Enabled(FeatureFaces, RoleGuest))
+		assert.False(t, f.Enabled(FeatureFederation, RoleAdmin))
+		assert.True(t, f.Enabled(FeatureFederation, RoleGuest))
+	})
+	t.Run("disable", func(t *testing.T) {
+		f := make(FeatureFlags).Parse("moments,-moments:guest")
+
+		assert.True(t, f.Enabled(FeatureMoments, RoleAdmin))
+		assert.False(t, f.Enabled(FeatureMoments, RoleGuest))
+	})
+	t.Run("empty", func(t *testing.T) {
+		f := make(FeatureFlags).Parse(" , ")
+
+		assert.Empty(t, f)
+	})
+}
+
+func TestFeatureFlags_Role(t *testing.T) {
+	f := make(FeatureFlags).Parse("faces:admin,maps")
+
+	assert.Equal(t, map[string]bool{"faces": true, "moments": false, "federation": false, "maps": true}, f.Role(RoleAdmin))
+	assert.Equal(t, map[string]bool{"faces": false, "moments": false, "federation": false, "maps": true}, f.Role(RoleGuest))
+}
+
+func TestFeatureFlags_String(t *testing.T) {
+	f := make(FeatureFlags).Parse("faces:admin,moments,-moments:guest")
+
+	assert.Equal(t, "faces:admin,moments:admin", f.String())
+}
+
+func TestConfig_FeatureFlags(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("experimental", func(t *testing.T) {
+		c.params.Experimental = true
+		c.params.FeatureFlags = "-moments,federation:guest"
+
+		assert.True(t, c.FeatureEnabled(FeatureFaces, RoleAdmin))
+		assert.False(t, c.FeatureEnabled(FeatureFaces, RoleGuest))
+		assert.False(t, c.FeatureEnabled(FeatureMoments, RoleAdmin))
+		assert.True(t, c.FeatureEnabled(FeatureFederation, RoleGuest))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		c.params.Experimental = false
+		c.params.FeatureFlags = ""
+
+		assert.Empty(t, c.FeatureFlags())
+		assert.False(t, c.FeatureEnabled(FeatureFaces, RoleAdmin))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/photoprism/photoprism/internal/sidecar"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -58,6 +59,10 @@ func (c *Config) CreateDirectories() error {
 		return createError(c.ThumbPath(), err)
 	}
 
+	if err := os.MkdirAll(c.WatermarksPath(), os.ModePerm); err != nil {
+		return createError(c.WatermarksPath(), err)
+	}
+
 	if err := os.MkdirAll(c.ResourcesPath(), os.ModePerm); err != nil {
 		return createError(c.ResourcesPath(), err)
 	}
@@ -127,6 +132,16 @@ func (c *Config) OriginalsPath() string {
 	return fs.Abs(c.params.OriginalsPath)
 }
 
+// BackupPath returns the hidden directory for incomplete uploads from mobile backup apps.
+func (c *Config) BackupPath() string {
+	return filepath.Join(c.ImportPath(), ".backup")
+}
+
+// IngestPath returns the import subdirectory for files uploaded by third-party backup apps like Immich.
+func (c *Config) IngestPath() string {
+	return filepath.Join(c.ImportPath(), "ingest")
+}
+
 // ImportPath returns the import directory.
 func (c *Config) ImportPath() string {
 	return fs.Abs(c.params.ImportPath)
@@ -149,7 +164,7 @@ func (c *Config) ExifToolBin() string {
 
 // SidecarJson returns true if metadata should be synced with json sidecar files as used by exiftool.
 func (c *Config) SidecarJson() bool {
-	if c.ReadOnly() || c.ExifToolBin() == "" {
+	if c.ExifToolBin() == "" {
 		return false
 	}
 
@@ -158,18 +173,67 @@ func (c *Config) SidecarJson() bool {
 
 // SidecarYaml returns true if metadata should be synced with PhotoPrism YAML sidecar files.
 func (c *Config) SidecarYaml() bool {
-	if c.ReadOnly() {
-		return false
-	}
-
 	return c.params.SidecarYaml
 }
 
-// SidecarHidden returns true if new sidecar files should be created in a .photoprism sub directory (hidden).
+// SidecarHidden returns true if new sidecar files should be created in a .photoprism sub directory (hidden)
+// unless a different sidecar strategy is configured.
 func (c *Config) SidecarHidden() bool {
 	return c.params.SidecarHidden
 }
 
+// SidecarStrategy returns the default sidecar strategy: inline, hidden, tree, or none.
+// New sidecar files are written to the sidecar path in read-only mode.
+func (c *Config) SidecarStrategy() sidecar.Strategy {
+	result := sidecar.Inline
+
+	if c.params.SidecarHidden {
+		result = sidecar.Hidden
+	}
+
+	if c.params.SidecarStrategy != "" {
+		if s, err := sidecar.ParseStrategy(c.params.SidecarStrategy); err != nil {
+			log.Warn(err)
+		} else {
+			result = s
+		}
+	}
+
+	if c.ReadOnly() && result != sidecar.None {
+		return sidecar.Tree
+	}
+
+	return result
+}
+
+// SidecarRoots returns sidecar strategies for folders in originals, e.g. mounted network shares.
+func (c *Config) SidecarRoots() sidecar.Strategies {
+	result, err := sidecar.ParseRoots(c.params.SidecarRoots)
+
+	if err != nil {
+		log.Warn(err)
+	}
+
+	if c.ReadOnly() {
+		for root, s := range result {
+			if s != sidecar.None {
+				result[root] = sidecar.Tree
+			}
+		}
+	}
+
+	return result
+}
+
+// SidecarPath returns the root of the parallel sidecar folder tree.
+func (c *Config) SidecarPath() string {
+	if c.params.SidecarPath == "" {
+		return filepath.Join(c.AssetsPath(), "sidecar")
+	}
+
+	return fs.Abs(c.params.SidecarPath)
+}
+
 // HeifConvertBin returns the heif-convert executable file name.
 func (c *Config) HeifConvertBin() string {
 	return findExecutable(c.params.HeifConvertBin, "heif-convert")
@@ -180,6 +244,11 @@ func (c *Config) FFmpegBin() string {
 	return findExecutable(c.params.FFmpegBin, "ffmpeg")
 }
 
+// FFprobeBin returns the ffprobe executable file name.
+func (c *Config) FFprobeBin() string {
+	return findExecutable(c.params.FFprobeBin, "ffprobe")
+}
+
 // TempPath returns a temporary directory name for uploads and downloads.
 func (c *Config) TempPath() string {
 	if c.params.TempPath == "" {
@@ -194,6 +263,11 @@ func (c *Config) CachePath() string {
 	return fs.Abs(c.params.CachePath)
 }
 
+// ConvertCachePath returns the path to JPEG versions of originals created for download.
+func (c *Config) ConvertCachePath() string {
+	return filepath.Join(c.CachePath(), "convert")
+}
+
 // AssetsPath returns the path to the assets.
 func (c *Config) AssetsPath() string {
 	return fs.Abs(c.params.AssetsPath)
@@ -222,3 +296,13 @@ func (c *Config) TensorFlowModelPath() string {
 func (c *Config) NSFWModelPath() string {
 	return filepath.Join(c.ResourcesPath(), "nsfw")
 }
+
+// QuarantinePath returns the path to suspicious uploads that need to be reviewed before they can be imported.
+func (c *Config) QuarantinePath() string {
+	return filepath.Join(c.AssetsPath(), "quarantine")
+}
+
+// WatermarksPath returns the path to PNG logos that can be used as watermark for shared thumbnails.
+func (c *Config) WatermarksPath() string {
+	return filepath.Join(c.ConfigPath(), "watermarks")
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/migrate"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// DatabaseBackupPath returns the path for database backups created before schema migrations.
+func (c *Config) DatabaseBackupPath() string {
+	if c.params.DatabaseBackupPath == "" {
+		return filepath.Join(c.AssetsPath(), "backup")
+	}
+
+	return fs.Abs(c.params.DatabaseBackupPath)
+}
+
+// MigrateManual returns true if pending schema migrations must be applied with the migrate command.
+func (c *Config) MigrateManual() bool {
+	return c.params.MigrateManual
+}
+
+// Migrator returns a migrator for the database schema.
+func (c *Config) Migrator() *migrate.Migrator {
+	return migrate.New(c.Db(), migrate.Migrations)
+}
+
+// BackupDb creates a backup of the database before schema migrations and returns the file name,
+// or an empty string if the database has not been initialized yet.
+func (c *Config) BackupDb() (string, error) {
+	if !c.Db().HasTable("photos") {
+		return "", nil
+	}
+
+	current, err := c.Migrator().Current()
+
+	if err != nil {
+		return "", err
+	}
+
+	fileName := migrate.BackupFileName(c.DatabaseBackupPath(), current)
+
+	if err := migrate.Backup(c.Db(), fileName); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// MigrateDb applies pending schema migrations, a backup is created first. New databases are always
+// initialized, other migrations are skipped if they should be applied manually.
+func (c *Config) MigrateDb() {
+	m := c.Migrator()
+
+	pending, err := m.Pending()
+
+	if err != nil {
+		log.Fatalf("migrate: %s", err)
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if current, err := m.Current(); err != nil {
+		log.Fatalf("migrate: %s", err)
+	} else if current > 0 && c.MigrateManual() {
+		log.Warnf("migrate: %d pending schema migrations, run \"photoprism migrate up\" to apply them", len(pending))
+		return
+	}
+
+	if fileName, err := c.BackupDb(); err != nil {
+		log.Fatalf("migrate: can't create backup (%s)", err)
+	} else if fileName != "" {
+		log.Infof("migrate: created backup %s", fileName)
+	}
+
+	if _, err := m.Up(0); err != nil {
+		log.Fatalf("migrate: %s", err)
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_DatabaseBackupPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasSuffix(c.DatabaseBackupPath(), "/backup"))
+
+	c.params.DatabaseBackupPath = "/srv/backup/db"
+	assert.Equal(t, "/srv/backup/db", c.DatabaseBackupPath())
+
+	c.params.DatabaseBackupPath = ""
+}
+
+func TestConfig_MigrateManual(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.MigrateManual())
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// ShareReminder returns how long before share links expire a reminder is sent, 0 if disabled.
+func (c *Config) ShareReminder() time.Duration {
+	if c.params.ShareReminder <= 0 {
+		return 0
+	}
+
+	return time.Duration(c.params.ShareReminder) * 24 * time.Hour
+}
+
+// NotifyEmail returns the email address notifications are sent to, or an empty string if disabled.
+func (c *Config) NotifyEmail() string {
+	if c.SmtpUrl() == "" {
+		return ""
+	}
+
+	return strings.TrimSpace(c.params.NotifyEmail)
+}
+
+// SmtpUrl returns the mail server url for sending notifications, including credentials. The url may be
+// encrypted, see "photoprism secrets encrypt".
+func (c *Config) SmtpUrl() string {
+	return c.reveal(strings.TrimSpace(c.params.SmtpUrl))
+}
@@ -0,0 +1,33 @@
+package config
+
+// Policies for photos that may be offensive.
+const (
+	NSFWPrivate = "private"
+	NSFWArchive = "archive"
+	NSFWReview  = "review"
+	NSFWIgnore  = "ignore"
+)
+
+// validNSFWPolicy returns true if the policy is supported.
+func validNSFWPolicy(policy string) bool {
+	switch policy {
+	case NSFWPrivate, NSFWArchive, NSFWReview, NSFWIgnore:
+		return true
+	default:
+		return false
+	}
+}
+
+// NSFWPolicy returns how newly indexed photos that may be offensive are handled.
+// The index settings override the config option, so it can be changed in the user interface.
+func (c *Config) NSFWPolicy() string {
+	if c.settings != nil && validNSFWPolicy(c.settings.Index.NSFW) {
+		return c.settings.Index.NSFW
+	}
+
+	if validNSFWPolicy(c.params.NSFWPolicy) {
+		return c.params.NSFWPolicy
+	}
+
+	return NSFWPrivate
+}
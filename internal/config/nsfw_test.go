@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_NSFWPolicy(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("default", func(t *testing.T) {
+		c.params.NSFWPolicy = ""
+		c.settings.Index.NSFW = ""
+		assert.Equal(t, NSFWPrivate, c.NSFWPolicy())
+	})
+	t.Run("option", func(t *testing.T) {
+		c.params.NSFWPolicy = NSFWReview
+		c.settings.Index.NSFW = ""
+		assert.Equal(t, NSFWReview, c.NSFWPolicy())
+	})
+	t.Run("settings override", func(t *testing.T) {
+		c.params.NSFWPolicy = NSFWReview
+		c.settings.Index.NSFW = NSFWIgnore
+		assert.Equal(t, NSFWIgnore, c.NSFWPolicy())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		c.params.NSFWPolicy = "delete"
+		c.settings.Index.NSFW = "foo"
+		assert.Equal(t, NSFWPrivate, c.NSFWPolicy())
+	})
+
+	c.params.NSFWPolicy = ""
+	c.settings.Index.NSFW = ""
+}
@@ -28,66 +28,116 @@ const (
 //
 // See https://github.com/photoprism/photoprism/issues/50#issuecomment-433856358
 type Params struct {
-	Name               string
-	Url                string `yaml:"url" flag:"url"`
-	Title              string `yaml:"title" flag:"title"`
-	Subtitle           string `yaml:"subtitle" flag:"subtitle"`
-	Description        string `yaml:"description" flag:"description"`
-	Author             string `yaml:"author" flag:"author"`
-	Version            string
-	Copyright          string
-	Public             bool   `yaml:"public" flag:"public"`
-	Debug              bool   `yaml:"debug" flag:"debug"`
-	ReadOnly           bool   `yaml:"read-only" flag:"read-only"`
-	Experimental       bool   `yaml:"experimental" flag:"experimental"`
-	Workers            int    `yaml:"workers" flag:"workers"`
-	WakeupInterval     int    `yaml:"wakeup-interval" flag:"wakeup-interval"`
-	AdminPassword      string `yaml:"admin-password" flag:"admin-password"`
-	WebDAVPassword     string `yaml:"webdav-password" flag:"webdav-password"`
-	LogLevel           string `yaml:"log-level" flag:"log-level"`
-	ConfigFile         string
-	ConfigPath         string `yaml:"config-path" flag:"config-path"`
-	TempPath           string `yaml:"temp-path" flag:"temp-path"`
-	CachePath          string `yaml:"cache-path" flag:"cache-path"`
-	OriginalsPath      string `yaml:"originals-path" flag:"originals-path"`
-	OriginalsLimit     int64  `yaml:"originals-limit" flag:"originals-limit"`
-	ImportPath         string `yaml:"import-path" flag:"import-path"`
-	AssetsPath         string `yaml:"assets-path" flag:"assets-path"`
-	ResourcesPath      string `yaml:"resources-path" flag:"resources-path"`
-	DatabaseDriver     string `yaml:"database-driver" flag:"database-driver"`
-	DatabaseDsn        string `yaml:"database-dsn" flag:"database-dsn"`
-	TidbServerHost     string `yaml:"tidb-host" flag:"tidb-host"`
-	TidbServerPort     uint   `yaml:"tidb-port" flag:"tidb-port"`
-	TidbServerPassword string `yaml:"tidb-password" flag:"tidb-password"`
-	TidbServerPath     string `yaml:"tidb-path" flag:"tidb-path"`
-	HttpServerHost     string `yaml:"http-host" flag:"http-host"`
-	HttpServerPort     int    `yaml:"http-port" flag:"http-port"`
-	HttpServerMode     string `yaml:"http-mode" flag:"http-mode"`
-	HttpServerPassword string `yaml:"http-password" flag:"http-password"`
-	SipsBin            string `yaml:"sips-bin" flag:"sips-bin"`
-	DarktableBin       string `yaml:"darktable-bin" flag:"darktable-bin"`
-	HeifConvertBin     string `yaml:"heifconvert-bin" flag:"heifconvert-bin"`
-	FFmpegBin          string `yaml:"ffmpeg-bin" flag:"ffmpeg-bin"`
-	ExifToolBin        string `yaml:"exiftool-bin" flag:"exiftool-bin"`
-	SidecarJson        bool   `yaml:"sidecar-json" flag:"sidecar-json"`
-	SidecarYaml        bool   `yaml:"sidecar-yaml" flag:"sidecar-yaml"`
-	SidecarHidden      bool   `yaml:"sidecar-hidden" flag:"sidecar-hidden"`
-	PIDFilename        string `yaml:"pid-filename" flag:"pid-filename"`
-	LogFilename        string `yaml:"log-filename" flag:"log-filename"`
-	DetachServer       bool   `yaml:"detach-server" flag:"detach-server"`
-	DetectNSFW         bool   `yaml:"detect-nsfw" flag:"detect-nsfw"`
-	UploadNSFW         bool   `yaml:"upload-nsfw" flag:"upload-nsfw"`
-	GeoCodingApi       string `yaml:"geocoding-api" flag:"geocoding-api"`
-	DownloadToken      string `yaml:"download-token" flag:"download-token"`
-	PreviewToken       string `yaml:"preview-token" flag:"preview-token"`
-	ThumbFilter        string `yaml:"thumb-filter" flag:"thumb-filter"`
-	ThumbUncached      bool   `yaml:"thumb-uncached" flag:"thumb-uncached"`
-	ThumbSize          int    `yaml:"thumb-size" flag:"thumb-size"`
-	ThumbLimit         int    `yaml:"thumb-limit" flag:"thumb-limit"`
-	JpegHidden         bool   `yaml:"jpeg-hidden" flag:"jpeg-hidden"`
-	JpegQuality        int    `yaml:"jpeg-quality" flag:"jpeg-quality"`
-	DisableTensorFlow  bool   `yaml:"disable-tf" flag:"disable-tf"`
-	DisableSettings    bool   `yaml:"disable-settings" flag:"disable-settings"`
+	Name                string
+	Url                 string `yaml:"url" flag:"url"`
+	Title               string `yaml:"title" flag:"title"`
+	Subtitle            string `yaml:"subtitle" flag:"subtitle"`
+	Description         string `yaml:"description" flag:"description"`
+	Author              string `yaml:"author" flag:"author"`
+	Version             string
+	Copyright           string
+	Public              bool   `yaml:"public" flag:"public"`
+	Debug               bool   `yaml:"debug" flag:"debug"`
+	ReadOnly            bool   `yaml:"read-only" flag:"read-only"`
+	Experimental        bool   `yaml:"experimental" flag:"experimental"`
+	UsageStats          bool   `yaml:"usage-stats" flag:"usage-stats"`
+	FeatureFlags        string `yaml:"feature-flags" flag:"feature-flags"`
+	Workers             int    `yaml:"workers" flag:"workers"`
+	WorkerMemory        int    `yaml:"worker-memory" flag:"worker-memory"`
+	MemoryReserve       int    `yaml:"memory-reserve" flag:"memory-reserve"`
+	WakeupInterval      int    `yaml:"wakeup-interval" flag:"wakeup-interval"`
+	IndexSchedule       string `yaml:"index-schedule" flag:"index-schedule"`
+	MissingGrace        int    `yaml:"missing-grace" flag:"missing-grace"`
+	ColdStorage         string `yaml:"cold-storage" flag:"cold-storage"`
+	ColdAfter           int    `yaml:"cold-after" flag:"cold-after"`
+	AdminPassword       string `yaml:"admin-password" flag:"admin-password"`
+	WebDAVPassword      string `yaml:"webdav-password" flag:"webdav-password"`
+	SecretKey           string `yaml:"secret-key" flag:"secret-key"`
+	SecretKeyFile       string `yaml:"secret-keyfile" flag:"secret-keyfile"`
+	SamlIdpUrl          string `yaml:"saml-idp-url" flag:"saml-idp-url"`
+	SamlIdpCert         string `yaml:"saml-idp-cert" flag:"saml-idp-cert"`
+	SamlRoleAttr        string `yaml:"saml-role-attr" flag:"saml-role-attr"`
+	SamlAdminRole       string `yaml:"saml-admin-role" flag:"saml-admin-role"`
+	SamlContributorRole string `yaml:"saml-contributor-role" flag:"saml-contributor-role"`
+	LogLevel            string `yaml:"log-level" flag:"log-level"`
+	ConfigFile          string
+	ConfigPath          string `yaml:"config-path" flag:"config-path"`
+	TempPath            string `yaml:"temp-path" flag:"temp-path"`
+	TempLimit           int64  `yaml:"temp-limit" flag:"temp-limit"`
+	CachePath           string `yaml:"cache-path" flag:"cache-path"`
+	OriginalsPath       string `yaml:"originals-path" flag:"originals-path"`
+	OriginalsLimit      int64  `yaml:"originals-limit" flag:"originals-limit"`
+	DiskReserve         int64  `yaml:"disk-reserve" flag:"disk-reserve"`
+	ImportPath          string `yaml:"import-path" flag:"import-path"`
+	ImportUrlHosts      string `yaml:"import-url-hosts" flag:"import-url-hosts"`
+	AssetsPath          string `yaml:"assets-path" flag:"assets-path"`
+	ResourcesPath       string `yaml:"resources-path" flag:"resources-path"`
+	DatabaseDriver      string `yaml:"database-driver" flag:"database-driver"`
+	DatabaseDsn         string `yaml:"database-dsn" flag:"database-dsn"`
+	DatabaseBackupPath  string `yaml:"database-backup-path" flag:"database-backup-path"`
+	MigrateManual       bool   `yaml:"migrate-manual" flag:"migrate-manual"`
+	TidbServerHost      string `yaml:"tidb-host" flag:"tidb-host"`
+	TidbServerPort      uint   `yaml:"tidb-port" flag:"tidb-port"`
+	TidbServerPassword  string `yaml:"tidb-password" flag:"tidb-password"`
+	TidbServerPath      string `yaml:"tidb-path" flag:"tidb-path"`
+	HttpServerHost      string `yaml:"http-host" flag:"http-host"`
+	HttpServerPort      int    `yaml:"http-port" flag:"http-port"`
+	GrpcServerPort      int    `yaml:"grpc-port" flag:"grpc-port"`
+	HttpServerMode      string `yaml:"http-mode" flag:"http-mode"`
+	HttpServerPassword  string `yaml:"http-password" flag:"http-password"`
+	TrustedProxy        string `yaml:"trusted-proxy" flag:"trusted-proxy"`
+	SipsBin             string `yaml:"sips-bin" flag:"sips-bin"`
+	DarktableBin        string `yaml:"darktable-bin" flag:"darktable-bin"`
+	HeifConvertBin      string `yaml:"heifconvert-bin" flag:"heifconvert-bin"`
+	FFmpegBin           string `yaml:"ffmpeg-bin" flag:"ffmpeg-bin"`
+	FFprobeBin          string `yaml:"ffprobe-bin" flag:"ffprobe-bin"`
+	VideoPoster         string `yaml:"video-poster" flag:"video-poster"`
+	ExifToolBin         string `yaml:"exiftool-bin" flag:"exiftool-bin"`
+	SidecarJson         bool   `yaml:"sidecar-json" flag:"sidecar-json"`
+	SidecarYaml         bool   `yaml:"sidecar-yaml" flag:"sidecar-yaml"`
+	SidecarHidden       bool   `yaml:"sidecar-hidden" flag:"sidecar-hidden"`
+	SidecarStrategy     string `yaml:"sidecar-strategy" flag:"sidecar-strategy"`
+	SidecarRoots        string `yaml:"sidecar-roots" flag:"sidecar-roots"`
+	SidecarPath         string `yaml:"sidecar-path" flag:"sidecar-path"`
+	SearchLanguages     string `yaml:"search-languages" flag:"search-languages"`
+	SearchTimeout       int    `yaml:"search-timeout" flag:"search-timeout"`
+	PIDFilename         string `yaml:"pid-filename" flag:"pid-filename"`
+	LogFilename         string `yaml:"log-filename" flag:"log-filename"`
+	DetachServer        bool   `yaml:"detach-server" flag:"detach-server"`
+	DetectNSFW          bool   `yaml:"detect-nsfw" flag:"detect-nsfw"`
+	DetectGeometry      bool   `yaml:"detect-geometry" flag:"detect-geometry"`
+	ExcludeCategories   bool   `yaml:"exclude-categories" flag:"exclude-categories"`
+	UploadNSFW          bool   `yaml:"upload-nsfw" flag:"upload-nsfw"`
+	UploadQuarantine    bool   `yaml:"upload-quarantine" flag:"upload-quarantine"`
+	UploadReencode      bool   `yaml:"upload-reencode" flag:"upload-reencode"`
+	UploadMaxRes        int    `yaml:"upload-max-resolution" flag:"upload-max-resolution"`
+	NSFWPolicy          string `yaml:"nsfw-policy" flag:"nsfw-policy"`
+	GeoCodingApi        string `yaml:"geocoding-api" flag:"geocoding-api"`
+	PrintService        string `yaml:"print-service" flag:"print-service"`
+	PrintServiceUrl     string `yaml:"print-service-url" flag:"print-service-url"`
+	PrintServiceKey     string `yaml:"print-service-key" flag:"print-service-key"`
+	ChatService         string `yaml:"chat-service" flag:"chat-service"`
+	ChatServiceUrl      string `yaml:"chat-service-url" flag:"chat-service-url"`
+	ChatServiceKey      string `yaml:"chat-service-key" flag:"chat-service-key"`
+	ChatServiceTarget   string `yaml:"chat-service-target" flag:"chat-service-target"`
+	ShareReminder       int    `yaml:"share-reminder" flag:"share-reminder"`
+	NotifyEmail         string `yaml:"notify-email" flag:"notify-email"`
+	SmtpUrl             string `yaml:"smtp-url" flag:"smtp-url"`
+	CheckpointInterval  int    `yaml:"checkpoint-interval" flag:"checkpoint-interval"`
+	CheckpointKeep      int    `yaml:"checkpoint-keep" flag:"checkpoint-keep"`
+	SemanticServiceUrl  string `yaml:"semantic-service-url" flag:"semantic-service-url"`
+	SemanticServiceKey  string `yaml:"semantic-service-key" flag:"semantic-service-key"`
+	DownloadToken       string `yaml:"download-token" flag:"download-token"`
+	PreviewToken        string `yaml:"preview-token" flag:"preview-token"`
+	ThumbFilter         string `yaml:"thumb-filter" flag:"thumb-filter"`
+	ThumbUncached       bool   `yaml:"thumb-uncached" flag:"thumb-uncached"`
+	ThumbAdaptive       bool   `yaml:"thumb-adaptive" flag:"thumb-adaptive"`
+	ThumbSize           int    `yaml:"thumb-size" flag:"thumb-size"`
+	ThumbLimit          int    `yaml:"thumb-limit" flag:"thumb-limit"`
+	JpegHidden          bool   `yaml:"jpeg-hidden" flag:"jpeg-hidden"`
+	JpegQuality         int    `yaml:"jpeg-quality" flag:"jpeg-quality"`
+	DisableTensorFlow   bool   `yaml:"disable-tf" flag:"disable-tf"`
+	DisableSettings     bool   `yaml:"disable-settings" flag:"disable-settings"`
 }
 
 // NewParams creates a new configuration entity by using two methods:
@@ -0,0 +1,23 @@
+package config
+
+import "strings"
+
+// PrintService returns the name of the service print orders are submitted to, or an empty string
+// if albums can only be exported. Defaults to "http" if a service url is configured.
+func (c *Config) PrintService() string {
+	if c.params.PrintService == "" && c.params.PrintServiceUrl != "" {
+		return "http"
+	}
+
+	return strings.ToLower(strings.TrimSpace(c.params.PrintService))
+}
+
+// PrintServiceUrl returns the print service api url.
+func (c *Config) PrintServiceUrl() string {
+	return c.params.PrintServiceUrl
+}
+
+// PrintServiceKey returns the print service api key.
+func (c *Config) PrintServiceKey() string {
+	return c.params.PrintServiceKey
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_PrintService(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.PrintService())
+
+	c.params.PrintServiceUrl = "https://lab.example.com/orders"
+	assert.Equal(t, "http", c.PrintService())
+	assert.Equal(t, "https://lab.example.com/orders", c.PrintServiceUrl())
+
+	c.params.PrintService = " Custom "
+	assert.Equal(t, "custom", c.PrintService())
+
+	c.params.PrintService = ""
+	c.params.PrintServiceUrl = ""
+}
+
+func TestConfig_PrintServiceKey(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.PrintServiceKey())
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
 
@@ -50,6 +51,32 @@ func (c *Config) ThumbUncached() bool {
 	return c.params.ThumbUncached
 }
 
+// ThumbAdaptive returns true if thumbnails larger than displayed by clients should be rendered on demand.
+func (c *Config) ThumbAdaptive() bool {
+	return c.params.ThumbAdaptive
+}
+
+// UpdateThumbSize sets the size limit for thumbnails rendered in advance based on display sizes reported by clients.
+func (c *Config) UpdateThumbSize() {
+	if !c.ThumbAdaptive() {
+		thumb.Size = c.ThumbSize()
+		return
+	}
+
+	reports, err := entity.ThumbUsageReports()
+
+	if err != nil {
+		log.Errorf("config: %s", err)
+		thumb.Size = c.ThumbSize()
+		return
+	}
+
+	if size := thumb.AdaptiveSize(reports, c.ThumbSize()); size != thumb.Size {
+		log.Infof("config: thumbnails up to %d pixels will be rendered in advance", size)
+		thumb.Size = size
+	}
+}
+
 // ThumbSize returns the default thumbnail size limit in pixels (720-3840).
 func (c *Config) ThumbSize() int {
 	if c.params.ThumbSize > 3840 {
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// SamlEnabled returns true if single sign-on with a SAML identity provider is configured.
+func (c *Config) SamlEnabled() bool {
+	return c.SamlIdpUrl() != "" && c.SamlIdpCert() != ""
+}
+
+// SamlIdpUrl returns the single sign-on URL of the SAML identity provider.
+func (c *Config) SamlIdpUrl() string {
+	return c.params.SamlIdpUrl
+}
+
+// SamlIdpCert returns the filename of the PEM encoded identity provider signing certificate.
+func (c *Config) SamlIdpCert() string {
+	if c.params.SamlIdpCert == "" {
+		return ""
+	}
+
+	return fs.Abs(c.params.SamlIdpCert)
+}
+
+// SamlRoleAttr returns the name of the SAML attribute containing user roles (default is "Role").
+func (c *Config) SamlRoleAttr() string {
+	if c.params.SamlRoleAttr == "" {
+		return "Role"
+	}
+
+	return c.params.SamlRoleAttr
+}
+
+// SamlAdminRole returns the role value that grants admin access (default is "admin").
+func (c *Config) SamlAdminRole() string {
+	if c.params.SamlAdminRole == "" {
+		return "admin"
+	}
+
+	return c.params.SamlAdminRole
+}
+
+// SamlContributorRole returns the role value that grants contributor access (default is "contributor").
+func (c *Config) SamlContributorRole() string {
+	if c.params.SamlContributorRole == "" {
+		return "contributor"
+	}
+
+	return c.params.SamlContributorRole
+}
+
+// SamlEntityID returns the service provider entity ID, which is also the metadata URL.
+func (c *Config) SamlEntityID() string {
+	return strings.TrimRight(c.Url(), "/") + "/api/v1/saml/metadata"
+}
+
+// SamlAcsUrl returns the assertion consumer service URL.
+func (c *Config) SamlAcsUrl() string {
+	return strings.TrimRight(c.Url(), "/") + "/api/v1/saml/acs"
+}
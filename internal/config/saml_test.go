@@ -0,0 +1,39 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SamlEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.SamlEnabled())
+
+	c.params.SamlIdpUrl = "https://idp.example.com/sso"
+	c.params.SamlIdpCert = "testdata/idp.pem"
+
+	assert.True(t, c.SamlEnabled())
+	assert.Equal(t, "https://idp.example.com/sso", c.SamlIdpUrl())
+	assert.Contains(t, c.SamlIdpCert(), "/testdata/idp.pem")
+
+	c.params.SamlIdpUrl = ""
+	c.params.SamlIdpCert = ""
+}
+
+func TestConfig_SamlRoleAttr(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "Role", c.SamlRoleAttr())
+	assert.Equal(t, "admin", c.SamlAdminRole())
+	assert.Equal(t, "contributor", c.SamlContributorRole())
+}
+
+func TestConfig_SamlEntityID(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, strings.TrimRight(c.Url(), "/")+"/api/v1/saml/metadata", c.SamlEntityID())
+	assert.Equal(t, strings.TrimRight(c.Url(), "/")+"/api/v1/saml/acs", c.SamlAcsUrl())
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule represents a daily time window like "01:00-06:00", an empty schedule always matches.
+type Schedule struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseSchedule parses a time window in the format "HH:MM-HH:MM".
+func ParseSchedule(s string) (result Schedule, err error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return result, nil
+	}
+
+	parts := strings.Split(s, "-")
+
+	if len(parts) != 2 {
+		return result, fmt.Errorf("invalid schedule %s, expected HH:MM-HH:MM", s)
+	}
+
+	if result.Start, err = parseClock(parts[0]); err != nil {
+		return Schedule{}, err
+	}
+
+	if result.End, err = parseClock(parts[1]); err != nil {
+		return Schedule{}, err
+	}
+
+	return result, nil
+}
+
+// parseClock returns the duration since midnight for a time in the format "HH:MM".
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %s, expected HH:MM", strings.TrimSpace(s))
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Empty returns true if no time window is configured.
+func (s Schedule) Empty() bool {
+	return s.Start == s.End
+}
+
+// Contains returns true if the time of day is within the window, which may wrap around midnight.
+func (s Schedule) Contains(t time.Time) bool {
+	if s.Empty() {
+		return true
+	}
+
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	if s.Start < s.End {
+		return d >= s.Start && d < s.End
+	}
+
+	return d >= s.Start || d < s.End
+}
+
+// String returns the schedule in the format "HH:MM-HH:MM".
+func (s Schedule) String() string {
+	if s.Empty() {
+		return ""
+	}
+
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", int(s.Start.Hours()), int(s.Start.Minutes())%60, int(s.End.Hours()), int(s.End.Minutes())%60)
+}
+
+// IndexSchedule returns the daily time window in which indexing and importing may run.
+func (c *Config) IndexSchedule() Schedule {
+	result, err := ParseSchedule(c.params.IndexSchedule)
+
+	if err != nil {
+		log.Errorf("config: %s", err)
+	}
+
+	return result
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s, err := ParseSchedule("")
+		assert.Nil(t, err)
+		assert.True(t, s.Empty())
+		assert.Equal(t, "", s.String())
+	})
+	t.Run("night", func(t *testing.T) {
+		s, err := ParseSchedule("01:00-06:30")
+		assert.Nil(t, err)
+		assert.Equal(t, time.Hour, s.Start)
+		assert.Equal(t, 6*time.Hour+30*time.Minute, s.End)
+		assert.Equal(t, "01:00-06:30", s.String())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseSchedule("01:00")
+		assert.Error(t, err)
+		_, err = ParseSchedule("1am-6am")
+		assert.Error(t, err)
+	})
+}
+
+func TestSchedule_Contains(t *testing.T) {
+	day := func(hour, min int) time.Time {
+		return time.Date(2020, 5, 1, hour, min, 0, 0, time.Local)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, Schedule{}.Contains(day(12, 0)))
+	})
+	t.Run("night", func(t *testing.T) {
+		s, _ := ParseSchedule("01:00-06:00")
+		assert.True(t, s.Contains(day(1, 0)))
+		assert.True(t, s.Contains(day(5, 59)))
+		assert.False(t, s.Contains(day(6, 0)))
+		assert.False(t, s.Contains(day(12, 0)))
+	})
+	t.Run("midnight", func(t *testing.T) {
+		s, _ := ParseSchedule("22:00-05:00")
+		assert.True(t, s.Contains(day(23, 0)))
+		assert.True(t, s.Contains(day(2, 0)))
+		assert.False(t, s.Contains(day(12, 0)))
+	})
+}
+
+func TestConfig_IndexSchedule(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, c.IndexSchedule().Empty())
+}
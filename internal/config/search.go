@@ -0,0 +1,42 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/synonyms"
+)
+
+// SearchLanguages returns the language codes of the synonym packs used for search, all if empty.
+func (c *Config) SearchLanguages() (result []string) {
+	for _, lang := range strings.Split(c.params.SearchLanguages, ",") {
+		if lang = strings.ToLower(strings.TrimSpace(lang)); lang != "" {
+			result = append(result, lang)
+		}
+	}
+
+	return result
+}
+
+// SearchTimeout returns the max execution time of search queries, 0 if disabled. Only MySQL 5.7+
+// supports statement timeouts, so it's always disabled for other databases.
+func (c *Config) SearchTimeout() time.Duration {
+	if c.params.SearchTimeout <= 0 || c.DatabaseDriver() != DriverMysql {
+		return 0
+	}
+
+	return time.Duration(c.params.SearchTimeout) * time.Second
+}
+
+// SynonymsPath returns the path to custom language packs, which extend the built-in packs.
+func (c *Config) SynonymsPath() string {
+	return filepath.Join(c.ConfigPath(), "synonyms")
+}
+
+// Synonyms loads the built-in and custom language packs for search.
+func (c *Config) Synonyms() synonyms.Index {
+	dirs := []string{filepath.Join(c.ResourcesPath(), "synonyms"), c.SynonymsPath()}
+
+	return synonyms.Load(dirs, c.SearchLanguages())
+}
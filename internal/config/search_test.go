@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SearchLanguages(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("all", func(t *testing.T) {
+		c.params.SearchLanguages = ""
+		assert.Empty(t, c.SearchLanguages())
+	})
+	t.Run("list", func(t *testing.T) {
+		c.params.SearchLanguages = "de, FR,,es"
+		assert.Equal(t, []string{"de", "fr", "es"}, c.SearchLanguages())
+	})
+}
+
+func TestConfig_SynonymsPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.ConfigPath()+"/synonyms", c.SynonymsPath())
+}
+
+func TestConfig_Synonyms(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.params.SearchLanguages = "de"
+
+	idx := c.Synonyms()
+
+	assert.Equal(t, "hund dog", idx.Expand("hund"))
+	assert.Equal(t, "chien", idx.Expand("chien"))
+}
+
+func TestConfig_SearchTimeout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.params.SearchTimeout = 0
+	assert.Equal(t, time.Duration(0), c.SearchTimeout())
+
+	c.params.SearchTimeout = 10
+	c.params.DatabaseDriver = DriverMysql
+	assert.Equal(t, 10*time.Second, c.SearchTimeout())
+
+	c.params.DatabaseDriver = DriverTidb
+	assert.Equal(t, time.Duration(0), c.SearchTimeout())
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/secret"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SecretKey returns the base64 encoded master key for encrypting stored passwords.
+func (c *Config) SecretKey() string {
+	return c.params.SecretKey
+}
+
+// SecretKeyFile returns the master key file name.
+func (c *Config) SecretKeyFile() string {
+	if c.params.SecretKeyFile == "" {
+		return filepath.Join(c.ConfigPath(), "secret.key")
+	}
+
+	return fs.Abs(c.params.SecretKeyFile)
+}
+
+// InitSecrets sets the master key for encrypting stored passwords. A new key file is created
+// if no key has been configured, so that passwords are never stored in plaintext.
+func (c *Config) InitSecrets() error {
+	if c.SecretKey() != "" {
+		key, err := secret.ParseKey(c.SecretKey())
+
+		if err != nil {
+			return err
+		}
+
+		return secret.SetKey(key)
+	}
+
+	fileName := c.SecretKeyFile()
+
+	key, err := secret.ReadKeyFile(fileName)
+
+	if os.IsNotExist(err) {
+		log.Infof("config: creating master key file %s", txt.Quote(fileName))
+		key, err = secret.WriteKeyFile(fileName)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return secret.SetKey(key)
+}
+
+// reveal returns the plaintext of an encrypted config value, see "photoprism secrets encrypt".
+func (c *Config) reveal(s string) string {
+	result, err := secret.Decrypt(s)
+
+	if err != nil {
+		log.Errorf("config: %s", err)
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSecretKey is a base64 encoded 32 byte master key.
+const testSecretKey = "cGhvdG9wcmlzbS10ZXN0LW1hc3Rlci1rZXktMzJieXQ="
+
+func TestConfig_SecretKeyFile(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/config/secret.key", c.SecretKeyFile())
+}
+
+func TestConfig_InitSecrets(t *testing.T) {
+	t.Run("key", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.params.SecretKey = testSecretKey
+
+		assert.Nil(t, c.InitSecrets())
+		assert.True(t, secret.HasKey())
+	})
+	t.Run("passphrase", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.params.SecretKey = "photoprism"
+
+		assert.Equal(t, secret.ErrKeyFormat, c.InitSecrets())
+	})
+	t.Run("key file", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.params.SecretKey = ""
+		c.params.SecretKeyFile = filepath.Join(os.TempDir(), "photoprism-config-test", "secret.key")
+		defer os.RemoveAll(filepath.Dir(c.params.SecretKeyFile))
+
+		assert.Nil(t, c.InitSecrets())
+		assert.FileExists(t, c.SecretKeyFile())
+		assert.Nil(t, c.InitSecrets())
+	})
+}
+
+func TestConfig_WebDAVPassword(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.params.SecretKey = testSecretKey
+
+	if err := c.InitSecrets(); err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := secret.Encrypt("foobar")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.params.WebDAVPassword = enc
+	assert.Equal(t, "foobar", c.WebDAVPassword())
+
+	c.params.WebDAVPassword = "plain"
+	assert.Equal(t, "plain", c.WebDAVPassword())
+}
@@ -0,0 +1,18 @@
+package config
+
+import "strings"
+
+// SemanticServiceUrl returns the CLIP encoder service url for natural language search.
+func (c *Config) SemanticServiceUrl() string {
+	return strings.TrimSpace(c.params.SemanticServiceUrl)
+}
+
+// SemanticServiceKey returns the CLIP encoder service api key.
+func (c *Config) SemanticServiceKey() string {
+	return c.params.SemanticServiceKey
+}
+
+// SemanticSearch returns true if natural language search is enabled.
+func (c *Config) SemanticSearch() bool {
+	return c.SemanticServiceUrl() != ""
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_SemanticSearch(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.SemanticSearch())
+	assert.Equal(t, "", c.SemanticServiceUrl())
+	assert.Equal(t, "", c.SemanticServiceKey())
+
+	c.params.SemanticServiceUrl = " http://clip:8000 "
+	c.params.SemanticServiceKey = "secret"
+
+	assert.True(t, c.SemanticSearch())
+	assert.Equal(t, "http://clip:8000", c.SemanticServiceUrl())
+	assert.Equal(t, "secret", c.SemanticServiceKey())
+
+	c.params.SemanticServiceUrl = ""
+	c.params.SemanticServiceKey = ""
+}
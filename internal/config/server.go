@@ -2,6 +2,7 @@ package config
 
 import (
 	"path/filepath"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -29,6 +30,22 @@ func (c *Config) HttpServerPort() int {
 	return c.params.HttpServerPort
 }
 
+// GrpcServerPort returns the gRPC server port, the server is disabled if 0.
+func (c *Config) GrpcServerPort() int {
+	return c.params.GrpcServerPort
+}
+
+// TrustedProxies returns the IP addresses and ranges of reverse proxies whose forwarding headers are trusted.
+func (c *Config) TrustedProxies() (result []string) {
+	for _, proxy := range strings.Split(c.params.TrustedProxy, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			result = append(result, proxy)
+		}
+	}
+
+	return result
+}
+
 // HttpServerMode returns the server mode.
 func (c *Config) HttpServerMode() string {
 	if c.params.HttpServerMode == "" {
@@ -29,6 +29,7 @@ type IndexSettings struct {
 	Convert bool   `json:"convert" yaml:"convert"`
 	Rescan  bool   `json:"rescan" yaml:"rescan"`
 	Group   bool   `json:"group" yaml:"group"`
+	NSFW    string `json:"nsfw" yaml:"nsfw"`
 }
 
 type ImportSettings struct {
@@ -47,6 +47,7 @@ func NewTestParams() *Params {
 		ReadOnly:       false,
 		DetectNSFW:     true,
 		UploadNSFW:     false,
+		SecretKey:      "photoprism",
 		SidecarHidden:  true,
 		DarktableBin:   "/usr/bin/darktable-cli",
 		ExifToolBin:    "/usr/bin/exiftool",
@@ -70,6 +71,7 @@ func NewTestParamsError() *Params {
 
 	c := &Params{
 		DarktableBin:   "/usr/bin/darktable-cli",
+		SecretKey:      "photoprism",
 		AssetsPath:     assetsPath,
 		CachePath:      testDataPath + "/cache",
 		OriginalsPath:  testDataPath + "/originals",
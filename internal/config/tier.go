@@ -0,0 +1,30 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ColdStorage returns the folder name or URI of the cold storage for old originals.
+func (c *Config) ColdStorage() string {
+	return strings.TrimSpace(c.params.ColdStorage)
+}
+
+// ColdAfter returns the age in years after which originals are moved to cold storage, 0 if disabled.
+func (c *Config) ColdAfter() int {
+	if c.params.ColdAfter < 0 {
+		return 0
+	}
+
+	return c.params.ColdAfter
+}
+
+// ColdTiering returns true if originals should be moved to cold storage automatically.
+func (c *Config) ColdTiering() bool {
+	return c.ColdStorage() != "" && c.ColdAfter() > 0 && !c.ReadOnly()
+}
+
+// ColdCachePath returns the cache folder for originals fetched from cold storage.
+func (c *Config) ColdCachePath() string {
+	return filepath.Join(c.CachePath(), "cold")
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ColdStorage(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ColdStorage())
+
+	c.params.ColdStorage = " /mnt/cold "
+	assert.Equal(t, "/mnt/cold", c.ColdStorage())
+
+	c.params.ColdStorage = ""
+}
+
+func TestConfig_ColdTiering(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.ColdAfter())
+	assert.False(t, c.ColdTiering())
+
+	c.params.ColdStorage = "/mnt/cold"
+	c.params.ColdAfter = -1
+	assert.Equal(t, 0, c.ColdAfter())
+	assert.False(t, c.ColdTiering())
+
+	c.params.ColdAfter = 5
+	assert.Equal(t, 5, c.ColdAfter())
+	assert.True(t, c.ColdTiering())
+
+	c.params.ColdStorage = ""
+	c.params.ColdAfter = 0
+}
+
+func TestConfig_ColdCachePath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.CachePath()+"/cold", c.ColdCachePath())
+}
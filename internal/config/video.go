@@ -0,0 +1,10 @@
+package config
+
+// VideoPoster returns the offset of video poster frames in seconds, or "scene" to pick a representative frame.
+func (c *Config) VideoPoster() string {
+	if c.params.VideoPoster == "" {
+		return "1"
+	}
+
+	return c.params.VideoPoster
+}
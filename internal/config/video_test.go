@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_VideoPoster(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "1", c.VideoPoster())
+
+	c.params.VideoPoster = "scene"
+	assert.Equal(t, "scene", c.VideoPoster())
+
+	c.params.VideoPoster = ""
+}
+
+func TestConfig_FFprobeBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	bin := c.FFprobeBin()
+
+	if bin != "" {
+		assert.Contains(t, bin, "ffprobe")
+	}
+}
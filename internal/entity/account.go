@@ -5,9 +5,11 @@ import (
 	"sort"
 	"time"
 
+	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/secret"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/ulule/deepcopier"
 )
@@ -28,7 +30,7 @@ type Account struct {
 	AccType       string `gorm:"type:varbinary(255);"`
 	AccKey        string `gorm:"type:varbinary(255);"`
 	AccUser       string `gorm:"type:varbinary(255);"`
-	AccPass       string `gorm:"type:varbinary(255);"`
+	AccPass       string `gorm:"type:varbinary(512);"`
 	AccError      string `gorm:"type:varbinary(512);"`
 	AccErrors     int
 	AccShare      bool
@@ -50,6 +52,40 @@ type Account struct {
 	DeletedAt     *time.Time `deepcopier:"skip" sql:"index"`
 }
 
+// BeforeSave encrypts the account password before it is stored. Empty and already encrypted values are
+// left alone, so that updates of other columns don't overwrite the stored password.
+func (m *Account) BeforeSave(scope *gorm.Scope) error {
+	if m.AccPass == "" || secret.Encrypted(m.AccPass) {
+		return nil
+	}
+
+	pass, err := secret.Encrypt(m.AccPass)
+
+	if err != nil {
+		return err
+	}
+
+	return scope.SetColumn("AccPass", pass)
+}
+
+// AfterSave decrypts the account password after it was stored.
+func (m *Account) AfterSave() error {
+	return m.AfterFind()
+}
+
+// AfterFind decrypts the account password after it was loaded from the database.
+func (m *Account) AfterFind() error {
+	pass, err := secret.Decrypt(m.AccPass)
+
+	if err != nil {
+		log.Errorf("account: can't decrypt password of %s (%s)", m.AccName, err)
+	}
+
+	m.AccPass = pass
+
+	return nil
+}
+
 // CreateAccount creates a new account entity in the database.
 func CreateAccount(form form.Account) (model *Account, err error) {
 	model = &Account{
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -12,12 +13,22 @@ import (
 	"github.com/ulule/deepcopier"
 )
 
+// ErrAlbumParentInvalid is returned if an album would become its own ancestor.
+var ErrAlbumParentInvalid = errors.New("album: invalid parent")
+
+// ErrAlbumFilterInvalid is returned if the filter of a smart album isn't a valid photo search.
+var ErrAlbumFilterInvalid = errors.New("album: invalid filter")
+
+// ErrAlbumDateInvalid is returned if the year, month, or day of an album is out of range.
+var ErrAlbumDateInvalid = errors.New("album: invalid date")
+
 // Album represents a photo album
 type Album struct {
 	ID               uint       `gorm:"primary_key" json:"ID" yaml:"-"`
 	AlbumUID         string     `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
 	CoverUID         string     `gorm:"type:varbinary(36);" json:"CoverUID" yaml:"CoverUID,omitempty"`
 	FolderUID        string     `gorm:"type:varbinary(36);index;" json:"FolderUID" yaml:"FolderUID,omitempty"`
+	ParentUID        string     `gorm:"type:varbinary(36);index;" json:"ParentUID" yaml:"ParentUID,omitempty"`
 	AlbumSlug        string     `gorm:"type:varbinary(255);index;" json:"Slug" yaml:"Slug"`
 	AlbumType        string     `gorm:"type:varbinary(8);" json:"Type" yaml:"Type,omitempty"`
 	AlbumTitle       string     `gorm:"type:varchar(255);" json:"Title" yaml:"Title"`
@@ -25,15 +36,19 @@ type Album struct {
 	AlbumCaption     string     `gorm:"type:text;" json:"Caption" yaml:"Caption,omitempty"`
 	AlbumDescription string     `gorm:"type:text;" json:"Description" yaml:"Description,omitempty"`
 	AlbumNotes       string     `gorm:"type:text;" json:"Notes" yaml:"Notes,omitempty"`
+	AlbumStory       string     `gorm:"type:text;" json:"Story" yaml:"Story,omitempty"`
+	AlbumLocation    string     `gorm:"type:varchar(255);" json:"Location" yaml:"Location,omitempty"`
 	AlbumFilter      string     `gorm:"type:varbinary(1024);" json:"Filter" yaml:"Filter,omitempty"`
 	AlbumOrder       string     `gorm:"type:varbinary(32);" json:"Order" yaml:"Order,omitempty"`
 	AlbumTemplate    string     `gorm:"type:varbinary(255);" json:"Template" yaml:"Template,omitempty"`
 	AlbumCountry     string     `gorm:"type:varbinary(2);index:idx_albums_country_year_month;default:'zz'" json:"Country" yaml:"Country,omitempty"`
 	AlbumYear        int        `gorm:"index:idx_albums_country_year_month;" json:"Year" yaml:"Year,omitempty"`
 	AlbumMonth       int        `gorm:"index:idx_albums_country_year_month;" json:"Month" yaml:"Month,omitempty"`
+	AlbumDay         int        `json:"Day" yaml:"Day,omitempty"`
 	AlbumFavorite    bool       `json:"Favorite" yaml:"Favorite,omitempty"`
 	AlbumPrivate     bool       `json:"Private" yaml:"Private,omitempty"`
 	Links            []Link     `gorm:"foreignkey:share_uid;association_foreignkey:album_uid" json:"Links" yaml:"-"`
+	AlbumVersion     uint       `gorm:"not null;default:0;" json:"Version" yaml:"-"`
 	CreatedAt        time.Time  `json:"CreatedAt" yaml:"-"`
 	UpdatedAt        time.Time  `json:"UpdatedAt" yaml:"-"`
 	DeletedAt        *time.Time `sql:"index" json:"-" yaml:"-"`
@@ -48,6 +63,15 @@ func (m *Album) BeforeCreate(scope *gorm.Scope) error {
 	return scope.SetColumn("AlbumUID", rnd.PPID('a'))
 }
 
+// BeforeSave increments the version number if the complete album is saved, see VersionTag().
+func (m *Album) BeforeSave(scope *gorm.Scope) error {
+	if _, partial := scope.InstanceGet("gorm:update_attrs"); partial {
+		return nil
+	}
+
+	return scope.SetColumn("AlbumVersion", m.AlbumVersion+1)
+}
+
 // NewAlbum creates a new album; default name is current month and year
 func NewAlbum(albumTitle, albumType string) *Album {
 	now := time.Now().UTC()
@@ -82,8 +106,25 @@ func (m *Album) SetTitle(title string) {
 	}
 }
 
+// VersionTag returns the entity tag of the current version, e.g. to detect conflicting changes.
+func (m *Album) VersionTag() string {
+	return VersionTag(m.AlbumVersion)
+}
+
 // Saves the entity using form data and stores it in the database.
 func (m *Album) SaveForm(f form.Album) error {
+	if f.ParentUID != m.ParentUID && !m.ValidParent(f.ParentUID) {
+		return ErrAlbumParentInvalid
+	}
+
+	if f.AlbumType == TypeMoment && !ValidAlbumFilter(f.AlbumFilter) {
+		return ErrAlbumFilterInvalid
+	}
+
+	if !ValidAlbumDate(f.AlbumYear, f.AlbumMonth, f.AlbumDay) {
+		return ErrAlbumDateInvalid
+	}
+
 	if err := deepcopier.Copy(m).From(f); err != nil {
 		return err
 	}
@@ -95,6 +136,104 @@ func (m *Album) SaveForm(f form.Album) error {
 	return Db().Save(m).Error
 }
 
+// HasFilter returns true if the album is a smart album containing all photos that match its filter,
+// instead of the photos added to it.
+func (m *Album) HasFilter() bool {
+	return m.AlbumType == TypeMoment && m.AlbumFilter != ""
+}
+
+// ValidAlbumFilter returns true if the filter is a valid photo search like "label:cat year:2020".
+// Filters must not refer to albums, so that smart albums can't contain each other.
+func ValidAlbumFilter(filter string) bool {
+	// The filter column is limited to 1024 bytes.
+	if strings.TrimSpace(filter) == "" || len(filter) > 1024 {
+		return false
+	}
+
+	f := form.NewPhotoSearch(filter)
+
+	if err := f.ParseQueryString(); err != nil {
+		return false
+	}
+
+	return f.Album == ""
+}
+
+// ValidAlbumDate returns true if year, month, and day are either unknown or in range. Partial dates like
+// a year or a month and year are valid, so that albums can span a longer period.
+func ValidAlbumDate(year, month, day int) bool {
+	switch {
+	case year < 0 || year > txt.YearMax:
+		return false
+	case month < 0 || month > 12:
+		return false
+	case day < 0 || day > 31:
+		return false
+	case day > 0 && month == 0:
+		return false
+	}
+
+	return true
+}
+
+// ValidParent returns true if the album can be nested in the album with the given UID,
+// which must exist and must not be the album itself or one of its descendants.
+func (m *Album) ValidParent(parentUID string) bool {
+	if parentUID == "" {
+		return true
+	}
+
+	seen := make(map[string]bool)
+
+	for uid := parentUID; uid != ""; {
+		if uid == m.AlbumUID || seen[uid] {
+			return false
+		}
+
+		seen[uid] = true
+
+		var parent Album
+
+		if err := Db().Where("album_uid = ?", uid).First(&parent).Error; err != nil {
+			return false
+		}
+
+		uid = parent.ParentUID
+	}
+
+	return true
+}
+
+// SetParent moves the album into the album with the given UID, or to the top level if empty.
+func (m *Album) SetParent(parentUID string) error {
+	if parentUID == m.ParentUID {
+		return nil
+	}
+
+	if !m.ValidParent(parentUID) {
+		return ErrAlbumParentInvalid
+	}
+
+	m.ParentUID = parentUID
+
+	return m.Save()
+}
+
+// SetCover sets the photo that represents the album in previews. An empty photo UID resets the cover,
+// so that the best photo is used again.
+func (m *Album) SetCover(photoUID string) error {
+	m.CoverUID = photoUID
+
+	return m.Update("CoverUID", photoUID)
+}
+
+// SetStory changes the Markdown story of the album and saves it.
+func (m *Album) SetStory(markdown string) error {
+	m.AlbumStory = strings.TrimSpace(markdown)
+
+	return m.Save()
+}
+
 // Updates a column in the database.
 func (m *Album) Update(attr string, value interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
@@ -109,3 +248,39 @@ func (m *Album) Save() error {
 func (m *Album) Create() error {
 	return Db().Create(m).Error
 }
+
+// DeleteAlbums deletes the albums with the given UIDs including their photo links in a single transaction,
+// and returns the UIDs of the albums that were deleted. Unknown UIDs are ignored.
+func DeleteAlbums(albumUIDs []string) (deleted []string, err error) {
+	deleted = []string{}
+
+	if len(albumUIDs) == 0 {
+		return deleted, nil
+	}
+
+	tx := Db().Begin()
+
+	if err := tx.Model(&Album{}).Where("album_uid IN (?)", albumUIDs).Pluck("album_uid", &deleted).Error; err != nil {
+		tx.Rollback()
+		return deleted, err
+	} else if len(deleted) == 0 {
+		tx.Rollback()
+		return deleted, nil
+	}
+
+	if err := tx.Where("album_uid IN (?)", deleted).Delete(&Album{}).Error; err != nil {
+		tx.Rollback()
+		return []string{}, err
+	}
+
+	if err := tx.Where("album_uid IN (?)", deleted).Delete(&PhotoAlbum{}).Error; err != nil {
+		tx.Rollback()
+		return []string{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return []string{}, err
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,49 @@
+package entity
+
+// AlbumCloneSuffix is appended to the title of cloned albums.
+const AlbumCloneSuffix = " (Copy)"
+
+// Clone creates a copy of the album that contains the same photos in the same order, so that it can be
+// changed without modifying the original. Photos that were removed from the album stay hidden in the copy,
+// and album-specific captions are copied as well.
+func (m *Album) Clone() (*Album, error) {
+	result := NewAlbum(m.AlbumTitle+AlbumCloneSuffix, m.AlbumType)
+
+	result.ParentUID = m.ParentUID
+	result.CoverUID = m.CoverUID
+	result.AlbumCategory = m.AlbumCategory
+	result.AlbumCaption = m.AlbumCaption
+	result.AlbumDescription = m.AlbumDescription
+	result.AlbumFilter = m.AlbumFilter
+	result.AlbumOrder = m.AlbumOrder
+	result.AlbumFavorite = m.AlbumFavorite
+	result.AlbumPrivate = m.AlbumPrivate
+
+	tx := Db().Begin()
+
+	if err := tx.Create(result).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var links []PhotoAlbum
+
+	if err := tx.Where("album_uid = ?", m.AlbumUID).Find(&links).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, l := range links {
+		link := NewPhotoAlbum(l.PhotoUID, result.AlbumUID)
+		link.Order = l.Order
+		link.Hidden = l.Hidden
+		link.Caption = l.Caption
+
+		if err := tx.Create(link).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	return result, tx.Commit().Error
+}
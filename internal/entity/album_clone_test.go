@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbum_Clone(t *testing.T) {
+	orig := NewAlbum("Road Trip", TypeDefault)
+	orig.AlbumDescription = "Route 66"
+	orig.AlbumFavorite = true
+
+	if err := orig.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	first := NewPhotoAlbum("pt9jtdre2lvl0yh7", orig.AlbumUID)
+	first.Order = 2
+	first.Caption = "Grand Canyon at sunrise"
+	second := NewPhotoAlbum("pt9jtdre2lvl0yh8", orig.AlbumUID)
+	second.Order = 1
+	removed := NewPhotoAlbum("pt9jtdre2lvl0y11", orig.AlbumUID)
+	removed.Hidden = true
+
+	for _, l := range []*PhotoAlbum{first, second, removed} {
+		if err := l.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := orig.Clone()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEqual(t, orig.AlbumUID, m.AlbumUID)
+	assert.Equal(t, "Road Trip (Copy)", m.AlbumTitle)
+	assert.Equal(t, "road-trip-copy", m.AlbumSlug)
+	assert.Equal(t, "Route 66", m.AlbumDescription)
+	assert.True(t, m.AlbumFavorite)
+
+	var links []PhotoAlbum
+
+	if err := Db().Where("album_uid = ?", m.AlbumUID).Order("photo_uid").Find(&links).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, links, 3) {
+		assert.Equal(t, "pt9jtdre2lvl0y11", links[0].PhotoUID)
+		assert.True(t, links[0].Hidden)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", links[1].PhotoUID)
+		assert.Equal(t, 2, links[1].Order)
+		assert.Equal(t, "Grand Canyon at sunrise", links[1].Caption)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", links[2].PhotoUID)
+		assert.Equal(t, 1, links[2].Order)
+	}
+
+	var count int
+
+	if err := Db().Model(&PhotoAlbum{}).Where("album_uid = ?", orig.AlbumUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, count)
+}
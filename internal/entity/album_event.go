@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Types of changes recorded in the album activity log.
+const (
+	AlbumEventAdded    = "added"
+	AlbumEventRemoved  = "removed"
+	AlbumEventRenamed  = "renamed"
+	AlbumEventShared   = "shared"
+	AlbumEventUnshared = "unshared"
+	AlbumEventMerged   = "merged"
+	AlbumEventCloned   = "cloned"
+	AlbumEventReaction = "reaction"
+)
+
+// Sources of bulk changes recorded as event info.
+const (
+	AlbumEventCsv   = "csv"
+	AlbumEventRules = "album rules"
+)
+
+// AlbumEvent represents a change of an album in its activity log, so that users of shared libraries can
+// see who modified it. Users are identified by the key returned by their session, see api.sessionUserID().
+type AlbumEvent struct {
+	ID         uint      `gorm:"primary_key" json:"ID"`
+	AlbumUID   string    `gorm:"type:varbinary(36);index;" json:"AlbumUID"`
+	UserID     string    `gorm:"type:varbinary(128);" json:"User"`
+	EventType  string    `gorm:"type:varbinary(16);" json:"Type"`
+	PhotoCount int       `json:"PhotoCount"`
+	EventInfo  string    `gorm:"type:varchar(255);" json:"Info"`
+	CreatedAt  time.Time `json:"CreatedAt"`
+}
+
+// TableName returns AlbumEvent table identifier "album_events".
+func (AlbumEvent) TableName() string {
+	return "album_events"
+}
+
+// AddAlbumEvent records a change of an album, e.g. the number of photos added or the previous title.
+func AddAlbumEvent(albumUID, userID, eventType string, photoCount int, info string) (*AlbumEvent, error) {
+	m := &AlbumEvent{
+		AlbumUID:   albumUID,
+		UserID:     userID,
+		EventType:  eventType,
+		PhotoCount: photoCount,
+		EventInfo:  txt.Clip(info, txt.ClipDefault),
+	}
+
+	return m, Db().Create(m).Error
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumEvent_TableName(t *testing.T) {
+	assert.Equal(t, "album_events", AlbumEvent{}.TableName())
+}
+
+func TestAddAlbumEvent(t *testing.T) {
+	m, err := AddAlbumEvent("at9lxuqxpogaaba8", "admin@example.com", AlbumEventAdded, 3, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+	assert.Equal(t, "at9lxuqxpogaaba8", m.AlbumUID)
+	assert.Equal(t, "admin@example.com", m.UserID)
+	assert.Equal(t, AlbumEventAdded, m.EventType)
+	assert.Equal(t, 3, m.PhotoCount)
+	assert.False(t, m.CreatedAt.IsZero())
+}
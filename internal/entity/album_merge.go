@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+)
+
+// Merge moves the photos of the source albums to the album and deletes the sources. Photos in more than
+// one album are added once, and photos that were removed from a source are not added. Sub albums and album
+// rules of the sources are moved as well, and permalinks of the sources redirected. Share links of the sources
+// are deleted, so that visitors can't see more photos than were shared with them. Captions and the manual
+// order of moved photos are kept. Returns the number of photos added to the album.
+func (m *Album) Merge(sources []Album) (added int, err error) {
+	tx := Db().Begin()
+
+	for _, src := range sources {
+		var links []PhotoAlbum
+
+		if err := tx.Where("album_uid = ? AND hidden = 0", src.AlbumUID).Find(&links).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		for _, l := range links {
+			var existing PhotoAlbum
+
+			if err := tx.Where("photo_uid = ? AND album_uid = ?", l.PhotoUID, m.AlbumUID).First(&existing).Error; err != nil {
+				link := NewPhotoAlbum(l.PhotoUID, m.AlbumUID)
+				link.Order = l.Order
+				link.Hidden = l.Hidden
+				link.Caption = l.Caption
+
+				if err := tx.Create(link).Error; err != nil {
+					tx.Rollback()
+					return 0, err
+				}
+
+				added++
+			} else if existing.Hidden {
+				if err := tx.Model(&existing).UpdateColumn("hidden", false).Error; err != nil {
+					tx.Rollback()
+					return 0, err
+				}
+
+				added++
+			}
+		}
+
+		if err := tx.Where("album_uid = ?", src.AlbumUID).Delete(&PhotoAlbum{}).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if err := tx.Model(&Album{}).Where("parent_uid = ?", src.AlbumUID).UpdateColumn("parent_uid", m.AlbumUID).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if err := tx.Model(&AlbumRule{}).Where("album_uid = ?", src.AlbumUID).UpdateColumn("album_uid", m.AlbumUID).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if err := tx.Where("share_uid = ?", src.AlbumUID).Delete(&Link{}).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if err := tx.Delete(&Album{}, "album_uid = ?", src.AlbumUID).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if err := addRedirect(tx, src.AlbumUID, m.AlbumUID); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if err := tx.Model(m).UpdateColumn("updated_at", time.Now().UTC()).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return added, tx.Commit().Error
+}
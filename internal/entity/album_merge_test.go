@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbum_Merge(t *testing.T) {
+	target := NewAlbum("Summer", TypeDefault)
+	beach := NewAlbum("Beach", TypeDefault)
+	lake := NewAlbum("Lake", TypeDefault)
+
+	for _, a := range []*Album{target, beach, lake} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sub := NewAlbum("Sunsets", TypeDefault)
+	sub.ParentUID = beach.AlbumUID
+
+	if err := sub.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	links := []*PhotoAlbum{
+		NewPhotoAlbum("pt9jtdre2lvl0yh7", target.AlbumUID),
+		NewPhotoAlbum("pt9jtdre2lvl0yh7", beach.AlbumUID),
+		NewPhotoAlbum("pt9jtdre2lvl0y11", beach.AlbumUID),
+		NewPhotoAlbum("pt9jtdre2lvl0y11", lake.AlbumUID),
+		NewPhotoAlbum("pt9jtdre2lvl0yh8", lake.AlbumUID),
+	}
+
+	removed := NewPhotoAlbum("pt9jtdre2lvl0y22", lake.AlbumUID)
+	removed.Hidden = true
+	links = append(links, removed)
+
+	for _, l := range links {
+		if err := l.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	share := NewLink("", false, false)
+	share.ShareUID = beach.AlbumUID
+
+	if err := Db().Create(&share).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := target.Merge([]Album{*beach, *lake})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, added)
+
+	var photos []string
+
+	if err := Db().Model(&PhotoAlbum{}).Where("album_uid = ? AND hidden = 0", target.AlbumUID).Order("photo_uid").Pluck("photo_uid", &photos).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, photos)
+
+	var count int
+
+	if err := Db().Model(&PhotoAlbum{}).Where("album_uid IN (?)", []string{beach.AlbumUID, lake.AlbumUID}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+
+	if err := Db().Model(&Album{}).Where("album_uid IN (?)", []string{beach.AlbumUID, lake.AlbumUID}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+
+	var child Album
+
+	if err := Db().Where("album_uid = ?", sub.AlbumUID).First(&child).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, target.AlbumUID, child.ParentUID)
+
+	var redirect Redirect
+
+	if err := Db().Where("from_uid = ?", beach.AlbumUID).First(&redirect).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, target.AlbumUID, redirect.ToUID)
+
+	if err := Db().Model(&Link{}).Where("share_uid = ?", beach.AlbumUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+}
+
+func TestAlbum_Merge_Caption(t *testing.T) {
+	target := NewAlbum("Wedding", TypeDefault)
+	src := NewAlbum("Wedding Party", TypeDefault)
+
+	for _, a := range []*Album{target, src} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	link := NewPhotoAlbum("pt9jtdre2lvl0yh7", src.AlbumUID)
+	link.Caption = "Cutting the cake"
+	link.Order = 3
+
+	if err := link.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := target.Merge([]Album{*src})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, added)
+
+	var result PhotoAlbum
+
+	if err := Db().Where("photo_uid = ? AND album_uid = ?", link.PhotoUID, target.AlbumUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Cutting the cake", result.Caption)
+	assert.Equal(t, 3, result.Order)
+	assert.False(t, result.Hidden)
+}
@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// AlbumRuleInput contains the properties of an imported photo that album rules are matched against.
+type AlbumRuleInput struct {
+	Folder       string // Folder relative to the import path.
+	TakenAt      time.Time
+	CameraSerial string
+	Uploader     string
+}
+
+// AlbumRule represents a rule that adds imported photos to an album if all of its conditions match.
+type AlbumRule struct {
+	ID               uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	RuleUID          string     `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	AlbumUID         string     `gorm:"type:varbinary(36);index;" json:"AlbumUID" yaml:"AlbumUID"`
+	RuleName         string     `gorm:"type:varchar(255);" json:"Name" yaml:"Name,omitempty"`
+	RuleFolder       string     `gorm:"type:varchar(255);" json:"Folder" yaml:"Folder,omitempty"`
+	RuleAfter        *time.Time `json:"After" yaml:"After,omitempty"`
+	RuleBefore       *time.Time `json:"Before" yaml:"Before,omitempty"`
+	RuleCameraSerial string     `gorm:"type:varbinary(255);" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
+	RuleUploader     string     `gorm:"type:varchar(255);" json:"Uploader" yaml:"Uploader,omitempty"`
+	CreatedAt        time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt        time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// AlbumRules represents a list of album rules.
+type AlbumRules []AlbumRule
+
+// TableName returns AlbumRule table identifier "album_rules".
+func (AlbumRule) TableName() string {
+	return "album_rules"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *AlbumRule) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.RuleUID, 'e') {
+		return nil
+	}
+
+	return scope.SetColumn("RuleUID", rnd.PPID('e'))
+}
+
+// NewAlbumRule creates an album rule from form values.
+func NewAlbumRule(f form.AlbumRule) (*AlbumRule, error) {
+	m := &AlbumRule{RuleUID: rnd.PPID('e')}
+
+	if err := m.SetForm(f); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SetForm updates the album and conditions after validating them.
+func (m *AlbumRule) SetForm(f form.AlbumRule) error {
+	if !rnd.IsUID(f.AlbumUID, 'a') {
+		return errors.New("album rule: invalid album uid")
+	}
+
+	folder := strings.ToLower(strings.Trim(filepath.ToSlash(strings.TrimSpace(f.Folder)), "/"))
+
+	if _, err := path.Match(folder, ""); err != nil {
+		return errors.New("album rule: invalid folder pattern")
+	}
+
+	if f.After != nil && f.Before != nil && !f.Before.After(*f.After) {
+		return errors.New("album rule: date range is empty")
+	}
+
+	m.AlbumUID = f.AlbumUID
+	m.RuleName = txt.Clip(f.Name, txt.ClipDefault)
+	m.RuleFolder = txt.Clip(folder, txt.ClipDefault)
+	m.RuleAfter = f.After
+	m.RuleBefore = f.Before
+	m.RuleCameraSerial = txt.Clip(f.CameraSerial, txt.ClipDefault)
+	m.RuleUploader = txt.Clip(f.Uploader, txt.ClipDefault)
+
+	if !m.HasConditions() {
+		return errors.New("album rule: no conditions")
+	}
+
+	return nil
+}
+
+// HasConditions returns true if at least one condition is set, so that not all photos match.
+func (m *AlbumRule) HasConditions() bool {
+	return m.RuleFolder != "" || m.RuleAfter != nil || m.RuleBefore != nil || m.RuleCameraSerial != "" || m.RuleUploader != ""
+}
+
+// Match returns true if the imported photo matches all conditions.
+func (m *AlbumRule) Match(in AlbumRuleInput) bool {
+	if !m.HasConditions() {
+		return false
+	}
+
+	if m.RuleFolder != "" && !matchFolder(m.RuleFolder, in.Folder) {
+		return false
+	}
+
+	if m.RuleAfter != nil && in.TakenAt.Before(*m.RuleAfter) {
+		return false
+	}
+
+	if m.RuleBefore != nil && !in.TakenAt.Before(*m.RuleBefore) {
+		return false
+	}
+
+	if m.RuleCameraSerial != "" && !strings.EqualFold(m.RuleCameraSerial, strings.TrimSpace(in.CameraSerial)) {
+		return false
+	}
+
+	if m.RuleUploader != "" && !strings.EqualFold(m.RuleUploader, strings.TrimSpace(in.Uploader)) {
+		return false
+	}
+
+	return true
+}
+
+// matchFolder returns true if the pattern matches the folder path or, if it contains
+// no slash, the name of any folder in it.
+func matchFolder(pattern, folder string) bool {
+	folder = strings.ToLower(strings.Trim(filepath.ToSlash(folder), "/"))
+
+	if folder == "." {
+		folder = ""
+	}
+
+	if ok, _ := path.Match(pattern, folder); ok {
+		return true
+	} else if strings.Contains(pattern, "/") {
+		return false
+	}
+
+	for _, name := range strings.Split(folder, "/") {
+		if ok, _ := path.Match(pattern, name); ok && name != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Albums returns the UIDs of the albums the imported photo should be added to.
+func (list AlbumRules) Albums(in AlbumRuleInput) (albums []string) {
+	done := make(map[string]bool)
+
+	for _, rule := range list {
+		if done[rule.AlbumUID] || !rule.Match(in) {
+			continue
+		}
+
+		done[rule.AlbumUID] = true
+		albums = append(albums, rule.AlbumUID)
+	}
+
+	return albums
+}
+
+// Create inserts a new row to the database.
+func (m *AlbumRule) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new row.
+func (m *AlbumRule) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the album rule.
+func (m *AlbumRule) Delete() error {
+	return Db().Delete(m).Error
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAlbumRule(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := NewAlbumRule(form.AlbumRule{Name: "Drone", AlbumUID: "at9lxuqxpogaaba7", Folder: "/DJI*/", CameraSerial: "0K1234"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Drone", m.RuleName)
+		assert.Equal(t, "dji*", m.RuleFolder)
+		assert.Equal(t, "0K1234", m.RuleCameraSerial)
+	})
+	t.Run("invalid album", func(t *testing.T) {
+		_, err := NewAlbumRule(form.AlbumRule{AlbumUID: "xxx", Folder: "dji"})
+		assert.EqualError(t, err, "album rule: invalid album uid")
+	})
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewAlbumRule(form.AlbumRule{AlbumUID: "at9lxuqxpogaaba7", Folder: "dji["})
+		assert.EqualError(t, err, "album rule: invalid folder pattern")
+	})
+	t.Run("empty range", func(t *testing.T) {
+		after := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+		_, err := NewAlbumRule(form.AlbumRule{AlbumUID: "at9lxuqxpogaaba7", After: &after, Before: &before})
+		assert.EqualError(t, err, "album rule: date range is empty")
+	})
+	t.Run("no conditions", func(t *testing.T) {
+		_, err := NewAlbumRule(form.AlbumRule{Name: "All", AlbumUID: "at9lxuqxpogaaba7"})
+		assert.EqualError(t, err, "album rule: no conditions")
+	})
+}
+
+func TestAlbumRule_Match(t *testing.T) {
+	after := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	takenAt := time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("folder name", func(t *testing.T) {
+		m := AlbumRule{RuleFolder: "dji*"}
+		assert.True(t, m.Match(AlbumRuleInput{Folder: "2020/DJI_Flight"}))
+		assert.True(t, m.Match(AlbumRuleInput{Folder: "dji"}))
+		assert.False(t, m.Match(AlbumRuleInput{Folder: "2020/Phone"}))
+		assert.False(t, m.Match(AlbumRuleInput{Folder: ""}))
+	})
+	t.Run("folder path", func(t *testing.T) {
+		m := AlbumRule{RuleFolder: "upload/*"}
+		assert.True(t, m.Match(AlbumRuleInput{Folder: "upload/4d5e6f"}))
+		assert.False(t, m.Match(AlbumRuleInput{Folder: "2020/upload/4d5e6f"}))
+	})
+	t.Run("date range", func(t *testing.T) {
+		m := AlbumRule{RuleAfter: &after, RuleBefore: &before}
+		assert.True(t, m.Match(AlbumRuleInput{TakenAt: takenAt}))
+		assert.True(t, m.Match(AlbumRuleInput{TakenAt: after}))
+		assert.False(t, m.Match(AlbumRuleInput{TakenAt: before}))
+		assert.False(t, m.Match(AlbumRuleInput{TakenAt: after.Add(-time.Second)}))
+	})
+	t.Run("camera and uploader", func(t *testing.T) {
+		m := AlbumRule{RuleCameraSerial: "0k1234", RuleUploader: "jane@example.com"}
+		assert.True(t, m.Match(AlbumRuleInput{CameraSerial: "0K1234", Uploader: "Jane@example.com"}))
+		assert.False(t, m.Match(AlbumRuleInput{CameraSerial: "0K1234"}))
+		assert.False(t, m.Match(AlbumRuleInput{CameraSerial: "0K9999", Uploader: "jane@example.com"}))
+	})
+	t.Run("no conditions", func(t *testing.T) {
+		m := AlbumRule{}
+		assert.False(t, m.Match(AlbumRuleInput{Folder: "dji", TakenAt: takenAt}))
+	})
+}
+
+func TestAlbumRules_Albums(t *testing.T) {
+	rules := AlbumRules{
+		{AlbumUID: "at9lxuqxpogaaba7", RuleFolder: "dji*"},
+		{AlbumUID: "at9lxuqxpogaaba7", RuleCameraSerial: "0K1234"},
+		{AlbumUID: "at9lxuqxpogaaba8", RuleCameraSerial: "0K1234"},
+		{AlbumUID: "at9lxuqxpogaaba9", RuleUploader: "jane@example.com"},
+	}
+
+	assert.Equal(t, []string{"at9lxuqxpogaaba7", "at9lxuqxpogaaba8"}, rules.Albums(AlbumRuleInput{Folder: "DJI", CameraSerial: "0K1234"}))
+	assert.Empty(t, rules.Albums(AlbumRuleInput{Folder: "Phone"}))
+}
+
+func TestAlbumRule_Create(t *testing.T) {
+	m, err := NewAlbumRule(form.AlbumRule{Name: "Aerial", AlbumUID: "at9lxuqxpogaaba8", Folder: "drone"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+
+	m.RuleUploader = "jane@example.com"
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
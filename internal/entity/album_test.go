@@ -89,3 +89,250 @@ func TestAlbum_Save(t *testing.T) {
 	})
 
 }
+
+func TestAlbum_VersionTag(t *testing.T) {
+	album := NewAlbum("Versioned", TypeDefault)
+
+	assert.Equal(t, `"0"`, album.VersionTag())
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `"1"`, album.VersionTag())
+
+	if err := album.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `"2"`, album.VersionTag())
+
+	if err := album.Update("AlbumFavorite", true); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint(2), album.AlbumVersion)
+}
+
+func TestAlbum_SetParent(t *testing.T) {
+	parent := NewAlbum("Parent Album", TypeDefault)
+	child := NewAlbum("Child Album", TypeDefault)
+
+	for _, a := range []*Album{parent, child} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.NoError(t, child.SetParent(parent.AlbumUID))
+	assert.Equal(t, parent.AlbumUID, child.ParentUID)
+	assert.Equal(t, ErrAlbumParentInvalid, parent.SetParent(child.AlbumUID))
+	assert.Equal(t, "", parent.ParentUID)
+	assert.NoError(t, child.SetParent(""))
+	assert.Equal(t, "", child.ParentUID)
+}
+
+func TestAlbum_SetCover(t *testing.T) {
+	album := NewAlbum("Cover Album", TypeDefault)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("photo", func(t *testing.T) {
+		if err := album.SetCover("pt9jtdre2lvl0yh7"); err != nil {
+			t.Fatal(err)
+		}
+
+		var result Album
+
+		if err := Db().First(&result, "album_uid = ?", album.AlbumUID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", result.CoverUID)
+	})
+	t.Run("reset", func(t *testing.T) {
+		if err := album.SetCover(""); err != nil {
+			t.Fatal(err)
+		}
+
+		var result Album
+
+		if err := Db().First(&result, "album_uid = ?", album.AlbumUID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", result.CoverUID)
+	})
+}
+
+func TestAlbum_ValidParent(t *testing.T) {
+	parent := NewAlbum("Parent", TypeDefault)
+	child := NewAlbum("Child", TypeDefault)
+
+	if err := parent.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	child.ParentUID = parent.AlbumUID
+
+	if err := child.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, child.ValidParent(""))
+	assert.True(t, child.ValidParent(parent.AlbumUID))
+	assert.False(t, child.ValidParent(child.AlbumUID))
+	assert.False(t, parent.ValidParent(child.AlbumUID))
+	assert.False(t, parent.ValidParent("at9lxuqxpogaaxxx"))
+
+	f, err := form.NewAlbum(parent)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.ParentUID = child.AlbumUID
+
+	assert.Equal(t, ErrAlbumParentInvalid, parent.SaveForm(f))
+}
+
+func TestAlbum_HasFilter(t *testing.T) {
+	album := NewAlbum("Smart", TypeMoment)
+	assert.False(t, album.HasFilter())
+
+	album.AlbumFilter = "label:flower"
+	assert.True(t, album.HasFilter())
+
+	album.AlbumType = TypeDefault
+	assert.False(t, album.HasFilter())
+}
+
+func TestValidAlbumFilter(t *testing.T) {
+	assert.True(t, ValidAlbumFilter("label:flower year:2020"))
+	assert.True(t, ValidAlbumFilter("beach"))
+	assert.False(t, ValidAlbumFilter(""))
+	assert.False(t, ValidAlbumFilter("foo:bar"))
+	assert.False(t, ValidAlbumFilter("album:at9lxuqxpogaaba8"))
+}
+
+func TestAlbum_SaveForm_Filter(t *testing.T) {
+	album := NewAlbum("Smart Form", TypeDefault)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := form.NewAlbum(album)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.AlbumType = TypeMoment
+	f.AlbumFilter = "foo:bar"
+
+	assert.Equal(t, ErrAlbumFilterInvalid, album.SaveForm(f))
+
+	f.AlbumFilter = "favorite:true"
+
+	if err := album.SaveForm(f); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, album.HasFilter())
+}
+
+func TestAlbum_SaveForm_Date(t *testing.T) {
+	album := NewAlbum("Road Trip", TypeDefault)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := form.NewAlbum(album)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.AlbumMonth = 13
+
+	assert.Equal(t, ErrAlbumDateInvalid, album.SaveForm(f))
+
+	f.AlbumLocation = "Route 66"
+	f.AlbumYear = 2019
+	f.AlbumMonth = 8
+	f.AlbumDay = 12
+
+	if err := album.SaveForm(f); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Route 66", album.AlbumLocation)
+	assert.Equal(t, 2019, album.AlbumYear)
+	assert.Equal(t, 8, album.AlbumMonth)
+	assert.Equal(t, 12, album.AlbumDay)
+}
+
+func TestValidAlbumDate(t *testing.T) {
+	assert.True(t, ValidAlbumDate(0, 0, 0))
+	assert.True(t, ValidAlbumDate(2019, 0, 0))
+	assert.True(t, ValidAlbumDate(2019, 8, 0))
+	assert.True(t, ValidAlbumDate(2019, 8, 12))
+	assert.True(t, ValidAlbumDate(0, 12, 24))
+	assert.False(t, ValidAlbumDate(-1, 0, 0))
+	assert.False(t, ValidAlbumDate(2019, 13, 0))
+	assert.False(t, ValidAlbumDate(2019, 8, 32))
+	assert.False(t, ValidAlbumDate(2019, 0, 12))
+}
+
+func TestDeleteAlbums(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		first := NewAlbum("Import 1", TypeDefault)
+		second := NewAlbum("Import 2", TypeDefault)
+
+		for _, a := range []*Album{first, second} {
+			if err := a.Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := NewPhotoAlbum("pt9jtdre2lvl0yh7", first.AlbumUID).Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		deleted, err := DeleteAlbums([]string{first.AlbumUID, second.AlbumUID, "at9lxuqxpogaxxxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{first.AlbumUID, second.AlbumUID}, deleted)
+
+		var count int
+
+		if err := Db().Model(&Album{}).Where("album_uid IN (?)", deleted).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+
+		if err := Db().Model(&PhotoAlbum{}).Where("album_uid = ?", first.AlbumUID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+	})
+	t.Run("not found", func(t *testing.T) {
+		deleted, err := DeleteAlbums([]string{"at9lxuqxpogaxxxx"})
+		assert.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+	t.Run("empty", func(t *testing.T) {
+		deleted, err := DeleteAlbums(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+}
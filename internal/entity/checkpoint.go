@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Checkpoint represents a point-in-time copy of library metadata like titles, labels and album contents,
+// so that changes caused by bad batch edits or a misbehaving client can be reviewed and restored.
+// The metadata itself is stored in a compressed file, see FileName().
+type Checkpoint struct {
+	ID             uint      `gorm:"primary_key" json:"-"`
+	CheckpointUID  string    `gorm:"type:varbinary(36);unique_index;" json:"UID"`
+	CheckpointNote string    `gorm:"type:varchar(255);" json:"Note"`
+	PhotoCount     int       `json:"PhotoCount"`
+	AlbumCount     int       `json:"AlbumCount"`
+	CreatedAt      time.Time `json:"CreatedAt"`
+}
+
+// NewCheckpoint returns a new checkpoint with an optional note.
+func NewCheckpoint(note string) *Checkpoint {
+	return &Checkpoint{
+		CheckpointUID:  rnd.PPID('k'),
+		CheckpointNote: txt.Clip(note, txt.ClipDefault),
+	}
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Checkpoint) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.CheckpointUID, 'k') {
+		return nil
+	}
+
+	return scope.SetColumn("CheckpointUID", rnd.PPID('k'))
+}
+
+// FileName returns the base name of the checkpoint file.
+func (m *Checkpoint) FileName() string {
+	return m.CheckpointUID + ".json.gz"
+}
+
+// Create inserts a new row to the database.
+func (m *Checkpoint) Create() error {
+	return Db().Create(m).Error
+}
+
+// Delete removes the row from the database, the checkpoint file must be deleted separately.
+func (m *Checkpoint) Delete() error {
+	return Db().Delete(m).Error
+}
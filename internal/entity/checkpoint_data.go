@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// Types of checkpoint changes.
+const (
+	CheckpointPhoto = "photo"
+	CheckpointAlbum = "album"
+)
+
+// Fields that can be restored from a checkpoint.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldFavorite    = "favorite"
+	FieldPrivate     = "private"
+	FieldLabels      = "labels"
+	FieldAlbums      = "albums"
+)
+
+// CheckpointData represents the library metadata saved in a checkpoint, photos and albums are keyed by UID.
+type CheckpointData struct {
+	CreatedAt time.Time                      `json:"CreatedAt"`
+	Photos    map[string]CheckpointPhotoData `json:"Photos"`
+	Albums    map[string]CheckpointAlbumData `json:"Albums"`
+}
+
+// CheckpointPhotoData represents the metadata of a photo, Albums contains the UIDs of albums it is in.
+type CheckpointPhotoData struct {
+	Title          string                `json:"Title"`
+	TitleSrc       string                `json:"TitleSrc,omitempty"`
+	Description    string                `json:"Description,omitempty"`
+	DescriptionSrc string                `json:"DescriptionSrc,omitempty"`
+	Favorite       bool                  `json:"Favorite,omitempty"`
+	Private        bool                  `json:"Private,omitempty"`
+	Labels         []CheckpointLabelData `json:"Labels,omitempty"`
+	Albums         []string              `json:"Albums,omitempty"`
+}
+
+// CheckpointLabelData represents a label assigned to a photo.
+type CheckpointLabelData struct {
+	UID         string `json:"UID"`
+	Uncertainty int    `json:"Uncertainty"`
+	Src         string `json:"Src"`
+}
+
+// CheckpointAlbumData represents the metadata of an album.
+type CheckpointAlbumData struct {
+	Title       string `json:"Title"`
+	Description string `json:"Description,omitempty"`
+}
+
+// CheckpointChange represents a field that was changed since a checkpoint was created.
+type CheckpointChange struct {
+	Type   string      `json:"Type"`
+	UID    string      `json:"UID"`
+	Field  string      `json:"Field"`
+	Before interface{} `json:"Before"`
+	After  interface{} `json:"After"`
+}
+
+// NewCheckpointData returns empty checkpoint data.
+func NewCheckpointData() CheckpointData {
+	return CheckpointData{
+		CreatedAt: time.Now().UTC(),
+		Photos:    make(map[string]CheckpointPhotoData),
+		Albums:    make(map[string]CheckpointAlbumData),
+	}
+}
+
+// LabelUIDs returns the sorted UIDs of labels assigned to the photo.
+func (p CheckpointPhotoData) LabelUIDs() []string {
+	result := make([]string, 0, len(p.Labels))
+
+	for _, l := range p.Labels {
+		result = append(result, l.UID)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Diff returns the fields that have been changed in current compared to the checkpoint, ordered by type and UID.
+// Photos and albums that were added or deleted since are ignored, as they can't be restored.
+func (d CheckpointData) Diff(current CheckpointData) (changes []CheckpointChange) {
+	changes = []CheckpointChange{}
+
+	albumUIDs := make([]string, 0, len(d.Albums))
+
+	for uid := range d.Albums {
+		albumUIDs = append(albumUIDs, uid)
+	}
+
+	sort.Strings(albumUIDs)
+
+	for _, uid := range albumUIDs {
+		before := d.Albums[uid]
+		after, ok := current.Albums[uid]
+
+		if !ok {
+			continue
+		}
+
+		if before.Title != after.Title {
+			changes = append(changes, CheckpointChange{CheckpointAlbum, uid, FieldTitle, before.Title, after.Title})
+		}
+
+		if before.Description != after.Description {
+			changes = append(changes, CheckpointChange{CheckpointAlbum, uid, FieldDescription, before.Description, after.Description})
+		}
+	}
+
+	photoUIDs := make([]string, 0, len(d.Photos))
+
+	for uid := range d.Photos {
+		photoUIDs = append(photoUIDs, uid)
+	}
+
+	sort.Strings(photoUIDs)
+
+	for _, uid := range photoUIDs {
+		before := d.Photos[uid]
+		after, ok := current.Photos[uid]
+
+		if !ok {
+			continue
+		}
+
+		if before.Title != after.Title {
+			changes = append(changes, CheckpointChange{CheckpointPhoto, uid, FieldTitle, before.Title, after.Title})
+		}
+
+		if before.Description != after.Description {
+			changes = append(changes, CheckpointChange{CheckpointPhoto, uid, FieldDescription, before.Description, after.Description})
+		}
+
+		if before.Favorite != after.Favorite {
+			changes = append(changes, CheckpointChange{CheckpointPhoto, uid, FieldFavorite, before.Favorite, after.Favorite})
+		}
+
+		if before.Private != after.Private {
+			changes = append(changes, CheckpointChange{CheckpointPhoto, uid, FieldPrivate, before.Private, after.Private})
+		}
+
+		if b, a := before.LabelUIDs(), after.LabelUIDs(); !equalStrings(b, a) {
+			changes = append(changes, CheckpointChange{CheckpointPhoto, uid, FieldLabels, b, a})
+		}
+
+		if b, a := sortedStrings(before.Albums), sortedStrings(after.Albums); !equalStrings(b, a) {
+			changes = append(changes, CheckpointChange{CheckpointPhoto, uid, FieldAlbums, b, a})
+		}
+	}
+
+	return changes
+}
+
+// sortedStrings returns a sorted copy of a string slice, never nil.
+func sortedStrings(s []string) []string {
+	result := make([]string, len(s))
+	copy(result, s)
+	sort.Strings(result)
+
+	return result
+}
+
+// equalStrings returns true if both slices contain the same strings in the same order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointData_Diff(t *testing.T) {
+	before := NewCheckpointData()
+	before.Photos["pt9jtdre2lvl0yh7"] = CheckpointPhotoData{
+		Title:  "Lake",
+		Labels: []CheckpointLabelData{{UID: "lt9k3pw1wowuy3c2", Uncertainty: 20, Src: SrcImage}, {UID: "lt9k3pw1wowuy3c3", Uncertainty: 0, Src: SrcManual}},
+		Albums: []string{"at9lxuqxpogaaba8", "at9lxuqxpogaaba7"},
+	}
+	before.Photos["pt9jtdre2lvl0yh8"] = CheckpointPhotoData{Title: "Deleted"}
+	before.Albums["at9lxuqxpogaaba7"] = CheckpointAlbumData{Title: "Christmas", Description: "Family"}
+
+	t.Run("unchanged", func(t *testing.T) {
+		current := NewCheckpointData()
+		current.Photos["pt9jtdre2lvl0yh7"] = CheckpointPhotoData{
+			Title:  "Lake",
+			Labels: []CheckpointLabelData{{UID: "lt9k3pw1wowuy3c3", Uncertainty: 0, Src: SrcManual}, {UID: "lt9k3pw1wowuy3c2", Uncertainty: 20, Src: SrcImage}},
+			Albums: []string{"at9lxuqxpogaaba7", "at9lxuqxpogaaba8"},
+		}
+		current.Albums["at9lxuqxpogaaba7"] = CheckpointAlbumData{Title: "Christmas", Description: "Family"}
+
+		assert.Empty(t, before.Diff(current))
+	})
+	t.Run("changed", func(t *testing.T) {
+		current := NewCheckpointData()
+		current.Photos["pt9jtdre2lvl0yh7"] = CheckpointPhotoData{
+			Title:    "Sea",
+			Favorite: true,
+			Labels:   []CheckpointLabelData{{UID: "lt9k3pw1wowuy3c2", Uncertainty: 20, Src: SrcImage}},
+		}
+		current.Photos["pt9jtdre2lvl0yh9"] = CheckpointPhotoData{Title: "Added"}
+		current.Albums["at9lxuqxpogaaba7"] = CheckpointAlbumData{Title: "Xmas", Description: "Family"}
+
+		changes := before.Diff(current)
+
+		if !assert.Len(t, changes, 5) {
+			return
+		}
+
+		assert.Equal(t, CheckpointChange{CheckpointAlbum, "at9lxuqxpogaaba7", FieldTitle, "Christmas", "Xmas"}, changes[0])
+		assert.Equal(t, CheckpointChange{CheckpointPhoto, "pt9jtdre2lvl0yh7", FieldTitle, "Lake", "Sea"}, changes[1])
+		assert.Equal(t, CheckpointChange{CheckpointPhoto, "pt9jtdre2lvl0yh7", FieldFavorite, false, true}, changes[2])
+		assert.Equal(t, CheckpointChange{CheckpointPhoto, "pt9jtdre2lvl0yh7", FieldLabels, []string{"lt9k3pw1wowuy3c2", "lt9k3pw1wowuy3c3"}, []string{"lt9k3pw1wowuy3c2"}}, changes[3])
+		assert.Equal(t, CheckpointChange{CheckpointPhoto, "pt9jtdre2lvl0yh7", FieldAlbums, []string{"at9lxuqxpogaaba7", "at9lxuqxpogaaba8"}, []string{}}, changes[4])
+	})
+}
+
+func TestNewCheckpoint(t *testing.T) {
+	m := NewCheckpoint("Before batch edit")
+
+	assert.Equal(t, "Before batch edit", m.CheckpointNote)
+	assert.Equal(t, m.CheckpointUID+".json.gz", m.FileName())
+	assert.Equal(t, byte('k'), m.CheckpointUID[0])
+}
@@ -11,6 +11,7 @@ const (
 	SrcMeta     = "meta"
 	SrcXmp      = "xmp"
 	SrcYaml     = "yaml"
+	SrcDevice   = "device"
 	SrcLocation = classify.SrcLocation
 	SrcImage    = classify.SrcImage
 
@@ -21,6 +22,9 @@ const (
 	SortOrderImported  = "imported"
 	SortOrderSimilar   = "similar"
 	SortOrderName      = "name"
+	SortOrderSnapshot  = "snapshot"
+	SortOrderDistance  = "distance"
+	SortOrderAlbum     = "album"
 
 	// unknown values
 	YearUnknown  = -1
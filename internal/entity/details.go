@@ -1,14 +1,24 @@
 package entity
 
+import (
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
 // Details stores additional metadata fields for each photo to improve search performance.
 type Details struct {
-	PhotoID   uint   `gorm:"primary_key;auto_increment:false" yaml:"-"`
-	Keywords  string `gorm:"type:text;" json:"Keywords" yaml:"Keywords"`
-	Notes     string `gorm:"type:text;" json:"Notes" yaml:"Notes,omitempty"`
-	Subject   string `gorm:"type:varchar(255);" json:"Subject" yaml:"Subject,omitempty"`
-	Artist    string `gorm:"type:varchar(255);" json:"Artist" yaml:"Artist,omitempty"`
-	Copyright string `gorm:"type:varchar(255);" json:"Copyright" yaml:"Copyright,omitempty"`
-	License   string `gorm:"type:varchar(255);" json:"License" yaml:"License,omitempty"`
+	PhotoID      uint   `gorm:"primary_key;auto_increment:false" yaml:"-"`
+	Keywords     string `gorm:"type:text;" json:"Keywords" yaml:"Keywords"`
+	Notes        string `gorm:"type:text;" json:"Notes" yaml:"Notes,omitempty"`
+	Subject      string `gorm:"type:varchar(255);" json:"Subject" yaml:"Subject,omitempty"`
+	Artist       string `gorm:"type:varchar(255);" json:"Artist" yaml:"Artist,omitempty"`
+	Copyright    string `gorm:"type:varchar(255);" json:"Copyright" yaml:"Copyright,omitempty"`
+	License      string `gorm:"type:varchar(255);" json:"License" yaml:"License,omitempty"`
+	Attribution  string `gorm:"type:varchar(255);" json:"Attribution" yaml:"Attribution,omitempty"`
+	Credit       string `gorm:"type:varchar(255);" json:"Credit" yaml:"Credit,omitempty"`
+	NotesSrc     string `gorm:"type:varbinary(8);" json:"NotesSrc" yaml:"NotesSrc,omitempty"`
+	SubjectSrc   string `gorm:"type:varbinary(8);" json:"SubjectSrc" yaml:"SubjectSrc,omitempty"`
+	ArtistSrc    string `gorm:"type:varbinary(8);" json:"ArtistSrc" yaml:"ArtistSrc,omitempty"`
+	CopyrightSrc string `gorm:"type:varbinary(8);" json:"CopyrightSrc" yaml:"CopyrightSrc,omitempty"`
 }
 
 // Create inserts a new row to the database.
@@ -16,6 +26,11 @@ func (m *Details) Create() error {
 	return Db().Create(m).Error
 }
 
+// Save updates the existing or inserts a new row.
+func (m *Details) Save() error {
+	return Db().Save(m).Error
+}
+
 // FirstOrCreateDetails returns the existing row, inserts a new row or nil in case of errors.
 func FirstOrCreateDetails(m *Details) *Details {
 	result := Details{}
@@ -54,3 +69,72 @@ func (m *Details) NoArtist() bool {
 func (m *Details) NoCopyright() bool {
 	return m.Copyright == ""
 }
+
+// NoLicense checks if the photo has no License
+func (m *Details) NoLicense() bool {
+	return m.License == ""
+}
+
+// SetLicense updates license, attribution and credit, empty values are ignored.
+func (m *Details) SetLicense(license, attribution, credit string) {
+	if license != "" {
+		m.License = txt.Clip(license, txt.ClipDefault)
+	}
+
+	if attribution != "" {
+		m.Attribution = txt.Clip(attribution, txt.ClipDefault)
+	}
+
+	if credit != "" {
+		m.Credit = txt.Clip(credit, txt.ClipDefault)
+	}
+}
+
+// srcAllows tests if a value from the given source may replace the current value.
+func srcAllows(currentSrc, currentValue, source string) bool {
+	if currentSrc == SrcManual && source != SrcManual {
+		return false
+	}
+
+	return currentSrc == SrcAuto || currentSrc == source || source == SrcManual || currentValue == ""
+}
+
+// SetNotes changes the notes unless they have been edited manually or were set by another source.
+func (m *Details) SetNotes(notes, source string) {
+	if notes == "" || !srcAllows(m.NotesSrc, m.Notes, source) {
+		return
+	}
+
+	m.Notes = notes
+	m.NotesSrc = source
+}
+
+// SetSubject changes the subject unless it has been edited manually or was set by another source.
+func (m *Details) SetSubject(subject, source string) {
+	if subject == "" || !srcAllows(m.SubjectSrc, m.Subject, source) {
+		return
+	}
+
+	m.Subject = txt.Clip(subject, txt.ClipDefault)
+	m.SubjectSrc = source
+}
+
+// SetArtist changes the artist unless it has been edited manually or was set by another source.
+func (m *Details) SetArtist(artist, source string) {
+	if artist == "" || !srcAllows(m.ArtistSrc, m.Artist, source) {
+		return
+	}
+
+	m.Artist = txt.Clip(artist, txt.ClipDefault)
+	m.ArtistSrc = source
+}
+
+// SetCopyright changes the copyright unless it has been edited manually or was set by another source.
+func (m *Details) SetCopyright(copyright, source string) {
+	if copyright == "" || !srcAllows(m.CopyrightSrc, m.Copyright, source) {
+		return
+	}
+
+	m.Copyright = txt.Clip(copyright, txt.ClipDefault)
+	m.CopyrightSrc = source
+}
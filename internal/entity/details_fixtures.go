@@ -22,13 +22,15 @@ func (m DetailsMap) Pointer(name string, photoId uint) *Details {
 
 var DetailsFixtures = DetailsMap{
 	"lake": {
-		PhotoID:   1000000,
-		Keywords:  "nature, frog",
-		Notes:     "notes",
-		Subject:   "Lake",
-		Artist:    "Hans",
-		Copyright: "copy",
-		License:   "MIT",
+		PhotoID:     1000000,
+		Keywords:    "nature, frog",
+		Notes:       "notes",
+		Subject:     "Lake",
+		Artist:      "Hans",
+		Copyright:   "copy",
+		License:     "MIT",
+		Attribution: "Photo by Hans",
+		Credit:      "PhotoPrism",
 	},
 	"blacklist": {
 		PhotoID:   1000001,
@@ -78,3 +78,84 @@ func TestDetails_NoCopyright(t *testing.T) {
 		assert.Equal(t, false, description.NoCopyright())
 	})
 }
+
+func TestDetails_NoLicense(t *testing.T) {
+	t.Run("no license", func(t *testing.T) {
+		description := &Details{PhotoID: 123, License: ""}
+
+		assert.Equal(t, true, description.NoLicense())
+	})
+	t.Run("license", func(t *testing.T) {
+		description := &Details{PhotoID: 123, Artist: "Bender", License: "CC BY 4.0"}
+
+		assert.Equal(t, false, description.NoLicense())
+	})
+}
+
+func TestDetails_SetLicense(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		details := &Details{PhotoID: 123}
+
+		details.SetLicense("CC BY 4.0", "Photo by Bender", "Planet Express")
+
+		assert.Equal(t, "CC BY 4.0", details.License)
+		assert.Equal(t, "Photo by Bender", details.Attribution)
+		assert.Equal(t, "Planet Express", details.Credit)
+	})
+	t.Run("empty values", func(t *testing.T) {
+		details := &Details{PhotoID: 123, License: "CC0", Attribution: "Leela", Credit: "DOOP"}
+
+		details.SetLicense("", "Photo by Bender", "")
+
+		assert.Equal(t, "CC0", details.License)
+		assert.Equal(t, "Photo by Bender", details.Attribution)
+		assert.Equal(t, "DOOP", details.Credit)
+	})
+}
+
+func TestDetails_SetNotes(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		m := &Details{}
+		m.SetNotes("Meta Notes", SrcMeta)
+		assert.Equal(t, "Meta Notes", m.Notes)
+		assert.Equal(t, SrcMeta, m.NotesSrc)
+		m.SetNotes("Changed Notes", SrcMeta)
+		assert.Equal(t, "Changed Notes", m.Notes)
+	})
+	t.Run("other source", func(t *testing.T) {
+		m := &Details{Notes: "Xmp Notes", NotesSrc: SrcXmp}
+		m.SetNotes("Meta Notes", SrcMeta)
+		assert.Equal(t, "Xmp Notes", m.Notes)
+	})
+	t.Run("manual", func(t *testing.T) {
+		m := &Details{Notes: "", NotesSrc: SrcManual}
+		m.SetNotes("Meta Notes", SrcMeta)
+		assert.Equal(t, "", m.Notes)
+		m.SetNotes("My Notes", SrcManual)
+		assert.Equal(t, "My Notes", m.Notes)
+	})
+}
+
+func TestDetails_SetArtist(t *testing.T) {
+	m := &Details{Artist: "Jane", ArtistSrc: SrcManual}
+	m.SetArtist("Camera Owner", SrcMeta)
+	assert.Equal(t, "Jane", m.Artist)
+	m.ArtistSrc = SrcAuto
+	m.SetArtist("Camera Owner", SrcMeta)
+	assert.Equal(t, "Camera Owner", m.Artist)
+	m.SetArtist("", SrcMeta)
+	assert.Equal(t, "Camera Owner", m.Artist)
+}
+
+func TestDetails_SetSubject(t *testing.T) {
+	m := &Details{}
+	m.SetSubject("Holiday", SrcXmp)
+	assert.Equal(t, "Holiday", m.Subject)
+	assert.Equal(t, SrcXmp, m.SubjectSrc)
+}
+
+func TestDetails_SetCopyright(t *testing.T) {
+	m := &Details{Copyright: "(c) Jane", CopyrightSrc: SrcManual}
+	m.SetCopyright("(c) John", SrcMeta)
+	assert.Equal(t, "(c) Jane", m.Copyright)
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ErrDeviceNameEmpty is returned if a device is registered without name.
+var ErrDeviceNameEmpty = errors.New("device: name must not be empty")
+
+// Device represents a registered client that uploads photos, e.g. the phone of a family member
+// running a mobile backup app. Uploads are attributed to the device sending its token, so that
+// photos of several people can be backed up into one library and still be told apart.
+type Device struct {
+	ID          uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	DeviceUID   string     `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	DeviceToken string     `gorm:"type:varbinary(64);unique_index;" json:"-" yaml:"-"`
+	DeviceName  string     `gorm:"type:varchar(255);" json:"Name" yaml:"Name"`
+	DeviceOwner string     `gorm:"type:varchar(255);" json:"Owner" yaml:"Owner,omitempty"`
+	DeviceAlbum bool       `json:"Album" yaml:"Album,omitempty"`
+	DeviceLabel bool       `json:"Label" yaml:"Label,omitempty"`
+	AlbumUID    string     `gorm:"type:varbinary(36);" json:"AlbumUID" yaml:"AlbumUID,omitempty"`
+	UploadCount int        `json:"UploadCount" yaml:"-"`
+	UploadedAt  *time.Time `json:"UploadedAt" yaml:"-"`
+	CreatedAt   time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt   *time.Time `sql:"index" json:"-" yaml:"-"`
+}
+
+// TableName returns Device table identifier "devices".
+func (Device) TableName() string {
+	return "devices"
+}
+
+// BeforeCreate creates a random UID and token if needed before inserting a new row to the database.
+func (m *Device) BeforeCreate(scope *gorm.Scope) error {
+	if m.DeviceToken == "" {
+		if err := scope.SetColumn("DeviceToken", rnd.UUID()); err != nil {
+			return err
+		}
+	}
+
+	if rnd.IsUID(m.DeviceUID, 'c') {
+		return nil
+	}
+
+	return scope.SetColumn("DeviceUID", rnd.PPID('c'))
+}
+
+// NewDevice returns a new device registered by the given owner, e.g. the email of the current user.
+func NewDevice(f form.Device, owner string) (*Device, error) {
+	m := &Device{
+		DeviceUID:   rnd.PPID('c'),
+		DeviceToken: rnd.UUID(),
+		DeviceOwner: txt.Clip(owner, txt.ClipDefault),
+	}
+
+	if err := m.SetForm(f); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SetForm updates the name and options of the device.
+func (m *Device) SetForm(f form.Device) error {
+	name := txt.Clip(f.Name, txt.ClipDefault)
+
+	if name == "" {
+		return ErrDeviceNameEmpty
+	}
+
+	m.DeviceName = name
+	m.DeviceAlbum = f.Album
+	m.DeviceLabel = f.Label
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *Device) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the row in the database.
+func (m *Device) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the device, photos uploaded by it are kept.
+func (m *Device) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// CountUpload increments the number of photos uploaded by the device.
+func (m *Device) CountUpload() error {
+	now := time.Now().UTC()
+	m.UploadCount++
+	m.UploadedAt = &now
+
+	return Db().Model(m).UpdateColumns(map[string]interface{}{
+		"upload_count": gorm.Expr("upload_count + 1"),
+		"uploaded_at":  now,
+	}).Error
+}
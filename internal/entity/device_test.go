@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDevice(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := NewDevice(form.Device{Name: " Anna's iPhone ", Album: true}, "anna@example.com")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Anna's iPhone", m.DeviceName)
+		assert.Equal(t, "anna@example.com", m.DeviceOwner)
+		assert.True(t, m.DeviceAlbum)
+		assert.False(t, m.DeviceLabel)
+		assert.Equal(t, byte('c'), m.DeviceUID[0])
+		assert.NotEmpty(t, m.DeviceToken)
+	})
+	t.Run("name empty", func(t *testing.T) {
+		m, err := NewDevice(form.Device{Name: " "}, "")
+
+		assert.Equal(t, ErrDeviceNameEmpty, err)
+		assert.Nil(t, m)
+	})
+}
+
+func TestDevice_Create(t *testing.T) {
+	m := &Device{DeviceName: "Tablet"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, byte('c'), m.DeviceUID[0])
+	assert.NotEmpty(t, m.DeviceToken)
+
+	if err := m.CountUpload(); err != nil {
+		t.Fatal(err)
+	}
+
+	var found Device
+
+	if err := Db().Where("device_uid = ?", m.DeviceUID).First(&found).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, found.UploadCount)
+	assert.NotNil(t, found.UploadedAt)
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
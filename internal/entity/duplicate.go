@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Actions for resolving a group of duplicate photos.
+const (
+	DuplicateKeepBest = "keep-best"
+	DuplicateMerge    = "merge"
+	DuplicateLink     = "link"
+)
+
+// DuplicateActions lists all supported duplicate resolution actions.
+var DuplicateActions = map[string]bool{
+	DuplicateKeepBest: true,
+	DuplicateMerge:    true,
+	DuplicateLink:     true,
+}
+
+// DuplicateDate represents the date a photo was taken including its source.
+type DuplicateDate struct {
+	TakenAt      time.Time `json:"TakenAt"`
+	TakenAtLocal time.Time `json:"TakenAtLocal"`
+	TakenSrc     string    `json:"TakenSrc"`
+}
+
+// DuplicateChanges lists the changes made when resolving a group of duplicates, so that they
+// can be previewed before and undone after they were applied.
+type DuplicateChanges struct {
+	Keep     string            `json:"Keep"`
+	Archived []string          `json:"Archived,omitempty"`
+	Labels   []string          `json:"Labels,omitempty"`
+	Albums   []string          `json:"Albums,omitempty"`
+	Links    map[string]string `json:"Links,omitempty"`
+	Date     *DuplicateDate    `json:"Date,omitempty"`
+	PrevDate *DuplicateDate    `json:"PrevDate,omitempty"`
+}
+
+// DuplicateResolution represents an applied duplicate resolution that can be undone.
+type DuplicateResolution struct {
+	ID            uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	ResolutionUID string     `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	Action        string     `gorm:"type:varbinary(16);" json:"Action" yaml:"Action"`
+	PhotoUID      string     `gorm:"type:varbinary(36);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	Changes       string     `gorm:"type:text;" json:"-" yaml:"-"`
+	CreatedAt     time.Time  `json:"CreatedAt" yaml:"-"`
+	UndoneAt      *time.Time `gorm:"type:datetime;" json:"UndoneAt" yaml:"-"`
+}
+
+// TableName returns DuplicateResolution table identifier "duplicate_resolutions".
+func (DuplicateResolution) TableName() string {
+	return "duplicate_resolutions"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *DuplicateResolution) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.ResolutionUID, 'r') {
+		return nil
+	}
+
+	return scope.SetColumn("ResolutionUID", rnd.PPID('r'))
+}
+
+// NewDuplicateResolution creates an undo record for the changes made by a duplicate resolution action.
+func NewDuplicateResolution(action string, changes DuplicateChanges) (*DuplicateResolution, error) {
+	data, err := json.Marshal(changes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DuplicateResolution{
+		ResolutionUID: rnd.PPID('r'),
+		Action:        action,
+		PhotoUID:      changes.Keep,
+		Changes:       string(data),
+	}
+
+	return result, nil
+}
+
+// Create inserts a new row to the database.
+func (m *DuplicateResolution) Create() error {
+	return Db().Create(m).Error
+}
+
+// DuplicateChanges returns the changes that were made by the resolution.
+func (m *DuplicateResolution) DuplicateChanges() (changes DuplicateChanges, err error) {
+	err = json.Unmarshal([]byte(m.Changes), &changes)
+
+	return changes, err
+}
+
+// Undone returns true if the changes have been undone.
+func (m *DuplicateResolution) Undone() bool {
+	return m.UndoneAt != nil
+}
+
+// SetUndone marks the resolution as undone.
+func (m *DuplicateResolution) SetUndone() error {
+	undone := time.Now().UTC()
+	m.UndoneAt = &undone
+
+	return Db().Model(m).UpdateColumn("undone_at", m.UndoneAt).Error
+}
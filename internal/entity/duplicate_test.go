@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDuplicateResolution(t *testing.T) {
+	taken := time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	changes := DuplicateChanges{
+		Keep:     "pt9jtdre2lvl0yh7",
+		Archived: []string{"pt9jtdre2lvl0yh9"},
+		Links:    map[string]string{"4jxf3jfn2k": "pt9jtdre2lvl0yh9"},
+		Date:     &DuplicateDate{TakenAt: taken, TakenAtLocal: taken, TakenSrc: SrcMeta},
+	}
+
+	m, err := NewDuplicateResolution(DuplicateLink, changes)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, DuplicateLink, m.Action)
+	assert.Equal(t, "pt9jtdre2lvl0yh7", m.PhotoUID)
+	assert.Contains(t, m.Changes, `"Archived":["pt9jtdre2lvl0yh9"]`)
+	assert.NotContains(t, m.Changes, "Albums")
+
+	result, err := m.DuplicateChanges()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, changes.Archived, result.Archived)
+	assert.Equal(t, changes.Links, result.Links)
+	assert.True(t, taken.Equal(result.Date.TakenAt))
+	assert.Nil(t, result.PrevDate)
+}
+
+func TestDuplicateResolution_SetUndone(t *testing.T) {
+	m, err := NewDuplicateResolution(DuplicateKeepBest, DuplicateChanges{Keep: "pt9jtdre2lvl0yh7"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, m.Undone())
+
+	if err := m.SetUndone(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Undone())
+
+	var result DuplicateResolution
+
+	if err := Db().Where("resolution_uid = ?", m.ResolutionUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result.Undone())
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/secret"
 )
 
 var log = event.Log
@@ -29,27 +30,51 @@ type Types map[string]interface{}
 
 // List of database entities and their table names.
 var Entities = Types{
-	"errors":          &Error{},
-	"accounts":        &Account{},
-	"folders":         &Folder{},
-	"files":           &File{},
-	"files_share":     &FileShare{},
-	"files_sync":      &FileSync{},
-	"photos":          &Photo{},
-	"details":         &Details{},
-	"places":          &Place{},
-	"locations":       &Location{},
-	"cameras":         &Camera{},
-	"lenses":          &Lens{},
-	"countries":       &Country{},
-	"albums":          &Album{},
-	"photos_albums":   &PhotoAlbum{},
-	"labels":          &Label{},
-	"categories":      &Category{},
-	"photos_labels":   &PhotoLabel{},
-	"keywords":        &Keyword{},
-	"photos_keywords": &PhotoKeyword{},
-	"links":           &Link{},
+	"errors":                &Error{},
+	"accounts":              &Account{},
+	"folders":               &Folder{},
+	"files":                 &File{},
+	"files_share":           &FileShare{},
+	"files_sync":            &FileSync{},
+	"photos":                &Photo{},
+	"details":               &Details{},
+	"places":                &Place{},
+	"locations":             &Location{},
+	"cameras":               &Camera{},
+	"lenses":                &Lens{},
+	"countries":             &Country{},
+	"albums":                &Album{},
+	"photos_albums":         &PhotoAlbum{},
+	"labels":                &Label{},
+	"categories":            &Category{},
+	"photos_labels":         &PhotoLabel{},
+	"keywords":              &Keyword{},
+	"photos_keywords":       &PhotoKeyword{},
+	"links":                 &Link{},
+	"links_aliases":         &LinkAlias{},
+	"links_exclusions":      &LinkExclusion{},
+	"guest_reactions":       &GuestReaction{},
+	"guests":                &Guest{},
+	"guests_links":          &GuestLink{},
+	"duplicate_resolutions": &DuplicateResolution{},
+	"geometry_fixes":        &GeometryFix{},
+	"snapshots":             &Snapshot{},
+	"snapshots_photos":      &SnapshotPhoto{},
+	"presets":               &Preset{},
+	"album_rules":           &AlbumRule{},
+	"thumb_usage":           &ThumbUsage{},
+	"subjects":              &Subject{},
+	"markers":               &Marker{},
+	"feature_usage":         &FeatureUsage{},
+	"index_errors":          &IndexError{},
+	"quarantine_files":      &QuarantineFile{},
+	"checkpoints":           &Checkpoint{},
+	"photos_embeddings":     &PhotoEmbedding{},
+	"search_history":        &SearchHistory{},
+	"devices":               &Device{},
+	"thumb_errors":          &ThumbError{},
+	"redirects":             &Redirect{},
+	"album_events":          &AlbumEvent{},
 }
 
 // WaitForMigration waits for the database migration to be successful.
@@ -120,14 +145,6 @@ func CreateDefaultFixtures() {
 	CreateViews()
 }
 
-// MigrateDb creates all tables and inserts default entities as needed.
-func MigrateDb() {
-	Entities.Migrate()
-	Entities.WaitForMigration()
-
-	CreateDefaultFixtures()
-}
-
 // ResetTestFixtures drops database tables for all known entities and re-creates them with fixtures.
 func ResetTestFixtures() {
 	Entities.Migrate()
@@ -150,6 +167,12 @@ func InitTestDb(dsn string) *Gorm {
 		Dsn:    dsn,
 	}
 
+	if !secret.HasKey() {
+		if err := secret.SetKey([]byte("photoprism-test-master-key-32byt")); err != nil {
+			log.Error(err)
+		}
+	}
+
 	SetDbProvider(db)
 	ResetTestFixtures()
 
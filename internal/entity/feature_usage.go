@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Features counted in local usage statistics.
+const (
+	UsageSearch   = "search"
+	UsageShare    = "share"
+	UsageDownload = "download"
+	UsageUpload   = "upload"
+)
+
+// FeatureUsage counts how often a feature was used per day, without recording who used it.
+type FeatureUsage struct {
+	UsageDate  string    `gorm:"type:varbinary(10);primary_key;auto_increment:false;" json:"Date"`
+	Feature    string    `gorm:"type:varbinary(32);primary_key;auto_increment:false;" json:"Feature"`
+	UsageCount int       `json:"Count"`
+	CreatedAt  time.Time `json:"-"`
+	UpdatedAt  time.Time `json:"-"`
+}
+
+// TableName returns FeatureUsage table identifier "feature_usage".
+func (FeatureUsage) TableName() string {
+	return "feature_usage"
+}
+
+// CountFeatureUsage increments the usage counter of a feature for the current day.
+func CountFeatureUsage(feature string) error {
+	date := time.Now().UTC().Format("2006-01-02")
+	m := FeatureUsage{UsageDate: date, Feature: feature}
+
+	if err := Db().FirstOrCreate(&m, "usage_date = ? AND feature = ?", date, feature).Error; err != nil {
+		return err
+	}
+
+	return Db().Model(&m).UpdateColumns(map[string]interface{}{
+		"usage_count": gorm.Expr("usage_count + 1"),
+		"updated_at":  time.Now().UTC(),
+	}).Error
+}
+
+// FeatureUsageSince returns the daily usage counters since the given day, oldest first.
+func FeatureUsageSince(since time.Time) (result []FeatureUsage, err error) {
+	result = []FeatureUsage{}
+
+	err = Db().Where("usage_date >= ?", since.UTC().Format("2006-01-02")).
+		Order("usage_date, feature").
+		Find(&result).Error
+
+	return result, err
+}
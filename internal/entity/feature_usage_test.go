@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountFeatureUsage(t *testing.T) {
+	count := func() int {
+		rows, err := FeatureUsageSince(time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range rows {
+			if r.Feature == UsageDownload {
+				return r.UsageCount
+			}
+		}
+
+		return 0
+	}
+
+	before := count()
+
+	if err := CountFeatureUsage(UsageDownload); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CountFeatureUsage(UsageDownload); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, before+2, count())
+}
+
+func TestFeatureUsageSince(t *testing.T) {
+	if err := CountFeatureUsage(UsageSearch); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("today", func(t *testing.T) {
+		rows, err := FeatureUsageSince(time.Now().AddDate(0, 0, -1))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, rows)
+	})
+	t.Run("future", func(t *testing.T) {
+		rows, err := FeatureUsageSince(time.Now().AddDate(0, 0, 2))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, rows)
+	})
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -32,19 +33,25 @@ type File struct {
 	FilePrimary     bool          `json:"Primary" yaml:"Primary,omitempty"`
 	FileSidecar     bool          `json:"Sidecar" yaml:"Sidecar,omitempty"`
 	FileMissing     bool          `json:"Missing" yaml:"Missing,omitempty"`
+	MissingSince    *time.Time    `gorm:"type:datetime;" json:"MissingSince,omitempty" yaml:"-"`
+	FileCold        bool          `json:"Cold" yaml:"Cold,omitempty"`
 	FileDuplicate   bool          `json:"Duplicate" yaml:"Duplicate,omitempty"`
 	FilePortrait    bool          `json:"Portrait" yaml:"Portrait,omitempty"`
 	FileVideo       bool          `json:"Video" yaml:"Video,omitempty"`
 	FileDuration    time.Duration `json:"Duration" yaml:"Duration,omitempty"`
-	FileWidth       int           `json:"Width" yaml:"Width,omitempty"`
-	FileHeight      int           `json:"Height" yaml:"Height,omitempty"`
+	FileWidth       int           `gorm:"index:idx_files_dimensions;" json:"Width" yaml:"Width,omitempty"`
+	FileHeight      int           `gorm:"index:idx_files_dimensions;" json:"Height" yaml:"Height,omitempty"`
 	FileOrientation int           `json:"Orientation" yaml:"Orientation,omitempty"`
-	FileAspectRatio float32       `gorm:"type:FLOAT;" json:"AspectRatio" yaml:"AspectRatio,omitempty"`
+	FileAspectRatio float32       `gorm:"type:FLOAT;index;" json:"AspectRatio" yaml:"AspectRatio,omitempty"`
 	FileMainColor   string        `gorm:"type:varbinary(16);index;" json:"MainColor" yaml:"MainColor,omitempty"`
 	FileColors      string        `gorm:"type:varbinary(9);" json:"Colors" yaml:"Colors,omitempty"`
 	FileLuminance   string        `gorm:"type:varbinary(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff        uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma      uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FileMono        bool          `json:"Mono" yaml:"Mono,omitempty"`
+	FileHistogram   string        `gorm:"type:varbinary(16);" json:"Histogram" yaml:"Histogram,omitempty"`
+	FileUnder       uint8         `json:"Under" yaml:"Under,omitempty"`
+	FileOver        uint8         `json:"Over" yaml:"Over,omitempty"`
 	FileNotes       string        `gorm:"type:text" json:"Notes" yaml:"Notes,omitempty"`
 	FileError       string        `gorm:"type:varbinary(512)" json:"Error" yaml:"Error,omitempty"`
 	Share           []FileShare   `json:"-" yaml:"-"`
@@ -67,6 +74,10 @@ type FileInfos struct {
 	FileLuminance   string
 	FileDiff        uint32
 	FileChroma      uint8
+	FileMono        bool
+	FileHistogram   string
+	FileUnder       uint8
+	FileOver        uint8
 }
 
 // FirstFileByHash gets a file in db from its hash
@@ -130,6 +141,59 @@ func (m *File) Purge() error {
 	return Db().Unscoped().Model(m).Updates(map[string]interface{}{"file_missing": true, "file_primary": false}).Error
 }
 
+// Quarantine marks a file that can't be found, so that it is only flagged as missing after a grace period.
+func (m *File) Quarantine() error {
+	if m.MissingSince != nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	m.MissingSince = &now
+
+	return Db().Unscoped().Model(m).UpdateColumn("missing_since", m.MissingSince).Error
+}
+
+// Quarantined returns true if the file can't be found, but is not flagged as missing yet.
+func (m *File) Quarantined() bool {
+	return m.MissingSince != nil && !m.FileMissing
+}
+
+// Found removes the missing flag and quarantine state, e.g. after a drive was mounted again.
+func (m *File) Found() error {
+	m.FileMissing = false
+	m.MissingSince = nil
+
+	return Db().Unscoped().Model(m).UpdateColumns(map[string]interface{}{"file_missing": false, "missing_since": nil}).Error
+}
+
+// Relocate changes the file name after a file was moved to a different folder, for example after the
+// drive containing it was mounted elsewhere.
+func (m *File) Relocate(fileName string) error {
+	if err := Db().Unscoped().Model(m).UpdateColumns(map[string]interface{}{
+		"file_name":     fileName,
+		"file_missing":  false,
+		"missing_since": nil,
+	}).Error; err != nil {
+		return err
+	}
+
+	m.FileName = fileName
+	m.FileMissing = false
+	m.MissingSince = nil
+
+	if !m.FilePrimary {
+		return nil
+	}
+
+	photoPath := filepath.Dir(fileName)
+
+	if photoPath == "." {
+		photoPath = ""
+	}
+
+	return Db().Unscoped().Model(&Photo{}).Where("id = ?", m.PhotoID).UpdateColumn("photo_path", photoPath).Error
+}
+
 // AllFilesMissing returns true, if all files for the photo of this file are missing.
 func (m *File) AllFilesMissing() bool {
 	count := 0
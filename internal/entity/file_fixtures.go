@@ -33,6 +33,9 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "8836BD496",
 		FileDiff:        968,
 		FileChroma:      25,
+		FileHistogram:   "F9643211000001",
+		FileUnder:       35,
+		FileOver:        2,
 		FileNotes:       "",
 		FileError:       "",
 		Share: []FileShare{
@@ -192,6 +195,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        800,
 		FileChroma:      4,
+		FileMono:        true,
 		FileNotes:       "",
 		FileError:       "Error",
 		Share:           []FileShare{},
@@ -81,6 +81,58 @@ func TestFile_Purge(t *testing.T) {
 	})
 }
 
+func TestFile_Quarantine(t *testing.T) {
+	file := &File{PhotoID: 1000000, FileType: "jpg", FileUID: "fqzz1quarantine1", FileName: "quarantine/test.jpg"}
+
+	if err := Db().Create(file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, file.Quarantined())
+
+	if err := file.Quarantine(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, file.Quarantined())
+
+	since := *file.MissingSince
+
+	if err := file.Quarantine(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, since, *file.MissingSince)
+
+	if err := file.Found(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, file.Quarantined())
+	assert.Nil(t, file.MissingSince)
+}
+
+func TestFile_Relocate(t *testing.T) {
+	file := &File{PhotoID: 1000000, FileType: "jpg", FileUID: "fqzz1relocate001", FileName: "old/test.jpg", FileMissing: true}
+
+	if err := Db().Create(file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := file.Relocate("new/test.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	var found File
+
+	if err := Db().Where("file_uid = ?", file.FileUID).First(&found).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "new/test.jpg", found.FileName)
+	assert.False(t, found.FileMissing)
+}
+
 func TestFile_AllFilesMissing(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		photo := &Photo{TakenAtLocal: time.Date(2019, 01, 15, 0, 0, 0, 0, time.UTC), PhotoTitle: ""}
@@ -9,6 +9,7 @@ func CreateTestFixtures() {
 	CreateAlbumFixtures()
 	CreateAccountFixtures()
 	CreateLinkFixtures()
+	CreateGuestFixtures()
 	CreatePhotoAlbumFixtures()
 	CreateFileFixtures()
 	CreateKeywordFixtures()
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Review states of suggested orientation and geometry fixes.
+const (
+	GeometryPending   = "pending"
+	GeometryApplied   = "applied"
+	GeometryDismissed = "dismissed"
+	GeometryOk        = "ok"
+)
+
+// GeometryFix represents a suggestion to rotate or straighten the primary image of a photo.
+// Photos without issues are recorded with status "ok", so that they are not checked again.
+type GeometryFix struct {
+	ID        uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	FixUID    string    `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	PhotoUID  string    `gorm:"type:varbinary(36);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	FileUID   string    `gorm:"type:varbinary(36);unique_index;" json:"FileUID" yaml:"FileUID"`
+	FixRotate int       `json:"Rotate" yaml:"Rotate,omitempty"`
+	FixTilt   float64   `json:"Tilt" yaml:"Tilt,omitempty"`
+	FixScore  float32   `json:"Score" yaml:"Score,omitempty"`
+	FixStatus string    `gorm:"type:varbinary(16);index;" json:"Status" yaml:"Status"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns GeometryFix table identifier "geometry_fixes".
+func (GeometryFix) TableName() string {
+	return "geometry_fixes"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *GeometryFix) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.FixUID, 'o') {
+		return nil
+	}
+
+	return scope.SetColumn("FixUID", rnd.PPID('o'))
+}
+
+// NewGeometryFix returns a new fix suggestion. Rotate is the clockwise rotation in degrees,
+// tilt the angle in degrees the horizon is tilted counter-clockwise.
+func NewGeometryFix(photoUID, fileUID string, rotate int, tilt float64, score float32) *GeometryFix {
+	result := &GeometryFix{
+		FixUID:    rnd.PPID('o'),
+		PhotoUID:  photoUID,
+		FileUID:   fileUID,
+		FixRotate: rotate,
+		FixTilt:   tilt,
+		FixScore:  score,
+		FixStatus: GeometryPending,
+	}
+
+	if !result.NeedsFix() {
+		result.FixStatus = GeometryOk
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *GeometryFix) Create() error {
+	return Db().Create(m).Error
+}
+
+// NeedsFix returns true if the image should be rotated or straightened.
+func (m *GeometryFix) NeedsFix() bool {
+	return m.FixRotate != 0 || m.FixTilt != 0
+}
+
+// Pending returns true if the suggestion has not been reviewed yet.
+func (m *GeometryFix) Pending() bool {
+	return m.FixStatus == GeometryPending
+}
+
+// SetStatus updates the review status.
+func (m *GeometryFix) SetStatus(status string) error {
+	m.FixStatus = status
+
+	return Db().Model(m).UpdateColumn("fix_status", status).Error
+}
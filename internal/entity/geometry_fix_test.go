@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGeometryFix(t *testing.T) {
+	t.Run("rotate", func(t *testing.T) {
+		m := NewGeometryFix("pt9jtdre2lvl0yh7", "ft8es39w45bnlqdw", 90, 0, 0.8)
+
+		assert.True(t, rnd.IsUID(m.FixUID, 'o'))
+		assert.Equal(t, 90, m.FixRotate)
+		assert.True(t, m.NeedsFix())
+		assert.True(t, m.Pending())
+	})
+	t.Run("ok", func(t *testing.T) {
+		m := NewGeometryFix("pt9jtdre2lvl0yh7", "ft8es39w45bnlqdw", 0, 0, 0)
+
+		assert.False(t, m.NeedsFix())
+		assert.False(t, m.Pending())
+		assert.Equal(t, GeometryOk, m.FixStatus)
+	})
+}
+
+func TestGeometryFix_SetStatus(t *testing.T) {
+	m := NewGeometryFix("pt9jtdre2lvl0yh7", "ft8es39w45bnlqdx", 0, 2.5, 0.4)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SetStatus(GeometryDismissed); err != nil {
+		t.Fatal(err)
+	}
+
+	var result GeometryFix
+
+	if err := Db().Where("fix_uid = ?", m.FixUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, GeometryDismissed, result.FixStatus)
+	assert.Equal(t, 2.5, result.FixTilt)
+}
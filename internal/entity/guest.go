@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const ClipGuestLogin = 64
+
+// Guest represents a limited account for recurring share link visitors. Guests get a persistent login
+// that is restricted to the links they have been given access to.
+type Guest struct {
+	ID           uint        `gorm:"primary_key" json:"-" yaml:"-"`
+	GuestUID     string      `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	GuestName    string      `gorm:"type:varchar(64);" json:"Name" yaml:"Name"`
+	LoginToken   string      `gorm:"type:varbinary(255);unique_index;" json:"LoginToken" yaml:"-"`
+	GuestExpires *time.Time  `gorm:"type:datetime;" json:"Expires" yaml:"Expires,omitempty"`
+	Upgraded     bool        `json:"Upgraded" yaml:"Upgraded,omitempty"`
+	LoginAt      *time.Time  `gorm:"type:datetime;" json:"LoginAt" yaml:"-"`
+	Links        []GuestLink `gorm:"foreignkey:guest_uid;association_foreignkey:guest_uid" json:"-" yaml:"-"`
+	CreatedAt    time.Time   `json:"CreatedAt" yaml:"-"`
+	UpdatedAt    time.Time   `json:"UpdatedAt" yaml:"-"`
+	DeletedAt    *time.Time  `sql:"index" json:"-" yaml:"-"`
+}
+
+// GuestLink represents a share link a guest has access to.
+type GuestLink struct {
+	GuestUID  string `gorm:"type:varbinary(36);primary_key;auto_increment:false"`
+	LinkToken string `gorm:"type:varbinary(255);primary_key;auto_increment:false;index"`
+	CreatedAt time.Time
+}
+
+// TableName returns GuestLink table identifier "guests_links".
+func (GuestLink) TableName() string {
+	return "guests_links"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Guest) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.GuestUID, 'g') {
+		return nil
+	}
+
+	return scope.SetColumn("GuestUID", rnd.PPID('g'))
+}
+
+// NewGuest creates a guest account for a share link visitor, which expires with the link.
+func NewGuest(link Link, name string) *Guest {
+	result := &Guest{
+		GuestUID:     rnd.PPID('g'),
+		GuestName:    txt.Clip(name, ClipGuestLogin),
+		LoginToken:   rnd.Token(16),
+		GuestExpires: link.LinkExpires,
+	}
+
+	return result
+}
+
+// Create inserts the guest account along with access to the link it was created from.
+func (m *Guest) Create(link Link) error {
+	if m.GuestName == "" {
+		return errors.New("guest: name is empty")
+	}
+
+	if err := Db().Create(m).Error; err != nil {
+		return err
+	}
+
+	return m.AddLink(link)
+}
+
+// AddLink grants the guest access to a share link.
+func (m *Guest) AddLink(link Link) error {
+	if link.LinkToken == "" {
+		return errors.New("guest: link token is empty")
+	}
+
+	return Db().FirstOrCreate(&GuestLink{}, GuestLink{GuestUID: m.GuestUID, LinkToken: link.LinkToken}).Error
+}
+
+// AddAlbum grants the guest access to an album, using an existing share link without password or a new one.
+func (m *Guest) AddAlbum(albumUID string) error {
+	var links []Link
+
+	if err := Db().Where("share_uid = ?", albumUID).Find(&links).Error; err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if link.LinkPassword == "" && !link.Expired() {
+			return m.AddLink(link)
+		}
+	}
+
+	link := NewLink("", false, false)
+	link.ShareUID = albumUID
+
+	if err := Db().Create(&link).Error; err != nil {
+		return err
+	}
+
+	return m.AddLink(link)
+}
+
+// Expired returns true if the guest account has an expiration date in the past.
+func (m *Guest) Expired() bool {
+	if m.GuestExpires == nil {
+		return false
+	}
+
+	return m.GuestExpires.Before(time.Now())
+}
+
+// Upgrade turns the guest account into a permanent account.
+func (m *Guest) Upgrade() error {
+	m.GuestExpires = nil
+	m.Upgraded = true
+
+	return Db().Model(m).Updates(map[string]interface{}{"guest_expires": nil, "upgraded": true}).Error
+}
+
+// Login updates the last login time.
+func (m *Guest) Login() error {
+	now := time.Now().UTC()
+	m.LoginAt = &now
+
+	return Db().Model(m).UpdateColumn("login_at", now).Error
+}
+
+// Delete removes the guest account and its access to share links.
+func (m *Guest) Delete() error {
+	if err := Db().Where("guest_uid = ?", m.GuestUID).Delete(&GuestLink{}).Error; err != nil {
+		return err
+	}
+
+	return Db().Delete(m).Error
+}
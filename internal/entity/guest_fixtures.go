@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type GuestMap map[string]Guest
+
+var GuestFixtures = GuestMap{
+	"grandma": {
+		ID:         1000000,
+		GuestUID:   "gt9k8pkzkqrv8hlw",
+		GuestName:  "Grandma",
+		LoginToken: "4jxf3jfn2k9a8b7c",
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+}
+
+// CreateGuestFixtures inserts known entities into the database for testing.
+func CreateGuestFixtures() {
+	for _, entity := range GuestFixtures {
+		Db().Create(&entity)
+		Db().Create(&GuestLink{GuestUID: entity.GuestUID, LinkToken: "1jxf3jfn2k"})
+	}
+}
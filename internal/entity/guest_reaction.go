@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	ClipGuestName  = 64
+	ClipGuestEmoji = 16
+	ClipGuestNote  = 512
+)
+
+// GuestReaction represents an emoji reaction or short note left by a share link visitor.
+type GuestReaction struct {
+	ID         uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	LinkToken  string     `gorm:"type:varbinary(255);index;" json:"-" yaml:"-"`
+	ShareUID   string     `gorm:"type:varbinary(36);index;" json:"ShareUID" yaml:"ShareUID"`
+	PhotoUID   string     `gorm:"type:varbinary(36);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	GuestName  string     `gorm:"type:varchar(64);" json:"Name" yaml:"Name,omitempty"`
+	GuestEmoji string     `gorm:"type:varchar(16);" json:"Emoji" yaml:"Emoji,omitempty"`
+	GuestNote  string     `gorm:"type:varchar(512);" json:"Note" yaml:"Note,omitempty"`
+	GuestAddr  string     `gorm:"type:varbinary(64);" json:"-" yaml:"-"`
+	Hidden     bool       `json:"Hidden" yaml:"Hidden,omitempty"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt  *time.Time `sql:"index" json:"-" yaml:"-"`
+}
+
+// TableName returns GuestReaction table identifier "guest_reactions".
+func (GuestReaction) TableName() string {
+	return "guest_reactions"
+}
+
+// NewGuestReaction creates a new guest reaction for a photo shared with the given link.
+func NewGuestReaction(link Link, photoUID, name, emoji, note string) *GuestReaction {
+	result := &GuestReaction{
+		LinkToken:  link.LinkToken,
+		ShareUID:   link.ShareUID,
+		PhotoUID:   photoUID,
+		GuestName:  txt.Clip(name, ClipGuestName),
+		GuestEmoji: txt.Clip(emoji, ClipGuestEmoji),
+		GuestNote:  txt.Clip(note, ClipGuestNote),
+	}
+
+	return result
+}
+
+// Validate returns an error if the reaction has neither an emoji nor a note.
+func (m *GuestReaction) Validate() error {
+	if m.PhotoUID == "" {
+		return errors.New("guest reaction: photo uid is empty")
+	}
+
+	if m.GuestEmoji == "" && m.GuestNote == "" {
+		return errors.New("guest reaction: emoji or note required")
+	}
+
+	return nil
+}
+
+// AlbumEvent returns the reaction as event for the album activity log. It isn't stored in the album_events
+// table, so the event ID is always 0. The name is entered freely by visitors, so it is prefixed with
+// "visitor:" to keep it apart from user and guest account IDs.
+func (m *GuestReaction) AlbumEvent() AlbumEvent {
+	return AlbumEvent{
+		AlbumUID:   m.ShareUID,
+		UserID:     "visitor:" + m.GuestName,
+		EventType:  AlbumEventReaction,
+		PhotoCount: 1,
+		EventInfo:  strings.TrimSpace(m.GuestEmoji + " " + m.GuestNote),
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *GuestReaction) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Create(m).Error
+}
+
+// Update a column in the database.
+func (m *GuestReaction) Update(attr string, value interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
+}
+
+// Delete removes the reaction from the database.
+func (m *GuestReaction) Delete() error {
+	return Db().Delete(m).Error
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGuestReaction(t *testing.T) {
+	link := Link{LinkToken: "abc123", ShareUID: "at9lxuqxpogaaba8"}
+	m := NewGuestReaction(link, "pt9jtdre2lvl0yh7", "  Grandma ", "❤️", "Lovely!")
+
+	assert.Equal(t, "abc123", m.LinkToken)
+	assert.Equal(t, "at9lxuqxpogaaba8", m.ShareUID)
+	assert.Equal(t, "pt9jtdre2lvl0yh7", m.PhotoUID)
+	assert.Equal(t, "Grandma", m.GuestName)
+	assert.Equal(t, "❤️", m.GuestEmoji)
+	assert.Equal(t, "Lovely!", m.GuestNote)
+	assert.False(t, m.Hidden)
+}
+
+func TestGuestReaction_Validate(t *testing.T) {
+	t.Run("emoji", func(t *testing.T) {
+		m := GuestReaction{PhotoUID: "pt9jtdre2lvl0yh7", GuestEmoji: "👍"}
+		assert.Nil(t, m.Validate())
+	})
+	t.Run("note", func(t *testing.T) {
+		m := GuestReaction{PhotoUID: "pt9jtdre2lvl0yh7", GuestNote: "Nice"}
+		assert.Nil(t, m.Validate())
+	})
+	t.Run("empty", func(t *testing.T) {
+		m := GuestReaction{PhotoUID: "pt9jtdre2lvl0yh7", GuestName: "Bob"}
+		assert.Error(t, m.Validate())
+	})
+	t.Run("no photo", func(t *testing.T) {
+		m := GuestReaction{GuestEmoji: "👍"}
+		assert.Error(t, m.Validate())
+	})
+}
+
+func TestGuestReaction_AlbumEvent(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := GuestReaction{ShareUID: "at9lxuqxpogaaba8", PhotoUID: "pt9jtdre2lvl0yh7", GuestName: "Grandma", GuestEmoji: "😍", GuestNote: "Lovely"}
+		result := m.AlbumEvent()
+
+		assert.Equal(t, "at9lxuqxpogaaba8", result.AlbumUID)
+		assert.Equal(t, "visitor:Grandma", result.UserID)
+		assert.Equal(t, AlbumEventReaction, result.EventType)
+		assert.Equal(t, "😍 Lovely", result.EventInfo)
+	})
+	t.Run("admin", func(t *testing.T) {
+		m := GuestReaction{PhotoUID: "pt9jtdre2lvl0yh7", GuestName: "admin", GuestEmoji: "👍"}
+		assert.NotEqual(t, "admin", m.AlbumEvent().UserID)
+	})
+}
+
+func TestGuestReaction_Create(t *testing.T) {
+	link := Link{LinkToken: "abc123", ShareUID: "at9lxuqxpogaaba8"}
+	m := NewGuestReaction(link, "pt9jtdre2lvl0yh7", "Grandma", "😍", "")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+
+	if err := m.Update("Hidden", true); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Hidden)
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGuest(t *testing.T) {
+	expires := time.Date(2050, 3, 6, 2, 6, 51, 0, time.UTC)
+	link := Link{LinkToken: "abc123", ShareUID: "at9lxuqxpogaaba8", LinkExpires: &expires}
+	m := NewGuest(link, " Grandpa ")
+
+	assert.Equal(t, "Grandpa", m.GuestName)
+	assert.Len(t, m.LoginToken, 16)
+	assert.Equal(t, &expires, m.GuestExpires)
+	assert.False(t, m.Upgraded)
+}
+
+func TestGuest_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		link := LinkFixtures["1jxf3jfn2k"]
+		m := NewGuest(link, "Grandpa")
+
+		if err := m.Create(link); err != nil {
+			t.Fatal(err)
+		}
+
+		var links []GuestLink
+
+		if err := Db().Where("guest_uid = ?", m.GuestUID).Find(&links).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, links, 1)
+		assert.Equal(t, "1jxf3jfn2k", links[0].LinkToken)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("name empty", func(t *testing.T) {
+		m := NewGuest(LinkFixtures["1jxf3jfn2k"], "")
+
+		assert.Error(t, m.Create(LinkFixtures["1jxf3jfn2k"]))
+	})
+}
+
+func TestGuest_Expired(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	assert.False(t, (&Guest{}).Expired())
+	assert.True(t, (&Guest{GuestExpires: &past}).Expired())
+	assert.False(t, (&Guest{GuestExpires: &future}).Expired())
+}
+
+func TestGuest_Upgrade(t *testing.T) {
+	link := LinkFixtures["1jxf3jfn2k"]
+	m := NewGuest(link, "Uncle")
+
+	if err := m.Create(link); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, m.GuestExpires)
+
+	if err := m.Upgrade(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result Guest
+
+	if err := Db().Where("guest_uid = ?", m.GuestUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result.Upgraded)
+	assert.Nil(t, result.GuestExpires)
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGuest_AddAlbum(t *testing.T) {
+	link := LinkFixtures["1jxf3jfn2k"]
+	m := NewGuest(link, "Aunt")
+
+	if err := m.Create(link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.AddAlbum("at9lxuqxpogaaba8"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Adding the same album twice reuses the link.
+	if err := m.AddAlbum("at9lxuqxpogaaba8"); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+
+	if err := Db().Model(&GuestLink{}).Where("guest_uid = ?", m.GuestUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, count)
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Kinds of indexing failures.
+const (
+	IndexErrorMeta    = "metadata"
+	IndexErrorCodec   = "codec"
+	IndexErrorConvert = "convert"
+	IndexErrorThumb   = "thumbnail"
+	IndexErrorIndex   = "index"
+)
+
+// IndexError represents a file that failed to index, so that failures can be reviewed and retried
+// instead of only being logged. Errors are removed once the file was indexed successfully.
+type IndexError struct {
+	ID           uint      `gorm:"primary_key" json:"ID"`
+	FileName     string    `gorm:"type:varbinary(768);unique_index;" json:"FileName"`
+	ErrorKind    string    `gorm:"type:varbinary(16);index;" json:"Kind"`
+	ErrorMessage string    `gorm:"type:varbinary(2048);" json:"Message"`
+	ErrorCount   int       `json:"Count"`
+	ErrorIgnored bool      `json:"Ignored"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
+
+// TableName returns IndexError table identifier "index_errors".
+func (IndexError) TableName() string {
+	return "index_errors"
+}
+
+// SaveIndexError records a failure for the file name relative to the originals path. Repeated failures
+// increase the error count, ignored errors remain ignored.
+func SaveIndexError(fileName, kind, message string) error {
+	m := IndexError{}
+
+	if err := Db().Where("file_name = ?", fileName).First(&m).Error; err != nil {
+		m = IndexError{FileName: fileName}
+	}
+
+	m.ErrorKind = kind
+	m.ErrorMessage = txt.Clip(message, 2048)
+	m.ErrorCount++
+
+	return Db().Save(&m).Error
+}
+
+// ClearIndexError removes the error recorded for a file name, e.g. after it was indexed successfully.
+func ClearIndexError(fileName string) error {
+	return Db().Where("file_name = ?", fileName).Delete(&IndexError{}).Error
+}
+
+// Ignore hides the error from the list of unresolved errors, e.g. if a file is known to be broken.
+func (m *IndexError) Ignore() error {
+	m.ErrorIgnored = true
+
+	return Db().Model(m).UpdateColumn("error_ignored", true).Error
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveIndexError(t *testing.T) {
+	fileName := "2020/broken/corrupt-exif.jpg"
+
+	assert.NoError(t, SaveIndexError(fileName, IndexErrorMeta, "unexpected EOF (exif metadata)"))
+	assert.NoError(t, SaveIndexError(fileName, IndexErrorConvert, "darktable crashed"))
+
+	m := IndexError{}
+
+	if err := Db().Where("file_name = ?", fileName).First(&m).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, IndexErrorConvert, m.ErrorKind)
+	assert.Equal(t, "darktable crashed", m.ErrorMessage)
+	assert.Equal(t, 2, m.ErrorCount)
+	assert.False(t, m.ErrorIgnored)
+
+	assert.NoError(t, m.Ignore())
+	assert.NoError(t, SaveIndexError(fileName, IndexErrorConvert, "darktable crashed"))
+
+	if err := Db().Where("file_name = ?", fileName).First(&m).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.ErrorIgnored)
+	assert.Equal(t, 3, m.ErrorCount)
+
+	assert.NoError(t, ClearIndexError(fileName))
+	assert.Error(t, Db().Where("file_name = ?", fileName).First(&m).Error)
+}
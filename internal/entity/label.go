@@ -22,6 +22,8 @@ type Label struct {
 	LabelFavorite    bool       `gorm:"type:varchar(255);" json:"Favorite" yaml:"Favorite,omitempty"`
 	LabelDescription string     `gorm:"type:text;" json:"Description" yaml:"Description,omitempty"`
 	LabelNotes       string     `gorm:"type:text;" json:"Notes" yaml:"Notes,omitempty"`
+	LabelCover       string     `gorm:"type:varbinary(36);" json:"Cover" yaml:"Cover,omitempty"`
+	LabelCrop        string     `gorm:"type:varbinary(64);" json:"Crop" yaml:"Crop,omitempty"`
 	LabelCategories  []*Label   `gorm:"many2many:categories;association_jointable_foreignkey:category_id" json:"-" yaml:"-"`
 	Links            []Link     `gorm:"foreignkey:share_uid;association_foreignkey:label_uid" json:"Links" yaml:"-"`
 	PhotoCount       int        `gorm:"default:1" json:"PhotoCount" yaml:"-"`
@@ -100,6 +102,19 @@ func (m *Label) AfterCreate(scope *gorm.Scope) error {
 	return nil
 }
 
+// SetCover sets the photo and optional image region, e.g. a face, that represent the label in previews.
+// An empty photo UID resets the cover, so that the best matching photo is used again.
+func (m *Label) SetCover(photoUID, crop string) error {
+	if photoUID == "" {
+		crop = ""
+	}
+
+	m.LabelCover = photoUID
+	m.LabelCrop = crop
+
+	return Db().Model(m).Updates(map[string]interface{}{"label_cover": photoUID, "label_crop": crop}).Error
+}
+
 // SetName changes the label name.
 func (m *Label) SetName(name string) {
 	newName := txt.Clip(name, txt.ClipDefault)
@@ -131,3 +131,40 @@ func TestLabel_Update(t *testing.T) {
 
 	})
 }
+
+func TestLabel_SetCover(t *testing.T) {
+	label := NewLabel("Cover Person", 0)
+
+	if err := label.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("photo and crop", func(t *testing.T) {
+		if err := label.SetCover("pt9jtdre2lvl0yh7", "0.250,0.100,0.200,0.300"); err != nil {
+			t.Fatal(err)
+		}
+
+		var result Label
+
+		if err := Db().First(&result, "label_uid = ?", label.LabelUID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", result.LabelCover)
+		assert.Equal(t, "0.250,0.100,0.200,0.300", result.LabelCrop)
+	})
+	t.Run("reset", func(t *testing.T) {
+		if err := label.SetCover("", "0.250,0.100,0.200,0.300"); err != nil {
+			t.Fatal(err)
+		}
+
+		var result Label
+
+		if err := Db().First(&result, "label_uid = ?", label.LabelUID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", result.LabelCover)
+		assert.Equal(t, "", result.LabelCrop)
+	})
+}
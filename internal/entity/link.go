@@ -1,20 +1,52 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/gosimple/slug"
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// LinkSlugLength is the maximum length of vanity slugs.
+const LinkSlugLength = 64
+
+var (
+	ErrLinkSlugInvalid   = errors.New("link: invalid slug")
+	ErrLinkSlugTaken     = errors.New("link: slug already in use")
+	ErrLinkDomainInvalid = errors.New("link: invalid domain")
+	ErrLinkDomainTaken   = errors.New("link: domain already in use")
+)
+
+var linkDomainRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
 // Link represents a sharing link.
 type Link struct {
 	LinkToken    string     `gorm:"type:varbinary(255);primary_key;" json:"Token"`
-	LinkPassword string     `gorm:"type:varbinary(255);" json:"Password"`
+	LinkPassword string     `gorm:"type:varbinary(512);" json:"-"`
+	HasPassword  bool       `gorm:"-" json:"HasPassword"`
 	LinkExpires  *time.Time `gorm:"type:datetime;" json:"Expires"`
+	LinkRenewal  int        `json:"Renewal"`
+	LinkNotified *time.Time `gorm:"type:datetime;" json:"Notified,omitempty"`
+	LinkSlug     string     `gorm:"type:varbinary(255);index;" json:"Slug"`
+	LinkDomain   string     `gorm:"type:varbinary(255);index;" json:"Domain"`
 	ShareUID     string     `gorm:"type:varbinary(36);index;" json:"ShareUID"`
+	LinkSubtree  bool       `json:"Subtree"`
+	LinkIPs      string     `gorm:"type:varbinary(1024);" json:"AllowedIPs"`
+	LinkOrigins  string     `gorm:"type:varbinary(1024);" json:"AllowedOrigins"`
 	CanComment   bool       `json:"CanComment"`
 	CanEdit      bool       `json:"CanEdit"`
+	CanDownload  bool       `json:"CanDownload"`
+	WmText       string     `gorm:"type:varchar(255);" json:"WatermarkText"`
+	WmImage      string     `gorm:"type:varchar(255);" json:"WatermarkImage"`
+	WmPosition   string     `gorm:"type:varbinary(16);" json:"WatermarkPosition"`
+	WmOpacity    float64    `json:"WatermarkOpacity"`
 	CreatedAt    time.Time  `deepcopier:"skip" json:"CreatedAt"`
 	UpdatedAt    time.Time  `deepcopier:"skip" json:"UpdatedAt"`
 	DeletedAt    *time.Time `deepcopier:"skip" sql:"index" json:"DeletedAt,omitempty"`
@@ -29,14 +61,240 @@ func (m *Link) BeforeCreate(scope *gorm.Scope) error {
 	return nil
 }
 
+// BeforeSave hashes the link password before it is stored, unless it is already hashed.
+func (m *Link) BeforeSave(scope *gorm.Scope) error {
+	if m.LinkPassword == "" || linkPasswordHashed(m.LinkPassword) {
+		return nil
+	}
+
+	if err := m.SetPassword(m.LinkPassword); err != nil {
+		return err
+	}
+
+	return scope.SetColumn("LinkPassword", m.LinkPassword)
+}
+
 // NewLink creates a sharing link.
 func NewLink(password string, canComment, canEdit bool) Link {
 	result := Link{
-		LinkToken:    rnd.Token(10),
-		LinkPassword: password,
-		CanComment:   canComment,
-		CanEdit:      canEdit,
+		LinkToken:  rnd.Token(10),
+		CanComment: canComment,
+		CanEdit:    canEdit,
+	}
+
+	if err := result.SetPassword(password); err != nil {
+		log.Errorf("link: %s", err)
 	}
 
 	return result
 }
+
+// Expired returns true if the link has an expiration date in the past.
+func (m *Link) Expired() bool {
+	if m.LinkExpires == nil {
+		return false
+	}
+
+	return m.LinkExpires.Before(time.Now())
+}
+
+// SetPassword sets the bcrypt hash of a new link password, an empty password removes it.
+func (m *Link) SetPassword(password string) error {
+	if password == "" {
+		m.LinkPassword = ""
+		m.HasPassword = false
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	if err != nil {
+		return err
+	}
+
+	m.LinkPassword = string(hash)
+	m.HasPassword = true
+
+	return nil
+}
+
+// AfterFind sets HasPassword, as the password hash isn't returned to clients.
+func (m *Link) AfterFind() error {
+	m.HasPassword = m.LinkPassword != ""
+
+	return nil
+}
+
+// CheckPassword returns true if the password matches or the link doesn't have one.
+func (m *Link) CheckPassword(password string) bool {
+	if m.LinkPassword == "" {
+		return true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(m.LinkPassword), []byte(password)) == nil
+}
+
+// linkPasswordHashed returns true if s is a bcrypt hash.
+func linkPasswordHashed(s string) bool {
+	_, err := bcrypt.Cost([]byte(s))
+
+	return err == nil
+}
+
+// HasWatermark returns true if shared thumbnails should get a watermark.
+func (m *Link) HasWatermark() bool {
+	return m.WmText != "" || m.WmImage != ""
+}
+
+// Path returns the share page path relative to the site url, using the vanity slug if set.
+func (m *Link) Path() string {
+	if m.LinkSlug != "" {
+		return "s/" + m.LinkSlug
+	}
+
+	return "s/" + m.LinkToken
+}
+
+// URL returns the share page url. Links with a custom domain use it instead of the site url.
+func (m *Link) URL(siteUrl string) string {
+	if m.LinkDomain == "" {
+		return strings.TrimRight(siteUrl, "/") + "/" + m.Path()
+	}
+
+	scheme := "https"
+
+	if strings.HasPrefix(siteUrl, "http://") {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%s://%s/%s", scheme, m.LinkDomain, m.Path())
+}
+
+// SetSlug changes the vanity slug, an empty string removes it. Previous slugs keep redirecting to the link.
+func (m *Link) SetSlug(s string) error {
+	newSlug := txt.Clip(slug.Make(s), LinkSlugLength)
+
+	if s != "" && newSlug == "" {
+		return ErrLinkSlugInvalid
+	} else if newSlug == m.LinkSlug {
+		return nil
+	} else if newSlug != "" && LinkSlugTaken(newSlug, m.LinkToken) {
+		return ErrLinkSlugTaken
+	}
+
+	oldSlug := m.LinkSlug
+
+	if err := Db().Model(m).UpdateColumn("link_slug", newSlug).Error; err != nil {
+		return err
+	}
+
+	m.LinkSlug = newSlug
+
+	if newSlug != "" {
+		if err := Db().Where("alias_slug = ?", newSlug).Delete(&LinkAlias{}).Error; err != nil {
+			return err
+		}
+	}
+
+	if oldSlug != "" {
+		return Db().Save(&LinkAlias{AliasSlug: oldSlug, LinkToken: m.LinkToken}).Error
+	}
+
+	return nil
+}
+
+// SetDomain changes the custom hostname of the share page, an empty string removes it.
+func (m *Link) SetDomain(domain string) error {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+
+	if domain != "" && !linkDomainRegexp.MatchString(domain) {
+		return ErrLinkDomainInvalid
+	} else if domain == m.LinkDomain {
+		return nil
+	}
+
+	if domain != "" {
+		var count int
+
+		if err := Db().Model(&Link{}).Where("link_domain = ? AND link_token <> ?", domain, m.LinkToken).Count(&count).Error; err != nil {
+			return err
+		} else if count > 0 {
+			return ErrLinkDomainTaken
+		}
+	}
+
+	if err := Db().Model(m).UpdateColumn("link_domain", domain).Error; err != nil {
+		return err
+	}
+
+	m.LinkDomain = domain
+
+	return nil
+}
+
+// SetScope changes whether an album link also shares all nested albums. Excluded albums are not shared,
+// including their descendants.
+func (m *Link) SetScope(subtree bool, exclude []string) error {
+	if err := Db().Model(m).UpdateColumn("link_subtree", subtree).Error; err != nil {
+		return err
+	}
+
+	m.LinkSubtree = subtree
+
+	if err := Db().Where("link_token = ?", m.LinkToken).Delete(&LinkExclusion{}).Error; err != nil {
+		return err
+	}
+
+	for _, albumUID := range exclude {
+		if albumUID == "" || albumUID == m.ShareUID {
+			continue
+		}
+
+		if err := Db().Save(&LinkExclusion{LinkToken: m.LinkToken, AlbumUID: albumUID}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete revokes the link, so that it can't be used anymore.
+func (m *Link) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// LinkSlugTaken returns true if the slug is used by another link, either as token, slug or previous slug.
+func LinkSlugTaken(slug, token string) bool {
+	var count int
+
+	if err := Db().Model(&Link{}).Where("link_token <> ? AND (link_token = ? OR link_slug = ?)", token, slug, slug).Count(&count).Error; err != nil {
+		log.Errorf("link: %s", err)
+		return true
+	} else if count > 0 {
+		return true
+	}
+
+	if err := Db().Model(&LinkAlias{}).Where("alias_slug = ? AND link_token <> ?", slug, token).Count(&count).Error; err != nil {
+		log.Errorf("link: %s", err)
+		return true
+	}
+
+	return count > 0
+}
+
+// UniqueLinkSlug returns the slug with a number appended if it's taken, e.g. "summer-2024-2".
+func UniqueLinkSlug(s, token string) string {
+	base := txt.Clip(slug.Make(s), LinkSlugLength-4)
+
+	if base == "" || !LinkSlugTaken(base, token) {
+		return base
+	}
+
+	for i := 2; i < 1000; i++ {
+		if result := fmt.Sprintf("%s-%d", base, i); !LinkSlugTaken(result, token) {
+			return result
+		}
+	}
+
+	return ""
+}
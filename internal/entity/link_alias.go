@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// LinkAlias represents a previous vanity slug of a share link, so that old urls keep working.
+type LinkAlias struct {
+	AliasSlug string `gorm:"type:varbinary(255);primary_key;auto_increment:false"`
+	LinkToken string `gorm:"type:varbinary(255);index;"`
+	CreatedAt time.Time
+}
+
+// TableName returns LinkAlias table identifier "links_aliases".
+func (LinkAlias) TableName() string {
+	return "links_aliases"
+}
@@ -0,0 +1,12 @@
+package entity
+
+// LinkExclusion represents a nested album that is not shared by a subtree link, see Link.SetScope().
+type LinkExclusion struct {
+	LinkToken string `gorm:"type:varbinary(255);primary_key;auto_increment:false"`
+	AlbumUID  string `gorm:"type:varbinary(36);primary_key;auto_increment:false"`
+}
+
+// TableName returns LinkExclusion table identifier "links_exclusions".
+func (LinkExclusion) TableName() string {
+	return "links_exclusions"
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// LinkRenewalMin is the shortest auto-renewal period, so that links are not renewed on every worker run.
+const LinkRenewalMin = 24 * time.Hour
+
+var ErrLinkRenewalInvalid = errors.New("link: renewal period must be at least one day")
+
+// ExpiresWithin returns true if the link has not expired yet, but will expire within the given duration.
+func (m *Link) ExpiresWithin(d time.Duration) bool {
+	if m.LinkExpires == nil || m.Expired() {
+		return false
+	}
+
+	return m.LinkExpires.Before(time.Now().Add(d))
+}
+
+// Renew extends the expiration date by the given duration, starting now if the link has already expired.
+// Links without expiration date are not changed.
+func (m *Link) Renew(d time.Duration) error {
+	if m.LinkExpires == nil {
+		return nil
+	}
+
+	start := time.Now()
+
+	if m.LinkExpires.After(start) {
+		start = *m.LinkExpires
+	}
+
+	expires := start.Add(d)
+
+	if err := Db().Model(m).UpdateColumns(map[string]interface{}{"link_expires": &expires, "link_notified": nil}).Error; err != nil {
+		return err
+	}
+
+	m.LinkExpires = &expires
+	m.LinkNotified = nil
+
+	return nil
+}
+
+// SetRenewal changes the period in seconds by which the link is renewed automatically before it expires,
+// 0 disables auto-renewal.
+func (m *Link) SetRenewal(seconds int) error {
+	if seconds < 0 || (seconds > 0 && time.Duration(seconds)*time.Second < LinkRenewalMin) {
+		return ErrLinkRenewalInvalid
+	}
+
+	if err := Db().Model(m).UpdateColumn("link_renewal", seconds).Error; err != nil {
+		return err
+	}
+
+	m.LinkRenewal = seconds
+
+	return nil
+}
+
+// SetNotified marks the link as notified, so that the owner is reminded of the expiration date only once.
+func (m *Link) SetNotified() error {
+	now := time.Now().UTC()
+
+	if err := Db().Model(m).UpdateColumn("link_notified", &now).Error; err != nil {
+		return err
+	}
+
+	m.LinkNotified = &now
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink_ExpiresWithin(t *testing.T) {
+	t.Run("no expiration date", func(t *testing.T) {
+		link := NewLink("", false, false)
+		assert.False(t, link.ExpiresWithin(24*time.Hour))
+	})
+	t.Run("expires soon", func(t *testing.T) {
+		expires := time.Now().Add(time.Hour)
+		link := Link{LinkExpires: &expires}
+		assert.True(t, link.ExpiresWithin(24*time.Hour))
+		assert.False(t, link.ExpiresWithin(time.Minute))
+	})
+	t.Run("expired", func(t *testing.T) {
+		expires := time.Now().Add(-time.Hour)
+		link := Link{LinkExpires: &expires}
+		assert.False(t, link.ExpiresWithin(24*time.Hour))
+	})
+}
+
+func TestLink_Renew(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	link := NewLink("", false, false)
+	link.LinkExpires = &expires
+
+	if err := Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, link.SetNotified())
+	assert.NotNil(t, link.LinkNotified)
+
+	assert.NoError(t, link.Renew(48*time.Hour))
+	assert.Nil(t, link.LinkNotified)
+	assert.WithinDuration(t, expires.Add(48*time.Hour), *link.LinkExpires, time.Second)
+
+	t.Run("expired", func(t *testing.T) {
+		expired := time.Now().Add(-72 * time.Hour)
+		link.LinkExpires = &expired
+
+		assert.NoError(t, link.Renew(24*time.Hour))
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), *link.LinkExpires, time.Minute)
+	})
+	t.Run("no expiration date", func(t *testing.T) {
+		m := NewLink("", false, false)
+		assert.NoError(t, m.Renew(24*time.Hour))
+		assert.Nil(t, m.LinkExpires)
+	})
+}
+
+func TestLink_SetRenewal(t *testing.T) {
+	link := NewLink("", false, false)
+
+	if err := Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, link.SetRenewal(365*24*3600))
+	assert.Equal(t, 365*24*3600, link.LinkRenewal)
+	assert.Equal(t, ErrLinkRenewalInvalid, link.SetRenewal(3600))
+	assert.Equal(t, ErrLinkRenewalInvalid, link.SetRenewal(-1))
+	assert.NoError(t, link.SetRenewal(0))
+	assert.Equal(t, 0, link.LinkRenewal)
+}
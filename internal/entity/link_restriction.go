@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrLinkIPInvalid     = errors.New("link: invalid ip range")
+	ErrLinkOriginInvalid = errors.New("link: invalid origin")
+)
+
+// AllowedIPs returns the IP ranges a link may be used from, an empty list allows all addresses.
+func (m *Link) AllowedIPs() []string {
+	return splitList(m.LinkIPs)
+}
+
+// AllowedOrigins returns the origins that may embed or refer to a link, an empty list allows all origins.
+func (m *Link) AllowedOrigins() []string {
+	return splitList(m.LinkOrigins)
+}
+
+// AllowsIP returns true if the client IP is within one of the allowed ranges.
+func (m *Link) AllowsIP(clientIP string) bool {
+	ranges := m.AllowedIPs()
+
+	if len(ranges) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(clientIP)
+
+	if ip == nil {
+		return false
+	}
+
+	for _, r := range ranges {
+		if _, network, err := net.ParseCIDR(r); err == nil && network.Contains(ip) {
+			return true
+		} else if allowed := net.ParseIP(r); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsOrigin returns true if the origin, e.g. "https://intranet.example.com", may embed or refer to the link.
+// Requests without origin are allowed, as browsers may not send a referrer.
+func (m *Link) AllowsOrigin(origin string) bool {
+	origins := m.AllowedOrigins()
+
+	if len(origins) == 0 || origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(strings.ToLower(origin))
+
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	for _, o := range origins {
+		allowed, err := url.Parse(o)
+
+		if err != nil || allowed.Scheme != u.Scheme {
+			continue
+		}
+
+		if allowed.Host == u.Host {
+			return true
+		} else if strings.HasPrefix(allowed.Host, "*.") && strings.HasSuffix(u.Host, allowed.Host[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetRestrictions changes the allowed IP ranges and origins, empty lists remove the restrictions.
+func (m *Link) SetRestrictions(ips, origins []string) error {
+	var validIPs, validOrigins []string
+
+	for _, ip := range ips {
+		if ip = strings.TrimSpace(ip); ip == "" {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(ip); err != nil && net.ParseIP(ip) == nil {
+			return ErrLinkIPInvalid
+		}
+
+		validIPs = append(validIPs, ip)
+	}
+
+	for _, origin := range origins {
+		if origin = strings.ToLower(strings.TrimRight(strings.TrimSpace(origin), "/")); origin == "" {
+			continue
+		}
+
+		u, err := url.Parse(origin)
+
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+			return ErrLinkOriginInvalid
+		}
+
+		validOrigins = append(validOrigins, u.Scheme+"://"+u.Host)
+	}
+
+	linkIPs := strings.Join(validIPs, ",")
+	linkOrigins := strings.Join(validOrigins, ",")
+
+	if err := Db().Model(m).UpdateColumns(map[string]interface{}{"link_ips": linkIPs, "link_origins": linkOrigins}).Error; err != nil {
+		return err
+	}
+
+	m.LinkIPs = linkIPs
+	m.LinkOrigins = linkOrigins
+
+	return nil
+}
+
+// splitList returns the non-empty values of a comma separated list.
+func splitList(s string) (result []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
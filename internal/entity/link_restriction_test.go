@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink_AllowsIP(t *testing.T) {
+	t.Run("unrestricted", func(t *testing.T) {
+		link := Link{}
+		assert.True(t, link.AllowsIP("203.0.113.7"))
+	})
+	t.Run("range", func(t *testing.T) {
+		link := Link{LinkIPs: "10.0.0.0/8,192.168.1.20"}
+		assert.True(t, link.AllowsIP("10.12.0.1"))
+		assert.True(t, link.AllowsIP("192.168.1.20"))
+		assert.False(t, link.AllowsIP("192.168.1.21"))
+		assert.False(t, link.AllowsIP("invalid"))
+	})
+}
+
+func TestLink_AllowsOrigin(t *testing.T) {
+	t.Run("unrestricted", func(t *testing.T) {
+		link := Link{}
+		assert.True(t, link.AllowsOrigin("https://example.com"))
+	})
+	t.Run("restricted", func(t *testing.T) {
+		link := Link{LinkOrigins: "https://intranet.example.com,https://*.corp.example.com"}
+		assert.True(t, link.AllowsOrigin(""))
+		assert.True(t, link.AllowsOrigin("https://intranet.example.com"))
+		assert.True(t, link.AllowsOrigin("https://wiki.corp.example.com"))
+		assert.False(t, link.AllowsOrigin("http://intranet.example.com"))
+		assert.False(t, link.AllowsOrigin("https://example.com"))
+	})
+}
+
+func TestLink_SetRestrictions(t *testing.T) {
+	link := NewLink("", false, false)
+
+	if err := Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, link.SetRestrictions([]string{" 10.0.0.0/8 ", "", "192.168.1.20"}, []string{"https://Intranet.example.com/"}))
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.20"}, link.AllowedIPs())
+	assert.Equal(t, []string{"https://intranet.example.com"}, link.AllowedOrigins())
+	assert.Equal(t, ErrLinkIPInvalid, link.SetRestrictions([]string{"10.0.0.0/33"}, nil))
+	assert.Equal(t, ErrLinkOriginInvalid, link.SetRestrictions(nil, []string{"intranet.example.com"}))
+	assert.Equal(t, ErrLinkOriginInvalid, link.SetRestrictions(nil, []string{"https://example.com/page"}))
+	assert.NoError(t, link.SetRestrictions(nil, nil))
+	assert.Empty(t, link.AllowedIPs())
+	assert.Empty(t, link.AllowedOrigins())
+}
@@ -1,14 +1,193 @@
 package entity
 
 import (
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewLink(t *testing.T) {
 	link := NewLink("passwd12", true, false)
-	assert.Equal(t, "passwd12", link.LinkPassword)
+	assert.NotEqual(t, "passwd12", link.LinkPassword)
+	assert.True(t, link.CheckPassword("passwd12"))
+	assert.True(t, link.HasPassword)
+	assert.False(t, NewLink("", false, false).HasPassword)
 	assert.Equal(t, false, link.CanEdit)
 	assert.Equal(t, true, link.CanComment)
 	assert.Equal(t, 10, len(link.LinkToken))
 }
+
+func TestLink_CheckPassword(t *testing.T) {
+	t.Run("no password", func(t *testing.T) {
+		link := NewLink("", false, false)
+		assert.True(t, link.CheckPassword(""))
+		assert.True(t, link.CheckPassword("foobar"))
+	})
+	t.Run("password", func(t *testing.T) {
+		link := NewLink("passwd12", false, false)
+		assert.True(t, link.CheckPassword("passwd12"))
+		assert.False(t, link.CheckPassword(""))
+		assert.False(t, link.CheckPassword("passwd1"))
+	})
+	t.Run("hashed on save", func(t *testing.T) {
+		link := NewLink("", false, false)
+		link.LinkPassword = "passwd12"
+
+		if err := Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, linkPasswordHashed(link.LinkPassword))
+		assert.True(t, link.CheckPassword("passwd12"))
+	})
+}
+
+func TestLink_HasPassword(t *testing.T) {
+	link := NewLink("passwd12", false, false)
+
+	if err := Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var found Link
+
+	if err := Db().Where("link_token = ?", link.LinkToken).First(&found).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, found.HasPassword)
+
+	data, err := json.Marshal(found)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(data), `"HasPassword":true`)
+	assert.NotContains(t, string(data), found.LinkPassword)
+}
+
+func TestLink_Expired(t *testing.T) {
+	t.Run("no expiration", func(t *testing.T) {
+		link := NewLink("", false, false)
+		assert.False(t, link.Expired())
+	})
+	t.Run("fixture", func(t *testing.T) {
+		link := LinkFixtures["1jxf3jfn2k"]
+		assert.False(t, link.Expired())
+	})
+}
+
+func TestLink_Delete(t *testing.T) {
+	link := NewLink("", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := link.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+
+	if err := Db().Model(&Link{}).Where("link_token = ?", link.LinkToken).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+}
+
+func TestLink_HasWatermark(t *testing.T) {
+	link := NewLink("", false, false)
+	assert.False(t, link.HasWatermark())
+	link.WmText = "Proof"
+	assert.True(t, link.HasWatermark())
+}
+
+func TestLink_Path(t *testing.T) {
+	link := Link{LinkToken: "1jxf3jfn2k"}
+	assert.Equal(t, "s/1jxf3jfn2k", link.Path())
+
+	link.LinkSlug = "summer-2024"
+	assert.Equal(t, "s/summer-2024", link.Path())
+}
+
+func TestLink_URL(t *testing.T) {
+	link := Link{LinkToken: "1jxf3jfn2k", LinkSlug: "summer-2024"}
+
+	assert.Equal(t, "https://photos.example.com/s/summer-2024", link.URL("https://photos.example.com/"))
+
+	link.LinkDomain = "summer.example.com"
+
+	assert.Equal(t, "https://summer.example.com/s/summer-2024", link.URL("https://photos.example.com/"))
+	assert.Equal(t, "http://summer.example.com/s/summer-2024", link.URL("http://localhost:2342/"))
+}
+
+func TestLink_SetSlug(t *testing.T) {
+	first := NewLink("", false, false)
+	first.ShareUID = "at9lxuqxpogaaba7"
+
+	if err := Db().Create(&first).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	second := NewLink("", false, false)
+	second.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := Db().Create(&second).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("new slug", func(t *testing.T) {
+		assert.NoError(t, first.SetSlug("Summer 2024"))
+		assert.Equal(t, "summer-2024", first.LinkSlug)
+	})
+	t.Run("taken", func(t *testing.T) {
+		assert.Equal(t, ErrLinkSlugTaken, second.SetSlug("summer-2024"))
+		assert.Equal(t, ErrLinkSlugTaken, second.SetSlug(first.LinkToken))
+		assert.Equal(t, "summer-2024-2", UniqueLinkSlug("Summer 2024", second.LinkToken))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, ErrLinkSlugInvalid, second.SetSlug("!!!"))
+	})
+	t.Run("previous slug is kept", func(t *testing.T) {
+		assert.NoError(t, first.SetSlug("summer-holidays"))
+		assert.True(t, LinkSlugTaken("summer-2024", second.LinkToken))
+		assert.False(t, LinkSlugTaken("summer-2024", first.LinkToken))
+
+		// Previous slugs can be used again by the same link.
+		assert.NoError(t, first.SetSlug("summer-2024"))
+		assert.Equal(t, "summer-2024", first.LinkSlug)
+	})
+	t.Run("remove", func(t *testing.T) {
+		assert.NoError(t, second.SetSlug("winter-2024"))
+		assert.NoError(t, second.SetSlug(""))
+		assert.Equal(t, "", second.LinkSlug)
+		assert.True(t, LinkSlugTaken("winter-2024", first.LinkToken))
+	})
+}
+
+func TestLink_SetDomain(t *testing.T) {
+	first := NewLink("", false, false)
+
+	if err := Db().Create(&first).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	second := NewLink("", false, false)
+
+	if err := Db().Create(&second).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, first.SetDomain(" Wedding.Example.com "))
+	assert.Equal(t, "wedding.example.com", first.LinkDomain)
+	assert.Equal(t, ErrLinkDomainTaken, second.SetDomain("wedding.example.com"))
+	assert.Equal(t, ErrLinkDomainInvalid, second.SetDomain("https://wedding.example.com/"))
+	assert.Equal(t, ErrLinkDomainInvalid, second.SetDomain("localhost"))
+	assert.NoError(t, first.SetDomain(""))
+	assert.Equal(t, "", first.LinkDomain)
+}
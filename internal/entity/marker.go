@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+const (
+	MarkerFace = "face"
+)
+
+// Marker represents an image region, e.g. a face, that is assigned to a subject.
+// Region coordinates and size are relative to the image dimensions.
+type Marker struct {
+	ID         uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	MarkerUID  string    `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	PhotoUID   string    `gorm:"type:varbinary(36);index;" json:"PhotoUID" yaml:"-"`
+	SubjUID    string    `gorm:"type:varbinary(36);index;" json:"SubjUID" yaml:"SubjUID"`
+	MarkerType string    `gorm:"type:varbinary(8);" json:"Type" yaml:"Type"`
+	MarkerSrc  string    `gorm:"type:varbinary(8);" json:"Src" yaml:"Src"`
+	X          float32   `gorm:"type:FLOAT;" json:"X" yaml:"X"`
+	Y          float32   `gorm:"type:FLOAT;" json:"Y" yaml:"Y"`
+	W          float32   `gorm:"type:FLOAT;" json:"W" yaml:"W"`
+	H          float32   `gorm:"type:FLOAT;" json:"H" yaml:"H"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns Marker table identifier "markers".
+func (Marker) TableName() string {
+	return "markers"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Marker) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.MarkerUID, 'x') {
+		return nil
+	}
+
+	return scope.SetColumn("MarkerUID", rnd.PPID('x'))
+}
+
+// NewMarker creates a marker for a subject in a photo. Regions can't be detected yet, so the
+// marker covers the whole image.
+func NewMarker(photoUID, subjUID, markerType, markerSrc string) *Marker {
+	result := &Marker{
+		PhotoUID:   photoUID,
+		SubjUID:    subjUID,
+		MarkerType: markerType,
+		MarkerSrc:  markerSrc,
+		W:          1,
+		H:          1,
+	}
+
+	return result
+}
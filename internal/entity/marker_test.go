@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMarker(t *testing.T) {
+	m := NewMarker("pt9jtdre2lvl0yh7", "jqu0xs11qekk9jx8", MarkerFace, SrcManual)
+
+	assert.Equal(t, "pt9jtdre2lvl0yh7", m.PhotoUID)
+	assert.Equal(t, "jqu0xs11qekk9jx8", m.SubjUID)
+	assert.Equal(t, MarkerFace, m.MarkerType)
+	assert.Equal(t, SrcManual, m.MarkerSrc)
+	assert.Equal(t, float32(0), m.X)
+	assert.Equal(t, float32(0), m.Y)
+	assert.Equal(t, float32(1), m.W)
+	assert.Equal(t, float32(1), m.H)
+}
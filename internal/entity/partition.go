@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PartitionClause returns a MySQL clause that partitions a table by year, with one partition per year
+// from "from" to "to" and two additional partitions for earlier or unknown and later years.
+func PartitionClause(column string, from, to int) string {
+	var parts []string
+
+	parts = append(parts, fmt.Sprintf("PARTITION p_early VALUES LESS THAN (%d)", from))
+
+	for year := from; year <= to; year++ {
+		parts = append(parts, fmt.Sprintf("PARTITION p%d VALUES LESS THAN (%d)", year, year+1))
+	}
+
+	parts = append(parts, "PARTITION p_later VALUES LESS THAN MAXVALUE")
+
+	return fmt.Sprintf("PARTITION BY RANGE (%s) (%s)", column, strings.Join(parts, ", "))
+}
+
+// keyHasColumn returns true if the index of a table contains the column.
+func keyHasColumn(table, index, column string) (bool, error) {
+	var count int
+
+	err := UnscopedDb().Table("information_schema.statistics").
+		Where("table_schema = DATABASE() AND table_name = ? AND index_name = ? AND column_name = ?", table, index, column).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// PartitionPhotos partitions the photos table by year, so that searches filtering by year only read
+// the matching partitions. MySQL requires the partition column to be part of every unique key, so the
+// primary key and the unique photo_uid index are extended by photo_year first. Running it again
+// repartitions the table, e.g. to add partitions for new years.
+//
+// The database then only ensures that a UID is unique within a year, so photos are checked for an
+// existing UID before they are created instead, see Photo.BeforeCreate. Rows inserted by other means
+// than the Photo entity, e.g. by hand, are not checked.
+//
+// The files table is not partitioned. It has no year column, and MySQL would require one in its unique
+// indexes of file UIDs and of file names and roots, so the database could no longer reject duplicate files.
+func PartitionPhotos(from, to int) error {
+	if from > to {
+		return fmt.Errorf("partition: invalid year range %d-%d", from, to)
+	}
+
+	db := UnscopedDb()
+
+	if ok, err := keyHasColumn("photos", "PRIMARY", "photo_year"); err != nil {
+		return err
+	} else if !ok {
+		log.Infof("partition: adding photo_year to primary key of photos")
+
+		if err := db.Exec("ALTER TABLE photos DROP PRIMARY KEY, ADD PRIMARY KEY (id, photo_year)").Error; err != nil {
+			return err
+		}
+	}
+
+	if ok, err := keyHasColumn("photos", "uix_photos_photo_uid", "photo_year"); err != nil {
+		return err
+	} else if !ok {
+		log.Infof("partition: adding photo_year to unique index uix_photos_photo_uid")
+
+		if err := db.Exec("ALTER TABLE photos DROP INDEX uix_photos_photo_uid, ADD UNIQUE INDEX uix_photos_photo_uid (photo_uid, photo_year)").Error; err != nil {
+			return err
+		}
+	}
+
+	log.Infof("partition: partitioning photos by year from %d to %d", from, to)
+
+	return db.Exec("ALTER TABLE photos " + PartitionClause("photo_year", from, to)).Error
+}
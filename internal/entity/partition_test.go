@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionClause(t *testing.T) {
+	t.Run("range", func(t *testing.T) {
+		result := PartitionClause("photo_year", 2019, 2020)
+
+		assert.Equal(t, "PARTITION BY RANGE (photo_year) (PARTITION p_early VALUES LESS THAN (2019), PARTITION p2019 VALUES LESS THAN (2020), PARTITION p2020 VALUES LESS THAN (2021), PARTITION p_later VALUES LESS THAN MAXVALUE)", result)
+	})
+	t.Run("unknown year", func(t *testing.T) {
+		result := PartitionClause("photo_year", 1990, 1990)
+
+		assert.Contains(t, result, "PARTITION p_early VALUES LESS THAN (1990)")
+		assert.Less(t, YearUnknown, 1990)
+	})
+}
+
+func TestPartitionPhotos(t *testing.T) {
+	t.Run("invalid range", func(t *testing.T) {
+		err := PartitionPhotos(2020, 2019)
+
+		assert.Error(t, err)
+	})
+}
@@ -15,6 +15,10 @@ import (
 	"github.com/ulule/deepcopier"
 )
 
+// ErrPhotoUIDExists is returned if a photo is created with the UID of another photo. Unique indexes of
+// partitioned tables must contain the partition column, so this is checked before insert, see PartitionPhotos.
+var ErrPhotoUIDExists = errors.New("photo: uid already exists")
+
 // Photo represents a photo, all its properties, and link to all its images and sidecar files.
 type Photo struct {
 	ID               uint         `gorm:"primary_key" yaml:"-"`
@@ -22,8 +26,11 @@ type Photo struct {
 	TakenAt          time.Time    `gorm:"type:datetime;index:idx_photos_taken_uid;" json:"TakenAt" yaml:"TakenAt"`
 	TakenAtLocal     time.Time    `gorm:"type:datetime;" yaml:"-"`
 	TakenSrc         string       `gorm:"type:varbinary(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
+	TakenApprox      string       `gorm:"type:varbinary(8);" json:"TakenApprox" yaml:"TakenApprox,omitempty"`
 	PhotoUID         string       `gorm:"type:varbinary(36);unique_index;index:idx_photos_taken_uid;" json:"UID" yaml:"UID"`
 	PhotoType        string       `gorm:"type:varbinary(8);default:'image';" json:"Type" yaml:"Type"`
+	PhotoCategory    string       `gorm:"type:varbinary(16);index;" json:"Category" yaml:"Category,omitempty"`
+	CategorySrc      string       `gorm:"type:varbinary(8);" json:"CategorySrc" yaml:"CategorySrc,omitempty"`
 	PhotoTitle       string       `gorm:"type:varchar(255);" json:"Title" yaml:"Title"`
 	TitleSrc         string       `gorm:"type:varbinary(8);" json:"TitleSrc" yaml:"TitleSrc,omitempty"`
 	PhotoDescription string       `gorm:"type:text;" json:"Description" yaml:"Description,omitempty"`
@@ -33,6 +40,8 @@ type Photo struct {
 	PhotoName        string       `gorm:"type:varbinary(255);" yaml:"-"`
 	PhotoFavorite    bool         `json:"Favorite" yaml:"Favorite,omitempty"`
 	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
+	PhotoNSFW        float32      `gorm:"type:FLOAT;" json:"NSFW" yaml:"NSFW,omitempty"`
+	NSFWReview       bool         `gorm:"index;" json:"NSFWReview" yaml:"NSFWReview,omitempty"`
 	TimeZone         string       `gorm:"type:varbinary(64);" json:"TimeZone" yaml:"-"`
 	PlaceUID         string       `gorm:"type:varbinary(16);index;" json:"PlaceUID" yaml:"-"`
 	LocUID           string       `gorm:"type:varbinary(16);index;" json:"LocUID" yaml:"-"`
@@ -40,6 +49,8 @@ type Photo struct {
 	PhotoLat         float32      `gorm:"type:FLOAT;index;" json:"Lat" yaml:"Lat,omitempty"`
 	PhotoLng         float32      `gorm:"type:FLOAT;index;" json:"Lng" yaml:"Lng,omitempty"`
 	PhotoAltitude    int          `json:"Altitude" yaml:"Altitude,omitempty"`
+	PhotoDirection   float32      `gorm:"type:FLOAT;" json:"Direction" yaml:"Direction,omitempty"`
+	PhotoSpeed       float32      `gorm:"type:FLOAT;" json:"Speed" yaml:"Speed,omitempty"`
 	PhotoCountry     string       `gorm:"type:varbinary(2);index:idx_photos_country_year_month;default:'zz'" json:"Country" yaml:"-"`
 	PhotoYear        int          `gorm:"index:idx_photos_country_year_month;" json:"Year" yaml:"-"`
 	PhotoMonth       int          `gorm:"index:idx_photos_country_year_month;" json:"Month" yaml:"-"`
@@ -48,10 +59,11 @@ type Photo struct {
 	PhotoFNumber     float32      `gorm:"type:FLOAT;" json:"FNumber" yaml:"FNumber,omitempty"`
 	PhotoFocalLength int          `json:"FocalLength" yaml:"FocalLength,omitempty"`
 	PhotoQuality     int          `gorm:"type:SMALLINT" json:"Quality" yaml:"-"`
-	PhotoResolution  int          `gorm:"type:SMALLINT" json:"Resolution" yaml:"-"`
+	PhotoResolution  int          `gorm:"type:SMALLINT;index;" json:"Resolution" yaml:"-"`
 	CameraID         uint         `gorm:"index:idx_photos_camera_lens;" json:"CameraID" yaml:"-"`
 	CameraSerial     string       `gorm:"type:varbinary(255);" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
 	CameraSrc        string       `gorm:"type:varbinary(8);" json:"CameraSrc" yaml:"-"`
+	DeviceUID        string       `gorm:"type:varbinary(36);index;" json:"DeviceUID" yaml:"DeviceUID,omitempty"`
 	LensID           uint         `gorm:"index:idx_photos_camera_lens;" json:"LensID" yaml:"-"`
 	Camera           *Camera      `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Camera" yaml:"-"`
 	Lens             *Lens        `gorm:"association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Lens" yaml:"-"`
@@ -62,6 +74,7 @@ type Photo struct {
 	Albums           []Album      `json:"-" yaml:"-"`
 	Files            []File       `yaml:"-"`
 	Labels           []PhotoLabel `yaml:"-"`
+	PhotoVersion     uint         `gorm:"not null;default:0;" json:"Version" yaml:"-"`
 	CreatedAt        time.Time    `yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time    `yaml:"UpdatedAt,omitempty"`
 	EditedAt         *time.Time   `yaml:"EditedAt,omitempty"`
@@ -73,6 +86,7 @@ type Photo struct {
 func SavePhotoForm(model Photo, form form.Photo, geoApi string) error {
 	db := Db()
 	locChanged := model.PhotoLat != form.PhotoLat || model.PhotoLng != form.PhotoLng
+	original := model
 
 	if err := deepcopier.Copy(&model).From(form); err != nil {
 		return err
@@ -82,6 +96,11 @@ func SavePhotoForm(model Photo, form form.Photo, geoApi string) error {
 		return errors.New("photo: can't save form, id is empty")
 	}
 
+	// Dates changed by the user are exact.
+	if !model.TakenAt.Equal(original.TakenAt) {
+		model.TakenApprox = ""
+	}
+
 	model.UpdateYearMonth()
 
 	if form.Details.PhotoID == model.ID {
@@ -92,6 +111,13 @@ func SavePhotoForm(model Photo, form form.Photo, geoApi string) error {
 		model.Details.Keywords = strings.Join(txt.UniqueKeywords(model.Details.Keywords), ", ")
 	}
 
+	if !ValidCategory(model.PhotoCategory) {
+		model.PhotoCategory = original.PhotoCategory
+	}
+
+	// Make sure manual changes are not overwritten when files are indexed again.
+	model.LockEdited(original)
+
 	if model.HasLatLng() && locChanged && model.LocSrc == SrcManual {
 		locKeywords, labels := model.UpdateLocation(geoApi)
 
@@ -194,13 +220,27 @@ func (m *Photo) BeforeCreate(scope *gorm.Scope) error {
 	}
 
 	if rnd.IsUID(m.PhotoUID, 'p') {
-		return nil
+		return m.checkUID()
 	}
 
 	return scope.SetColumn("PhotoUID", rnd.PPID('p'))
 }
 
+// checkUID returns ErrPhotoUIDExists if another photo, including archived photos, has the same UID.
+func (m *Photo) checkUID() error {
+	var count int
+
+	if err := UnscopedDb().Model(&Photo{}).Where("photo_uid = ?", m.PhotoUID).Count(&count).Error; err != nil {
+		return err
+	} else if count > 0 {
+		return ErrPhotoUIDExists
+	}
+
+	return nil
+}
+
 // BeforeSave ensures the existence of TakenAt properties before indexing or updating a photo
+// and increments the version number, see VersionTag().
 func (m *Photo) BeforeSave(scope *gorm.Scope) error {
 	if m.TakenAt.IsZero() || m.TakenAtLocal.IsZero() {
 		now := time.Now()
@@ -214,7 +254,17 @@ func (m *Photo) BeforeSave(scope *gorm.Scope) error {
 		}
 	}
 
-	return nil
+	// Partial updates don't change the version, as other values may not have been loaded.
+	if _, partial := scope.InstanceGet("gorm:update_attrs"); partial {
+		return nil
+	}
+
+	return scope.SetColumn("PhotoVersion", m.PhotoVersion+1)
+}
+
+// VersionTag returns the entity tag of the current version, e.g. to detect conflicting changes.
+func (m *Photo) VersionTag() string {
+	return VersionTag(m.PhotoVersion)
 }
 
 // IndexKeywords adds given keywords to the photo entry
@@ -533,6 +583,7 @@ func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 
 	m.TakenAt = taken.Round(time.Second).UTC()
 	m.TakenSrc = source
+	m.TakenApprox = ""
 
 	if local.IsZero() || local.Year() < 1000 {
 		m.TakenAtLocal = m.TakenAt
@@ -560,6 +611,9 @@ func (m *Photo) UpdateYearMonth() {
 	if m.TakenSrc == SrcAuto {
 		m.PhotoYear = YearUnknown
 		m.PhotoMonth = MonthUnknown
+	} else if m.TakenApprox != "" {
+		m.PhotoYear = m.TakenAtLocal.Year()
+		m.PhotoMonth = MonthUnknown
 	} else {
 		m.PhotoYear = m.TakenAtLocal.Year()
 		m.PhotoMonth = int(m.TakenAtLocal.Month())
@@ -582,6 +636,21 @@ func (m *Photo) SetCoordinates(lat, lng float32, altitude int, source string) {
 	m.LocSrc = source
 }
 
+// SetDirection changes the photo direction (compass bearing in degrees) and speed (km/h) if the location
+// has the same source.
+func (m *Photo) SetDirection(direction, speed float32, source string) {
+	if direction == 0 && speed == 0 {
+		return
+	}
+
+	if m.LocSrc != source && source != SrcManual {
+		return
+	}
+
+	m.PhotoDirection = direction
+	m.PhotoSpeed = speed
+}
+
 // AllFilesMissing returns true, if all files for this photo are missing.
 func (m *Photo) AllFilesMissing() bool {
 	count := 0
@@ -612,6 +681,7 @@ func (m *Photo) DeletePermanently() error {
 	Db().Unscoped().Delete(PhotoKeyword{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoLabel{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID)
+	Db().Unscoped().Delete(PhotoEmbedding{}, "photo_uid = ?", m.PhotoUID)
 
 	return Db().Unscoped().Delete(m).Error
 }
@@ -631,7 +701,14 @@ func (m *Photo) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumns(values).Error
 }
 
-// SetFavorite updates the favorite status of a photo.
+// ApproveNSFW removes a photo that may be offensive from review.
+func (m *Photo) ApproveNSFW() error {
+	m.NSFWReview = false
+	m.PhotoQuality = m.QualityScore()
+
+	return m.Updates(map[string]interface{}{"NSFWReview": false, "PhotoQuality": m.PhotoQuality})
+}
+
 func (m *Photo) SetFavorite(favorite bool) error {
 	changed := m.PhotoFavorite != favorite
 	m.PhotoFavorite = favorite
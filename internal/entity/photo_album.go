@@ -2,6 +2,9 @@ package entity
 
 import (
 	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // PhotoAlbum represents the many_to_many relation between Photo and Album
@@ -10,6 +13,7 @@ type PhotoAlbum struct {
 	AlbumUID  string `gorm:"type:varbinary(36);primary_key;auto_increment:false;index"`
 	Order     int
 	Hidden    bool
+	Caption   string `gorm:"type:text;"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Photo     *Photo `gorm:"PRELOAD:false"`
@@ -49,3 +53,39 @@ func FirstOrCreatePhotoAlbum(m *PhotoAlbum) *PhotoAlbum {
 
 	return m
 }
+
+// SetAlbumPhotoOrder sets the manual order of photos in an album. Photos that aren't listed are shown after
+// the listed photos, sorted by date.
+func SetAlbumPhotoOrder(albumUID string, photoUIDs []string) error {
+	tx := Db().Begin()
+
+	if err := tx.Model(&PhotoAlbum{}).Where("album_uid = ?", albumUID).UpdateColumn("order", 0).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i, photoUID := range photoUIDs {
+		if err := tx.Model(&PhotoAlbum{}).Where("album_uid = ? AND photo_uid = ?", albumUID, photoUID).UpdateColumn("order", i+1).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// SetPhotoAlbumCaption changes the caption of a photo in an album, so that the same photo can have a
+// different caption in each album. An empty caption removes the override.
+func SetPhotoAlbumCaption(albumUID, photoUID, caption string) error {
+	result := Db().Model(&PhotoAlbum{}).
+		Where("album_uid = ? AND photo_uid = ?", albumUID, photoUID).
+		UpdateColumn("caption", txt.Clip(caption, txt.ClipDescription))
+
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"testing"
 
+	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,3 +38,68 @@ func TestFirstOrCreatePhotoAlbum(t *testing.T) {
 		t.Errorf("PhotoUID should be the same: %s %s", result.PhotoUID, model.PhotoUID)
 	}
 }
+
+func TestSetAlbumPhotoOrder(t *testing.T) {
+	album := NewAlbum("Ordered Album", TypeDefault)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, uid := range []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh8"} {
+		FirstOrCreatePhotoAlbum(NewPhotoAlbum(uid, album.AlbumUID))
+	}
+
+	order := func() map[string]int {
+		var rows []PhotoAlbum
+
+		if err := Db().Where("album_uid = ?", album.AlbumUID).Find(&rows).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		result := make(map[string]int)
+
+		for _, r := range rows {
+			result[r.PhotoUID] = r.Order
+		}
+
+		return result
+	}
+
+	if err := SetAlbumPhotoOrder(album.AlbumUID, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh7"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]int{"pt9jtdre2lvl0y11": 1, "pt9jtdre2lvl0yh7": 2, "pt9jtdre2lvl0yh8": 0}, order())
+
+	if err := SetAlbumPhotoOrder(album.AlbumUID, []string{"pt9jtdre2lvl0yh8"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]int{"pt9jtdre2lvl0y11": 0, "pt9jtdre2lvl0yh7": 0, "pt9jtdre2lvl0yh8": 1}, order())
+}
+
+func TestSetPhotoAlbumCaption(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		if err := SetPhotoAlbumCaption("at9lxuqxpogaaba9", "pt9jtdre2lvl0y11", " Checkpoint Charlie "); err != nil {
+			t.Fatal(err)
+		}
+
+		var m PhotoAlbum
+
+		if err := Db().Where("album_uid = ? AND photo_uid = ?", "at9lxuqxpogaaba9", "pt9jtdre2lvl0y11").First(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Checkpoint Charlie", m.Caption)
+
+		if err := SetPhotoAlbumCaption("at9lxuqxpogaaba9", "pt9jtdre2lvl0y11", ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("not in album", func(t *testing.T) {
+		err := SetPhotoAlbumCaption("at9lxuqxpogaaba9", "pt9jtdre2lvl0yxx", "Foo")
+
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+	})
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Precision of approximate dates, e.g. for scanned prints without Exif data.
+const (
+	ApproxYear   = "year"
+	ApproxDecade = "decade"
+)
+
+// SetApproxDate sets an estimated date for photos that don't have an exact one, e.g. scans of old prints.
+// The date is set to the middle of the year or decade, so that photos are sorted close to where they belong.
+// Approximate dates are displayed like "circa 1987" and locked against changes by indexing.
+func (m *Photo) SetApproxDate(year int, approx string) error {
+	if year < 1000 || year > txt.YearMax {
+		return fmt.Errorf("photo: invalid year %d", year)
+	}
+
+	var taken time.Time
+
+	switch approx {
+	case ApproxYear:
+		taken = time.Date(year, 7, 1, 0, 0, 0, 0, time.UTC)
+	case ApproxDecade:
+		taken = time.Date(year/10*10+5, 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return fmt.Errorf("photo: invalid date precision %s", txt.Quote(approx))
+	}
+
+	m.TakenAt = taken
+	m.TakenAtLocal = taken
+	m.TakenSrc = SrcManual
+	m.TakenApprox = approx
+
+	m.UpdateYearMonth()
+
+	return nil
+}
+
+// ApproxDate returns true if the photo only has an estimated date.
+func (m *Photo) ApproxDate() bool {
+	return m.TakenApprox != ""
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_SetApproxDate(t *testing.T) {
+	t.Run("year", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+
+		if err := m.SetApproxDate(1987, ApproxYear); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(1987, 7, 1, 0, 0, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(1987, 7, 1, 0, 0, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+		assert.Equal(t, ApproxYear, m.TakenApprox)
+		assert.Equal(t, 1987, m.PhotoYear)
+		assert.Equal(t, MonthUnknown, m.PhotoMonth)
+		assert.True(t, m.ApproxDate())
+	})
+	t.Run("decade", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+
+		if err := m.SetApproxDate(1987, ApproxDecade); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, ApproxDecade, m.TakenApprox)
+		assert.Equal(t, 1985, m.PhotoYear)
+		assert.Equal(t, MonthUnknown, m.PhotoMonth)
+	})
+	t.Run("invalid year", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+
+		assert.Error(t, m.SetApproxDate(0, ApproxYear))
+		assert.Error(t, m.SetApproxDate(9999, ApproxYear))
+		assert.Equal(t, time.Date(2013, 11, 11, 9, 7, 18, 0, time.UTC), m.TakenAt)
+		assert.False(t, m.ApproxDate())
+	})
+	t.Run("invalid precision", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+
+		assert.Error(t, m.SetApproxDate(1987, "month"))
+		assert.Equal(t, "", m.TakenApprox)
+	})
+	t.Run("exact date replaces approximate date", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+
+		if err := m.SetApproxDate(1987, ApproxYear); err != nil {
+			t.Fatal(err)
+		}
+
+		m.SetTakenAt(time.Date(1987, 3, 4, 10, 0, 0, 0, time.UTC), time.Time{}, "", SrcManual)
+
+		assert.Equal(t, "", m.TakenApprox)
+		assert.Equal(t, 3, m.PhotoMonth)
+	})
+}
@@ -0,0 +1,53 @@
+package entity
+
+// Photo categories for images that aren't regular photos, e.g. screenshots.
+const (
+	CategoryNone       = ""
+	CategoryScreenshot = "screenshot"
+	CategoryReceipt    = "receipt"
+	CategoryWhiteboard = "whiteboard"
+	CategoryDocument   = "document"
+)
+
+// Categories lists all photo categories.
+var Categories = []string{
+	CategoryScreenshot,
+	CategoryReceipt,
+	CategoryWhiteboard,
+	CategoryDocument,
+}
+
+// ValidCategory returns true if the category is known or none.
+func ValidCategory(category string) bool {
+	if category == CategoryNone {
+		return true
+	}
+
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetCategory changes the photo category unless it has been set manually. Detected categories
+// are removed if no longer detected, e.g. after updating the detection rules.
+func (m *Photo) SetCategory(category, source string) {
+	if !ValidCategory(category) {
+		return
+	}
+
+	if m.CategorySrc == SrcManual && source != SrcManual {
+		return
+	}
+
+	m.PhotoCategory = category
+	m.CategorySrc = source
+}
+
+// HasCategory returns true if the photo has a category, e.g. is a screenshot.
+func (m *Photo) HasCategory() bool {
+	return m.PhotoCategory != CategoryNone
+}
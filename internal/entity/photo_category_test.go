@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidCategory(t *testing.T) {
+	assert.True(t, ValidCategory(CategoryNone))
+	assert.True(t, ValidCategory(CategoryScreenshot))
+	assert.True(t, ValidCategory(CategoryDocument))
+	assert.False(t, ValidCategory("selfie"))
+}
+
+func TestPhoto_SetCategory(t *testing.T) {
+	t.Run("auto", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetCategory(CategoryScreenshot, SrcAuto)
+
+		assert.Equal(t, CategoryScreenshot, m.PhotoCategory)
+		assert.Equal(t, SrcAuto, m.CategorySrc)
+		assert.True(t, m.HasCategory())
+
+		m.SetCategory(CategoryNone, SrcAuto)
+
+		assert.Equal(t, CategoryNone, m.PhotoCategory)
+		assert.False(t, m.HasCategory())
+	})
+	t.Run("manual", func(t *testing.T) {
+		m := Photo{PhotoCategory: CategoryReceipt, CategorySrc: SrcManual}
+
+		m.SetCategory(CategoryDocument, SrcAuto)
+
+		assert.Equal(t, CategoryReceipt, m.PhotoCategory)
+		assert.Equal(t, SrcManual, m.CategorySrc)
+
+		m.SetCategory(CategoryNone, SrcManual)
+
+		assert.Equal(t, CategoryNone, m.PhotoCategory)
+		assert.Equal(t, SrcManual, m.CategorySrc)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		m := Photo{PhotoCategory: CategoryWhiteboard, CategorySrc: SrcAuto}
+
+		m.SetCategory("selfie", SrcAuto)
+
+		assert.Equal(t, CategoryWhiteboard, m.PhotoCategory)
+	})
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+)
+
+// PhotoEmbedding stores the CLIP image embedding of a photo's primary file for natural language search.
+type PhotoEmbedding struct {
+	PhotoUID       string    `gorm:"type:varbinary(36);primary_key;auto_increment:false" json:"PhotoUID"`
+	EmbeddingModel string    `gorm:"type:varbinary(64);" json:"Model"`
+	Embedding      []byte    `gorm:"type:blob;" json:"-"`
+	UpdatedAt      time.Time `json:"UpdatedAt"`
+}
+
+// TableName returns PhotoEmbedding table identifier "photos_embeddings"
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// NewPhotoEmbedding returns a new embedding for a photo.
+func NewPhotoEmbedding(photoUID, model string, embedding []byte) *PhotoEmbedding {
+	return &PhotoEmbedding{
+		PhotoUID:       photoUID,
+		EmbeddingModel: model,
+		Embedding:      embedding,
+	}
+}
+
+// Save updates the existing or inserts a new row.
+func (m *PhotoEmbedding) Save() error {
+	return Db().Save(m).Error
+}
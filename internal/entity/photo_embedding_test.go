@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoEmbedding_Save(t *testing.T) {
+	m := NewPhotoEmbedding("pt9jtdre2lvl0yh7", "ViT-B-32", []byte{0, 0, 128, 63})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.EmbeddingModel = "ViT-L-14"
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result PhotoEmbedding
+
+	if err := Db().Where("photo_uid = ?", m.PhotoUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "ViT-L-14", result.EmbeddingModel)
+	assert.Equal(t, []byte{0, 0, 128, 63}, result.Embedding)
+
+	if err := Db().Delete(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -93,6 +93,8 @@ var PhotoFixtures = PhotoMap{
 		PhotoDescription: "photo description blacklist",
 		PhotoPath:        "2790/02",
 		PhotoName:        "Photo01",
+		PhotoCategory:    CategoryDocument,
+		CategorySrc:      SrcAuto,
 		PhotoQuality:     3,
 		PhotoResolution:  2,
 		PhotoFavorite:    true,
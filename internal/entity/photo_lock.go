@@ -0,0 +1,132 @@
+package entity
+
+// Photo fields that are locked against changes by indexing once they have been edited manually.
+const (
+	LockTitle       = "title"
+	LockDescription = "description"
+	LockTaken       = "taken"
+	LockLocation    = "location"
+	LockCamera      = "camera"
+	LockNotes       = "notes"
+	LockSubject     = "subject"
+	LockArtist      = "artist"
+	LockCopyright   = "copyright"
+	LockCategory    = "category"
+)
+
+// LockFields lists the names of all lockable photo fields.
+var LockFields = []string{
+	LockTitle,
+	LockDescription,
+	LockTaken,
+	LockLocation,
+	LockCamera,
+	LockNotes,
+	LockSubject,
+	LockArtist,
+	LockCopyright,
+	LockCategory,
+}
+
+// src returns a pointer to the source of a lockable field or nil if the field name is unknown.
+func (m *Photo) src(field string) *string {
+	switch field {
+	case LockTitle:
+		return &m.TitleSrc
+	case LockDescription:
+		return &m.DescriptionSrc
+	case LockTaken:
+		return &m.TakenSrc
+	case LockLocation:
+		return &m.LocSrc
+	case LockCamera:
+		return &m.CameraSrc
+	case LockNotes:
+		return &m.Details.NotesSrc
+	case LockSubject:
+		return &m.Details.SubjectSrc
+	case LockArtist:
+		return &m.Details.ArtistSrc
+	case LockCopyright:
+		return &m.Details.CopyrightSrc
+	case LockCategory:
+		return &m.CategorySrc
+	default:
+		return nil
+	}
+}
+
+// Locked returns the names of fields that have been edited manually and won't be changed by indexing.
+func (m *Photo) Locked() (fields []string) {
+	fields = []string{}
+
+	for _, name := range LockFields {
+		if src := m.src(name); *src == SrcManual {
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
+}
+
+// Unlock resets the source of manually edited fields, so that file metadata is adopted again
+// when the photo is indexed. All fields are unlocked if none are specified.
+func (m *Photo) Unlock(fields ...string) (unlocked []string) {
+	if len(fields) == 0 {
+		fields = LockFields
+	}
+
+	unlocked = []string{}
+
+	for _, name := range fields {
+		if src := m.src(name); src != nil && *src == SrcManual {
+			*src = SrcAuto
+			unlocked = append(unlocked, name)
+		}
+	}
+
+	return unlocked
+}
+
+// LockEdited locks all fields that differ from the original, so that later indexing doesn't overwrite them.
+func (m *Photo) LockEdited(original Photo) {
+	if m.PhotoTitle != original.PhotoTitle {
+		m.TitleSrc = SrcManual
+	}
+
+	if m.PhotoDescription != original.PhotoDescription {
+		m.DescriptionSrc = SrcManual
+	}
+
+	if !m.TakenAt.Equal(original.TakenAt) || !m.TakenAtLocal.Equal(original.TakenAtLocal) {
+		m.TakenSrc = SrcManual
+	}
+
+	if m.PhotoLat != original.PhotoLat || m.PhotoLng != original.PhotoLng {
+		m.LocSrc = SrcManual
+	}
+
+	if m.CameraID != original.CameraID || m.LensID != original.LensID {
+		m.CameraSrc = SrcManual
+	}
+
+	if m.Details.Notes != original.Details.Notes {
+		m.Details.NotesSrc = SrcManual
+	}
+
+	if m.Details.Subject != original.Details.Subject {
+		m.Details.SubjectSrc = SrcManual
+	}
+
+	if m.Details.Artist != original.Details.Artist {
+		m.Details.ArtistSrc = SrcManual
+	}
+
+	if m.Details.Copyright != original.Details.Copyright {
+		m.Details.CopyrightSrc = SrcManual
+	}
+
+	if m.PhotoCategory != original.PhotoCategory {
+		m.CategorySrc = SrcManual
+	}
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_LockEdited(t *testing.T) {
+	original := Photo{PhotoTitle: "Old Title", TitleSrc: SrcMeta, TakenAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := original
+	m.PhotoTitle = "New Title"
+	m.Details.Artist = "Jane"
+
+	m.LockEdited(original)
+
+	assert.Equal(t, SrcManual, m.TitleSrc)
+	assert.Equal(t, SrcManual, m.Details.ArtistSrc)
+	assert.Equal(t, SrcAuto, m.TakenSrc)
+	assert.Equal(t, SrcAuto, m.LocSrc)
+	assert.Equal(t, []string{LockTitle, LockArtist}, m.Locked())
+}
+
+func TestPhoto_Unlock(t *testing.T) {
+	t.Run("selected", func(t *testing.T) {
+		m := Photo{TitleSrc: SrcManual, TakenSrc: SrcManual, LocSrc: SrcMeta}
+
+		assert.Equal(t, []string{LockTitle}, m.Unlock(LockTitle, LockLocation, "foo"))
+		assert.Equal(t, SrcAuto, m.TitleSrc)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+		assert.Equal(t, SrcMeta, m.LocSrc)
+	})
+	t.Run("all", func(t *testing.T) {
+		m := Photo{TitleSrc: SrcManual, TakenSrc: SrcManual, Details: Details{NotesSrc: SrcManual}}
+
+		assert.Equal(t, []string{LockTitle, LockTaken, LockNotes}, m.Unlock())
+		assert.Empty(t, m.Locked())
+	})
+}
+
+func TestPhoto_LockedAgainstIndexing(t *testing.T) {
+	original := Photo{PhotoTitle: "Old Title", TitleSrc: SrcMeta}
+	m := original
+	m.PhotoTitle = "My Title"
+	m.Details.Notes = "My Notes"
+	m.LockEdited(original)
+
+	m.SetTitle("Title from Exif", SrcMeta)
+	m.Details.SetNotes("Notes from Exif", SrcMeta)
+
+	assert.Equal(t, "My Title", m.PhotoTitle)
+	assert.Equal(t, "My Notes", m.Details.Notes)
+
+	m.Unlock()
+	m.SetTitle("Title from Exif", SrcMeta)
+	m.Details.SetNotes("Notes from Exif", SrcMeta)
+
+	assert.Equal(t, "Title from Exif", m.PhotoTitle)
+	assert.Equal(t, "Notes from Exif", m.Details.Notes)
+}
@@ -20,6 +20,11 @@ var (
 
 // QualityScore returns a score based on photo properties like size and metadata.
 func (m *Photo) QualityScore() (score int) {
+	// Photos that may be offensive stay in review until approved.
+	if m.NSFWReview {
+		return -1
+	}
+
 	if m.PhotoFavorite {
 		score += 3
 	}
@@ -21,4 +21,9 @@ func TestPhoto_QualityScore(t *testing.T) {
 	t.Run("PhotoFixturePhoto15 - description with blacklist", func(t *testing.T) {
 		assert.Equal(t, 2, PhotoFixtures.Pointer("Photo15").QualityScore())
 	})
+	t.Run("nsfw review", func(t *testing.T) {
+		photo := PhotoFixtures.Get("Photo01")
+		photo.NSFWReview = true
+		assert.Equal(t, -1, photo.QualityScore())
+	})
 }
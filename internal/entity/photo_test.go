@@ -6,6 +6,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,6 +70,31 @@ func TestSavePhotoForm(t *testing.T) {
 	})
 }
 
+func TestPhoto_BeforeCreate(t *testing.T) {
+	t.Run("existing uid", func(t *testing.T) {
+		existing := PhotoFixtures.Get("19800101_000002_D640C559")
+
+		photo := Photo{
+			PhotoUID:   existing.PhotoUID,
+			PhotoYear:  existing.PhotoYear + 1,
+			PhotoTitle: "Same UID, other year",
+		}
+
+		err := Db().Create(&photo).Error
+
+		assert.Equal(t, ErrPhotoUIDExists, err)
+	})
+	t.Run("new uid", func(t *testing.T) {
+		photo := Photo{PhotoTitle: "New UID"}
+
+		if err := Db().Create(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, rnd.IsPPID(photo.PhotoUID, 'p'))
+	})
+}
+
 func TestPhoto_Save(t *testing.T) {
 	t.Run("new photo", func(t *testing.T) {
 		photo := Photo{
@@ -546,6 +572,27 @@ func TestPhoto_SetCoordinates(t *testing.T) {
 	})
 }
 
+func TestPhoto_SetDirection(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		m.SetDirection(0, 0, "location")
+		assert.Equal(t, float32(0), m.PhotoDirection)
+		assert.Equal(t, float32(0), m.PhotoSpeed)
+	})
+	t.Run("different source", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		m.SetDirection(270.5, 12, "meta")
+		assert.Equal(t, float32(0), m.PhotoDirection)
+		assert.Equal(t, float32(0), m.PhotoSpeed)
+	})
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		m.SetDirection(270.5, 12, "location")
+		assert.Equal(t, float32(270.5), m.PhotoDirection)
+		assert.Equal(t, float32(12), m.PhotoSpeed)
+	})
+}
+
 func TestPhoto_Delete(t *testing.T) {
 	t.Run("not permanent", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo16")
@@ -562,3 +609,25 @@ func TestPhoto_Delete(t *testing.T) {
 		}
 	})
 }
+
+func TestPhoto_ApproveNSFW(t *testing.T) {
+	photo := Photo{PhotoNSFW: 0.99, NSFWReview: true, PhotoQuality: -1}
+
+	if err := Db().Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := photo.ApproveNSFW(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result Photo
+
+	if err := Db().Where("id = ?", photo.ID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, result.NSFWReview)
+	assert.Equal(t, float32(0.99), result.PhotoNSFW)
+	assert.LessOrEqual(t, 0, result.PhotoQuality)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/photoprism/photoprism/internal/sidecar"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"gopkg.in/yaml.v2"
 )
@@ -62,11 +63,14 @@ func (m *Photo) LoadFromYaml(fileName string) error {
 	return nil
 }
 
-// YamlFileName returns the YAML backup file name.
-func (m *Photo) YamlFileName(originalsPath string, hidden bool) string {
-	if hidden {
-		return filepath.Join(originalsPath, m.PhotoPath, fs.HiddenPath, m.PhotoName) + fs.YamlExt
+// YamlFileName returns the YAML backup file name depending on the sidecar strategy,
+// or an empty string if no sidecar file should be written.
+func (m *Photo) YamlFileName(originalsPath string) string {
+	dir := sidecar.Dir(filepath.Join(originalsPath, m.PhotoPath))
+
+	if dir == "" {
+		return ""
 	}
 
-	return filepath.Join(originalsPath, m.PhotoPath, m.PhotoName) + fs.YamlExt
+	return filepath.Join(dir, m.PhotoName) + fs.YamlExt
 }
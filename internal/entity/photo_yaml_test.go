@@ -1,6 +1,11 @@
 package entity
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/sidecar"
+	"github.com/stretchr/testify/assert"
+)
 
 func TestPhoto_Yaml(t *testing.T) {
 	t.Run("create from fixture", func(t *testing.T) {
@@ -15,3 +20,22 @@ func TestPhoto_Yaml(t *testing.T) {
 		t.Logf("YAML: %s", result)
 	})
 }
+
+func TestPhoto_YamlFileName(t *testing.T) {
+	m := Photo{PhotoPath: "2020/10", PhotoName: "IMG_1234"}
+
+	defer func(path string, strategy sidecar.Strategy, roots sidecar.Strategies) {
+		sidecar.OriginalsPath, sidecar.Default, sidecar.Roots = path, strategy, roots
+	}(sidecar.OriginalsPath, sidecar.Default, sidecar.Roots)
+
+	sidecar.OriginalsPath = "/originals"
+	sidecar.Roots = sidecar.Strategies{"2020": sidecar.None}
+
+	sidecar.Default = sidecar.Hidden
+	assert.Equal(t, "/originals/2019/.photoprism/IMG_1234.yml", (&Photo{PhotoPath: "2019", PhotoName: "IMG_1234"}).YamlFileName("/originals"))
+
+	sidecar.Default = sidecar.Inline
+	assert.Equal(t, "/originals/2019/IMG_1234.yml", (&Photo{PhotoPath: "2019", PhotoName: "IMG_1234"}).YamlFileName("/originals"))
+
+	assert.Equal(t, "", m.YamlFileName("/originals"))
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Actions that can be used in presets.
+const (
+	PresetAddLabel    = "add-label"
+	PresetRemoveLabel = "remove-label"
+	PresetAddAlbum    = "add-album"
+	PresetRemoveAlbum = "remove-album"
+	PresetPrivate     = "private"
+	PresetFavorite    = "favorite"
+	PresetArchive     = "archive"
+)
+
+// PresetValueRequired maps supported preset actions to whether they require a value.
+var PresetValueRequired = map[string]bool{
+	PresetAddLabel:    true,
+	PresetRemoveLabel: true,
+	PresetAddAlbum:    true,
+	PresetRemoveAlbum: true,
+	PresetPrivate:     false,
+	PresetFavorite:    false,
+	PresetArchive:     false,
+}
+
+// PresetAction represents a single action of a preset.
+type PresetAction struct {
+	Action string `json:"Action"`
+	Value  string `json:"Value,omitempty"`
+}
+
+// Bool returns the action value as boolean, an empty value means true.
+func (a PresetAction) Bool() bool {
+	return a.Value != "false"
+}
+
+// PresetActions represents a list of actions that are applied in order.
+type PresetActions []PresetAction
+
+// Validate returns an error if an action is unknown or its value is missing.
+func (list PresetActions) Validate() error {
+	if len(list) == 0 {
+		return errors.New("preset: no actions")
+	}
+
+	for _, a := range list {
+		required, ok := PresetValueRequired[a.Action]
+
+		if !ok {
+			return fmt.Errorf("preset: unknown action %s", txt.Quote(a.Action))
+		} else if required && a.Value == "" {
+			return fmt.Errorf("preset: %s requires a value", a.Action)
+		} else if !required && a.Value != "" && a.Value != "true" && a.Value != "false" {
+			return fmt.Errorf("preset: %s must be true or false", a.Action)
+		}
+	}
+
+	return nil
+}
+
+// Preset represents a named list of curation actions that can be applied to a selection of photos at once.
+type Preset struct {
+	ID            uint          `gorm:"primary_key" json:"-" yaml:"-"`
+	PresetUID     string        `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	PresetName    string        `gorm:"type:varchar(255);" json:"Name" yaml:"Name"`
+	PresetActions string        `gorm:"type:text;" json:"-" yaml:"-"`
+	Actions       PresetActions `gorm:"-" json:"Actions" yaml:"Actions"`
+	CreatedAt     time.Time     `json:"CreatedAt" yaml:"-"`
+	UpdatedAt     time.Time     `json:"UpdatedAt" yaml:"-"`
+	DeletedAt     *time.Time    `sql:"index" json:"-" yaml:"-"`
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Preset) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.PresetUID, 'm') {
+		return nil
+	}
+
+	return scope.SetColumn("PresetUID", rnd.PPID('m'))
+}
+
+// BeforeSave serializes the list of actions before saving the preset.
+func (m *Preset) BeforeSave(scope *gorm.Scope) error {
+	data, err := json.Marshal(m.Actions)
+
+	if err != nil {
+		return err
+	}
+
+	return scope.SetColumn("PresetActions", string(data))
+}
+
+// AfterFind restores the list of actions after loading the preset.
+func (m *Preset) AfterFind() error {
+	if m.PresetActions == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(m.PresetActions), &m.Actions)
+}
+
+// NewPreset creates a preset from form values.
+func NewPreset(f form.Preset) (*Preset, error) {
+	m := &Preset{PresetUID: rnd.PPID('m')}
+
+	if err := m.SetForm(f); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SetForm updates the preset name and actions after validating them.
+func (m *Preset) SetForm(f form.Preset) error {
+	actions := make(PresetActions, len(f.Actions))
+
+	for i, a := range f.Actions {
+		actions[i] = PresetAction{Action: a.Action, Value: txt.Clip(a.Value, txt.ClipDefault)}
+	}
+
+	if err := actions.Validate(); err != nil {
+		return err
+	}
+
+	name := txt.Clip(f.Name, txt.ClipDefault)
+
+	if name == "" {
+		return errors.New("preset: name is empty")
+	}
+
+	m.PresetName = name
+	m.Actions = actions
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *Preset) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new row.
+func (m *Preset) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the preset.
+func (m *Preset) Delete() error {
+	return Db().Delete(m).Error
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresetActions_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		actions := PresetActions{{Action: PresetAddLabel, Value: "Family"}, {Action: PresetPrivate}, {Action: PresetFavorite, Value: "false"}}
+		assert.NoError(t, actions.Validate())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Error(t, PresetActions{}.Validate())
+	})
+	t.Run("unknown action", func(t *testing.T) {
+		assert.Error(t, PresetActions{{Action: "xxx"}}.Validate())
+	})
+	t.Run("value missing", func(t *testing.T) {
+		assert.Error(t, PresetActions{{Action: PresetAddAlbum}}.Validate())
+	})
+	t.Run("invalid bool", func(t *testing.T) {
+		assert.Error(t, PresetActions{{Action: PresetArchive, Value: "yes"}}.Validate())
+	})
+}
+
+func TestPresetAction_Bool(t *testing.T) {
+	assert.True(t, PresetAction{Action: PresetPrivate}.Bool())
+	assert.True(t, PresetAction{Action: PresetPrivate, Value: "true"}.Bool())
+	assert.False(t, PresetAction{Action: PresetPrivate, Value: "false"}.Bool())
+}
+
+func TestNewPreset(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := NewPreset(form.Preset{Name: "Family", Actions: []form.PresetAction{{Action: PresetAddLabel, Value: "family"}, {Action: PresetPrivate}}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Family", m.PresetName)
+		assert.Len(t, m.Actions, 2)
+		assert.Equal(t, PresetPrivate, m.Actions[1].Action)
+	})
+	t.Run("name missing", func(t *testing.T) {
+		_, err := NewPreset(form.Preset{Actions: []form.PresetAction{{Action: PresetPrivate}}})
+		assert.Error(t, err)
+	})
+	t.Run("invalid action", func(t *testing.T) {
+		_, err := NewPreset(form.Preset{Name: "Invalid", Actions: []form.PresetAction{{Action: "xxx"}}})
+		assert.Error(t, err)
+	})
+}
+
+func TestPreset_Create(t *testing.T) {
+	m, err := NewPreset(form.Preset{Name: "Holiday", Actions: []form.PresetAction{{Action: PresetAddLabel, Value: "holiday"}, {Action: PresetFavorite}}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result Preset
+
+	if err := Db().Where("preset_uid = ?", m.PresetUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Holiday", result.PresetName)
+	assert.Equal(t, m.Actions, result.Actions)
+
+	if err := result.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, Db().Where("preset_uid = ?", m.PresetUID).First(&Preset{}).RecordNotFound())
+}
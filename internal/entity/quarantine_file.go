@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Sources of quarantined files.
+const (
+	QuarantineUpload = "upload"
+	QuarantineWebDAV = "webdav"
+	QuarantineBackup = "backup"
+	QuarantineUrl    = "url"
+)
+
+// QuarantineFile represents a suspicious upload that was moved to the quarantine path, so that it can be
+// reviewed by an admin before it is imported or deleted.
+type QuarantineFile struct {
+	ID           uint      `gorm:"primary_key" json:"ID"`
+	FileName     string    `gorm:"type:varbinary(768);unique_index;" json:"FileName"`
+	OriginalName string    `gorm:"type:varbinary(768);" json:"OriginalName"`
+	FileSize     int64     `json:"Size"`
+	FileMime     string    `gorm:"type:varbinary(64);" json:"Mime"`
+	Reason       string    `gorm:"type:varbinary(512);" json:"Reason"`
+	Source       string    `gorm:"type:varbinary(16);index;" json:"Source"`
+	Uploader     string    `gorm:"type:varchar(255);" json:"Uploader"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+}
+
+// TableName returns QuarantineFile table identifier "quarantine_files".
+func (QuarantineFile) TableName() string {
+	return "quarantine_files"
+}
+
+// Create inserts a new row to the database.
+func (m *QuarantineFile) Create() error {
+	m.Reason = txt.Clip(m.Reason, 512)
+
+	return Db().Create(m).Error
+}
+
+// Delete removes the row from the database, the file must be deleted or released separately.
+func (m *QuarantineFile) Delete() error {
+	return Db().Delete(m).Error
+}
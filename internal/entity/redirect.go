@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Redirect maps the previous UID of a photo or album to its current UID, e.g. after duplicates were
+// stacked or albums merged, so that permalinks keep working.
+type Redirect struct {
+	FromUID   string    `gorm:"type:varbinary(36);primary_key;auto_increment:false" json:"From"`
+	ToUID     string    `gorm:"type:varbinary(36);index;" json:"To"`
+	CreatedAt time.Time `json:"CreatedAt"`
+}
+
+// TableName returns Redirect table identifier "redirects".
+func (Redirect) TableName() string {
+	return "redirects"
+}
+
+// AddRedirect redirects a previous UID to the current UID. Existing redirects to the previous UID are
+// updated, so that every UID is resolved in a single step.
+func AddRedirect(fromUID, toUID string) error {
+	return addRedirect(Db(), fromUID, toUID)
+}
+
+// AddRedirectTx adds a redirect like AddRedirect within the transaction passed.
+func AddRedirectTx(tx *gorm.DB, fromUID, toUID string) error {
+	return addRedirect(tx, fromUID, toUID)
+}
+
+// addRedirect adds a redirect using the database connection or transaction passed.
+func addRedirect(db *gorm.DB, fromUID, toUID string) error {
+	if fromUID == "" || toUID == "" || fromUID == toUID {
+		return nil
+	}
+
+	// The current UID must not redirect anywhere, e.g. if a previous change was undone.
+	if err := db.Where("from_uid = ?", toUID).Delete(&Redirect{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Model(&Redirect{}).Where("to_uid = ?", fromUID).UpdateColumn("to_uid", toUID).Error; err != nil {
+		return err
+	}
+
+	return db.Save(&Redirect{FromUID: fromUID, ToUID: toUID, CreatedAt: time.Now().UTC()}).Error
+}
+
+// RemoveRedirects removes the redirects of previous UIDs, e.g. after the change was undone.
+func RemoveRedirects(fromUIDs []string) error {
+	if len(fromUIDs) == 0 {
+		return nil
+	}
+
+	return Db().Where("from_uid IN (?)", fromUIDs).Delete(&Redirect{}).Error
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRedirect(t *testing.T) {
+	redirect := func(uid string) string {
+		var m Redirect
+
+		if err := Db().Where("from_uid = ?", uid).First(&m).Error; err != nil {
+			return ""
+		}
+
+		return m.ToUID
+	}
+
+	t.Run("chain", func(t *testing.T) {
+		assert.NoError(t, AddRedirect("pt9jtdre2lvlr001", "pt9jtdre2lvlr002"))
+		assert.NoError(t, AddRedirect("pt9jtdre2lvlr002", "pt9jtdre2lvlr003"))
+
+		assert.Equal(t, "pt9jtdre2lvlr003", redirect("pt9jtdre2lvlr001"))
+		assert.Equal(t, "pt9jtdre2lvlr003", redirect("pt9jtdre2lvlr002"))
+	})
+	t.Run("reverse", func(t *testing.T) {
+		assert.NoError(t, AddRedirect("pt9jtdre2lvlr003", "pt9jtdre2lvlr001"))
+
+		assert.Equal(t, "", redirect("pt9jtdre2lvlr001"))
+		assert.Equal(t, "pt9jtdre2lvlr001", redirect("pt9jtdre2lvlr002"))
+		assert.Equal(t, "pt9jtdre2lvlr001", redirect("pt9jtdre2lvlr003"))
+	})
+	t.Run("same uid", func(t *testing.T) {
+		assert.NoError(t, AddRedirect("pt9jtdre2lvlr004", "pt9jtdre2lvlr004"))
+		assert.Equal(t, "", redirect("pt9jtdre2lvlr004"))
+	})
+	t.Run("remove", func(t *testing.T) {
+		assert.NoError(t, RemoveRedirects([]string{"pt9jtdre2lvlr002", "pt9jtdre2lvlr003"}))
+		assert.Equal(t, "", redirect("pt9jtdre2lvlr002"))
+		assert.Equal(t, "", redirect("pt9jtdre2lvlr003"))
+		assert.NoError(t, RemoveRedirects(nil))
+	})
+}
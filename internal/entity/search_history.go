@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Max length of search queries in the history.
+const searchQueryLength = 255
+
+// SearchHistoryLimit is the max number of searches kept per user, pinned searches are kept in addition.
+const SearchHistoryLimit = 100
+
+// SearchHistory represents a search query of a user, so that it can be run again and suggested
+// when typing. Users are identified by the key returned by their session, see api.sessionUserID().
+type SearchHistory struct {
+	ID          uint      `gorm:"primary_key" json:"ID"`
+	UserID      string    `gorm:"type:varbinary(128);unique_index:idx_search_history_user_query;" json:"-"`
+	SearchQuery string    `gorm:"type:varchar(255);unique_index:idx_search_history_user_query;" json:"Query"`
+	SearchCount int       `json:"Count"`
+	Pinned      bool      `json:"Pinned"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+// TableName returns SearchHistory table identifier "search_history".
+func (SearchHistory) TableName() string {
+	return "search_history"
+}
+
+// NormalizeSearch returns the search query as stored in the history, or an empty string if it should be ignored.
+func NormalizeSearch(q string) string {
+	return txt.Clip(strings.Join(strings.Fields(q), " "), searchQueryLength)
+}
+
+// AddSearchHistory adds a search query to the history of a user, or increments its counter if it was
+// searched before. The oldest queries are removed once there are more than SearchHistoryLimit.
+func AddSearchHistory(userID, q string) (*SearchHistory, error) {
+	q = NormalizeSearch(q)
+
+	if q == "" {
+		return nil, nil
+	}
+
+	m := SearchHistory{UserID: userID, SearchQuery: q}
+
+	if err := Db().FirstOrCreate(&m, "user_id = ? AND search_query = ?", userID, q).Error; err != nil {
+		return nil, err
+	}
+
+	if err := Db().Model(&m).UpdateColumns(map[string]interface{}{
+		"search_count": gorm.Expr("search_count + 1"),
+		"updated_at":   time.Now().UTC(),
+	}).Error; err != nil {
+		return &m, err
+	}
+
+	m.SearchCount++
+
+	return &m, PruneSearchHistory(userID, SearchHistoryLimit)
+}
+
+// PruneSearchHistory removes the least recently used searches of a user that are not pinned,
+// so that no more than limit remain.
+func PruneSearchHistory(userID string, limit int) error {
+	var ids []uint
+
+	if err := Db().Model(&SearchHistory{}).
+		Where("user_id = ? AND pinned = 0", userID).
+		Order("updated_at DESC, id DESC").
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	if len(ids) <= limit {
+		return nil
+	}
+
+	return Db().Where("id IN (?)", ids[limit:]).Delete(&SearchHistory{}).Error
+}
+
+// ClearSearchHistory removes all searches of a user that are not pinned.
+func ClearSearchHistory(userID string) error {
+	return Db().Where("user_id = ? AND pinned = 0", userID).Delete(&SearchHistory{}).Error
+}
+
+// SetPinned pins or unpins the search, pinned searches are listed first and never removed automatically.
+func (m *SearchHistory) SetPinned(pinned bool) error {
+	m.Pinned = pinned
+
+	return Db().Model(m).UpdateColumn("pinned", pinned).Error
+}
+
+// Delete removes the search from the history.
+func (m *SearchHistory) Delete() error {
+	return Db().Delete(m).Error
+}
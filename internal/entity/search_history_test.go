@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSearch(t *testing.T) {
+	assert.Equal(t, "", NormalizeSearch("  "))
+	assert.Equal(t, "cat label:dog", NormalizeSearch(" cat   label:dog\n"))
+}
+
+func TestAddSearchHistory(t *testing.T) {
+	t.Run("empty query", func(t *testing.T) {
+		m, err := AddSearchHistory("history-empty@example.com", " ")
+
+		assert.Nil(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("count", func(t *testing.T) {
+		m, err := AddSearchHistory("history-count@example.com", "beach")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, m.SearchCount)
+
+		m, err = AddSearchHistory("history-count@example.com", " beach ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, m.SearchCount)
+		assert.Equal(t, "beach", m.SearchQuery)
+	})
+}
+
+func TestPruneSearchHistory(t *testing.T) {
+	userID := "history-prune@example.com"
+
+	for i := 0; i < 5; i++ {
+		if _, err := AddSearchHistory(userID, fmt.Sprintf("query %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var pinned SearchHistory
+
+	if err := Db().Where("user_id = ? AND search_query = ?", userID, "query 0").First(&pinned).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pinned.SetPinned(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PruneSearchHistory(userID, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	var queries []string
+
+	if err := Db().Model(&SearchHistory{}).Where("user_id = ?", userID).Order("search_query").Pluck("search_query", &queries).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, queries, 3)
+	assert.Contains(t, queries, "query 0")
+
+	if err := ClearSearchHistory(userID); err != nil {
+		t.Fatal(err)
+	}
+
+	queries = nil
+
+	if err := Db().Model(&SearchHistory{}).Where("user_id = ?", userID).Pluck("search_query", &queries).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"query 0"}, queries)
+}
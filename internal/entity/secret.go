@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/internal/secret"
+)
+
+// secretColumn represents a table column containing passwords that must be encrypted.
+type secretColumn struct {
+	Table  string
+	Key    string
+	Column string
+}
+
+// secretRow represents a row with a password that is still stored in plaintext.
+type secretRow struct {
+	Key   string
+	Value string
+}
+
+// secretColumns lists all columns containing passwords.
+var secretColumns = []secretColumn{
+	{Table: "accounts", Key: "id", Column: "acc_pass"},
+}
+
+// EncryptSecrets encrypts passwords that are still stored in plaintext and returns the number of updated rows.
+func EncryptSecrets() (count int, err error) {
+	if !secret.HasKey() {
+		return 0, secret.ErrNoKey
+	}
+
+	db := UnscopedDb()
+
+	for _, s := range secretColumns {
+		// Columns must be large enough to store the encrypted value, see AutoMigrate.
+		if err := db.Table(s.Table).ModifyColumn(s.Column, "VARBINARY(512)").Error; err != nil {
+			return count, err
+		}
+
+		var rows []secretRow
+
+		if err := db.Table(s.Table).Select(s.Key+" AS `key`, "+s.Column+" AS value").
+			Where(s.Column+" <> '' AND "+s.Column+" NOT LIKE ?", secret.Prefix+"%").
+			Scan(&rows).Error; err != nil {
+			return count, err
+		}
+
+		for _, row := range rows {
+			value, err := secret.Encrypt(row.Value)
+
+			if err != nil {
+				return count, err
+			}
+
+			if err := db.Table(s.Table).Where(s.Key+" = ?", row.Key).UpdateColumn(s.Column, value).Error; err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		log.Infof("secret: encrypted %d rows in %s", len(rows), s.Table)
+	}
+
+	return count, nil
+}
+
+// HashLinkPasswords replaces encrypted and plaintext share link passwords with their bcrypt hash and returns
+// the number of updated rows.
+func HashLinkPasswords() (count int, err error) {
+	db := UnscopedDb()
+
+	var rows []secretRow
+
+	if err := db.Table("links").Select("link_token AS `key`, link_password AS value").
+		Where("link_password <> ''").Scan(&rows).Error; err != nil {
+		return count, err
+	}
+
+	for _, row := range rows {
+		if linkPasswordHashed(row.Value) {
+			continue
+		}
+
+		password, err := secret.Decrypt(row.Value)
+
+		if err != nil {
+			return count, err
+		}
+
+		var link Link
+
+		if err := link.SetPassword(password); err != nil {
+			return count, err
+		}
+
+		if err := db.Table("links").Where("link_token = ?", row.Key).UpdateColumn("link_password", link.LinkPassword).Error; err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	log.Infof("secret: hashed %d share link passwords", count)
+
+	return count, nil
+}
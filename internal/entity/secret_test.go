@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount_AfterFind(t *testing.T) {
+	var stored secretRow
+
+	if err := Db().Table("accounts").Select("id AS `key`, acc_pass AS value").Where("id = ?", 1000000).Scan(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, secret.Encrypted(stored.Value))
+
+	var m Account
+
+	if err := Db().First(&m, 1000000).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "photoprism", m.AccPass)
+}
+
+func TestAccount_BeforeSave(t *testing.T) {
+	m := Account{AccName: "Partial", AccType: "webdav", AccUser: "admin", AccPass: "keep123"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Update other columns without loading the password first.
+	if err := Db().Model(&Account{ID: m.ID}).Updates(map[string]interface{}{"SyncStatus": AccountSyncStatusSynced}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var stored secretRow
+
+	if err := Db().Table("accounts").Select("id AS `key`, acc_pass AS value").Where("id = ?", m.ID).Scan(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, secret.Encrypted(stored.Value))
+
+	var found Account
+
+	if err := Db().First(&found, m.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, AccountSyncStatusSynced, found.SyncStatus)
+	assert.Equal(t, "keep123", found.AccPass)
+}
+
+func TestEncryptSecrets(t *testing.T) {
+	m := Account{AccName: "Plaintext", AccType: "webdav", AccUser: "admin", AccPass: "plain12"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a password stored by a previous version.
+	if err := Db().Model(&m).UpdateColumn("acc_pass", "plain12").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := EncryptSecrets()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, count, 1)
+
+	var stored secretRow
+
+	if err := Db().Table("accounts").Select("id AS `key`, acc_pass AS value").Where("id = ?", m.ID).Scan(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, secret.Encrypted(stored.Value))
+
+	var found Account
+
+	if err := Db().First(&found, m.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "plain12", found.AccPass)
+}
+
+func TestHashLinkPasswords(t *testing.T) {
+	encrypted, err := secret.Encrypt("plain12")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []string{"plain12", encrypted} {
+		link := NewLink("", false, false)
+
+		if err := Db().Create(&link).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		// Simulate a password stored by a previous version.
+		if err := Db().Model(&link).UpdateColumn("link_password", value).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := HashLinkPasswords()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, count, 1)
+
+		var found Link
+
+		if err := Db().Where("link_token = ?", link.LinkToken).First(&found).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, linkPasswordHashed(found.LinkPassword))
+		assert.True(t, found.CheckPassword("plain12"))
+		assert.False(t, found.CheckPassword(value+"x"))
+	}
+}
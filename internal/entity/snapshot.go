@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Snapshot represents an immutable list of photos, e.g. a search result frozen at a point in time,
+// so that shares and exports don't change when new photos are added to the library.
+type Snapshot struct {
+	ID            uint            `gorm:"primary_key" json:"-" yaml:"-"`
+	SnapshotUID   string          `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	SnapshotTitle string          `gorm:"type:varchar(255);" json:"Title" yaml:"Title"`
+	SnapshotQuery string          `gorm:"type:varchar(1024);" json:"Query" yaml:"Query,omitempty"`
+	PhotoCount    int             `json:"PhotoCount" yaml:"-"`
+	Photos        []SnapshotPhoto `gorm:"foreignkey:snapshot_uid;association_foreignkey:snapshot_uid" json:"-" yaml:"-"`
+	Links         []Link          `gorm:"foreignkey:share_uid;association_foreignkey:snapshot_uid" json:"Links" yaml:"-"`
+	CreatedAt     time.Time       `json:"CreatedAt" yaml:"-"`
+	DeletedAt     *time.Time      `sql:"index" json:"-" yaml:"-"`
+}
+
+// SnapshotPhoto represents a photo and its position in a snapshot.
+type SnapshotPhoto struct {
+	SnapshotUID string `gorm:"type:varbinary(36);primary_key;auto_increment:false"`
+	PhotoUID    string `gorm:"type:varbinary(36);primary_key;auto_increment:false;index"`
+	PhotoOrder  int
+}
+
+// TableName returns SnapshotPhoto table identifier "snapshots_photos".
+func (SnapshotPhoto) TableName() string {
+	return "snapshots_photos"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Snapshot) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.SnapshotUID, 's') {
+		return nil
+	}
+
+	return scope.SetColumn("SnapshotUID", rnd.PPID('s'))
+}
+
+// NewSnapshot creates a snapshot of the given photos, keeping their order.
+func NewSnapshot(title, query string, photoUIDs []string) *Snapshot {
+	uid := rnd.PPID('s')
+
+	result := &Snapshot{
+		SnapshotUID:   uid,
+		SnapshotTitle: txt.Clip(title, txt.ClipDefault),
+		SnapshotQuery: txt.Clip(query, 1024),
+		PhotoCount:    len(photoUIDs),
+		Photos:        make([]SnapshotPhoto, len(photoUIDs)),
+	}
+
+	for i, photoUID := range photoUIDs {
+		result.Photos[i] = SnapshotPhoto{SnapshotUID: uid, PhotoUID: photoUID, PhotoOrder: i}
+	}
+
+	return result
+}
+
+// Create inserts the snapshot and its photos in a single transaction.
+func (m *Snapshot) Create() error {
+	if m.SnapshotTitle == "" {
+		return errors.New("snapshot: title is empty")
+	} else if len(m.Photos) == 0 {
+		return errors.New("snapshot: no photos")
+	}
+
+	tx := Db().Begin()
+
+	photos := m.Photos
+	m.Photos = nil
+
+	defer func() { m.Photos = photos }()
+
+	if err := tx.Create(m).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, p := range photos {
+		if err := tx.Create(&p).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// PhotoUIDs returns the UIDs of all photos in the snapshot in their original order.
+func (m *Snapshot) PhotoUIDs() (result []string) {
+	result = []string{}
+
+	if err := Db().Model(&SnapshotPhoto{}).
+		Where("snapshot_uid = ?", m.SnapshotUID).
+		Order("photo_order").
+		Pluck("photo_uid", &result).Error; err != nil {
+		log.Errorf("snapshot: %s", err)
+	}
+
+	return result
+}
+
+// Delete removes the snapshot, its share links and list of photos, the photos themselves are not affected.
+func (m *Snapshot) Delete() error {
+	if err := UnscopedDb().Delete(SnapshotPhoto{}, "snapshot_uid = ?", m.SnapshotUID).Error; err != nil {
+		return err
+	}
+
+	if err := Db().Delete(Link{}, "share_uid = ?", m.SnapshotUID).Error; err != nil {
+		return err
+	}
+
+	return Db().Delete(m).Error
+}
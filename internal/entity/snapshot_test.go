@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	m := NewSnapshot("Yearbook", "label:cake", []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"})
+
+	assert.Equal(t, "Yearbook", m.SnapshotTitle)
+	assert.Equal(t, "label:cake", m.SnapshotQuery)
+	assert.Equal(t, 2, m.PhotoCount)
+	assert.Len(t, m.Photos, 2)
+	assert.Equal(t, 1, m.Photos[1].PhotoOrder)
+	assert.Equal(t, m.SnapshotUID, m.Photos[1].SnapshotUID)
+}
+
+func TestSnapshot_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := NewSnapshot("Yearbook", "", []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"})
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, m.Photos, 2)
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"}, m.PhotoUIDs())
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, m.PhotoUIDs())
+	})
+	t.Run("title empty", func(t *testing.T) {
+		m := NewSnapshot("", "", []string{"pt9jtdre2lvl0yh8"})
+
+		assert.Error(t, m.Create())
+	})
+	t.Run("no photos", func(t *testing.T) {
+		m := NewSnapshot("Empty", "", nil)
+
+		assert.Error(t, m.Create())
+	})
+}
@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	SubjPerson = "person"
+)
+
+// Subject represents a person or other subject that is tagged in photos with markers.
+type Subject struct {
+	ID         uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	SubjUID    string    `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	SubjType   string    `gorm:"type:varbinary(8);" json:"Type" yaml:"Type"`
+	SubjSlug   string    `gorm:"type:varbinary(255);index;" json:"Slug" yaml:"-"`
+	SubjName   string    `gorm:"type:varchar(255);" json:"Name" yaml:"Name"`
+	PhotoCount int       `gorm:"default:0" json:"PhotoCount" yaml:"-"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns Subject table identifier "subjects".
+func (Subject) TableName() string {
+	return "subjects"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Subject) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.SubjUID, 'j') {
+		return nil
+	}
+
+	return scope.SetColumn("SubjUID", rnd.PPID('j'))
+}
+
+// NewSubject creates a new subject with the given name and type, e.g. a person.
+func NewSubject(name, subjType string) *Subject {
+	subjName := txt.Title(txt.Clip(name, txt.ClipDefault))
+
+	result := &Subject{
+		SubjType: subjType,
+		SubjSlug: slug.Make(txt.Clip(subjName, txt.ClipSlug)),
+		SubjName: subjName,
+	}
+
+	return result
+}
+
+// FindSubject returns an existing subject with the same slug and type, or nil if not found.
+func FindSubject(name, subjType string) *Subject {
+	result := Subject{}
+
+	if err := Db().Where("subj_slug = ? AND subj_type = ?", NewSubject(name, subjType).SubjSlug, subjType).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FirstOrCreateSubject returns the existing row, inserts a new row or nil in case of errors.
+func FirstOrCreateSubject(m *Subject) *Subject {
+	if result := FindSubject(m.SubjName, m.SubjType); result != nil {
+		return result
+	} else if err := Db().Create(m).Error; err != nil {
+		log.Errorf("subject: %s", err)
+		return nil
+	}
+
+	return m
+}
+
+// AddPhotos tags the subject in all given photos that don't have a marker for it yet and returns
+// the UIDs of the tagged photos. Changes are rolled back if any photo can't be tagged.
+func (m *Subject) AddPhotos(photoUIDs []string) (added []string, err error) {
+	tx := Db().Begin()
+
+	for _, photoUID := range photoUIDs {
+		var count int
+
+		if err := tx.Model(&Marker{}).Where("photo_uid = ? AND subj_uid = ?", photoUID, m.SubjUID).Count(&count).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		} else if count > 0 {
+			continue
+		}
+
+		if err := tx.Create(NewMarker(photoUID, m.SubjUID, MarkerFace, SrcManual)).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		added = append(added, photoUID)
+	}
+
+	if err := m.updatePhotoCount(tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return added, tx.Commit().Error
+}
+
+// RemovePhotos deletes the markers of the subject in the given photos and returns the number
+// of photos it was removed from.
+func (m *Subject) RemovePhotos(photoUIDs []string) (removed int, err error) {
+	tx := Db().Begin()
+
+	var uids []string
+
+	if err := tx.Model(&Marker{}).Where("photo_uid IN (?) AND subj_uid = ?", photoUIDs, m.SubjUID).Pluck("DISTINCT photo_uid", &uids).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Where("photo_uid IN (?) AND subj_uid = ?", photoUIDs, m.SubjUID).Delete(&Marker{}).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := m.updatePhotoCount(tx); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return len(uids), tx.Commit().Error
+}
+
+// updatePhotoCount updates the number of photos the subject is tagged in.
+func (m *Subject) updatePhotoCount(tx *gorm.DB) error {
+	var count int
+
+	if err := tx.Model(&Marker{}).Where("subj_uid = ?", m.SubjUID).Select("COUNT(DISTINCT photo_uid)").Row().Scan(&count); err != nil {
+		return err
+	}
+
+	m.PhotoCount = count
+
+	return tx.Model(m).UpdateColumn("photo_count", count).Error
+}
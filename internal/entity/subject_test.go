@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSubject(t *testing.T) {
+	m := NewSubject("jane doe", SubjPerson)
+
+	assert.Equal(t, "Jane Doe", m.SubjName)
+	assert.Equal(t, "jane-doe", m.SubjSlug)
+	assert.Equal(t, SubjPerson, m.SubjType)
+}
+
+func TestFirstOrCreateSubject(t *testing.T) {
+	m := FirstOrCreateSubject(NewSubject("First Or Create", SubjPerson))
+
+	if m == nil {
+		t.Fatal("subject must not be nil")
+	}
+
+	assert.NotEmpty(t, m.SubjUID)
+
+	found := FirstOrCreateSubject(NewSubject("first or create", SubjPerson))
+
+	if found == nil {
+		t.Fatal("subject must not be nil")
+	}
+
+	assert.Equal(t, m.SubjUID, found.SubjUID)
+	assert.Nil(t, FindSubject("Unknown Subject", SubjPerson))
+}
+
+func TestSubject_AddPhotos(t *testing.T) {
+	m := FirstOrCreateSubject(NewSubject("Add Photos", SubjPerson))
+
+	if m == nil {
+		t.Fatal("subject must not be nil")
+	}
+
+	added, err := m.AddPhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, added, 2)
+	assert.Equal(t, 2, m.PhotoCount)
+
+	// Photos that are already tagged are skipped.
+	added, err = m.AddPhotos([]string{"pt9jtdre2lvl0yh7"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, added, 0)
+	assert.Equal(t, 2, FindSubject("Add Photos", SubjPerson).PhotoCount)
+
+	removed, err := m.RemovePhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y11"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 1, m.PhotoCount)
+	assert.Equal(t, 1, FindSubject("Add Photos", SubjPerson).PhotoCount)
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Thumbnails are rendered again after ThumbRetryDelay, which doubles with every failure up to
+// ThumbRetryMaxDelay. Files that failed ThumbErrorLimit times are not retried automatically anymore.
+const (
+	ThumbRetryDelay    = time.Minute
+	ThumbRetryMaxDelay = 24 * time.Hour
+	ThumbErrorLimit    = 10
+)
+
+// ThumbError represents a thumbnail that could not be rendered, so that it isn't rendered again every
+// time it is requested. Errors are removed once the thumbnail was rendered successfully.
+type ThumbError struct {
+	ID           uint      `gorm:"primary_key" json:"ID"`
+	FileHash     string    `gorm:"type:varbinary(128);unique_index:idx_thumb_errors_hash_type;" json:"Hash"`
+	ThumbType    string    `gorm:"type:varbinary(64);unique_index:idx_thumb_errors_hash_type;" json:"Type"`
+	FileName     string    `gorm:"type:varbinary(768);" json:"FileName"`
+	ErrorMessage string    `gorm:"type:varbinary(2048);" json:"Message"`
+	ErrorCount   int       `gorm:"index;" json:"Count"`
+	RetryAt      time.Time `json:"RetryAt"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+	UpdatedAt    time.Time `json:"UpdatedAt"`
+}
+
+// TableName returns ThumbError table identifier "thumb_errors".
+func (ThumbError) TableName() string {
+	return "thumb_errors"
+}
+
+// ThumbRetryAfter returns the delay before a thumbnail that failed count times is rendered again.
+func ThumbRetryAfter(count int) time.Duration {
+	delay := ThumbRetryDelay
+
+	for i := 1; i < count && delay < ThumbRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > ThumbRetryMaxDelay {
+		return ThumbRetryMaxDelay
+	}
+
+	return delay
+}
+
+// SaveThumbError records a failure to render a thumbnail type for a file hash and schedules the next
+// attempt. The file name relative to the originals path is stored for reference.
+func SaveThumbError(fileHash, thumbType, fileName, message string) (*ThumbError, error) {
+	m := ThumbError{}
+
+	if err := Db().Where("file_hash = ? AND thumb_type = ?", fileHash, thumbType).First(&m).Error; err != nil {
+		m = ThumbError{FileHash: fileHash, ThumbType: thumbType}
+	}
+
+	m.FileName = fileName
+	m.ErrorMessage = txt.Clip(message, 2048)
+	m.ErrorCount++
+	m.RetryAt = time.Now().UTC().Add(ThumbRetryAfter(m.ErrorCount))
+
+	return &m, Db().Save(&m).Error
+}
+
+// ClearThumbError removes the failure recorded for a thumbnail type and file hash, e.g. after it was
+// rendered successfully.
+func ClearThumbError(fileHash, thumbType string) error {
+	return Db().Where("file_hash = ? AND thumb_type = ?", fileHash, thumbType).Delete(&ThumbError{}).Error
+}
+
+// Permanent tests if the thumbnail failed too often to be retried automatically.
+func (m *ThumbError) Permanent() bool {
+	return m.ErrorCount >= ThumbErrorLimit
+}
+
+// Retry tests if the thumbnail should be rendered again.
+func (m *ThumbError) Retry() bool {
+	return !m.Permanent() && !time.Now().Before(m.RetryAt)
+}
+
+// Err returns an error describing why the thumbnail isn't rendered.
+func (m *ThumbError) Err() error {
+	if m.Permanent() {
+		return fmt.Errorf("thumb: %s failed %d times for %s, not retrying (%s)", m.ThumbType, m.ErrorCount, txt.Quote(m.FileName), m.ErrorMessage)
+	}
+
+	return fmt.Errorf("thumb: %s failed for %s, retrying in %s (%s)", m.ThumbType, txt.Quote(m.FileName), time.Until(m.RetryAt).Round(time.Second), m.ErrorMessage)
+}
+
+// Delete removes the failure, so that the thumbnail is rendered again when requested.
+func (m *ThumbError) Delete() error {
+	return Db().Delete(m).Error
+}
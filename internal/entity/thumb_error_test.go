@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Minute, ThumbRetryAfter(1))
+	assert.Equal(t, 2*time.Minute, ThumbRetryAfter(2))
+	assert.Equal(t, 8*time.Minute, ThumbRetryAfter(4))
+	assert.Equal(t, ThumbRetryMaxDelay, ThumbRetryAfter(20))
+}
+
+func TestSaveThumbError(t *testing.T) {
+	fileHash := "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"
+	fileName := "2020/broken/truncated.jpg"
+
+	m, err := SaveThumbError(fileHash, "tile_224", fileName, "unexpected EOF")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, m.ErrorCount)
+	assert.False(t, m.Retry())
+	assert.False(t, m.Permanent())
+	assert.Contains(t, m.Err().Error(), "retrying in")
+
+	if m, err = SaveThumbError(fileHash, "tile_224", fileName, "invalid JPEG format"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, m.ErrorCount)
+	assert.Equal(t, "invalid JPEG format", m.ErrorMessage)
+	assert.True(t, m.RetryAt.After(time.Now().Add(time.Minute)))
+
+	m.RetryAt = time.Now().Add(-time.Second)
+	assert.True(t, m.Retry())
+
+	m.ErrorCount = ThumbErrorLimit
+	assert.True(t, m.Permanent())
+	assert.False(t, m.Retry())
+	assert.Contains(t, m.Err().Error(), "not retrying")
+
+	assert.NoError(t, ClearThumbError(fileHash, "tile_224"))
+	assert.Error(t, Db().Where("file_hash = ? AND thumb_type = ?", fileHash, "tile_224").First(&ThumbError{}).Error)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ThumbUsage counts how often clients reported a display size that requires a thumbnail type.
+type ThumbUsage struct {
+	ThumbType string    `gorm:"type:varbinary(16);primary_key;auto_increment:false;" json:"Type"`
+	Reports   int       `json:"Reports"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+// TableName returns ThumbUsage table identifier "thumb_usage".
+func (ThumbUsage) TableName() string {
+	return "thumb_usage"
+}
+
+// ReportThumbUsage increments the report counter for a thumbnail type.
+func ReportThumbUsage(thumbType string) error {
+	m := ThumbUsage{ThumbType: thumbType}
+
+	if err := Db().FirstOrCreate(&m, "thumb_type = ?", thumbType).Error; err != nil {
+		return err
+	}
+
+	return Db().Model(&m).UpdateColumns(map[string]interface{}{
+		"reports":    gorm.Expr("reports + 1"),
+		"updated_at": time.Now().UTC(),
+	}).Error
+}
+
+// ThumbUsageReports returns the number of reports per thumbnail type.
+func ThumbUsageReports() (result map[string]int, err error) {
+	var rows []ThumbUsage
+
+	result = make(map[string]int)
+
+	if err := Db().Find(&rows).Error; err != nil {
+		return result, err
+	}
+
+	for _, r := range rows {
+		result[r.ThumbType] = r.Reports
+	}
+
+	return result, nil
+}
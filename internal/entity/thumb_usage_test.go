@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportThumbUsage(t *testing.T) {
+	before, err := ThumbUsageReports()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReportThumbUsage("fit_1280"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReportThumbUsage("fit_1280"); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := ThumbUsageReports()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, before["fit_1280"]+2, after["fit_1280"])
+}
@@ -0,0 +1,8 @@
+package entity
+
+import "fmt"
+
+// VersionTag returns the quoted entity tag of a version number as used in ETag and If-Match headers.
+func VersionTag(version uint) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionTag(t *testing.T) {
+	assert.Equal(t, `"0"`, VersionTag(0))
+	assert.Equal(t, `"42"`, VersionTag(42))
+}
@@ -5,7 +5,7 @@ import (
 )
 
 func PublishEntities(name, ev string, entities interface{}) {
-	SharedHub().Publish(Message{
+	publish(Message{
 		Name: fmt.Sprintf("%s.%s", name, ev),
 		Fields: Data{
 			"entities": entities,
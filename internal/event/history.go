@@ -0,0 +1,136 @@
+package event
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// SeqKey is the data field that contains the sequence number of events kept for replay.
+const SeqKey = "seq"
+
+// ReplayTopics contains the topic prefixes of events kept for clients that reconnect.
+var ReplayTopics = []string{"photos.", "albums.", "labels.", "count."}
+
+var sharedHistory = NewHistory(1000)
+
+// History keeps recent events with ascending sequence numbers, so that clients which briefly
+// lost their connection can replay missed events instead of fetching everything again.
+type History struct {
+	mutex    sync.RWMutex
+	seq      uint64
+	size     int
+	messages []Message
+}
+
+// NewHistory returns a history that keeps up to size events. Sequence numbers start with
+// the current time in milliseconds, so they don't overlap after a restart.
+func NewHistory(size int) *History {
+	if size < 1 {
+		size = 1
+	}
+
+	return &History{
+		seq:      uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+		size:     size,
+		messages: make([]Message, 0, size),
+	}
+}
+
+// SharedHistory returns the history of the shared event hub.
+func SharedHistory() *History {
+	return sharedHistory
+}
+
+// Add adds a message with the next sequence number and returns it. The data is copied,
+// so that the original message isn't modified.
+func (h *History) Add(msg Message) Message {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.seq++
+
+	data := make(Data, len(msg.Fields)+1)
+
+	for k, v := range msg.Fields {
+		data[k] = v
+	}
+
+	data[SeqKey] = h.seq
+
+	result := Message{Name: msg.Name, Fields: data}
+
+	if len(h.messages) >= h.size {
+		copy(h.messages, h.messages[1:])
+		h.messages[len(h.messages)-1] = result
+	} else {
+		h.messages = append(h.messages, result)
+	}
+
+	return result
+}
+
+// Seq returns the sequence number of the latest event.
+func (h *History) Seq() uint64 {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.seq
+}
+
+// Since returns the events after the given sequence number. The result is incomplete if
+// events have been discarded in the meantime or the sequence number is unknown,
+// e.g. after a restart. Clients must then fetch the current state instead.
+func (h *History) Since(seq uint64) (result []Message, complete bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if seq > h.seq {
+		return result, false
+	}
+
+	oldest := h.seq + 1
+
+	if len(h.messages) > 0 {
+		oldest = Seq(h.messages[0])
+	}
+
+	if seq+1 < oldest {
+		return result, false
+	}
+
+	for _, msg := range h.messages {
+		if Seq(msg) > seq {
+			result = append(result, msg)
+		}
+	}
+
+	return result, true
+}
+
+// Seq returns the sequence number of a message, or 0 if it isn't kept for replay.
+func Seq(msg Message) uint64 {
+	seq, _ := msg.Fields[SeqKey].(uint64)
+
+	return seq
+}
+
+// replayable returns true if events with this name are kept for replay.
+func replayable(name string) bool {
+	for _, prefix := range ReplayTopics {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publish adds replayable events to the history before publishing them.
+func publish(msg Message) {
+	if replayable(msg.Name) {
+		msg = SharedHistory().Add(msg)
+	}
+
+	SharedHub().Publish(msg)
+}
@@ -0,0 +1,94 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory_Add(t *testing.T) {
+	h := NewHistory(2)
+	start := h.Seq()
+	data := Data{"id": 1}
+
+	msg := h.Add(Message{Name: "photos.updated", Fields: data})
+
+	assert.Equal(t, "photos.updated", msg.Name)
+	assert.Equal(t, start+1, Seq(msg))
+	assert.Equal(t, start+1, h.Seq())
+	assert.Equal(t, Data{"id": 1}, data)
+}
+
+func TestHistory_Since(t *testing.T) {
+	h := NewHistory(2)
+	start := h.Seq()
+
+	t.Run("empty", func(t *testing.T) {
+		result, complete := h.Since(start)
+
+		assert.True(t, complete)
+		assert.Empty(t, result)
+	})
+
+	h.Add(Message{Name: "photos.updated", Fields: Data{"id": 1}})
+	h.Add(Message{Name: "photos.updated", Fields: Data{"id": 2}})
+
+	t.Run("all", func(t *testing.T) {
+		result, complete := h.Since(start)
+
+		assert.True(t, complete)
+		assert.Len(t, result, 2)
+	})
+
+	h.Add(Message{Name: "albums.updated", Fields: Data{"id": 3}})
+
+	t.Run("latest", func(t *testing.T) {
+		result, complete := h.Since(start + 2)
+
+		assert.True(t, complete)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "albums.updated", result[0].Name)
+			assert.Equal(t, 3, result[0].Fields["id"])
+		}
+	})
+
+	t.Run("discarded", func(t *testing.T) {
+		result, complete := h.Since(start)
+
+		assert.False(t, complete)
+		assert.Empty(t, result)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		result, complete := h.Since(start + 100)
+
+		assert.False(t, complete)
+		assert.Empty(t, result)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		result, complete := h.Since(h.Seq())
+
+		assert.True(t, complete)
+		assert.Empty(t, result)
+	})
+}
+
+func TestPublishReplayable(t *testing.T) {
+	s := Subscribe("photos.updated")
+	seq := SharedHistory().Seq()
+
+	EntitiesUpdated("photos", "test")
+	msg := <-s.Receiver
+
+	assert.Equal(t, seq+1, Seq(msg))
+	assert.Equal(t, "test", msg.Fields["entities"])
+
+	result, complete := SharedHistory().Since(seq)
+
+	assert.True(t, complete)
+	assert.Len(t, result, 1)
+
+	Unsubscribe(s)
+}
@@ -40,7 +40,7 @@ func Warning(msg string) {
 }
 
 func Publish(event string, data Data) {
-	SharedHub().Publish(Message{
+	publish(Message{
 		Name:   event,
 		Fields: data,
 	})
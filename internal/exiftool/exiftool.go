@@ -0,0 +1,26 @@
+/*
+This package provides a pool of persistent exiftool processes for fast metadata extraction.
+
+Instead of starting a new process for every file, exiftool is started with "-stay_open True"
+and receives its arguments through stdin. Each command is terminated with "-execute" and exiftool
+signals completion by writing "{ready}" to stdout.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package exiftool
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// MaxRequests is the number of files a process handles before it is restarted to bound memory usage.
+var MaxRequests = 10000
+
+// Timeout is how long exiftool may take to process a file before the process is killed and replaced.
+var Timeout = 2 * time.Minute
@@ -0,0 +1,118 @@
+package exiftool
+
+import (
+	"errors"
+	"sync"
+)
+
+// Pool manages a bounded number of persistent exiftool processes.
+type Pool struct {
+	bin    string
+	size   int
+	idle   chan *Process
+	slots  chan struct{}
+	mutex  sync.Mutex
+	closed bool
+}
+
+// NewPool returns a new pool with up to size processes. Processes are started on demand.
+func NewPool(bin string, size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+
+	return &Pool{
+		bin:   bin,
+		size:  size,
+		idle:  make(chan *Process, size),
+		slots: make(chan struct{}, size),
+	}
+}
+
+// Size returns the max number of processes.
+func (p *Pool) Size() int {
+	return p.size
+}
+
+// Extract returns the metadata of a file as JSON encoded by exiftool.
+func (p *Pool) Extract(fileName string) ([]byte, error) {
+	proc, err := p.acquire()
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := proc.Extract(fileName)
+
+	// Broken processes are replaced, others are recycled after MaxRequests.
+	if proc.Broken() || proc.Requests() >= MaxRequests {
+		p.discard(proc)
+	} else {
+		p.release(proc)
+	}
+
+	return out, err
+}
+
+// Close stops all idle processes. Busy processes are stopped when they are released.
+func (p *Pool) Close() {
+	p.mutex.Lock()
+	p.closed = true
+	p.mutex.Unlock()
+
+	for {
+		select {
+		case proc := <-p.idle:
+			p.discard(proc)
+		default:
+			return
+		}
+	}
+}
+
+// acquire returns an idle process or starts a new one if the pool is not full.
+func (p *Pool) acquire() (*Process, error) {
+	p.mutex.Lock()
+	closed := p.closed
+	p.mutex.Unlock()
+
+	if closed {
+		return nil, errors.New("exiftool: pool closed")
+	}
+
+	select {
+	case proc := <-p.idle:
+		return proc, nil
+	case p.slots <- struct{}{}:
+		proc, err := NewProcess(p.bin)
+
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+
+		return proc, nil
+	}
+}
+
+// release returns a process to the pool.
+func (p *Pool) release(proc *Process) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		go p.discard(proc)
+		return
+	}
+
+	p.idle <- proc
+}
+
+// discard stops a process and frees its slot.
+func (p *Pool) discard(proc *Process) {
+	if err := proc.Close(); err != nil {
+		log.Debugf("exiftool: %s", err)
+	}
+
+	<-p.slots
+}
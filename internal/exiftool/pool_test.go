@@ -0,0 +1,61 @@
+package exiftool
+
+import (
+	"os/exec"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPool(t *testing.T) {
+	p := NewPool("exiftool", 0)
+
+	assert.Equal(t, 1, p.Size())
+
+	p.Close()
+
+	_, err := p.Extract("cat_brown.jpg")
+
+	assert.EqualError(t, err, "exiftool: pool closed")
+}
+
+func TestPool_Extract(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		p := NewPool("", 2)
+
+		_, err := p.Extract("cat_brown.jpg")
+
+		assert.EqualError(t, err, "exiftool: executable not found")
+		assert.Len(t, p.slots, 0)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		bin, err := exec.LookPath("exiftool")
+
+		if err != nil {
+			t.Skip("exiftool not installed")
+		}
+
+		p := NewPool(bin, 2)
+
+		defer p.Close()
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				out, err := p.Extract("../../assets/resources/examples/cat_brown.jpg")
+
+				assert.NoError(t, err)
+				assert.Contains(t, string(out), "cat_brown.jpg")
+			}()
+		}
+
+		wg.Wait()
+
+		assert.LessOrEqual(t, len(p.idle), 2)
+	})
+}
@@ -0,0 +1,220 @@
+package exiftool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readyMarker is written to stdout by exiftool after each executed command.
+var readyMarker = []byte("{ready")
+
+// Process represents a running exiftool process in stay open mode.
+type Process struct {
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	stdout   *bufio.Reader
+	stderr   stderrBuffer
+	requests int
+	broken   bool
+}
+
+// stderrBuffer collects error messages written by exiftool in the background.
+type stderrBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *stderrBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *stderrBuffer) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.buf.Reset()
+}
+
+func (b *stderrBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buf.String()
+}
+
+// NewProcess starts a new exiftool process in stay open mode.
+func NewProcess(bin string) (*Process, error) {
+	if bin == "" {
+		return nil, errors.New("exiftool: executable not found")
+	}
+
+	p := &Process{}
+
+	p.cmd = exec.Command(bin, "-stay_open", "True", "-@", "-", "-common_args", "-j", "-charset", "filename=utf8")
+	p.cmd.Stderr = &p.stderr
+
+	stdin, err := p.cmd.StdinPipe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := p.cmd.StdoutPipe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.stdin = stdin
+	p.stdout = bufio.NewReader(stdout)
+
+	if err := p.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Extract returns the metadata of a file as JSON encoded by exiftool.
+func (p *Process) Extract(fileName string) ([]byte, error) {
+	if strings.ContainsAny(fileName, "\r\n") {
+		return nil, fmt.Errorf("exiftool: invalid file name %s", fileName)
+	}
+
+	p.requests++
+	p.stderr.Reset()
+
+	if _, err := fmt.Fprintf(p.stdin, "%s\n-execute\n", fileName); err != nil {
+		p.broken = true
+		return nil, err
+	}
+
+	out, err := p.wait(fileName)
+
+	if err != nil {
+		p.broken = true
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		if msg := strings.TrimSpace(p.stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+
+		return nil, fmt.Errorf("exiftool: no metadata found in %s", fileName)
+	}
+
+	if err := validate(out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// response represents the output of an executed command.
+type response struct {
+	out []byte
+	err error
+}
+
+// wait returns the output of the last command, or kills the process if exiftool doesn't respond within Timeout,
+// so that a hanging process can't block indexing.
+func (p *Process) wait(fileName string) ([]byte, error) {
+	result := make(chan response, 1)
+
+	go func() {
+		out, err := readResponse(p.stdout)
+		result <- response{out: out, err: err}
+	}()
+
+	select {
+	case r := <-result:
+		return r.out, r.err
+	case <-time.After(Timeout):
+		if err := p.cmd.Process.Kill(); err != nil {
+			log.Debugf("exiftool: %s", err)
+		}
+
+		return nil, fmt.Errorf("exiftool: timeout while processing %s", fileName)
+	}
+}
+
+// Requests returns the number of files processed so far.
+func (p *Process) Requests() int {
+	return p.requests
+}
+
+// Broken returns true if the process can't be used anymore.
+func (p *Process) Broken() bool {
+	return p.broken
+}
+
+// Close stops the exiftool process.
+func (p *Process) Close() error {
+	if _, err := io.WriteString(p.stdin, "-stay_open\nFalse\n"); err != nil {
+		log.Debugf("exiftool: %s", err)
+	}
+
+	if err := p.stdin.Close(); err != nil {
+		log.Debugf("exiftool: %s", err)
+	}
+
+	return p.cmd.Wait()
+}
+
+// readResponse reads lines from r until exiftool signals that the command has been executed.
+func readResponse(r *bufio.Reader) ([]byte, error) {
+	var out bytes.Buffer
+
+	for {
+		line, err := r.ReadBytes('\n')
+
+		if bytes.HasPrefix(line, readyMarker) {
+			return out.Bytes(), nil
+		}
+
+		out.Write(line)
+
+		if err == io.EOF {
+			return nil, errors.New("exiftool: unexpected end of output")
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// validate decodes the JSON output token by token to make sure it is a complete array of objects.
+func validate(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if t, err := dec.Token(); err != nil {
+		return fmt.Errorf("exiftool: %s", err)
+	} else if d, ok := t.(json.Delim); !ok || d != '[' {
+		return errors.New("exiftool: expected json array")
+	}
+
+	for dec.More() {
+		var obj map[string]json.RawMessage
+
+		if err := dec.Decode(&obj); err != nil {
+			return fmt.Errorf("exiftool: %s", err)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("exiftool: %s", err)
+	}
+
+	return nil
+}
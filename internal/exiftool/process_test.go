@@ -0,0 +1,132 @@
+package exiftool
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadResponse(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("[{\n  \"FileName\": \"cat.jpg\"\n}]\n{ready}\n[{}]\n{ready}\n"))
+
+		out, err := readResponse(r)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "[{\n  \"FileName\": \"cat.jpg\"\n}]\n", string(out))
+
+		out, err = readResponse(r)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "[{}]\n", string(out))
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("{ready}\n"))
+
+		out, err := readResponse(r)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, out)
+	})
+	t.Run("eof", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("[{}]\n"))
+
+		_, err := readResponse(r)
+
+		assert.EqualError(t, err, "exiftool: unexpected end of output")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validate([]byte(`[{"SourceFile": "cat.jpg", "ImageWidth": 100}, {}]`)))
+	})
+	t.Run("object", func(t *testing.T) {
+		assert.EqualError(t, validate([]byte(`{"SourceFile": "cat.jpg"}`)), "exiftool: expected json array")
+	})
+	t.Run("truncated", func(t *testing.T) {
+		assert.Error(t, validate([]byte(`[{"SourceFile": "cat.jpg"`)))
+	})
+}
+
+func TestProcess_Extract(t *testing.T) {
+	bin, err := exec.LookPath("exiftool")
+
+	if err != nil {
+		t.Skip("exiftool not installed")
+	}
+
+	p, err := NewProcess(bin)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer p.Close()
+
+	t.Run("cat_brown.jpg", func(t *testing.T) {
+		out, err := p.Extract("../../assets/resources/examples/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(out), "cat_brown.jpg")
+		assert.Equal(t, 1, p.Requests())
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := p.Extract("foo\nbar.jpg")
+
+		assert.Error(t, err)
+		assert.False(t, p.Broken())
+	})
+}
+
+func TestProcess_Timeout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exiftool")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	// Fake exiftool that never responds.
+	bin := filepath.Join(dir, "exiftool")
+
+	if err := ioutil.WriteFile(bin, []byte("#!/bin/sh\nexec sleep 60\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(d time.Duration) { Timeout = d }(Timeout)
+	Timeout = 100 * time.Millisecond
+
+	p, err := NewProcess(bin)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer p.Close()
+
+	_, err = p.Extract("cat.jpg")
+
+	assert.EqualError(t, err, "exiftool: timeout while processing cat.jpg")
+	assert.True(t, p.Broken())
+}
@@ -0,0 +1,90 @@
+/*
+This package renders Atom feeds, e.g. of the photos recently added to an album.
+
+See RFC 4287 for the format specification:
+
+https://tools.ietf.org/html/rfc4287
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ContentType is the media type of Atom feeds.
+const ContentType = "application/atom+xml; charset=utf-8"
+
+// Namespace is the XML namespace of Atom feeds.
+const Namespace = "http://www.w3.org/2005/Atom"
+
+// Feed represents an Atom feed document.
+type Feed struct {
+	XMLName xml.Name `xml:"feed"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Author  *Person  `xml:"author,omitempty"`
+	Links   []Link   `xml:"link"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Person represents the author of a feed.
+type Person struct {
+	Name string `xml:"name"`
+}
+
+// Link represents a link to a web page or an enclosure like an image.
+type Link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// Content represents the text or HTML content of an entry.
+type Content struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// Entry represents a feed entry.
+type Entry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Updated   string   `xml:"updated"`
+	Published string   `xml:"published,omitempty"`
+	Links     []Link   `xml:"link"`
+	Summary   string   `xml:"summary,omitempty"`
+	Content   *Content `xml:"content,omitempty"`
+}
+
+// New returns an empty feed with the given id, title, and time of the last update.
+func New(id, title string, updated time.Time) *Feed {
+	return &Feed{
+		Xmlns:   Namespace,
+		ID:      id,
+		Title:   title,
+		Updated: Time(updated),
+	}
+}
+
+// Time formats a timestamp as required by Atom feeds.
+func Time(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Bytes returns the feed as XML document.
+func (f *Feed) Bytes() ([]byte, error) {
+	data, err := xml.MarshalIndent(f, "", "  ")
+
+	if err != nil {
+		return data, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,42 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	loc := time.FixedZone("CEST", 2*60*60)
+	assert.Equal(t, "2020-05-01T10:00:00Z", Time(time.Date(2020, 5, 1, 12, 0, 0, 0, loc)))
+}
+
+func TestFeed_Bytes(t *testing.T) {
+	f := New("urn:uid:at9lxuqxpogaaba8", "Holiday & Family", time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC))
+	f.Links = append(f.Links, Link{Rel: "self", Href: "https://photos.example.com/feed"})
+	f.Entries = append(f.Entries, Entry{
+		ID:      "urn:uid:pt9jtdre2lvl0yh7",
+		Title:   "Beach",
+		Updated: f.Updated,
+		Links:   []Link{{Rel: "enclosure", Type: "image/jpeg", Href: "https://photos.example.com/t/1.jpg"}},
+		Content: &Content{Type: "html", Body: `<img src="https://photos.example.com/t/1.jpg">`},
+	})
+
+	data, err := f.Bytes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := string(data)
+
+	assert.True(t, strings.HasPrefix(s, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, s, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, s, `<title>Holiday &amp; Family</title>`)
+	assert.Contains(t, s, `<updated>2020-05-01T12:00:00Z</updated>`)
+	assert.Contains(t, s, `<link rel="enclosure" type="image/jpeg" href="https://photos.example.com/t/1.jpg"></link>`)
+	assert.Contains(t, s, `<content type="html">&lt;img src=&#34;https://photos.example.com/t/1.jpg&#34;&gt;</content>`)
+	assert.NotContains(t, s, "<author>")
+}
@@ -6,18 +6,21 @@ import "github.com/ulule/deepcopier"
 type Album struct {
 	CoverUID         string `json:"CoverUID"`
 	FolderUID        string `json:"FolderUID"`
+	ParentUID        string `json:"ParentUID"`
 	AlbumType        string `json:"Type"`
 	AlbumTitle       string `json:"Title"`
 	AlbumCategory    string `json:"Category"`
 	AlbumCaption     string `json:"Caption"`
 	AlbumDescription string `json:"Description"`
 	AlbumNotes       string `json:"Notes"`
+	AlbumLocation    string `json:"Location"`
 	AlbumFilter      string `json:"Filter"`
 	AlbumOrder       string `json:"Order"`
 	AlbumTemplate    string `json:"Template"`
 	AlbumCountry     string `json:"Country"`
 	AlbumYear        int    `json:"Year"`
 	AlbumMonth       int    `json:"Month"`
+	AlbumDay         int    `json:"Day"`
 	AlbumFavorite    bool   `json:"Favorite"`
 	AlbumPrivate     bool   `json:"Private"`
 }
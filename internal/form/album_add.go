@@ -0,0 +1,8 @@
+package form
+
+// AlbumAdd represents a form for adding a photo to an existing album, or to a new album with the given title.
+type AlbumAdd struct {
+	AlbumUID  string `json:"AlbumUID"`
+	Title     string `json:"Title"`
+	ParentUID string `json:"ParentUID"`
+}
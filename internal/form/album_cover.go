@@ -0,0 +1,6 @@
+package form
+
+// AlbumCover represents the photo used as album cover.
+type AlbumCover struct {
+	Photo string `json:"Photo" binding:"required"`
+}
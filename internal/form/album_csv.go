@@ -0,0 +1,6 @@
+package form
+
+// AlbumCsv represents options for importing album photos from a CSV file.
+type AlbumCsv struct {
+	Replace bool `form:"replace"`
+}
@@ -0,0 +1,42 @@
+package form
+
+// Options for RAW files in album downloads.
+const (
+	RawExclude = "exclude"
+	RawInclude = "include"
+)
+
+// SizeOriginal is the default size of album downloads.
+const SizeOriginal = "original"
+
+// AlbumDownload represents optional filters for downloading a subset of an album. Quality is the minimum
+// quality score like in PhotoSearch, Size either "original" or a thumbnail type like "fit_2048" for resized JPEGs.
+type AlbumDownload struct {
+	Favorite bool   `form:"favorite"`
+	Quality  int    `form:"quality"`
+	Label    string `form:"label"`
+	Size     string `form:"size"`
+	Raw      string `form:"raw"`
+}
+
+// Original returns true if originals should be downloaded instead of resized JPEGs.
+func (f AlbumDownload) Original() bool {
+	return f.Size == "" || f.Size == SizeOriginal
+}
+
+// IncludeRaw returns true if RAW originals should be added to the download.
+func (f AlbumDownload) IncludeRaw() bool {
+	return f.Raw == RawInclude
+}
+
+// PhotoSearch returns the search form for photos in the given album matching the filters.
+func (f AlbumDownload) PhotoSearch(albumUID string) PhotoSearch {
+	return PhotoSearch{
+		Album:    albumUID,
+		Favorite: f.Favorite,
+		Quality:  f.Quality,
+		Label:    f.Label,
+		Count:    10000,
+		Offset:   0,
+	}
+}
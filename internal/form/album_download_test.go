@@ -0,0 +1,31 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumDownload_PhotoSearch(t *testing.T) {
+	f := AlbumDownload{Favorite: true, Quality: 3, Label: "cat"}
+
+	result := f.PhotoSearch("at9lxuqxpogaaba7")
+
+	assert.Equal(t, "at9lxuqxpogaaba7", result.Album)
+	assert.True(t, result.Favorite)
+	assert.Equal(t, 3, result.Quality)
+	assert.Equal(t, "cat", result.Label)
+	assert.Equal(t, 10000, result.Count)
+}
+
+func TestAlbumDownload_Original(t *testing.T) {
+	assert.True(t, AlbumDownload{}.Original())
+	assert.True(t, AlbumDownload{Size: "original"}.Original())
+	assert.False(t, AlbumDownload{Size: "fit_2048"}.Original())
+}
+
+func TestAlbumDownload_IncludeRaw(t *testing.T) {
+	assert.False(t, AlbumDownload{}.IncludeRaw())
+	assert.False(t, AlbumDownload{Raw: "exclude"}.IncludeRaw())
+	assert.True(t, AlbumDownload{Raw: "include"}.IncludeRaw())
+}
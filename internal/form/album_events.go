@@ -0,0 +1,7 @@
+package form
+
+// AlbumEvents represents search form fields for "/api/v1/albums/:uid/events".
+type AlbumEvents struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// AlbumHighlights represents a request to create a curated highlights album.
+type AlbumHighlights struct {
+	Title string `json:"Title"`
+	Count int    `json:"Count"`
+}
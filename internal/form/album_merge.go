@@ -0,0 +1,6 @@
+package form
+
+// AlbumMerge represents a request to merge albums into another album.
+type AlbumMerge struct {
+	Albums []string `json:"albums" binding:"required"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// AlbumParent represents a form for moving an album into another album, or to the top level if empty.
+type AlbumParent struct {
+	ParentUID string `json:"ParentUID"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// AlbumPhotoOrder represents the manual order of photos in an album.
+type AlbumPhotoOrder struct {
+	Photos []string `json:"photos" binding:"required"`
+}
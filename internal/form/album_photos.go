@@ -0,0 +1,13 @@
+package form
+
+// AlbumPhotos represents a form for adding photos to an album, either by selection or by a search
+// filter like "label:beach year:2022" that is applied on the server.
+type AlbumPhotos struct {
+	Selection
+	Filter string `json:"filter"`
+}
+
+// Empty returns true if neither photos nor a filter have been specified.
+func (f AlbumPhotos) Empty() bool {
+	return f.Selection.Empty() && f.Filter == ""
+}
@@ -0,0 +1,34 @@
+package form
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumPhotos_Empty(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, AlbumPhotos{}.Empty())
+	})
+	t.Run("photos", func(t *testing.T) {
+		var f AlbumPhotos
+
+		if err := json.Unmarshal([]byte(`{"photos": ["pt9jtdre2lvl0yh7"]}`), &f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, f.Photos)
+		assert.False(t, f.Empty())
+	})
+	t.Run("filter", func(t *testing.T) {
+		var f AlbumPhotos
+
+		if err := json.Unmarshal([]byte(`{"filter": "label:beach year:2022"}`), &f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "label:beach year:2022", f.Filter)
+		assert.False(t, f.Empty())
+	})
+}
@@ -0,0 +1,7 @@
+package form
+
+// AlbumPrint represents a print order for an album.
+type AlbumPrint struct {
+	Size   string `form:"size" json:"Size" binding:"required"`
+	Copies int    `form:"copies" json:"Copies"`
+}
@@ -0,0 +1,14 @@
+package form
+
+import "time"
+
+// AlbumRule represents a rule that adds imported photos to an album if all given conditions match.
+type AlbumRule struct {
+	Name         string     `json:"Name"`
+	AlbumUID     string     `json:"AlbumUID" binding:"required"`
+	Folder       string     `json:"Folder"`
+	After        *time.Time `json:"After"`
+	Before       *time.Time `json:"Before"`
+	CameraSerial string     `json:"CameraSerial"`
+	Uploader     string     `json:"Uploader"`
+}
@@ -5,12 +5,14 @@ type AlbumSearch struct {
 	Query    string `form:"q"`
 	ID       string `form:"id"`
 	Slug     string `form:"slug"`
+	Parent   string `form:"parent"`
 	Title    string `form:"title"`
 	Country  string `json:"country"`
 	Year     int    `json:"year"`
 	Month    int    `json:"month"`
 	Favorite bool   `form:"favorite"`
 	Private  bool   `form:"private"`
+	Tree     bool   `form:"tree"`
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
@@ -0,0 +1,6 @@
+package form
+
+// AlbumStory represents a form for editing the Markdown story of an album.
+type AlbumStory struct {
+	Markdown string `json:"Markdown"`
+}
@@ -0,0 +1,10 @@
+package form
+
+// ApiV2List represents the query parameters of list endpoints in "/api/v2". Filters are part of the
+// search query, e.g. "label:cat year:2020".
+type ApiV2List struct {
+	Query  string `form:"q"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+	Order  string `form:"order"`
+}
@@ -0,0 +1,13 @@
+package form
+
+// BackupCheck represents a list of SHA1 file hashes a mobile backup app wants to check.
+type BackupCheck struct {
+	Hashes []string `json:"Hashes" binding:"required"`
+}
+
+// BackupUpload represents a file a mobile backup app is going to upload.
+type BackupUpload struct {
+	Name string `json:"Name" binding:"required"`
+	Size int64  `json:"Size" binding:"required"`
+	Hash string `json:"Hash" binding:"required"`
+}
@@ -0,0 +1,9 @@
+package form
+
+// ChatShare represents a form for posting photos and a share link to the configured chat.
+type ChatShare struct {
+	Photos  []string `json:"Photos" binding:"required"`
+	Message string   `json:"Message"`
+	Size    string   `json:"Size"`
+	Expires int      `json:"Expires"`
+}
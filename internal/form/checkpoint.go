@@ -0,0 +1,27 @@
+package form
+
+// Checkpoint represents a request to save the current library metadata in a new checkpoint.
+type Checkpoint struct {
+	Note string `json:"Note"`
+}
+
+// CheckpointSearch represents search form fields for "/api/v1/checkpoints".
+type CheckpointSearch struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
+
+// CheckpointDiff represents a request to list fields changed since a checkpoint was created.
+type CheckpointDiff struct {
+	Field  string `form:"field"`
+	Count  int    `form:"count" binding:"required"`
+	Offset int    `form:"offset"`
+}
+
+// CheckpointRestore represents a request to restore fields of photos and albums to a checkpoint.
+// All changed photos, albums and fields are restored if none are selected.
+type CheckpointRestore struct {
+	Photos []string `json:"photos"`
+	Albums []string `json:"albums"`
+	Fields []string `json:"fields"`
+}
@@ -0,0 +1,8 @@
+package form
+
+// Device represents a form to register or update a device that uploads photos, e.g. using a mobile backup app.
+type Device struct {
+	Name  string `json:"Name"`
+	Album bool   `json:"Album"`
+	Label bool   `json:"Label"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// DisplaySize represents a display size report from a client, in physical pixels.
+type DisplaySize struct {
+	Width  int `json:"Width" binding:"required"`
+	Height int `json:"Height" binding:"required"`
+}
@@ -0,0 +1,14 @@
+package form
+
+// Duplicates represents search form fields for "/api/v1/duplicates".
+type Duplicates struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
+
+// DuplicateResolve represents a request to resolve a group of duplicate photos.
+type DuplicateResolve struct {
+	Action string   `json:"Action" binding:"required"`
+	Photos []string `json:"Photos" binding:"required"`
+	DryRun bool     `json:"DryRun"`
+}
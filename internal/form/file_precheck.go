@@ -0,0 +1,12 @@
+package form
+
+// FilePrecheck represents files an upload client wants to check before transferring them.
+type FilePrecheck struct {
+	Files []FilePrecheckItem `json:"Files" binding:"required"`
+}
+
+// FilePrecheckItem represents a file by its SHA1 hash and optional size in bytes.
+type FilePrecheckItem struct {
+	Hash string `json:"Hash"`
+	Size int64  `json:"Size"`
+}
@@ -19,6 +19,7 @@ type GeoSearch struct {
 	S2       string    `form:"s2"`
 	Olc      string    `form:"olc"`
 	Dist     uint      `form:"dist"`
+	Alt      string    `form:"alt"`
 	Quality  int       `form:"quality"`
 	Review   bool      `form:"review"`
 	Album    string    `form:"album"`
@@ -0,0 +1,7 @@
+package form
+
+// GeometryReview represents search form fields for "/api/v1/geometry".
+type GeometryReview struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
@@ -0,0 +1,17 @@
+package form
+
+// Guest represents a request to create a guest account from a share link.
+type Guest struct {
+	Name     string `json:"Name" binding:"required"`
+	Password string `json:"Password"`
+}
+
+// GuestLogin represents a guest login with a bookmarkable login token.
+type GuestLogin struct {
+	Token string `json:"Token" binding:"required"`
+}
+
+// GuestUpgrade represents additional albums a guest account gets access to when upgraded.
+type GuestUpgrade struct {
+	Albums []string `json:"Albums"`
+}
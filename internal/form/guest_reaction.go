@@ -0,0 +1,9 @@
+package form
+
+// GuestReaction represents a reaction form submitted by a share link visitor.
+type GuestReaction struct {
+	PhotoUID string `json:"PhotoUID"`
+	Name     string `json:"Name"`
+	Emoji    string `json:"Emoji"`
+	Note     string `json:"Note"`
+}
@@ -0,0 +1,40 @@
+package form
+
+import "time"
+
+// ImmichLogin represents a login request of the Immich mobile app.
+type ImmichLogin struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// ImmichAsset represents a file the Immich app wants to upload, identified by its SHA1 checksum.
+type ImmichAsset struct {
+	ID       string `json:"id"`
+	Checksum string `json:"checksum"`
+}
+
+// ImmichUploadCheck represents a request of the Immich app to check which files need to be uploaded.
+type ImmichUploadCheck struct {
+	Assets []ImmichAsset `json:"assets"`
+}
+
+// ImmichUpload represents the form fields of a file uploaded by the Immich app, which sends the file
+// itself as "assetData".
+type ImmichUpload struct {
+	DeviceAssetID  string    `form:"deviceAssetId"`
+	DeviceID       string    `form:"deviceId"`
+	FileCreatedAt  time.Time `form:"fileCreatedAt"`
+	FileModifiedAt time.Time `form:"fileModifiedAt"`
+	IsFavorite     bool      `form:"isFavorite"`
+	Duration       string    `form:"duration"`
+}
+
+// TakenAt returns the date of the file shown in the app.
+func (f ImmichUpload) TakenAt() time.Time {
+	if !f.FileCreatedAt.IsZero() {
+		return f.FileCreatedAt.UTC()
+	}
+
+	return f.FileModifiedAt.UTC()
+}
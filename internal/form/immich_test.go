@@ -0,0 +1,17 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmichUpload_TakenAt(t *testing.T) {
+	created := time.Date(2020, 8, 12, 10, 30, 0, 0, time.UTC)
+	modified := time.Date(2020, 8, 14, 18, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, created, ImmichUpload{FileCreatedAt: created, FileModifiedAt: modified}.TakenAt())
+	assert.Equal(t, modified, ImmichUpload{FileModifiedAt: modified}.TakenAt())
+	assert.True(t, ImmichUpload{}.TakenAt().IsZero())
+}
@@ -0,0 +1,7 @@
+package form
+
+// ImportUrl represents a remote image or video to be imported.
+type ImportUrl struct {
+	Url      string `json:"url" binding:"required"`
+	Checksum string `json:"checksum"`
+}
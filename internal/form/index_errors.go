@@ -0,0 +1,9 @@
+package form
+
+// IndexErrors represents search form fields for "/api/v1/errors".
+type IndexErrors struct {
+	Kind    string `form:"kind"`
+	Ignored bool   `form:"ignored"`
+	Count   int    `form:"count" binding:"required"`
+	Offset  int    `form:"offset"`
+}
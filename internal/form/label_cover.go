@@ -0,0 +1,7 @@
+package form
+
+// LabelCover represents the photo and optional image region, e.g. a face, used as label cover.
+type LabelCover struct {
+	Photo string `json:"Photo" binding:"required"`
+	Crop  string `json:"Crop"`
+}
@@ -0,0 +1,11 @@
+package form
+
+// LinkRenew represents a form for extending the expiration date of a share link.
+type LinkRenew struct {
+	Expires int `json:"Expires"`
+}
+
+// LinkRenewal represents a form for renewing a share link automatically before it expires.
+type LinkRenewal struct {
+	Renewal int `json:"Renewal"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// LinkRestrictions represents a form for limiting the networks and sites a share link can be used from.
+type LinkRestrictions struct {
+	AllowedIPs     []string `json:"AllowedIPs"`
+	AllowedOrigins []string `json:"AllowedOrigins"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// LinkScope represents a form for sharing nested albums with an album link.
+type LinkScope struct {
+	Subtree bool     `json:"Subtree"`
+	Exclude []string `json:"Exclude"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// LinkUrl represents a form for changing the vanity slug and custom domain of a share link.
+type LinkUrl struct {
+	Slug   string `json:"Slug"`
+	Domain string `json:"Domain"`
+}
@@ -0,0 +1,15 @@
+package form
+
+// MissingFiles represents search form fields for "/api/v1/index/missing".
+type MissingFiles struct {
+	Path   string `form:"path"`
+	Count  int    `form:"count" binding:"required"`
+	Offset int    `form:"offset"`
+}
+
+// RelocateFiles represents a request to move missing files from one originals folder to another,
+// e.g. after a drive was mounted elsewhere.
+type RelocateFiles struct {
+	From string `json:"From" binding:"required"`
+	To   string `json:"To" binding:"required"`
+}
@@ -2,8 +2,14 @@ package form
 
 // Link represents a sharing link form.
 type NewLink struct {
-	Password   string `json:"Password"`
-	Expires    int    `json:"Expires"`
-	CanComment bool   `json:"CanComment"`
-	CanEdit    bool   `json:"CanEdit"`
+	Password    string `json:"Password"`
+	Expires     int    `json:"Expires"`
+	CanComment  bool   `json:"CanComment"`
+	CanEdit     bool   `json:"CanEdit"`
+	CanDownload bool   `json:"CanDownload"`
+
+	WatermarkText     string  `json:"WatermarkText"`
+	WatermarkImage    string  `json:"WatermarkImage"`
+	WatermarkPosition string  `json:"WatermarkPosition"`
+	WatermarkOpacity  float64 `json:"WatermarkOpacity"`
 }
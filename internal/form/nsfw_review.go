@@ -0,0 +1,7 @@
+package form
+
+// NSFWReview represents search form fields for "/api/v1/nsfw".
+type NSFWReview struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
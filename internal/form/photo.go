@@ -7,18 +7,22 @@ import (
 )
 
 type Details struct {
-	PhotoID   uint   `json:"PhotoID" deepcopier:"skip"`
-	Keywords  string `json:"Keywords"`
-	Notes     string `json:"Notes"`
-	Subject   string `json:"Subject"`
-	Artist    string `json:"Artist"`
-	Copyright string `json:"Copyright"`
-	License   string `json:"License"`
+	PhotoID     uint   `json:"PhotoID" deepcopier:"skip"`
+	Keywords    string `json:"Keywords"`
+	Notes       string `json:"Notes"`
+	Subject     string `json:"Subject"`
+	Artist      string `json:"Artist"`
+	Copyright   string `json:"Copyright"`
+	License     string `json:"License"`
+	Attribution string `json:"Attribution"`
+	Credit      string `json:"Credit"`
 }
 
 // Photo represents a photo edit form.
 type Photo struct {
 	PhotoType        string    `json:"Type"`
+	PhotoCategory    string    `json:"Category"`
+	CategorySrc      string    `json:"CategorySrc"`
 	TakenAt          time.Time `json:"TakenAt"`
 	TakenAtLocal     time.Time `json:"TakenAtLocal"`
 	TakenSrc         string    `json:"TakenSrc"`
@@ -34,6 +38,8 @@ type Photo struct {
 	PhotoLat         float32   `json:"Lat"`
 	PhotoLng         float32   `json:"Lng"`
 	PhotoAltitude    int       `json:"Altitude"`
+	PhotoDirection   float32   `json:"Direction"`
+	PhotoSpeed       float32   `json:"Speed"`
 	PhotoIso         int       `json:"Iso"`
 	PhotoFocalLength int       `json:"FocalLength"`
 	PhotoFNumber     float32   `json:"FNumber"`
@@ -0,0 +1,6 @@
+package form
+
+// PhotoAlbumCaption represents a form for changing the caption of a photo in a specific album.
+type PhotoAlbumCaption struct {
+	Caption string `json:"Caption"`
+}
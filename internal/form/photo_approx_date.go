@@ -0,0 +1,8 @@
+package form
+
+// PhotoApproxDate represents a batch edit form for assigning an approximate date, e.g. to scans.
+type PhotoApproxDate struct {
+	Photos []string `json:"photos"`
+	Year   int      `json:"Year"`
+	Approx string   `json:"Approx"`
+}
@@ -0,0 +1,14 @@
+package form
+
+// PhotoLicense represents a batch edit form for license and attribution details.
+type PhotoLicense struct {
+	Photos      []string `json:"photos"`
+	License     string   `json:"License"`
+	Attribution string   `json:"Attribution"`
+	Credit      string   `json:"Credit"`
+}
+
+// Empty returns true if no details should be changed.
+func (f PhotoLicense) Empty() bool {
+	return f.License == "" && f.Attribution == "" && f.Credit == ""
+}
@@ -0,0 +1,20 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoLicense_Empty(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		f := PhotoLicense{Photos: []string{"pt9jtdre2lvl0yh7"}}
+
+		assert.True(t, f.Empty())
+	})
+	t.Run("license", func(t *testing.T) {
+		f := PhotoLicense{Photos: []string{"pt9jtdre2lvl0yh7"}, License: "CC BY 4.0"}
+
+		assert.False(t, f.Empty())
+	})
+}
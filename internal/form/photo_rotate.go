@@ -0,0 +1,7 @@
+package form
+
+// PhotoRotate represents a batch edit form for rotating photos clockwise by 90, 180 or 270 degrees.
+type PhotoRotate struct {
+	Photos []string `json:"photos"`
+	Angle  int      `json:"angle"`
+}
@@ -1,17 +1,23 @@
 package form
 
 import (
+	"strconv"
+	"strings"
 	"time"
 )
 
 // PhotoSearch represents search form fields for "/api/v1/photos".
 type PhotoSearch struct {
 	Query     string    `form:"q"`
+	Semantic  string    `form:"semantic"`
 	ID        string    `form:"id"`
 	Type      string    `form:"type"`
+	Category  string    `form:"category"`
 	Path      string    `form:"path"`
 	Folder    string    `form:"folder"` // Alias for Path
 	Name      string    `form:"name"`
+	Filename  string    `form:"filename"`
+	Ext       string    `form:"ext"`
 	Title     string    `form:"title"`
 	Hash      string    `form:"hash"`
 	Video     bool      `form:"video"`
@@ -19,18 +25,31 @@ type PhotoSearch struct {
 	Duplicate bool      `form:"duplicate"`
 	Archived  bool      `form:"archived"`
 	Error     bool      `form:"error"`
+	Cold      bool      `form:"cold"`
+	Primary   bool      `form:"primary"`
 	Lat       float32   `form:"lat"`
 	Lng       float32   `form:"lng"`
 	Dist      uint      `form:"dist"`
+	Alt       string    `form:"alt"`
+	Near      string    `form:"near"`
 	Fmin      float32   `form:"fmin"`
 	Fmax      float32   `form:"fmax"`
+	Dmin      uint      `form:"dmin"`
+	Dmax      uint      `form:"dmax"`
 	Chroma    uint8     `form:"chroma"`
 	Diff      uint32    `form:"diff"`
 	Mono      bool      `form:"mono"`
+	Exposed   string    `form:"exposed"`
 	Portrait  bool      `form:"portrait"`
+	Ratio     string    `form:"ratio"`
+	Mp        string    `form:"mp"`
+	Res       string    `form:"res"`
 	Location  bool      `form:"location"`
 	Album     string    `form:"album"`
+	Snapshot  string    `form:"snapshot"`
+	Device    string    `form:"device"`
 	Label     string    `form:"label"`
+	Person    string    `form:"person"` // Alias for Label
 	Country   string    `form:"country"`
 	Year      int       `form:"year"`
 	Month     int       `form:"month"`
@@ -42,6 +61,7 @@ type PhotoSearch struct {
 	Before    time.Time `form:"before" time_format:"2006-01-02"`
 	After     time.Time `form:"after" time_format:"2006-01-02"`
 	Favorite  bool      `form:"favorite"`
+	Undated   bool      `form:"undated"`
 	Public    bool      `form:"public"`
 	Private   bool      `form:"private"`
 	Safe      bool      `form:"safe"`
@@ -66,9 +86,31 @@ func (f *PhotoSearch) ParseQueryString() error {
 		f.Path = f.Folder
 	}
 
+	if f.Label == "" && f.Person != "" {
+		f.Label = f.Person
+	}
+
 	return err
 }
 
+// NearLatLng returns the coordinates of the point results can be sorted by distance from, e.g. "52.52,13.405".
+func (f *PhotoSearch) NearLatLng() (lat, lng float64, ok bool) {
+	values := strings.Split(f.Near, ",")
+
+	if len(values) != 2 {
+		return 0, 0, false
+	}
+
+	lat, errLat := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	lng, errLng := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+	if errLat != nil || errLng != nil || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return 0, 0, false
+	}
+
+	return lat, lng, true
+}
+
 // Serialize returns a string containing non-empty fields and values of a struct.
 func (f *PhotoSearch) Serialize() string {
 	return Serialize(f, false)
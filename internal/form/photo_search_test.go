@@ -41,6 +41,80 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "123abc/,EFG", form.Path)
 	})
+	t.Run("semantic", func(t *testing.T) {
+		form := &PhotoSearch{Query: "semantic:\"red car in the snow\" favorite:true"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "red car in the snow", form.Semantic)
+		assert.True(t, form.Favorite)
+	})
+	t.Run("album and person", func(t *testing.T) {
+		form := &PhotoSearch{Query: "album:holiday-2030 person:jane beach"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "holiday-2030", form.Album)
+		assert.Equal(t, "jane", form.Label)
+		assert.Equal(t, "beach", form.Query)
+	})
+	t.Run("filename and ext", func(t *testing.T) {
+		form := &PhotoSearch{Query: "filename:IMG_*.jpg ext:dng,CR2"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "IMG_*.jpg", form.Filename)
+		assert.Equal(t, "dng,CR2", form.Ext)
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("video duration", func(t *testing.T) {
+		form := &PhotoSearch{Query: "dmin:10 dmax:60"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, uint(10), form.Dmin)
+		assert.Equal(t, uint(60), form.Dmax)
+	})
+	t.Run("id", func(t *testing.T) {
+		form := &PhotoSearch{Query: "id:pt9jtdre2lvl0yh7"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", form.ID)
+	})
+	t.Run("ratio and resolution", func(t *testing.T) {
+		form := &PhotoSearch{Query: "ratio:landscape,panorama mp:>20 res:>=4000x3000"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "landscape,panorama", form.Ratio)
+		assert.Equal(t, ">20", form.Mp)
+		assert.Equal(t, ">=4000x3000", form.Res)
+	})
 	t.Run("valid query", func(t *testing.T) {
 		form := &PhotoSearch{Query: "label:cat query:\"fooBar baz\" before:2019-01-15 camera:23 favorite:false dist:25000 lat:33.45343166666667"}
 
@@ -168,6 +242,43 @@ func TestParseQueryString(t *testing.T) {
 	})
 }
 
+func TestPhotoSearch_NearLatLng(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		form := &PhotoSearch{Query: "near:52.52,13.405"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		lat, lng, ok := form.NearLatLng()
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.52, lat)
+		assert.Equal(t, 13.405, lng)
+	})
+	t.Run("spaces", func(t *testing.T) {
+		form := &PhotoSearch{Near: "-33.8688, 151.2093"}
+
+		lat, lng, ok := form.NearLatLng()
+
+		assert.True(t, ok)
+		assert.Equal(t, -33.8688, lat)
+		assert.Equal(t, 151.2093, lng)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, _, ok := (&PhotoSearch{}).NearLatLng()
+		assert.False(t, ok)
+	})
+	t.Run("out of range", func(t *testing.T) {
+		_, _, ok := (&PhotoSearch{Near: "91,13.405"}).NearLatLng()
+		assert.False(t, ok)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, ok := (&PhotoSearch{Near: "berlin"}).NearLatLng()
+		assert.False(t, ok)
+	})
+}
+
 func TestNewPhotoSearch(t *testing.T) {
 	r := NewPhotoSearch("cat")
 	assert.IsType(t, PhotoSearch{}, r)
@@ -0,0 +1,8 @@
+package form
+
+// PhotoSubjects represents a batch edit form for tagging a person in photos, or removing the tag.
+type PhotoSubjects struct {
+	Photos  []string `json:"photos"`
+	Subject string   `json:"subject"`
+	Remove  bool     `json:"remove"`
+}
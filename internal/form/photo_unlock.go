@@ -0,0 +1,6 @@
+package form
+
+// PhotoUnlock represents a request to unlock manually edited photo fields, e.g. "title" or "taken".
+type PhotoUnlock struct {
+	Fields []string `json:"fields"`
+}
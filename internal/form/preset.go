@@ -0,0 +1,13 @@
+package form
+
+// PresetAction represents a single action of a preset, e.g. adding a label.
+type PresetAction struct {
+	Action string `json:"Action" binding:"required"`
+	Value  string `json:"Value"`
+}
+
+// Preset represents a named list of actions that can be applied to a selection of photos.
+type Preset struct {
+	Name    string         `json:"Name" binding:"required"`
+	Actions []PresetAction `json:"Actions" binding:"required"`
+}
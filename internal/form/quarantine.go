@@ -0,0 +1,8 @@
+package form
+
+// QuarantineFiles represents search form fields for "/api/v1/quarantine".
+type QuarantineFiles struct {
+	Source string `form:"source"`
+	Count  int    `form:"count" binding:"required"`
+	Offset int    `form:"offset"`
+}
@@ -0,0 +1,115 @@
+package form
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var intRangeRegexp = regexp.MustCompile(`^(-?\d+)-(-?\d+)$`)
+
+// IntRange parses a numeric filter like ">2000", "<=500", "1000-2000", or "1500" and returns the lower and
+// upper bounds. Open bounds are math.MinInt32 or math.MaxInt32, ok is false if the filter is empty or invalid.
+func IntRange(s string) (min, max int, ok bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+
+	if s == "" {
+		return 0, 0, false
+	}
+
+	min, max = math.MinInt32, math.MaxInt32
+
+	if m := intRangeRegexp.FindStringSubmatch(s); m != nil {
+		from, errFrom := strconv.Atoi(m[1])
+		to, errTo := strconv.Atoi(m[2])
+
+		if errFrom != nil || errTo != nil {
+			return 0, 0, false
+		}
+
+		if from > to {
+			from, to = to, from
+		}
+
+		return from, to, true
+	}
+
+	var op string
+
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, prefix) {
+			op, s = prefix, s[len(prefix):]
+			break
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+
+	if err != nil {
+		return 0, 0, false
+	}
+
+	switch op {
+	case ">=":
+		min = n
+	case ">":
+		min = n + 1
+	case "<=":
+		max = n
+	case "<":
+		max = n - 1
+	default:
+		min, max = n, n
+	}
+
+	return min, max, true
+}
+
+// aspectRatios maps the names accepted by the ratio filter to aspect ratio ranges, i.e. width divided by
+// height. Ranges include the lower and exclude the upper bound, so that each ratio has exactly one name.
+var aspectRatios = map[string][2]float32{
+	"portrait":  {0, 0.95},
+	"square":    {0.95, 1.05},
+	"landscape": {1.05, 2},
+	"panorama":  {2, math.MaxFloat32},
+}
+
+// RatioRange returns the aspect ratio range of a ratio filter value like "portrait", "landscape", "square",
+// or "panorama". Landscape doesn't include panoramas, which are at least twice as wide as high.
+func RatioRange(s string) (min, max float32, ok bool) {
+	r, ok := aspectRatios[strings.ToLower(strings.TrimSpace(s))]
+
+	return r[0], r[1], ok
+}
+
+// SizeRange parses a resolution filter like ">=4000x3000" or "<1920x1080" and returns the width and height
+// ranges with the same semantics as IntRange, ok is false if the filter is empty or invalid.
+func SizeRange(s string) (minWidth, maxWidth, minHeight, maxHeight int, ok bool) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+
+	var op string
+
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, prefix) {
+			op, s = prefix, s[len(prefix):]
+			break
+		}
+	}
+
+	values := strings.Split(s, "x")
+
+	if len(values) != 2 || strings.ContainsAny(s, "<>=-") {
+		return 0, 0, 0, 0, false
+	}
+
+	if minWidth, maxWidth, ok = IntRange(op + values[0]); !ok {
+		return 0, 0, 0, 0, false
+	}
+
+	if minHeight, maxHeight, ok = IntRange(op + values[1]); !ok {
+		return 0, 0, 0, 0, false
+	}
+
+	return minWidth, maxWidth, minHeight, maxHeight, true
+}
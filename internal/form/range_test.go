@@ -0,0 +1,105 @@
+package form
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntRange(t *testing.T) {
+	t.Run("greater", func(t *testing.T) {
+		min, max, ok := IntRange(">2000")
+		assert.True(t, ok)
+		assert.Equal(t, 2001, min)
+		assert.Equal(t, math.MaxInt32, max)
+	})
+	t.Run("greater or equal", func(t *testing.T) {
+		min, max, ok := IntRange(">=2000")
+		assert.True(t, ok)
+		assert.Equal(t, 2000, min)
+		assert.Equal(t, math.MaxInt32, max)
+	})
+	t.Run("less", func(t *testing.T) {
+		min, max, ok := IntRange("<500")
+		assert.True(t, ok)
+		assert.Equal(t, math.MinInt32, min)
+		assert.Equal(t, 499, max)
+	})
+	t.Run("range", func(t *testing.T) {
+		min, max, ok := IntRange("2000-1000")
+		assert.True(t, ok)
+		assert.Equal(t, 1000, min)
+		assert.Equal(t, 2000, max)
+	})
+	t.Run("negative", func(t *testing.T) {
+		min, max, ok := IntRange("-50")
+		assert.True(t, ok)
+		assert.Equal(t, -50, min)
+		assert.Equal(t, -50, max)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, ok := IntRange(">high")
+		assert.False(t, ok)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, _, ok := IntRange("")
+		assert.False(t, ok)
+	})
+}
+
+func TestRatioRange(t *testing.T) {
+	t.Run("portrait", func(t *testing.T) {
+		min, max, ok := RatioRange("Portrait")
+		assert.True(t, ok)
+		assert.Equal(t, float32(0), min)
+		assert.Equal(t, float32(0.95), max)
+	})
+	t.Run("panorama", func(t *testing.T) {
+		min, max, ok := RatioRange(" panorama")
+		assert.True(t, ok)
+		assert.Equal(t, float32(2), min)
+		assert.Equal(t, float32(math.MaxFloat32), max)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, _, ok := RatioRange("round")
+		assert.False(t, ok)
+	})
+}
+
+func TestSizeRange(t *testing.T) {
+	t.Run("greater or equal", func(t *testing.T) {
+		minWidth, maxWidth, minHeight, maxHeight, ok := SizeRange(">=4000x3000")
+		assert.True(t, ok)
+		assert.Equal(t, 4000, minWidth)
+		assert.Equal(t, math.MaxInt32, maxWidth)
+		assert.Equal(t, 3000, minHeight)
+		assert.Equal(t, math.MaxInt32, maxHeight)
+	})
+	t.Run("less", func(t *testing.T) {
+		minWidth, maxWidth, minHeight, maxHeight, ok := SizeRange("< 1920 X 1080")
+		assert.True(t, ok)
+		assert.Equal(t, math.MinInt32, minWidth)
+		assert.Equal(t, 1919, maxWidth)
+		assert.Equal(t, math.MinInt32, minHeight)
+		assert.Equal(t, 1079, maxHeight)
+	})
+	t.Run("exact", func(t *testing.T) {
+		minWidth, maxWidth, minHeight, maxHeight, ok := SizeRange("1920x1080")
+		assert.True(t, ok)
+		assert.Equal(t, 1920, minWidth)
+		assert.Equal(t, 1920, maxWidth)
+		assert.Equal(t, 1080, minHeight)
+		assert.Equal(t, 1080, maxHeight)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, _, _, ok := SizeRange("4000")
+		assert.False(t, ok)
+
+		_, _, _, _, ok = SizeRange(">4000x>3000")
+		assert.False(t, ok)
+
+		_, _, _, _, ok = SizeRange("")
+		assert.False(t, ok)
+	})
+}
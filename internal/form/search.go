@@ -36,7 +36,9 @@ func ParseQueryString(f SearchForm) (result error) {
 		if unicode.IsSpace(char) && !escaped {
 			if isKeyValue {
 				fieldName := strings.Title(string(key))
-				field := formValues.FieldByName(fieldName)
+				field := formValues.FieldByNameFunc(func(name string) bool {
+					return strings.EqualFold(name, fieldName)
+				})
 				stringValue := string(value)
 
 				if field.CanSet() {
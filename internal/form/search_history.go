@@ -0,0 +1,13 @@
+package form
+
+// SearchHistory represents search form fields for "/api/v1/searches/history".
+type SearchHistory struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
+
+// SearchSuggestions represents search form fields for "/api/v1/searches/suggestions".
+type SearchSuggestions struct {
+	Query string `form:"q"`
+	Count int    `form:"count"`
+}
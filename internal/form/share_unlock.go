@@ -0,0 +1,6 @@
+package form
+
+// ShareUnlock represents a request to unlock a password protected share link.
+type ShareUnlock struct {
+	Password string `json:"password"`
+}
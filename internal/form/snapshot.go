@@ -0,0 +1,10 @@
+package form
+
+// Snapshot represents a request to freeze a search result or a selection of photos.
+// The search query is used if no photos are selected.
+type Snapshot struct {
+	Title  string   `json:"Title" binding:"required"`
+	Query  string   `json:"Query"`
+	Order  string   `json:"Order"`
+	Photos []string `json:"Photos"`
+}
@@ -0,0 +1,31 @@
+package form
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SyncManifest represents a sync manifest request of a third-party client.
+type SyncManifest struct {
+	Since  string `form:"since"`
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+// SinceTime returns the time of the last sync, either a unix timestamp or RFC 3339 encoded.
+func (f SyncManifest) SinceTime() (time.Time, error) {
+	if f.Since == "" {
+		return time.Time{}, nil
+	}
+
+	if sec, err := strconv.ParseInt(f.Since, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, f.Since); err == nil {
+		return t.UTC(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %s", f.Since)
+}
@@ -0,0 +1,34 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncManifest_SinceTime(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		result, err := SyncManifest{}.SinceTime()
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsZero())
+	})
+	t.Run("unix", func(t *testing.T) {
+		result, err := SyncManifest{Since: "1593561600"}.SinceTime()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), result)
+	})
+	t.Run("rfc3339", func(t *testing.T) {
+		result, err := SyncManifest{Since: "2020-07-01T02:00:00+02:00"}.SinceTime()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), result)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := SyncManifest{Since: "yesterday"}.SinceTime()
+
+		assert.EqualError(t, err, "invalid time yesterday")
+	})
+}
@@ -0,0 +1,8 @@
+package form
+
+// ThumbErrors represents search form fields for "/api/v1/thumbs/errors".
+type ThumbErrors struct {
+	Permanent bool `form:"permanent"`
+	Count     int  `form:"count" binding:"required"`
+	Offset    int  `form:"offset"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// UsageStats represents the period for which feature usage statistics are returned.
+type UsageStats struct {
+	Days int `form:"days"`
+}
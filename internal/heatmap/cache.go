@@ -0,0 +1,86 @@
+package heatmap
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// CacheFolder is the name of the heatmap folder in the cache path.
+const CacheFolder = "heatmap"
+
+// FileName returns the cache file name of a tile, the version changes when photo locations change.
+func (t Tile) FileName(cachePath, version string) string {
+	return filepath.Join(cachePath, CacheFolder, version, strconv.Itoa(t.Z), strconv.Itoa(t.X), strconv.Itoa(t.Y)+".png")
+}
+
+// Encode returns the image as PNG.
+func Encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Save writes an encoded tile to the cache, other requests never see partially written files.
+func Save(data []byte, fileName string) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(fileName), "tile-*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), fileName)
+}
+
+// Cleanup removes cached tiles of all other versions and returns the number of removed versions.
+func Cleanup(cachePath, version string) (removed int, err error) {
+	dir := filepath.Join(cachePath, CacheFolder)
+
+	infos, err := ioutil.ReadDir(dir)
+
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	for _, info := range infos {
+		if !info.IsDir() || info.Name() == version {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(dir, info.Name())); err != nil {
+			log.Warnf("heatmap: %s", err)
+			continue
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,64 @@
+package heatmap
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTile_FileName(t *testing.T) {
+	assert.Equal(t, "/cache/heatmap/v1/3/4/2.png", Tile{Z: 3, X: 4, Y: 2}.FileName("/cache", "v1"))
+}
+
+func TestSaveAndCleanup(t *testing.T) {
+	cachePath, err := ioutil.TempDir("", "heatmap")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(cachePath)
+
+	data, err := Encode(Render(Tile{}, []Point{{Lat: 0, Lng: 0, Weight: 1}}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tile := Tile{Z: 0}
+
+	for _, version := range []string{"1-100", "2-200"} {
+		if err := Save(data, tile.FileName(cachePath, version)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved, err := ioutil.ReadFile(tile.FileName(cachePath, "2-200"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, data, saved)
+
+	tmp, _ := filepath.Glob(filepath.Join(cachePath, CacheFolder, "2-200", "0", "0", "*.tmp"))
+	assert.Empty(t, tmp)
+
+	removed, err := Cleanup(cachePath, "2-200")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, removed)
+	assert.NoFileExists(t, tile.FileName(cachePath, "1-100"))
+	assert.FileExists(t, tile.FileName(cachePath, "2-200"))
+
+	removed, err = Cleanup(filepath.Join(cachePath, "missing"), "2-200")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
@@ -0,0 +1,29 @@
+/*
+This package renders map tiles showing the density of photo locations.
+
+Tiles use the same Web Mercator projection and z/x/y numbering as common map libraries, so that they
+can be added as a layer on top of the regular map. Rendered tiles are cached on disk until the photo
+locations change.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package heatmap
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+var ErrTile = errors.New("heatmap: invalid tile")
+
+// Point represents a location and the number of photos taken there.
+type Point struct {
+	Lat    float64
+	Lng    float64
+	Weight int
+}
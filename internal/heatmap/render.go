@@ -0,0 +1,120 @@
+package heatmap
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Radius is the radius of a single location in pixels.
+const Radius = 12
+
+// Saturation is the density at which the color scale reaches its maximum. Using a fixed value instead of
+// the maximum density of a tile makes sure neighboring tiles use the same scale.
+const Saturation = 50.0
+
+// gradient contains the colors used for increasing density.
+var gradient = []struct {
+	Pos   float64
+	Color color.NRGBA
+}{
+	{0.0, color.NRGBA{R: 0, G: 0, B: 255, A: 0}},
+	{0.2, color.NRGBA{R: 0, G: 64, B: 255, A: 140}},
+	{0.45, color.NRGBA{R: 0, G: 255, B: 128, A: 180}},
+	{0.7, color.NRGBA{R: 255, G: 230, B: 0, A: 210}},
+	{1.0, color.NRGBA{R: 255, G: 0, B: 0, A: 230}},
+}
+
+// kernel returns the weights of a location in a square around it, decreasing with the distance.
+func kernel() []float64 {
+	size := 2*Radius + 1
+	result := make([]float64, size*size)
+
+	for y := -Radius; y <= Radius; y++ {
+		for x := -Radius; x <= Radius; x++ {
+			d := math.Sqrt(float64(x*x+y*y)) / Radius
+
+			if d < 1 {
+				result[(y+Radius)*size+x+Radius] = (1 - d) * (1 - d)
+			}
+		}
+	}
+
+	return result
+}
+
+// Color returns the color for a normalized density between 0 and 1.
+func Color(v float64) color.NRGBA {
+	if v <= 0 {
+		return gradient[0].Color
+	} else if v >= 1 {
+		return gradient[len(gradient)-1].Color
+	}
+
+	for i := 1; i < len(gradient); i++ {
+		if v > gradient[i].Pos {
+			continue
+		}
+
+		a, b := gradient[i-1], gradient[i]
+		f := (v - a.Pos) / (b.Pos - a.Pos)
+		mix := func(x, y uint8) uint8 {
+			return uint8(math.Round(float64(x) + f*(float64(y)-float64(x))))
+		}
+
+		return color.NRGBA{R: mix(a.Color.R, b.Color.R), G: mix(a.Color.G, b.Color.G), B: mix(a.Color.B, b.Color.B), A: mix(a.Color.A, b.Color.A)}
+	}
+
+	return gradient[len(gradient)-1].Color
+}
+
+// Render returns a transparent tile image showing the density of the given locations.
+func Render(t Tile, points []Point) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, TileSize, TileSize))
+	density := make([]float64, TileSize*TileSize)
+	weights := kernel()
+	size := 2*Radius + 1
+
+	for _, p := range points {
+		px, py := t.Pixel(p.Lat, p.Lng)
+		cx, cy := int(math.Round(px)), int(math.Round(py))
+
+		if cx < -Radius || cy < -Radius || cx > TileSize+Radius || cy > TileSize+Radius {
+			continue
+		}
+
+		weight := float64(p.Weight)
+
+		if weight < 1 {
+			weight = 1
+		}
+
+		for y := -Radius; y <= Radius; y++ {
+			if cy+y < 0 || cy+y >= TileSize {
+				continue
+			}
+
+			for x := -Radius; x <= Radius; x++ {
+				if cx+x < 0 || cx+x >= TileSize {
+					continue
+				}
+
+				if w := weights[(y+Radius)*size+x+Radius]; w > 0 {
+					density[(cy+y)*TileSize+cx+x] += w * weight
+				}
+			}
+		}
+	}
+
+	scale := math.Log1p(Saturation)
+
+	for i, d := range density {
+		if d <= 0 {
+			continue
+		}
+
+		img.SetNRGBA(i%TileSize, i/TileSize, Color(math.Log1p(d)/scale))
+	}
+
+	return img
+}
@@ -0,0 +1,44 @@
+package heatmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColor(t *testing.T) {
+	assert.Equal(t, uint8(0), Color(0).A)
+	assert.Equal(t, gradient[len(gradient)-1].Color, Color(1))
+	assert.Equal(t, gradient[len(gradient)-1].Color, Color(2))
+	assert.Greater(t, Color(0.8).R, Color(0.3).R)
+}
+
+func TestRender(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		img := Render(Tile{}, nil)
+
+		assert.Equal(t, TileSize, img.Bounds().Dx())
+		assert.Equal(t, uint8(0), img.NRGBAAt(128, 128).A)
+	})
+	t.Run("density", func(t *testing.T) {
+		img := Render(Tile{}, []Point{{Lat: 0, Lng: 0, Weight: 100}, {Lat: 45, Lng: 90, Weight: 1}})
+
+		hot := img.NRGBAAt(128, 128)
+		cold := img.NRGBAAt(0, 0)
+
+		assert.Greater(t, hot.A, uint8(0))
+		assert.Equal(t, uint8(0), cold.A)
+
+		x, y := Tile{}.Pixel(45, 90)
+		low := img.NRGBAAt(int(x), int(y))
+
+		assert.Greater(t, low.A, uint8(0))
+		assert.Greater(t, hot.R, low.R)
+	})
+	t.Run("neighbor tile", func(t *testing.T) {
+		tile := Tile{Z: 1, X: 1, Y: 1}
+		img := Render(tile, []Point{{Lat: -0.01, Lng: -0.01, Weight: 10}})
+
+		assert.Greater(t, img.NRGBAAt(0, 0).A, uint8(0))
+	})
+}
@@ -0,0 +1,102 @@
+package heatmap
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	TileSize = 256
+	MaxZoom  = 18
+	MaxLat   = 85.0511287798
+)
+
+// Tile represents a map tile in Web Mercator projection.
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// NewTile returns a tile based on its zoom level and coordinates, the y coordinate may end with ".png".
+func NewTile(z, x, y string) (t Tile, err error) {
+	if t.Z, err = strconv.Atoi(z); err != nil {
+		return t, ErrTile
+	}
+
+	if t.X, err = strconv.Atoi(x); err != nil {
+		return t, ErrTile
+	}
+
+	if t.Y, err = strconv.Atoi(strings.TrimSuffix(y, ".png")); err != nil {
+		return t, ErrTile
+	}
+
+	if !t.Valid() {
+		return t, ErrTile
+	}
+
+	return t, nil
+}
+
+// Valid returns true if the tile exists at its zoom level.
+func (t Tile) Valid() bool {
+	if t.Z < 0 || t.Z > MaxZoom {
+		return false
+	}
+
+	n := 1 << uint(t.Z)
+
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// String returns the tile coordinates as z/x/y.
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// worldSize returns the size of the whole map in pixels at the tile zoom level.
+func (t Tile) worldSize() float64 {
+	return float64(TileSize) * math.Exp2(float64(t.Z))
+}
+
+// Pixel returns the position of a location in pixels relative to the top left corner of the tile.
+func (t Tile) Pixel(lat, lng float64) (x, y float64) {
+	lat = math.Max(-MaxLat, math.Min(MaxLat, lat))
+	size := t.worldSize()
+	sin := math.Sin(lat * math.Pi / 180)
+
+	x = (lng + 180) / 360 * size
+	y = (0.5 - math.Log((1+sin)/(1-sin))/(4*math.Pi)) * size
+
+	return x - float64(t.X*TileSize), y - float64(t.Y*TileSize)
+}
+
+// Location returns the latitude and longitude of a pixel position relative to the top left corner of the tile.
+func (t Tile) Location(x, y float64) (lat, lng float64) {
+	size := t.worldSize()
+	x += float64(t.X * TileSize)
+	y += float64(t.Y * TileSize)
+
+	lng = x/size*360 - 180
+	lat = math.Atan(math.Sinh(math.Pi*(1-2*y/size))) * 180 / math.Pi
+
+	return lat, lng
+}
+
+// Bounds returns the tile boundaries including a margin in pixels.
+func (t Tile) Bounds(margin int) (latMin, latMax, lngMin, lngMax float64) {
+	m := float64(margin)
+
+	latMax, lngMin = t.Location(-m, -m)
+	latMin, lngMax = t.Location(TileSize+m, TileSize+m)
+
+	return latMin, latMax, lngMin, lngMax
+}
+
+// Step returns the width of a pixel in degrees longitude, which is used to aggregate nearby locations.
+func (t Tile) Step() float64 {
+	return 360 / t.worldSize()
+}
@@ -0,0 +1,69 @@
+package heatmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTile(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tile, err := NewTile("3", "4", "2.png")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Tile{Z: 3, X: 4, Y: 2}, tile)
+		assert.Equal(t, "3/4/2", tile.String())
+	})
+	t.Run("out of range", func(t *testing.T) {
+		_, err := NewTile("1", "2", "0")
+		assert.Equal(t, ErrTile, err)
+	})
+	t.Run("zoom too large", func(t *testing.T) {
+		_, err := NewTile("19", "0", "0")
+		assert.Equal(t, ErrTile, err)
+	})
+	t.Run("not a number", func(t *testing.T) {
+		_, err := NewTile("1", "x", "0.png")
+		assert.Equal(t, ErrTile, err)
+	})
+}
+
+func TestTile_Pixel(t *testing.T) {
+	t.Run("world", func(t *testing.T) {
+		x, y := Tile{}.Pixel(0, 0)
+		assert.InDelta(t, 128, x, 0.001)
+		assert.InDelta(t, 128, y, 0.001)
+	})
+	t.Run("berlin", func(t *testing.T) {
+		tile := Tile{Z: 10, X: 550, Y: 335}
+		x, y := tile.Pixel(52.52, 13.405)
+		assert.True(t, x >= 0 && x < TileSize)
+		assert.True(t, y >= 0 && y < TileSize)
+
+		lat, lng := tile.Location(x, y)
+		assert.InDelta(t, 52.52, lat, 0.0001)
+		assert.InDelta(t, 13.405, lng, 0.0001)
+	})
+}
+
+func TestTile_Bounds(t *testing.T) {
+	latMin, latMax, lngMin, lngMax := Tile{Z: 1, X: 1, Y: 0}.Bounds(0)
+
+	assert.InDelta(t, 0, latMin, 0.0001)
+	assert.InDelta(t, MaxLat, latMax, 0.0001)
+	assert.InDelta(t, 0, lngMin, 0.0001)
+	assert.InDelta(t, 180, lngMax, 0.0001)
+
+	latMin, _, lngMin, _ = Tile{Z: 1, X: 1, Y: 0}.Bounds(Radius)
+
+	assert.Less(t, latMin, 0.0)
+	assert.Less(t, lngMin, 0.0)
+}
+
+func TestTile_Step(t *testing.T) {
+	assert.InDelta(t, 360.0/256, Tile{}.Step(), 0.0001)
+	assert.InDelta(t, 360.0/512, Tile{Z: 1}.Step(), 0.0001)
+}
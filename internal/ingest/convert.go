@@ -0,0 +1,39 @@
+package ingest
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hash returns the SHA1 checksum sent by a backup app as lowercase hex string, or an empty string if it
+// isn't valid. Immich sends base64 encoded checksums, other apps use hex.
+func Hash(checksum string) string {
+	checksum = strings.TrimSpace(checksum)
+
+	if len(checksum) == 40 {
+		if _, err := hex.DecodeString(checksum); err == nil {
+			return strings.ToLower(checksum)
+		}
+	}
+
+	if data, err := base64.StdEncoding.DecodeString(checksum); err == nil && len(data) == 20 {
+		return hex.EncodeToString(data)
+	}
+
+	return ""
+}
+
+// Mtime returns the time in an X-OC-Mtime header, which contains the file modification time in seconds
+// since the Unix epoch. WebDAV clients like PhotoSync send it to preserve the date of files.
+func Mtime(header string) time.Time {
+	sec, err := strconv.ParseFloat(strings.TrimSpace(header), 64)
+
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(sec), 0).UTC()
+}
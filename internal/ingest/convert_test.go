@@ -0,0 +1,22 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, "3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", Hash("3CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818"))
+	assert.Equal(t, "3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", Hash("PK2RaPpqzFxcKWXd9uxGXKQv2Bg="))
+	assert.Equal(t, "", Hash("PK2RaPpqzFxc"))
+	assert.Equal(t, "", Hash(""))
+}
+
+func TestMtime(t *testing.T) {
+	assert.Equal(t, time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC), Mtime("1600000000"))
+	assert.Equal(t, time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC), Mtime(" 1600000000.75 "))
+	assert.True(t, Mtime("").IsZero())
+	assert.True(t, Mtime("yesterday").IsZero())
+}
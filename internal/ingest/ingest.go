@@ -0,0 +1,176 @@
+/*
+This package keeps the metadata sent by third-party mobile backup apps like Immich until uploaded files have
+been imported, so that fields without an equivalent in the files themselves, like favorites and the date
+shown in the app, can be applied to the indexed photos.
+
+Entries are identified by the SHA1 hash of the uploaded file and saved in a JSON file, so that they survive
+a restart between upload and import.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package ingest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Apps whose upload conventions are supported.
+const (
+	AppImmich    = "immich"
+	AppPhotoSync = "photosync"
+)
+
+// MaxAge is the time after which metadata of files that haven't been imported is discarded.
+const MaxAge = 7 * 24 * time.Hour
+
+// Meta contains the metadata of an uploaded file.
+type Meta struct {
+	App      string    `json:"app"`
+	Name     string    `json:"name"`
+	Device   string    `json:"device,omitempty"`
+	TakenAt  time.Time `json:"taken,omitempty"`
+	Favorite bool      `json:"favorite,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+// Queue contains the metadata of uploaded files that haven't been imported yet.
+type Queue struct {
+	fileName string
+	entries  map[string]Meta
+	mutex    sync.Mutex
+}
+
+// New returns a queue that saves its entries in fileName. Entries saved by a previous instance are loaded
+// if the file exists.
+func New(fileName string) *Queue {
+	q := &Queue{
+		fileName: fileName,
+		entries:  make(map[string]Meta),
+	}
+
+	if fileName == "" {
+		return q
+	}
+
+	if data, err := ioutil.ReadFile(fileName); err != nil {
+		log.Debugf("ingest: %s", err)
+	} else if err := json.Unmarshal(data, &q.entries); err != nil {
+		log.Errorf("ingest: %s", err)
+	}
+
+	return q
+}
+
+// Add adds the metadata of an uploaded file with the given SHA1 hash.
+func (q *Queue) Add(hash string, m Meta) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if m.Created.IsZero() {
+		m.Created = time.Now().UTC()
+	}
+
+	q.entries[hash] = m
+
+	return q.save()
+}
+
+// Get returns the metadata of the file with the given SHA1 hash.
+func (q *Queue) Get(hash string) (Meta, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	m, ok := q.entries[hash]
+
+	return m, ok
+}
+
+// Remove removes the metadata of files that have been imported.
+func (q *Queue) Remove(hashes ...string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, hash := range hashes {
+		delete(q.entries, hash)
+	}
+
+	return q.save()
+}
+
+// Hashes returns the sorted hashes of all files in the queue.
+func (q *Queue) Hashes() []string {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	result := make([]string, 0, len(q.entries))
+
+	for hash := range q.entries {
+		result = append(result, hash)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Len returns the number of files in the queue.
+func (q *Queue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.entries)
+}
+
+// Expire removes entries older than maxAge and returns their number, e.g. if files were never imported.
+func (q *Queue) Expire(maxAge time.Duration) (removed int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for hash, m := range q.entries {
+		if time.Since(m.Created) > maxAge {
+			delete(q.entries, hash)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		if err := q.save(); err != nil {
+			log.Errorf("ingest: %s", err)
+		}
+	}
+
+	return removed
+}
+
+// save writes all entries to disk, the mutex must be locked.
+func (q *Queue) save() error {
+	if q.fileName == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(q.entries)
+
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that a crash can't leave incomplete metadata behind.
+	tmpName := q.fileName + ".tmp"
+
+	if err := ioutil.WriteFile(tmpName, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, q.fileName)
+}
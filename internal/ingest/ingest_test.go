@@ -0,0 +1,61 @@
+package ingest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ingest")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "ingest.json")
+	taken := time.Date(2020, 8, 12, 10, 30, 0, 0, time.UTC)
+
+	q := New(fileName)
+
+	if err := q.Add("3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", Meta{App: AppImmich, Name: "IMG_0001.HEIC", TakenAt: taken, Favorite: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Add("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", Meta{App: AppPhotoSync, Name: "IMG_0002.JPG"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, fileName)
+	assert.Equal(t, []string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "3cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}, q.Hashes())
+
+	t.Run("restart", func(t *testing.T) {
+		restarted := New(fileName)
+		assert.Equal(t, 2, restarted.Len())
+
+		m, ok := restarted.Get("3cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
+		assert.True(t, ok)
+		assert.Equal(t, AppImmich, m.App)
+		assert.True(t, m.Favorite)
+		assert.True(t, taken.Equal(m.TakenAt))
+		assert.False(t, m.Created.IsZero())
+	})
+	t.Run("remove", func(t *testing.T) {
+		if err := q.Remove("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, New(fileName).Len())
+	})
+	t.Run("expire", func(t *testing.T) {
+		assert.Equal(t, 0, q.Expire(time.Hour))
+		assert.Equal(t, 1, q.Expire(0))
+		assert.Equal(t, 0, New(fileName).Len())
+	})
+}
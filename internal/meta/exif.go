@@ -25,6 +25,16 @@ func ValidDateTime(s string) bool {
 	return len(s) == len(DateTimeZero) && s != DateTimeZero
 }
 
+// CorruptError is returned if Exif data exists but is damaged, so that the parser crashed.
+type CorruptError struct {
+	Message string
+}
+
+// Error returns the error message.
+func (e CorruptError) Error() string {
+	return e.Message
+}
+
 // Exif parses an image file for Exif meta data and returns as Data struct.
 func Exif(fileName string) (data Data, err error) {
 	err = data.Exif(fileName)
@@ -36,7 +46,7 @@ func Exif(fileName string) (data Data, err error) {
 func (data *Data) Exif(fileName string) (err error) {
 	defer func() {
 		if e := recover(); e != nil {
-			err = fmt.Errorf("%s (exif metadata)", e)
+			err = CorruptError{Message: fmt.Sprintf("%s (exif metadata)", e)}
 		}
 	}()
 
@@ -295,6 +305,14 @@ func (data *Data) Exif(fileName string) (err error) {
 		}
 	}
 
+	if value, ok := tags["GPSImgDirection"]; ok {
+		data.Direction = GpsDirection(value)
+	}
+
+	if value, ok := tags["GPSSpeed"]; ok {
+		data.Speed = GpsSpeed(value, tags["GPSSpeedRef"])
+	}
+
 	if data.Lat != 0 && data.Lng != 0 {
 		zones, err := tz.GetZone(tz.Point{
 			Lat: float64(data.Lat),
@@ -1,8 +1,10 @@
 package meta
 
 import (
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/dsoprea/go-exif/v2"
 )
@@ -10,6 +12,13 @@ import (
 // var GpsCoordsRegexp = regexp.MustCompile("(-?\\d+(\\.\\d+)?),\\s*(-?\\d+(\\.\\d+)?)")
 var GpsCoordsRegexp = regexp.MustCompile("[0-9\\.]+")
 var GpsRefRegexp = regexp.MustCompile("[NSEW]+")
+var GpsNumberRegexp = regexp.MustCompile("-?[0-9]+(\\.[0-9]+)?(/[0-9]+)?")
+
+// Conversion factors for GPS speed units, see GPSSpeedRef.
+const (
+	KmhPerMph  = 1.609344
+	KmhPerKnot = 1.852
+)
 
 // GpsToLatLng returns the GPS latitude and longitude as float point number.
 func GpsToLatLng(s string) (lat, lng float32) {
@@ -78,3 +87,63 @@ func GpsCoord(s string) float64 {
 
 	return result
 }
+
+// GpsNumber returns the first number in a GPS value as float, rationals like "1234/100" are supported.
+func GpsNumber(s string) float64 {
+	n := GpsNumberRegexp.FindString(s)
+
+	if n == "" {
+		return 0
+	}
+
+	if values := strings.Split(n, "/"); len(values) == 2 {
+		number, _ := strconv.ParseFloat(values[0], 64)
+		denom, _ := strconv.ParseFloat(values[1], 64)
+
+		if denom == 0 {
+			return 0
+		}
+
+		return number / denom
+	}
+
+	result, _ := strconv.ParseFloat(n, 64)
+
+	return result
+}
+
+// GpsAltitude returns the altitude in meters, negative if the reference is below sea level, e.g. "1" or "Below Sea Level".
+func GpsAltitude(s, ref string) int {
+	altitude := math.Abs(GpsNumber(s))
+
+	if ref == "1" || strings.Contains(strings.ToLower(s+" "+ref), "below") {
+		altitude = -altitude
+	}
+
+	return int(math.Round(altitude))
+}
+
+// GpsDirection returns the image direction as compass bearing in degrees from 0 to 359.9.
+func GpsDirection(s string) float32 {
+	direction := math.Mod(GpsNumber(s), 360)
+
+	if direction < 0 {
+		direction += 360
+	}
+
+	return float32(math.Round(direction*10) / 10)
+}
+
+// GpsSpeed returns the speed in km/h, the reference may be "K" (km/h), "M" (mph), or "N" (knots).
+func GpsSpeed(s, ref string) float32 {
+	speed := math.Abs(GpsNumber(s))
+
+	switch r := strings.ToLower(strings.TrimSpace(ref)); {
+	case r == "m" || strings.HasPrefix(r, "mph") || strings.HasPrefix(r, "mile"):
+		speed = speed * KmhPerMph
+	case r == "n" || strings.HasPrefix(r, "knot"):
+		speed = speed * KmhPerKnot
+	}
+
+	return float32(math.Round(speed*10) / 10)
+}
@@ -1,6 +1,10 @@
 package meta
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestGpsToLat(t *testing.T) {
 	lat := GpsToDecimal("51 deg 15' 17.47\" N")
@@ -32,3 +36,30 @@ func TestGpsToLatLng(t *testing.T) {
 		t.Fatalf("lng is %f, should be %f", lng, expLng)
 	}
 }
+
+func TestGpsNumber(t *testing.T) {
+	assert.Equal(t, 123.45, GpsNumber("12345/100"))
+	assert.Equal(t, 86.5, GpsNumber("86.5 m Above Sea Level"))
+	assert.Equal(t, float64(0), GpsNumber("1/0"))
+	assert.Equal(t, float64(0), GpsNumber(""))
+}
+
+func TestGpsAltitude(t *testing.T) {
+	assert.Equal(t, 2350, GpsAltitude("2350 m Above Sea Level", ""))
+	assert.Equal(t, -28, GpsAltitude("27.6 m Below Sea Level", ""))
+	assert.Equal(t, -12, GpsAltitude("12", "1"))
+	assert.Equal(t, 3, GpsAltitude("300/100", "0"))
+}
+
+func TestGpsDirection(t *testing.T) {
+	assert.Equal(t, float32(270.5), GpsDirection("541/2"))
+	assert.Equal(t, float32(10), GpsDirection("370"))
+	assert.Equal(t, float32(0), GpsDirection(""))
+}
+
+func TestGpsSpeed(t *testing.T) {
+	assert.Equal(t, float32(25), GpsSpeed("25", "K"))
+	assert.Equal(t, float32(16.1), GpsSpeed("10", "M"))
+	assert.Equal(t, float32(18.5), GpsSpeed("10", "knots"))
+	assert.Equal(t, float32(0), GpsSpeed("", "K"))
+}
@@ -110,6 +110,21 @@ func (data *Data) JSON(fileName string) (err error) {
 		data.Lng = GpsToDecimal(data.GPSLongitude)
 	}
 
+	// Altitude, image direction, and speed as found in GPS metadata.
+	if data.Altitude == 0 {
+		if v, ok := jsonValues["GPSAltitude"]; ok {
+			data.Altitude = GpsAltitude(v.String(), jsonValues["GPSAltitudeRef"].String())
+		}
+	}
+
+	if v, ok := jsonValues["GPSImgDirection"]; ok {
+		data.Direction = GpsDirection(v.String())
+	}
+
+	if v, ok := jsonValues["GPSSpeed"]; ok {
+		data.Speed = GpsSpeed(v.String(), jsonValues["GPSSpeedRef"].String())
+	}
+
 	// Set time zone and calculate UTC time.
 	if data.Lat != 0 && data.Lng != 0 {
 		zones, err := tz.GetZone(tz.Point{
@@ -0,0 +1,211 @@
+package migrate
+
+import (
+	"bufio"
+	"compress/gzip"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Backup writes a gzip compressed SQL dump of all tables to the given file. It doesn't depend on
+// external tools like mysqldump, which are not available with the built-in database server.
+// The dump can be restored with any MySQL client, e.g. "zcat backup.sql.gz | mysql photoprism".
+func Backup(db *gorm.DB, fileName string) (err error) {
+	start := time.Now()
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return err
+	}
+
+	tmpName := fileName + ".tmp"
+	f, err := os.OpenFile(tmpName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(tmpName)
+		}
+	}()
+
+	zw := gzip.NewWriter(f)
+	w := bufio.NewWriter(zw)
+
+	tables, err := baseTables(db.DB())
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "-- PhotoPrism database backup created %s\n\nSET FOREIGN_KEY_CHECKS=0;\n", start.UTC().Format(time.RFC3339))
+
+	for _, table := range tables {
+		if err := dumpTable(db.DB(), w, table); err != nil {
+			return fmt.Errorf("migrate: backup of %s failed (%s)", table, err)
+		}
+	}
+
+	fmt.Fprint(w, "\nSET FOREIGN_KEY_CHECKS=1;\n")
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return err
+	}
+
+	log.Infof("migrate: backup of %d tables created in %s", len(tables), time.Since(start))
+
+	return nil
+}
+
+// BackupFileName returns a backup file name in the given path for the current schema version.
+func BackupFileName(path string, version int) string {
+	return filepath.Join(path, fmt.Sprintf("%s-v%d.sql.gz", time.Now().UTC().Format("20060102-150405"), version))
+}
+
+// baseTables returns the names of all tables, views are skipped as they contain no data.
+func baseTables(db *sql.DB) (tables []string, err error) {
+	rows, err := db.Query("SHOW FULL TABLES")
+
+	if err != nil {
+		return tables, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, tableType string
+
+		if err := rows.Scan(&name, &tableType); err != nil {
+			return tables, err
+		}
+
+		if tableType == "BASE TABLE" {
+			tables = append(tables, name)
+		}
+	}
+
+	return tables, rows.Err()
+}
+
+// dumpTable writes the table definition and an insert statement for each row.
+func dumpTable(db *sql.DB, w *bufio.Writer, table string) error {
+	var name, create string
+
+	if err := db.QueryRow(fmt.Sprintf("SHOW CREATE TABLE `%s`", table)).Scan(&name, &create); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nDROP TABLE IF EXISTS `%s`;\n%s;\n", table, create)
+
+	rows, err := db.Query(fmt.Sprintf("SELECT * FROM `%s`", table))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	literals := make([]string, len(columns))
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+
+		for i, v := range values {
+			literals[i] = sqlLiteral(v)
+		}
+
+		fmt.Fprintf(w, "INSERT INTO `%s` VALUES (%s);\n", table, strings.Join(literals, ","))
+	}
+
+	return rows.Err()
+}
+
+// sqlLiteral returns a value as SQL literal.
+func sqlLiteral(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	case bool:
+		if t {
+			return "1"
+		}
+
+		return "0"
+	case time.Time:
+		return "'" + t.Format("2006-01-02 15:04:05.999999") + "'"
+	case []byte:
+		return quote(string(t))
+	default:
+		return quote(fmt.Sprint(t))
+	}
+}
+
+// quote returns a quoted string literal with special characters escaped.
+func quote(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('\'')
+
+	for _, r := range []byte(s) {
+		switch r {
+		case 0:
+			b.WriteString(`\0`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`\'`)
+		case 0x1a:
+			b.WriteString(`\Z`)
+		default:
+			b.WriteByte(r)
+		}
+	}
+
+	b.WriteByte('\'')
+
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackup(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "TestBackup")
+
+	defer os.RemoveAll(dir)
+
+	fileName := BackupFileName(dir, 1)
+
+	if err := Backup(entity.Db(), fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadAll(zr)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dump := string(data)
+
+	assert.Contains(t, dump, "CREATE TABLE `photos`")
+	assert.Contains(t, dump, "INSERT INTO `photos` VALUES (")
+	assert.NoFileExists(t, fileName+".tmp")
+}
+
+func TestBackupFileName(t *testing.T) {
+	fileName := BackupFileName("/srv/backup", 12)
+
+	assert.True(t, strings.HasPrefix(fileName, "/srv/backup/"))
+	assert.True(t, strings.HasSuffix(fileName, "-v12.sql.gz"))
+}
+
+func TestSqlLiteral(t *testing.T) {
+	assert.Equal(t, "NULL", sqlLiteral(nil))
+	assert.Equal(t, "42", sqlLiteral(int64(42)))
+	assert.Equal(t, "0.5", sqlLiteral(0.5))
+	assert.Equal(t, "1", sqlLiteral(true))
+	assert.Equal(t, "'2020-05-01 10:20:30'", sqlLiteral(time.Date(2020, 5, 1, 10, 20, 30, 0, time.UTC)))
+	assert.Equal(t, `'It\'s a \\ test\n'`, sqlLiteral([]byte("It's a \\ test\n")))
+}
@@ -0,0 +1,47 @@
+package migrate
+
+import (
+	"fmt"
+)
+
+// List represents migrations in ascending version order.
+type List []Migration
+
+// Validate returns an error if versions are not positive, unique and ascending, or functions are missing.
+func (list List) Validate() error {
+	for i, m := range list {
+		if m.Version <= 0 {
+			return fmt.Errorf("migrate: version of %s must be greater than 0", m.Name)
+		}
+
+		if m.Up == nil {
+			return fmt.Errorf("migrate: %s has no up function", m)
+		}
+
+		if i > 0 && m.Version <= list[i-1].Version {
+			return fmt.Errorf("migrate: version %d must be greater than %d", m.Version, list[i-1].Version)
+		}
+	}
+
+	return nil
+}
+
+// Latest returns the highest version, or 0 if the list is empty.
+func (list List) Latest() int {
+	if len(list) == 0 {
+		return 0
+	}
+
+	return list[len(list)-1].Version
+}
+
+// Find returns the migration with the given version.
+func (list List) Find(version int) (Migration, error) {
+	for _, m := range list {
+		if m.Version == version {
+			return m, nil
+		}
+	}
+
+	return Migration{}, ErrVersion
+}
@@ -0,0 +1,49 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_Validate(t *testing.T) {
+	up := func(db *gorm.DB) error { return nil }
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, List{{Version: 1, Up: up}, {Version: 3, Up: up}}.Validate())
+	})
+	t.Run("not ascending", func(t *testing.T) {
+		err := List{{Version: 2, Up: up}, {Version: 2, Up: up}}.Validate()
+		assert.EqualError(t, err, "migrate: version 2 must be greater than 2")
+	})
+	t.Run("up missing", func(t *testing.T) {
+		err := List{{Version: 1, Name: "baseline"}}.Validate()
+		assert.EqualError(t, err, "migrate: 1 baseline has no up function")
+	})
+	t.Run("version missing", func(t *testing.T) {
+		err := List{{Name: "baseline", Up: up}}.Validate()
+		assert.EqualError(t, err, "migrate: version of baseline must be greater than 0")
+	})
+	t.Run("migrations", func(t *testing.T) {
+		assert.NoError(t, Migrations.Validate())
+	})
+}
+
+func TestList_Latest(t *testing.T) {
+	assert.Equal(t, 0, List{}.Latest())
+	assert.Equal(t, 5, List{{Version: 1}, {Version: 5}}.Latest())
+}
+
+func TestList_Find(t *testing.T) {
+	list := List{{Version: 1, Name: "baseline"}, {Version: 2, Name: "second"}}
+
+	m, err := list.Find(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "second", m.Name)
+
+	_, err = list.Find(3)
+
+	assert.Equal(t, ErrVersion, err)
+}
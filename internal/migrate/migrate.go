@@ -0,0 +1,70 @@
+/*
+This package applies and rolls back versioned database schema migrations.
+
+Each migration has a version number, an up function that changes the schema and an optional down
+function that reverts the change. Applied versions are recorded in the migrations table, so that
+only pending migrations run on upgrade, and the last ones can be rolled back if needed.
+
+The first migration is the baseline: it creates the current schema from the entity models.
+Databases without migration history, either new or created by earlier versions using
+auto-migration, only run the baseline and record all other migrations as applied.
+
+MySQL commits schema changes implicitly, so they can't be wrapped in transactions. A migration is
+therefore flagged as dirty while it runs. Dirty migrations block further upgrades until they are
+rolled back, or the schema is fixed manually and the backup created before is restored.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package migrate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+var (
+	ErrIrreversible = errors.New("migrate: migration can't be rolled back")
+	ErrDirty        = errors.New("migrate: a migration failed previously, roll it back or restore the backup")
+	ErrVersion      = errors.New("migrate: unknown version")
+)
+
+// Func changes the database schema.
+type Func func(db *gorm.DB) error
+
+// Migration represents a versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      Func
+	Down    Func
+}
+
+// String returns the version and name for logs.
+func (m Migration) String() string {
+	return fmt.Sprintf("%d %s", m.Version, m.Name)
+}
+
+// Reversible returns true if the migration can be rolled back.
+func (m Migration) Reversible() bool {
+	return m.Down != nil
+}
+
+// SQL returns a function that executes the statements in order.
+func SQL(statements ...string) Func {
+	return func(db *gorm.DB) error {
+		for _, s := range statements {
+			if err := db.Exec(s).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
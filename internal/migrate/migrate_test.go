@@ -0,0 +1,59 @@
+package migrate
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	dsn := os.Getenv("PHOTOPRISM_TEST_DSN")
+
+	if dsn == "" {
+		panic("database dsn is empty")
+	}
+
+	db := entity.InitTestDb(strings.Replace(dsn, "/photoprism", "/migrate", 1))
+
+	code := m.Run()
+
+	if db != nil {
+		db.Close()
+	}
+
+	os.Exit(code)
+}
+
+func TestMigration_String(t *testing.T) {
+	m := Migration{Version: 2, Name: "add-file-index"}
+
+	assert.Equal(t, "2 add-file-index", m.String())
+}
+
+func TestMigration_Reversible(t *testing.T) {
+	up := func(db *gorm.DB) error { return nil }
+
+	assert.False(t, Migration{Version: 1, Up: up}.Reversible())
+	assert.True(t, Migration{Version: 1, Up: up, Down: up}.Reversible())
+}
+
+func TestSQL(t *testing.T) {
+	db := entity.Db()
+
+	t.Run("success", func(t *testing.T) {
+		err := SQL("CREATE TABLE test_sql (id INT)", "DROP TABLE test_sql")(db)
+		assert.NoError(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		err := SQL("SELECT * FROM test_sql_missing")(db)
+		assert.Error(t, err)
+	})
+}
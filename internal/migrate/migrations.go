@@ -0,0 +1,337 @@
+package migrate
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Migrations contains all schema migrations in ascending version order. Versions must never be
+// changed or reused once released, add a new migration to revert a change instead.
+//
+// Entity models must be updated along with each migration, as new databases are created from them.
+var Migrations = List{
+	{
+		Version: 1,
+		Name:    "baseline",
+		Up: func(db *gorm.DB) error {
+			entity.Entities.Migrate()
+			entity.Entities.WaitForMigration()
+
+			return nil
+		},
+	},
+	{
+		Version: 2,
+		Name:    "link-slugs",
+		Up: SQL(
+			"ALTER TABLE links ADD COLUMN link_slug VARBINARY(255)",
+			"ALTER TABLE links ADD COLUMN link_domain VARBINARY(255)",
+			"CREATE INDEX idx_links_link_slug ON links (link_slug)",
+			"CREATE INDEX idx_links_link_domain ON links (link_domain)",
+			"CREATE TABLE IF NOT EXISTS links_aliases (alias_slug VARBINARY(255) NOT NULL, link_token VARBINARY(255), created_at DATETIME NULL, PRIMARY KEY (alias_slug))",
+			"CREATE INDEX idx_links_aliases_link_token ON links_aliases (link_token)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS links_aliases",
+			"DROP INDEX idx_links_link_domain ON links",
+			"DROP INDEX idx_links_link_slug ON links",
+			"ALTER TABLE links DROP COLUMN link_domain",
+			"ALTER TABLE links DROP COLUMN link_slug",
+		),
+	},
+	{
+		Version: 3,
+		Name:    "geometry-fixes",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS geometry_fixes (id INT UNSIGNED NOT NULL AUTO_INCREMENT, fix_uid VARBINARY(36), photo_uid VARBINARY(36), file_uid VARBINARY(36), fix_rotate INT, fix_tilt DOUBLE, fix_score FLOAT, fix_status VARBINARY(16), created_at DATETIME NULL, updated_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX uix_geometry_fixes_fix_uid ON geometry_fixes (fix_uid)",
+			"CREATE UNIQUE INDEX uix_geometry_fixes_file_uid ON geometry_fixes (file_uid)",
+			"CREATE INDEX idx_geometry_fixes_photo_uid ON geometry_fixes (photo_uid)",
+			"CREATE INDEX idx_geometry_fixes_fix_status ON geometry_fixes (fix_status)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS geometry_fixes",
+		),
+	},
+	{
+		Version: 4,
+		Name:    "album-rules",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS album_rules (id INT UNSIGNED NOT NULL AUTO_INCREMENT, rule_uid VARBINARY(36), album_uid VARBINARY(36), rule_name VARCHAR(255), rule_folder VARCHAR(255), rule_after DATETIME NULL, rule_before DATETIME NULL, rule_camera_serial VARBINARY(255), rule_uploader VARCHAR(255), created_at DATETIME NULL, updated_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX uix_album_rules_rule_uid ON album_rules (rule_uid)",
+			"CREATE INDEX idx_album_rules_album_uid ON album_rules (album_uid)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS album_rules",
+		),
+	},
+	{
+		Version: 5,
+		Name:    "versions",
+		Up: SQL(
+			"ALTER TABLE albums ADD COLUMN album_version INT UNSIGNED NOT NULL DEFAULT 0",
+			"ALTER TABLE photos ADD COLUMN photo_version INT UNSIGNED NOT NULL DEFAULT 0",
+		),
+		Down: SQL(
+			"ALTER TABLE photos DROP COLUMN photo_version",
+			"ALTER TABLE albums DROP COLUMN album_version",
+		),
+	},
+	{
+		Version: 6,
+		Name:    "album-trees",
+		Up: SQL(
+			"ALTER TABLE albums ADD COLUMN parent_uid VARBINARY(36)",
+			"CREATE INDEX idx_albums_parent_uid ON albums (parent_uid)",
+			"ALTER TABLE links ADD COLUMN link_subtree BOOLEAN",
+			"CREATE TABLE IF NOT EXISTS links_exclusions (link_token VARBINARY(255) NOT NULL, album_uid VARBINARY(36) NOT NULL, PRIMARY KEY (link_token, album_uid))",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS links_exclusions",
+			"ALTER TABLE links DROP COLUMN link_subtree",
+			"DROP INDEX idx_albums_parent_uid ON albums",
+			"ALTER TABLE albums DROP COLUMN parent_uid",
+		),
+	},
+	{
+		Version: 7,
+		Name:    "album-stories",
+		Up: SQL(
+			"ALTER TABLE albums ADD COLUMN album_story TEXT",
+		),
+		Down: SQL(
+			"ALTER TABLE albums DROP COLUMN album_story",
+		),
+	},
+	{
+		Version: 8,
+		Name:    "photo-direction",
+		Up: SQL(
+			"ALTER TABLE photos ADD COLUMN photo_direction FLOAT",
+			"ALTER TABLE photos ADD COLUMN photo_speed FLOAT",
+		),
+		Down: SQL(
+			"ALTER TABLE photos DROP COLUMN photo_speed",
+			"ALTER TABLE photos DROP COLUMN photo_direction",
+		),
+	},
+	{
+		Version: 9,
+		Name:    "link-restrictions",
+		Up: SQL(
+			"ALTER TABLE links ADD COLUMN link_ips VARBINARY(1024)",
+			"ALTER TABLE links ADD COLUMN link_origins VARBINARY(1024)",
+		),
+		Down: SQL(
+			"ALTER TABLE links DROP COLUMN link_origins",
+			"ALTER TABLE links DROP COLUMN link_ips",
+		),
+	},
+	{
+		Version: 10,
+		Name:    "index-errors",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS index_errors (id INT UNSIGNED NOT NULL AUTO_INCREMENT, file_name VARBINARY(768), error_kind VARBINARY(16), error_message VARBINARY(2048), error_count INT, error_ignored BOOLEAN, created_at DATETIME NULL, updated_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX uix_index_errors_file_name ON index_errors (file_name)",
+			"CREATE INDEX idx_index_errors_error_kind ON index_errors (error_kind)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS index_errors",
+		),
+	},
+	{
+		Version: 11,
+		Name:    "file-mono",
+		Up: SQL(
+			"ALTER TABLE files ADD COLUMN file_mono BOOLEAN",
+		),
+		Down: SQL(
+			"ALTER TABLE files DROP COLUMN file_mono",
+		),
+	},
+	{
+		Version: 12,
+		Name:    "quarantine-files",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS quarantine_files (id INT UNSIGNED NOT NULL AUTO_INCREMENT, file_name VARBINARY(768), original_name VARBINARY(768), file_size BIGINT, file_mime VARBINARY(64), reason VARBINARY(512), source VARBINARY(16), uploader VARCHAR(255), created_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX uix_quarantine_files_file_name ON quarantine_files (file_name)",
+			"CREATE INDEX idx_quarantine_files_source ON quarantine_files (source)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS quarantine_files",
+		),
+	},
+	{
+		Version: 13,
+		Name:    "link-renewal",
+		Up: SQL(
+			"ALTER TABLE links ADD COLUMN link_renewal INT",
+			"ALTER TABLE links ADD COLUMN link_notified DATETIME NULL",
+		),
+		Down: SQL(
+			"ALTER TABLE links DROP COLUMN link_notified",
+			"ALTER TABLE links DROP COLUMN link_renewal",
+		),
+	},
+	{
+		Version: 14,
+		Name:    "checkpoints",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS checkpoints (id INT UNSIGNED NOT NULL AUTO_INCREMENT, checkpoint_uid VARBINARY(36), checkpoint_note VARCHAR(255), photo_count INT, album_count INT, created_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX uix_checkpoints_checkpoint_uid ON checkpoints (checkpoint_uid)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS checkpoints",
+		),
+	},
+	{
+		Version: 15,
+		Name:    "photos-embeddings",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS photos_embeddings (photo_uid VARBINARY(36) NOT NULL, embedding_model VARBINARY(64), embedding BLOB, updated_at DATETIME NULL, PRIMARY KEY (photo_uid))",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS photos_embeddings",
+		),
+	},
+	{
+		Version: 16,
+		Name:    "photo-categories",
+		Up: SQL(
+			"ALTER TABLE photos ADD COLUMN photo_category VARBINARY(16)",
+			"ALTER TABLE photos ADD COLUMN category_src VARBINARY(8)",
+			"CREATE INDEX idx_photos_photo_category ON photos (photo_category)",
+		),
+		Down: SQL(
+			"DROP INDEX idx_photos_photo_category ON photos",
+			"ALTER TABLE photos DROP COLUMN category_src",
+			"ALTER TABLE photos DROP COLUMN photo_category",
+		),
+	},
+	{
+		Version: 17,
+		Name:    "search-history",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS search_history (id INT UNSIGNED NOT NULL AUTO_INCREMENT, user_id VARBINARY(128), search_query VARCHAR(255), search_count INT, pinned BOOLEAN, created_at DATETIME NULL, updated_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX idx_search_history_user_query ON search_history (user_id, search_query)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS search_history",
+		),
+	},
+	{
+		Version: 18,
+		Name:    "devices",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS devices (id INT UNSIGNED NOT NULL AUTO_INCREMENT, device_uid VARBINARY(36), device_token VARBINARY(64), device_name VARCHAR(255), device_owner VARCHAR(255), device_album BOOLEAN, device_label BOOLEAN, album_uid VARBINARY(36), upload_count INT, uploaded_at DATETIME NULL, created_at DATETIME NULL, updated_at DATETIME NULL, deleted_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX uix_devices_device_uid ON devices (device_uid)",
+			"CREATE UNIQUE INDEX uix_devices_device_token ON devices (device_token)",
+			"CREATE INDEX idx_devices_deleted_at ON devices (deleted_at)",
+			"ALTER TABLE photos ADD COLUMN device_uid VARBINARY(36)",
+			"CREATE INDEX idx_photos_device_uid ON photos (device_uid)",
+		),
+		Down: SQL(
+			"DROP INDEX idx_photos_device_uid ON photos",
+			"ALTER TABLE photos DROP COLUMN device_uid",
+			"DROP TABLE IF EXISTS devices",
+		),
+	},
+	{
+		Version: 19,
+		Name:    "photo-dimension-indexes",
+		Up: SQL(
+			"CREATE INDEX idx_files_file_aspect_ratio ON files (file_aspect_ratio)",
+			"CREATE INDEX idx_files_dimensions ON files (file_width, file_height)",
+			"CREATE INDEX idx_photos_photo_resolution ON photos (photo_resolution)",
+		),
+		Down: SQL(
+			"DROP INDEX idx_photos_photo_resolution ON photos",
+			"DROP INDEX idx_files_dimensions ON files",
+			"DROP INDEX idx_files_file_aspect_ratio ON files",
+		),
+	},
+	{
+		Version: 20,
+		Name:    "thumb-errors",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS thumb_errors (id INT UNSIGNED NOT NULL AUTO_INCREMENT, file_hash VARBINARY(128), thumb_type VARBINARY(64), file_name VARBINARY(768), error_message VARBINARY(2048), error_count INT, retry_at DATETIME NULL, created_at DATETIME NULL, updated_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE UNIQUE INDEX idx_thumb_errors_hash_type ON thumb_errors (file_hash, thumb_type)",
+			"CREATE INDEX idx_thumb_errors_error_count ON thumb_errors (error_count)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS thumb_errors",
+		),
+	},
+	{
+		Version: 21,
+		Name:    "redirects",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS redirects (from_uid VARBINARY(36) NOT NULL, to_uid VARBINARY(36), created_at DATETIME NULL, PRIMARY KEY (from_uid))",
+			"CREATE INDEX idx_redirects_to_uid ON redirects (to_uid)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS redirects",
+		),
+	},
+	{
+		Version: 22,
+		Name:    "link-download",
+		Up: SQL(
+			"ALTER TABLE links ADD COLUMN can_download BOOLEAN",
+		),
+		Down: SQL(
+			"ALTER TABLE links DROP COLUMN can_download",
+		),
+	},
+	{
+		Version: 23,
+		Name:    "taken-approx",
+		Up: SQL(
+			"ALTER TABLE photos ADD COLUMN taken_approx VARBINARY(8)",
+		),
+		Down: SQL(
+			"ALTER TABLE photos DROP COLUMN taken_approx",
+		),
+	},
+	{
+		Version: 24,
+		Name:    "photo-album-caption",
+		Up: SQL(
+			"ALTER TABLE photos_albums ADD COLUMN caption TEXT",
+		),
+		Down: SQL(
+			"ALTER TABLE photos_albums DROP COLUMN caption",
+		),
+	},
+	{
+		Version: 25,
+		Name:    "album-events",
+		Up: SQL(
+			"CREATE TABLE IF NOT EXISTS album_events (id INT UNSIGNED NOT NULL AUTO_INCREMENT, album_uid VARBINARY(36), user_id VARBINARY(128), event_type VARBINARY(16), photo_count INT, event_info VARCHAR(255), created_at DATETIME NULL, PRIMARY KEY (id))",
+			"CREATE INDEX idx_album_events_album_uid ON album_events (album_uid)",
+		),
+		Down: SQL(
+			"DROP TABLE IF EXISTS album_events",
+		),
+	},
+	{
+		Version: 26,
+		Name:    "album-location-day",
+		Up: SQL(
+			"ALTER TABLE albums ADD COLUMN album_location VARCHAR(255)",
+			"ALTER TABLE albums ADD COLUMN album_day INT",
+		),
+		Down: SQL(
+			"ALTER TABLE albums DROP COLUMN album_location",
+			"ALTER TABLE albums DROP COLUMN album_day",
+		),
+	},
+	{
+		Version: 27,
+		Name:    "link-password-hash",
+		Up: func(db *gorm.DB) error {
+			_, err := entity.HashLinkPasswords()
+			return err
+		},
+	},
+}
@@ -0,0 +1,262 @@
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DefaultTable is the name of the table applied migrations are recorded in.
+const DefaultTable = "migrations"
+
+// Version represents an applied migration.
+type Version struct {
+	Version   int       `gorm:"primary_key;auto_increment:false"`
+	Name      string    `gorm:"type:varchar(255);"`
+	Dirty     bool      `gorm:"type:bool;"`
+	AppliedAt time.Time `gorm:"type:datetime;"`
+}
+
+// Status represents a known migration and whether it has been applied.
+type Status struct {
+	Migration
+	Applied   bool
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+// Migrator applies and rolls back migrations.
+type Migrator struct {
+	db    *gorm.DB
+	list  List
+	table string
+}
+
+// New returns a new migrator for the given database connection and migrations.
+func New(db *gorm.DB, list List) *Migrator {
+	return &Migrator{db: db, list: list, table: DefaultTable}
+}
+
+// Table sets the name of the table applied migrations are recorded in.
+func (m *Migrator) Table(name string) *Migrator {
+	m.table = name
+	return m
+}
+
+// init creates the migrations table if it doesn't exist yet.
+func (m *Migrator) init() error {
+	if err := m.list.Validate(); err != nil {
+		return err
+	}
+
+	return m.db.Table(m.table).AutoMigrate(&Version{}).Error
+}
+
+// versions returns applied migrations by version.
+func (m *Migrator) versions() (map[int]Version, error) {
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+
+	var rows []Version
+
+	if err := m.db.Table(m.table).Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]Version, len(rows))
+
+	for _, v := range rows {
+		result[v.Version] = v
+	}
+
+	return result, nil
+}
+
+// Status returns all known migrations and whether they have been applied.
+func (m *Migrator) Status() (result []Status, err error) {
+	versions, err := m.versions()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, migration := range m.list {
+		s := Status{Migration: migration}
+
+		if v, ok := versions[migration.Version]; ok {
+			s.Applied = true
+			s.Dirty = v.Dirty
+			s.AppliedAt = v.AppliedAt
+		}
+
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// Current returns the highest applied version, or 0 if the database has no migration history.
+func (m *Migrator) Current() (int, error) {
+	versions, err := m.versions()
+
+	if err != nil {
+		return 0, err
+	}
+
+	current := 0
+
+	for version := range versions {
+		if version > current {
+			current = version
+		}
+	}
+
+	return current, nil
+}
+
+// Pending returns migrations that have not been applied yet. For databases without history,
+// only the baseline is returned as the other migrations are recorded as applied along with it.
+func (m *Migrator) Pending() (result List, err error) {
+	versions, err := m.versions()
+
+	if err != nil {
+		return result, err
+	}
+
+	if len(versions) == 0 && len(m.list) > 0 {
+		return m.list[:1], nil
+	}
+
+	for _, migration := range m.list {
+		if _, ok := versions[migration.Version]; !ok {
+			result = append(result, migration)
+		}
+	}
+
+	return result, nil
+}
+
+// Up applies pending migrations up to and including the target version, 0 for all.
+func (m *Migrator) Up(target int) (applied List, err error) {
+	versions, err := m.versions()
+
+	if err != nil {
+		return applied, err
+	}
+
+	for _, v := range versions {
+		if v.Dirty {
+			return applied, ErrDirty
+		}
+	}
+
+	if target > 0 {
+		if _, err := m.list.Find(target); err != nil {
+			return applied, err
+		}
+	}
+
+	// Databases without history are created from the entity models, which already include
+	// the changes of all known migrations.
+	if len(versions) == 0 && len(m.list) > 0 {
+		baseline := m.list[0]
+
+		if err := m.run(baseline); err != nil {
+			return applied, err
+		}
+
+		for _, migration := range m.list[1:] {
+			if err := m.record(migration, false); err != nil {
+				return applied, err
+			}
+		}
+
+		return List{baseline}, nil
+	}
+
+	for _, migration := range m.list {
+		if target > 0 && migration.Version > target {
+			break
+		}
+
+		if _, ok := versions[migration.Version]; ok {
+			continue
+		}
+
+		if err := m.run(migration); err != nil {
+			return applied, err
+		}
+
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+// Down rolls back the given number of most recently applied migrations. A dirty migration is
+// always rolled back first, as it could not be applied completely.
+func (m *Migrator) Down(steps int) (reverted List, err error) {
+	versions, err := m.versions()
+
+	if err != nil {
+		return reverted, err
+	}
+
+	for i := len(m.list) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := m.list[i]
+
+		if _, ok := versions[migration.Version]; !ok {
+			continue
+		}
+
+		if !migration.Reversible() {
+			return reverted, fmt.Errorf("%s (%s)", ErrIrreversible, migration)
+		}
+
+		log.Infof("migrate: rolling back %s", migration)
+
+		if err := migration.Down(m.db); err != nil {
+			return reverted, fmt.Errorf("migrate: rollback of %s failed (%s)", migration, err)
+		}
+
+		if err := m.db.Table(m.table).Where("version = ?", migration.Version).Delete(&Version{}).Error; err != nil {
+			return reverted, err
+		}
+
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// run applies a migration and records the version, which is flagged as dirty until it succeeds.
+func (m *Migrator) run(migration Migration) error {
+	start := time.Now()
+
+	log.Infof("migrate: applying %s", migration)
+
+	if err := m.record(migration, true); err != nil {
+		return err
+	}
+
+	if err := migration.Up(m.db); err != nil {
+		return fmt.Errorf("migrate: %s failed (%s)", migration, err)
+	}
+
+	if err := m.db.Table(m.table).Where("version = ?", migration.Version).Update("dirty", false).Error; err != nil {
+		return err
+	}
+
+	log.Infof("migrate: applied %s in %s", migration, time.Since(start))
+
+	return nil
+}
+
+// record adds a version to the migrations table.
+func (m *Migrator) record(migration Migration, dirty bool) error {
+	v := Version{Version: migration.Version, Name: migration.Name, Dirty: dirty, AppliedAt: time.Now().UTC()}
+
+	return m.db.Table(m.table).Create(&v).Error
+}
@@ -0,0 +1,157 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// testList returns migrations creating test tables, the third one fails if fail is true.
+func testList(fail bool) List {
+	third := SQL("ALTER TABLE migrate_test ADD COLUMN note VARCHAR(255)")
+
+	if fail {
+		third = func(db *gorm.DB) error { return errors.New("failed") }
+	}
+
+	return List{
+		{Version: 1, Name: "baseline", Up: SQL("CREATE TABLE IF NOT EXISTS migrate_test (id INT)")},
+		{Version: 2, Name: "add-title", Up: SQL("ALTER TABLE migrate_test ADD COLUMN title VARCHAR(255)"), Down: SQL("ALTER TABLE migrate_test DROP COLUMN title")},
+		{Version: 3, Name: "add-note", Up: third, Down: func(db *gorm.DB) error {
+			if !db.Dialect().HasColumn("migrate_test", "note") {
+				return nil
+			}
+
+			return db.Exec("ALTER TABLE migrate_test DROP COLUMN note").Error
+		}},
+	}
+}
+
+// resetTest removes the test tables.
+func resetTest(t *testing.T, table string) {
+	if err := SQL("DROP TABLE IF EXISTS migrate_test", "DROP TABLE IF EXISTS "+table)(entity.Db()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("new database", func(t *testing.T) {
+		resetTest(t, "migrations_test_new")
+		m := New(entity.Db(), testList(false)).Table("migrations_test_new")
+
+		pending, err := m.Pending()
+
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+
+		applied, err := m.Up(0)
+
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+
+		current, err := m.Current()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, current)
+
+		pending, err = m.Pending()
+
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+	t.Run("upgrade", func(t *testing.T) {
+		resetTest(t, "migrations_test_up")
+		m := New(entity.Db(), testList(false)[:1]).Table("migrations_test_up")
+
+		if _, err := m.Up(0); err != nil {
+			t.Fatal(err)
+		}
+
+		m = New(entity.Db(), testList(false)).Table("migrations_test_up")
+
+		applied, err := m.Up(2)
+
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+		assert.Equal(t, 2, applied[0].Version)
+
+		applied, err = m.Up(0)
+
+		assert.NoError(t, err)
+		assert.Len(t, applied, 1)
+		assert.Equal(t, 3, applied[0].Version)
+	})
+	t.Run("unknown version", func(t *testing.T) {
+		m := New(entity.Db(), testList(false)).Table("migrations_test_up")
+
+		_, err := m.Up(9)
+
+		assert.Equal(t, ErrVersion, err)
+	})
+	t.Run("dirty", func(t *testing.T) {
+		resetTest(t, "migrations_test_dirty")
+		m := New(entity.Db(), testList(true)[:1]).Table("migrations_test_dirty")
+
+		if _, err := m.Up(0); err != nil {
+			t.Fatal(err)
+		}
+
+		m = New(entity.Db(), testList(true)).Table("migrations_test_dirty")
+
+		_, err := m.Up(0)
+
+		assert.EqualError(t, err, "migrate: 3 add-note failed (failed)")
+
+		status, err := m.Status()
+
+		assert.NoError(t, err)
+		assert.True(t, status[2].Applied)
+		assert.True(t, status[2].Dirty)
+
+		_, err = m.Up(0)
+
+		assert.Equal(t, ErrDirty, err)
+
+		reverted, err := m.Down(1)
+
+		assert.NoError(t, err)
+		assert.Len(t, reverted, 1)
+		assert.Equal(t, 3, reverted[0].Version)
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	resetTest(t, "migrations_test_down")
+	m := New(entity.Db(), testList(false)[:1]).Table("migrations_test_down")
+
+	if _, err := m.Up(0); err != nil {
+		t.Fatal(err)
+	}
+
+	m = New(entity.Db(), testList(false)).Table("migrations_test_down")
+
+	if _, err := m.Up(0); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("two steps", func(t *testing.T) {
+		reverted, err := m.Down(2)
+
+		assert.NoError(t, err)
+		assert.Len(t, reverted, 2)
+
+		current, err := m.Current()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, current)
+	})
+	t.Run("irreversible", func(t *testing.T) {
+		reverted, err := m.Down(1)
+
+		assert.EqualError(t, err, "migrate: migration can't be rolled back (1 baseline)")
+		assert.Empty(t, reverted)
+	})
+}
@@ -3,11 +3,14 @@ package mutex
 import (
 	"errors"
 	"sync"
+	"time"
 )
 
 type Busy struct {
 	busy     bool
 	canceled bool
+	paused   bool
+	started  time.Time
 	mutex    sync.Mutex
 }
 
@@ -32,6 +35,8 @@ func (b *Busy) Start() error {
 
 	b.busy = true
 	b.canceled = false
+	b.paused = false
+	b.started = time.Now()
 
 	return nil
 }
@@ -42,6 +47,7 @@ func (b *Busy) Stop() {
 
 	b.busy = false
 	b.canceled = false
+	b.paused = false
 }
 
 func (b *Busy) Cancel() {
@@ -59,3 +65,42 @@ func (b *Busy) Canceled() bool {
 
 	return b.canceled
 }
+
+func (b *Busy) Pause() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.busy {
+		return errors.New("not running")
+	}
+
+	b.paused = true
+
+	return nil
+}
+
+func (b *Busy) Resume() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.paused = false
+}
+
+func (b *Busy) Paused() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.paused
+}
+
+// Started returns the time the worker was started, or zero if it isn't busy.
+func (b *Busy) Started() time.Time {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.busy {
+		return time.Time{}
+	}
+
+	return b.started
+}
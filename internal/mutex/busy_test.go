@@ -21,3 +21,29 @@ func TestBusy_Busy(t *testing.T) {
 	assert.False(t, b.Canceled())
 	assert.False(t, b.Busy())
 }
+
+func TestBusy_Pause(t *testing.T) {
+	b := Busy{}
+
+	assert.Error(t, b.Pause())
+	assert.False(t, b.Paused())
+	assert.Nil(t, b.Start())
+	assert.Nil(t, b.Pause())
+	assert.True(t, b.Paused())
+	assert.True(t, b.Busy())
+	b.Resume()
+	assert.False(t, b.Paused())
+	assert.Nil(t, b.Pause())
+	b.Stop()
+	assert.False(t, b.Paused())
+}
+
+func TestBusy_Started(t *testing.T) {
+	b := Busy{}
+
+	assert.True(t, b.Started().IsZero())
+	assert.Nil(t, b.Start())
+	assert.False(t, b.Started().IsZero())
+	b.Stop()
+	assert.True(t, b.Started().IsZero())
+}
@@ -0,0 +1,33 @@
+package mutex
+
+import (
+	"sync"
+)
+
+// Mount represents the availability of a mounted folder.
+type Mount struct {
+	offline bool
+	mutex   sync.Mutex
+}
+
+// Offline returns true if the folder is not available.
+func (m *Mount) Offline() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.offline
+}
+
+// SetOffline updates the availability and returns true if it changed.
+func (m *Mount) SetOffline(offline bool) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.offline == offline {
+		return false
+	}
+
+	m.offline = offline
+
+	return true
+}
@@ -0,0 +1,18 @@
+package mutex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMount_SetOffline(t *testing.T) {
+	m := Mount{}
+
+	assert.False(t, m.Offline())
+	assert.True(t, m.SetOffline(true))
+	assert.True(t, m.Offline())
+	assert.False(t, m.SetOffline(true))
+	assert.True(t, m.SetOffline(false))
+	assert.False(t, m.Offline())
+}
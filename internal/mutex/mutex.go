@@ -10,8 +10,17 @@ var (
 	SyncWorker  = Busy{}
 	ShareWorker = Busy{}
 	PrismWorker = Busy{}
+	Originals   = Mount{}
 )
 
+// Workers maps job names to background workers, e.g. for listing and canceling running jobs.
+var Workers = map[string]*Busy{
+	"main":  &MainWorker,
+	"sync":  &SyncWorker,
+	"share": &ShareWorker,
+	"prism": &PrismWorker,
+}
+
 // WorkersBusy returns true if any worker is busy.
 func WorkersBusy() bool {
 	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || PrismWorker.Busy()
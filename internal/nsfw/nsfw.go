@@ -33,6 +33,21 @@ func (l *Labels) IsSafe() bool {
 	return !l.NSFW(ThresholdSafe)
 }
 
+// Score returns the highest confidence of all offensive categories.
+func (l *Labels) Score() float32 {
+	score := l.Porn
+
+	if l.Sexy > score {
+		score = l.Sexy
+	}
+
+	if l.Hentai > score {
+		score = l.Hentai
+	}
+
+	return score
+}
+
 // NSFW returns true if the image is may not be safe for work.
 func (l *Labels) NSFW(threshold float32) bool {
 	if l.Neutral > 0.25 {
@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// SessionHeader is the name of the header containing the session token.
+const SessionHeader = "X-Session-Token"
+
+// New returns a new document without operations.
+func New(title, version, serverUrl string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Servers: []Server{{URL: strings.TrimRight(serverUrl, "/")}},
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				"session": {Type: "apiKey", In: "header", Name: SessionHeader},
+			},
+		},
+		Security: []map[string][]string{{"session": {}}},
+	}
+}
+
+// Path converts a route path like "/albums/:uid" to "/albums/{uid}" and returns the names of all path parameters.
+func Path(route string) (result string, params []string) {
+	segments := strings.Split(route, "/")
+
+	for i, s := range segments {
+		if len(s) > 1 && (s[0] == ':' || s[0] == '*') {
+			params = append(params, s[1:])
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// HandlerName returns the function name of a route handler like "github.com/.../api.GetAlbums.func1".
+func HandlerName(handler string) string {
+	if i := strings.LastIndex(handler, "/"); i >= 0 {
+		handler = handler[i+1:]
+	}
+
+	parts := strings.Split(handler, ".")
+
+	if len(parts) > 1 {
+		return parts[1]
+	}
+
+	return handler
+}
+
+// tag returns the first path segment after the API version, e.g. "albums" for "/api/v1/albums/{uid}".
+func tag(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, s := range segments {
+		if len(s) > 1 && s[0] == 'v' && s[1] >= '0' && s[1] <= '9' && i+1 < len(segments) {
+			return segments[i+1]
+		}
+	}
+
+	return segments[0]
+}
+
+// Add adds an operation. If form is not nil, its fields are documented as query parameters for
+// GET and DELETE requests, and as JSON request body otherwise.
+func (d *Document) Add(method, route, handler string, form interface{}) {
+	path, params := Path(route)
+	method = strings.ToLower(method)
+
+	op := &Operation{
+		OperationID: HandlerName(handler),
+		Tags:        []string{tag(path)},
+		Responses:   map[string]Response{"200": {Description: http.StatusText(http.StatusOK)}},
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	if form != nil {
+		t := reflect.TypeOf(form)
+
+		if method == "get" || method == "delete" {
+			s := SchemaOf(t, "form")
+			names := make([]string, 0, len(s.Properties))
+
+			for name := range s.Properties {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Required: list.Contains(s.Required, name), Schema: s.Properties[name]})
+			}
+		} else {
+			name := t.Name()
+
+			if _, ok := d.Components.Schemas[name]; !ok {
+				d.Components.Schemas[name] = SchemaOf(t, "json")
+			}
+
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/" + name}}},
+			}
+		}
+	}
+
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(PathItem)
+	}
+
+	d.Paths[path][method] = op
+}
@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSearch struct {
+	Query  string `form:"q"`
+	Count  int    `form:"count" binding:"required"`
+	Offset int    `form:"offset"`
+	Hidden string `form:"-"`
+}
+
+type testAlbum struct {
+	Title   string     `json:"Title" binding:"required"`
+	Tags    []string   `json:"Tags"`
+	Expires *time.Time `json:"Expires"`
+	private string
+}
+
+func TestPath(t *testing.T) {
+	t.Run("params", func(t *testing.T) {
+		path, params := Path("/api/v1/albums/:uid/photos")
+		assert.Equal(t, "/api/v1/albums/{uid}/photos", path)
+		assert.Equal(t, []string{"uid"}, params)
+	})
+	t.Run("wildcard", func(t *testing.T) {
+		path, params := Path("/api/v1/import/*path")
+		assert.Equal(t, "/api/v1/import/{path}", path)
+		assert.Equal(t, []string{"path"}, params)
+	})
+	t.Run("static", func(t *testing.T) {
+		path, params := Path("/api/v1/status")
+		assert.Equal(t, "/api/v1/status", path)
+		assert.Empty(t, params)
+	})
+}
+
+func TestHandlerName(t *testing.T) {
+	assert.Equal(t, "GetAlbums", HandlerName("github.com/photoprism/photoprism/internal/api.GetAlbums.func1"))
+	assert.Equal(t, "main", HandlerName("main"))
+}
+
+func TestSchemaOf(t *testing.T) {
+	s := SchemaOf(reflect.TypeOf(testAlbum{}), "json")
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"Title"}, s.Required)
+	assert.Len(t, s.Properties, 3)
+	assert.Equal(t, "string", s.Properties["Title"].Type)
+	assert.Equal(t, "array", s.Properties["Tags"].Type)
+	assert.Equal(t, "string", s.Properties["Tags"].Items.Type)
+	assert.Equal(t, "date-time", s.Properties["Expires"].Format)
+}
+
+func TestDocument_Add(t *testing.T) {
+	doc := New("PhotoPrism", "1.0", "http://localhost:2342/")
+
+	doc.Add("GET", "/api/v1/albums", "api.GetAlbums.func1", testSearch{})
+	doc.Add("PUT", "/api/v1/albums/:uid", "api.UpdateAlbum.func1", testAlbum{})
+	doc.Add("DELETE", "/api/v1/albums/:uid", "api.DeleteAlbum.func1", nil)
+
+	assert.Equal(t, "http://localhost:2342", doc.Servers[0].URL)
+	assert.Len(t, doc.Paths, 2)
+
+	search := doc.Paths["/api/v1/albums"]["get"]
+
+	assert.Equal(t, "GetAlbums", search.OperationID)
+	assert.Equal(t, []string{"albums"}, search.Tags)
+	assert.Len(t, search.Parameters, 3)
+	assert.Equal(t, "count", search.Parameters[0].Name)
+	assert.True(t, search.Parameters[0].Required)
+	assert.Equal(t, "query", search.Parameters[0].In)
+
+	update := doc.Paths["/api/v1/albums/{uid}"]["put"]
+
+	assert.Equal(t, "uid", update.Parameters[0].Name)
+	assert.Equal(t, "path", update.Parameters[0].In)
+	assert.Equal(t, "#/components/schemas/testAlbum", update.RequestBody.Content["application/json"].Schema.Ref)
+	assert.NotNil(t, doc.Components.Schemas["testAlbum"])
+	assert.Nil(t, doc.Paths["/api/v1/albums/{uid}"]["delete"].RequestBody)
+
+	data, err := json.Marshal(doc)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(data), `"openapi":"3.0.3"`)
+	assert.Contains(t, string(data), `"$ref":"#/components/schemas/testAlbum"`)
+}
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema represents the data type of a parameter, property or body.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// tagName returns the name in a struct tag like `json:"name,omitempty"`, or an empty string if it should be skipped.
+func tagName(field reflect.StructField, key string) string {
+	tag, ok := field.Tag.Lookup(key)
+
+	if !ok {
+		return field.Name
+	}
+
+	name := strings.Split(tag, ",")[0]
+
+	if name == "-" {
+		return ""
+	} else if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+// required returns true if the field has a binding:"required" tag.
+func required(field reflect.StructField) bool {
+	for _, b := range strings.Split(field.Tag.Get("binding"), ",") {
+		if b == "required" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SchemaOf returns the schema of a Go type, using the given struct tag key for property names.
+func SchemaOf(t reflect.Type, key string) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: SchemaOf(t.Elem(), key)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: SchemaOf(t.Elem(), key)}
+	case reflect.Struct:
+		result := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := tagName(field, key)
+
+			if name == "" {
+				continue
+			}
+
+			result.Properties[name] = SchemaOf(field.Type, key)
+
+			if required(field) {
+				result.Required = append(result.Required, name)
+			}
+		}
+
+		return result
+	default:
+		return &Schema{}
+	}
+}
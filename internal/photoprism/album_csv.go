@@ -0,0 +1,254 @@
+package photoprism
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// AlbumCsvHeader contains the column names of exported album CSV files.
+var AlbumCsvHeader = []string{"Hash", "Path", "PhotoUID"}
+
+// ErrAlbumCsvNoMatch is returned when replacing an album with a CSV file that doesn't match any photo,
+// so that a wrong file can't remove all photos.
+var ErrAlbumCsvNoMatch = errors.New("no photos found in csv file")
+
+var sha1Hash = regexp.MustCompile("^[0-9a-fA-F]{40}$")
+
+// AlbumCsvResult reports the changes made by ImportAlbumCsv.
+type AlbumCsvResult struct {
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Unmatched []string `json:"unmatched"`
+}
+
+// albumCsvRow represents a row of an album CSV file.
+type albumCsvRow struct {
+	Hash     string
+	Path     string
+	PhotoUID string
+}
+
+// String returns the value that identifies the row in reports.
+func (r albumCsvRow) String() string {
+	switch {
+	case r.Path != "":
+		return r.Path
+	case r.Hash != "":
+		return r.Hash
+	default:
+		return r.PhotoUID
+	}
+}
+
+// ExportAlbumCsv writes the file hash, originals path and photo UID of all photos in an album as CSV,
+// one line per photo.
+func ExportAlbumCsv(w io.Writer, albumUID string) error {
+	files, err := query.AlbumFiles(albumUID)
+
+	if err != nil {
+		return err
+	}
+
+	out := csv.NewWriter(w)
+
+	if err := out.Write(AlbumCsvHeader); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := out.Write([]string{f.FileHash, f.FileName, f.PhotoUID}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+
+	return out.Error()
+}
+
+// ImportAlbumCsv adds the photos listed in a CSV file to an album. Rows are matched by file hash, path relative
+// to the originals folder, or photo UID, in that order. Files with a header row may contain these columns in any
+// order along with others, e.g. "Hash", "Path" and "PhotoUID" as exported by ExportAlbumCsv. Otherwise, the first
+// column must contain either file hashes or paths. If replace is true, photos not listed are removed from the album.
+func ImportAlbumCsv(r io.Reader, albumUID string, replace bool) (result AlbumCsvResult, err error) {
+	rows, err := readAlbumCsv(r)
+
+	if err != nil {
+		return result, err
+	}
+
+	var hashes, names []string
+
+	for _, row := range rows {
+		if row.Hash != "" {
+			hashes = append(hashes, row.Hash)
+		}
+
+		if row.Path != "" {
+			names = append(names, row.Path)
+		}
+	}
+
+	byHash, byName, err := query.FilePhotoUIDs(hashes, names)
+
+	if err != nil {
+		return result, err
+	}
+
+	matched := make(map[string]bool)
+	var photoUIDs []string
+
+	for _, row := range rows {
+		uid := byHash[row.Hash]
+
+		if uid == "" {
+			uid = byName[row.Path]
+		}
+
+		if uid == "" && row.PhotoUID != "" {
+			if p, err := query.PhotoByUID(row.PhotoUID); err == nil {
+				uid = p.PhotoUID
+			}
+		}
+
+		if uid == "" {
+			result.Unmatched = append(result.Unmatched, row.String())
+		} else if !matched[uid] {
+			matched[uid] = true
+			photoUIDs = append(photoUIDs, uid)
+		}
+	}
+
+	if replace && len(photoUIDs) == 0 {
+		return result, ErrAlbumCsvNoMatch
+	}
+
+	current, err := query.AlbumPhotoUIDs(albumUID)
+
+	if err != nil {
+		return result, err
+	}
+
+	existing := make(map[string]bool, len(current))
+
+	for _, uid := range current {
+		existing[uid] = true
+	}
+
+	for _, uid := range photoUIDs {
+		if m := entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(uid, albumUID)); m == nil {
+			continue
+		} else if m.Hidden {
+			if err := entity.Db().Model(m).Update("Hidden", false).Error; err != nil {
+				log.Errorf("album: %s", err)
+				continue
+			}
+		} else if existing[uid] {
+			continue
+		}
+
+		result.Added = append(result.Added, uid)
+	}
+
+	if !replace {
+		return result, nil
+	}
+
+	for _, uid := range current {
+		if !matched[uid] {
+			result.Removed = append(result.Removed, uid)
+		}
+	}
+
+	if len(result.Removed) > 0 {
+		if err := entity.Db().Where("album_uid = ? AND photo_uid IN (?)", albumUID, result.Removed).Delete(&entity.PhotoAlbum{}).Error; err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// readAlbumCsv parses an album CSV file and returns its rows, see ImportAlbumCsv.
+func readAlbumCsv(r io.Reader) (rows []albumCsvRow, err error) {
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = -1
+	in.TrimLeadingSpace = true
+
+	hashCol, pathCol, uidCol := -1, -1, -1
+	first := true
+
+	for {
+		record, err := in.Read()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return rows, err
+		}
+
+		if first {
+			first = false
+
+			// Remove byte order mark added by spreadsheet applications.
+			if len(record) > 0 {
+				record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			}
+
+			for i, name := range record {
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "hash", "filehash", "sha1":
+					hashCol = i
+				case "path", "file", "filename":
+					pathCol = i
+				case "uid", "photouid":
+					uidCol = i
+				}
+			}
+
+			if hashCol >= 0 || pathCol >= 0 || uidCol >= 0 {
+				continue
+			}
+		}
+
+		var row albumCsvRow
+
+		col := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		if hashCol < 0 && pathCol < 0 && uidCol < 0 {
+			// Without header, the first column contains either hashes or paths.
+			if v := col(0); sha1Hash.MatchString(v) {
+				row.Hash = v
+			} else {
+				row.Path = v
+			}
+		} else {
+			row.Hash = col(hashCol)
+			row.Path = col(pathCol)
+			row.PhotoUID = col(uidCol)
+		}
+
+		row.Hash = strings.ToLower(row.Hash)
+		row.Path = strings.TrimLeft(strings.TrimPrefix(row.Path, "./"), "/")
+
+		if row.Hash == "" && row.Path == "" && row.PhotoUID == "" {
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
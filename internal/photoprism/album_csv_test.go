@@ -0,0 +1,113 @@
+package photoprism
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAlbumCsv(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := ExportAlbumCsv(buf, "at9lxuqxpogaaba8"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Hash,Path,PhotoUID\n2cad9168fa6acc5c5c2965ddf6ec465ca42fd818,exampleFileName.jpg,pt9jtdre2lvl0yh7\n", buf.String())
+}
+
+func TestImportAlbumCsv(t *testing.T) {
+	album := entity.NewAlbum("CSV Import", entity.TypeDefault)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		csv := "Path,Hash\n/bridge.jpg,\n,2CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818\nnotfound.jpg,\n"
+
+		result, err := ImportAlbumCsv(strings.NewReader(csv), album.AlbumUID, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh7"}, result.Added)
+		assert.Empty(t, result.Removed)
+		assert.Equal(t, []string{"notfound.jpg"}, result.Unmatched)
+	})
+	t.Run("add again", func(t *testing.T) {
+		result, err := ImportAlbumCsv(strings.NewReader("bridge.jpg\n"), album.AlbumUID, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Added)
+		assert.Empty(t, result.Unmatched)
+	})
+	t.Run("replace", func(t *testing.T) {
+		result, err := ImportAlbumCsv(strings.NewReader("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818\n"), album.AlbumUID, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Added)
+		assert.Equal(t, []string{"pt9jtdre2lvl0y11"}, result.Removed)
+
+		uids, err := query.AlbumPhotoUIDs(album.AlbumUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, uids)
+	})
+	t.Run("replace without match", func(t *testing.T) {
+		_, err := ImportAlbumCsv(strings.NewReader("notfound.jpg\n"), album.AlbumUID, true)
+
+		assert.Equal(t, ErrAlbumCsvNoMatch, err)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		if err := ExportAlbumCsv(buf, "at9lxuqxpogaaba9"); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := ImportAlbumCsv(buf, album.AlbumUID, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0y11"}, result.Added)
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, result.Removed)
+	})
+}
+
+func TestReadAlbumCsv(t *testing.T) {
+	t.Run("header", func(t *testing.T) {
+		rows, err := readAlbumCsv(strings.NewReader("\ufeffTitle,PhotoUID\nFoo,pt9jtdre2lvl0y11\n"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []albumCsvRow{{PhotoUID: "pt9jtdre2lvl0y11"}}, rows)
+	})
+	t.Run("no header", func(t *testing.T) {
+		rows, err := readAlbumCsv(strings.NewReader("./2020/bridge.jpg\n\n2cad9168fa6acc5c5c2965ddf6ec465ca42fd818,foo\n"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []albumCsvRow{{Path: "2020/bridge.jpg"}, {Hash: "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}}, rows)
+	})
+}
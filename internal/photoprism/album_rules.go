@@ -0,0 +1,78 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// albumRules returns the rules for adding imported photos to albums.
+func (imp *Import) albumRules() entity.AlbumRules {
+	rules, err := query.AlbumRules()
+
+	if err != nil {
+		log.Errorf("import: %s (album rules)", err)
+	}
+
+	return rules
+}
+
+// ApplyAlbumRules adds an imported photo to the albums of all matching rules and returns their UIDs.
+// Photos that were removed from an album before are not added again.
+func ApplyAlbumRules(rules entity.AlbumRules, photo entity.Photo, folder, uploader string) (albums []string) {
+	in := entity.AlbumRuleInput{
+		Folder:       folder,
+		TakenAt:      photo.TakenAt,
+		CameraSerial: photo.CameraSerial,
+		Uploader:     uploader,
+	}
+
+	for _, albumUID := range rules.Albums(in) {
+		if entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(photo.PhotoUID, albumUID)) == nil {
+			log.Errorf("import: could not add %s to album %s", photo.PhotoUID, txt.Quote(albumUID))
+			continue
+		}
+
+		albums = append(albums, albumUID)
+	}
+
+	return albums
+}
+
+// AlbumRuleCounts counts the photos added to albums by rules during an import, so that the album activity
+// log gets one event per album instead of one per photo.
+type AlbumRuleCounts struct {
+	counts map[string]int
+	mutex  sync.Mutex
+}
+
+// NewAlbumRuleCounts returns a new, empty counter.
+func NewAlbumRuleCounts() *AlbumRuleCounts {
+	return &AlbumRuleCounts{counts: make(map[string]int)}
+}
+
+// Add counts a photo added to the given albums.
+func (r *AlbumRuleCounts) Add(albums []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, albumUID := range albums {
+		r.counts[albumUID]++
+	}
+}
+
+// Log records the number of photos added to each album in the album activity log.
+func (r *AlbumRuleCounts) Log(userID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for albumUID, count := range r.counts {
+		if _, err := entity.AddAlbumEvent(albumUID, userID, entity.AlbumEventAdded, count, entity.AlbumEventRules); err != nil {
+			log.Errorf("import: %s (activity log)", err)
+		}
+	}
+
+	r.counts = make(map[string]int)
+}
@@ -0,0 +1,55 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyAlbumRules(t *testing.T) {
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rules := entity.AlbumRules{
+		{AlbumUID: "at9lxuqxpogaaba8", RuleFolder: "dji*"},
+		{AlbumUID: "at9lxuqxpogaaba9", RuleCameraSerial: "0K1234", RuleAfter: &after},
+	}
+
+	photo := entity.Photo{PhotoUID: "pt9jtdre2lvlalb1", TakenAt: time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC), CameraSerial: "0K1234"}
+
+	t.Run("folder and camera", func(t *testing.T) {
+		albums := ApplyAlbumRules(rules, photo, "upload/DJI_0012", "")
+
+		assert.Equal(t, []string{"at9lxuqxpogaaba8", "at9lxuqxpogaaba9"}, albums)
+
+		var count int
+
+		if err := entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid = ?", photo.PhotoUID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, count)
+	})
+	t.Run("no match", func(t *testing.T) {
+		photo.CameraSerial = ""
+		assert.Empty(t, ApplyAlbumRules(rules, photo, "2020/Phone", "jane@example.com"))
+	})
+}
+
+func TestAlbumRuleCounts(t *testing.T) {
+	added := NewAlbumRuleCounts()
+	added.Add([]string{"at9lxuqxpogaaba8", "at9lxuqxpogaaba9"})
+	added.Add([]string{"at9lxuqxpogaaba8"})
+	added.Log("jane@example.com")
+
+	var event entity.AlbumEvent
+
+	if err := entity.Db().Where("album_uid = ? AND event_info = ?", "at9lxuqxpogaaba8", entity.AlbumEventRules).Last(&event).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, entity.AlbumEventAdded, event.EventType)
+	assert.Equal(t, 2, event.PhotoCount)
+	assert.Equal(t, "jane@example.com", event.UserID)
+}
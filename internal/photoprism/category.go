@@ -0,0 +1,161 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Max chroma of paper and whiteboards, see MediaFile.Colors().
+const categoryMaxChroma = 12
+
+// Max uncertainty of labels used to detect categories.
+const categoryMaxUncertainty = 60
+
+// Min height to width ratio of receipts.
+const receiptMinRatio = 2.0
+
+// categoryKeywords maps file name keywords to categories.
+var categoryKeywords = []struct {
+	Keyword  string
+	Category string
+}{
+	{"screenshot", entity.CategoryScreenshot},
+	{"screen shot", entity.CategoryScreenshot},
+	{"screen_shot", entity.CategoryScreenshot},
+	{"bildschirmfoto", entity.CategoryScreenshot},
+	{"schermata", entity.CategoryScreenshot},
+	{"capture d", entity.CategoryScreenshot},
+	{"receipt", entity.CategoryReceipt},
+	{"quittung", entity.CategoryReceipt},
+	{"kassenbon", entity.CategoryReceipt},
+	{"invoice", entity.CategoryReceipt},
+	{"rechnung", entity.CategoryReceipt},
+	{"whiteboard", entity.CategoryWhiteboard},
+	{"document", entity.CategoryDocument},
+	{"dokument", entity.CategoryDocument},
+}
+
+// documentLabels contains labels of images showing text, see classify.Rules.
+var documentLabels = map[string]bool{
+	"document": true,
+	"info":     true,
+	"office":   true,
+	"book":     true,
+}
+
+// screenSizes contains common display resolutions of phones, tablets and computers in landscape orientation.
+var screenSizes = map[[2]int]bool{
+	{1136, 640}: true, {1334, 750}: true, {1792, 828}: true, {2208, 1242}: true, {2436, 1125}: true,
+	{2532, 1170}: true, {2556, 1179}: true, {2688, 1242}: true, {2778, 1284}: true, {2796, 1290}: true,
+	{1920, 1080}: true, {2340, 1080}: true, {2400, 1080}: true, {2960, 1440}: true, {3040, 1440}: true,
+	{3200, 1440}: true, {2048, 1536}: true, {2224, 1668}: true, {2388, 1668}: true, {2732, 2048}: true,
+	{1366, 768}: true, {1440, 900}: true, {1680, 1050}: true, {2560, 1440}: true, {2560, 1600}: true,
+	{2880, 1800}: true, {3024, 1964}: true, {3456, 2234}: true, {3840, 2160}: true, {5120, 2880}: true,
+}
+
+// CategoryInput contains the file properties used to detect the category of a photo.
+type CategoryInput struct {
+	FileName    string
+	FileType    fs.FileType
+	Width       int
+	Height      int
+	CameraMake  string
+	CameraModel string
+	UserComment string
+	MainColor   string
+	Chroma      int
+	Labels      classify.Labels
+}
+
+// camera returns true if the file was created by a camera.
+func (in CategoryInput) camera() bool {
+	return in.CameraMake != "" || in.CameraModel != ""
+}
+
+// text returns true if the labels indicate that the image mainly shows text.
+func (in CategoryInput) text() bool {
+	for _, l := range in.Labels {
+		if l.Uncertainty <= categoryMaxUncertainty && documentLabels[l.Name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// paper returns true if the image is mostly white with little color, like paper or a whiteboard.
+func (in CategoryInput) paper() bool {
+	return in.Chroma <= categoryMaxChroma && (in.MainColor == "white" || in.MainColor == "grey")
+}
+
+// screen returns true if the image has the resolution of a common display.
+func (in CategoryInput) screen() bool {
+	w, h := in.Width, in.Height
+
+	if w < h {
+		w, h = h, w
+	}
+
+	return screenSizes[[2]int{w, h}]
+}
+
+// Category returns the category of a photo based on its file name, metadata, resolution, colors and labels,
+// or entity.CategoryNone if it seems to be a regular photo.
+func Category(in CategoryInput) string {
+	name := strings.ToLower(filepath.Base(in.FileName))
+
+	for _, k := range categoryKeywords {
+		if strings.Contains(name, k.Keyword) {
+			return k.Category
+		}
+	}
+
+	// iOS adds this comment to screenshots.
+	if strings.Contains(strings.ToLower(in.UserComment), "screenshot") {
+		return entity.CategoryScreenshot
+	}
+
+	// Screenshots have no camera metadata, and are usually saved as PNG in the resolution of the display.
+	if !in.camera() && in.screen() && (in.FileType == fs.TypePng || in.text()) {
+		return entity.CategoryScreenshot
+	}
+
+	if !in.text() || !in.paper() {
+		return entity.CategoryNone
+	}
+
+	// Receipts are long and narrow, whiteboards are photographed in landscape orientation,
+	// and scanned documents have no camera metadata.
+	if in.Width > 0 && float64(in.Height)/float64(in.Width) >= receiptMinRatio {
+		return entity.CategoryReceipt
+	} else if in.camera() && in.Width > in.Height {
+		return entity.CategoryWhiteboard
+	}
+
+	return entity.CategoryDocument
+}
+
+// originalType returns the type of the original file a JPEG was converted from, so that
+// screenshots saved as PNG can be recognized.
+func originalType(m *MediaFile) fs.FileType {
+	if !m.IsJpeg() {
+		return m.FileType()
+	}
+
+	fileName := m.FileName()
+
+	// Converted files may be stored in a hidden sub directory.
+	if dir := filepath.Dir(fileName); filepath.Base(dir) == fs.HiddenPath {
+		fileName = filepath.Join(filepath.Dir(dir), filepath.Base(fileName))
+	}
+
+	if fs.TypePng.Find(fileName, false) != "" {
+		return fs.TypePng
+	}
+
+	return m.FileType()
+}
@@ -0,0 +1,40 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestCategory(t *testing.T) {
+	text := classify.Labels{{Name: "document", Uncertainty: 30}}
+
+	tests := []struct {
+		name     string
+		in       CategoryInput
+		expected string
+	}{
+		{"regular photo", CategoryInput{FileName: "IMG_1234.jpg", FileType: fs.TypeJpeg, Width: 4032, Height: 3024, CameraMake: "Apple", MainColor: "green", Chroma: 40}, entity.CategoryNone},
+		{"screenshot file name", CategoryInput{FileName: "2020/Screenshot 2020-05-01 at 10.00.00.png", FileType: fs.TypePng}, entity.CategoryScreenshot},
+		{"receipt file name", CategoryInput{FileName: "Receipt_Hardware_Store.jpg", FileType: fs.TypeJpeg}, entity.CategoryReceipt},
+		{"user comment", CategoryInput{FileName: "IMG_0001.png", UserComment: "Screenshot"}, entity.CategoryScreenshot},
+		{"png with screen size", CategoryInput{FileName: "IMG_0002.png", FileType: fs.TypePng, Width: 1170, Height: 2532}, entity.CategoryScreenshot},
+		{"png with other size", CategoryInput{FileName: "IMG_0003.png", FileType: fs.TypePng, Width: 1000, Height: 800}, entity.CategoryNone},
+		{"screen size from camera", CategoryInput{FileName: "IMG_0004.jpg", FileType: fs.TypeJpeg, Width: 1920, Height: 1080, CameraMake: "Canon", Labels: text}, entity.CategoryNone},
+		{"receipt", CategoryInput{FileName: "IMG_0005.jpg", FileType: fs.TypeJpeg, Width: 1000, Height: 3000, CameraMake: "Apple", MainColor: "white", Chroma: 5, Labels: text}, entity.CategoryReceipt},
+		{"whiteboard", CategoryInput{FileName: "IMG_0006.jpg", FileType: fs.TypeJpeg, Width: 4032, Height: 3024, CameraMake: "Apple", MainColor: "grey", Chroma: 8, Labels: text}, entity.CategoryWhiteboard},
+		{"document", CategoryInput{FileName: "scan0001.jpg", FileType: fs.TypeJpeg, Width: 2480, Height: 3508, MainColor: "white", Chroma: 2, Labels: text}, entity.CategoryDocument},
+		{"colorful", CategoryInput{FileName: "IMG_0007.jpg", FileType: fs.TypeJpeg, Width: 2480, Height: 3508, MainColor: "red", Chroma: 50, Labels: text}, entity.CategoryNone},
+		{"uncertain", CategoryInput{FileName: "IMG_0008.jpg", FileType: fs.TypeJpeg, Width: 2480, Height: 3508, MainColor: "white", Chroma: 2, Labels: classify.Labels{{Name: "document", Uncertainty: 90}}}, entity.CategoryNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Category(tt.in))
+		})
+	}
+}
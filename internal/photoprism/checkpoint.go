@@ -0,0 +1,387 @@
+package photoprism
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/list"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CreateCheckpoint saves the current metadata of all photos and albums in a new checkpoint,
+// and deletes the oldest checkpoints if there are more than configured.
+func CreateCheckpoint(conf *config.Config, note string) (*entity.Checkpoint, error) {
+	data, err := query.CheckpointState()
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := entity.NewCheckpoint(note)
+	m.PhotoCount = len(data.Photos)
+	m.AlbumCount = len(data.Albums)
+
+	if err := os.MkdirAll(conf.CheckpointPath(), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	fileName := filepath.Join(conf.CheckpointPath(), m.FileName())
+
+	if err := writeCheckpoint(fileName, data); err != nil {
+		return nil, err
+	}
+
+	if err := m.Create(); err != nil {
+		_ = os.Remove(fileName)
+		return nil, err
+	}
+
+	log.Infof("checkpoint: saved metadata of %d photos and %d albums", m.PhotoCount, m.AlbumCount)
+
+	if _, err := PruneCheckpoints(conf); err != nil {
+		log.Errorf("checkpoint: %s", err)
+	}
+
+	return m, nil
+}
+
+// writeCheckpoint writes checkpoint data to a gzip compressed JSON file. A temporary file is
+// renamed once complete, so that checkpoints are never incomplete.
+func writeCheckpoint(fileName string, data entity.CheckpointData) error {
+	tmpName := fileName + ".tmp"
+
+	f, err := os.Create(tmpName)
+
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(f)
+
+	if err := json.NewEncoder(zw).Encode(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, fileName)
+}
+
+// LoadCheckpoint returns the metadata saved in a checkpoint.
+func LoadCheckpoint(conf *config.Config, m entity.Checkpoint) (data entity.CheckpointData, err error) {
+	f, err := os.Open(filepath.Join(conf.CheckpointPath(), m.FileName()))
+
+	if err != nil {
+		return data, err
+	}
+
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+
+	if err != nil {
+		return data, err
+	}
+
+	defer zr.Close()
+
+	if err := json.NewDecoder(zr).Decode(&data); err != nil {
+		return data, fmt.Errorf("checkpoint: can't read %s (%s)", txt.Quote(m.FileName()), err)
+	}
+
+	return data, nil
+}
+
+// DeleteCheckpoint deletes a checkpoint including its file.
+func DeleteCheckpoint(conf *config.Config, m entity.Checkpoint) error {
+	fileName := filepath.Join(conf.CheckpointPath(), m.FileName())
+
+	if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return m.Delete()
+}
+
+// PruneCheckpoints deletes the oldest checkpoints if there are more than configured.
+func PruneCheckpoints(conf *config.Config) (deleted int, err error) {
+	expired, err := query.Checkpoints(10000, conf.CheckpointKeep())
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range expired {
+		if err := DeleteCheckpoint(conf, m); err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Infof("checkpoint: deleted %d old checkpoints", deleted)
+	}
+
+	return deleted, nil
+}
+
+// CheckpointChanges returns the fields of photos and albums that have been changed since a checkpoint was created.
+func CheckpointChanges(conf *config.Config, m entity.Checkpoint) ([]entity.CheckpointChange, error) {
+	data, err := LoadCheckpoint(conf, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := query.CheckpointState()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Diff(current), nil
+}
+
+// RestoreCheckpoint restores the selected fields of photos and albums that have been changed since a checkpoint
+// was created and returns the restored changes. A new checkpoint is created first, so that a restore can be undone.
+func RestoreCheckpoint(conf *config.Config, m entity.Checkpoint, f form.CheckpointRestore) (restored []entity.CheckpointChange, err error) {
+	data, err := LoadCheckpoint(conf, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := query.CheckpointState()
+
+	if err != nil {
+		return nil, err
+	}
+
+	restored = []entity.CheckpointChange{}
+	photoFields := make(map[string]map[string]bool)
+	albumFields := make(map[string]map[string]bool)
+
+	for _, c := range data.Diff(current) {
+		if !checkpointSelected(f, c) {
+			continue
+		}
+
+		switch c.Type {
+		case entity.CheckpointPhoto:
+			if photoFields[c.UID] == nil {
+				photoFields[c.UID] = make(map[string]bool)
+			}
+
+			photoFields[c.UID][c.Field] = true
+		case entity.CheckpointAlbum:
+			if albumFields[c.UID] == nil {
+				albumFields[c.UID] = make(map[string]bool)
+			}
+
+			albumFields[c.UID][c.Field] = true
+		}
+
+		restored = append(restored, c)
+	}
+
+	if len(restored) == 0 {
+		return restored, nil
+	}
+
+	if _, err := CreateCheckpoint(conf, fmt.Sprintf("Before restoring %s", m.CheckpointUID)); err != nil {
+		return nil, err
+	}
+
+	for uid, fields := range albumFields {
+		if err := restoreAlbum(uid, data.Albums[uid], fields); err != nil {
+			return nil, err
+		}
+	}
+
+	for uid, fields := range photoFields {
+		if err := restorePhoto(uid, data.Photos[uid], fields); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Infof("checkpoint: restored %d fields of %d photos and %d albums", len(restored), len(photoFields), len(albumFields))
+
+	return restored, nil
+}
+
+// checkpointSelected returns true if a change matches the photos, albums and fields selected for restore.
+func checkpointSelected(f form.CheckpointRestore, c entity.CheckpointChange) bool {
+	if len(f.Fields) > 0 && !list.Contains(f.Fields, c.Field) {
+		return false
+	}
+
+	if len(f.Photos) == 0 && len(f.Albums) == 0 {
+		return true
+	}
+
+	switch c.Type {
+	case entity.CheckpointPhoto:
+		return list.Contains(f.Photos, c.UID)
+	case entity.CheckpointAlbum:
+		return list.Contains(f.Albums, c.UID)
+	default:
+		return false
+	}
+}
+
+// restoreAlbum restores the title and description of an album.
+func restoreAlbum(uid string, before entity.CheckpointAlbumData, fields map[string]bool) error {
+	a, err := query.AlbumByUID(uid)
+
+	if err != nil {
+		return err
+	}
+
+	if fields[entity.FieldTitle] {
+		a.SetTitle(before.Title)
+	}
+
+	if fields[entity.FieldDescription] {
+		a.AlbumDescription = before.Description
+	}
+
+	return a.Save()
+}
+
+// restorePhoto restores the metadata, labels and album entries of a photo.
+func restorePhoto(uid string, before entity.CheckpointPhotoData, fields map[string]bool) error {
+	if fields[entity.FieldAlbums] {
+		if err := restorePhotoAlbums(uid, before.Albums); err != nil {
+			return err
+		}
+	}
+
+	if fields[entity.FieldLabels] {
+		p, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			return err
+		}
+
+		if err := restorePhotoLabels(p, before.Labels); err != nil {
+			return err
+		}
+	}
+
+	// Load the photo after restoring labels, as they are used to update keywords when saving.
+	p, err := query.PhotoPreloadByUID(uid)
+
+	if err != nil {
+		return err
+	}
+
+	if fields[entity.FieldTitle] {
+		p.PhotoTitle = before.Title
+		p.TitleSrc = before.TitleSrc
+	}
+
+	if fields[entity.FieldDescription] {
+		p.PhotoDescription = before.Description
+		p.DescriptionSrc = before.DescriptionSrc
+	}
+
+	if fields[entity.FieldFavorite] {
+		p.PhotoFavorite = before.Favorite
+	}
+
+	if fields[entity.FieldPrivate] {
+		p.PhotoPrivate = before.Private
+	}
+
+	return p.Save()
+}
+
+// restorePhotoLabels assigns the labels of a photo as saved in a checkpoint. Other labels are removed,
+// manually added labels are deleted and the uncertainty of all others is set to 100.
+func restorePhotoLabels(p entity.Photo, labels []entity.CheckpointLabelData) error {
+	keep := make(map[uint]bool)
+
+	for _, l := range labels {
+		label, err := query.LabelByUID(l.UID)
+
+		if err != nil {
+			log.Warnf("checkpoint: label %s not found", l.UID)
+			continue
+		}
+
+		keep[label.ID] = true
+
+		if pl, err := query.PhotoLabel(p.ID, label.ID); err == nil {
+			if err := pl.Updates(map[string]interface{}{"Uncertainty": l.Uncertainty, "LabelSrc": l.Src}); err != nil {
+				return err
+			}
+		} else if err := entity.NewPhotoLabel(p.ID, label.ID, l.Uncertainty, l.Src).Create(); err != nil {
+			return err
+		}
+	}
+
+	for _, pl := range p.Labels {
+		if keep[pl.LabelID] || pl.Uncertainty >= 100 {
+			continue
+		}
+
+		if pl.LabelSrc == entity.SrcManual {
+			if err := entity.Db().Delete(&pl).Error; err != nil {
+				return err
+			}
+		} else if err := pl.Update("Uncertainty", 100); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restorePhotoAlbums adds a photo to the albums it was in as saved in a checkpoint, and removes it from all others.
+func restorePhotoAlbums(photoUID string, albumUIDs []string) error {
+	var keep []string
+
+	for _, albumUID := range albumUIDs {
+		if _, err := query.AlbumByUID(albumUID); err != nil {
+			log.Warnf("checkpoint: album %s not found", albumUID)
+			continue
+		}
+
+		keep = append(keep, albumUID)
+
+		if entry := entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(photoUID, albumUID)); entry == nil {
+			return fmt.Errorf("checkpoint: can't add %s to album %s", photoUID, albumUID)
+		} else if entry.Hidden {
+			if err := entity.Db().Model(entry).UpdateColumn("Hidden", false).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	s := entity.Db().Where("photo_uid = ? AND hidden = 0", photoUID)
+
+	if len(keep) > 0 {
+		s = s.Where("album_uid NOT IN (?)", keep)
+	}
+
+	return s.Delete(&entity.PhotoAlbum{}).Error
+}
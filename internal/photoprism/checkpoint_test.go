@@ -0,0 +1,97 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCheckpoint(t *testing.T) {
+	conf := config.TestConfig()
+
+	m, err := CreateCheckpoint(conf, "Test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Test", m.CheckpointNote)
+	assert.NotZero(t, m.PhotoCount)
+	assert.FileExists(t, filepath.Join(conf.CheckpointPath(), m.FileName()))
+
+	t.Run("load", func(t *testing.T) {
+		data, err := LoadCheckpoint(conf, *m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Photos, m.PhotoCount)
+		assert.Len(t, data.Albums, m.AlbumCount)
+	})
+	t.Run("changes", func(t *testing.T) {
+		changes, err := CheckpointChanges(conf, *m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, changes)
+	})
+	t.Run("restore unchanged", func(t *testing.T) {
+		restored, err := RestoreCheckpoint(conf, *m, form.CheckpointRestore{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, restored)
+	})
+	t.Run("delete", func(t *testing.T) {
+		if err := DeleteCheckpoint(conf, *m); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := os.Stat(filepath.Join(conf.CheckpointPath(), m.FileName()))
+
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestCheckpointSelected(t *testing.T) {
+	photoTitle := entity.CheckpointChange{Type: entity.CheckpointPhoto, UID: "pt9jtdre2lvl0yh7", Field: entity.FieldTitle}
+	albumTitle := entity.CheckpointChange{Type: entity.CheckpointAlbum, UID: "at9lxuqxpogaaba8", Field: entity.FieldTitle}
+	photoLabels := entity.CheckpointChange{Type: entity.CheckpointPhoto, UID: "pt9jtdre2lvl0yh7", Field: entity.FieldLabels}
+
+	t.Run("all", func(t *testing.T) {
+		f := form.CheckpointRestore{}
+
+		assert.True(t, checkpointSelected(f, photoTitle))
+		assert.True(t, checkpointSelected(f, albumTitle))
+		assert.True(t, checkpointSelected(f, photoLabels))
+	})
+	t.Run("fields", func(t *testing.T) {
+		f := form.CheckpointRestore{Fields: []string{entity.FieldLabels}}
+
+		assert.False(t, checkpointSelected(f, photoTitle))
+		assert.False(t, checkpointSelected(f, albumTitle))
+		assert.True(t, checkpointSelected(f, photoLabels))
+	})
+	t.Run("photos", func(t *testing.T) {
+		f := form.CheckpointRestore{Photos: []string{"pt9jtdre2lvl0yh7"}}
+
+		assert.True(t, checkpointSelected(f, photoTitle))
+		assert.False(t, checkpointSelected(f, albumTitle))
+	})
+	t.Run("albums", func(t *testing.T) {
+		f := form.CheckpointRestore{Albums: []string{"at9lxuqxpogaaba8"}, Fields: []string{entity.FieldTitle}}
+
+		assert.False(t, checkpointSelected(f, photoTitle))
+		assert.True(t, checkpointSelected(f, albumTitle))
+	})
+}
@@ -61,3 +61,33 @@ func (m *MediaFile) Colors(thumbPath string) (perception colors.ColorPerception,
 
 	return perception, nil
 }
+
+// Mono returns true if the image is black-and-white or toned with a single hue (only JPEG supported).
+func (m *MediaFile) Mono(thumbPath string) (bool, error) {
+	if !m.IsJpeg() {
+		return false, errors.New("no chroma information: not a JPEG file")
+	}
+
+	img, err := m.Resample(thumbPath, "tile_224")
+
+	if err != nil {
+		return false, err
+	}
+
+	return colors.Monochrome(img), nil
+}
+
+// Histogram returns the luma histogram of an image (only JPEG supported).
+func (m *MediaFile) Histogram(thumbPath string) (h colors.Histogram, err error) {
+	if !m.IsJpeg() {
+		return h, errors.New("no exposure information: not a JPEG file")
+	}
+
+	img, err := m.Resample(thumbPath, "fit_720")
+
+	if err != nil {
+		return h, err
+	}
+
+	return colors.NewHistogram(img), nil
+}
@@ -181,3 +181,35 @@ func TestMediaFile_Colors(t *testing.T) {
 
 	})
 }
+
+func TestMediaFile_Histogram(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("cat_brown.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := mediaFile.Histogram(conf.ThumbPath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Less(t, uint32(0), h.Total())
+		assert.Len(t, h.Hex(), 16)
+	})
+	t.Run("Random.docx", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.Histogram(conf.ThumbPath())
+
+		assert.EqualError(t, err, "no exposure information: not a JPEG file")
+	})
+}
@@ -14,15 +14,20 @@ import (
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/exiftool"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/sidecar"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // Convert represents a converter that can convert RAW/HEIF images to JPEG.
 type Convert struct {
-	conf     *config.Config
-	cmdMutex sync.Mutex
+	conf      *config.Config
+	cmdMutex  sync.Mutex
+	exifMutex sync.Mutex
+	exifPool  *exiftool.Pool
 }
 
 // NewConvert returns a new converter and expects the config as argument.
@@ -124,7 +129,9 @@ func (c *Convert) ConvertCommand(mf *MediaFile, jpegName string, xmpName string)
 			return nil, useMutex, fmt.Errorf("convert: no raw to jpeg converter installed (%s)", mf.Base(c.conf.Settings().Index.Group))
 		}
 	} else if mf.IsVideo() {
-		result = exec.Command(c.conf.FFmpegBin(), "-i", mf.FileName(), "-ss", "00:00:00.001", "-vframes", "1", jpegName)
+		info := c.VideoInfo(mf)
+		offset, scene := video.PosterOffset(c.conf.VideoPoster(), info.Duration)
+		result = exec.Command(c.conf.FFmpegBin(), video.PosterArgs(mf.FileName(), jpegName, offset, scene, video.Filter(info))...)
 	} else if mf.IsHEIF() {
 		result = exec.Command(c.conf.HeifConvertBin(), mf.FileName(), jpegName)
 	} else {
@@ -134,9 +141,53 @@ func (c *Convert) ConvertCommand(mf *MediaFile, jpegName string, xmpName string)
 	return result, useMutex, nil
 }
 
+// VideoInfo returns the technical properties of a video file. Legacy formats are probed with ffprobe,
+// since camcorder footage is often interlaced or has non-square pixels.
+func (c *Convert) VideoInfo(mf *MediaFile) video.Info {
+	info := video.Info{Duration: mf.MetaData().Duration}
+
+	if !mf.IsLegacyVideo() {
+		return info
+	}
+
+	if probed, err := video.Probe(c.conf.FFprobeBin(), mf.FileName()); err != nil {
+		log.Debugf("convert: %s", err)
+	} else {
+		if probed.Duration == 0 {
+			probed.Duration = info.Duration
+		}
+
+		return probed
+	}
+
+	return info
+}
+
+// ExifTool returns the pool of persistent exiftool processes, one per worker.
+func (c *Convert) ExifTool() *exiftool.Pool {
+	c.exifMutex.Lock()
+	defer c.exifMutex.Unlock()
+
+	if c.exifPool == nil {
+		c.exifPool = exiftool.NewPool(c.conf.ExifToolBin(), c.conf.Workers())
+	}
+
+	return c.exifPool
+}
+
+// Shutdown stops running exiftool processes.
+func (c *Convert) Shutdown() {
+	c.exifMutex.Lock()
+	defer c.exifMutex.Unlock()
+
+	if c.exifPool != nil {
+		c.exifPool.Close()
+	}
+}
+
 // ToJson uses exiftool to export metadata to a json file.
-func (c *Convert) ToJson(mf *MediaFile, hidden bool) (*MediaFile, error) {
-	jsonName := fs.TypeJson.FindSub(mf.FileName(), fs.HiddenPath, c.conf.Settings().Index.Group)
+func (c *Convert) ToJson(mf *MediaFile) (*MediaFile, error) {
+	jsonName := sidecar.Find(mf.FileName(), fs.TypeJson, c.conf.Settings().Index.Group)
 
 	result, err := NewMediaFile(jsonName)
 
@@ -144,39 +195,22 @@ func (c *Convert) ToJson(mf *MediaFile, hidden bool) (*MediaFile, error) {
 		return result, nil
 	}
 
-	if c.conf.ReadOnly() {
-		return nil, fmt.Errorf("convert: metadata export to json disabled in read only mode (%s)", mf.RelativeName(c.conf.OriginalsPath()))
-	}
-
-	if hidden {
-		jsonName = mf.HiddenName(".json", c.conf.Settings().Index.Group)
-	} else {
-		jsonName = mf.RelatedName(".json", c.conf.Settings().Index.Group)
+	if jsonName = sidecar.FileName(mf.FileName(), ".json", c.conf.Settings().Index.Group); jsonName == "" {
+		return nil, fmt.Errorf("convert: metadata export to json disabled for %s", mf.RelativeName(c.conf.OriginalsPath()))
 	}
 
 	fileName := mf.RelativeName(c.conf.OriginalsPath())
 
 	log.Infof("convert: %s -> %s", fileName, fs.RelativeName(jsonName, c.conf.OriginalsPath()))
 
-	cmd := exec.Command(c.conf.ExifToolBin(), "-j", mf.FileName())
+	out, err := c.ExifTool().Extract(mf.FileName())
 
-	// Fetch command output.
-	var out bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-
-	// Run convert command.
-	if err := cmd.Run(); err != nil {
-		if stderr.String() != "" {
-			return nil, errors.New(stderr.String())
-		} else {
-			return nil, err
-		}
+	if err != nil {
+		return nil, err
 	}
 
 	// Write output to file.
-	if err := ioutil.WriteFile(jsonName, []byte(out.String()), os.ModePerm); err != nil {
+	if err := ioutil.WriteFile(jsonName, out, os.ModePerm); err != nil {
 		return nil, err
 	}
 
@@ -233,10 +267,13 @@ func (c *Convert) ToJpeg(image *MediaFile, hidden bool) (*MediaFile, error) {
 		"xmpName":  filepath.Base(xmpName),
 	})
 
-	if image.IsImageOther() {
-		_, err = thumb.Jpeg(image.FileName(), jpegName)
+	return c.convertJpeg(image, jpegName, xmpName)
+}
 
-		if err != nil {
+// convertJpeg converts an image to JPEG using the converter that matches its format.
+func (c *Convert) convertJpeg(image *MediaFile, jpegName, xmpName string) (*MediaFile, error) {
+	if image.IsImageOther() {
+		if _, err := thumb.Jpeg(image.FileName(), jpegName); err != nil {
 			return nil, err
 		}
 
@@ -277,3 +314,64 @@ func (c *Convert) ToJpeg(image *MediaFile, hidden bool) (*MediaFile, error) {
 
 	return NewMediaFile(jpegName)
 }
+
+// ToMP4 transcodes a legacy video file to MP4 in the hidden sidecar folder, so that it can be played in browsers.
+func (c *Convert) ToMP4(mf *MediaFile) (*MediaFile, error) {
+	if c.conf.ReadOnly() {
+		return nil, errors.New("convert: disabled in read-only mode")
+	}
+
+	if !mf.Exists() {
+		return nil, fmt.Errorf("convert: can not transcode video, file does not exist (%s)", mf.RelativeName(c.conf.OriginalsPath()))
+	}
+
+	if mf.IsPlayableVideo() {
+		return mf, nil
+	}
+
+	mp4Name := fs.TypeMP4.FindSub(mf.FileName(), fs.HiddenPath, c.conf.Settings().Index.Group)
+
+	if mediaFile, err := NewMediaFile(mp4Name); err == nil {
+		return mediaFile, nil
+	}
+
+	if c.conf.FFmpegBin() == "" {
+		return nil, fmt.Errorf("convert: ffmpeg not found, can not transcode %s", mf.RelativeName(c.conf.OriginalsPath()))
+	}
+
+	mp4Name = mf.HiddenName(".mp4", c.conf.Settings().Index.Group)
+	tmpName := mp4Name + ".tmp"
+
+	fileName := mf.RelativeName(c.conf.OriginalsPath())
+
+	log.Infof("convert: %s -> %s", fileName, fs.RelativeName(mp4Name, c.conf.OriginalsPath()))
+
+	event.Publish("index.converting", event.Data{
+		"fileType": mf.FileType(),
+		"fileName": fileName,
+		"baseName": filepath.Base(fileName),
+	})
+
+	cmd := exec.Command(c.conf.FFmpegBin(), video.TranscodeArgs(mf.FileName(), tmpName, c.VideoInfo(mf))...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// Write to a temporary file first, so that incomplete videos are never indexed.
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(tmpName)
+
+		if stderr.String() != "" {
+			return nil, errors.New(stderr.String())
+		} else {
+			return nil, err
+		}
+	}
+
+	if err := os.Rename(tmpName, mp4Name); err != nil {
+		_ = os.Remove(tmpName)
+		return nil, err
+	}
+
+	return NewMediaFile(mp4Name)
+}
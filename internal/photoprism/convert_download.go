@@ -0,0 +1,59 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DownloadJpeg returns the name of a JPEG version of an original for download, e.g. for recipients that
+// can't open HEIC or RAW images. Converted files are cached by hash. JPEGs, videos, and other files that
+// can't be converted are returned unchanged, in which case converted is false.
+func (c *Convert) DownloadJpeg(fileName, fileHash string) (result string, converted bool, err error) {
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return fileName, false, err
+	}
+
+	if mf.IsJpeg() || !(mf.IsRaw() || mf.IsHEIF() || mf.IsImageOther()) {
+		return fileName, false, nil
+	}
+
+	if len(fileHash) < 2 {
+		return fileName, false, fmt.Errorf("convert: invalid hash for %s", txt.Quote(filepath.Base(fileName)))
+	}
+
+	dir := filepath.Join(c.conf.ConvertCachePath(), fileHash[0:1], fileHash[1:2])
+	jpegName := filepath.Join(dir, fileHash+fs.JpegExt)
+
+	if fs.FileExists(jpegName) {
+		return jpegName, true, nil
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fileName, false, err
+	}
+
+	log.Infof("convert: %s -> jpeg for download", txt.Quote(filepath.Base(fileName)))
+
+	// Convert to a temporary file first, so that incomplete files are never served from cache.
+	tmpName := filepath.Join(dir, fileHash+"-"+rnd.Token(4)+fs.JpegExt)
+	xmpName := fs.TypeXMP.Find(fileName, c.conf.Settings().Index.Group)
+
+	if _, err := c.convertJpeg(mf, tmpName, xmpName); err != nil {
+		_ = os.Remove(tmpName)
+		return fileName, false, err
+	}
+
+	if err := os.Rename(tmpName, jpegName); err != nil {
+		_ = os.Remove(tmpName)
+		return fileName, false, err
+	}
+
+	return jpegName, true, nil
+}
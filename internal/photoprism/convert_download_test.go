@@ -0,0 +1,70 @@
+package photoprism
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert_DownloadJpeg(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		fileName := conf.ExamplesPath() + "/elephants.jpg"
+
+		result, converted, err := convert.DownloadJpeg(fileName, fs.Hash(fileName))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, converted)
+		assert.Equal(t, fileName, result)
+	})
+
+	t.Run("example.png", func(t *testing.T) {
+		fileName := conf.ExamplesPath() + "/example.png"
+		fileHash := fs.Hash(fileName)
+
+		result, converted, err := convert.DownloadJpeg(fileName, fileHash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(result)
+
+		assert.True(t, converted)
+		assert.True(t, strings.HasPrefix(result, conf.ConvertCachePath()))
+		assert.True(t, strings.HasSuffix(result, fileHash+fs.JpegExt))
+		assert.FileExists(t, result)
+
+		cached, converted, err := convert.DownloadJpeg(fileName, fileHash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, converted)
+		assert.Equal(t, result, cached)
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		fileName := conf.ExamplesPath() + "/example.png"
+
+		result, converted, err := convert.DownloadJpeg(fileName, "a")
+
+		assert.Error(t, err)
+		assert.False(t, converted)
+		assert.Equal(t, fileName, result)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/sidecar"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
@@ -128,7 +129,7 @@ func TestConvert_ToJson(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		jsonFile, err := convert.ToJson(mf, true)
+		jsonFile, err := convert.ToJson(mf)
 
 		if err != nil {
 			t.Fatal(err)
@@ -157,13 +158,16 @@ func TestConvert_ToJson(t *testing.T) {
 		assert.Truef(t, fs.FileExists(fileName), "input file does not exist: %s", fileName)
 		assert.Falsef(t, fs.FileExists(outputName), "output file must not exist: %s", outputName)
 
+		sidecar.Default = sidecar.Inline
+		defer func() { sidecar.Default = conf.SidecarStrategy() }()
+
 		mf, err := NewMediaFile(fileName)
 
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		jsonFile, err := convert.ToJson(mf, false)
+		jsonFile, err := convert.ToJson(mf)
 
 		if err != nil {
 			t.Fatal(err)
@@ -183,6 +187,22 @@ func TestConvert_ToJson(t *testing.T) {
 		_ = os.Remove(outputName)
 	})
 
+	t.Run("disabled", func(t *testing.T) {
+		sidecar.Default = sidecar.None
+		defer func() { sidecar.Default = conf.SidecarStrategy() }()
+
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonFile, err := convert.ToJson(mf)
+
+		assert.Nil(t, jsonFile)
+		assert.EqualError(t, err, "convert: metadata export to json disabled for "+mf.RelativeName(conf.OriginalsPath()))
+	})
+
 	t.Run("iphone_7.heic", func(t *testing.T) {
 		fileName := conf.ExamplesPath() + "/iphone_7.heic"
 		outputName := conf.ExamplesPath() + "/iphone_7.json"
@@ -196,7 +216,7 @@ func TestConvert_ToJson(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		jsonFile, err := convert.ToJson(mf, true)
+		jsonFile, err := convert.ToJson(mf)
 
 		if err != nil {
 			t.Fatal(err)
@@ -0,0 +1,77 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// deviceMutex prevents import workers from creating the album of a device more than once.
+var deviceMutex = sync.Mutex{}
+
+// ApplyDevice attributes an imported photo to the device that uploaded it, and adds it to the album
+// and label named after the device if enabled, e.g. "Anna's iPhone".
+func ApplyDevice(deviceUID string, photo entity.Photo) error {
+	deviceMutex.Lock()
+	defer deviceMutex.Unlock()
+
+	d, err := query.DeviceByUID(deviceUID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := photo.Update("DeviceUID", d.DeviceUID); err != nil {
+		return err
+	}
+
+	if err := d.CountUpload(); err != nil {
+		log.Errorf("device: %s", err)
+	}
+
+	if d.DeviceAlbum {
+		if a, err := deviceAlbum(&d); err != nil {
+			log.Errorf("device: %s (album)", err)
+		} else if entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(photo.PhotoUID, a.AlbumUID)) == nil {
+			log.Errorf("device: could not add %s to album %s", photo.PhotoUID, txt.Quote(a.AlbumTitle))
+		}
+	}
+
+	if d.DeviceLabel {
+		if label := entity.FirstOrCreateLabel(entity.NewLabel(d.DeviceName, 0)); label == nil {
+			log.Errorf("device: could not create label %s", txt.Quote(d.DeviceName))
+		} else if entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(photo.ID, label.ID, 0, entity.SrcDevice)) == nil {
+			log.Errorf("device: could not add label %s to %s", txt.Quote(d.DeviceName), photo.PhotoUID)
+		}
+	}
+
+	return nil
+}
+
+// deviceAlbum returns the album of a device. A manual album with the device name is used or created
+// if the device has no album yet, or if it was deleted.
+func deviceAlbum(d *entity.Device) (a entity.Album, err error) {
+	if d.AlbumUID != "" {
+		if a, err = query.AlbumByUID(d.AlbumUID); err == nil {
+			return a, nil
+		}
+	}
+
+	if a, err = query.AlbumByTitle(d.DeviceName); err != nil {
+		m := entity.NewAlbum(d.DeviceName, entity.TypeDefault)
+
+		if err := m.Create(); err != nil {
+			return a, err
+		}
+
+		log.Infof("device: created album %s", txt.Quote(m.AlbumTitle))
+
+		a = *m
+	}
+
+	d.AlbumUID = a.AlbumUID
+
+	return a, d.Save()
+}
@@ -0,0 +1,64 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDevice(t *testing.T) {
+	d, err := entity.NewDevice(form.Device{Name: "Anna's Test Phone", Album: true, Label: true}, "anna@example.com")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	photo := entity.PhotoFixtures.Get("Photo15")
+
+	if err := ApplyDevice(d.DeviceUID, photo); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := query.DeviceByUID(d.DeviceUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, found.UploadCount)
+	assert.NotEmpty(t, found.AlbumUID)
+	assert.True(t, query.AlbumHasPhoto(found.AlbumUID, photo.PhotoUID))
+
+	result, err := query.PhotoByUID(photo.PhotoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, d.DeviceUID, result.DeviceUID)
+
+	t.Run("same album", func(t *testing.T) {
+		if err := ApplyDevice(d.DeviceUID, entity.PhotoFixtures.Get("Photo16")); err != nil {
+			t.Fatal(err)
+		}
+
+		again, err := query.DeviceByUID(d.DeviceUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, again.UploadCount)
+		assert.Equal(t, found.AlbumUID, again.AlbumUID)
+	})
+	t.Run("unknown device", func(t *testing.T) {
+		assert.Error(t, ApplyDevice("cqzyqhn2m6l3s000", photo))
+	})
+}
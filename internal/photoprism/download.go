@@ -0,0 +1,277 @@
+package photoprism
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DownloadOptions represents limits for downloading remote media files.
+type DownloadOptions struct {
+	Hosts    []string // Allowed host names like "example.com", "*.example.com", or "*" for any host.
+	MaxSize  int64    // Max file size in bytes.
+	Checksum string   // Expected SHA1 hash, not verified if empty.
+	Private  bool     // Allow downloads from loopback and private network addresses, e.g. for tests.
+
+	// DiskCheck is called with the file size before downloading, if not nil, see CheckDiskSpace.
+	DiskCheck func(size int64) error
+}
+
+// downloadTypes maps content types to file extensions for URLs without known extension.
+var downloadTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/tiff":      ".tiff",
+	"image/bmp":       ".bmp",
+	"image/heic":      ".heic",
+	"image/heif":      ".heif",
+	"video/mp4":       ".mp4",
+	"video/quicktime": ".mov",
+	"video/x-msvideo": ".avi",
+}
+
+// privateNetworks contains address ranges that aren't reachable on the internet, see publicIP.
+var privateNetworks = func() (result []*net.IPNet) {
+	for _, cidr := range []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"172.16.0.0/12",
+		"192.0.0.0/24",
+		"192.168.0.0/16",
+		"198.18.0.0/15",
+		"fc00::/7",
+	} {
+		_, network, _ := net.ParseCIDR(cidr)
+		result = append(result, network)
+	}
+
+	return result
+}()
+
+// publicIP returns true if the address is reachable on the internet, so that downloads can't be used to
+// access local services or cloud metadata endpoints like 169.254.169.254.
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// publicDial connects to a public address of the host. Addresses are checked after they were resolved,
+// so that host names pointing to private networks, e.g. with "*" in import-url-hosts, are rejected as well.
+func publicDial(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
+	for _, ip := range ips {
+		if !publicIP(ip.IP) {
+			return nil, fmt.Errorf("download: address of %s is not public", txt.Quote(host))
+		}
+	}
+
+	err = fmt.Errorf("download: can't resolve %s", txt.Quote(host))
+
+	for _, ip := range ips {
+		conn, dialErr := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+
+		if dialErr == nil {
+			return conn, nil
+		}
+
+		err = dialErr
+	}
+
+	return nil, err
+}
+
+// AllowedHost returns true if the host name matches the list of allowed hosts.
+func AllowedHost(host string, hosts []string) bool {
+	host = strings.ToLower(host)
+
+	if host == "" {
+		return false
+	}
+
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+
+		switch {
+		case h == "*":
+			return true
+		case strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:]):
+			return true
+		case h == host:
+			return true
+		}
+	}
+
+	return false
+}
+
+// DownloadMediaFile downloads a remote image or video to the directory and returns the file name.
+func DownloadMediaFile(rawUrl, dir string, opt DownloadOptions) (fileName string, err error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("download: unsupported url scheme %s", txt.Quote(u.Scheme))
+	}
+
+	if !AllowedHost(u.Hostname(), opt.Hosts) {
+		return "", fmt.Errorf("download: host %s not allowed", txt.Quote(u.Hostname()))
+	}
+
+	transport := &http.Transport{DialContext: publicDial}
+
+	if opt.Private {
+		transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second}).DialContext
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Minute,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("download: too many redirects")
+			}
+
+			if !AllowedHost(req.URL.Hostname(), opt.Hosts) {
+				return fmt.Errorf("download: redirect to %s not allowed", txt.Quote(req.URL.Hostname()))
+			}
+
+			return nil
+		},
+	}
+
+	resp, err := client.Get(u.String())
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download: bad status %s", resp.Status)
+	}
+
+	if opt.MaxSize > 0 && resp.ContentLength > opt.MaxSize {
+		return "", fmt.Errorf("download: file size exceeds limit of %d bytes", opt.MaxSize)
+	}
+
+	if opt.DiskCheck != nil {
+		if err := opt.DiskCheck(resp.ContentLength); err != nil {
+			return "", err
+		}
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	if contentType != "" && contentType != "application/octet-stream" &&
+		!strings.HasPrefix(contentType, "image/") && !strings.HasPrefix(contentType, "video/") {
+		return "", fmt.Errorf("download: unsupported content type %s", txt.Quote(contentType))
+	}
+
+	fileName = filepath.Join(dir, downloadName(u, contentType))
+
+	switch fs.GetMediaType(fileName) {
+	case fs.MediaImage, fs.MediaRaw, fs.MediaVideo:
+	default:
+		return "", fmt.Errorf("download: unsupported file type %s", txt.Quote(filepath.Base(fileName)))
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	out, err := os.Create(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	body := io.Reader(resp.Body)
+
+	if opt.MaxSize > 0 {
+		body = io.LimitReader(resp.Body, opt.MaxSize+1)
+	}
+
+	n, err := io.Copy(out, body)
+
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil && opt.MaxSize > 0 && n > opt.MaxSize {
+		err = fmt.Errorf("download: file size exceeds limit of %d bytes", opt.MaxSize)
+	}
+
+	if err == nil && opt.Checksum != "" && !strings.EqualFold(fs.Hash(fileName), strings.TrimSpace(opt.Checksum)) {
+		err = fmt.Errorf("download: checksum of %s doesn't match", txt.Quote(filepath.Base(fileName)))
+	}
+
+	if err != nil {
+		if removeErr := os.Remove(fileName); removeErr != nil {
+			log.Errorf("download: could not delete %s", txt.Quote(fileName))
+		}
+
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// downloadName returns a safe file name for a download, with an extension matching the content type
+// if the url doesn't contain a known extension.
+func downloadName(u *url.URL, contentType string) string {
+	name := path.Base(u.Path)
+
+	if name == "." || name == ".." || name == "/" || strings.HasPrefix(name, ".") {
+		name = "download"
+	}
+
+	if _, ok := fs.FileExt[strings.ToLower(filepath.Ext(name))]; !ok {
+		if ext, ok := downloadTypes[contentType]; ok {
+			name += ext
+		}
+	}
+
+	return name
+}
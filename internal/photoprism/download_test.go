@@ -0,0 +1,158 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowedHost(t *testing.T) {
+	hosts := []string{"example.com", "*.photoprism.org"}
+
+	assert.True(t, AllowedHost("example.com", hosts))
+	assert.True(t, AllowedHost("EXAMPLE.COM", hosts))
+	assert.True(t, AllowedHost("demo.photoprism.org", hosts))
+	assert.False(t, AllowedHost("photoprism.org", hosts))
+	assert.False(t, AllowedHost("evilphotoprism.org", hosts))
+	assert.False(t, AllowedHost("www.example.com", hosts))
+	assert.False(t, AllowedHost("", hosts))
+	assert.True(t, AllowedHost("www.example.com", []string{"*"}))
+	assert.False(t, AllowedHost("example.com", nil))
+}
+
+func TestPublicIP(t *testing.T) {
+	assert.True(t, publicIP(net.ParseIP("203.0.113.7")))
+	assert.True(t, publicIP(net.ParseIP("2a00:1450:4001:82a::200e")))
+	assert.False(t, publicIP(net.ParseIP("127.0.0.1")))
+	assert.False(t, publicIP(net.ParseIP("::1")))
+	assert.False(t, publicIP(net.ParseIP("169.254.169.254")))
+	assert.False(t, publicIP(net.ParseIP("10.1.2.3")))
+	assert.False(t, publicIP(net.ParseIP("172.20.0.1")))
+	assert.False(t, publicIP(net.ParseIP("192.168.178.1")))
+	assert.False(t, publicIP(net.ParseIP("100.100.100.200")))
+	assert.False(t, publicIP(net.ParseIP("0.0.0.0")))
+	assert.False(t, publicIP(net.ParseIP("fd00::1")))
+	assert.False(t, publicIP(net.ParseIP("fe80::1")))
+}
+
+func TestDownloadMediaFile(t *testing.T) {
+	conf := config.TestConfig()
+	exampleFile := filepath.Join(conf.ExamplesPath(), "elephants.jpg")
+	exampleHash := fs.Hash(exampleFile)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/elephants.jpg", "/share/abc":
+			w.Header().Set("Content-Type", "image/jpeg")
+			http.ServeFile(w, r, exampleFile)
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "download")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	opt := DownloadOptions{Hosts: []string{"127.0.0.1"}, MaxSize: 10 * 1024 * 1024, Private: true}
+
+	t.Run("success", func(t *testing.T) {
+		o := opt
+		o.Checksum = exampleHash
+
+		fileName, err := DownloadMediaFile(srv.URL+"/elephants.jpg", dir, o)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(dir, "elephants.jpg"), fileName)
+		assert.Equal(t, exampleHash, fs.Hash(fileName))
+	})
+
+	t.Run("extension from content type", func(t *testing.T) {
+		fileName, err := DownloadMediaFile(srv.URL+"/share/abc", dir, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(dir, "abc.jpg"), fileName)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		o := opt
+		o.Checksum = "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"
+
+		_, err := DownloadMediaFile(srv.URL+"/share/abc", dir, o)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		o := opt
+		o.MaxSize = 1024
+
+		_, err := DownloadMediaFile(srv.URL+"/elephants.jpg", dir, o)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("host not allowed", func(t *testing.T) {
+		o := opt
+		o.Hosts = []string{"example.com"}
+
+		_, err := DownloadMediaFile(srv.URL+"/elephants.jpg", dir, o)
+
+		assert.EqualError(t, err, "download: host 127.0.0.1 not allowed")
+	})
+
+	t.Run("private address", func(t *testing.T) {
+		o := opt
+		o.Hosts = []string{"*"}
+		o.Private = false
+
+		_, err := DownloadMediaFile(srv.URL+"/elephants.jpg", dir, o)
+
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		assert.Contains(t, err.Error(), "download: address of 127.0.0.1 is not public")
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		_, err := DownloadMediaFile(srv.URL+"/page.html", dir, opt)
+
+		assert.EqualError(t, err, "download: unsupported content type text/html")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := DownloadMediaFile(srv.URL+"/missing.jpg", dir, opt)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := DownloadMediaFile("file:///etc/passwd", dir, opt)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,344 @@
+package photoprism
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+var (
+	ErrDuplicateAction = errors.New("duplicates: unknown action")
+	ErrDuplicateGroup  = errors.New("duplicates: at least two photos required")
+	ErrDuplicateUndone = errors.New("duplicates: changes have already been undone")
+)
+
+// duplicatePrimary returns the primary file of a photo, or an empty file if there is none.
+func duplicatePrimary(p entity.Photo) entity.File {
+	for _, f := range p.Files {
+		if f.FilePrimary {
+			return f
+		}
+	}
+
+	return entity.File{}
+}
+
+// SortDuplicates sorts photos by resolution and quality, so that the best photo comes first.
+func SortDuplicates(photos []entity.Photo) {
+	sort.SliceStable(photos, func(i, j int) bool {
+		a, b := photos[i], photos[j]
+
+		if a.PhotoResolution != b.PhotoResolution {
+			return a.PhotoResolution > b.PhotoResolution
+		}
+
+		fa, fb := duplicatePrimary(a), duplicatePrimary(b)
+
+		if pa, pb := fa.FileWidth*fa.FileHeight, fb.FileWidth*fb.FileHeight; pa != pb {
+			return pa > pb
+		}
+
+		if a.PhotoQuality != b.PhotoQuality {
+			return a.PhotoQuality > b.PhotoQuality
+		}
+
+		if fa.FileSize != fb.FileSize {
+			return fa.FileSize > fb.FileSize
+		}
+
+		return a.PhotoUID < b.PhotoUID
+	})
+}
+
+// planDuplicates returns the changes needed to resolve a group of duplicates, the best photo comes first.
+func planDuplicates(action string, photos []entity.Photo) (changes entity.DuplicateChanges, err error) {
+	SortDuplicates(photos)
+
+	best, others := photos[0], photos[1:]
+	changes.Keep = best.PhotoUID
+
+	otherUIDs := make([]string, len(others))
+
+	for i, p := range others {
+		otherUIDs[i] = p.PhotoUID
+	}
+
+	switch action {
+	case entity.DuplicateKeepBest:
+		changes.Archived = otherUIDs
+	case entity.DuplicateMerge:
+		found := make(map[uint]bool)
+
+		for _, l := range best.Labels {
+			found[l.LabelID] = true
+		}
+
+		for _, p := range others {
+			for _, l := range p.Labels {
+				if found[l.LabelID] || l.Label == nil {
+					continue
+				}
+
+				found[l.LabelID] = true
+				changes.Labels = append(changes.Labels, l.Label.LabelUID)
+			}
+		}
+
+		earliest := best
+
+		for _, p := range others {
+			if p.TakenSrc != entity.SrcAuto && p.TakenAt.Before(earliest.TakenAt) {
+				earliest = p
+			}
+		}
+
+		if earliest.PhotoUID != best.PhotoUID {
+			changes.Date = &entity.DuplicateDate{TakenAt: earliest.TakenAt, TakenAtLocal: earliest.TakenAtLocal, TakenSrc: earliest.TakenSrc}
+			changes.PrevDate = &entity.DuplicateDate{TakenAt: best.TakenAt, TakenAtLocal: best.TakenAtLocal, TakenSrc: best.TakenSrc}
+		}
+	case entity.DuplicateLink:
+		changes.Archived = otherUIDs
+
+		if changes.Albums, err = query.DuplicateAlbums(otherUIDs, best.PhotoUID); err != nil {
+			return changes, err
+		}
+
+		links, err := query.LinksByShareUID(otherUIDs)
+
+		if err != nil {
+			return changes, err
+		}
+
+		if len(links) > 0 {
+			changes.Links = make(map[string]string, len(links))
+
+			for _, l := range links {
+				changes.Links[l.LinkToken] = l.ShareUID
+			}
+		}
+	default:
+		return changes, ErrDuplicateAction
+	}
+
+	return changes, nil
+}
+
+// ResolveDuplicates resolves a group of duplicate photos: keep-best archives all but the best photo,
+// merge adds the labels of all photos and the earliest date to the best photo, and link additionally
+// moves albums and share links of the archived photos to the best photo.
+// The changes are returned without applying them if dryRun is true, otherwise an undo record is created.
+func ResolveDuplicates(action string, photoUIDs []string, dryRun bool) (changes entity.DuplicateChanges, res *entity.DuplicateResolution, err error) {
+	if !entity.DuplicateActions[action] {
+		return changes, nil, ErrDuplicateAction
+	}
+
+	photos, err := query.DuplicatePhotos(photoUIDs)
+
+	if err != nil {
+		return changes, nil, err
+	} else if len(photos) < 2 {
+		return changes, nil, ErrDuplicateGroup
+	}
+
+	if changes, err = planDuplicates(action, photos); err != nil || dryRun {
+		return changes, nil, err
+	}
+
+	best := photos[0]
+
+	if res, err = entity.NewDuplicateResolution(action, changes); err != nil {
+		return changes, nil, err
+	}
+
+	// Changes are only applied together with the undo record, so that they can always be reverted.
+	tx := entity.Db().Begin()
+
+	if err := applyDuplicates(tx, best, photos[1:], changes); err != nil {
+		tx.Rollback()
+		return changes, nil, err
+	}
+
+	if err := tx.Create(res).Error; err != nil {
+		tx.Rollback()
+		return changes, nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return changes, nil, err
+	}
+
+	if len(changes.Archived) > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("duplicates: %s", err)
+		}
+	}
+
+	log.Infof("duplicates: resolved %d photos with %s, kept %s", len(photos), action, best.PhotoUID)
+
+	return changes, res, nil
+}
+
+// applyDuplicates applies the changes planned for a group of duplicates within the transaction passed.
+func applyDuplicates(tx *gorm.DB, best entity.Photo, others []entity.Photo, changes entity.DuplicateChanges) error {
+	if len(changes.Labels) > 0 {
+		added := make(map[string]bool, len(changes.Labels))
+
+		for _, uid := range changes.Labels {
+			added[uid] = true
+		}
+
+		for _, p := range others {
+			for _, l := range p.Labels {
+				if l.Label == nil || !added[l.Label.LabelUID] {
+					continue
+				}
+
+				delete(added, l.Label.LabelUID)
+
+				var existing entity.PhotoLabel
+
+				if err := tx.Where("photo_id = ? AND label_id = ?", best.ID, l.LabelID).First(&existing).Error; err == nil {
+					continue
+				} else if !gorm.IsRecordNotFoundError(err) {
+					return err
+				}
+
+				if err := tx.Create(entity.NewPhotoLabel(best.ID, l.LabelID, l.Uncertainty, l.LabelSrc)).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if changes.Date != nil {
+		if err := updateDuplicateDate(tx, best, *changes.Date); err != nil {
+			return err
+		}
+	}
+
+	for _, albumUID := range changes.Albums {
+		var existing entity.PhotoAlbum
+
+		if err := tx.Where("photo_uid = ? AND album_uid = ?", best.PhotoUID, albumUID).First(&existing).Error; err == nil {
+			continue
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		if err := tx.Create(entity.NewPhotoAlbum(best.PhotoUID, albumUID)).Error; err != nil {
+			return err
+		}
+	}
+
+	for token := range changes.Links {
+		if err := tx.Model(&entity.Link{}).Where("link_token = ?", token).UpdateColumn("share_uid", best.PhotoUID).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(changes.Archived) > 0 {
+		if err := tx.Where("photo_uid IN (?)", changes.Archived).Delete(&entity.Photo{}).Error; err != nil {
+			return err
+		}
+
+		// Permalinks of archived duplicates lead to the photo that was kept.
+		for _, uid := range changes.Archived {
+			if err := entity.AddRedirectTx(tx, uid, best.PhotoUID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateDuplicateDate changes the date a photo was taken including year and month.
+func updateDuplicateDate(db *gorm.DB, p entity.Photo, date entity.DuplicateDate) error {
+	p.TakenAt = date.TakenAt
+	p.TakenAtLocal = date.TakenAtLocal
+	p.TakenSrc = date.TakenSrc
+	p.UpdateYearMonth()
+
+	return db.Unscoped().Model(&p).UpdateColumns(map[string]interface{}{
+		"TakenAt":      p.TakenAt,
+		"TakenAtLocal": p.TakenAtLocal,
+		"TakenSrc":     p.TakenSrc,
+		"PhotoYear":    p.PhotoYear,
+		"PhotoMonth":   p.PhotoMonth,
+	}).Error
+}
+
+// UndoDuplicates reverts the changes of a duplicate resolution.
+func UndoDuplicates(uid string) (res entity.DuplicateResolution, err error) {
+	if res, err = query.DuplicateResolutionByUID(uid); err != nil {
+		return res, err
+	} else if res.Undone() {
+		return res, ErrDuplicateUndone
+	}
+
+	changes, err := res.DuplicateChanges()
+
+	if err != nil {
+		return res, err
+	}
+
+	db := entity.UnscopedDb()
+
+	var best entity.Photo
+
+	if err := db.Where("photo_uid = ?", changes.Keep).First(&best).Error; err != nil {
+		return res, err
+	}
+
+	if len(changes.Archived) > 0 {
+		if err := db.Model(&entity.Photo{}).Where("photo_uid IN (?)", changes.Archived).
+			UpdateColumn("deleted_at", gorm.Expr("NULL")).Error; err != nil {
+			return res, err
+		}
+
+		if err := entity.RemoveRedirects(changes.Archived); err != nil {
+			return res, err
+		}
+
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("duplicates: %s", err)
+		}
+	}
+
+	for token, shareUID := range changes.Links {
+		if err := db.Model(&entity.Link{}).Where("link_token = ?", token).UpdateColumn("share_uid", shareUID).Error; err != nil {
+			return res, err
+		}
+	}
+
+	if len(changes.Albums) > 0 {
+		if err := db.Where("photo_uid = ? AND album_uid IN (?)", best.PhotoUID, changes.Albums).
+			Delete(&entity.PhotoAlbum{}).Error; err != nil {
+			return res, err
+		}
+	}
+
+	if changes.PrevDate != nil {
+		if err := updateDuplicateDate(db, best, *changes.PrevDate); err != nil {
+			return res, err
+		}
+	}
+
+	if len(changes.Labels) > 0 {
+		if err := db.Where("photo_id = ? AND label_id IN (SELECT id FROM labels WHERE label_uid IN (?))", best.ID, changes.Labels).
+			Delete(&entity.PhotoLabel{}).Error; err != nil {
+			return res, err
+		}
+	}
+
+	if err := res.SetUndone(); err != nil {
+		return res, err
+	}
+
+	log.Infof("duplicates: undone %s of %s", res.Action, best.PhotoUID)
+
+	return res, nil
+}
@@ -0,0 +1,106 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortDuplicates(t *testing.T) {
+	photos := []entity.Photo{
+		{PhotoUID: "pt9jtdre2lvl0001", PhotoResolution: 2, PhotoQuality: 3,
+			Files: []entity.File{{FilePrimary: true, FileWidth: 1000, FileHeight: 500}}},
+		{PhotoUID: "pt9jtdre2lvl0002", PhotoResolution: 2, PhotoQuality: 3,
+			Files: []entity.File{{FilePrimary: true, FileWidth: 2000, FileHeight: 1000}}},
+		{PhotoUID: "pt9jtdre2lvl0003", PhotoResolution: 1, PhotoQuality: 5},
+		{PhotoUID: "pt9jtdre2lvl0004", PhotoResolution: 2, PhotoQuality: 4,
+			Files: []entity.File{{FilePrimary: true, FileWidth: 1000, FileHeight: 500}}},
+	}
+
+	SortDuplicates(photos)
+
+	assert.Equal(t, "pt9jtdre2lvl0002", photos[0].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0004", photos[1].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0001", photos[2].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0003", photos[3].PhotoUID)
+}
+
+func TestResolveDuplicates(t *testing.T) {
+	group := []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh9"}
+
+	t.Run("unknown action", func(t *testing.T) {
+		_, _, err := ResolveDuplicates("xxx", group, true)
+		assert.Equal(t, ErrDuplicateAction, err)
+	})
+	t.Run("single photo", func(t *testing.T) {
+		_, _, err := ResolveDuplicates(entity.DuplicateKeepBest, group[:1], true)
+		assert.Equal(t, ErrDuplicateGroup, err)
+	})
+	t.Run("merge dry run", func(t *testing.T) {
+		changes, res, err := ResolveDuplicates(entity.DuplicateMerge, group, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, res)
+		assert.Contains(t, group, changes.Keep)
+		assert.Empty(t, changes.Archived)
+		assert.Nil(t, changes.Date)
+	})
+	t.Run("link dry run", func(t *testing.T) {
+		changes, res, err := ResolveDuplicates(entity.DuplicateLink, group, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, res)
+		assert.Len(t, changes.Archived, 1)
+		assert.NotContains(t, changes.Archived, changes.Keep)
+	})
+	t.Run("keep best and undo", func(t *testing.T) {
+		changes, res, err := ResolveDuplicates(entity.DuplicateKeepBest, group, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotNil(t, res) {
+			assert.Equal(t, changes.Keep, res.PhotoUID)
+		}
+
+		if !assert.Len(t, changes.Archived, 1) {
+			t.FailNow()
+		}
+
+		if photos, err := query.DuplicatePhotos(changes.Archived); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Empty(t, photos)
+		}
+
+		assert.Equal(t, changes.Keep, query.RedirectUID(changes.Archived[0]))
+
+		undone, err := UndoDuplicates(res.ResolutionUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, undone.Undone())
+
+		if photos, err := query.DuplicatePhotos(changes.Archived); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, photos, 1)
+		}
+
+		assert.Equal(t, changes.Archived[0], query.RedirectUID(changes.Archived[0]))
+
+		_, err = UndoDuplicates(res.ResolutionUID)
+		assert.Equal(t, ErrDuplicateUndone, err)
+	})
+}
@@ -0,0 +1,417 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Thresholds used to suggest orientation and geometry fixes.
+const (
+	GeometryMinTilt     = 1.0  // Smaller angles are hardly noticeable.
+	GeometryMaxTilt     = 10.0 // Larger angles are usually intended.
+	GeometryMinStrength = 0.2  // Min share of nearly horizontal edges aligned to the horizon.
+	GeometryMinPeople   = 0.5  // Min confidence of people being shown after rotation.
+	GeometryMinGain     = 0.25 // Min confidence gain compared to the current orientation.
+)
+
+// geometryBinSize is the resolution of the edge angle histogram in degrees.
+const geometryBinSize = 0.5
+
+// geometryMinGradient is the min Sobel gradient magnitude of pixels considered as edges.
+const geometryMinGradient = 120.0
+
+var (
+	ErrGeometryFile     = errors.New("geometry: only jpeg originals can be fixed")
+	ErrGeometryReviewed = errors.New("geometry: fix has already been reviewed")
+)
+
+// Geometry represents a worker that detects photos which are rotated sideways or have a tilted
+// horizon, e.g. scans of old prints, and suggests fixes that can be reviewed and applied.
+type Geometry struct {
+	conf       *config.Config
+	tensorFlow *classify.TensorFlow
+	index      *Index
+}
+
+// NewGeometry returns a new geometry worker and expects its dependencies as arguments.
+func NewGeometry(conf *config.Config, tensorFlow *classify.TensorFlow, index *Index) *Geometry {
+	return &Geometry{conf: conf, tensorFlow: tensorFlow, index: index}
+}
+
+// Start checks primary JPEG originals that have not been checked before and returns the number of suggested fixes.
+func (w *Geometry) Start() (suggested int, err error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Errorf("geometry: %s [panic]", err)
+		}
+	}()
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return 0, fmt.Errorf("geometry: %s", err.Error())
+	}
+
+	defer func() {
+		mutex.MainWorker.Stop()
+		runtime.GC()
+	}()
+
+	var lastId uint
+
+	for {
+		if !waitForSchedule(w.conf, "geometry") {
+			return suggested, errors.New("geometry check canceled")
+		}
+
+		files, err := query.GeometryCandidates(lastId, 100)
+
+		if err != nil {
+			return suggested, err
+		}
+
+		if len(files) == 0 {
+			break
+		}
+
+		for _, f := range files {
+			if mutex.MainWorker.Canceled() {
+				return suggested, errors.New("geometry check canceled")
+			}
+
+			lastId = f.ID
+
+			fix, err := w.Check(f)
+
+			if err != nil {
+				log.Errorf("geometry: can't check %s (%s)", txt.Quote(f.FileName), err)
+				continue
+			}
+
+			if fix.Pending() {
+				log.Infof("geometry: suggest to rotate %s by %d° and straighten by %.1f°", txt.Quote(f.FileName), fix.FixRotate, fix.FixTilt)
+				suggested++
+			}
+		}
+	}
+
+	if suggested > 0 {
+		log.Infof("geometry: %d photos suggested for review", suggested)
+	}
+
+	return suggested, nil
+}
+
+// Check detects whether the image of a file is rotated sideways or has a tilted horizon and records the result.
+func (w *Geometry) Check(f entity.File) (*entity.GeometryFix, error) {
+	mf, err := NewMediaFile(filepath.Join(w.conf.OriginalsPath(), f.FileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rotate, rotateScore := 0, 0.0
+
+	if w.tensorFlow != nil && !w.conf.DisableTensorFlow() {
+		tile, err := mf.Resample(w.conf.ThumbPath(), "tile_224")
+
+		if err != nil {
+			return nil, err
+		}
+
+		var scores [4]float64
+
+		for i := range scores {
+			if scores[i], err = w.peopleScore(RotateImage(tile, i*90)); err != nil {
+				return nil, err
+			}
+		}
+
+		rotate, rotateScore = SuggestRotation(scores)
+	}
+
+	img, err := mf.Resample(w.conf.ThumbPath(), "fit_720")
+
+	if err != nil {
+		return nil, err
+	}
+
+	tilt, strength := DetectTilt(img)
+
+	if math.Abs(tilt) < GeometryMinTilt || strength < GeometryMinStrength {
+		tilt, strength = 0, 0
+	}
+
+	fix := entity.NewGeometryFix(f.PhotoUID, f.FileUID, rotate, math.Round(tilt*10)/10, float32(math.Max(rotateScore, strength)))
+
+	if err := fix.Create(); err != nil {
+		return nil, err
+	}
+
+	return fix, nil
+}
+
+// peopleScore returns the confidence of the image showing people.
+func (w *Geometry) peopleScore(img image.Image) (float64, error) {
+	buf := new(bytes.Buffer)
+
+	if err := imaging.Encode(buf, img, imaging.JPEG); err != nil {
+		return 0, err
+	}
+
+	labels, err := w.tensorFlow.Labels(buf.Bytes())
+
+	if err != nil {
+		return 0, err
+	}
+
+	return PeopleScore(labels), nil
+}
+
+// Apply rotates and straightens the original as suggested, indexes it again and marks the fix as applied.
+// The unchanged original is kept as backup in the hidden sidecar folder, see GeometryBackupName.
+func (w *Geometry) Apply(fix *entity.GeometryFix) error {
+	if !fix.Pending() {
+		return ErrGeometryReviewed
+	} else if w.conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	f, err := query.FileByUID(fix.FileUID)
+
+	if err != nil {
+		return err
+	} else if f.FileType != string(fs.TypeJpeg) || f.FileRoot != entity.RootDefault || f.FileCold {
+		return ErrGeometryFile
+	}
+
+	fileName := filepath.Join(w.conf.OriginalsPath(), f.FileName)
+	backupName := GeometryBackupName(fileName)
+
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	// Keep the first backup if fixes are applied repeatedly.
+	if fs.FileExists(backupName) {
+		log.Debugf("geometry: backup %s already exists", txt.Quote(filepath.Base(backupName)))
+	} else if err := os.MkdirAll(filepath.Dir(backupName), os.ModePerm); err != nil {
+		return err
+	} else if err := mf.Copy(backupName); err != nil {
+		return err
+	}
+
+	img, err := imaging.Open(fileName, imaging.AutoOrientation(true))
+
+	if err != nil {
+		return err
+	}
+
+	if err := imaging.Save(FixGeometry(img, fix.FixRotate, fix.FixTilt), fileName, imaging.JPEGQuality(thumb.JpegQuality)); err != nil {
+		return err
+	}
+
+	w.copyMetadata(backupName, fileName)
+
+	if res := w.index.SingleFile(fileName); res.Error != nil {
+		return res.Error
+	}
+
+	log.Infof("geometry: fixed %s, original saved as %s", txt.Quote(f.FileName), txt.Quote(fs.RelativeName(backupName, w.conf.OriginalsPath())))
+
+	return fix.SetStatus(entity.GeometryApplied)
+}
+
+// copyMetadata copies Exif and XMP metadata from the backup to the fixed image if exiftool is installed,
+// as it is lost when the image is saved again. The orientation is reset, since the image is upright now.
+func (w *Geometry) copyMetadata(srcName, destName string) {
+	if w.conf.ExifToolBin() == "" {
+		log.Warnf("geometry: exiftool not found, metadata of %s not kept", txt.Quote(filepath.Base(destName)))
+		return
+	}
+
+	cmd := exec.Command(w.conf.ExifToolBin(), "-q", "-overwrite_original", "-TagsFromFile", srcName, "-all:all",
+		"--ExifImageWidth", "--ExifImageHeight", "--ThumbnailImage", "-Orientation#=1", destName)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		log.Errorf("geometry: can't copy metadata to %s (%s)", txt.Quote(filepath.Base(destName)), err)
+
+		if stderr.String() != "" {
+			log.Debug(stderr.String())
+		}
+	}
+}
+
+// GeometryBackupName returns the file name the unchanged original is saved as before a fix is applied,
+// e.g. ".photoprism/scan.jpg.orig". The extension prevents it from being indexed.
+func GeometryBackupName(fileName string) string {
+	return filepath.Join(filepath.Dir(fileName), fs.HiddenPath, filepath.Base(fileName)+".orig")
+}
+
+// PeopleScore returns the highest confidence of labels showing people, between 0 and 1.
+func PeopleScore(labels classify.Labels) (score float64) {
+	for _, l := range labels {
+		people := l.Name == "people" || l.Name == "portrait"
+
+		for _, c := range l.Categories {
+			if c == "people" || c == "portrait" {
+				people = true
+			}
+		}
+
+		if confidence := float64(100-l.Uncertainty) / 100; people && confidence > score {
+			score = confidence
+		}
+	}
+
+	return score
+}
+
+// SuggestRotation returns the clockwise rotation in degrees and its score, given the confidence of the
+// image showing people when rotated by 0, 90, 180 and 270 degrees. Portraits shot or scanned sideways
+// are hardly recognized, so a rotation is suggested if it makes people clearly more recognizable.
+func SuggestRotation(scores [4]float64) (rotate int, score float64) {
+	best := 0
+
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+
+	if best == 0 || scores[best] < GeometryMinPeople || scores[best]-scores[0] < GeometryMinGain {
+		return 0, 0
+	}
+
+	return best * 90, scores[best]
+}
+
+// DetectTilt returns the angle in degrees the horizon is tilted counter-clockwise and the share of nearly
+// horizontal edges aligned to it. Vertical edges like walls and picture frames are counted as well.
+func DetectTilt(src image.Image) (tilt, strength float64) {
+	img := imaging.Grayscale(imaging.Fit(src, 512, 512, imaging.Linear))
+
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	bins := make([]float64, int(2*GeometryMaxTilt/geometryBinSize)+1)
+
+	lum := func(x, y int) float64 {
+		return float64(img.Pix[y*img.Stride+x*4])
+	}
+
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			gx := lum(x+1, y-1) + 2*lum(x+1, y) + lum(x+1, y+1) - lum(x-1, y-1) - 2*lum(x-1, y) - lum(x-1, y+1)
+			gy := lum(x-1, y+1) + 2*lum(x, y+1) + lum(x+1, y+1) - lum(x-1, y-1) - 2*lum(x, y-1) - lum(x+1, y-1)
+
+			mag := math.Hypot(gx, gy)
+
+			if mag < geometryMinGradient {
+				continue
+			}
+
+			// Edges are perpendicular to the gradient, the y axis points down.
+			angle := math.Mod(90-math.Atan2(gy, gx)*180/math.Pi, 90)
+
+			if angle > 45 {
+				angle -= 90
+			} else if angle <= -45 {
+				angle += 90
+			}
+
+			if math.Abs(angle) > GeometryMaxTilt {
+				continue
+			}
+
+			bins[int(math.Round((angle+GeometryMaxTilt)/geometryBinSize))] += mag
+		}
+	}
+
+	peak, peakSum, total := 0, 0.0, 0.0
+
+	for i := range bins {
+		total += bins[i]
+
+		if sum := geometryWindow(bins, i); sum > peakSum {
+			peak, peakSum = i, sum
+		}
+	}
+
+	if peakSum == 0 {
+		return 0, 0
+	}
+
+	var weighted float64
+
+	for i := peak - 1; i <= peak+1; i++ {
+		if i >= 0 && i < len(bins) {
+			weighted += bins[i] * (float64(i)*geometryBinSize - GeometryMaxTilt)
+		}
+	}
+
+	return weighted / peakSum, peakSum / total
+}
+
+// geometryWindow returns the sum of a histogram bin and its neighbours.
+func geometryWindow(bins []float64, i int) (sum float64) {
+	for j := i - 1; j <= i+1; j++ {
+		if j >= 0 && j < len(bins) {
+			sum += bins[j]
+		}
+	}
+
+	return sum
+}
+
+// RotateImage rotates an image clockwise by 90, 180 or 270 degrees.
+func RotateImage(img image.Image, degrees int) image.Image {
+	switch degrees {
+	case 90:
+		return imaging.Rotate270(img)
+	case 180:
+		return imaging.Rotate180(img)
+	case 270:
+		return imaging.Rotate90(img)
+	default:
+		return img
+	}
+}
+
+// FixGeometry rotates an image clockwise by the given degrees and straightens its horizon if tilted.
+// Straightened images are cropped to the largest area without blank corners, keeping the aspect ratio.
+func FixGeometry(img image.Image, rotate int, tilt float64) image.Image {
+	img = RotateImage(img, rotate)
+
+	if tilt == 0 {
+		return img
+	}
+
+	w, h := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+	a := math.Abs(tilt) * math.Pi / 180
+	sin, cos := math.Sin(a), math.Cos(a)
+	k := math.Min(w/(w*cos+h*sin), h/(w*sin+h*cos))
+
+	rotated := imaging.Rotate(img, -tilt, color.Black)
+
+	return imaging.CropCenter(rotated, int(math.Floor(w*k)), int(math.Floor(h*k)))
+}
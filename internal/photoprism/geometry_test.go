@@ -0,0 +1,143 @@
+package photoprism
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// geometryHorizon returns a landscape with a straight horizon tilted by the given angle.
+func geometryHorizon(angle float64) image.Image {
+	img := imaging.New(800, 600, color.NRGBA{R: 40, G: 60, B: 40, A: 255})
+	img = imaging.Paste(img, imaging.New(800, 300, color.NRGBA{R: 200, G: 220, B: 255, A: 255}), image.Pt(0, 0))
+
+	return imaging.CropCenter(imaging.Rotate(img, angle, color.Black), 500, 300)
+}
+
+func TestDetectTilt(t *testing.T) {
+	t.Run("straight", func(t *testing.T) {
+		tilt, strength := DetectTilt(geometryHorizon(0))
+
+		assert.Equal(t, 0.0, tilt)
+		assert.Equal(t, 1.0, strength)
+	})
+	t.Run("counter-clockwise", func(t *testing.T) {
+		tilt, strength := DetectTilt(geometryHorizon(4))
+
+		assert.InDelta(t, 4.0, tilt, 0.5)
+		assert.Greater(t, strength, GeometryMinStrength)
+	})
+	t.Run("clockwise", func(t *testing.T) {
+		tilt, strength := DetectTilt(geometryHorizon(-3))
+
+		assert.InDelta(t, -3.0, tilt, 0.5)
+		assert.Greater(t, strength, GeometryMinStrength)
+	})
+	t.Run("blank", func(t *testing.T) {
+		tilt, strength := DetectTilt(imaging.New(200, 100, color.White))
+
+		assert.Equal(t, 0.0, tilt)
+		assert.Equal(t, 0.0, strength)
+	})
+	t.Run("example", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		img, err := imaging.Open(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, strength := DetectTilt(img)
+
+		assert.Less(t, strength, GeometryMinStrength)
+	})
+}
+
+func TestFixGeometry(t *testing.T) {
+	t.Run("rotate", func(t *testing.T) {
+		img := imaging.New(300, 200, color.White)
+
+		assert.Equal(t, image.Rect(0, 0, 200, 300), FixGeometry(img, 90, 0).Bounds())
+		assert.Equal(t, image.Rect(0, 0, 300, 200), FixGeometry(img, 180, 0).Bounds())
+	})
+	t.Run("straighten", func(t *testing.T) {
+		result := FixGeometry(geometryHorizon(5), 0, 5)
+
+		assert.InDelta(t, 500.0/300.0, float64(result.Bounds().Dx())/float64(result.Bounds().Dy()), 0.02)
+
+		tilt, _ := DetectTilt(result)
+
+		assert.Less(t, tilt, GeometryMinTilt)
+		assert.Greater(t, tilt, -GeometryMinTilt)
+	})
+}
+
+func TestRotateImage(t *testing.T) {
+	img := imaging.New(2, 1, color.White)
+	img.Set(0, 0, color.Black)
+
+	t.Run("90", func(t *testing.T) {
+		result := imaging.Clone(RotateImage(img, 90))
+
+		assert.Equal(t, image.Rect(0, 0, 1, 2), result.Bounds())
+		assert.Equal(t, color.NRGBA{A: 255}, result.NRGBAAt(0, 0))
+	})
+	t.Run("270", func(t *testing.T) {
+		result := imaging.Clone(RotateImage(img, 270))
+
+		assert.Equal(t, color.NRGBA{A: 255}, result.NRGBAAt(0, 1))
+	})
+}
+
+func TestSuggestRotation(t *testing.T) {
+	t.Run("sideways", func(t *testing.T) {
+		rotate, score := SuggestRotation([4]float64{0.1, 0.2, 0.05, 0.85})
+
+		assert.Equal(t, 270, rotate)
+		assert.Equal(t, 0.85, score)
+	})
+	t.Run("upright", func(t *testing.T) {
+		rotate, _ := SuggestRotation([4]float64{0.7, 0.8, 0.1, 0.2})
+
+		assert.Equal(t, 0, rotate)
+	})
+	t.Run("uncertain", func(t *testing.T) {
+		rotate, _ := SuggestRotation([4]float64{0.1, 0.4, 0.1, 0.2})
+
+		assert.Equal(t, 0, rotate)
+	})
+}
+
+func TestPeopleScore(t *testing.T) {
+	labels := classify.Labels{
+		{Name: "dog", Uncertainty: 10},
+		{Name: "portrait", Uncertainty: 40, Categories: []string{"people"}},
+		{Name: "beach", Uncertainty: 20, Categories: []string{"water"}},
+	}
+
+	assert.Equal(t, 0.6, PeopleScore(labels))
+	assert.Equal(t, 0.0, PeopleScore(classify.Labels{}))
+}
+
+func TestGeometryBackupName(t *testing.T) {
+	assert.Equal(t, "/photos/2020/.photoprism/scan.jpg.orig", GeometryBackupName("/photos/2020/scan.jpg"))
+}
+
+func TestGeometry_Apply(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewGeometry(conf, nil, nil)
+
+	fix := entity.NewGeometryFix("pt9jtdre2lvl0yh7", "ft8es39w45bnlqdw", 90, 0, 0.8)
+	fix.FixStatus = entity.GeometryDismissed
+
+	assert.Equal(t, ErrGeometryReviewed, worker.Apply(fix))
+}
@@ -0,0 +1,110 @@
+package photoprism
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Score bonuses and diversity penalties used to pick album highlights.
+const (
+	HighlightFavoriteBonus = 2.0
+	HighlightPeopleBonus   = 1.5
+	HighlightBurstPenalty  = 3.0
+	HighlightHourPenalty   = 1.5
+	HighlightDayPenalty    = 0.5
+	HighlightPlacePenalty  = 1.0
+)
+
+// highlightScore returns the base score of a highlight candidate.
+func highlightScore(c query.HighlightCandidate) (score float64) {
+	score = float64(c.PhotoQuality)
+
+	if c.PhotoFavorite {
+		score += HighlightFavoriteBonus
+	}
+
+	if c.PhotoPeople {
+		score += HighlightPeopleBonus
+	}
+
+	return score
+}
+
+// highlightPenalty returns how similar a candidate is to an already selected photo.
+func highlightPenalty(c, selected query.HighlightCandidate) (penalty float64) {
+	diff := c.TakenAt.Sub(selected.TakenAt)
+
+	if diff < 0 {
+		diff = -diff
+	}
+
+	switch {
+	case diff < 10*time.Minute:
+		penalty += HighlightBurstPenalty
+	case diff < time.Hour:
+		penalty += HighlightHourPenalty
+	case diff < 24*time.Hour:
+		penalty += HighlightDayPenalty
+	}
+
+	if c.PlaceUID != "" && c.PlaceUID != "zz" && c.PlaceUID == selected.PlaceUID {
+		penalty += HighlightPlacePenalty
+	} else if c.PhotoLat != 0 && c.PhotoLng != 0 &&
+		math.Abs(float64(c.PhotoLat-selected.PhotoLat)) < 0.01 &&
+		math.Abs(float64(c.PhotoLng-selected.PhotoLng)) < 0.01 {
+		penalty += HighlightPlacePenalty
+	}
+
+	return penalty
+}
+
+// Highlights picks up to count photos with the best quality, favorites and people while
+// avoiding photos that were taken at a similar time or location. Results are sorted by date.
+func Highlights(candidates query.HighlightCandidates, count int) (results query.HighlightCandidates) {
+	if count <= 0 || len(candidates) == 0 {
+		return query.HighlightCandidates{}
+	}
+
+	if count >= len(candidates) {
+		results = append(results, candidates...)
+	} else {
+		scores := make([]float64, len(candidates))
+		picked := make([]bool, len(candidates))
+
+		for i, c := range candidates {
+			scores[i] = highlightScore(c)
+		}
+
+		for len(results) < count {
+			best := -1
+
+			for i := range candidates {
+				if picked[i] {
+					continue
+				}
+
+				if best < 0 || scores[i] > scores[best] {
+					best = i
+				}
+			}
+
+			picked[best] = true
+			results = append(results, candidates[best])
+
+			for i, c := range candidates {
+				if !picked[i] {
+					scores[i] -= highlightPenalty(c, candidates[best])
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].TakenAt.Before(results[j].TakenAt)
+	})
+
+	return results
+}
@@ -0,0 +1,42 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlights(t *testing.T) {
+	day := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	candidates := query.HighlightCandidates{
+		{PhotoUID: "burst1", TakenAt: day, PhotoQuality: 5, PlaceUID: "de:berlin"},
+		{PhotoUID: "burst2", TakenAt: day.Add(time.Minute), PhotoQuality: 5, PlaceUID: "de:berlin"},
+		{PhotoUID: "burst3", TakenAt: day.Add(2 * time.Minute), PhotoQuality: 5, PlaceUID: "de:berlin"},
+		{PhotoUID: "people", TakenAt: day.Add(48 * time.Hour), PhotoQuality: 3, PhotoPeople: true},
+		{PhotoUID: "favorite", TakenAt: day.Add(-48 * time.Hour), PhotoQuality: 4, PhotoFavorite: true},
+		{PhotoUID: "poor", TakenAt: day.Add(96 * time.Hour), PhotoQuality: 1},
+	}
+
+	t.Run("diverse", func(t *testing.T) {
+		results := Highlights(candidates, 3)
+
+		assert.Len(t, results, 3)
+		assert.Equal(t, "favorite", results[0].PhotoUID)
+		assert.Equal(t, "burst1", results[1].PhotoUID)
+		assert.Equal(t, "people", results[2].PhotoUID)
+	})
+	t.Run("all", func(t *testing.T) {
+		results := Highlights(candidates, 10)
+
+		assert.Len(t, results, 6)
+		assert.Equal(t, "favorite", results[0].PhotoUID)
+		assert.Equal(t, "poor", results[5].PhotoUID)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Highlights(candidates, 0))
+		assert.Empty(t, Highlights(nil, 5))
+	})
+}
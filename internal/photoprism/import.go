@@ -58,6 +58,11 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 		return done
 	}
 
+	if err := CheckDiskSpace(imp.conf, PathSize(importPath)); err != nil {
+		log.Errorf("import: %s", err)
+		return done
+	}
+
 	if err := mutex.MainWorker.Start(); err != nil {
 		event.Error(fmt.Sprintf("import: %s", err.Error()))
 		return done
@@ -84,8 +89,17 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 	}
 
 	indexOpt := IndexOptionsAll()
+	rules := imp.albumRules()
+	added := NewAlbumRuleCounts()
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
+	// Imports started by users don't wait for the index schedule, as they may be part of a request.
+	var schedule config.Schedule
+
+	if opt.Scheduled {
+		schedule = imp.conf.IndexSchedule()
+	}
+
 	if err := ignore.Dir(importPath); err != nil {
 		log.Infof("import: %s", err)
 	}
@@ -102,7 +116,7 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 				}
 			}()
 
-			if mutex.MainWorker.Canceled() {
+			if !waitForWindow(schedule, "import") || !waitForResources(imp.conf, "import") {
 				return errors.New("import canceled")
 			}
 
@@ -161,6 +175,8 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 				Related:   related,
 				IndexOpt:  indexOpt,
 				ImportOpt: opt,
+				Rules:     rules,
+				Added:     added,
 				Imp:       imp,
 			}
 
@@ -173,6 +189,8 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 	close(jobs)
 	wg.Wait()
 
+	added.Log(opt.Uploader)
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
@@ -232,7 +250,7 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 	if !mediaFile.IsSidecar() {
 		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil {
 			existingFilename := filepath.Join(imp.conf.OriginalsPath(), f.FileName)
-			if fs.FileExists(existingFilename) {
+			if f.FileCold || fs.FileExists(existingFilename) {
 				return existingFilename, fmt.Errorf("%s is identical to %s (sha1 %s)", txt.Quote(filepath.Base(mediaFile.FileName())), txt.Quote(f.FileName), mediaFile.Hash())
 			} else {
 				return existingFilename, nil
@@ -6,6 +6,9 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	Uploader               string // Matched against album rules, e.g. the email of the current user.
+	Device                 string // UID of the registered device that uploaded the files, see ApplyDevice.
+	Scheduled              bool   // Wait for the index schedule, e.g. for imports by background workers.
 }
 
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
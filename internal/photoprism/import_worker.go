@@ -5,7 +5,9 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -15,6 +17,8 @@ type ImportJob struct {
 	Related   RelatedFiles
 	IndexOpt  IndexOptions
 	ImportOpt ImportOptions
+	Rules     entity.AlbumRules
+	Added     *AlbumRuleCounts
 	Imp       *Import
 }
 
@@ -33,6 +37,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 		}
 
 		originalName := related.Main.RelativeName(importPath)
+		folder := filepath.Dir(related.Main.RelativeName(imp.conf.ImportPath()))
 
 		event.Publish("import.file", event.Data{
 			"fileName": originalName,
@@ -102,8 +107,16 @@ func ImportWorker(jobs <-chan ImportJob) {
 				}
 			}
 
+			if f.IsLegacyVideo() && !f.HasPlayableVideo() {
+				if mp4File, err := imp.convert.ToMP4(f); err != nil {
+					log.Errorf("import: transcoding video failed (%s)", err.Error())
+				} else {
+					log.Infof("import: %s created", fs.RelativeName(mp4File.FileName(), imp.originalsPath()))
+				}
+			}
+
 			if imp.conf.SidecarJson() && !f.HasJson() {
-				if jsonFile, err := imp.convert.ToJson(f, imp.conf.SidecarHidden()); err != nil {
+				if jsonFile, err := imp.convert.ToJson(f); err != nil {
 					log.Errorf("import: creating json sidecar file failed (%s)", err.Error())
 				} else {
 					log.Infof("import: %s created", fs.RelativeName(jsonFile.FileName(), imp.originalsPath()))
@@ -131,6 +144,28 @@ func ImportWorker(jobs <-chan ImportJob) {
 				res := ind.MediaFile(related.Main, indexOpt, originalName)
 				log.Infof("import: %s main %s file %s", res, related.Main.FileType(), txt.Quote(related.Main.RelativeName(ind.originalsPath())))
 				done[related.Main.FileName()] = true
+
+				if res.PhotoUID != "" && len(job.Rules) > 0 {
+					if photo, err := query.PhotoByUID(res.PhotoUID); err != nil {
+						log.Errorf("import: %s (album rules)", err)
+					} else if photo.HasCategory() && imp.conf.ExcludeCategories() {
+						log.Debugf("import: %s is a %s, skipped album rules", txt.Quote(related.Main.RelativeName(ind.originalsPath())), photo.PhotoCategory)
+					} else if albums := ApplyAlbumRules(job.Rules, photo, folder, opt.Uploader); len(albums) > 0 {
+						if job.Added != nil {
+							job.Added.Add(albums)
+						}
+
+						log.Infof("import: added %s to %d albums", txt.Quote(related.Main.RelativeName(ind.originalsPath())), len(albums))
+					}
+				}
+
+				if res.PhotoUID != "" && opt.Device != "" {
+					if photo, err := query.PhotoByUID(res.PhotoUID); err != nil {
+						log.Errorf("import: %s (device)", err)
+					} else if err := ApplyDevice(opt.Device, photo); err != nil {
+						log.Errorf("import: %s (device)", err)
+					}
+				}
 			} else {
 				log.Warnf("import: no main file for %s (conversion to jpeg failed?)", fs.RelativeName(destinationMainFilename, imp.originalsPath()))
 			}
@@ -57,6 +57,21 @@ func (ind *Index) Cancel() {
 	mutex.MainWorker.Cancel()
 }
 
+// Pause suspends the current indexing or import operation until it is resumed.
+func (ind *Index) Pause() error {
+	return mutex.MainWorker.Pause()
+}
+
+// Resume continues a paused indexing or import operation.
+func (ind *Index) Resume() {
+	mutex.MainWorker.Resume()
+}
+
+// Paused returns true if the current indexing or import operation is paused.
+func (ind *Index) Paused() bool {
+	return mutex.MainWorker.Paused()
+}
+
 // Start indexes media files in the originals directory.
 func (ind *Index) Start(opt IndexOptions) map[string]bool {
 	done := make(map[string]bool)
@@ -81,6 +96,13 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 		return done
 	}
 
+	// Indexing started by users doesn't wait for the index schedule, as it may be part of a request.
+	var schedule config.Schedule
+
+	if opt.Scheduled {
+		schedule = ind.conf.IndexSchedule()
+	}
+
 	jobs := make(chan IndexJob)
 
 	// Start a fixed number of goroutines to index files.
@@ -112,7 +134,7 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 				}
 			}()
 
-			if mutex.MainWorker.Canceled() {
+			if !waitForWindow(schedule, "index") || !waitForResources(ind.conf, "index") {
 				return errors.New("indexing canceled")
 			}
 
@@ -190,3 +212,45 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 
 	return done
 }
+
+// SingleFile indexes a single original and its related files again, even if they didn't change.
+func (ind *Index) SingleFile(fileName string) (result IndexResult) {
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		result.Status = IndexFailed
+		result.Error = err
+		return result
+	}
+
+	related, err := mf.RelatedFiles(ind.conf.Settings().Index.Group)
+
+	if err != nil {
+		result.Status = IndexFailed
+		result.Error = err
+		return result
+	}
+
+	if related.Main == nil {
+		result.Status = IndexFailed
+		result.Error = fmt.Errorf("index: no media file found for %s", txt.Quote(fs.RelativeName(fileName, ind.originalsPath())))
+		return result
+	}
+
+	opt := IndexOptionsNone()
+	opt.Rescan = true
+
+	result = ind.MediaFile(related.Main, opt, "")
+
+	for _, f := range related.Files {
+		if f.FileName() == related.Main.FileName() {
+			continue
+		}
+
+		res := ind.MediaFile(f, opt, "")
+
+		log.Debugf("index: %s related %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
+	}
+
+	return result
+}
@@ -0,0 +1,55 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// saveError records an indexing failure, so that it can be reviewed and retried later.
+func (ind *Index) saveError(m *MediaFile, kind string, err error) {
+	fileName := m.RelativeName(ind.originalsPath())
+
+	if err := entity.SaveIndexError(fileName, kind, err.Error()); err != nil {
+		log.Errorf("index: %s (save error for %s)", err, txt.Quote(fileName))
+	}
+}
+
+// clearError removes a previously recorded failure after the file was indexed successfully.
+func (ind *Index) clearError(m *MediaFile) {
+	fileName := m.RelativeName(ind.originalsPath())
+
+	if err := entity.ClearIndexError(fileName); err != nil {
+		log.Errorf("index: %s (clear error for %s)", err, txt.Quote(fileName))
+	}
+}
+
+// Retry indexes a file that failed before, including conversion if enabled, and returns the result of the
+// main file. Failures are recorded again, the error is removed on success.
+func (ind *Index) Retry(fileName string) (result IndexResult) {
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		result.Status = IndexFailed
+		result.Error = err
+		return result
+	}
+
+	related, err := mf.RelatedFiles(ind.conf.Settings().Index.Group)
+
+	if err != nil {
+		result.Status = IndexFailed
+		result.Error = err
+		return result
+	}
+
+	opt := IndexOptionsNone()
+	opt.Rescan = true
+	opt.Convert = ind.conf.Settings().Index.Convert && !ind.conf.ReadOnly()
+
+	return IndexMain(IndexJob{
+		FileName: mf.FileName(),
+		Related:  related,
+		IndexOpt: opt,
+		Ind:      ind,
+	})
+}
@@ -10,11 +10,15 @@ import (
 
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/semantic"
+	"github.com/photoprism/photoprism/internal/sidecar"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -95,7 +99,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		fileQuery = ind.db.Unscoped().First(&file, "file_hash = ?", fileHash)
 		fileExists = fileQuery.Error == nil
 
-		if fileExists && fs.FileExists(filepath.Join(ind.conf.OriginalsPath(), file.FileName)) {
+		if fileExists && (file.FileCold || fs.FileExists(filepath.Join(ind.conf.OriginalsPath(), file.FileName))) {
 			result.Status = IndexDuplicate
 			return result
 		}
@@ -136,6 +140,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	if photoExists {
 		ind.db.Model(&photo).Related(&description)
+		photo.Details = description
 	} else {
 		photo.PhotoQuality = -1
 	}
@@ -180,6 +185,22 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			file.FileChroma = p.Chroma.Value()
 		}
 
+		// Black-and-white detection
+		if mono, err := m.Mono(ind.thumbPath()); err != nil {
+			log.Errorf("index: %s for %s", err.Error(), quotedName)
+		} else {
+			file.FileMono = mono
+		}
+
+		// Exposure information
+		if h, err := m.Histogram(ind.thumbPath()); err != nil {
+			log.Errorf("index: %s for %s", err.Error(), quotedName)
+		} else {
+			file.FileHistogram = h.Hex()
+			file.FileUnder = h.Under()
+			file.FileOver = h.Over()
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -198,17 +219,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.SetTitle(data.Title, entity.SrcXmp)
 			photo.SetDescription(data.Description, entity.SrcXmp)
 
-			if photo.Details.NoNotes() && data.Comment != "" {
-				photo.Details.Notes = data.Comment
-			}
-
-			if photo.Details.NoArtist() && data.Artist != "" {
-				photo.Details.Artist = data.Artist
-			}
-
-			if photo.Details.NoCopyright() && data.Copyright != "" {
-				photo.Details.Copyright = data.Copyright
-			}
+			photo.Details.SetNotes(data.Comment, entity.SrcXmp)
+			photo.Details.SetArtist(data.Artist, entity.SrcXmp)
+			photo.Details.SetCopyright(data.Copyright, entity.SrcXmp)
 		}
 	case m.IsRaw():
 		if photo.PhotoType == entity.TypeImage {
@@ -217,6 +230,22 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	case m.IsVideo():
 		metaData = m.MetaData()
 
+		// Use ffprobe if exiftool can't read the video stream properties, or the video may have
+		// non-square pixels like camcorder footage.
+		if metaData.Duration == 0 || metaData.Width == 0 || metaData.Codec == "" || m.IsLegacyVideo() {
+			if info, err := video.Probe(ind.conf.FFprobeBin(), m.FileName()); err != nil {
+				log.Debugf("index: %s", err)
+			} else {
+				if info.Duration > 0 {
+					metaData.Duration = info.Duration
+				}
+
+				metaData.Codec = info.Codec
+				metaData.Width = info.Width
+				metaData.Height = info.Height
+			}
+		}
+
 		file.FileCodec = metaData.Codec
 		file.FileWidth = metaData.Width
 		file.FileHeight = metaData.Height
@@ -245,14 +274,23 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			file.FileDiff = primaryFile.FileDiff
 			file.FileMainColor = primaryFile.FileMainColor
 			file.FileChroma = primaryFile.FileChroma
+			file.FileMono = primaryFile.FileMono
 			file.FileLuminance = primaryFile.FileLuminance
 			file.FileColors = primaryFile.FileColors
 		}
+
+		if primaryFile.FileHistogram != "" {
+			file.FileHistogram = primaryFile.FileHistogram
+			file.FileUnder = primaryFile.FileUnder
+			file.FileOver = primaryFile.FileOver
+		}
 	}
 
-	// file obviously exists: remove deleted and missing flags
+	// file obviously exists: remove deleted, missing and cold storage flags
 	file.DeletedAt = nil
 	file.FileMissing = false
+	file.MissingSince = nil
+	file.FileCold = false
 	file.FileError = ""
 
 	// primary files are used for rendering thumbnails and image classification (plus sidecar files if they exist)
@@ -263,8 +301,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			// Image classification via TensorFlow.
 			labels = ind.classifyImage(m)
 
-			if !photoExists && ind.conf.Settings().Features.Private && ind.conf.DetectNSFW() {
-				photo.PhotoPrivate = ind.NSFW(m)
+			if !photoExists && ind.conf.DetectNSFW() {
+				if labels, ok := ind.NSFW(m); ok {
+					ind.applyNSFWPolicy(&photo, labels)
+				}
 			}
 		}
 
@@ -274,25 +314,19 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetDirection(metaData.Direction, metaData.Speed, entity.SrcMeta)
 
-			if photo.Details.NoNotes() {
-				photo.Details.Notes = metaData.Comment
-			}
-
-			if photo.Details.NoSubject() {
-				photo.Details.Subject = metaData.Subject
-			}
+			photo.Details.SetNotes(metaData.Comment, entity.SrcMeta)
+			photo.Details.SetSubject(metaData.Subject, entity.SrcMeta)
 
 			if photo.Details.NoKeywords() {
 				photo.Details.Keywords = metaData.Keywords
 			}
 
-			if photo.Details.NoArtist() && metaData.Artist != "" {
-				photo.Details.Artist = metaData.Artist
-			}
-
-			if photo.Details.NoArtist() && metaData.CameraOwner != "" {
-				photo.Details.Artist = metaData.CameraOwner
+			if metaData.Artist != "" {
+				photo.Details.SetArtist(metaData.Artist, entity.SrcMeta)
+			} else {
+				photo.Details.SetArtist(metaData.CameraOwner, entity.SrcMeta)
 			}
 
 			if photo.NoCameraSerial() {
@@ -336,6 +370,19 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.PhotoExposure = m.Exposure()
 		}
 
+		photo.SetCategory(Category(CategoryInput{
+			FileName:    m.FileName(),
+			FileType:    originalType(m),
+			Width:       m.Width(),
+			Height:      m.Height(),
+			CameraMake:  m.CameraMake(),
+			CameraModel: m.CameraModel(),
+			UserComment: m.MetaData().All["UserComment"],
+			MainColor:   file.FileMainColor,
+			Chroma:      int(file.FileChroma),
+			Labels:      labels,
+		}), entity.SrcAuto)
+
 		if photo.TakenAt.IsZero() || photo.TakenAtLocal.IsZero() {
 			takenUtc, takenSrc := m.TakenAt()
 			photo.SetTakenAt(takenUtc, takenUtc, "", takenSrc)
@@ -391,7 +438,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			return result
 		}
 	} else {
-		if yamlName := fs.TypeYaml.FindSub(m.FileName(), fs.HiddenPath, ind.conf.Settings().Index.Group); yamlName != "" {
+		if yamlName := sidecar.Find(m.FileName(), fs.TypeYaml, ind.conf.Settings().Index.Group); yamlName != "" {
 			if err := photo.LoadFromYaml(yamlName); err != nil {
 				log.Errorf("index: %s (restore from yaml) for %s", err.Error(), quotedName)
 			} else {
@@ -432,6 +479,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	file.PhotoID = photo.ID
 	result.PhotoID = photo.ID
 
+	// Files of photos that were removed from the index get a new photo, permalinks lead to it instead.
+	if !photoExists && file.PhotoUID != "" && file.PhotoUID != photo.PhotoUID {
+		if err := entity.AddRedirect(file.PhotoUID, photo.PhotoUID); err != nil {
+			log.Errorf("index: %s for %s", err, quotedName)
+		}
+	}
+
 	file.PhotoUID = photo.PhotoUID
 	result.PhotoUID = photo.PhotoUID
 
@@ -522,6 +576,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		}
 	}
 
+	// Update embedding for natural language search (optional).
+	if file.FilePrimary && m.IsJpeg() && semantic.Enabled() && (fileChanged || !semantic.Default.Contains(photo.PhotoUID)) {
+		if err := SemanticIndex(m, ind.thumbPath(), photo.PhotoUID); err != nil {
+			log.Errorf("index: %s for %s", err, quotedName)
+		}
+	}
+
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
@@ -537,9 +598,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	// Write YAML sidecar file (optional).
 	if file.FilePrimary && ind.conf.SidecarYaml() {
-		yamlFile := photo.YamlFileName(ind.originalsPath(), ind.conf.SidecarHidden())
+		yamlFile := photo.YamlFileName(ind.originalsPath())
 
-		if err := photo.SaveAsYaml(yamlFile); err != nil {
+		if yamlFile == "" {
+			log.Debugf("index: yaml sidecar disabled for %s", quotedName)
+		} else if err := photo.SaveAsYaml(yamlFile); err != nil {
 			log.Errorf("index: %s (update yaml) for %s", err.Error(), quotedName)
 		} else {
 			log.Infof("index: updated yaml file %s", txt.Quote(fs.RelativeName(yamlFile, ind.originalsPath())))
@@ -549,26 +612,48 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	return result
 }
 
-// NSFW returns true if media file might be offensive and detection is enabled.
-func (ind *Index) NSFW(jpeg *MediaFile) bool {
+// NSFW returns the labels of the NSFW detector, ok is false if the file could not be checked.
+func (ind *Index) NSFW(jpeg *MediaFile) (labels nsfw.Labels, ok bool) {
 	filename, err := jpeg.Thumbnail(ind.thumbPath(), "fit_720")
 
 	if err != nil {
 		log.Error(err)
-		return false
+		return labels, false
 	}
 
-	if nsfwLabels, err := ind.nsfwDetector.File(filename); err != nil {
+	if labels, err = ind.nsfwDetector.File(filename); err != nil {
 		log.Error(err)
-		return false
-	} else {
-		if nsfwLabels.NSFW(nsfw.ThresholdHigh) {
-			log.Warnf("index: %s might contain offensive content", txt.Quote(jpeg.RelativeName(ind.originalsPath())))
-			return true
-		}
+		return labels, false
+	}
+
+	if labels.NSFW(nsfw.ThresholdHigh) {
+		log.Warnf("index: %s might contain offensive content", txt.Quote(jpeg.RelativeName(ind.originalsPath())))
 	}
 
-	return false
+	return labels, true
+}
+
+// applyNSFWPolicy stores the NSFW score and flags a new photo according to the NSFW policy if it might be offensive.
+func (ind *Index) applyNSFWPolicy(photo *entity.Photo, labels nsfw.Labels) {
+	photo.PhotoNSFW = labels.Score()
+
+	if !labels.NSFW(nsfw.ThresholdHigh) {
+		return
+	}
+
+	features := ind.conf.Settings().Features
+
+	switch ind.conf.NSFWPolicy() {
+	case config.NSFWPrivate:
+		photo.PhotoPrivate = features.Private
+	case config.NSFWArchive:
+		if features.Archive {
+			now := time.Now()
+			photo.DeletedAt = &now
+		}
+	case config.NSFWReview:
+		photo.NSFWReview = features.Review
+	}
 }
 
 // classifyImage returns all matching labels for a media file.
@@ -1,9 +1,10 @@
 package photoprism
 
 type IndexOptions struct {
-	Path    string
-	Rescan  bool
-	Convert bool
+	Path      string
+	Rescan    bool
+	Convert   bool
+	Scheduled bool // Wait for the index schedule, e.g. for indexing by background workers.
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
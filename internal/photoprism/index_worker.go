@@ -1,8 +1,11 @@
 package photoprism
 
 import (
+	"fmt"
 	"path/filepath"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -17,77 +20,130 @@ type IndexJob struct {
 
 func IndexWorker(jobs <-chan IndexJob) {
 	for job := range jobs {
-		done := make(map[string]bool)
-		related := job.Related
-		opt := job.IndexOpt
-		ind := job.Ind
-
-		// Skip sidecar files without related media file.
-		if related.Main == nil {
-			log.Warnf("index: no media file found for %s", txt.Quote(fs.RelativeName(job.FileName, ind.originalsPath())))
-			continue
+		IndexMain(job)
+	}
+}
+
+// IndexMain indexes the main file of a job and its related files. Failures are recorded as index errors,
+// which are removed once a file was indexed successfully.
+func IndexMain(job IndexJob) (result IndexResult) {
+	done := make(map[string]bool)
+	related := job.Related
+	opt := job.IndexOpt
+	ind := job.Ind
+
+	// Skip sidecar files without related media file.
+	if related.Main == nil {
+		result.Status = IndexFailed
+		result.Error = fmt.Errorf("index: no media file found for %s", txt.Quote(fs.RelativeName(job.FileName, ind.originalsPath())))
+		log.Warn(result.Error)
+		return result
+	}
+
+	// Enforce file size limit for originals.
+	if ind.conf.OriginalsLimit() > 0 && related.Main.FileSize() > ind.conf.OriginalsLimit() {
+		log.Warnf("index: %s exceeds file size limit for originals [%d / %d MB]", filepath.Base(related.Main.FileName()), related.Main.FileSize()/(1024*1024), ind.conf.OriginalsLimit()/(1024*1024))
+		result.Status = IndexSkipped
+		return result
+	}
+
+	f := related.Main
+	failed := false
+
+	if opt.Convert && !f.HasJpeg() {
+		if jpegFile, err := ind.convert.ToJpeg(f, ind.conf.JpegHidden()); err != nil {
+			log.Errorf("index: creating jpeg failed (%s)", err.Error())
+			ind.saveError(f, entity.IndexErrorConvert, err)
+			result.Status = IndexFailed
+			result.Error = err
+			return result
+		} else {
+			log.Infof("index: %s created", fs.RelativeName(jpegFile.FileName(), ind.originalsPath()))
+
+			if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
+				log.Errorf("index: could not create default thumbnails (%s)", err.Error())
+				ind.saveError(f, entity.IndexErrorThumb, err)
+				result.Status = IndexFailed
+				result.Error = err
+				return result
+			}
+
+			related.Files = append(related.Files, jpegFile)
 		}
+	}
 
-		// Enforce file size limit for originals.
-		if ind.conf.OriginalsLimit() > 0 && related.Main.FileSize() > ind.conf.OriginalsLimit() {
-			log.Warnf("index: %s exceeds file size limit for originals [%d / %d MB]", filepath.Base(related.Main.FileName()), related.Main.FileSize()/(1024*1024), ind.conf.OriginalsLimit()/(1024*1024))
-			continue
+	if opt.Convert && f.IsLegacyVideo() && !f.HasPlayableVideo() {
+		if mp4File, err := ind.convert.ToMP4(f); err != nil {
+			log.Errorf("index: transcoding video failed (%s)", err.Error())
+			ind.saveError(f, entity.IndexErrorCodec, err)
+			failed = true
+		} else {
+			log.Infof("index: %s created", fs.RelativeName(mp4File.FileName(), ind.originalsPath()))
+
+			related.Files = append(related.Files, mp4File)
 		}
+	}
 
-		f := related.Main
+	if ind.conf.SidecarJson() && !f.HasJson() {
+		if jsonFile, err := ind.convert.ToJson(f); err != nil {
+			log.Errorf("index: creating json sidecar file failed (%s)", err.Error())
+			ind.saveError(f, entity.IndexErrorMeta, err)
+			failed = true
+		} else {
+			log.Infof("index: %s created", fs.RelativeName(jsonFile.FileName(), ind.originalsPath()))
+		}
+	}
 
-		if opt.Convert && !f.HasJpeg() {
-			if jpegFile, err := ind.convert.ToJpeg(f, ind.conf.JpegHidden()); err != nil {
-				log.Errorf("index: creating jpeg failed (%s)", err.Error())
-				continue
-			} else {
-				log.Infof("index: %s created", fs.RelativeName(jpegFile.FileName(), ind.originalsPath()))
+	result = ind.MediaFile(f, opt, "")
+	done[f.FileName()] = true
 
-				if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
-					log.Errorf("index: could not create default thumbnails (%s)", err.Error())
-					continue
-				}
+	if result.Status == IndexFailed && result.Error != nil {
+		ind.saveError(f, entity.IndexErrorIndex, result.Error)
+		failed = true
+	} else if err, ok := f.MetaData().Error.(meta.CorruptError); ok {
+		ind.saveError(f, entity.IndexErrorMeta, err)
+		failed = true
+	}
 
-				related.Files = append(related.Files, jpegFile)
-			}
+	if (result.Status == IndexAdded || result.Status == IndexUpdated) && f.IsJpeg() {
+		if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
+			log.Errorf("index: could not create default thumbnails (%s)", err.Error())
+			query.SetFileError(result.FileUID, err.Error())
+			ind.saveError(f, entity.IndexErrorThumb, err)
+			failed = true
 		}
+	}
 
-		if ind.conf.SidecarJson() && !f.HasJson() {
-			if jsonFile, err := ind.convert.ToJson(f, ind.conf.SidecarHidden()); err != nil {
-				log.Errorf("index: creating json sidecar file failed (%s)", err.Error())
-			} else {
-				log.Infof("index: %s created", fs.RelativeName(jsonFile.FileName(), ind.originalsPath()))
-			}
+	log.Infof("index: %s main %s file %s", result, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
+
+	for _, f := range related.Files {
+		if done[f.FileName()] {
+			continue
 		}
 
 		res := ind.MediaFile(f, opt, "")
 		done[f.FileName()] = true
 
-		if (res.Status == IndexAdded || res.Status == IndexUpdated) && f.IsJpeg() {
+		if res.Status == IndexFailed && res.Error != nil {
+			ind.saveError(f, entity.IndexErrorIndex, res.Error)
+		} else if (res.Status == IndexAdded || res.Status == IndexUpdated) && f.IsJpeg() {
 			if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
 				log.Errorf("index: could not create default thumbnails (%s)", err.Error())
 				query.SetFileError(res.FileUID, err.Error())
+				ind.saveError(f, entity.IndexErrorThumb, err)
+			} else {
+				ind.clearError(f)
 			}
+		} else {
+			ind.clearError(f)
 		}
 
-		log.Infof("index: %s main %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
-
-		for _, f := range related.Files {
-			if done[f.FileName()] {
-				continue
-			}
-
-			res := ind.MediaFile(f, opt, "")
-			done[f.FileName()] = true
-
-			if (res.Status == IndexAdded || res.Status == IndexUpdated) && f.IsJpeg() {
-				if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
-					log.Errorf("index: could not create default thumbnails (%s)", err.Error())
-					query.SetFileError(res.FileUID, err.Error())
-				}
-			}
+		log.Infof("index: %s related %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
+	}
 
-			log.Infof("index: %s related %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
-		}
+	if !failed {
+		ind.clearError(related.Main)
 	}
+
+	return result
 }
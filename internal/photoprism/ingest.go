@@ -0,0 +1,78 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ingest"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// ApplyIngest applies the metadata sent by third-party backup apps to imported photos and returns their
+// number. Entries are removed from the queue once applied, files that haven't been imported yet are kept.
+func ApplyIngest(q *ingest.Queue) (applied int) {
+	var done []string
+
+	for _, hash := range q.Hashes() {
+		m, ok := q.Get(hash)
+
+		if !ok {
+			continue
+		}
+
+		f, err := query.FileByHash(hash)
+
+		if err != nil {
+			continue
+		}
+
+		photo, err := query.PhotoByUID(f.PhotoUID)
+
+		if err != nil {
+			log.Errorf("ingest: %s (%s)", err, m.Name)
+			continue
+		}
+
+		if err := applyIngestMeta(m, photo); err != nil {
+			log.Errorf("ingest: %s (%s)", err, m.Name)
+		} else {
+			applied++
+		}
+
+		done = append(done, hash)
+	}
+
+	if err := q.Remove(done...); err != nil {
+		log.Errorf("ingest: %s", err)
+	}
+
+	return applied
+}
+
+// applyIngestMeta updates an imported photo with the metadata sent by a backup app. The date is only
+// used if the file didn't contain one, since apps like Immich send the file creation time otherwise.
+func applyIngestMeta(m ingest.Meta, photo entity.Photo) error {
+	if m.Favorite && !photo.PhotoFavorite {
+		if err := photo.SetFavorite(true); err != nil {
+			return err
+		}
+	}
+
+	if !m.TakenAt.IsZero() && photo.TakenSrc == entity.SrcAuto {
+		photo.SetTakenAt(m.TakenAt, m.TakenAt, "", entity.SrcDevice)
+
+		if err := photo.Updates(map[string]interface{}{
+			"TakenAt":      photo.TakenAt,
+			"TakenAtLocal": photo.TakenAtLocal,
+			"TakenSrc":     photo.TakenSrc,
+			"PhotoYear":    photo.PhotoYear,
+			"PhotoMonth":   photo.PhotoMonth,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if m.Device != "" {
+		return ApplyDevice(m.Device, photo)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ingest"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyIngest(t *testing.T) {
+	photo := entity.Photo{PhotoTitle: "Immich Upload", TakenAt: time.Now().UTC()}
+
+	if err := entity.Db().Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "ingest/immich/IMG_0001.jpg", FileType: "jpg", FileHash: "9f1e3c0d1a8b5e2f4c6d7a8b9c0d1e2f3a4b5c6d", FilePrimary: true}
+
+	if err := entity.Db().Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	taken := time.Date(2019, 8, 12, 10, 30, 0, 0, time.UTC)
+
+	q := ingest.New("")
+
+	if err := q.Add(file.FileHash, ingest.Meta{App: ingest.AppImmich, Name: "IMG_0001.jpg", TakenAt: taken, Favorite: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Add("0f1e3c0d1a8b5e2f4c6d7a8b9c0d1e2f3a4b5c6d", ingest.Meta{App: ingest.AppImmich, Name: "IMG_0002.jpg"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, ApplyIngest(q))
+	assert.Equal(t, []string{"0f1e3c0d1a8b5e2f4c6d7a8b9c0d1e2f3a4b5c6d"}, q.Hashes())
+
+	result, err := query.PhotoByUID(photo.PhotoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result.PhotoFavorite)
+	assert.True(t, taken.Equal(result.TakenAt))
+	assert.Equal(t, entity.SrcDevice, result.TakenSrc)
+	assert.Equal(t, 2019, result.PhotoYear)
+	assert.Equal(t, 8, result.PhotoMonth)
+}
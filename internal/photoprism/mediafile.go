@@ -17,7 +17,9 @@ import (
 	"github.com/djherbis/times"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/sidecar"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/capture"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -299,6 +301,15 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		}
 	}
 
+	// Add hidden MP4 if main file is a legacy video.
+	if result.Main != nil && result.Main.IsLegacyVideo() {
+		if mp4Name := fs.TypeMP4.FindSub(result.Main.FileName(), fs.HiddenPath, stripSequence); mp4Name != "" {
+			if resultFile, err := NewMediaFile(mp4Name); err == nil {
+				result.Files = append(result.Files, resultFile)
+			}
+		}
+	}
+
 	sort.Sort(result.Files)
 
 	return result, nil
@@ -572,6 +583,11 @@ func (m *MediaFile) IsPlayableVideo() bool {
 	return m.MediaType() == fs.MediaVideo && m.HasFileType(fs.TypeMP4)
 }
 
+// IsLegacyVideo returns true if this is a video format that must be transcoded for playback, e.g. AVCHD or DV.
+func (m *MediaFile) IsLegacyVideo() bool {
+	return m.IsVideo() && video.Legacy[m.FileType()]
+}
+
 // IsPhoto returns true if this file is a photo / image.
 func (m *MediaFile) IsPhoto() bool {
 	return m.IsJpeg() || m.IsRaw() || m.IsHEIF() || m.IsImageOther()
@@ -610,13 +626,22 @@ func (m *MediaFile) HasJpeg() bool {
 	return fs.TypeJpeg.FindSub(m.FileName(), fs.HiddenPath, false) != ""
 }
 
+// HasPlayableVideo returns true if this file has or is a video that can be played in browsers.
+func (m *MediaFile) HasPlayableVideo() bool {
+	if m.IsPlayableVideo() {
+		return true
+	}
+
+	return fs.TypeMP4.FindSub(m.FileName(), fs.HiddenPath, false) != ""
+}
+
 // HasJson returns true if this file has or is a json sidecar file.
 func (m *MediaFile) HasJson() bool {
 	if m.IsJson() {
 		return true
 	}
 
-	return fs.TypeJson.FindSub(m.FileName(), fs.HiddenPath, false) != ""
+	return sidecar.Find(m.FileName(), fs.TypeJson, false) != ""
 }
 
 func (m *MediaFile) decodeDimensions() error {
@@ -1,6 +1,7 @@
 package photoprism
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -1033,6 +1034,65 @@ func TestMediaFile_IsVideo(t *testing.T) {
 	})
 }
 
+func TestMediaFile_IsLegacyVideo(t *testing.T) {
+	t.Run("/christmas.mp4", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, mediaFile.IsLegacyVideo())
+		assert.True(t, mediaFile.HasPlayableVideo())
+	})
+	t.Run("00001.MTS", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "legacy-video")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		fileName := filepath.Join(dir, "00001.MTS")
+
+		if err := ioutil.WriteFile(fileName, []byte("avchd"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mediaFile.IsVideo())
+		assert.True(t, mediaFile.IsLegacyVideo())
+		assert.False(t, mediaFile.HasPlayableVideo())
+
+		if err := os.MkdirAll(filepath.Join(dir, fs.HiddenPath), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dir, fs.HiddenPath, "00001.mp4"), []byte("mp4"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mediaFile.HasPlayableVideo())
+
+		related, err := mediaFile.RelatedFiles(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fileName, related.Main.FileName())
+		assert.Len(t, related.Files, 2)
+	})
+}
+
 func TestMediaFile_HasJpeg(t *testing.T) {
 	t.Run("Random.docx", func(t *testing.T) {
 		conf := config.TestConfig()
@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/sidecar"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -13,7 +14,7 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 	m.metaDataOnce.Do(func() {
 		err := m.metaData.Exif(m.FileName())
 
-		if jsonFile := fs.TypeJson.FindSub(m.FileName(), fs.HiddenPath, false); jsonFile == "" {
+		if jsonFile := sidecar.Find(m.FileName(), fs.TypeJson, false); jsonFile == "" {
 			log.Debugf("mediafile: no json sidecar file found for %s", txt.Quote(filepath.Base(m.FileName())))
 		} else if jsonErr := m.metaData.JSON(jsonFile); jsonErr != nil {
 			log.Warn(jsonErr)
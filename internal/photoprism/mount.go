@@ -0,0 +1,37 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// MountTimeout is the maximum time to wait for a directory listing of the originals folder.
+const MountTimeout = 10 * time.Second
+
+// OriginalsAvailable checks if the originals folder is mounted and switches to degraded mode if not,
+// so that files are not flagged as missing just because a network drive dropped.
+func OriginalsAvailable(conf *config.Config) bool {
+	originalsPath := conf.OriginalsPath()
+	available := fs.Available(originalsPath, MountTimeout)
+
+	if !mutex.Originals.SetOffline(!available) {
+		return available
+	}
+
+	if available {
+		log.Infof("mount: originals folder %s is available again", txt.Quote(originalsPath))
+		event.Publish("mount.online", event.Data{"path": originalsPath})
+		event.Success("Originals folder is available again")
+	} else {
+		log.Errorf("mount: originals folder %s is empty or not responding, drive not mounted?", txt.Quote(originalsPath))
+		event.Publish("mount.offline", event.Data{"path": originalsPath})
+		event.Error("Originals folder is not available, missing files won't be flagged until it is back")
+	}
+
+	return available
+}
@@ -0,0 +1,21 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginalsAvailable(t *testing.T) {
+	conf := config.TestConfig()
+
+	expected := fs.Available(conf.OriginalsPath(), MountTimeout)
+
+	assert.Equal(t, expected, OriginalsAvailable(conf))
+	assert.Equal(t, !expected, mutex.Originals.Offline())
+
+	mutex.Originals.SetOffline(false)
+}
@@ -0,0 +1,151 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ApplyPreset applies the actions of a preset to the given photos in order.
+func ApplyPreset(preset entity.Preset, photos []entity.Photo) error {
+	if err := preset.Actions.Validate(); err != nil {
+		return err
+	}
+
+	labelsChanged := false
+
+	for _, a := range preset.Actions {
+		if err := applyPresetAction(a, photos); err != nil {
+			return fmt.Errorf("preset: %s failed (%s)", a.Action, err)
+		}
+
+		if a.Action == entity.PresetAddLabel || a.Action == entity.PresetRemoveLabel {
+			labelsChanged = true
+		}
+	}
+
+	log.Infof("preset: applied %s to %d photos", txt.Quote(preset.PresetName), len(photos))
+
+	if !labelsChanged {
+		return entity.UpdatePhotoCounts()
+	}
+
+	// Update titles and keywords that depend on labels.
+	for _, m := range photos {
+		p, err := query.PhotoPreloadByUID(m.PhotoUID)
+
+		if err != nil {
+			return err
+		}
+
+		if err := p.Save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyPresetAction applies a single preset action to the given photos.
+func applyPresetAction(a entity.PresetAction, photos []entity.Photo) error {
+	db := entity.UnscopedDb()
+
+	switch a.Action {
+	case entity.PresetAddLabel:
+		label := entity.FirstOrCreateLabel(entity.NewLabel(a.Value, 0))
+
+		if label == nil {
+			return fmt.Errorf("can't create label %s", txt.Quote(a.Value))
+		}
+
+		for _, p := range photos {
+			pl := entity.FirstOrCreatePhotoLabel(entity.NewPhotoLabel(p.ID, label.ID, 0, entity.SrcManual))
+
+			if pl == nil {
+				return fmt.Errorf("can't add label %s", txt.Quote(a.Value))
+			} else if pl.Uncertainty > 0 {
+				if err := pl.Updates(map[string]interface{}{"Uncertainty": 0, "LabelSrc": entity.SrcManual}); err != nil {
+					return err
+				}
+			}
+		}
+	case entity.PresetRemoveLabel:
+		label, err := query.LabelBySlug(slug.Make(a.Value))
+
+		if gorm.IsRecordNotFoundError(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, p := range photos {
+			pl, err := query.PhotoLabel(p.ID, label.ID)
+
+			if gorm.IsRecordNotFoundError(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			if pl.LabelSrc == entity.SrcManual {
+				err = db.Delete(&pl).Error
+			} else {
+				err = pl.Update("Uncertainty", 100)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	case entity.PresetAddAlbum:
+		if _, err := query.AlbumByUID(a.Value); err != nil {
+			return err
+		}
+
+		for _, p := range photos {
+			entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(p.PhotoUID, a.Value))
+		}
+	case entity.PresetRemoveAlbum:
+		if err := db.Where("album_uid = ? AND photo_uid IN (?)", a.Value, presetPhotoUIDs(photos)).
+			Delete(&entity.PhotoAlbum{}).Error; err != nil {
+			return err
+		}
+	case entity.PresetPrivate:
+		if err := db.Model(&entity.Photo{}).Where("photo_uid IN (?)", presetPhotoUIDs(photos)).
+			UpdateColumn("photo_private", a.Bool()).Error; err != nil {
+			return err
+		}
+	case entity.PresetFavorite:
+		for _, p := range photos {
+			if err := p.SetFavorite(a.Bool()); err != nil {
+				return err
+			}
+		}
+	case entity.PresetArchive:
+		if a.Bool() {
+			return entity.Db().Where("photo_uid IN (?)", presetPhotoUIDs(photos)).Delete(&entity.Photo{}).Error
+		}
+
+		return db.Model(&entity.Photo{}).Where("photo_uid IN (?)", presetPhotoUIDs(photos)).
+			UpdateColumn("deleted_at", gorm.Expr("NULL")).Error
+	default:
+		return fmt.Errorf("unknown action %s", txt.Quote(a.Action))
+	}
+
+	return nil
+}
+
+// presetPhotoUIDs returns the UIDs of the given photos.
+func presetPhotoUIDs(photos []entity.Photo) []string {
+	result := make([]string, len(photos))
+
+	for i, p := range photos {
+		result[i] = p.PhotoUID
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPreset(t *testing.T) {
+	selection := form.Selection{Photos: []string{"pt9jtdre2lvl0y25"}}
+
+	photos, err := query.PhotoSelection(selection)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		preset, err := entity.NewPreset(form.Preset{Name: "Family", Actions: []form.PresetAction{
+			{Action: entity.PresetAddLabel, Value: "Preset Family"},
+			{Action: entity.PresetAddAlbum, Value: "at9lxuqxpogaaba9"},
+			{Action: entity.PresetPrivate},
+		}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ApplyPreset(*preset, photos); err != nil {
+			t.Fatal(err)
+		}
+
+		label, err := query.LabelBySlug("preset-family")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pl, err := query.PhotoLabel(photos[0].ID, label.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.SrcManual, pl.LabelSrc)
+
+		p, err := query.PhotoPreloadByUID("pt9jtdre2lvl0y25")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, p.PhotoPrivate)
+		assert.True(t, presetHasAlbum(p, "at9lxuqxpogaaba9"))
+	})
+	t.Run("remove", func(t *testing.T) {
+		preset, err := entity.NewPreset(form.Preset{Name: "Undo Family", Actions: []form.PresetAction{
+			{Action: entity.PresetRemoveLabel, Value: "Preset Family"},
+			{Action: entity.PresetRemoveAlbum, Value: "at9lxuqxpogaaba9"},
+			{Action: entity.PresetPrivate, Value: "false"},
+		}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ApplyPreset(*preset, photos); err != nil {
+			t.Fatal(err)
+		}
+
+		label, err := query.LabelBySlug("preset-family")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = query.PhotoLabel(photos[0].ID, label.ID)
+		assert.Error(t, err)
+
+		p, err := query.PhotoPreloadByUID("pt9jtdre2lvl0y25")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, p.PhotoPrivate)
+		assert.False(t, presetHasAlbum(p, "at9lxuqxpogaaba9"))
+	})
+	t.Run("album not found", func(t *testing.T) {
+		preset := entity.Preset{PresetName: "Invalid", Actions: entity.PresetActions{{Action: entity.PresetAddAlbum, Value: "at9lxuqxpogaaxxx"}}}
+		assert.Error(t, ApplyPreset(preset, photos))
+	})
+}
+
+// presetHasAlbum returns true if the photo is in the album.
+func presetHasAlbum(p entity.Photo, albumUID string) bool {
+	for _, a := range p.Albums {
+		if a.AlbumUID == albumUID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/semantic"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -55,6 +56,18 @@ func (prg *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPh
 	purgedPhotos = make(map[string]bool)
 	originalsPath := prg.originalsPath()
 
+	// An empty originals folder usually means that the drive is not mounted.
+	if !OriginalsAvailable(prg.conf) {
+		err = fmt.Errorf("purge: originals folder %s is empty, drive not mounted?", txt.Quote(originalsPath))
+		return purgedFiles, purgedPhotos, err
+	}
+
+	grace := prg.conf.MissingGrace()
+
+	if opt.Force {
+		grace = 0
+	}
+
 	if err := mutex.MainWorker.Start(); err != nil {
 		err = fmt.Errorf("purge: %s", err.Error())
 		event.Error(err.Error())
@@ -91,7 +104,30 @@ func (prg *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPh
 				continue
 			}
 
-			if !fs.FileExists(fileName) {
+			exists := fs.FileExists(fileName)
+
+			// Network drives may disappear while purging, so don't flag files as missing in this case.
+			if !exists && !OriginalsAvailable(prg.conf) {
+				return purgedFiles, purgedPhotos, fmt.Errorf("purge: originals folder %s is not available anymore", txt.Quote(originalsPath))
+			}
+
+			if exists {
+				if file.MissingSince != nil && !opt.Dry {
+					if err := file.Found(); err != nil {
+						log.Errorf("purge: %s", err)
+					} else {
+						log.Infof("purge: found file %s again", txt.Quote(fs.RelativeName(fileName, originalsPath)))
+					}
+				}
+			} else if grace > 0 && (file.MissingSince == nil || time.Since(*file.MissingSince) < grace) {
+				if opt.Dry {
+					log.Infof("purge: file %s would be quarantined", txt.Quote(fs.RelativeName(fileName, originalsPath)))
+				} else if err := file.Quarantine(); err != nil {
+					log.Errorf("purge: %s", err)
+				} else {
+					log.Infof("purge: file %s is missing, will be removed after %s", txt.Quote(fs.RelativeName(fileName, originalsPath)), file.MissingSince.Add(grace).Format(time.RFC3339))
+				}
+			} else {
 				if opt.Dry {
 					purgedFiles[fileName] = true
 					log.Infof("purge: file %s would be removed", txt.Quote(fs.RelativeName(fileName, originalsPath)))
@@ -151,6 +187,7 @@ func (prg *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPh
 				purgedPhotos[photo.PhotoUID] = true
 
 				if opt.Hard {
+					semantic.Default.Remove(photo.PhotoUID)
 					log.Infof("purge: permanently deleted photo %s", txt.Quote(photo.PhotoName))
 				} else {
 					log.Infof("purge: removed photo %s", txt.Quote(photo.PhotoName))
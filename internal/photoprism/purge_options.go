@@ -5,4 +5,5 @@ type PurgeOptions struct {
 	Ignore map[string]bool
 	Dry    bool
 	Hard   bool
+	Force  bool
 }
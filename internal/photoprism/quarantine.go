@@ -0,0 +1,143 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// VerifyUpload checks an uploaded file if enabled in the config, see CheckUpload, and re-encodes images
+// if enabled. Suspicious files are moved to quarantine, in which case quarantined is true.
+func VerifyUpload(conf *config.Config, fileName, source, uploader string) (quarantined bool, err error) {
+	if !conf.UploadQuarantine() && !conf.UploadReencode() {
+		return false, nil
+	}
+
+	if conf.UploadQuarantine() {
+		if err := CheckUpload(conf, fileName); err != nil {
+			if _, ok := err.(UploadError); !ok {
+				return false, err
+			}
+
+			if _, err := Quarantine(conf, fileName, source, uploader, err); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	if conf.UploadReencode() {
+		if err := ReencodeUpload(fileName); err != nil {
+			if _, ok := err.(UploadError); !ok || !conf.UploadQuarantine() {
+				return false, err
+			}
+
+			if _, err := Quarantine(conf, fileName, source, uploader, err); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Quarantine moves a suspicious file to the quarantine path, so that it can be reviewed by an admin.
+func Quarantine(conf *config.Config, fileName, source, uploader string, reason error) (*entity.QuarantineFile, error) {
+	if err := os.MkdirAll(conf.QuarantinePath(), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	size, _ := mf.Stat()
+
+	m := &entity.QuarantineFile{
+		FileName:     rnd.PPID('q') + "_" + filepath.Base(fileName),
+		OriginalName: filepath.Base(fileName),
+		FileSize:     size,
+		FileMime:     fs.MimeType(fileName),
+		Reason:       reason.Error(),
+		Source:       source,
+		Uploader:     uploader,
+	}
+
+	if err := mf.Move(filepath.Join(conf.QuarantinePath(), m.FileName)); err != nil {
+		return nil, err
+	}
+
+	if err := m.Create(); err != nil {
+		return nil, err
+	}
+
+	log.Warnf("upload: %s, moved to quarantine", reason)
+
+	event.Warning(fmt.Sprintf("%s moved to quarantine", txt.Quote(m.OriginalName)))
+	event.Publish("upload.quarantined", event.Data{
+		"id":     m.ID,
+		"name":   m.OriginalName,
+		"reason": m.Reason,
+		"source": m.Source,
+	})
+
+	return m, nil
+}
+
+// ReleaseQuarantined moves a quarantined file to the import path, so that it can be imported after review.
+// The name of the returned file is relative to the import path.
+func ReleaseQuarantined(conf *config.Config, m entity.QuarantineFile) (string, error) {
+	dir := filepath.Join(conf.ImportPath(), "quarantine")
+	dest := filepath.Join(dir, m.OriginalName)
+
+	if fs.FileExists(dest) {
+		return "", fmt.Errorf("%s already exists in import folder", txt.Quote(m.OriginalName))
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	mf, err := NewMediaFile(filepath.Join(conf.QuarantinePath(), m.FileName))
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := mf.Move(dest); err != nil {
+		return "", err
+	}
+
+	if err := m.Delete(); err != nil {
+		return "", err
+	}
+
+	log.Infof("upload: released %s from quarantine", txt.Quote(m.OriginalName))
+
+	return fs.RelativeName(dest, conf.ImportPath()), nil
+}
+
+// DeleteQuarantined deletes a quarantined file permanently.
+func DeleteQuarantined(conf *config.Config, m entity.QuarantineFile) error {
+	fileName := filepath.Join(conf.QuarantinePath(), m.FileName)
+
+	if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	log.Infof("upload: deleted %s from quarantine", txt.Quote(m.OriginalName))
+
+	return m.Delete()
+}
@@ -65,7 +65,7 @@ func (rs *Resample) Start(force bool) error {
 				}
 			}()
 
-			if mutex.MainWorker.Canceled() {
+			if !waitForResources(rs.conf, "resample") {
 				return errors.New("resample: canceled")
 			}
 
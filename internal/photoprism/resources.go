@@ -0,0 +1,56 @@
+package photoprism
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/sysinfo"
+)
+
+// cpuPressure is the load per CPU core above which new jobs are delayed.
+const cpuPressure = 2.0
+
+// waitForResources blocks while less than the reserved memory is available, so that running jobs
+// can finish before new ones are started, and delays jobs if the CPU is under pressure.
+// It returns false if the worker was canceled while waiting.
+func waitForResources(conf *config.Config, prefix string) bool {
+	waiting := false
+
+	for {
+		if mutex.MainWorker.Canceled() {
+			return false
+		}
+
+		available := sysinfo.MemoryAvailable()
+
+		// Zero means unknown, e.g. on operating systems other than Linux.
+		if available == 0 || available >= conf.MemoryReserve() {
+			if waiting {
+				log.Infof("%s: resumed, %d MB memory available", prefix, available/sysinfo.MB)
+			}
+
+			break
+		}
+
+		if !waiting {
+			log.Warnf("%s: waiting for memory, only %d MB available", prefix, available/sysinfo.MB)
+			waiting = true
+		}
+
+		runtime.GC()
+		debug.FreeOSMemory()
+
+		time.Sleep(time.Second)
+	}
+
+	// Give other processes a chance if the CPU is overloaded.
+	if load := sysinfo.Load(); load > cpuPressure*float64(sysinfo.CPUs()) {
+		log.Debugf("%s: cpu load is %.1f, delaying next job", prefix, load)
+		time.Sleep(time.Second)
+	}
+
+	return true
+}
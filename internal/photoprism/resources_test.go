@@ -0,0 +1,28 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForResources(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("available", func(t *testing.T) {
+		assert.True(t, waitForResources(conf, "test"))
+	})
+	t.Run("canceled", func(t *testing.T) {
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		mutex.MainWorker.Cancel()
+
+		assert.False(t, waitForResources(conf, "test"))
+	})
+}
@@ -0,0 +1,116 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var (
+	ErrRotateAngle    = errors.New("rotate: angle must be 90, 180 or 270 degrees")
+	ErrRotateFile     = errors.New("rotate: only jpeg originals can be rotated")
+	ErrRotateExifTool = errors.New("rotate: exiftool not found")
+)
+
+// exifOrientation describes an Exif orientation as optional horizontal mirroring followed by a clockwise rotation.
+type exifOrientation struct {
+	mirror  bool
+	degrees int
+}
+
+// exifOrientations maps Exif orientation values to the transformation needed to display the image upright.
+var exifOrientations = map[int]exifOrientation{
+	1: {false, 0},
+	2: {true, 0},
+	3: {false, 180},
+	4: {true, 180},
+	5: {true, 270},
+	6: {false, 90},
+	7: {true, 90},
+	8: {false, 270},
+}
+
+// ValidRotation returns true if an image can be rotated clockwise by the given degrees using its Exif orientation.
+func ValidRotation(degrees int) bool {
+	return degrees == 90 || degrees == 180 || degrees == 270
+}
+
+// RotateOrientation returns the Exif orientation after rotating an image clockwise by the given degrees.
+// Unknown orientations are handled like 1, which means the image is displayed as stored.
+func RotateOrientation(orientation, degrees int) int {
+	current, ok := exifOrientations[orientation]
+
+	if !ok {
+		current = exifOrientations[1]
+	}
+
+	rotated := exifOrientation{mirror: current.mirror, degrees: ((current.degrees+degrees)%360 + 360) % 360}
+
+	for value, o := range exifOrientations {
+		if o == rotated {
+			return value
+		}
+	}
+
+	return 1
+}
+
+// Rotate rotates the primary JPEG of a photo clockwise by updating its Exif orientation, so that the image
+// data is not encoded again. The file is then indexed again and new default thumbnails are created.
+func Rotate(conf *config.Config, ind *Index, f entity.File, degrees int) error {
+	if !ValidRotation(degrees) {
+		return ErrRotateAngle
+	} else if conf.ReadOnly() {
+		return config.ErrReadOnly
+	} else if f.FileType != string(fs.TypeJpeg) || f.FileRoot != entity.RootDefault || f.FileCold {
+		return ErrRotateFile
+	} else if conf.ExifToolBin() == "" {
+		return ErrRotateExifTool
+	}
+
+	fileName := filepath.Join(conf.OriginalsPath(), f.FileName)
+
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	orientation := RotateOrientation(mf.Orientation(), degrees)
+
+	cmd := exec.Command(conf.ExifToolBin(), "-q", "-overwrite_original", "-Orientation#="+strconv.Itoa(orientation), fileName)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("rotate: %s (%s)", err, msg)
+		}
+
+		return fmt.Errorf("rotate: %s", err)
+	}
+
+	if res := ind.SingleFile(fileName); res.Error != nil {
+		return res.Error
+	}
+
+	if mf, err = NewMediaFile(fileName); err != nil {
+		return err
+	} else if err := mf.ResampleDefault(conf.ThumbPath(), false); err != nil {
+		return err
+	}
+
+	log.Infof("rotate: rotated %s by %d°", txt.Quote(f.FileName), degrees)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidRotation(t *testing.T) {
+	assert.True(t, ValidRotation(90))
+	assert.True(t, ValidRotation(180))
+	assert.True(t, ValidRotation(270))
+	assert.False(t, ValidRotation(0))
+	assert.False(t, ValidRotation(45))
+	assert.False(t, ValidRotation(-90))
+	assert.False(t, ValidRotation(360))
+}
+
+func TestRotateOrientation(t *testing.T) {
+	t.Run("upright", func(t *testing.T) {
+		assert.Equal(t, 6, RotateOrientation(1, 90))
+		assert.Equal(t, 3, RotateOrientation(1, 180))
+		assert.Equal(t, 8, RotateOrientation(1, 270))
+	})
+	t.Run("rotated", func(t *testing.T) {
+		assert.Equal(t, 3, RotateOrientation(6, 90))
+		assert.Equal(t, 1, RotateOrientation(8, 90))
+		assert.Equal(t, 6, RotateOrientation(3, 270))
+	})
+	t.Run("mirrored", func(t *testing.T) {
+		assert.Equal(t, 7, RotateOrientation(2, 90))
+		assert.Equal(t, 4, RotateOrientation(2, 180))
+		assert.Equal(t, 5, RotateOrientation(2, 270))
+		assert.Equal(t, 2, RotateOrientation(5, 90))
+		assert.Equal(t, 2, RotateOrientation(7, 270))
+	})
+	t.Run("full turn", func(t *testing.T) {
+		for orientation := 1; orientation <= 8; orientation++ {
+			assert.Equal(t, orientation, RotateOrientation(RotateOrientation(orientation, 90), 270))
+			assert.Equal(t, orientation, RotateOrientation(RotateOrientation(orientation, 180), 180))
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, 6, RotateOrientation(0, 90))
+		assert.Equal(t, 1, RotateOrientation(9, 0))
+	})
+}
+
+func TestRotate(t *testing.T) {
+	conf := config.TestConfig()
+	ind := NewIndex(conf, nil, nil, NewConvert(conf))
+
+	t.Run("invalid angle", func(t *testing.T) {
+		err := Rotate(conf, ind, entity.File{FileType: "jpg", FileRoot: entity.RootDefault}, 45)
+		assert.Equal(t, ErrRotateAngle, err)
+	})
+	t.Run("not a jpeg", func(t *testing.T) {
+		err := Rotate(conf, ind, entity.File{FileType: "raw", FileRoot: entity.RootDefault}, 90)
+		assert.Equal(t, ErrRotateFile, err)
+	})
+	t.Run("cold storage", func(t *testing.T) {
+		err := Rotate(conf, ind, entity.File{FileType: "jpg", FileRoot: entity.RootDefault, FileCold: true}, 90)
+		assert.Equal(t, ErrRotateFile, err)
+	})
+}
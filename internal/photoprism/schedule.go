@@ -0,0 +1,48 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// waitForSchedule blocks while the main worker is paused or outside the configured index schedule.
+// It returns false if the worker was canceled while waiting.
+func waitForSchedule(conf *config.Config, prefix string) bool {
+	return waitForWindow(conf.IndexSchedule(), prefix)
+}
+
+// waitForWindow blocks while the main worker is paused or outside the schedule. Empty schedules
+// don't restrict the time of day.
+func waitForWindow(schedule config.Schedule, prefix string) bool {
+	waiting := false
+
+	for {
+		if mutex.MainWorker.Canceled() {
+			return false
+		}
+
+		paused := mutex.MainWorker.Paused()
+
+		if !paused && schedule.Contains(time.Now()) {
+			if waiting {
+				log.Infof("%s: resumed", prefix)
+			}
+
+			return true
+		}
+
+		if !waiting {
+			if paused {
+				log.Infof("%s: paused", prefix)
+			} else {
+				log.Infof("%s: waiting for schedule %s", prefix, schedule)
+			}
+
+			waiting = true
+		}
+
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,21 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForWindow(t *testing.T) {
+	t.Run("empty schedule", func(t *testing.T) {
+		assert.True(t, waitForWindow(config.Schedule{}, "import"))
+	})
+	t.Run("within schedule", func(t *testing.T) {
+		now := time.Now()
+		start := time.Duration(now.Hour()) * time.Hour
+
+		assert.True(t, waitForWindow(config.Schedule{Start: start, End: start + time.Hour}, "index"))
+	})
+}
@@ -0,0 +1,81 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/semantic"
+)
+
+// SemanticIndex computes the CLIP embedding of a JPEG for natural language search, saves it,
+// and adds it to the search index.
+func SemanticIndex(m *MediaFile, thumbPath, photoUID string) error {
+	fileName, err := m.Thumbnail(thumbPath, "tile_224")
+
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	e, err := semantic.EncodeImage(data)
+
+	if err != nil {
+		return err
+	}
+
+	if err := entity.NewPhotoEmbedding(photoUID, e.Model, e.Vector.Bytes()).Save(); err != nil {
+		return err
+	}
+
+	return semantic.Default.Add(photoUID, e.Vector)
+}
+
+// LoadEmbeddings adds the saved embeddings of all photos to the search index, which is trained in the background
+// once it is large enough.
+func LoadEmbeddings() error {
+	start := time.Now()
+	limit := 10000
+	offset := 0
+	count := 0
+
+	for {
+		embeddings, err := query.PhotoEmbeddings(limit, offset)
+
+		if err != nil {
+			return err
+		}
+
+		for _, m := range embeddings {
+			v, err := semantic.NewVector(m.Embedding)
+
+			if err != nil {
+				log.Warnf("semantic: %s for %s", err, m.PhotoUID)
+				continue
+			}
+
+			if err := semantic.Default.Add(m.PhotoUID, v); err != nil {
+				log.Warnf("semantic: %s for %s", err, m.PhotoUID)
+				continue
+			}
+
+			count++
+		}
+
+		if len(embeddings) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	log.Infof("semantic: loaded %d embeddings [%s]", count, time.Since(start))
+
+	return nil
+}
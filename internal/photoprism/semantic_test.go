@@ -0,0 +1,28 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/semantic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEmbeddings(t *testing.T) {
+	m := entity.NewPhotoEmbedding("pt9jtdre2lvl0y12", "test", semantic.Vector{1, 0}.Bytes())
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.Db().Delete(m)
+
+	semantic.Default.Clear()
+	defer semantic.Default.Clear()
+
+	if err := LoadEmbeddings(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, semantic.Default.Contains("pt9jtdre2lvl0y12"))
+}
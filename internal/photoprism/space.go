@@ -0,0 +1,91 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/sysinfo"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// diskFree returns the free space of the volume a path belongs to, can be replaced for testing.
+var diskFree = sysinfo.DiskFree
+
+// DiskSpaceError is returned if a volume doesn't have enough free space for files to be imported.
+type DiskSpaceError struct {
+	Path     string
+	Free     uint64
+	Required uint64
+}
+
+// Error returns the error message including the free and required space in MB.
+func (e DiskSpaceError) Error() string {
+	return fmt.Sprintf("not enough disk space in %s (%d MB free, %d MB required)", txt.Quote(e.Path), e.Free/sysinfo.MB, e.Required/sysinfo.MB)
+}
+
+// CheckDiskSpace returns a DiskSpaceError if the originals, sidecar, or temp volume, or the volume of any
+// other path, has less free space than the payload size plus the configured reserve. Volumes of which the
+// free space is unknown are skipped.
+func CheckDiskSpace(conf *config.Config, size int64, paths ...string) error {
+	required := conf.DiskReserve()
+
+	if size > 0 {
+		required += uint64(size)
+	}
+
+	paths = append([]string{conf.OriginalsPath(), conf.SidecarPath(), conf.TempPath()}, paths...)
+
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+
+		if free := diskFree(existingPath(p)); free > 0 && free < required {
+			err := DiskSpaceError{Path: p, Free: free, Required: required}
+
+			event.Error(err.Error())
+			event.Publish("import.diskfull", event.Data{
+				"path":     p,
+				"free":     free,
+				"required": required,
+			})
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PathSize returns the total size of all regular files in a path.
+func PathSize(p string) (size int64) {
+	_ = filepath.Walk(p, func(fileName string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+// existingPath returns the path or its closest existing parent, as folders may be created when needed.
+func existingPath(p string) string {
+	for {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+
+		parent := filepath.Dir(p)
+
+		if parent == p {
+			return p
+		}
+
+		p = parent
+	}
+}
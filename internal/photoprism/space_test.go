@@ -0,0 +1,67 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/sysinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDiskSpace(t *testing.T) {
+	conf := config.TestConfig()
+
+	defer func() { diskFree = sysinfo.DiskFree }()
+
+	t.Run("enough space", func(t *testing.T) {
+		diskFree = func(string) uint64 { return 100 * sysinfo.MB }
+
+		assert.NoError(t, CheckDiskSpace(conf, 10*sysinfo.MB))
+	})
+	t.Run("not enough space", func(t *testing.T) {
+		diskFree = func(string) uint64 { return 100 * sysinfo.MB }
+
+		err := CheckDiskSpace(conf, int64(200*sysinfo.MB+conf.DiskReserve()), conf.ImportPath())
+
+		if assert.IsType(t, DiskSpaceError{}, err) {
+			e := err.(DiskSpaceError)
+			assert.Equal(t, conf.OriginalsPath(), e.Path)
+			assert.Equal(t, uint64(100*sysinfo.MB), e.Free)
+			assert.Contains(t, e.Error(), "100 MB free")
+		}
+	})
+	t.Run("import volume full", func(t *testing.T) {
+		diskFree = func(p string) uint64 {
+			if p == conf.ImportPath() {
+				return sysinfo.MB
+			}
+
+			return 100 * sysinfo.MB
+		}
+
+		err := CheckDiskSpace(conf, 10*sysinfo.MB, conf.ImportPath())
+
+		if assert.Error(t, err) {
+			assert.Equal(t, conf.ImportPath(), err.(DiskSpaceError).Path)
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		diskFree = func(string) uint64 { return 0 }
+
+		assert.NoError(t, CheckDiskSpace(conf, 200*sysinfo.MB))
+	})
+}
+
+func TestPathSize(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.Greater(t, PathSize(conf.ExamplesPath()), int64(0))
+	assert.Equal(t, int64(0), PathSize(filepath.Join(conf.ExamplesPath(), "xxx")))
+}
+
+func TestExistingPath(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.Equal(t, conf.ExamplesPath(), existingPath(filepath.Join(conf.ExamplesPath(), "foo", "bar")))
+}
@@ -0,0 +1,337 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/tier"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ColdCacheMaxAge is the time after which originals fetched from cold storage are removed from the cache.
+const ColdCacheMaxAge = 7 * 24 * time.Hour
+
+// TieringOptions represents options for moving originals to and from cold storage.
+type TieringOptions struct {
+	Years int
+	Dry   bool
+}
+
+// Tiering represents a worker that moves old originals to cold storage, thumbnails and metadata are kept.
+type Tiering struct {
+	conf *config.Config
+}
+
+// NewTiering returns a new tiering worker.
+func NewTiering(conf *config.Config) *Tiering {
+	return &Tiering{conf: conf}
+}
+
+// store returns the configured cold storage.
+func (t *Tiering) store() (tier.Store, error) {
+	if t.conf.ReadOnly() {
+		return nil, config.ErrReadOnly
+	}
+
+	return tier.New(t.conf.ColdStorage())
+}
+
+// Start moves originals of photos taken more than the configured number of years ago to cold storage.
+func (t *Tiering) Start(opt TieringOptions) (moved int, err error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Errorf("tiering: %s [panic]", err)
+		}
+	}()
+
+	years := opt.Years
+
+	if years <= 0 {
+		years = t.conf.ColdAfter()
+	}
+
+	if years <= 0 {
+		return 0, errors.New("tiering: age in years not configured")
+	}
+
+	store, err := t.store()
+
+	if err != nil {
+		return 0, err
+	}
+
+	// An empty originals folder usually means that the drive is not mounted.
+	if !OriginalsAvailable(t.conf) {
+		return 0, fmt.Errorf("tiering: originals folder %s is empty, drive not mounted?", txt.Quote(t.conf.OriginalsPath()))
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return 0, fmt.Errorf("tiering: %s", err.Error())
+	}
+
+	defer func() {
+		mutex.MainWorker.Stop()
+		runtime.GC()
+	}()
+
+	before := time.Now().AddDate(-years, 0, 0)
+
+	var lastId uint
+
+	for {
+		if !waitForSchedule(t.conf, "tiering") {
+			return moved, errors.New("tiering canceled")
+		}
+
+		files, err := query.ColdCandidates(before, lastId, 100)
+
+		if err != nil {
+			return moved, err
+		}
+
+		if len(files) == 0 {
+			break
+		}
+
+		for _, f := range files {
+			if mutex.MainWorker.Canceled() {
+				return moved, errors.New("tiering canceled")
+			}
+
+			lastId = f.ID
+
+			if opt.Dry {
+				log.Infof("tiering: would move %s to cold storage", txt.Quote(f.FileName))
+				moved++
+				continue
+			}
+
+			if err := moveToCold(t.conf, store, f); err != nil {
+				log.Errorf("tiering: can't move %s to cold storage (%s)", txt.Quote(f.FileName), err)
+				continue
+			}
+
+			log.Infof("tiering: moved %s to cold storage", txt.Quote(f.FileName))
+
+			moved++
+		}
+	}
+
+	pruneColdCache(t.conf)
+
+	return moved, nil
+}
+
+// Restore moves all originals in cold storage back to the originals folder.
+func (t *Tiering) Restore(opt TieringOptions) (restored int, err error) {
+	store, err := t.store()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return 0, fmt.Errorf("tiering: %s", err.Error())
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	offset := 0
+
+	for {
+		files, err := query.ColdFiles(100, offset)
+
+		if err != nil {
+			return restored, err
+		}
+
+		if len(files) == 0 {
+			break
+		}
+
+		for _, f := range files {
+			if mutex.MainWorker.Canceled() {
+				return restored, errors.New("tiering canceled")
+			}
+
+			if opt.Dry {
+				log.Infof("tiering: would restore %s", txt.Quote(f.FileName))
+				restored++
+				offset++
+				continue
+			}
+
+			if err := restoreFromCold(t.conf, store, f); err != nil {
+				log.Errorf("tiering: can't restore %s (%s)", txt.Quote(f.FileName), err)
+				offset++
+				continue
+			}
+
+			log.Infof("tiering: restored %s", txt.Quote(f.FileName))
+
+			restored++
+		}
+	}
+
+	return restored, nil
+}
+
+// moveToCold copies an original to cold storage and removes the local file once the copy has been verified.
+func moveToCold(conf *config.Config, store tier.Store, f entity.File) error {
+	fileName := filepath.Join(conf.OriginalsPath(), f.FileName)
+
+	if !fs.FileExists(fileName) {
+		return errors.New("file not found")
+	}
+
+	if err := store.Put(fileName, f.FileName); err != nil {
+		return err
+	}
+
+	// Keep the local file unless the copy in cold storage is complete.
+	if err := verifyColdCopy(conf, store, f, fileName); err != nil {
+		if err := store.Delete(f.FileName); err != nil {
+			log.Warnf("tiering: can't remove %s from cold storage (%s)", txt.Quote(f.FileName), err)
+		}
+
+		return err
+	}
+
+	// Flag the file first, so that it never looks missing if it can't be removed.
+	if err := f.Update("FileCold", true); err != nil {
+		return err
+	}
+
+	return os.Remove(fileName)
+}
+
+// verifyColdCopy fetches the copy of an original from cold storage and returns an error if its
+// hash doesn't match the local file, e.g. because the upload was truncated.
+func verifyColdCopy(conf *config.Config, store tier.Store, f entity.File, fileName string) error {
+	if err := os.MkdirAll(conf.TempPath(), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmpName := filepath.Join(conf.TempPath(), fmt.Sprintf("%s.%s.tmp", f.FileUID, rnd.Token(6)))
+
+	defer os.Remove(tmpName)
+
+	if err := store.Fetch(f.FileName, tmpName); err != nil {
+		return fmt.Errorf("can't verify copy (%s)", err)
+	}
+
+	if hash := fs.Hash(tmpName); hash == "" || hash != fs.Hash(fileName) {
+		return errors.New("copy in cold storage is incomplete")
+	}
+
+	return nil
+}
+
+// restoreFromCold copies an original back to the originals folder and removes it from cold storage.
+func restoreFromCold(conf *config.Config, store tier.Store, f entity.File) error {
+	fileName := filepath.Join(conf.OriginalsPath(), f.FileName)
+
+	if !fs.FileExists(fileName) {
+		tmpName := fmt.Sprintf("%s.%s.tmp", fileName, rnd.Token(6))
+
+		if err := store.Fetch(f.FileName, tmpName); err != nil {
+			os.Remove(tmpName)
+			return err
+		}
+
+		if err := os.Rename(tmpName, fileName); err != nil {
+			return err
+		}
+	}
+
+	if err := f.Update("FileCold", false); err != nil {
+		return err
+	}
+
+	if err := store.Delete(f.FileName); err != nil {
+		log.Warnf("tiering: can't remove %s from cold storage (%s)", txt.Quote(f.FileName), err)
+	}
+
+	return nil
+}
+
+// OriginalFileName returns the local file name of an original. Files in cold storage are fetched
+// to the cache first, which may take a while depending on the storage.
+func OriginalFileName(conf *config.Config, f entity.File) (string, error) {
+	fileName := filepath.Join(conf.OriginalsPath(), f.FileName)
+
+	if !f.FileCold || fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	cacheName := filepath.Join(conf.ColdCachePath(), f.FileUID+strings.ToLower(filepath.Ext(f.FileName)))
+
+	if fs.FileExists(cacheName) {
+		now := time.Now()
+
+		// Keep recently used files in the cache.
+		if err := os.Chtimes(cacheName, now, now); err != nil {
+			log.Warnf("tiering: %s", err)
+		}
+
+		return cacheName, nil
+	}
+
+	store, err := tier.New(conf.ColdStorage())
+
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	tmpName := fmt.Sprintf("%s.%s.tmp", cacheName, rnd.Token(6))
+
+	if err := store.Fetch(f.FileName, tmpName); err != nil {
+		os.Remove(tmpName)
+		return "", fmt.Errorf("tiering: can't fetch %s from cold storage (%s)", txt.Quote(f.FileName), err)
+	}
+
+	if err := os.Rename(tmpName, cacheName); err != nil {
+		return "", err
+	}
+
+	log.Infof("tiering: fetched %s from cold storage in %s", txt.Quote(f.FileName), time.Since(start))
+
+	return cacheName, nil
+}
+
+// pruneColdCache removes originals from the cache that haven't been used recently.
+func pruneColdCache(conf *config.Config) {
+	cachePath := conf.ColdCachePath()
+
+	if !fs.PathExists(cachePath) {
+		return
+	}
+
+	err := filepath.Walk(cachePath, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || time.Since(info.ModTime()) < ColdCacheMaxAge {
+			return nil
+		}
+
+		if err := os.Remove(fileName); err != nil {
+			log.Warnf("tiering: %s", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Errorf("tiering: %s", err)
+	}
+}
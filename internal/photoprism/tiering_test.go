@@ -0,0 +1,169 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/tier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTiering_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewTiering(conf)
+
+	t.Run("not configured", func(t *testing.T) {
+		moved, err := worker.Start(TieringOptions{})
+
+		assert.EqualError(t, err, "tiering: age in years not configured")
+		assert.Equal(t, 0, moved)
+	})
+	t.Run("no storage", func(t *testing.T) {
+		moved, err := worker.Start(TieringOptions{Years: 10, Dry: true})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, moved)
+	})
+}
+
+func TestOriginalFileName(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("hot", func(t *testing.T) {
+		f := entity.File{FileUID: "ft9es39w45bnlxxx", FileName: "2020/05/IMG_1234.jpg"}
+
+		fileName, err := OriginalFileName(conf, f)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(conf.OriginalsPath(), "2020/05/IMG_1234.jpg"), fileName)
+	})
+	t.Run("cached", func(t *testing.T) {
+		f := entity.File{FileUID: "ft9es39w45bnlxxx", FileName: "2020/05/IMG_1234.JPG", FileCold: true}
+		cacheName := filepath.Join(conf.ColdCachePath(), "ft9es39w45bnlxxx.jpg")
+
+		if err := os.MkdirAll(conf.ColdCachePath(), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(cacheName, []byte("jpeg data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(cacheName)
+
+		fileName, err := OriginalFileName(conf, f)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cacheName, fileName)
+	})
+	t.Run("cold storage not configured", func(t *testing.T) {
+		f := entity.File{FileUID: "ft9es39w45bnlyyy", FileName: "2020/05/IMG_5678.jpg", FileCold: true}
+
+		_, err := OriginalFileName(conf, f)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPruneColdCache(t *testing.T) {
+	conf := config.TestConfig()
+
+	if err := os.MkdirAll(conf.ColdCachePath(), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	oldName := filepath.Join(conf.ColdCachePath(), "ft9es39w45bnlold.jpg")
+	newName := filepath.Join(conf.ColdCachePath(), "ft9es39w45bnlnew.jpg")
+
+	for _, fileName := range []string{oldName, newName} {
+		if err := ioutil.WriteFile(fileName, []byte("jpeg data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer os.Remove(newName)
+
+	expired := time.Now().Add(-2 * ColdCacheMaxAge)
+
+	if err := os.Chtimes(oldName, expired, expired); err != nil {
+		t.Fatal(err)
+	}
+
+	pruneColdCache(conf)
+
+	assert.NoFileExists(t, oldName)
+	assert.FileExists(t, newName)
+}
+
+// truncatingStore simulates a cold storage that doesn't write files completely.
+type truncatingStore struct {
+	*tier.Dir
+}
+
+func (s truncatingStore) Put(src, name string) error {
+	if err := s.Dir.Put(src, name); err != nil {
+		return err
+	}
+
+	tmpName := filepath.Join(os.TempDir(), "truncated.tmp")
+
+	defer os.Remove(tmpName)
+
+	if err := ioutil.WriteFile(tmpName, []byte("jpeg"), 0644); err != nil {
+		return err
+	}
+
+	return s.Dir.Put(tmpName, name)
+}
+
+func TestMoveToCold(t *testing.T) {
+	conf := config.TestConfig()
+
+	coldPath, err := ioutil.TempDir("", "cold")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(coldPath)
+
+	dir, err := tier.NewDir(coldPath)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := entity.File{FileUID: "ft9es39w45bnlcld", FileName: "tiering/IMG_COLD.jpg"}
+	fileName := filepath.Join(conf.OriginalsPath(), f.FileName)
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(fileName, []byte("jpeg data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	t.Run("incomplete copy", func(t *testing.T) {
+		err := moveToCold(conf, truncatingStore{dir}, f)
+
+		assert.EqualError(t, err, "copy in cold storage is incomplete")
+		assert.FileExists(t, fileName)
+		assert.NoFileExists(t, filepath.Join(coldPath, f.FileName))
+	})
+	t.Run("verified copy", func(t *testing.T) {
+		if err := dir.Put(fileName, f.FileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, verifyColdCopy(conf, dir, f, fileName))
+	})
+}
@@ -0,0 +1,174 @@
+package photoprism
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// UploadError is returned if an uploaded file failed verification and must be quarantined.
+type UploadError struct {
+	FileName string
+	Reason   string
+}
+
+// Error returns the error message including the file name and the reason.
+func (e UploadError) Error() string {
+	return fmt.Sprintf("%s %s", txt.Quote(filepath.Base(e.FileName)), e.Reason)
+}
+
+// uploadMimeTypes contains the content types of file types that can be detected by sniffing, so that
+// files with a forged extension are recognized.
+var uploadMimeTypes = map[fs.FileType]string{
+	fs.TypeJpeg:   "image/jpeg",
+	fs.TypePng:    "image/png",
+	fs.TypeGif:    "image/gif",
+	fs.TypeBitmap: "image/bmp",
+}
+
+// uploadBlockedMimeTypes contains content types that must never be uploaded, regardless of the extension.
+var uploadBlockedMimeTypes = []string{
+	"text/html",
+	"application/pdf",
+	"application/postscript",
+	"application/zip",
+	"application/x-gzip",
+	"application/x-rar-compressed",
+	"application/wasm",
+}
+
+// uploadExecMagic contains the signatures of executables and scripts.
+var uploadExecMagic = [][]byte{
+	[]byte("MZ"),             // Windows
+	[]byte("\x7fELF"),        // Linux
+	[]byte("#!"),             // Shell scripts
+	{0xCA, 0xFE, 0xBA, 0xBE}, // macOS universal binaries, Java classes
+	{0xCF, 0xFA, 0xED, 0xFE}, // macOS 64-bit
+	{0xCE, 0xFA, 0xED, 0xFE}, // macOS 32-bit
+}
+
+// CheckUpload returns an UploadError if the extension of an uploaded file is not supported or doesn't
+// match its content, if it contains executable code, or if an image exceeds the max resolution.
+func CheckUpload(conf *config.Config, fileName string) error {
+	fileType := fs.GetFileType(fileName)
+
+	if fileType == fs.TypeOther {
+		return UploadError{FileName: fileName, Reason: "has an unsupported extension"}
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	// Only the first 512 bytes are used to sniff the content type.
+	header := make([]byte, 512)
+
+	n, err := f.Read(header)
+
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	header = header[:n]
+
+	for _, magic := range uploadExecMagic {
+		if bytes.HasPrefix(header, magic) {
+			return UploadError{FileName: fileName, Reason: "contains executable code"}
+		}
+	}
+
+	mimeType := http.DetectContentType(header)
+
+	for _, blocked := range uploadBlockedMimeTypes {
+		if strings.HasPrefix(mimeType, blocked) {
+			return UploadError{FileName: fileName, Reason: fmt.Sprintf("has a forbidden content type (%s)", blocked)}
+		}
+	}
+
+	expected, ok := uploadMimeTypes[fileType]
+
+	if !ok {
+		return nil
+	} else if !strings.HasPrefix(mimeType, expected) {
+		return UploadError{FileName: fileName, Reason: fmt.Sprintf("content doesn't match extension (%s)", mimeType)}
+	}
+
+	maxRes := conf.UploadMaxResolution()
+
+	if maxRes <= 0 || fileType == fs.TypeBitmap {
+		return nil
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	// Only decodes the image header, so that decompression bombs are detected without running out of memory.
+	cfg, _, err := image.DecodeConfig(f)
+
+	if err != nil {
+		return UploadError{FileName: fileName, Reason: "can't be decoded"}
+	} else if cfg.Width > maxRes || cfg.Height > maxRes {
+		return UploadError{FileName: fileName, Reason: fmt.Sprintf("exceeds the max resolution of %d px (%dx%d)", maxRes, cfg.Width, cfg.Height)}
+	}
+
+	return nil
+}
+
+// ReencodeUpload decodes and encodes an uploaded JPEG or PNG image to remove data that is not part of the
+// image itself, e.g. appended archives and scripts. Metadata is removed as well, so the orientation is
+// applied first. Other file types are skipped.
+func ReencodeUpload(fileName string) error {
+	var format imaging.Format
+
+	switch fs.GetFileType(fileName) {
+	case fs.TypeJpeg:
+		format = imaging.JPEG
+	case fs.TypePng:
+		format = imaging.PNG
+	default:
+		return nil
+	}
+
+	img, err := imaging.Open(fileName, imaging.AutoOrientation(true))
+
+	if err != nil {
+		return UploadError{FileName: fileName, Reason: "can't be decoded"}
+	}
+
+	tmpName := fileName + ".tmp"
+
+	f, err := os.Create(tmpName)
+
+	if err != nil {
+		return err
+	}
+
+	if err := imaging.Encode(f, img, format, imaging.JPEGQuality(thumb.JpegQuality)); err != nil {
+		f.Close()
+		os.Remove(tmpName)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, fileName)
+}
@@ -0,0 +1,160 @@
+package photoprism
+
+import (
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUpload(t *testing.T) {
+	conf := config.TestConfig()
+	dir := filepath.Join(conf.TempPath(), "upload_check")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	write := func(name string, data []byte) string {
+		fileName := filepath.Join(dir, name)
+
+		if err := ioutil.WriteFile(fileName, data, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		return fileName
+	}
+
+	t.Run("jpeg", func(t *testing.T) {
+		assert.NoError(t, CheckUpload(conf, filepath.Join(conf.ExamplesPath(), "cat_brown.jpg")))
+	})
+	t.Run("unsupported extension", func(t *testing.T) {
+		err := CheckUpload(conf, write("setup.exe", []byte("foo")))
+
+		if assert.IsType(t, UploadError{}, err) {
+			assert.Contains(t, err.Error(), "unsupported extension")
+		}
+	})
+	t.Run("executable", func(t *testing.T) {
+		err := CheckUpload(conf, write("executable.jpg", []byte("MZ\x90\x00\x03\x00\x00\x00")))
+
+		if assert.IsType(t, UploadError{}, err) {
+			assert.Contains(t, err.Error(), "executable code")
+		}
+	})
+	t.Run("html", func(t *testing.T) {
+		err := CheckUpload(conf, write("page.heic", []byte("<!DOCTYPE html><html><script>alert(1)</script></html>")))
+
+		if assert.IsType(t, UploadError{}, err) {
+			assert.Contains(t, err.Error(), "text/html")
+		}
+	})
+	t.Run("forged extension", func(t *testing.T) {
+		err := CheckUpload(conf, write("image.png", []byte("GIF89a\x01\x00\x01\x00")))
+
+		if assert.IsType(t, UploadError{}, err) {
+			assert.Contains(t, err.Error(), "doesn't match extension")
+		}
+	})
+	t.Run("sidecar", func(t *testing.T) {
+		assert.NoError(t, CheckUpload(conf, write("image.xmp", []byte(`<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>`))))
+	})
+}
+
+func TestReencodeUpload(t *testing.T) {
+	conf := config.TestConfig()
+	dir := filepath.Join(conf.TempPath(), "upload_reencode")
+	fileName := filepath.Join(dir, "image.jpg")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := imaging.Save(imaging.New(30, 20, color.White), fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY, 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Write([]byte("PK\x03\x04 appended archive")); err != nil {
+		t.Fatal(err)
+	}
+
+	f.Close()
+
+	assert.NoError(t, ReencodeUpload(fileName))
+
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotContains(t, string(data), "appended archive")
+	assert.Equal(t, "image/jpeg", fs.MimeType(fileName))
+
+	t.Run("broken", func(t *testing.T) {
+		brokenName := filepath.Join(dir, "broken.png")
+
+		if err := ioutil.WriteFile(brokenName, []byte("\x89PNG\r\n\x1a\nfoo"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, UploadError{}, ReencodeUpload(brokenName))
+	})
+}
+
+func TestQuarantine(t *testing.T) {
+	conf := config.TestConfig()
+	fileName := filepath.Join(conf.ImportPath(), "quarantine_test.jpg")
+
+	if err := ioutil.WriteFile(fileName, []byte("MZ\x90\x00"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Quarantine(conf, fileName, "upload", "test@example.com", UploadError{FileName: fileName, Reason: "contains executable code"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, fs.FileExists(fileName))
+	assert.True(t, fs.FileExists(filepath.Join(conf.QuarantinePath(), m.FileName)))
+	assert.Equal(t, "quarantine_test.jpg", m.OriginalName)
+	assert.Equal(t, int64(4), m.FileSize)
+	assert.Contains(t, m.Reason, "executable")
+
+	released, err := ReleaseQuarantined(conf, *m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "quarantine/quarantine_test.jpg", released)
+	assert.True(t, fs.FileExists(filepath.Join(conf.ImportPath(), released)))
+
+	fileName = filepath.Join(conf.ImportPath(), released)
+
+	m, err = Quarantine(conf, fileName, "webdav", "", UploadError{FileName: fileName, Reason: "contains executable code"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, DeleteQuarantined(conf, *m))
+	assert.False(t, fs.FileExists(filepath.Join(conf.QuarantinePath(), m.FileName)))
+}
@@ -0,0 +1,84 @@
+package prints
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Source represents an original image to be printed.
+type Source struct {
+	FileName string
+	PhotoUID string
+}
+
+// Export resizes the source images to the print size and writes them to a zip archive along with the order sheet.
+// Images that can't be opened are skipped.
+func (o *Order) Export(sources []Source, zipName string) error {
+	f, err := os.Create(zipName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	for _, src := range sources {
+		img, err := imaging.Open(src.FileName, imaging.AutoOrientation(true))
+
+		if err != nil {
+			log.Errorf("prints: can't open %s (%s)", txt.Quote(src.FileName), err)
+			continue
+		}
+
+		img = o.Size.Fill(img)
+		bounds := img.Bounds()
+
+		item := Item{
+			FileName: fmt.Sprintf("%04d_%s.jpg", len(o.Items)+1, o.Size.Name),
+			PhotoUID: src.PhotoUID,
+			Width:    bounds.Dx(),
+			Height:   bounds.Dy(),
+		}
+
+		// Images are stored without compression as JPEG data is compressed already.
+		writer, err := w.CreateHeader(&zip.FileHeader{Name: item.FileName, Method: zip.Store, Modified: o.CreatedAt})
+
+		if err != nil {
+			return err
+		}
+
+		if err := imaging.Encode(writer, img, imaging.JPEG, imaging.JPEGQuality(95)); err != nil {
+			return err
+		}
+
+		o.Items = append(o.Items, item)
+	}
+
+	if len(o.Items) == 0 {
+		return ErrEmpty
+	}
+
+	sheet, err := o.CSV()
+
+	if err != nil {
+		return err
+	}
+
+	writer, err := w.CreateHeader(&zip.FileHeader{Name: OrderSheet, Method: zip.Deflate, Modified: o.CreatedAt})
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(sheet); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,71 @@
+package prints
+
+import (
+	"archive/zip"
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_Export(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "TestOrder_Export")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	imageName := filepath.Join(dir, "image.jpg")
+
+	if err := imaging.Save(image.NewRGBA(image.Rect(0, 0, 600, 400)), imageName); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		o, err := NewOrder("at9lxuqxpogaaba7", "Christmas", "9x13", 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		zipName := filepath.Join(dir, "order.zip")
+		sources := []Source{{FileName: imageName, PhotoUID: "pt9jtdre2lvl0yh7"}, {FileName: filepath.Join(dir, "missing.jpg")}}
+
+		if err := o.Export(sources, zipName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, o.Items, 1)
+		assert.Equal(t, "0001_9x13.jpg", o.Items[0].FileName)
+		assert.Equal(t, 1500, o.Items[0].Width)
+		assert.Equal(t, 1051, o.Items[0].Height)
+
+		r, err := zip.OpenReader(zipName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer r.Close()
+
+		assert.Len(t, r.File, 2)
+		assert.Equal(t, "0001_9x13.jpg", r.File[0].Name)
+		assert.Equal(t, OrderSheet, r.File[1].Name)
+	})
+	t.Run("empty", func(t *testing.T) {
+		o, err := NewOrder("at9lxuqxpogaaba7", "Christmas", "9x13", 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = o.Export([]Source{}, filepath.Join(dir, "empty.zip"))
+
+		assert.Equal(t, ErrEmpty, err)
+	})
+}
@@ -0,0 +1,92 @@
+package prints
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HttpServiceName is the name of the built-in service that submits orders to a web API.
+const HttpServiceName = "http"
+
+// HttpService submits print orders as multipart form with the order as JSON and the exported zip archive.
+type HttpService struct {
+	Url    string
+	Key    string
+	Client *http.Client
+}
+
+// NewHttpService returns a new print service that submits orders to the given url.
+func NewHttpService(url, key string) *HttpService {
+	return &HttpService{Url: url, Key: key, Client: &http.Client{Timeout: 5 * time.Minute}}
+}
+
+// Submit uploads the order and the exported images.
+func (s *HttpService) Submit(o *Order, zipName string) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	orderJson, err := json.Marshal(o)
+
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteField("order", string(orderJson)); err != nil {
+		return err
+	}
+
+	f, err := os.Open(zipName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	part, err := w.CreateFormFile("files", filepath.Base(zipName))
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, f); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.Url, body)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	if s.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Key)
+	}
+
+	resp, err := s.Client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("prints: service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package prints
+
+import (
+	"encoding/json"
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpService_Submit(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "TestHttpService_Submit")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	imageName := filepath.Join(dir, "image.jpg")
+
+	if err := imaging.Save(image.NewRGBA(image.Rect(0, 0, 300, 400)), imageName); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := NewOrder("at9lxuqxpogaaba7", "Christmas", "10x15", 3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zipName := filepath.Join(dir, "order.zip")
+
+	if err := o.Export([]Source{{FileName: imageName, PhotoUID: "pt9jtdre2lvl0yh7"}}, zipName); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+			var received Order
+
+			if err := json.Unmarshal([]byte(r.FormValue("order")), &received); err != nil {
+				t.Error(err)
+			}
+
+			assert.Equal(t, 3, received.Copies)
+			assert.Len(t, received.Items, 1)
+
+			_, header, err := r.FormFile("files")
+
+			if err != nil {
+				t.Error(err)
+			} else {
+				assert.Equal(t, "order.zip", header.Filename)
+			}
+
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		defer server.Close()
+
+		assert.NoError(t, NewHttpService(server.URL, "secret").Submit(o, zipName))
+	})
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+
+		defer server.Close()
+
+		err := NewHttpService(server.URL, "").Submit(o, zipName)
+
+		assert.EqualError(t, err, "prints: service returned status 401")
+	})
+}
@@ -0,0 +1,80 @@
+package prints
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+)
+
+// OrderSheet is the file name of the order sheet in exported archives.
+const OrderSheet = "order.csv"
+
+// Item represents an exported image in a print order.
+type Item struct {
+	FileName string `json:"FileName"`
+	PhotoUID string `json:"PhotoUID"`
+	Width    int    `json:"Width"`
+	Height   int    `json:"Height"`
+}
+
+// Order represents a print order for an album.
+type Order struct {
+	AlbumUID   string    `json:"AlbumUID"`
+	AlbumTitle string    `json:"AlbumTitle"`
+	Size       Size      `json:"Size"`
+	Copies     int       `json:"Copies"`
+	Items      []Item    `json:"Items"`
+	CreatedAt  time.Time `json:"CreatedAt"`
+}
+
+// NewOrder returns a new print order for an album.
+func NewOrder(albumUID, albumTitle, size string, copies int) (*Order, error) {
+	s, ok := Sizes[size]
+
+	if !ok {
+		return nil, ErrSize
+	}
+
+	if copies < 1 {
+		copies = 1
+	}
+
+	return &Order{
+		AlbumUID:   albumUID,
+		AlbumTitle: albumTitle,
+		Size:       s,
+		Copies:     copies,
+		Items:      []Item{},
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// CSV returns the order sheet with one line per image.
+func (o *Order) CSV() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	if err := w.Write([]string{"File", "Size", "Copies", "Width", "Height", "PhotoUID"}); err != nil {
+		return nil, err
+	}
+
+	for _, item := range o.Items {
+		record := []string{
+			item.FileName,
+			o.Size.Name,
+			strconv.Itoa(o.Copies),
+			strconv.Itoa(item.Width),
+			strconv.Itoa(item.Height),
+			item.PhotoUID,
+		}
+
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,44 @@
+package prints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		o, err := NewOrder("at9lxuqxpogaaba7", "Christmas", "13x18", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "13x18", o.Size.Name)
+		assert.Equal(t, 1, o.Copies)
+		assert.Empty(t, o.Items)
+	})
+	t.Run("unknown size", func(t *testing.T) {
+		_, err := NewOrder("at9lxuqxpogaaba7", "Christmas", "1x1", 1)
+
+		assert.Equal(t, ErrSize, err)
+	})
+}
+
+func TestOrder_CSV(t *testing.T) {
+	o, err := NewOrder("at9lxuqxpogaaba7", "Christmas", "10x15", 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o.Items = append(o.Items, Item{FileName: "0001_10x15.jpg", PhotoUID: "pt9jtdre2lvl0yh7", Width: 1795, Height: 1205})
+
+	sheet, err := o.CSV()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "File,Size,Copies,Width,Height,PhotoUID\n0001_10x15.jpg,10x15,2,1795,1205,pt9jtdre2lvl0yh7\n", string(sheet))
+}
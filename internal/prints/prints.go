@@ -0,0 +1,26 @@
+/*
+This package exports albums for ordering physical prints.
+
+Photos are cropped and resized to the selected print size at 300 dpi and stored as JPEG files in a
+zip archive, together with an order sheet in CSV format that most print labs accept. Orders can
+optionally be submitted to an external print service, see Service.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package prints
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+var (
+	ErrSize    = errors.New("prints: unknown size")
+	ErrEmpty   = errors.New("prints: no photos")
+	ErrService = errors.New("prints: unknown service")
+)
@@ -0,0 +1,33 @@
+package prints
+
+import (
+	"sync"
+)
+
+// Service submits exported print orders to a print lab.
+type Service interface {
+	Submit(o *Order, zipName string) error
+}
+
+var services = make(map[string]Service)
+var servicesMutex = sync.RWMutex{}
+
+// Register adds a print service that can be selected by name.
+func Register(name string, s Service) {
+	servicesMutex.Lock()
+	defer servicesMutex.Unlock()
+
+	services[name] = s
+}
+
+// Find returns the print service with the given name.
+func Find(name string) (Service, error) {
+	servicesMutex.RLock()
+	defer servicesMutex.RUnlock()
+
+	if s, ok := services[name]; ok {
+		return s, nil
+	}
+
+	return nil, ErrService
+}
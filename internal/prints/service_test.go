@@ -0,0 +1,22 @@
+package prints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	s := NewHttpService("http://localhost/", "")
+
+	Register("test", s)
+
+	result, err := Find("test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, s, result)
+
+	_, err = Find("xxx")
+
+	assert.Equal(t, ErrService, err)
+}
@@ -0,0 +1,60 @@
+package prints
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/disintegration/imaging"
+)
+
+// DPI is the resolution of exported images in dots per inch.
+const DPI = 300
+
+// Size represents a print format in millimeters, portrait orientation.
+type Size struct {
+	Name   string `json:"Name"`
+	Width  int    `json:"Width"`
+	Height int    `json:"Height"`
+}
+
+// Sizes contains the supported print formats by name.
+var Sizes = map[string]Size{
+	"9x13":  {Name: "9x13", Width: 89, Height: 127},
+	"10x15": {Name: "10x15", Width: 102, Height: 152},
+	"13x18": {Name: "13x18", Width: 127, Height: 178},
+	"15x20": {Name: "15x20", Width: 152, Height: 203},
+	"20x30": {Name: "20x30", Width: 203, Height: 305},
+	"30x45": {Name: "30x45", Width: 305, Height: 457},
+}
+
+// SizeNames returns the names of all supported print formats.
+func SizeNames() (names []string) {
+	for name := range Sizes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Pixels returns the image width and height in pixels.
+func (s Size) Pixels() (width, height int) {
+	width = int(math.Round(float64(s.Width) / 25.4 * DPI))
+	height = int(math.Round(float64(s.Height) / 25.4 * DPI))
+
+	return width, height
+}
+
+// Fill crops and resizes an image to the print size, in landscape orientation for landscape images.
+func (s Size) Fill(img image.Image) image.Image {
+	width, height := s.Pixels()
+	bounds := img.Bounds()
+
+	if bounds.Dx() > bounds.Dy() {
+		width, height = height, width
+	}
+
+	return imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
+}
@@ -0,0 +1,40 @@
+package prints
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizeNames(t *testing.T) {
+	names := SizeNames()
+
+	assert.Len(t, names, len(Sizes))
+	assert.Contains(t, names, "10x15")
+}
+
+func TestSize_Pixels(t *testing.T) {
+	width, height := Sizes["10x15"].Pixels()
+
+	assert.Equal(t, 1205, width)
+	assert.Equal(t, 1795, height)
+}
+
+func TestSize_Fill(t *testing.T) {
+	s := Sizes["9x13"]
+	width, height := s.Pixels()
+
+	t.Run("landscape", func(t *testing.T) {
+		img := s.Fill(image.NewRGBA(image.Rect(0, 0, 400, 300)))
+
+		assert.Equal(t, height, img.Bounds().Dx())
+		assert.Equal(t, width, img.Bounds().Dy())
+	})
+	t.Run("portrait", func(t *testing.T) {
+		img := s.Fill(image.NewRGBA(image.Rect(0, 0, 300, 400)))
+
+		assert.Equal(t, width, img.Bounds().Dx())
+		assert.Equal(t, height, img.Bounds().Dy())
+	})
+}
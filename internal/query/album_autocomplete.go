@@ -0,0 +1,49 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// AlbumSuggestion contains an album suggested while typing its title.
+type AlbumSuggestion struct {
+	AlbumUID   string `json:"UID"`
+	ParentUID  string `json:"ParentUID,omitempty"`
+	AlbumSlug  string `json:"Slug"`
+	AlbumTitle string `json:"Title"`
+	PhotoCount int    `json:"PhotoCount"`
+}
+
+// AlbumAutocomplete returns manual albums with titles containing all words of the query in any order,
+// exact and prefix matches first. Recently updated albums are returned if the query is empty.
+func AlbumAutocomplete(q string, limit int) (results []AlbumSuggestion, err error) {
+	results = []AlbumSuggestion{}
+	q = strings.ToLower(strings.TrimSpace(q))
+
+	s := UnscopedDb().Table("albums").
+		Select(`albums.album_uid, albums.parent_uid, albums.album_slug, albums.album_title, 
+			(SELECT COUNT(*) FROM photos_albums WHERE photos_albums.album_uid = albums.album_uid AND photos_albums.hidden = 0) AS photo_count`).
+		Where("albums.deleted_at IS NULL AND albums.album_type = ?", entity.TypeDefault)
+
+	for _, w := range strings.Fields(q) {
+		if wordSlug := slug.Make(w); wordSlug != "" {
+			s = s.Where("LOWER(albums.album_title) LIKE ? OR albums.album_slug LIKE ?", "%"+w+"%", "%"+wordSlug+"%")
+		} else {
+			s = s.Where("LOWER(albums.album_title) LIKE ?", "%"+w+"%")
+		}
+	}
+
+	if q == "" {
+		s = s.Order("albums.updated_at DESC, albums.id DESC")
+	} else {
+		s = s.Order(gorm.Expr("CASE WHEN LOWER(albums.album_title) = ? THEN 0 WHEN LOWER(albums.album_title) LIKE ? THEN 1 ELSE 2 END, "+
+			"albums.album_favorite DESC, albums.updated_at DESC", q, q+"%"))
+	}
+
+	err = s.Limit(limit).Scan(&results).Error
+
+	return results, err
+}
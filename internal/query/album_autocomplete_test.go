@@ -0,0 +1,65 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumAutocomplete(t *testing.T) {
+	t.Run("title", func(t *testing.T) {
+		results, err := AlbumAutocomplete("holiday", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.Equal(t, "at9lxuqxpogaaba8", results[0].AlbumUID)
+	})
+	t.Run("exact match first", func(t *testing.T) {
+		results, err := AlbumAutocomplete("berlin2019", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.Equal(t, "Berlin2019", results[0].AlbumTitle)
+	})
+	t.Run("empty query", func(t *testing.T) {
+		results, err := AlbumAutocomplete("", 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+	})
+	t.Run("no match", func(t *testing.T) {
+		results, err := AlbumAutocomplete("xxx-no-such-album", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestAlbumByTitle(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		album, err := AlbumByTitle(" holiday2030 ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "at9lxuqxpogaaba8", album.AlbumUID)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := AlbumByTitle("xxx-no-such-album")
+
+		assert.Error(t, err)
+	})
+}
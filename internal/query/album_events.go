@@ -0,0 +1,46 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// AlbumEvents returns the activity log of an album, most recent changes first. Visible guest reactions
+// to photos shared with album links are included.
+func AlbumEvents(albumUID string, limit, offset int) (results []entity.AlbumEvent, err error) {
+	results = []entity.AlbumEvent{}
+
+	// Both sources are sorted, so the first limit + offset rows of each are enough for the requested page.
+	if err := Db().Where("album_uid = ?", albumUID).
+		Order("created_at DESC, id DESC").
+		Limit(limit + offset).
+		Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	var reactions []entity.GuestReaction
+
+	if err := Db().Where("share_uid = ? AND hidden = 0", albumUID).
+		Order("created_at DESC, id DESC").
+		Limit(limit + offset).
+		Find(&reactions).Error; err != nil {
+		return results, err
+	}
+
+	for _, r := range reactions {
+		results = append(results, r.AlbumEvent())
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	if offset >= len(results) {
+		return []entity.AlbumEvent{}, nil
+	} else if offset+limit < len(results) {
+		results = results[:offset+limit]
+	}
+
+	return results[offset:], nil
+}
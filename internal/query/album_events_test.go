@@ -0,0 +1,85 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumEvents(t *testing.T) {
+	albumUID := "at9lxuqxpogaaba7"
+
+	if _, err := entity.AddAlbumEvent(albumUID, "query-events@example.com", entity.AlbumEventAdded, 2, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := entity.AddAlbumEvent(albumUID, "query-events@example.com", entity.AlbumEventRenamed, 0, "Christmas 2030"); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := AlbumEvents(albumUID, 10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, entity.AlbumEventRenamed, results[0].EventType)
+	assert.Equal(t, "Christmas 2030", results[0].EventInfo)
+	assert.Equal(t, entity.AlbumEventAdded, results[1].EventType)
+
+	results, err = AlbumEvents(albumUID, 1, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, entity.AlbumEventAdded, results[0].EventType)
+}
+
+func TestAlbumEvents_Reactions(t *testing.T) {
+	albumUID := "at9lxuqxpogaaba6"
+	link := entity.Link{LinkToken: "eventsreaction", ShareUID: albumUID}
+
+	if _, err := entity.AddAlbumEvent(albumUID, "query-events@example.com", entity.AlbumEventShared, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	visible := entity.NewGuestReaction(link, "pt9jtdre2lvl0yh7", "Grandma", "😍", "Lovely")
+	visible.CreatedAt = time.Now().Add(time.Hour)
+
+	if err := visible.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	hidden := entity.NewGuestReaction(link, "pt9jtdre2lvl0yh7", "Spam", "", "Buy now")
+	hidden.Hidden = true
+
+	if err := hidden.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := AlbumEvents(albumUID, 10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, entity.AlbumEventReaction, results[0].EventType)
+	assert.Equal(t, "visitor:Grandma", results[0].UserID)
+	assert.Equal(t, "😍 Lovely", results[0].EventInfo)
+	assert.Equal(t, entity.AlbumEventShared, results[1].EventType)
+
+	results, err = AlbumEvents(albumUID, 1, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, entity.AlbumEventShared, results[0].EventType)
+}
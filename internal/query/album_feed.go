@@ -0,0 +1,68 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// AlbumFeedItem represents a photo recently added to an album, see AlbumFeed.
+type AlbumFeedItem struct {
+	PhotoUID         string    `json:"UID"`
+	PhotoTitle       string    `json:"Title"`
+	PhotoDescription string    `json:"Description"`
+	TakenAt          time.Time `json:"TakenAt"`
+	FileHash         string    `json:"Hash"`
+	AddedAt          time.Time `json:"AddedAt"`
+}
+
+// AlbumFeed returns the photos most recently added to an album, newest first. Private photos are
+// excluded if public is true. Smart albums return the most recently imported photos matching their filter.
+func AlbumFeed(a entity.Album, public bool, limit int) (results []AlbumFeedItem, err error) {
+	results = []AlbumFeedItem{}
+
+	if a.HasFilter() {
+		photos, _, err := PhotoSearch(form.PhotoSearch{
+			Album:  a.AlbumUID,
+			Public: public,
+			Order:  entity.SortOrderImported,
+			Merged: true,
+			Count:  limit,
+		})
+
+		if err != nil {
+			return results, err
+		}
+
+		for _, p := range photos {
+			results = append(results, AlbumFeedItem{
+				PhotoUID:         p.PhotoUID,
+				PhotoTitle:       p.PhotoTitle,
+				PhotoDescription: p.PhotoDescription,
+				TakenAt:          p.TakenAt,
+				FileHash:         p.FileHash,
+				AddedAt:          p.CreatedAt,
+			})
+		}
+
+		return results, nil
+	}
+
+	s := Db().Table("photos_albums").
+		Select(`photos.photo_uid, photos.photo_title, photos.photo_description, photos.taken_at,
+		files.file_hash, photos_albums.created_at AS added_at`).
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid AND photos.deleted_at IS NULL").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0", a.AlbumUID).
+		Order("photos_albums.created_at DESC, photos.photo_uid").
+		Limit(limit)
+
+	if public {
+		s = s.Where("photos.photo_private = 0")
+	}
+
+	err = s.Scan(&results).Error
+
+	return results, err
+}
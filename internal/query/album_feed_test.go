@@ -0,0 +1,43 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumFeed(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		results, err := AlbumFeed(entity.AlbumFixtures.Get("holiday-2030"), false, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(results) == 0 {
+			t.Fatal("at least one result expected")
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", results[0].PhotoUID)
+		assert.NotEmpty(t, results[0].FileHash)
+	})
+	t.Run("limit", func(t *testing.T) {
+		results, err := AlbumFeed(entity.AlbumFixtures.Get("holiday-2030"), true, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+	t.Run("not found", func(t *testing.T) {
+		results, err := AlbumFeed(entity.Album{AlbumUID: "xxx"}, false, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
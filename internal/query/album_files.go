@@ -0,0 +1,81 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// AlbumFile represents the primary file of a photo in an album.
+type AlbumFile struct {
+	PhotoUID string
+	FileHash string
+	FileName string
+}
+
+// AlbumFiles returns the primary files of all photos in an album, oldest first.
+func AlbumFiles(albumUID string) (results []AlbumFile, err error) {
+	err = UnscopedDb().Table("photos_albums").
+		Select("photos_albums.photo_uid, files.file_hash, files.file_name").
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid AND photos.deleted_at IS NULL").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0", albumUID).
+		Order("photos.taken_at, photos_albums.photo_uid").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// FilePhotoUIDs returns the UIDs of photos with files matching the given hashes or names,
+// keyed by file hash and file name. Deleted photos and files are ignored.
+func FilePhotoUIDs(hashes, names []string) (byHash, byName map[string]string, err error) {
+	byHash = make(map[string]string)
+	byName = make(map[string]string)
+
+	// Split lists into batches to stay within the limit of query parameters.
+	batchSize := 500
+
+	find := func(column string, values []string, result map[string]string) error {
+		for i := 0; i < len(values); i += batchSize {
+			j := i + batchSize
+
+			if j > len(values) {
+				j = len(values)
+			}
+
+			var rows []struct {
+				PhotoUID string
+				Value    string
+			}
+
+			if err := UnscopedDb().Table("files").
+				Select("files.photo_uid, files."+column+" AS value").
+				Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+				Where("files.deleted_at IS NULL AND files."+column+" IN (?)", values[i:j]).
+				Scan(&rows).Error; err != nil {
+				return err
+			}
+
+			for _, r := range rows {
+				result[r.Value] = r.PhotoUID
+			}
+		}
+
+		return nil
+	}
+
+	if err := find("file_hash", hashes, byHash); err != nil {
+		return byHash, byName, err
+	}
+
+	if err := find("file_name", names, byName); err != nil {
+		return byHash, byName, err
+	}
+
+	return byHash, byName, nil
+}
+
+// AlbumPhotoUIDs returns the UIDs of all photos in an album, including hidden entries.
+func AlbumPhotoUIDs(albumUID string) (uids []string, err error) {
+	err = Db().Model(&entity.PhotoAlbum{}).Where("album_uid = ?", albumUID).Pluck("photo_uid", &uids).Error
+
+	return uids, err
+}
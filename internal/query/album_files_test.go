@@ -0,0 +1,52 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumFiles(t *testing.T) {
+	t.Run("holiday-2030", func(t *testing.T) {
+		results, err := AlbumFiles("at9lxuqxpogaaba8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", results[0].PhotoUID)
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", results[0].FileHash)
+		assert.Equal(t, "exampleFileName.jpg", results[0].FileName)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		results, err := AlbumFiles("at9lxuqxpogaaxxx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestFilePhotoUIDs(t *testing.T) {
+	byHash, byName, err := FilePhotoUIDs([]string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "0000000000000000000000000000000000000000"}, []string{"bridge.jpg", "notfound.jpg"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818": "pt9jtdre2lvl0yh7"}, byHash)
+	assert.Equal(t, map[string]string{"bridge.jpg": "pt9jtdre2lvl0y11"}, byName)
+}
+
+func TestAlbumPhotoUIDs(t *testing.T) {
+	uids, err := AlbumPhotoUIDs("at9lxuqxpogaaba9")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.ElementsMatch(t, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh8"}, uids)
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"time"
+)
+
+// HighlightCandidate contains the photo properties used to pick album highlights.
+type HighlightCandidate struct {
+	PhotoUID      string    `json:"UID"`
+	TakenAt       time.Time `json:"TakenAt"`
+	PhotoLat      float32   `json:"Lat"`
+	PhotoLng      float32   `json:"Lng"`
+	PlaceUID      string    `json:"PlaceUID"`
+	PhotoQuality  int       `json:"Quality"`
+	PhotoFavorite bool      `json:"Favorite"`
+	PhotoPeople   bool      `json:"People"`
+}
+
+// HighlightCandidates represents a list of highlight candidates.
+type HighlightCandidates []HighlightCandidate
+
+// AlbumHighlightCandidates returns all visible photos in an album including a flag indicating whether
+// people have been detected, based on the "people" and "portrait" labels. Photos with a category like
+// screenshots can be excluded.
+func AlbumHighlightCandidates(albumUID string, excludeCategories bool) (results HighlightCandidates, err error) {
+	s := Db().Table("photos").
+		Select(`photos.photo_uid, photos.taken_at, photos.photo_lat, photos.photo_lng, photos.place_uid, 
+		photos.photo_quality, photos.photo_favorite, 
+		photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON l.id = pl.label_id 
+		WHERE pl.uncertainty < 100 AND l.label_slug IN ('people', 'portrait')) AS photo_people`).
+		Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0", albumUID).
+		Where("photos.deleted_at IS NULL AND photos.photo_quality >= 0").
+		Order("photos.taken_at, photos.photo_uid")
+
+	if excludeCategories {
+		s = s.Where("photos.photo_category IS NULL OR photos.photo_category = ''")
+	}
+
+	if err := s.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,28 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumHighlightCandidates(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		results, err := AlbumHighlightCandidates("at9lxuqxpogaaba8", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(results))
+	})
+	t.Run("not found", func(t *testing.T) {
+		results, err := AlbumHighlightCandidates("xxx", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
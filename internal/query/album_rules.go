@@ -0,0 +1,26 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// AlbumRuleByUID returns an album rule based on the UID.
+func AlbumRuleByUID(uid string) (rule entity.AlbumRule, err error) {
+	if err := Db().Where("rule_uid = ?", uid).First(&rule).Error; err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+// AlbumRules returns all rules of albums that have not been deleted, sorted by name.
+func AlbumRules() (rules entity.AlbumRules, err error) {
+	rules = entity.AlbumRules{}
+
+	err = Db().Table("album_rules").Select("album_rules.*").
+		Joins("JOIN albums ON albums.album_uid = album_rules.album_uid AND albums.deleted_at IS NULL").
+		Order("album_rules.rule_name, album_rules.id").
+		Scan(&rules).Error
+
+	return rules, err
+}
@@ -0,0 +1,66 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumRuleByUID(t *testing.T) {
+	m, err := entity.NewAlbumRule(form.AlbumRule{Name: "Aerial", AlbumUID: "at9lxuqxpogaaba9", Folder: "dji*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rules of albums that don't exist are ignored.
+	orphan, err := entity.NewAlbumRule(form.AlbumRule{Name: "Orphan", AlbumUID: "at9lxuqxpogaxxxx", Folder: "dji*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := orphan.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("existing", func(t *testing.T) {
+		result, err := AlbumRuleByUID(m.RuleUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Aerial", result.RuleName)
+		assert.Equal(t, "dji*", result.RuleFolder)
+	})
+	t.Run("list", func(t *testing.T) {
+		results, err := AlbumRules()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, r := range results {
+			assert.NotEqual(t, orphan.RuleUID, r.RuleUID)
+
+			if r.RuleUID == m.RuleUID {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := AlbumRuleByUID("et9jtdre2lvl0xxx")
+		assert.Error(t, err)
+	})
+}
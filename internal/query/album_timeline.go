@@ -0,0 +1,99 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// TimelinePhoto contains the photo properties shown in the day-by-day view of an album.
+type TimelinePhoto struct {
+	PhotoUID     string    `json:"UID"`
+	PhotoType    string    `json:"Type"`
+	PhotoTitle   string    `json:"Title"`
+	TakenAt      time.Time `json:"TakenAt"`
+	TakenAtLocal time.Time `json:"TakenAtLocal"`
+	FileHash     string    `json:"Hash"`
+	PlaceUID     string    `json:"-"`
+	LocCity      string    `json:"-"`
+	LocCountry   string    `json:"-"`
+}
+
+// TimelinePhotos represents a list of timeline photos sorted by time.
+type TimelinePhotos []TimelinePhoto
+
+// TimelineDay contains the photos taken on a single day and a summary of them.
+type TimelineDay struct {
+	Day       string         `json:"Day"`
+	Start     time.Time      `json:"Start"`
+	End       time.Time      `json:"End"`
+	Count     int            `json:"Count"`
+	Videos    int            `json:"Videos"`
+	Places    []string       `json:"Places"`
+	Countries []string       `json:"Countries"`
+	Photos    TimelinePhotos `json:"Photos"`
+}
+
+// TimelineDays represents a list of days sorted by date.
+type TimelineDays []TimelineDay
+
+// AlbumTimelinePhotos returns all visible photos in an album sorted by the local time they were taken.
+func AlbumTimelinePhotos(albumUID string) (results TimelinePhotos, err error) {
+	s := Db().Table("photos").
+		Select(`photos.photo_uid, photos.photo_type, photos.photo_title, photos.taken_at, photos.taken_at_local,
+		files.file_hash, photos.place_uid, places.loc_city, places.loc_country`).
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Joins("JOIN places ON places.place_uid = photos.place_uid").
+		Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0", albumUID).
+		Where("photos.deleted_at IS NULL AND photos.photo_quality >= 0").
+		Order("photos.taken_at_local, photos.photo_uid")
+
+	if err := s.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// Days groups the photos by the local date they were taken.
+func (photos TimelinePhotos) Days() (days TimelineDays) {
+	days = TimelineDays{}
+
+	var places, countries map[string]bool
+
+	for _, p := range photos {
+		key := p.TakenAtLocal.Format("2006-01-02")
+
+		if len(days) == 0 || days[len(days)-1].Day != key {
+			days = append(days, TimelineDay{Day: key, Start: p.TakenAt, Places: []string{}, Countries: []string{}})
+			places = make(map[string]bool)
+			countries = make(map[string]bool)
+		}
+
+		day := &days[len(days)-1]
+		day.End = p.TakenAt
+		day.Count++
+		day.Photos = append(day.Photos, p)
+
+		if p.PhotoType == entity.TypeVideo {
+			day.Videos++
+		}
+
+		if p.PlaceUID == entity.UnknownPlace.PlaceUID {
+			continue
+		}
+
+		if p.LocCity != "" && !places[p.LocCity] {
+			places[p.LocCity] = true
+			day.Places = append(day.Places, p.LocCity)
+		}
+
+		if p.LocCountry != "" && !countries[p.LocCountry] {
+			countries[p.LocCountry] = true
+			day.Countries = append(day.Countries, p.LocCountry)
+		}
+	}
+
+	return days
+}
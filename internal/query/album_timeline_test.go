@@ -0,0 +1,70 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumTimelinePhotos(t *testing.T) {
+	t.Run("existing album", func(t *testing.T) {
+		results, err := AlbumTimelinePhotos("at9lxuqxpogaaba8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 1; i < len(results); i++ {
+			assert.False(t, results[i].TakenAtLocal.Before(results[i-1].TakenAtLocal))
+		}
+	})
+	t.Run("not existing album", func(t *testing.T) {
+		results, err := AlbumTimelinePhotos("xxx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestTimelinePhotos_Days(t *testing.T) {
+	day1 := time.Date(2020, 7, 1, 9, 0, 0, 0, time.UTC)
+	day2 := time.Date(2020, 7, 2, 23, 30, 0, 0, time.UTC)
+
+	photos := TimelinePhotos{
+		{PhotoUID: "p1", PhotoType: entity.TypeImage, TakenAt: day1, TakenAtLocal: day1, PlaceUID: "de:berlin", LocCity: "Berlin", LocCountry: "de"},
+		{PhotoUID: "p2", PhotoType: entity.TypeVideo, TakenAt: day1.Add(time.Hour), TakenAtLocal: day1.Add(time.Hour), PlaceUID: "de:berlin", LocCity: "Berlin", LocCountry: "de"},
+		{PhotoUID: "p3", PhotoType: entity.TypeImage, TakenAt: day1.Add(3 * time.Hour), TakenAtLocal: day1.Add(3 * time.Hour), PlaceUID: "de:potsdam", LocCity: "Potsdam", LocCountry: "de"},
+		{PhotoUID: "p4", PhotoType: entity.TypeImage, TakenAt: day2, TakenAtLocal: day2, PlaceUID: "zz", LocCity: "Unknown", LocCountry: "zz"},
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		days := TimelinePhotos{}.Days()
+
+		assert.NotNil(t, days)
+		assert.Empty(t, days)
+	})
+	t.Run("two days", func(t *testing.T) {
+		days := photos.Days()
+
+		assert.Len(t, days, 2)
+
+		assert.Equal(t, "2020-07-01", days[0].Day)
+		assert.Equal(t, 3, days[0].Count)
+		assert.Equal(t, 1, days[0].Videos)
+		assert.Equal(t, []string{"Berlin", "Potsdam"}, days[0].Places)
+		assert.Equal(t, []string{"de"}, days[0].Countries)
+		assert.Equal(t, day1, days[0].Start)
+		assert.Equal(t, day1.Add(3*time.Hour), days[0].End)
+
+		assert.Equal(t, "2020-07-02", days[1].Day)
+		assert.Equal(t, 1, days[1].Count)
+		assert.Empty(t, days[1].Places)
+		assert.Empty(t, days[1].Countries)
+		assert.Len(t, days[1].Photos, 1)
+	})
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// TrackPoint represents the position of a photo on the route of a trip, see AlbumTrack.
+type TrackPoint struct {
+	PhotoUID string    `json:"UID"`
+	TakenAt  time.Time `json:"TakenAt"`
+	PhotoLat float32   `json:"Lat"`
+	PhotoLng float32   `json:"Lng"`
+}
+
+// Lat returns the position latitude.
+func (p TrackPoint) Lat() float64 {
+	return float64(p.PhotoLat)
+}
+
+// Lng returns the position longitude.
+func (p TrackPoint) Lng() float64 {
+	return float64(p.PhotoLng)
+}
+
+// AlbumTrack returns the positions of all visible photos in an album that have GPS coordinates,
+// ordered by the time they were taken. Smart albums return up to 1000 photos matching their filter.
+func AlbumTrack(a entity.Album) (results []TrackPoint, err error) {
+	results = []TrackPoint{}
+
+	if a.HasFilter() {
+		photos, _, err := PhotoSearch(form.PhotoSearch{
+			Album:  a.AlbumUID,
+			Order:  entity.SortOrderOldest,
+			Merged: true,
+			Count:  1000,
+		})
+
+		if err != nil {
+			return results, err
+		}
+
+		for _, p := range photos {
+			if p.PhotoLat == 0 && p.PhotoLng == 0 {
+				continue
+			}
+
+			results = append(results, TrackPoint{
+				PhotoUID: p.PhotoUID,
+				TakenAt:  p.TakenAt,
+				PhotoLat: p.PhotoLat,
+				PhotoLng: p.PhotoLng,
+			})
+		}
+
+		return results, nil
+	}
+
+	err = Db().Table("photos").
+		Select("photos.photo_uid, photos.taken_at, photos.photo_lat, photos.photo_lng").
+		Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0", a.AlbumUID).
+		Where("photos.deleted_at IS NULL AND (photos.photo_lat <> 0 OR photos.photo_lng <> 0)").
+		Order("photos.taken_at, photos.photo_uid").
+		Scan(&results).Error
+
+	return results, err
+}
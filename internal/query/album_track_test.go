@@ -0,0 +1,37 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumTrack(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		results, err := AlbumTrack(entity.AlbumFixtures.Get("berlin-2019"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(results) < 2 {
+			t.Fatalf("at least two results expected: %+v", results)
+		}
+
+		for i := 1; i < len(results); i++ {
+			assert.False(t, results[i].TakenAt.Before(results[i-1].TakenAt))
+		}
+
+		assert.NotEqual(t, float64(0), results[0].Lat())
+	})
+	t.Run("not found", func(t *testing.T) {
+		results, err := AlbumTrack(entity.Album{AlbumUID: "xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
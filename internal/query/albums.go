@@ -8,34 +8,40 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // AlbumResult contains found albums
 type AlbumResult struct {
-	ID               uint      `json:"-"`
-	AlbumUID         string    `json:"UID"`
-	CoverUID         string    `json:"CoverUID"`
-	FolderUID        string    `json:"FolderUID"`
-	AlbumSlug        string    `json:"Slug"`
-	AlbumType        string    `json:"Type"`
-	AlbumTitle       string    `json:"Title"`
-	AlbumCategory    string    `json:"Category"`
-	AlbumCaption     string    `json:"Caption"`
-	AlbumDescription string    `json:"Description"`
-	AlbumNotes       string    `json:"Notes"`
-	AlbumFilter      string    `json:"Filter"`
-	AlbumOrder       string    `json:"Order"`
-	AlbumTemplate    string    `json:"Template"`
-	AlbumCountry     string    `json:"Country"`
-	AlbumYear        int       `json:"Year"`
-	AlbumMonth       int       `json:"Month"`
-	AlbumFavorite    bool      `json:"Favorite"`
-	AlbumPrivate     bool      `json:"Private"`
-	PhotoCount       int       `json:"PhotoCount"`
-	LinkCount        int       `json:"LinkCount"`
-	CreatedAt        time.Time `json:"CreatedAt"`
-	UpdatedAt        time.Time `json:"UpdatedAt"`
-	DeletedAt        time.Time `json:"DeletedAt,omitempty"`
+	ID               uint          `json:"-"`
+	AlbumUID         string        `json:"UID"`
+	CoverUID         string        `json:"CoverUID"`
+	FolderUID        string        `json:"FolderUID"`
+	ParentUID        string        `json:"ParentUID"`
+	AlbumSlug        string        `json:"Slug"`
+	AlbumType        string        `json:"Type"`
+	AlbumTitle       string        `json:"Title"`
+	AlbumCategory    string        `json:"Category"`
+	AlbumCaption     string        `json:"Caption"`
+	AlbumDescription string        `json:"Description"`
+	AlbumNotes       string        `json:"Notes"`
+	AlbumLocation    string        `json:"Location"`
+	AlbumFilter      string        `json:"Filter"`
+	AlbumOrder       string        `json:"Order"`
+	AlbumTemplate    string        `json:"Template"`
+	AlbumCountry     string        `json:"Country"`
+	AlbumYear        int           `json:"Year"`
+	AlbumMonth       int           `json:"Month"`
+	AlbumDay         int           `json:"Day"`
+	AlbumFavorite    bool          `json:"Favorite"`
+	AlbumPrivate     bool          `json:"Private"`
+	PhotoCount       int           `json:"PhotoCount"`
+	LinkCount        int           `json:"LinkCount"`
+	CreatedAt        time.Time     `json:"CreatedAt"`
+	UpdatedAt        time.Time     `json:"UpdatedAt"`
+	DeletedAt        time.Time     `json:"DeletedAt,omitempty"`
+	Children         []AlbumResult `json:"Children,omitempty" gorm:"-"`
 }
 
 // AlbumByUID returns a Album based on the UID.
@@ -47,8 +53,63 @@ func AlbumByUID(albumUID string) (album entity.Album, err error) {
 	return album, nil
 }
 
-// AlbumThumbByUID returns a album preview file based on the uid.
+// AlbumByTitle returns the manual album with the given title, ignoring case.
+func AlbumByTitle(title string) (album entity.Album, err error) {
+	err = Db().Where("album_type = ? AND LOWER(album_title) = ?", entity.TypeDefault, strings.ToLower(strings.TrimSpace(title))).
+		Order("id").First(&album).Error
+
+	return album, err
+}
+
+// AlbumUIDs returns the UIDs of albums in a comma-separated list of album UIDs or slugs,
+// so that albums can also be searched by name like "album:holiday-2030".
+func AlbumUIDs(albums string) (uids []string, err error) {
+	var slugs []string
+
+	for _, a := range strings.Split(albums, ",") {
+		if a = strings.TrimSpace(a); a == "" {
+			continue
+		} else if rnd.IsPPID(a, 'a') {
+			uids = append(uids, a)
+		} else {
+			slugs = append(slugs, strings.ToLower(a))
+		}
+	}
+
+	if len(slugs) == 0 {
+		return uids, nil
+	}
+
+	var found []string
+
+	if err := Db().Model(&entity.Album{}).Where("album_slug IN (?)", slugs).Pluck("album_uid", &found).Error; err != nil {
+		return uids, err
+	} else if len(found) == 0 {
+		return uids, fmt.Errorf("album %s not found", txt.Quote(strings.Join(slugs, ", ")))
+	}
+
+	return append(uids, found...), nil
+}
+
+// AlbumThumbByUID returns a album preview file based on the uid, the selected cover photo is preferred.
 func AlbumThumbByUID(albumUID string) (file entity.File, err error) {
+	// Smart albums use the best photo matching their filter.
+	if a, err := AlbumByUID(albumUID); err == nil && a.HasFilter() {
+		return filterThumb(albumUID)
+	}
+
+	// Use cover photo if selected and still in the album.
+	err = Db().
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.file_type = 'jpg' AND files.deleted_at IS NULL").
+		Joins("JOIN albums ON albums.album_uid = ? AND albums.cover_uid = files.photo_uid", albumUID).
+		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = files.photo_uid AND pa.hidden = 0").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		First(&file).Error
+
+	if err == nil {
+		return file, nil
+	}
+
 	if err := Db().
 		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.file_type = 'jpg' AND files.deleted_at IS NULL").
 		Joins("JOIN albums ON albums.album_uid = ?", albumUID).
@@ -62,8 +123,118 @@ func AlbumThumbByUID(albumUID string) (file entity.File, err error) {
 	return file, nil
 }
 
+// filterThumb returns the preview file of a smart album.
+func filterThumb(albumUID string) (file entity.File, err error) {
+	f := form.PhotoSearch{Album: albumUID, Public: true, Order: entity.SortOrderRelevance, Count: 1}
+
+	results, _, err := PhotoSearch(f)
+
+	if err != nil {
+		return file, err
+	} else if len(results) == 0 {
+		return file, fmt.Errorf("album %s is empty", albumUID)
+	}
+
+	return FileByUID(results[0].FileUID)
+}
+
+// albumTree nests albums in their parent albums and returns the albums in the parent album with the given
+// UID, or top level albums if empty or "none". Albums are top level if their parent isn't in the list.
+// Count and offset apply to the returned albums, not to nested albums.
+func albumTree(albums []AlbumResult, parentUID string, count, offset int) []AlbumResult {
+	result := nestAlbums(albums, parentUID)
+
+	if count <= 0 || count > 1000 {
+		count = 100
+	}
+
+	if offset >= len(result) {
+		return []AlbumResult{}
+	} else if offset > 0 {
+		result = result[offset:]
+	}
+
+	if len(result) > count {
+		result = result[:count]
+	}
+
+	return result
+}
+
+// nestAlbums nests albums in their parent albums like albumTree, without limiting the number of results.
+func nestAlbums(albums []AlbumResult, parentUID string) []AlbumResult {
+	found := make(map[string]bool, len(albums))
+
+	for _, a := range albums {
+		found[a.AlbumUID] = true
+	}
+
+	children := make(map[string][]int)
+
+	for i, a := range albums {
+		if found[a.ParentUID] {
+			children[a.ParentUID] = append(children[a.ParentUID], i)
+		} else {
+			children[""] = append(children[""], i)
+		}
+	}
+
+	seen := make(map[string]bool, len(albums))
+
+	var nest func(uid string) []AlbumResult
+
+	nest = func(uid string) (result []AlbumResult) {
+		for _, i := range children[uid] {
+			a := albums[i]
+
+			if seen[a.AlbumUID] {
+				continue
+			}
+
+			seen[a.AlbumUID] = true
+			a.Children = nest(a.AlbumUID)
+			result = append(result, a)
+		}
+
+		return result
+	}
+
+	if parentUID == "none" {
+		parentUID = ""
+	}
+
+	return nest(parentUID)
+}
+
+// AlbumHasPhoto returns true if the photo is visible in the album.
+func AlbumHasPhoto(albumUID, photoUID string) bool {
+	var count int
+
+	if err := Db().Model(&entity.PhotoAlbum{}).
+		Where("album_uid = ? AND photo_uid = ? AND hidden = 0", albumUID, photoUID).
+		Count(&count).Error; err != nil {
+		log.Errorf("albums: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
 // AlbumSearch searches albums based on their name.
 func AlbumSearch(f form.AlbumSearch) (results []AlbumResult, err error) {
+	return albumSearch(f, nil)
+}
+
+// AlbumSearchTotal searches albums like AlbumSearch and additionally returns the total number of
+// matching albums regardless of count and offset, so that clients can show pagination.
+func AlbumSearchTotal(f form.AlbumSearch) (results []AlbumResult, total int, err error) {
+	results, err = albumSearch(f, &total)
+
+	return results, total, err
+}
+
+// albumSearch runs an album search and counts all matches if total isn't nil.
+func albumSearch(f form.AlbumSearch, total *int) (results []AlbumResult, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
@@ -72,18 +243,47 @@ func AlbumSearch(f form.AlbumSearch) (results []AlbumResult, err error) {
 
 	s := Db().NewScope(nil).DB()
 
-	s = s.Table("albums").
-		Select(`albums.*, 
+	s = s.Table("albums").Where("albums.deleted_at IS NULL")
+
+	if f.ID != "" {
+		s = s.Where("albums.album_uid = ?", f.ID)
+	} else {
+		if f.Query != "" {
+			likeString := "%" + strings.ToLower(f.Query) + "%"
+			s = s.Where("LOWER(albums.album_title) LIKE ?", likeString)
+		}
+
+		if f.Favorite {
+			s = s.Where("albums.album_favorite = 1")
+		}
+
+		// The tree contains all nested albums, so the parent filter is applied afterwards.
+		if !f.Tree {
+			switch f.Parent {
+			case "":
+			case "none":
+				s = s.Where("albums.parent_uid IS NULL OR albums.parent_uid = ''")
+			default:
+				s = s.Where("albums.parent_uid = ?", f.Parent)
+			}
+		}
+	}
+
+	// Count matching albums before photos and links are joined.
+	if total != nil && !f.Tree {
+		if err := searchCount(s, total); err != nil {
+			return results, err
+		}
+	}
+
+	s = s.Select(timeoutHint() + `albums.*, 
 			COUNT(photos_albums.album_uid) AS photo_count,
 			COUNT(links.link_token) AS link_count`).
 		Joins("LEFT JOIN photos_albums ON photos_albums.album_uid = albums.album_uid").
 		Joins("LEFT JOIN links ON links.share_uid = albums.album_uid").
-		Where("albums.deleted_at IS NULL").
 		Group("albums.id")
 
 	if f.ID != "" {
-		s = s.Where("albums.album_uid = ?", f.ID)
-
 		if result := s.Scan(&results); result.Error != nil {
 			return results, result.Error
 		}
@@ -91,15 +291,6 @@ func AlbumSearch(f form.AlbumSearch) (results []AlbumResult, err error) {
 		return results, nil
 	}
 
-	if f.Query != "" {
-		likeString := "%" + strings.ToLower(f.Query) + "%"
-		s = s.Where("LOWER(albums.album_title) LIKE ?", likeString)
-	}
-
-	if f.Favorite {
-		s = s.Where("albums.album_favorite = 1")
-	}
-
 	switch f.Order {
 	case "slug":
 		s = s.Order("albums.album_favorite DESC, album_slug ASC")
@@ -107,6 +298,19 @@ func AlbumSearch(f form.AlbumSearch) (results []AlbumResult, err error) {
 		s = s.Order("albums.album_favorite DESC, photo_count DESC, albums.created_at DESC")
 	}
 
+	// The tree contains all nested albums, so the parent filter and limit are applied afterwards.
+	if f.Tree {
+		if result := s.Scan(&results); result.Error != nil {
+			return results, searchErr(result.Error)
+		}
+
+		if total != nil {
+			*total = len(nestAlbums(results, f.Parent))
+		}
+
+		return albumTree(results, f.Parent, f.Count, f.Offset), nil
+	}
+
 	if f.Count > 0 && f.Count <= 1000 {
 		s = s.Limit(f.Count).Offset(f.Offset)
 	} else {
@@ -114,7 +318,7 @@ func AlbumSearch(f form.AlbumSearch) (results []AlbumResult, err error) {
 	}
 
 	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
+		return results, searchErr(result.Error)
 	}
 
 	return results, nil
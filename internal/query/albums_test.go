@@ -3,6 +3,7 @@ package query
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	form "github.com/photoprism/photoprism/internal/form"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +37,51 @@ func TestAlbumThumbByUID(t *testing.T) {
 		assert.Equal(t, "exampleFileName.jpg", file.FileName)
 	})
 
+	t.Run("cover", func(t *testing.T) {
+		album := entity.NewAlbum("Cover Query", entity.TypeDefault)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum("pt9jtdre2lvl0yh7", album.AlbumUID))
+		entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum("pt9jtdre2lvl0y11", album.AlbumUID))
+
+		for photoUID, fileName := range map[string]string{"pt9jtdre2lvl0y11": "bridge.jpg", "pt9jtdre2lvl0yh7": "exampleFileName.jpg"} {
+			if err := album.SetCover(photoUID); err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := AlbumThumbByUID(album.AlbumUID)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, fileName, file.FileName)
+		}
+	})
+	t.Run("cover not in album", func(t *testing.T) {
+		album := entity.NewAlbum("Cover Removed", entity.TypeDefault)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum("pt9jtdre2lvl0y11", album.AlbumUID))
+
+		if err := album.SetCover("pt9jtdre2lvl0yh7"); err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := AlbumThumbByUID(album.AlbumUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "bridge.jpg", file.FileName)
+	})
 	t.Run("not existing uid", func(t *testing.T) {
 		file, err := AlbumThumbByUID("3765")
 		assert.Error(t, err, "record not found")
@@ -43,6 +89,173 @@ func TestAlbumThumbByUID(t *testing.T) {
 	})
 }
 
+func TestAlbumSearchTotal(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
+		result, total, err := AlbumSearchTotal(form.AlbumSearch{Count: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.LessOrEqual(t, 3, total)
+
+		all, err := AlbumSearch(form.AlbumSearch{Count: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(all), total)
+	})
+	t.Run("id", func(t *testing.T) {
+		result, total, err := AlbumSearchTotal(form.AlbumSearch{ID: "at9lxuqxpogaaba8"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1, total)
+	})
+	t.Run("tree", func(t *testing.T) {
+		result, total, err := AlbumSearchTotal(form.AlbumSearch{Tree: true, Parent: "none", Count: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.LessOrEqual(t, 2, total)
+	})
+}
+
+func TestAlbumSearch_Tree(t *testing.T) {
+	year := entity.NewAlbum("2023", entity.TypeDefault)
+	vacations := entity.NewAlbum("Vacations", entity.TypeDefault)
+	italy := entity.NewAlbum("Italy", entity.TypeDefault)
+
+	for _, a := range []*entity.Album{year, vacations, italy} {
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := vacations.SetParent(year.AlbumUID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := italy.SetParent(vacations.AlbumUID); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("parent", func(t *testing.T) {
+		f := form.AlbumSearch{Parent: year.AlbumUID, Count: 10}
+
+		result, err := AlbumSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, vacations.AlbumUID, result[0].AlbumUID)
+		assert.Empty(t, result[0].Children)
+	})
+	t.Run("top level", func(t *testing.T) {
+		f := form.AlbumSearch{Parent: "none", Count: 1000}
+
+		result, err := AlbumSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, a := range result {
+			assert.Empty(t, a.ParentUID)
+		}
+	})
+	t.Run("tree", func(t *testing.T) {
+		f := form.AlbumSearch{Tree: true, Count: 1000}
+
+		result, err := AlbumSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, a := range result {
+			assert.NotEqual(t, vacations.AlbumUID, a.AlbumUID)
+			assert.NotEqual(t, italy.AlbumUID, a.AlbumUID)
+
+			if a.AlbumUID == year.AlbumUID {
+				found = true
+				assert.Len(t, a.Children, 1)
+				assert.Equal(t, vacations.AlbumUID, a.Children[0].AlbumUID)
+				assert.Len(t, a.Children[0].Children, 1)
+				assert.Equal(t, italy.AlbumUID, a.Children[0].Children[0].AlbumUID)
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("subtree", func(t *testing.T) {
+		f := form.AlbumSearch{Tree: true, Parent: year.AlbumUID, Count: 10}
+
+		result, err := AlbumSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, vacations.AlbumUID, result[0].AlbumUID)
+		assert.Len(t, result[0].Children, 1)
+	})
+}
+
+func TestAlbumTree(t *testing.T) {
+	albums := []AlbumResult{
+		{AlbumUID: "a1"},
+		{AlbumUID: "a2", ParentUID: "a1"},
+		{AlbumUID: "a3", ParentUID: "a2"},
+		{AlbumUID: "a4", ParentUID: "deleted"},
+		{AlbumUID: "a5"},
+	}
+
+	t.Run("top level", func(t *testing.T) {
+		result := albumTree(albums, "", 10, 0)
+
+		assert.Len(t, result, 3)
+		assert.Equal(t, "a1", result[0].AlbumUID)
+		assert.Equal(t, "a3", result[0].Children[0].Children[0].AlbumUID)
+		assert.Equal(t, "a4", result[1].AlbumUID)
+	})
+	t.Run("count and offset", func(t *testing.T) {
+		result := albumTree(albums, "none", 1, 1)
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "a4", result[0].AlbumUID)
+		assert.Empty(t, albumTree(albums, "", 10, 5))
+	})
+	t.Run("cycle", func(t *testing.T) {
+		result := albumTree([]AlbumResult{{AlbumUID: "a1", ParentUID: "a2"}, {AlbumUID: "a2", ParentUID: "a1"}}, "a1", 10, 0)
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "a2", result[0].AlbumUID)
+		assert.Len(t, result[0].Children, 1)
+		assert.Empty(t, result[0].Children[0].Children)
+	})
+}
+
+func TestAlbumHasPhoto(t *testing.T) {
+	assert.True(t, AlbumHasPhoto("at9lxuqxpogaaba8", "pt9jtdre2lvl0yh7"))
+	assert.False(t, AlbumHasPhoto("at9lxuqxpogaaba8", "pt9jtdre2lvl0y11"))
+	assert.False(t, AlbumHasPhoto("xxx", "pt9jtdre2lvl0yh7"))
+}
+
 func TestAlbums(t *testing.T) {
 	t.Run("search with string", func(t *testing.T) {
 		query := form.NewAlbumSearch("chr")
@@ -122,3 +335,28 @@ func TestAlbums(t *testing.T) {
 		assert.Equal(t, "christmas2030", result[0].AlbumSlug)
 	})
 }
+
+func TestAlbumUIDs(t *testing.T) {
+	t.Run("uid", func(t *testing.T) {
+		uids, err := AlbumUIDs("at9lxuqxpogaaba8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"at9lxuqxpogaaba8"}, uids)
+	})
+	t.Run("slug", func(t *testing.T) {
+		uids, err := AlbumUIDs("at9lxuqxpogaaba7, Holiday-2030")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"at9lxuqxpogaaba7", "at9lxuqxpogaaba8"}, uids)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := AlbumUIDs("no-such-album")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,119 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Checkpoints returns metadata checkpoints, most recent first.
+func Checkpoints(limit, offset int) (results []entity.Checkpoint, err error) {
+	results = []entity.Checkpoint{}
+
+	err = Db().Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&results).Error
+
+	return results, err
+}
+
+// CheckpointByUID returns the checkpoint with the given UID.
+func CheckpointByUID(uid string) (result entity.Checkpoint, err error) {
+	err = Db().Where("checkpoint_uid = ?", uid).First(&result).Error
+
+	return result, err
+}
+
+// CheckpointState returns the current metadata of all photos and albums as saved in checkpoints,
+// including archived photos. Labels with an uncertainty of 100 have been removed and are skipped.
+func CheckpointState() (result entity.CheckpointData, err error) {
+	result = entity.NewCheckpointData()
+
+	var photos []struct {
+		PhotoUID         string
+		PhotoTitle       string
+		TitleSrc         string
+		PhotoDescription string
+		DescriptionSrc   string
+		PhotoFavorite    bool
+		PhotoPrivate     bool
+	}
+
+	if err := UnscopedDb().Table("photos").
+		Select("photo_uid, photo_title, title_src, photo_description, description_src, photo_favorite, photo_private").
+		Scan(&photos).Error; err != nil {
+		return result, err
+	}
+
+	for _, p := range photos {
+		result.Photos[p.PhotoUID] = entity.CheckpointPhotoData{
+			Title:          p.PhotoTitle,
+			TitleSrc:       p.TitleSrc,
+			Description:    p.PhotoDescription,
+			DescriptionSrc: p.DescriptionSrc,
+			Favorite:       p.PhotoFavorite,
+			Private:        p.PhotoPrivate,
+		}
+	}
+
+	var labels []struct {
+		PhotoUID    string
+		LabelUID    string
+		Uncertainty int
+		LabelSrc    string
+	}
+
+	if err := UnscopedDb().Table("photos_labels").
+		Select("photos.photo_uid, labels.label_uid, photos_labels.uncertainty, photos_labels.label_src").
+		Joins("JOIN photos ON photos.id = photos_labels.photo_id").
+		Joins("JOIN labels ON labels.id = photos_labels.label_id AND labels.deleted_at IS NULL").
+		Where("photos_labels.uncertainty < 100").
+		Scan(&labels).Error; err != nil {
+		return result, err
+	}
+
+	for _, l := range labels {
+		if p, ok := result.Photos[l.PhotoUID]; ok {
+			p.Labels = append(p.Labels, entity.CheckpointLabelData{UID: l.LabelUID, Uncertainty: l.Uncertainty, Src: l.LabelSrc})
+			result.Photos[l.PhotoUID] = p
+		}
+	}
+
+	var albums []struct {
+		AlbumUID         string
+		AlbumTitle       string
+		AlbumDescription string
+	}
+
+	if err := Db().Table("albums").
+		Select("album_uid, album_title, album_description").
+		Where("deleted_at IS NULL").
+		Scan(&albums).Error; err != nil {
+		return result, err
+	}
+
+	for _, a := range albums {
+		result.Albums[a.AlbumUID] = entity.CheckpointAlbumData{Title: a.AlbumTitle, Description: a.AlbumDescription}
+	}
+
+	var entries []struct {
+		PhotoUID string
+		AlbumUID string
+	}
+
+	if err := UnscopedDb().Table("photos_albums").
+		Select("photo_uid, album_uid").
+		Where("hidden = 0").
+		Scan(&entries).Error; err != nil {
+		return result, err
+	}
+
+	for _, e := range entries {
+		if _, ok := result.Albums[e.AlbumUID]; !ok {
+			continue
+		}
+
+		if p, ok := result.Photos[e.PhotoUID]; ok {
+			p.Albums = append(p.Albums, e.AlbumUID)
+			result.Photos[e.PhotoUID] = p
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointState(t *testing.T) {
+	result, err := CheckpointState()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result.Photos)
+	assert.NotEmpty(t, result.Albums)
+
+	if album, ok := result.Albums["at9lxuqxpogaaba8"]; assert.True(t, ok) {
+		assert.Equal(t, "Holiday2030", album.Title)
+		assert.Equal(t, "Wonderful christmas", album.Description)
+	}
+
+	if photo, ok := result.Photos["pt9jtdre2lvl0yh7"]; assert.True(t, ok) {
+		assert.Contains(t, photo.Albums, "at9lxuqxpogaaba8")
+	}
+}
+
+func TestCheckpoints(t *testing.T) {
+	m := entity.NewCheckpoint("Query test")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	t.Run("list", func(t *testing.T) {
+		results, err := Checkpoints(10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+	t.Run("by uid", func(t *testing.T) {
+		result, err := CheckpointByUID(m.CheckpointUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Query test", result.CheckpointNote)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := CheckpointByUID("kxxxxxxxxxxxxxxx")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+package query
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Devices returns all registered devices sorted by name.
+func Devices() (results []entity.Device, err error) {
+	results = []entity.Device{}
+
+	err = Db().Order("device_name, id").Find(&results).Error
+
+	return results, err
+}
+
+// DeviceByUID returns the device with the given UID.
+func DeviceByUID(uid string) (result entity.Device, err error) {
+	err = Db().Where("device_uid = ?", uid).First(&result).Error
+
+	return result, err
+}
+
+// DeviceByToken returns the device with the given token, e.g. as sent by a mobile backup app.
+func DeviceByToken(token string) (result entity.Device, err error) {
+	if token == "" {
+		return result, gorm.ErrRecordNotFound
+	}
+
+	err = Db().Where("device_token = ?", token).First(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,54 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevices(t *testing.T) {
+	m, err := entity.NewDevice(form.Device{Name: "Query Phone"}, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		results, err := Devices()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+	t.Run("by uid", func(t *testing.T) {
+		result, err := DeviceByUID(m.DeviceUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Query Phone", result.DeviceName)
+	})
+	t.Run("by token", func(t *testing.T) {
+		result, err := DeviceByToken(m.DeviceToken)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.DeviceUID, result.DeviceUID)
+	})
+	t.Run("token empty", func(t *testing.T) {
+		_, err := DeviceByToken("")
+
+		assert.Error(t, err)
+	})
+}
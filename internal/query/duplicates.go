@@ -0,0 +1,87 @@
+package query
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// DuplicateGroup represents photos taken with the same camera at the same time, which are likely duplicates.
+type DuplicateGroup struct {
+	TakenAt  time.Time `json:"TakenAt"`
+	CameraID uint      `json:"CameraID"`
+	Photos   []string  `json:"Photos"`
+}
+
+// DuplicateGroups returns groups of photos that are likely duplicates based on the date taken and camera
+// found in their metadata, in the range of limit and offset sorted by date.
+func DuplicateGroups(limit, offset int) (groups []DuplicateGroup, err error) {
+	groups = []DuplicateGroup{}
+
+	var rows []struct {
+		TakenAt  time.Time
+		CameraID uint
+	}
+
+	stmt := Db().Table("photos").
+		Where("photos.deleted_at IS NULL AND photos.taken_src = ?", entity.SrcMeta).
+		Where("photos.camera_id > 0 AND photos.camera_id <> ?", entity.UnknownCamera.ID)
+
+	if err := stmt.Select("photos.taken_at, photos.camera_id").
+		Group("photos.taken_at, photos.camera_id").
+		Having("COUNT(*) > 1").
+		Order("photos.taken_at DESC, photos.camera_id").
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		return groups, err
+	}
+
+	for _, r := range rows {
+		g := DuplicateGroup{TakenAt: r.TakenAt, CameraID: r.CameraID}
+
+		if err := stmt.Where("photos.taken_at = ? AND photos.camera_id = ?", r.TakenAt, r.CameraID).
+			Order("photos.photo_uid").
+			Pluck("photos.photo_uid", &g.Photos).Error; err != nil {
+			return groups, err
+		}
+
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+// DuplicatePhotos returns the photos of a duplicate group including their files and labels.
+func DuplicatePhotos(photoUIDs []string) (photos []entity.Photo, err error) {
+	err = Db().Where("photo_uid IN (?)", photoUIDs).
+		Preload("Files").
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
+		}).
+		Preload("Labels.Label").
+		Order("photo_uid").
+		Find(&photos).Error
+
+	return photos, err
+}
+
+// DuplicateAlbums returns the visible albums of the given photos that don't contain the photo to keep.
+func DuplicateAlbums(photoUIDs []string, keepUID string) (albums []string, err error) {
+	err = Db().Model(&entity.PhotoAlbum{}).
+		Where("photo_uid IN (?) AND hidden = 0", photoUIDs).
+		Where("album_uid NOT IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ?)", keepUID).
+		Order("album_uid").
+		Pluck("DISTINCT album_uid", &albums).Error
+
+	return albums, err
+}
+
+// DuplicateResolutionByUID returns a duplicate resolution undo record based on the UID.
+func DuplicateResolutionByUID(uid string) (result entity.DuplicateResolution, err error) {
+	if err := Db().Where("resolution_uid = ?", uid).First(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
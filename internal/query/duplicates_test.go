@@ -0,0 +1,71 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateGroups(t *testing.T) {
+	groups, err := DuplicateGroups(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, groups)
+
+	for _, g := range groups {
+		assert.GreaterOrEqual(t, len(g.Photos), 2)
+		assert.NotZero(t, g.CameraID)
+	}
+
+	found := false
+
+	for _, g := range groups {
+		if g.TakenAt.Year() == 2008 {
+			found = true
+			assert.Contains(t, g.Photos, "pt9jtdre2lvl0yh7")
+			assert.Contains(t, g.Photos, "pt9jtdre2lvl0yh9")
+			assert.NotContains(t, g.Photos, "pt9jtxrexxvl0yh0")
+		}
+	}
+
+	assert.True(t, found)
+}
+
+func TestDuplicatePhotos(t *testing.T) {
+	photos, err := DuplicatePhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh9"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, photos, 2)
+	assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	assert.NotEmpty(t, photos[0].Labels)
+}
+
+func TestDuplicateAlbums(t *testing.T) {
+	albums, err := DuplicateAlbums([]string{"pt9jtdre2lvl0yh7"}, "pt9jtdre2lvl0yh9")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, albums)
+
+	albums, err = DuplicateAlbums([]string{"pt9jtdre2lvl0yh7"}, "pt9jtdre2lvl0yh7")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, albums)
+}
+
+func TestDuplicateResolutionByUID(t *testing.T) {
+	_, err := DuplicateResolutionByUID("rt9lxuqxpogaaxxx")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoEmbeddings returns saved photo embeddings ordered by photo UID.
+func PhotoEmbeddings(limit, offset int) (results []entity.PhotoEmbedding, err error) {
+	err = Db().Order("photo_uid").Limit(limit).Offset(offset).Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoEmbeddings(t *testing.T) {
+	m := entity.NewPhotoEmbedding("pt9jtdre2lvl0y11", "test", []byte{0, 0, 128, 63})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer Db().Delete(m)
+
+	results, err := PhotoEmbeddings(10000, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, r := range results {
+		if r.PhotoUID == m.PhotoUID {
+			found = true
+			assert.Equal(t, "test", r.EmbeddingModel)
+			assert.Equal(t, []byte{0, 0, 128, 63}, r.Embedding)
+		}
+	}
+
+	assert.True(t, found)
+}
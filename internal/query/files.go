@@ -2,8 +2,10 @@ package query
 
 import (
 	"strings"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 type Files []entity.File
@@ -25,13 +27,14 @@ func FilesByPath(rootName, pathName string) (files Files, err error) {
 	return files, err
 }
 
-// ExistingFiles returns not-missing and not-deleted file entities in the range of limit and offset sorted by id.
+// ExistingFiles returns not-missing and not-deleted file entities in the range of limit and offset sorted by id,
+// files in cold storage are excluded.
 func ExistingFiles(limit int, offset int, pathName string) (files Files, err error) {
 	if strings.HasPrefix(pathName, "/") {
 		pathName = pathName[1:]
 	}
 
-	stmt := Db().Unscoped().Where("file_missing = 0 AND deleted_at IS NULL")
+	stmt := Db().Unscoped().Where("file_missing = 0 AND file_cold = 0 AND deleted_at IS NULL")
 
 	if pathName != "" {
 		stmt = stmt.Where("files.file_name LIKE ?", pathName+"/%")
@@ -42,6 +45,46 @@ func ExistingFiles(limit int, offset int, pathName string) (files Files, err err
 	return files, err
 }
 
+// MissingFiles returns files that can't be found in the range of limit and offset, including quarantined files
+// that are not flagged as missing yet.
+func MissingFiles(limit int, offset int, pathName string) (files Files, err error) {
+	if strings.HasPrefix(pathName, "/") {
+		pathName = pathName[1:]
+	}
+
+	stmt := Db().Where("file_missing = 1 OR missing_since IS NOT NULL")
+
+	if pathName != "" {
+		stmt = stmt.Where("files.file_name LIKE ?", pathName+"/%")
+	}
+
+	err = stmt.Order("file_name").Limit(limit).Offset(offset).Find(&files).Error
+
+	return files, err
+}
+
+// ColdCandidates returns original files of photos taken before the given time that are not in cold storage yet,
+// starting after the given file id. Sidecar files and files in hidden folders are kept hot.
+func ColdCandidates(before time.Time, afterId uint, limit int) (files Files, err error) {
+	err = Db().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_cold = 0 AND files.file_missing = 0 AND files.file_sidecar = 0 AND files.deleted_at IS NULL").
+		Where("files.file_root = ? AND files.file_name NOT LIKE ?", entity.RootDefault, "%"+fs.HiddenPath+"/%").
+		Where("photos.taken_at < ? AND files.id > ?", before, afterId).
+		Order("files.id").Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// ColdFiles returns files in cold storage in the range of limit and offset sorted by id.
+func ColdFiles(limit int, offset int) (files Files, err error) {
+	err = Db().Where("file_cold = 1").Order("id").Limit(limit).Offset(offset).Find(&files).Error
+
+	return files, err
+}
+
 // FilesByUID
 func FilesByUID(u []string, limit int, offset int) (files Files, err error) {
 	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
@@ -51,6 +94,19 @@ func FilesByUID(u []string, limit int, offset int) (files Files, err error) {
 	return files, nil
 }
 
+// RawFiles returns the RAW originals of the given photos, e.g. for adding them to album downloads.
+func RawFiles(photoUIDs []string) (files Files, err error) {
+	if len(photoUIDs) == 0 {
+		return files, nil
+	}
+
+	err = Db().Where("photo_uid IN (?) AND file_type = ? AND file_missing = 0", photoUIDs, string(fs.TypeRaw)).
+		Order("photo_uid, file_name").
+		Find(&files).Error
+
+	return files, err
+}
+
 // FileByPhotoUID
 func FileByPhotoUID(u string) (file entity.File, err error) {
 	if err := Db().Where("photo_uid = ? AND file_primary = 1", u).Preload("Links").Preload("Photo").First(&file).Error; err != nil {
@@ -60,9 +116,9 @@ func FileByPhotoUID(u string) (file entity.File, err error) {
 	return file, nil
 }
 
-// VideoByPhotoUID
+// VideoByPhotoUID returns the video file of a photo, transcoded MP4 versions of legacy formats come first.
 func VideoByPhotoUID(u string) (file entity.File, err error) {
-	if err := Db().Where("photo_uid = ? AND file_video = 1", u).Preload("Links").Preload("Photo").First(&file).Error; err != nil {
+	if err := Db().Where("photo_uid = ? AND file_video = 1", u).Order("file_type = 'mp4' DESC, id").Preload("Links").Preload("Photo").First(&file).Error; err != nil {
 		return file, err
 	}
 
@@ -99,3 +155,56 @@ func SetFileError(fileUID, errorString string) {
 		log.Errorf("query: %s", err.Error())
 	}
 }
+
+// FileDimension contains the width and height of a file in pixels.
+type FileDimension struct {
+	FileWidth  int
+	FileHeight int
+}
+
+// PrimaryFileDimensions returns the dimensions of all primary files that have thumbnails.
+func PrimaryFileDimensions() (results []FileDimension, err error) {
+	err = Db().Table("files").
+		Select("file_width, file_height").
+		Where("file_primary = 1 AND file_missing = 0 AND deleted_at IS NULL").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// ExistingHashes returns the hashes of indexed files that match one of the given hashes.
+func ExistingHashes(hashes []string) (results []string, err error) {
+	results = []string{}
+
+	if len(hashes) == 0 {
+		return results, nil
+	}
+
+	err = Db().Table("files").
+		Where("file_hash IN (?) AND deleted_at IS NULL", hashes).
+		Pluck("DISTINCT file_hash", &results).Error
+
+	return results, err
+}
+
+// FileHashSize contains the hash and size of an indexed file.
+type FileHashSize struct {
+	FileHash string
+	FileSize int64
+}
+
+// FileHashSizes returns the hashes and sizes of indexed files that match one of the given hashes.
+func FileHashSizes(hashes []string) (results []FileHashSize, err error) {
+	results = []FileHashSize{}
+
+	if len(hashes) == 0 {
+		return results, nil
+	}
+
+	err = Db().Table("files").
+		Select("DISTINCT file_hash, file_size").
+		Where("file_hash IN (?) AND deleted_at IS NULL", hashes).
+		Scan(&results).Error
+
+	return results, err
+}
@@ -1,8 +1,10 @@
 package query
 
 import (
-	"github.com/photoprism/photoprism/internal/entity"
 	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +47,69 @@ func TestExistingFiles(t *testing.T) {
 	})
 }
 
+func TestMissingFiles(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		files, err := MissingFiles(1000, 0, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(files))
+
+		for _, f := range files {
+			assert.True(t, f.FileMissing || f.MissingSince != nil)
+		}
+	})
+	t.Run("search for files path", func(t *testing.T) {
+		files, err := MissingFiles(1000, 0, "Photos")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestColdCandidates(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		files, err := ColdCandidates(time.Now(), 0, 1000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(files))
+
+		for _, f := range files {
+			assert.False(t, f.FileCold)
+			assert.False(t, f.FileSidecar)
+		}
+	})
+	t.Run("too recent", func(t *testing.T) {
+		files, err := ColdCandidates(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), 0, 1000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestColdFiles(t *testing.T) {
+	files, err := ColdFiles(1000, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		assert.True(t, f.FileCold)
+	}
+}
+
 func TestFilesByUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		files, err := FilesByUID([]string{"ft8es39w45bnlqdw"}, 100, 0)
@@ -65,6 +130,27 @@ func TestFilesByUID(t *testing.T) {
 	})
 }
 
+func TestRawFiles(t *testing.T) {
+	t.Run("no photos", func(t *testing.T) {
+		files, err := RawFiles([]string{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, len(files))
+	})
+	t.Run("raw files only", func(t *testing.T) {
+		files, err := RawFiles([]string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh7"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			assert.Equal(t, "raw", f.FileType)
+		}
+	})
+}
+
 func TestFileByPhotoUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByPhotoUID("pt9jtdre2lvl0y11")
@@ -135,3 +221,49 @@ func TestSetPhotoPrimary(t *testing.T) {
 	//TODO How to assert
 	//assert.Equal(t, true, entity.FileFixturesExampleXMP.FilePrimary)
 }
+
+func TestPrimaryFileDimensions(t *testing.T) {
+	results, err := PrimaryFileDimensions()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, len(results))
+}
+
+func TestExistingHashes(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		results, err := ExistingHashes([]string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}, results)
+	})
+	t.Run("empty", func(t *testing.T) {
+		results, err := ExistingHashes(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestFileHashSizes(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		results, err := FileHashSizes([]string{"2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", "xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []FileHashSize{{FileHash: "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", FileSize: 4278906}}, results)
+	})
+	t.Run("empty", func(t *testing.T) {
+		results, err := FileHashSizes(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -27,8 +27,8 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	s := UnscopedDb()
 
 	s = s.Table("photos").
-		Select(`photos.id, photos.photo_uid, photos.photo_type, photos.photo_lat, photos.photo_lng, 
-		photos.photo_title, photos.photo_description, photos.photo_favorite, photos.taken_at, files.file_hash, files.file_width, 
+		Select(timeoutHint() + `photos.id, photos.photo_uid, photos.photo_type, photos.photo_lat, photos.photo_lng, 
+		photos.photo_altitude, photos.photo_direction, photos.photo_title, photos.photo_description, photos.photo_favorite, photos.taken_at, files.file_hash, files.file_width, 
 		files.file_height`).
 		Joins(`JOIN files ON files.photo_id = photos.id AND 
 		files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL`).
@@ -169,6 +169,11 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		}
 	}
 
+	// Filter by altitude in meters, e.g. ">2000".
+	if min, max, ok := form.IntRange(f.Alt); ok {
+		s = s.Where("photos.photo_altitude BETWEEN ? AND ?", min, max)
+	}
+
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
@@ -180,7 +185,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	s = s.Order("taken_at, photos.photo_uid")
 
 	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
+		return results, searchErr(result.Error)
 	}
 
 	log.Infof("geo: found %d photos for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
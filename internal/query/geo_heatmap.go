@@ -0,0 +1,52 @@
+package query
+
+import (
+	"fmt"
+	"time"
+)
+
+// GeoCell represents the average location and number of photos in a grid cell.
+type GeoCell struct {
+	Lat   float64
+	Lng   float64
+	Count int
+}
+
+// GeoHeatmap returns the number of public photos per grid cell within the given bounds,
+// cells are step degrees wide so that hundreds of thousands of locations can be rendered quickly.
+func GeoHeatmap(latMin, latMax, lngMin, lngMax, step float64) (results []GeoCell, err error) {
+	if step <= 0 {
+		return results, fmt.Errorf("invalid step")
+	}
+
+	err = UnscopedDb().Table("photos").
+		Select("AVG(photo_lat) AS lat, AVG(photo_lng) AS lng, COUNT(*) AS count").
+		Where("deleted_at IS NULL AND photo_private = 0 AND photo_lat <> 0").
+		Where("photo_lat BETWEEN ? AND ?", latMin, latMax).
+		Where("photo_lng BETWEEN ? AND ?", lngMin, lngMax).
+		Group(fmt.Sprintf("FLOOR(photo_lat / %.10f), FLOOR(photo_lng / %.10f)", step, step)).
+		Scan(&results).Error
+
+	return results, err
+}
+
+// GeoHeatmapVersion returns a string that changes whenever photo locations may have changed.
+func GeoHeatmapVersion() (version string, err error) {
+	var result struct {
+		Count     int
+		UpdatedAt *time.Time
+	}
+
+	if err := UnscopedDb().Table("photos").
+		Select("COUNT(*) AS count, MAX(updated_at) AS updated_at").
+		Where("deleted_at IS NULL AND photo_private = 0 AND photo_lat <> 0").
+		Scan(&result).Error; err != nil {
+		return "", err
+	}
+
+	if result.UpdatedAt == nil {
+		return fmt.Sprintf("%d", result.Count), nil
+	}
+
+	return fmt.Sprintf("%d-%d", result.Count, result.UpdatedAt.Unix()), nil
+}
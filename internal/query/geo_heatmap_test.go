@@ -0,0 +1,48 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoHeatmap(t *testing.T) {
+	t.Run("world", func(t *testing.T) {
+		cells, err := GeoHeatmap(-90, 90, -180, 180, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, cells)
+
+		for _, c := range cells {
+			assert.Greater(t, c.Count, 0)
+			assert.NotZero(t, c.Lat)
+		}
+	})
+	t.Run("empty area", func(t *testing.T) {
+		cells, err := GeoHeatmap(-89, -88, 0, 1, 0.1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, cells)
+	})
+	t.Run("invalid step", func(t *testing.T) {
+		_, err := GeoHeatmap(-90, 90, -180, 180, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGeoHeatmapVersion(t *testing.T) {
+	version, err := GeoHeatmapVersion()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, version)
+}
@@ -11,6 +11,8 @@ type GeoResult struct {
 	PhotoType        string    `json:"Type,omitempty"`
 	PhotoLat         float32   `json:"Lat"`
 	PhotoLng         float32   `json:"Lng"`
+	PhotoAltitude    int       `json:"Altitude,omitempty"`
+	PhotoDirection   float32   `json:"Direction,omitempty"`
 	PhotoTitle       string    `json:"Title"`
 	PhotoDescription string    `json:"Description,omitempty"`
 	PhotoFavorite    bool      `json:"Favorite,omitempty"`
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// GeometryResult contains a photo with a suggested orientation or geometry fix.
+type GeometryResult struct {
+	FixUID     string    `json:"UID"`
+	PhotoUID   string    `json:"PhotoUID"`
+	PhotoTitle string    `json:"Title"`
+	TakenAt    time.Time `json:"TakenAt"`
+	FileHash   string    `json:"Hash"`
+	FixRotate  int       `json:"Rotate"`
+	FixTilt    float64   `json:"Tilt"`
+	FixScore   float32   `json:"Score"`
+}
+
+// GeometryCandidates returns primary JPEG originals that have not been checked for orientation
+// and geometry issues yet, sorted by id.
+func GeometryCandidates(afterId uint, limit int) (files Files, err error) {
+	err = Db().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Joins("LEFT JOIN geometry_fixes ON geometry_fixes.file_uid = files.file_uid").
+		Where("files.file_primary = 1 AND files.file_type = ? AND files.file_missing = 0 AND files.file_cold = 0 AND files.deleted_at IS NULL", string(fs.TypeJpeg)).
+		Where("files.file_root = ? AND files.file_name NOT LIKE ?", entity.RootDefault, "%"+fs.HiddenPath+"/%").
+		Where("geometry_fixes.id IS NULL AND files.id > ?", afterId).
+		Order("files.id").Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// GeometryReview returns pending orientation and geometry fixes, sorted by confidence score.
+func GeometryReview(limit, offset int) (results []GeometryResult, err error) {
+	err = UnscopedDb().Table("geometry_fixes").
+		Select("geometry_fixes.fix_uid, geometry_fixes.photo_uid, photos.photo_title, photos.taken_at, files.file_hash, "+
+			"geometry_fixes.fix_rotate, geometry_fixes.fix_tilt, geometry_fixes.fix_score").
+		Joins("JOIN files ON files.file_uid = geometry_fixes.file_uid AND files.deleted_at IS NULL").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("geometry_fixes.fix_status = ?", entity.GeometryPending).
+		Order("geometry_fixes.fix_score DESC, geometry_fixes.id").
+		Limit(limit).Offset(offset).
+		Scan(&results).Error
+
+	return results, err
+}
+
+// GeometryFixByUID returns a suggested orientation or geometry fix.
+func GeometryFixByUID(uid string) (result entity.GeometryFix, err error) {
+	err = Db().Where("fix_uid = ?", uid).First(&result).Error
+
+	return result, err
+}
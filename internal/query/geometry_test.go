@@ -0,0 +1,103 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeometryCandidates(t *testing.T) {
+	photo := entity.Photo{PhotoTitle: "Geometry Candidate", PhotoQuality: 3}
+
+	if err := Db().Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "geometry/candidate.jpg", FileType: "jpg", FileHash: "geometrycandidate01234", FilePrimary: true}
+
+	if err := Db().Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	contains := func(files Files) bool {
+		for _, f := range files {
+			if f.ID == file.ID {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	files, err := GeometryCandidates(file.ID-1, 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, contains(files))
+
+	if err := entity.NewGeometryFix(photo.PhotoUID, file.FileUID, 0, 0, 0).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = GeometryCandidates(file.ID-1, 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, contains(files))
+}
+
+func TestGeometryReview(t *testing.T) {
+	photo := entity.Photo{PhotoTitle: "Geometry Review", PhotoQuality: 3}
+
+	if err := Db().Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "geometry/review.jpg", FileType: "jpg", FileHash: "geometryreview0123456", FilePrimary: true}
+
+	if err := Db().Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	fix := entity.NewGeometryFix(photo.PhotoUID, file.FileUID, 270, 0, 0.75)
+
+	if err := fix.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := GeometryReview(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, r := range results {
+		if r.FixUID == fix.FixUID {
+			found = true
+			assert.Equal(t, photo.PhotoUID, r.PhotoUID)
+			assert.Equal(t, 270, r.FixRotate)
+			assert.Equal(t, "geometryreview0123456", r.FileHash)
+		}
+	}
+
+	assert.True(t, found)
+
+	result, err := GeometryFixByUID(fix.FixUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, file.FileUID, result.FileUID)
+
+	if _, err := GeometryFixByUID("ox0000000000000"); err == nil {
+		t.Fatal("error expected")
+	}
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// GuestReactions returns the reactions left by visitors of shared content, optionally including hidden ones.
+func GuestReactions(shareUID string, hidden bool) (results []entity.GuestReaction, err error) {
+	s := Db().Where("share_uid = ?", shareUID)
+
+	if !hidden {
+		s = s.Where("hidden = 0")
+	}
+
+	if err := s.Order("created_at DESC").Limit(1000).Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// GuestReactionByID returns a guest reaction based on the ID.
+func GuestReactionByID(id uint) (result entity.GuestReaction, err error) {
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
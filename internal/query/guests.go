@@ -0,0 +1,56 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// GuestByUID returns a guest account based on the UID.
+func GuestByUID(uid string) (guest entity.Guest, err error) {
+	if err := Db().Where("guest_uid = ?", uid).First(&guest).Error; err != nil {
+		return guest, err
+	}
+
+	return guest, nil
+}
+
+// GuestByLoginToken returns a guest account based on the login token.
+func GuestByLoginToken(token string) (guest entity.Guest, err error) {
+	if err := Db().Where("login_token = ?", token).First(&guest).Error; err != nil {
+		return guest, err
+	}
+
+	return guest, nil
+}
+
+// Guests returns all guest accounts sorted by name.
+func Guests() (guests []entity.Guest, err error) {
+	err = Db().Order("guest_name, id").Find(&guests).Error
+
+	return guests, err
+}
+
+// GuestShare represents a share link a guest has access to.
+type GuestShare struct {
+	LinkToken   string     `json:"Token"`
+	LinkExpires *time.Time `json:"Expires"`
+	ShareUID    string     `json:"ShareUID"`
+	AlbumTitle  string     `json:"Title"`
+}
+
+// GuestShares returns the share links a guest has access to, excluding expired links.
+func GuestShares(guestUID string) (results []GuestShare, err error) {
+	results = []GuestShare{}
+
+	err = Db().Table("guests_links").
+		Select("links.link_token, links.link_expires, links.share_uid, albums.album_title").
+		Joins("JOIN links ON links.link_token = guests_links.link_token AND links.deleted_at IS NULL").
+		Joins("LEFT JOIN albums ON albums.album_uid = links.share_uid AND albums.deleted_at IS NULL").
+		Where("guests_links.guest_uid = ?", guestUID).
+		Where("links.link_expires IS NULL OR links.link_expires > ?", time.Now()).
+		Order("albums.album_title, links.link_token").
+		Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestByUID(t *testing.T) {
+	t.Run("existing guest", func(t *testing.T) {
+		guest, err := GuestByUID("gt9k8pkzkqrv8hlw")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Grandma", guest.GuestName)
+	})
+	t.Run("not existing guest", func(t *testing.T) {
+		_, err := GuestByUID("gt9k8pkzkqrv8xxx")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGuestByLoginToken(t *testing.T) {
+	guest, err := GuestByLoginToken("4jxf3jfn2k9a8b7c")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "gt9k8pkzkqrv8hlw", guest.GuestUID)
+
+	_, err = GuestByLoginToken("xxx")
+
+	assert.Error(t, err)
+}
+
+func TestGuests(t *testing.T) {
+	guests, err := Guests()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, len(guests))
+}
+
+func TestGuestShares(t *testing.T) {
+	shares, err := GuestShares("gt9k8pkzkqrv8hlw")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, shares, 1)
+	assert.Equal(t, "1jxf3jfn2k", shares[0].LinkToken)
+}
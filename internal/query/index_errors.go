@@ -0,0 +1,29 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// IndexErrors returns files that failed to index, most recent failures first. Ignored errors are only
+// returned if requested.
+func IndexErrors(f form.IndexErrors) (results []entity.IndexError, err error) {
+	results = []entity.IndexError{}
+
+	s := Db().Where("error_ignored = ?", f.Ignored)
+
+	if f.Kind != "" {
+		s = s.Where("error_kind = ?", f.Kind)
+	}
+
+	err = s.Order("updated_at DESC, id DESC").Limit(f.Count).Offset(f.Offset).Find(&results).Error
+
+	return results, err
+}
+
+// IndexErrorByID returns the index error with the given id.
+func IndexErrorByID(id uint) (result entity.IndexError, err error) {
+	err = Db().Where("id = ?", id).First(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,42 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexErrors(t *testing.T) {
+	if err := entity.SaveIndexError("2020/broken/unsupported.avi", entity.IndexErrorCodec, "unknown codec"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("kind", func(t *testing.T) {
+		results, err := IndexErrors(form.IndexErrors{Kind: entity.IndexErrorCodec, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(results))
+
+		for _, r := range results {
+			assert.Equal(t, entity.IndexErrorCodec, r.ErrorKind)
+			assert.False(t, r.ErrorIgnored)
+		}
+
+		m, err := IndexErrorByID(results[0].ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, results[0].FileName, m.FileName)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := IndexErrorByID(999999)
+		assert.Error(t, err)
+	})
+}
@@ -45,8 +45,18 @@ func LabelThumbBySlug(labelSlug string) (file entity.File, err error) {
 	return file, nil
 }
 
-// LabelThumbByUID returns a label preview file based on the label UID.
+// LabelThumbByUID returns a label preview file based on the label UID, the selected cover photo is preferred.
 func LabelThumbByUID(labelUID string) (file entity.File, err error) {
+	// Use cover photo if selected
+	err = Db().Where("files.file_primary AND files.deleted_at IS NULL").
+		Joins("JOIN labels ON labels.label_uid = ? AND labels.label_cover = files.photo_uid", labelUID).
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		First(&file).Error
+
+	if err == nil {
+		return file, nil
+	}
+
 	// Search matching label
 	err = Db().Where("files.file_primary AND files.deleted_at IS NULL").
 		Joins("JOIN labels ON labels.label_uid = ?", labelUID).
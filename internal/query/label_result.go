@@ -16,6 +16,8 @@ type LabelResult struct {
 	LabelFavorite    bool      `json:"Favorite"`
 	LabelDescription string    `json:"Description"`
 	LabelNotes       string    `json:"Notes"`
+	LabelCover       string    `json:"Cover"`
+	LabelCrop        string    `json:"Crop"`
 	PhotoCount       int       `json:"PhotoCount"`
 	CreatedAt        time.Time `json:"CreatedAt"`
 	UpdatedAt        time.Time `json:"UpdatedAt"`
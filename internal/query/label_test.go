@@ -3,6 +3,7 @@ package query
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,6 +76,27 @@ func TestLabelThumbByUID(t *testing.T) {
 		assert.Equal(t, "bridge2.jpg", file.FileName)
 	})
 
+	t.Run("cover", func(t *testing.T) {
+		label := entity.NewLabel("Cover Query", 0)
+
+		if err := label.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := label.SetCover("pt9jtdre2lvl0yh7", ""); err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := LabelThumbByUID(label.LabelUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", file.PhotoUID)
+		assert.True(t, file.FilePrimary)
+	})
+
 	t.Run("no file found", func(t *testing.T) {
 		file, err := LabelThumbByUID("14")
 
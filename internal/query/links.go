@@ -0,0 +1,192 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// LinkByToken returns a share link based on the token.
+func LinkByToken(token string) (link entity.Link, err error) {
+	if err := Db().Where("link_token = ?", token).First(&link).Error; err != nil {
+		return link, err
+	}
+
+	return link, nil
+}
+
+// LinkBySlug returns a share link based on its vanity slug.
+func LinkBySlug(slug string) (link entity.Link, err error) {
+	if slug == "" {
+		return link, fmt.Errorf("links: slug is empty")
+	}
+
+	if err := Db().Where("link_slug = ?", slug).First(&link).Error; err != nil {
+		return link, err
+	}
+
+	return link, nil
+}
+
+// LinkByAlias returns the share link a previous vanity slug belonged to.
+func LinkByAlias(slug string) (link entity.Link, err error) {
+	if err := Db().Joins("JOIN links_aliases ON links_aliases.link_token = links.link_token").
+		Where("links_aliases.alias_slug = ?", slug).First(&link).Error; err != nil {
+		return link, err
+	}
+
+	return link, nil
+}
+
+// LinkByDomain returns the share link with the given custom hostname.
+func LinkByDomain(domain string) (link entity.Link, err error) {
+	if domain == "" {
+		return link, fmt.Errorf("links: domain is empty")
+	}
+
+	if err := Db().Where("link_domain = ?", strings.ToLower(domain)).First(&link).Error; err != nil {
+		return link, err
+	}
+
+	return link, nil
+}
+
+// LinkSharesPhoto returns true if the photo is part of the content shared by the link. Private and
+// archived photos are only shared by links to the photo itself.
+func LinkSharesPhoto(link entity.Link, photoUID string) bool {
+	if link.ShareUID == photoUID {
+		return true
+	}
+
+	var count int
+
+	albumUIDs, err := LinkAlbumUIDs(link)
+
+	if err != nil {
+		log.Errorf("links: %s", err)
+		return false
+	}
+
+	// Private and archived photos are not shared, even if they are part of a shared album or snapshot.
+	if err := Db().Model(&entity.PhotoAlbum{}).
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid").
+		Where("photos_albums.album_uid IN (?) AND photos_albums.photo_uid = ?", albumUIDs, photoUID).
+		Where("photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		Count(&count).Error; err != nil {
+		log.Errorf("links: %s", err)
+		return false
+	} else if count > 0 {
+		return true
+	}
+
+	if err := Db().Model(&entity.SnapshotPhoto{}).
+		Joins("JOIN photos ON photos.photo_uid = snapshots_photos.photo_uid").
+		Where("snapshots_photos.snapshot_uid = ? AND snapshots_photos.photo_uid = ?", link.ShareUID, photoUID).
+		Where("photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		Count(&count).Error; err != nil {
+		log.Errorf("links: %s", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// LinkAlbumUIDs returns the UIDs of all albums shared by the link. Links with subtree scope share
+// the album and its descendants, except for excluded albums and their descendants.
+func LinkAlbumUIDs(link entity.Link) (result []string, err error) {
+	if !rnd.IsPPID(link.ShareUID, 'a') {
+		return []string{link.ShareUID}, nil
+	}
+
+	result = []string{link.ShareUID}
+
+	if !link.LinkSubtree {
+		return result, nil
+	}
+
+	var excluded []string
+
+	if err := Db().Model(&entity.LinkExclusion{}).Where("link_token = ?", link.LinkToken).Pluck("album_uid", &excluded).Error; err != nil {
+		return result, err
+	}
+
+	seen := map[string]bool{link.ShareUID: true}
+
+	for _, uid := range excluded {
+		seen[uid] = true
+	}
+
+	for parents := result; len(parents) > 0; {
+		var children []string
+
+		if err := Db().Model(&entity.Album{}).Where("parent_uid IN (?)", parents).Pluck("album_uid", &children).Error; err != nil {
+			return result, err
+		}
+
+		parents = nil
+
+		for _, uid := range children {
+			if seen[uid] {
+				continue
+			}
+
+			seen[uid] = true
+			parents = append(parents, uid)
+		}
+
+		result = append(result, parents...)
+	}
+
+	return result, nil
+}
+
+// LinkSharesAlbum returns true if the album is shared by the link.
+func LinkSharesAlbum(link entity.Link, albumUID string) bool {
+	albumUIDs, err := LinkAlbumUIDs(link)
+
+	if err != nil {
+		log.Errorf("links: %s", err)
+		return false
+	}
+
+	for _, uid := range albumUIDs {
+		if uid == albumUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LinkAlbums returns the albums shared by the link sorted by title.
+func LinkAlbums(link entity.Link) (results []entity.Album, err error) {
+	albumUIDs, err := LinkAlbumUIDs(link)
+
+	if err != nil {
+		return results, err
+	}
+
+	err = Db().Where("album_uid IN (?)", albumUIDs).Order("album_title, id").Find(&results).Error
+
+	return results, err
+}
+
+// LinksByShareUID returns the share links of the given photos, albums, or snapshots.
+func LinksByShareUID(shareUIDs []string) (links []entity.Link, err error) {
+	err = Db().Where("share_uid IN (?)", shareUIDs).Order("link_token").Find(&links).Error
+
+	return links, err
+}
+
+// LinksExpiring returns share links that have not expired yet, but will expire within the given duration.
+func LinksExpiring(within time.Duration) (links []entity.Link, err error) {
+	now := time.Now()
+
+	err = Db().Where("link_expires > ? AND link_expires <= ?", now, now.Add(within)).
+		Order("link_expires, link_token").Find(&links).Error
+
+	return links, err
+}
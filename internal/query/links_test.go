@@ -0,0 +1,198 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkBySlug(t *testing.T) {
+	link := entity.NewLink("", false, false)
+	link.ShareUID = "at9lxuqxpogaaba8"
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := link.SetSlug("christmas-2030"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("slug", func(t *testing.T) {
+		result, err := LinkBySlug("christmas-2030")
+
+		assert.NoError(t, err)
+		assert.Equal(t, link.LinkToken, result.LinkToken)
+	})
+	t.Run("alias", func(t *testing.T) {
+		if err := link.SetSlug("xmas-2030"); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := LinkByAlias("christmas-2030")
+
+		assert.NoError(t, err)
+		assert.Equal(t, link.LinkToken, result.LinkToken)
+		assert.Equal(t, "xmas-2030", result.LinkSlug)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := LinkBySlug("")
+		assert.Error(t, err)
+	})
+}
+
+func TestLinkByDomain(t *testing.T) {
+	link := entity.NewLink("", false, false)
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := link.SetDomain("christmas.example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := LinkByDomain("Christmas.Example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, link.LinkToken, result.LinkToken)
+
+	_, err = LinkByDomain("other.example.com")
+
+	assert.Error(t, err)
+}
+
+func TestLinkAlbumUIDs(t *testing.T) {
+	root := entity.NewAlbum("Grandkids 2024", entity.TypeDefault)
+	child := entity.NewAlbum("Grandkids 2024 Summer", entity.TypeDefault)
+	grandchild := entity.NewAlbum("Grandkids 2024 Beach", entity.TypeDefault)
+	excluded := entity.NewAlbum("Grandkids 2024 Private", entity.TypeDefault)
+	excludedChild := entity.NewAlbum("Grandkids 2024 Private Bath", entity.TypeDefault)
+
+	child.ParentUID = root.AlbumUID
+	grandchild.ParentUID = child.AlbumUID
+	excluded.ParentUID = root.AlbumUID
+	excludedChild.ParentUID = excluded.AlbumUID
+
+	for _, m := range []*entity.Album{root, child, grandchild, excluded, excludedChild} {
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	link := entity.NewLink("", false, false)
+	link.ShareUID = root.AlbumUID
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("album only", func(t *testing.T) {
+		result, err := LinkAlbumUIDs(link)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{root.AlbumUID}, result)
+		assert.False(t, LinkSharesAlbum(link, child.AlbumUID))
+	})
+	t.Run("subtree", func(t *testing.T) {
+		if err := link.SetScope(true, []string{excluded.AlbumUID}); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := LinkAlbumUIDs(link)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{root.AlbumUID, child.AlbumUID, grandchild.AlbumUID}, result)
+		assert.True(t, LinkSharesAlbum(link, grandchild.AlbumUID))
+		assert.False(t, LinkSharesAlbum(link, excluded.AlbumUID))
+		assert.False(t, LinkSharesAlbum(link, excludedChild.AlbumUID))
+	})
+	t.Run("no exclusions", func(t *testing.T) {
+		if err := link.SetScope(true, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		albums, err := LinkAlbums(link)
+
+		assert.NoError(t, err)
+		assert.Len(t, albums, 5)
+	})
+	t.Run("photo link", func(t *testing.T) {
+		result, err := LinkAlbumUIDs(entity.Link{ShareUID: "pt9jtdre2lvl0yh7", LinkSubtree: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh7"}, result)
+	})
+}
+
+func TestLinkSharesPhoto(t *testing.T) {
+	album := entity.NewAlbum("Family Reunion 2015", entity.TypeDefault)
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, photoUID := range []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y12"} {
+		if err := entity.NewPhotoAlbum(photoUID, album.AlbumUID).Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	link := entity.NewLink("", false, false)
+	link.ShareUID = album.AlbumUID
+
+	t.Run("public photo", func(t *testing.T) {
+		assert.True(t, LinkSharesPhoto(link, "pt9jtdre2lvl0yh7"))
+	})
+	t.Run("private photo", func(t *testing.T) {
+		assert.False(t, LinkSharesPhoto(link, "pt9jtdre2lvl0y12"))
+	})
+	t.Run("other photo", func(t *testing.T) {
+		assert.False(t, LinkSharesPhoto(link, "pt9jtdre2lvl0y11"))
+	})
+	t.Run("photo link", func(t *testing.T) {
+		assert.True(t, LinkSharesPhoto(entity.Link{ShareUID: "pt9jtdre2lvl0y12"}, "pt9jtdre2lvl0y12"))
+	})
+}
+
+func TestLinksExpiring(t *testing.T) {
+	expires := time.Now().Add(48 * time.Hour)
+	link := entity.NewLink("", false, false)
+	link.LinkExpires = &expires
+
+	if err := entity.Db().Create(&link).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	found := func(links []entity.Link) bool {
+		for _, l := range links {
+			if l.LinkToken == link.LinkToken {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	links, err := LinksExpiring(7 * 24 * time.Hour)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, found(links))
+
+	for _, l := range links {
+		assert.NotEqual(t, "1jxf3jfn2k", l.LinkToken)
+	}
+
+	links, err = LinksExpiring(24 * time.Hour)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, found(links))
+}
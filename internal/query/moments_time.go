@@ -7,8 +7,8 @@ type MomentsTimeResult struct {
 	Count      int
 }
 
-// GetMomentsTime counts photos per month and year
-func GetMomentsTime() (results []MomentsTimeResult, err error) {
+// GetMomentsTime counts photos per month and year, optionally excluding photos with a category like screenshots.
+func GetMomentsTime(excludeCategories bool) (results []MomentsTimeResult, err error) {
 	s := UnscopedDb()
 
 	s = s.Table("photos").
@@ -17,6 +17,10 @@ func GetMomentsTime() (results []MomentsTimeResult, err error) {
 		Group("photos.photo_year, photos.photo_month").
 		Order("photos.photo_year DESC, photos.photo_month DESC")
 
+	if excludeCategories {
+		s = s.Where("photos.photo_category IS NULL OR photos.photo_category = ''")
+	}
+
 	if result := s.Scan(&results); result.Error != nil {
 		return results, result.Error
 	}
@@ -8,10 +8,17 @@ import (
 
 func TestGetMomentsTime(t *testing.T) {
 	t.Run("result found", func(t *testing.T) {
-		result, err := GetMomentsTime()
+		result, err := GetMomentsTime(false)
 
 		assert.Nil(t, err)
 		assert.Equal(t, 2790, result[0].PhotoYear)
 		assert.Equal(t, 2, result[0].Count)
 	})
+	t.Run("exclude categories", func(t *testing.T) {
+		result, err := GetMomentsTime(true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2790, result[0].PhotoYear)
+		assert.Equal(t, 1, result[0].Count)
+	})
 }
@@ -0,0 +1,25 @@
+package query
+
+import "time"
+
+// NSFWResult contains a photo in review because it may be offensive.
+type NSFWResult struct {
+	PhotoUID   string    `json:"UID"`
+	PhotoTitle string    `json:"Title"`
+	PhotoNSFW  float32   `json:"NSFW"`
+	TakenAt    time.Time `json:"TakenAt"`
+	FileHash   string    `json:"Hash"`
+}
+
+// NSFWReview returns photos in review because they may be offensive, sorted by confidence score.
+func NSFWReview(limit, offset int) (results []NSFWResult, err error) {
+	err = UnscopedDb().Table("photos").
+		Select("photos.photo_uid, photos.photo_title, photos.photo_nsfw, photos.taken_at, files.file_hash").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("photos.nsfw_review = 1 AND photos.deleted_at IS NULL").
+		Order("photos.photo_nsfw DESC, photos.id").
+		Limit(limit).Offset(offset).
+		Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNSFWReview(t *testing.T) {
+	photo := entity.Photo{PhotoTitle: "NSFW Review", PhotoNSFW: 0.99, NSFWReview: true, PhotoQuality: -1}
+
+	if err := Db().Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "nsfw-review.jpg", FileHash: "nsfwreview0123456789", FilePrimary: true}
+
+	if err := Db().Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := NSFWReview(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, r := range results {
+		if r.PhotoUID == photo.PhotoUID {
+			found = true
+			assert.Equal(t, float32(0.99), r.PhotoNSFW)
+			assert.Equal(t, "nsfwreview0123456789", r.FileHash)
+		}
+	}
+
+	assert.True(t, found)
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoCredit contains the public license and attribution details of a shared photo.
+type PhotoCredit struct {
+	PhotoUID    string    `json:"UID"`
+	PhotoTitle  string    `json:"Title"`
+	TakenAt     time.Time `json:"TakenAt"`
+	Artist      string    `json:"Artist"`
+	Copyright   string    `json:"Copyright"`
+	License     string    `json:"License"`
+	Attribution string    `json:"Attribution"`
+	Credit      string    `json:"Credit"`
+}
+
+// PhotoCredits returns license and attribution details for public photos shared by the link, including
+// nested albums of links with subtree scope and the photos of shared snapshots.
+func PhotoCredits(link entity.Link) (results []PhotoCredit, err error) {
+	albumUIDs, err := LinkAlbumUIDs(link)
+
+	if err != nil {
+		return results, err
+	}
+
+	s := Db().Table("photos").
+		Select(`photos.photo_uid, photos.photo_title, photos.taken_at, 
+		details.artist, details.copyright, details.license, details.attribution, details.credit`).
+		Joins("LEFT JOIN details ON details.photo_id = photos.id").
+		Where("photos.deleted_at IS NULL AND photos.photo_private = 0").
+		Where("photos.photo_uid = ? OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE album_uid IN (?) AND hidden = 0) "+
+			"OR photos.photo_uid IN (SELECT photo_uid FROM snapshots_photos WHERE snapshot_uid = ?)", link.ShareUID, albumUIDs, link.ShareUID).
+		Order("photos.taken_at, photos.photo_uid")
+
+	if err := s.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
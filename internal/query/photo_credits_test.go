@@ -0,0 +1,95 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoCredits(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		results, err := PhotoCredits(entity.Link{ShareUID: "at9lxuqxpogaaba8"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(results))
+
+		for _, r := range results {
+			if r.PhotoUID == "pt9jtdre2lvl0yh7" {
+				assert.Equal(t, "MIT", r.License)
+				assert.Equal(t, "Photo by Hans", r.Attribution)
+				assert.Equal(t, "PhotoPrism", r.Credit)
+			}
+		}
+	})
+	t.Run("photo", func(t *testing.T) {
+		results, err := PhotoCredits(entity.Link{ShareUID: "pt9jtdre2lvl0yh7"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+	t.Run("subtree", func(t *testing.T) {
+		root := entity.NewAlbum("Credits 2030", entity.TypeDefault)
+		child := entity.NewAlbum("Credits 2030 Summer", entity.TypeDefault)
+		child.ParentUID = root.AlbumUID
+
+		for _, m := range []*entity.Album{root, child} {
+			if err := m.Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := entity.NewPhotoAlbum("pt9jtdre2lvl0yh7", child.AlbumUID).Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		link := entity.NewLink("", false, false)
+		link.ShareUID = root.AlbumUID
+
+		results, err := PhotoCredits(link)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+
+		link.LinkSubtree = true
+
+		if results, err = PhotoCredits(link); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		snapshot := entity.NewSnapshot("Credits Chat", "", []string{"pt9jtdre2lvl0yh7"})
+
+		if err := snapshot.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := PhotoCredits(entity.Link{ShareUID: snapshot.SnapshotUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+	t.Run("not found", func(t *testing.T) {
+		results, err := PhotoCredits(entity.Link{ShareUID: "xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
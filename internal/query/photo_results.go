@@ -20,14 +20,17 @@ type PhotoResult struct {
 	DocumentID       string        `json:"DocumentID,omitempty"`
 	PhotoUID         string        `json:"UID"`
 	PhotoType        string        `json:"Type"`
+	PhotoCategory    string        `json:"Category"`
 	TakenAt          time.Time     `json:"TakenAt"`
 	TakenAtLocal     time.Time     `json:"TakenAtLocal"`
 	TakenSrc         string        `json:"TakenSrc"`
+	TakenApprox      string        `json:"TakenApprox"`
 	TimeZone         string        `json:"TimeZone"`
 	PhotoPath        string        `json:"Path"`
 	PhotoName        string        `json:"Name"`
 	PhotoTitle       string        `json:"Title"`
 	PhotoDescription string        `json:"Description"`
+	AlbumCaption     string        `json:"AlbumCaption,omitempty"`
 	PhotoYear        int           `json:"Year"`
 	PhotoMonth       int           `json:"Month"`
 	PhotoCountry     string        `json:"Country"`
@@ -36,6 +39,8 @@ type PhotoResult struct {
 	PhotoLat         float32       `json:"Lat"`
 	PhotoLng         float32       `json:"Lng"`
 	PhotoAltitude    int           `json:"Altitude"`
+	PhotoDirection   float32       `json:"Direction,omitempty"`
+	PhotoSpeed       float32       `json:"Speed,omitempty"`
 	PhotoIso         int           `json:"Iso"`
 	PhotoFocalLength int           `json:"FocalLength"`
 	PhotoFNumber     float32       `json:"FNumber"`
@@ -63,6 +68,7 @@ type PhotoResult struct {
 	FileHeight       int           `json:"Height"`
 	FilePrimary      bool          `json:"-"`
 	FileMissing      bool          `json:"-"`
+	FileCold         bool          `json:"Cold"`
 	FileVideo        bool          `json:"-"`
 	FileDuration     time.Duration `json:"-"`
 	FileCodec        string        `json:"-"`
@@ -73,8 +79,12 @@ type PhotoResult struct {
 	FileAspectRatio  float32       `json:"-"`
 	FileColors       string        `json:"-"`
 	FileChroma       uint8         `json:"-"`
+	FileMono         bool          `json:"-"`
 	FileLuminance    string        `json:"-"`
 	FileDiff         uint32        `json:"-"`
+	FileUnder        uint8         `json:"-"`
+	FileOver         uint8         `json:"-"`
+	Distance         float64       `json:"Distance,omitempty"`
 	Merged           bool          `json:"Merged"`
 	CreatedAt        time.Time     `json:"CreatedAt"`
 	UpdatedAt        time.Time     `json:"UpdatedAt"`
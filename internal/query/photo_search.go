@@ -2,38 +2,72 @@ package query
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/semantic"
+	"github.com/photoprism/photoprism/internal/synonyms"
+	"github.com/photoprism/photoprism/pkg/colors"
+	"github.com/photoprism/photoprism/pkg/s2"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // PhotoSearch searches for photos based on a Form and returns PhotoResults ([]PhotoResult).
 func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error) {
+	return photoSearch(f, nil)
+}
+
+// PhotoSearchTotal searches for photos like PhotoSearch and additionally returns the total number of
+// matching results regardless of count and offset, so that clients can show pagination. Like count and
+// offset, the total refers to files if results are merged.
+func PhotoSearchTotal(f form.PhotoSearch) (results PhotoResults, count, total int, err error) {
+	results, count, err = photoSearch(f, &total)
+
+	return results, count, total, err
+}
+
+// photoSearch runs a photo search and counts all matches if total isn't nil.
+func photoSearch(f form.PhotoSearch, total *int) (results PhotoResults, count int, err error) {
 	start := time.Now()
 
 	if err := f.ParseQueryString(); err != nil {
 		return results, 0, err
 	}
 
+	// Smart albums contain all photos matching their filter, so that new photos are included automatically.
+	// Filter values take precedence over search values with the same name.
+	if f.Album != "" {
+		if a, err := AlbumByUID(f.Album); err == nil && a.HasFilter() {
+			f.Album = ""
+			f.Query = strings.TrimSpace(f.Query + " " + a.AlbumFilter)
+
+			if err := f.ParseQueryString(); err != nil {
+				return results, 0, err
+			}
+		}
+	}
+
 	s := UnscopedDb()
 
 	// s.LogMode(true)
 
 	// Main search query, avoids (slow) left joins.
-	s = s.Table("photos").
-		Select(`photos.*,
-		files.id AS file_id, files.file_uid, files.file_primary, files.file_missing, files.file_name,
+	cols := timeoutHint() + `photos.*,
+		files.id AS file_id, files.file_uid, files.file_primary, files.file_missing, files.file_cold, files.file_name,
 		files.file_root, files.file_hash, files.file_codec, files.file_type, files.file_mime, files.file_width, 
 		files.file_height, files.file_aspect_ratio, files.file_orientation, files.file_main_color, 
-		files.file_colors, files.file_luminance, files.file_chroma,
+		files.file_colors, files.file_luminance, files.file_chroma, files.file_mono, files.file_under, files.file_over,
 		files.file_diff, files.file_video, files.file_duration, files.file_size,
 		cameras.camera_make, cameras.camera_model,
 		lenses.lens_make, lenses.lens_model,
-		places.loc_label, places.loc_city, places.loc_state, places.loc_country`).
+		places.loc_label, places.loc_city, places.loc_state, places.loc_country`
+
+	s = s.Table("photos").
+		Select(cols).
 		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = 0 AND files.deleted_at IS NULL").
 		Joins("JOIN cameras ON photos.camera_id = cameras.id").
 		Joins("JOIN lenses ON photos.lens_id = lenses.id").
@@ -51,6 +85,10 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 
 		log.Infof("photos: found %d results for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
 
+		if total != nil {
+			*total = len(results)
+		}
+
 		if f.Merged {
 			return results.Merged()
 		}
@@ -82,6 +120,41 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		}
 	}
 
+	// Find English labels and keywords using synonyms in other languages.
+	f.Query = synonyms.Default.Expand(f.Query)
+
+	// Find photos matching a natural language description, e.g. "red car in the snow".
+	var semanticRank interface{}
+
+	if f.Semantic != "" {
+		matches, err := semantic.Search(f.Semantic, semantic.MaxResults)
+
+		if err != nil {
+			return results, 0, err
+		} else if len(matches) == 0 {
+			return results, 0, nil
+		}
+
+		uids := make([]string, len(matches))
+		rank := "CASE photos.photo_uid"
+		args := make([]interface{}, 0, 2*len(matches))
+
+		for i, m := range matches {
+			uids[i] = m.UID
+			rank += " WHEN ? THEN ?"
+			args = append(args, m.UID, i)
+		}
+
+		semanticRank = gorm.Expr(rank+" END", args...)
+
+		s = s.Where("photos.photo_uid IN (?)", uids)
+
+		// Sort by similarity by default.
+		if f.Order == "" {
+			f.Order = entity.SortOrderRelevance
+		}
+	}
+
 	// Filter by location.
 	if f.Location == true {
 		s = s.Where("loc_uid <> ''")
@@ -148,8 +221,42 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("files.file_error = ''")
 	}
 
+	if f.Cold {
+		s = s.Where("files.file_cold = 1")
+	}
+
+	// Return one result per photo, e.g. for pagination by photo instead of by file.
+	if f.Primary {
+		s = s.Where("files.file_primary = 1")
+	}
+
+	// Filter by album, the membership condition is part of the join so that the query planner can
+	// read the members of a single album using its index instead of scanning all photos.
+	albumJoined := false
+
 	if f.Album != "" {
-		s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.album_uid IN (?)", strings.Split(f.Album, ","))
+		albums, err := AlbumUIDs(f.Album)
+
+		if err != nil {
+			return results, 0, err
+		}
+
+		if len(albums) == 1 {
+			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid AND photos_albums.album_uid = ?", albums[0])
+			albumJoined = true
+		} else {
+			// Photos in more than one of the albums are returned once.
+			s = s.Where("photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE album_uid IN (?))", albums)
+		}
+	}
+
+	if f.Snapshot != "" {
+		s = s.Joins("JOIN snapshots_photos ON snapshots_photos.photo_uid = photos.photo_uid").Where("snapshots_photos.snapshot_uid = ?", f.Snapshot)
+	}
+
+	// Filter by the device that uploaded photos, see entity.Device.
+	if f.Device != "" {
+		s = s.Where("photos.device_uid IN (?)", strings.Split(f.Device, ","))
 	}
 
 	if f.Camera > 0 {
@@ -168,6 +275,11 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_month = ?", f.Month)
 	}
 
+	// Filter by photos without known date, e.g. scans that still need an approximate one.
+	if f.Undated {
+		s = s.Where("photos.taken_src = ''")
+	}
+
 	if f.Color != "" {
 		s = s.Where("files.file_main_color IN (?)", strings.Split(strings.ToLower(f.Color), ","))
 	}
@@ -185,6 +297,28 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_type IN (?)", strings.Split(strings.ToLower(f.Type), ","))
 	}
 
+	// Filter by category, "none" matches regular photos without category.
+	if f.Category != "" {
+		var categories []string
+		var none bool
+
+		for _, c := range strings.Split(strings.ToLower(f.Category), ",") {
+			if c == "none" {
+				none = true
+			} else {
+				categories = append(categories, c)
+			}
+		}
+
+		if none && len(categories) > 0 {
+			s = s.Where("photos.photo_category IS NULL OR photos.photo_category = '' OR photos.photo_category IN (?)", categories)
+		} else if none {
+			s = s.Where("photos.photo_category IS NULL OR photos.photo_category = ''")
+		} else {
+			s = s.Where("photos.photo_category IN (?)", categories)
+		}
+	}
+
 	if f.Video {
 		s = s.Where("photos.photo_type = 'video'")
 	} else if f.Photo {
@@ -211,6 +345,47 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_name LIKE ?", strings.ReplaceAll(f.Name, "*", "%"))
 	}
 
+	// Filter by original file name, including sidecar and RAW files.
+	if f.Filename != "" {
+		p := strings.ReplaceAll(strings.TrimPrefix(f.Filename, "/"), "*", "%")
+
+		if strings.Contains(p, "/") {
+			s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_name LIKE ? AND deleted_at IS NULL)", p)
+		} else {
+			s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE (file_name LIKE ? OR file_name LIKE ?) AND deleted_at IS NULL)", p, "%/"+p)
+		}
+	}
+
+	// Filter by file extension, e.g. "dng" or "cr2,nef".
+	if f.Ext != "" {
+		var where []string
+		var values []interface{}
+
+		for _, ext := range strings.Split(f.Ext, ",") {
+			ext = strings.TrimPrefix(strings.TrimSpace(ext), ".")
+
+			if ext == "" {
+				continue
+			}
+
+			where = append(where, "file_name LIKE ? OR file_name LIKE ?")
+			values = append(values, "%."+strings.ToLower(ext), "%."+strings.ToUpper(ext))
+		}
+
+		if len(where) > 0 {
+			s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE ("+strings.Join(where, " OR ")+") AND deleted_at IS NULL)", values...)
+		}
+	}
+
+	// Filter by video duration in seconds.
+	if f.Dmin > 0 {
+		s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_video = 1 AND file_duration >= ? AND deleted_at IS NULL)", time.Duration(f.Dmin)*time.Second)
+	}
+
+	if f.Dmax > 0 {
+		s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_video = 1 AND file_duration <= ? AND deleted_at IS NULL)", time.Duration(f.Dmax)*time.Second)
+	}
+
 	if f.Title != "" {
 		s = s.Where("LOWER(photos.photo_title) LIKE ?", strings.ReplaceAll(strings.ToLower(f.Title), "*", "%"))
 	}
@@ -227,14 +402,64 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("files.file_portrait = 1")
 	}
 
+	// Filter by aspect ratio, e.g. "portrait" or "landscape,panorama".
+	if f.Ratio != "" {
+		var where []string
+		var values []interface{}
+
+		for _, name := range strings.Split(f.Ratio, ",") {
+			min, max, ok := form.RatioRange(name)
+
+			if !ok {
+				return results, 0, fmt.Errorf("ratio must be portrait, landscape, square, or panorama")
+			}
+
+			where = append(where, "files.file_aspect_ratio >= ? AND files.file_aspect_ratio < ?")
+			values = append(values, min, max)
+		}
+
+		s = s.Where("files.file_aspect_ratio > 0").Where(strings.Join(where, " OR "), values...)
+	}
+
+	// Filter by resolution in megapixels, e.g. ">20".
+	if min, max, ok := form.IntRange(f.Mp); ok {
+		s = s.Where("photos.photo_resolution BETWEEN ? AND ?", min, max)
+	}
+
+	// Filter by width and height in pixels, e.g. ">=4000x3000", in either orientation so that
+	// portrait photos are found as well.
+	if f.Res != "" {
+		minWidth, maxWidth, minHeight, maxHeight, ok := form.SizeRange(f.Res)
+
+		if !ok {
+			return results, 0, fmt.Errorf("res must contain width and height, e.g. >=4000x3000")
+		}
+
+		s = s.Where("(files.file_width BETWEEN ? AND ? AND files.file_height BETWEEN ? AND ?) OR "+
+			"(files.file_width BETWEEN ? AND ? AND files.file_height BETWEEN ? AND ?)",
+			minWidth, maxWidth, minHeight, maxHeight, minHeight, maxHeight, minWidth, maxWidth)
+	}
+
 	if f.Mono {
-		s = s.Where("files.file_chroma = 0")
+		s = s.Where("files.file_mono = 1 OR files.file_chroma = 0")
 	} else if f.Chroma > 9 {
 		s = s.Where("files.file_chroma > ?", f.Chroma)
 	} else if f.Chroma > 0 {
 		s = s.Where("files.file_chroma > 0 AND files.file_chroma <= ?", f.Chroma)
 	}
 
+	// Filter by percentage of under- and overexposed pixels, see colors.Exposure().
+	switch strings.ToLower(f.Exposed) {
+	case colors.ExposureUnder:
+		s = s.Where("files.file_under > ? AND files.file_under >= files.file_over", colors.ExposureLimit)
+	case colors.ExposureOver:
+		s = s.Where("files.file_over > ? AND (files.file_under <= ? OR files.file_under < files.file_over)", colors.ExposureLimit, colors.ExposureLimit)
+	case colors.ExposureNormal:
+		s = s.Where("files.file_histogram <> '' AND files.file_under <= ? AND files.file_over <= ?", colors.ExposureLimit, colors.ExposureLimit)
+	case "bad":
+		s = s.Where("files.file_under > ? OR files.file_over > ?", colors.ExposureLimit, colors.ExposureLimit)
+	}
+
 	if f.Diff != 0 {
 		s = s.Where("files.file_diff = ?", f.Diff)
 	}
@@ -266,18 +491,65 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
 	}
 
+	// Filter by altitude in meters, e.g. ">2000".
+	if min, max, ok := form.IntRange(f.Alt); ok {
+		s = s.Where("photos.photo_altitude BETWEEN ? AND ?", min, max)
+	}
+
+	// Additional conditions on photo_year allow the query planner to skip partitions, see entity.PartitionPhotos.
+	// The year may differ from taken_at in UTC by one, and is unknown if the date was estimated.
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
+		s = s.Where("photos.photo_year <= ?", f.Before.Year()+1)
 	}
 
 	if !f.After.IsZero() {
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
+		s = s.Where("photos.photo_year >= ? OR photos.photo_year <= 0", f.After.Year()-1)
+	}
+
+	// Keep the original order of snapshots by default.
+	if f.Snapshot != "" && f.Order == "" {
+		f.Order = entity.SortOrderSnapshot
+	}
+
+	nearLat, nearLng, near := f.NearLatLng()
+
+	if f.Near != "" && !near {
+		return results, 0, fmt.Errorf("near must contain latitude and longitude, e.g. 52.52,13.405")
+	}
+
+	// Sort by distance if a point is given, photos without location are excluded.
+	if near && f.Order == "" {
+		f.Order = entity.SortOrderDistance
+	}
+
+	if f.Order == entity.SortOrderDistance {
+		if !near {
+			return results, 0, fmt.Errorf("near is required to sort by distance")
+		}
+
+		s = s.Where("photos.photo_lat <> 0 OR photos.photo_lng <> 0")
+	}
+
+	// Count all matches before the sort order and limit are set.
+	if total != nil {
+		if err := searchCount(s, total); err != nil {
+			return results, 0, err
+		}
+	}
+
+	// Photos can have a different caption in each album.
+	if albumJoined {
+		s = s.Select(cols + ", photos_albums.caption AS album_caption")
 	}
 
 	// Set sort order for results.
 	switch f.Order {
 	case entity.SortOrderRelevance:
-		if f.Label != "" {
+		if semanticRank != nil {
+			s = s.Order(semanticRank).Order("files.file_primary DESC")
+		} else if f.Label != "" {
 			s = s.Order("photo_quality DESC, photos_labels.uncertainty ASC, taken_at DESC, files.file_primary DESC")
 		} else {
 			s = s.Order("photo_quality DESC, taken_at DESC, files.file_primary DESC")
@@ -293,6 +565,23 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Order("files.file_main_color, photos.loc_uid, files.file_diff, taken_at DESC, files.file_primary DESC")
 	case entity.SortOrderName:
 		s = s.Order("photos.photo_path, photos.photo_name, files.file_primary DESC")
+	case entity.SortOrderDistance:
+		// Equirectangular approximation, accurate enough to sort and much faster than the great-circle distance.
+		s = s.Order(fmt.Sprintf("POW(photos.photo_lat - %.6f, 2) + POW(LEAST(ABS(photos.photo_lng - %.6f), 360 - ABS(photos.photo_lng - %.6f)) * %.6f, 2), "+
+			"taken_at DESC, photos.photo_uid, files.file_primary DESC", nearLat, nearLng, nearLng, math.Cos(nearLat*math.Pi/180)))
+	case entity.SortOrderAlbum:
+		// Photos without manual order are shown last.
+		if albumJoined {
+			s = s.Order("photos_albums.`order` = 0, photos_albums.`order`, taken_at, photos.photo_uid, files.file_primary DESC")
+		} else {
+			s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
+		}
+	case entity.SortOrderSnapshot:
+		if f.Snapshot != "" {
+			s = s.Order("snapshots_photos.photo_order, files.file_primary DESC")
+		} else {
+			s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
+		}
 	default:
 		s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
 	}
@@ -304,7 +593,15 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	if result := s.Scan(&results); result.Error != nil {
-		return results, 0, result.Error
+		return results, 0, searchErr(result.Error)
+	}
+
+	if near {
+		for i, r := range results {
+			if r.PhotoLat != 0 || r.PhotoLng != 0 {
+				results[i].Distance = s2.Distance(nearLat, nearLng, float64(r.PhotoLat), float64(r.PhotoLng))
+			}
+		}
 	}
 
 	log.Infof("photos: found %d results for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/semantic"
+	"github.com/photoprism/photoprism/internal/synonyms"
 )
 
 func TestPhotos(t *testing.T) {
@@ -172,6 +174,25 @@ func TestPhotos(t *testing.T) {
 		}
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("search for label using synonym", func(t *testing.T) {
+		synonyms.Default = make(synonyms.Index)
+		synonyms.Default.Add(synonyms.Pack{Lang: "de", Words: map[string][]string{"flower": {"blume"}}})
+
+		defer func() {
+			synonyms.Default = make(synonyms.Index)
+		}()
+
+		var f form.PhotoSearch
+		f.Query = "blume"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.LessOrEqual(t, 1, len(photos))
+	})
 	t.Run("search for archived", func(t *testing.T) {
 
 		var f form.PhotoSearch
@@ -259,6 +280,21 @@ func TestPhotos(t *testing.T) {
 		}
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("search for cold", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Count = 5000
+		f.Cold = true
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.True(t, p.FileCold)
+		}
+	})
 	t.Run("form.camera", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = ""
@@ -379,6 +415,29 @@ func TestPhotos(t *testing.T) {
 		assert.LessOrEqual(t, 1, len(photos))
 
 	})
+	t.Run("form.mono toned", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "mono:true"
+		f.Count = 100
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, p := range photos {
+			if p.FileUID == "ft3es39w45bnlqdw" {
+				found = true
+				assert.True(t, p.FileMono)
+			}
+		}
+
+		assert.True(t, found)
+	})
 	t.Run("form.mono", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = "mono:true"
@@ -527,4 +586,570 @@ func TestPhotos(t *testing.T) {
 		}
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("search in album with manual order", func(t *testing.T) {
+		album := entity.NewAlbum("Ordered Search", entity.TypeDefault)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum("pt9jtdre2lvl0yh7", album.AlbumUID))
+		entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum("pt9jtdre2lvl0y11", album.AlbumUID))
+
+		for _, order := range [][]string{{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh7"}, {"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y11"}} {
+			if err := entity.SetAlbumPhotoOrder(album.AlbumUID, order); err != nil {
+				t.Fatal(err)
+			}
+
+			var f form.PhotoSearch
+			f.Album = album.AlbumUID
+			f.Order = entity.SortOrderAlbum
+			f.Count = 10
+
+			photos, _, err := PhotoSearch(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var uids []string
+
+			for _, p := range photos {
+				if len(uids) == 0 || uids[len(uids)-1] != p.PhotoUID {
+					uids = append(uids, p.PhotoUID)
+				}
+			}
+
+			assert.Equal(t, order, uids)
+		}
+	})
+	t.Run("search in album by slug and label", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "album:holiday-2030 flower"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Equal(t, "pt9jtdre2lvl0yh7", p.PhotoUID)
+		}
+	})
+	t.Run("search in album by person", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "album:at9lxuqxpogaaba9 person:flower"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+	t.Run("search in multiple albums", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Album = "at9lxuqxpogaaba8,at9lxuqxpogaaba9"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := make(map[string]bool)
+
+		for _, p := range photos {
+			assert.False(t, found[p.PhotoUID+p.FileUID])
+			found[p.PhotoUID+p.FileUID] = true
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+	})
+	t.Run("album not found", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "album:no-such-album"
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+	t.Run("search for filename", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "filename:exampleFileName.jpg"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(photos))
+		assert.Equal(t, "exampleFileName.jpg", photos[0].FileName)
+	})
+	t.Run("search for ext", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "ext:.mp4"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("search for video duration", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "dmax:100"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		f.Query = "dmin:3600"
+
+		photos, _, err = PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("search for exposure", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "exposed:under"
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Less(t, uint8(20), p.FileUnder)
+		}
+
+		f.Query = "exposed:over"
+
+		photos, _, err = PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Less(t, uint8(20), p.FileOver)
+		}
+	})
+	t.Run("order by distance", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Near = "-21.34,55.46"
+		f.Order = entity.SortOrderDistance
+		f.Count = 5000
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+
+		for i, p := range photos {
+			assert.False(t, p.PhotoLat == 0 && p.PhotoLng == 0)
+
+			if i > 0 {
+				assert.LessOrEqual(t, photos[i-1].Distance, p.Distance+1)
+			}
+		}
+
+		assert.Less(t, photos[0].Distance, 10.0)
+	})
+	t.Run("near without order", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "near:48.52,9.05"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+		assert.Less(t, photos[0].Distance, 10.0)
+	})
+	t.Run("order by distance without point", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Order = entity.SortOrderDistance
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid point", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Near = "north"
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+	t.Run("search for altitude", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "alt:>2"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Less(t, 2, p.PhotoAltitude)
+		}
+	})
+	t.Run("search for portrait ratio", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "ratio:portrait"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Greater(t, p.FileAspectRatio, float32(0))
+			assert.Less(t, p.FileAspectRatio, float32(0.95))
+		}
+	})
+	t.Run("search for landscape or square ratio", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "ratio:landscape,square"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.FileAspectRatio, float32(0.95))
+			assert.Less(t, p.FileAspectRatio, float32(2))
+		}
+	})
+	t.Run("search for unknown ratio", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "ratio:round"
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.EqualError(t, err, "ratio must be portrait, landscape, square, or panorama")
+	})
+	t.Run("search for megapixels", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "mp:>=2"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.LessOrEqual(t, 2, p.PhotoResolution)
+		}
+	})
+	t.Run("search for resolution", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "res:>=1600x1200"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			if p.FileWidth >= p.FileHeight {
+				assert.LessOrEqual(t, 1600, p.FileWidth)
+				assert.LessOrEqual(t, 1200, p.FileHeight)
+			} else {
+				assert.LessOrEqual(t, 1200, p.FileWidth)
+				assert.LessOrEqual(t, 1600, p.FileHeight)
+			}
+		}
+	})
+	t.Run("search for invalid resolution", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "res:4000"
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.EqualError(t, err, "res must contain width and height, e.g. >=4000x3000")
+	})
+	t.Run("search for category", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "category:document"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Equal(t, entity.CategoryDocument, p.PhotoCategory)
+		}
+	})
+	t.Run("search for photos without category", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "category:none"
+		f.Count = 100
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Empty(t, p.PhotoCategory)
+		}
+	})
+	t.Run("semantic search disabled", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "semantic:\"red flower\""
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+	t.Run("semantic search", func(t *testing.T) {
+		semantic.SetEncoder(semanticTestEncoder{})
+		defer semantic.SetEncoder(nil)
+
+		semantic.Default.Clear()
+		defer semantic.Default.Clear()
+
+		assert.NoError(t, semantic.Default.Add("pt9jtdre2lvl0yh7", semantic.Vector{1, 0.1}))
+		assert.NoError(t, semantic.Default.Add("pt9jtdre2lvl0yh8", semantic.Vector{0, 1}))
+
+		var f form.PhotoSearch
+		f.Query = "semantic:\"red flower\""
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Equal(t, "pt9jtdre2lvl0yh7", p.PhotoUID)
+		}
+	})
+}
+
+type semanticTestEncoder struct{}
+
+func (semanticTestEncoder) Image(jpeg []byte) (semantic.Embedding, error) {
+	return semantic.Embedding{}, semantic.ErrEmpty
+}
+
+func (semanticTestEncoder) Text(text string) (semantic.Embedding, error) {
+	return semantic.Embedding{Vector: semantic.Vector{1, 0}, Model: "test"}, nil
+}
+
+func TestPhotoSearchTotal(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
+		photos, count, total, err := PhotoSearchTotal(form.PhotoSearch{Count: 2, Offset: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 2)
+		assert.Equal(t, 2, count)
+		assert.LessOrEqual(t, 4, total)
+
+		all, _, err := PhotoSearch(form.PhotoSearch{Count: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(all), total)
+	})
+	t.Run("id", func(t *testing.T) {
+		photos, _, total, err := PhotoSearchTotal(form.PhotoSearch{ID: "pt9jtdre2lvl0yh7", Merged: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, 1, total)
+	})
+}
+
+func TestPhotoSearch_AlbumFilter(t *testing.T) {
+	album := entity.NewAlbum("Smart Search", entity.TypeMoment)
+	album.AlbumFilter = "label:flower"
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _, err := PhotoSearch(form.PhotoSearch{Label: "flower", Count: 100})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		photos, _, err := PhotoSearch(form.PhotoSearch{Album: album.AlbumUID, Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(photos))
+	})
+	t.Run("query", func(t *testing.T) {
+		photos, _, err := PhotoSearch(form.PhotoSearch{Query: "album:" + album.AlbumUID, Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(photos))
+	})
+	t.Run("filter takes precedence", func(t *testing.T) {
+		photos, _, err := PhotoSearch(form.PhotoSearch{Album: album.AlbumUID, Label: "cake", Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(expected), len(photos))
+	})
+}
+
+func TestPhotoSearch_Undated(t *testing.T) {
+	photos, _, err := PhotoSearch(form.PhotoSearch{Undated: true, Count: 1000, Merged: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, len(photos))
+
+	for _, p := range photos {
+		assert.Equal(t, entity.SrcAuto, p.TakenSrc)
+	}
+}
+
+func TestPhotoSearch_AlbumCaption(t *testing.T) {
+	if err := entity.SetPhotoAlbumCaption("at9lxuqxpogaaba9", "pt9jtdre2lvl0y11", "Checkpoint Charlie"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.SetPhotoAlbumCaption("at9lxuqxpogaaba9", "pt9jtdre2lvl0y11", "")
+
+	photos, _, err := PhotoSearch(form.PhotoSearch{Album: "at9lxuqxpogaaba9", Count: 10, Merged: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, p := range photos {
+		if p.PhotoUID == "pt9jtdre2lvl0y11" {
+			assert.Equal(t, "Checkpoint Charlie", p.AlbumCaption)
+			found = true
+		}
+	}
+
+	assert.True(t, found)
+
+	photos, _, err = PhotoSearch(form.PhotoSearch{ID: "pt9jtdre2lvl0y11", Merged: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "", photos[0].AlbumCaption)
+}
+
+func TestPhotoSearch_Primary(t *testing.T) {
+	photos, _, err := PhotoSearch(form.PhotoSearch{Primary: true, Count: 1000})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, len(photos))
+
+	uids := make(map[string]bool, len(photos))
+
+	for _, p := range photos {
+		assert.True(t, p.FilePrimary)
+		assert.False(t, uids[p.PhotoUID])
+		uids[p.PhotoUID] = true
+	}
 }
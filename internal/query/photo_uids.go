@@ -0,0 +1,41 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// PhotoSearchUIDs returns the UIDs of all photos matching a search, regardless of count and offset.
+// Results are fetched in batches, so that large searches don't need to be paged by the client.
+func PhotoSearchUIDs(f form.PhotoSearch) (uids []string, err error) {
+	uids = []string{}
+	seen := make(map[string]bool)
+
+	f.Offset = 0
+	f.Count = 1000
+	f.Merged = false
+
+	for {
+		results, _, err := PhotoSearch(f)
+
+		if err != nil {
+			return uids, err
+		}
+
+		for _, r := range results {
+			if seen[r.PhotoUID] {
+				continue
+			}
+
+			seen[r.PhotoUID] = true
+			uids = append(uids, r.PhotoUID)
+		}
+
+		if len(results) < f.Count {
+			break
+		}
+
+		f.Offset += len(results)
+	}
+
+	return uids, nil
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoSearchUIDs(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		uids, err := PhotoSearchUIDs(form.PhotoSearch{Count: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := PhotoSearch(form.PhotoSearch{Count: 1000, Merged: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, uids, len(photos))
+	})
+	t.Run("filter", func(t *testing.T) {
+		uids, err := PhotoSearchUIDs(form.NewPhotoSearch("favorite:true"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(uids))
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := PhotoSearchUIDs(form.NewPhotoSearch("near:foo"))
+
+		assert.Error(t, err)
+	})
+}
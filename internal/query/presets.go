@@ -0,0 +1,23 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PresetByUID returns an action preset based on the UID.
+func PresetByUID(uid string) (preset entity.Preset, err error) {
+	if err := Db().Where("preset_uid = ?", uid).First(&preset).Error; err != nil {
+		return preset, err
+	}
+
+	return preset, nil
+}
+
+// Presets returns all action presets sorted by name.
+func Presets() (presets []entity.Preset, err error) {
+	presets = []entity.Preset{}
+
+	err = Db().Order("preset_name, id").Find(&presets).Error
+
+	return presets, err
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresetByUID(t *testing.T) {
+	m, err := entity.NewPreset(form.Preset{Name: "Private", Actions: []form.PresetAction{{Action: entity.PresetPrivate}}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("existing", func(t *testing.T) {
+		result, err := PresetByUID(m.PresetUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Private", result.PresetName)
+		assert.Equal(t, entity.PresetPrivate, result.Actions[0].Action)
+	})
+	t.Run("list", func(t *testing.T) {
+		results, err := Presets()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := PresetByUID("mt9jtdre2lvl0xxx")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,113 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Process represents a statement that is running in the database.
+type Process struct {
+	ID      uint64 `json:"ID"`
+	User    string `json:"User"`
+	Host    string `json:"Host"`
+	Command string `json:"Command"`
+	Seconds int    `json:"Seconds"`
+	State   string `json:"State"`
+	Info    string `json:"Info"`
+}
+
+// Processes returns the statements that are running in the current database, longest running first.
+// Idle connections and the process list query itself are skipped.
+func Processes() (results []Process, err error) {
+	results = []Process{}
+
+	var database string
+
+	if err := Db().Raw("SELECT DATABASE()").Row().Scan(&database); err != nil {
+		return results, err
+	}
+
+	rows, err := Db().Raw("SHOW FULL PROCESSLIST").Rows()
+
+	if err != nil {
+		return results, err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+
+	if err != nil {
+		return results, err
+	}
+
+	values := make([]sql.NullString, len(cols))
+	dest := make([]interface{}, len(cols))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return results, err
+		}
+
+		row := make(map[string]string, len(cols))
+
+		for i, col := range cols {
+			row[strings.ToLower(col)] = values[i].String
+		}
+
+		if row["db"] != database || row["command"] == "Sleep" || row["info"] == "" ||
+			strings.HasPrefix(strings.ToUpper(row["info"]), "SHOW FULL PROCESSLIST") {
+			continue
+		}
+
+		p := Process{
+			User:    row["user"],
+			Host:    row["host"],
+			Command: row["command"],
+			State:   row["state"],
+			Info:    row["info"],
+		}
+
+		p.ID, _ = strconv.ParseUint(row["id"], 10, 64)
+		p.Seconds, _ = strconv.Atoi(row["time"])
+
+		results = append(results, p)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Seconds > results[j].Seconds
+	})
+
+	return results, rows.Err()
+}
+
+// KillQuery cancels the statement of a process in the current database, the connection remains open.
+// TiDB requires a different syntax, as the regular KILL statement is ignored for compatibility.
+func KillQuery(id uint64, tidb bool) error {
+	processes, err := Processes()
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range processes {
+		if p.ID != id {
+			continue
+		}
+
+		if tidb {
+			return Db().Exec(fmt.Sprintf("KILL TIDB QUERY %d", id)).Error
+		}
+
+		return Db().Exec(fmt.Sprintf("KILL QUERY %d", id)).Error
+	}
+
+	return fmt.Errorf("query: process %d not found", id)
+}
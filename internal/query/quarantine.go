@@ -0,0 +1,28 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// QuarantineFiles returns quarantined uploads that need to be reviewed, most recent uploads first.
+func QuarantineFiles(f form.QuarantineFiles) (results []entity.QuarantineFile, err error) {
+	results = []entity.QuarantineFile{}
+
+	s := Db()
+
+	if f.Source != "" {
+		s = s.Where("source = ?", f.Source)
+	}
+
+	err = s.Order("created_at DESC, id DESC").Limit(f.Count).Offset(f.Offset).Find(&results).Error
+
+	return results, err
+}
+
+// QuarantineFileByID returns the quarantined file with the given id.
+func QuarantineFileByID(id uint) (result entity.QuarantineFile, err error) {
+	err = Db().Where("id = ?", id).First(&result).Error
+
+	return result, err
+}
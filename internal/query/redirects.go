@@ -0,0 +1,16 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// RedirectUID returns the current UID of a photo or album, which differs if the UID was redirected.
+func RedirectUID(uid string) string {
+	var result entity.Redirect
+
+	if err := Db().Where("from_uid = ?", uid).First(&result).Error; err != nil {
+		return uid
+	}
+
+	return result.ToUID
+}
@@ -0,0 +1,17 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectUID(t *testing.T) {
+	if err := entity.AddRedirect("at9lxuqxpogar001", "at9lxuqxpogaaba8"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "at9lxuqxpogaaba8", RedirectUID("at9lxuqxpogar001"))
+	assert.Equal(t, "at9lxuqxpogaaba9", RedirectUID("at9lxuqxpogaaba9"))
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Sources of search suggestions.
+const (
+	SuggestionPinned  = "pinned"
+	SuggestionHistory = "history"
+	SuggestionLabel   = "label"
+)
+
+// SearchSuggestion contains a query suggested while typing a search.
+type SearchSuggestion struct {
+	Query  string `json:"Query"`
+	Source string `json:"Source"`
+	ID     uint   `json:"ID,omitempty"`
+}
+
+// SearchHistory returns the searches of a user, pinned searches first and then the most recently used.
+func SearchHistory(userID string, limit, offset int) (results []entity.SearchHistory, err error) {
+	results = []entity.SearchHistory{}
+
+	err = Db().Where("user_id = ?", userID).
+		Order("pinned DESC, updated_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&results).Error
+
+	return results, err
+}
+
+// SearchHistoryByID returns a search of a user, searches of other users are not found.
+func SearchHistoryByID(userID string, id uint) (result entity.SearchHistory, err error) {
+	err = Db().Where("user_id = ? AND id = ?", userID, id).First(&result).Error
+
+	return result, err
+}
+
+// SearchSuggestions returns queries to suggest while typing a search. Previous searches of the user come
+// first, pinned and frequent ones before others, followed by the names of matching labels.
+func SearchSuggestions(userID, q string, limit int) (results []SearchSuggestion, err error) {
+	results = []SearchSuggestion{}
+	q = strings.ToLower(entity.NormalizeSearch(q))
+
+	var searches []entity.SearchHistory
+
+	s := Db().Where("user_id = ?", userID)
+
+	if q != "" {
+		s = s.Where("LOWER(search_query) LIKE ? OR LOWER(search_query) LIKE ?", q+"%", "% "+q+"%")
+	}
+
+	if err := s.Order("pinned DESC, search_count DESC, updated_at DESC").Limit(limit).Find(&searches).Error; err != nil {
+		return results, err
+	}
+
+	known := make(map[string]bool)
+
+	for _, m := range searches {
+		source := SuggestionHistory
+
+		if m.Pinned {
+			source = SuggestionPinned
+		}
+
+		known[strings.ToLower(m.SearchQuery)] = true
+		results = append(results, SearchSuggestion{Query: m.SearchQuery, Source: source, ID: m.ID})
+	}
+
+	if q == "" || len(results) >= limit {
+		return results, nil
+	}
+
+	var labels []string
+
+	if err := Db().Model(&entity.Label{}).
+		Where("LOWER(label_name) LIKE ? AND label_priority >= 0", q+"%").
+		Order("label_favorite DESC, label_priority DESC, label_name").
+		Limit(limit).
+		Pluck("label_name", &labels).Error; err != nil {
+		return results, err
+	}
+
+	for _, name := range labels {
+		if len(results) >= limit {
+			break
+		} else if known[strings.ToLower(name)] {
+			continue
+		}
+
+		known[strings.ToLower(name)] = true
+		results = append(results, SearchSuggestion{Query: name, Source: SuggestionLabel})
+	}
+
+	return results, nil
+}
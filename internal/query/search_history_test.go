@@ -0,0 +1,96 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchHistory(t *testing.T) {
+	userID := "query-history@example.com"
+
+	for _, q := range []string{"beach", "mountains", "beach"} {
+		if _, err := entity.AddSearchHistory(userID, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := SearchHistory(userID, 10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 2)
+
+	result, err := SearchHistoryByID(userID, results[0].ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, results[0].SearchQuery, result.SearchQuery)
+
+	_, err = SearchHistoryByID("other@example.com", results[0].ID)
+
+	assert.Error(t, err)
+}
+
+func TestSearchSuggestions(t *testing.T) {
+	userID := "query-suggestions@example.com"
+
+	for _, q := range []string{"flower garden", "flower", "flower", "forest"} {
+		if _, err := entity.AddSearchHistory(userID, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("frequent first", func(t *testing.T) {
+		results, err := SearchSuggestions(userID, "fl", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+		assert.Equal(t, "flower", results[0].Query)
+		assert.Equal(t, SuggestionHistory, results[0].Source)
+		assert.Equal(t, "flower garden", results[1].Query)
+
+		for _, r := range results {
+			assert.NotEqual(t, "Flower", r.Query)
+		}
+	})
+	t.Run("pinned first", func(t *testing.T) {
+		m, err := entity.AddSearchHistory(userID, "forest")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SetPinned(true); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := SearchSuggestions(userID, "", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 3)
+		assert.Equal(t, "forest", results[0].Query)
+		assert.Equal(t, SuggestionPinned, results[0].Source)
+	})
+	t.Run("labels", func(t *testing.T) {
+		results, err := SearchSuggestions("query-suggestions-labels@example.com", "flo", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.Equal(t, SuggestionLabel, results[0].Source)
+	})
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// MaxSnapshotPhotos is the maximum number of photos in a snapshot.
+const MaxSnapshotPhotos = 10000
+
+// SnapshotByUID returns a snapshot including its share links based on the UID.
+func SnapshotByUID(uid string) (snapshot entity.Snapshot, err error) {
+	if err := Db().Where("snapshot_uid = ?", uid).Preload("Links").First(&snapshot).Error; err != nil {
+		return snapshot, err
+	}
+
+	return snapshot, nil
+}
+
+// Snapshots returns all snapshots, newest first.
+func Snapshots() (snapshots []entity.Snapshot, err error) {
+	snapshots = []entity.Snapshot{}
+
+	err = Db().Order("created_at DESC, id DESC").Find(&snapshots).Error
+
+	return snapshots, err
+}
+
+// SnapshotSearch returns the UIDs of all photos matching the search form in result order,
+// up to MaxSnapshotPhotos.
+func SnapshotSearch(f form.PhotoSearch) (photoUIDs []string, err error) {
+	photoUIDs = []string{}
+	found := make(map[string]bool)
+
+	f.Count = 1000
+	f.Offset = 0
+	f.Merged = false
+
+	for len(photoUIDs) < MaxSnapshotPhotos {
+		results, count, err := PhotoSearch(f)
+
+		if err != nil {
+			return photoUIDs, err
+		}
+
+		for _, r := range results {
+			if found[r.PhotoUID] {
+				continue
+			}
+
+			found[r.PhotoUID] = true
+			photoUIDs = append(photoUIDs, r.PhotoUID)
+
+			if len(photoUIDs) == MaxSnapshotPhotos {
+				break
+			}
+		}
+
+		if count < f.Count {
+			break
+		}
+
+		f.Offset += f.Count
+	}
+
+	return photoUIDs, nil
+}
+
+// SnapshotPhotos returns the UIDs of the given photos that exist and are not archived, keeping their order.
+func SnapshotPhotos(photoUIDs []string) (result []string, err error) {
+	result = []string{}
+
+	var existing []string
+
+	if err := Db().Model(&entity.Photo{}).Where("photo_uid IN (?)", photoUIDs).Pluck("photo_uid", &existing).Error; err != nil {
+		return result, err
+	}
+
+	found := make(map[string]bool, len(existing))
+
+	for _, uid := range existing {
+		found[uid] = true
+	}
+
+	for _, uid := range photoUIDs {
+		if found[uid] {
+			delete(found, uid)
+			result = append(result, uid)
+		}
+	}
+
+	return result, nil
+}
+
+// SnapshotFiles returns the primary files of all photos in a snapshot in their original order.
+func SnapshotFiles(snapshotUID string) (files []entity.File, err error) {
+	err = Db().Table("files").Select("files.*").
+		Joins("JOIN snapshots_photos ON snapshots_photos.photo_uid = files.photo_uid").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("snapshots_photos.snapshot_uid = ?", snapshotUID).
+		Where("files.file_primary = 1 AND files.file_missing = 0").
+		Order("snapshots_photos.photo_order").
+		Find(&files).Error
+
+	return files, err
+}
@@ -0,0 +1,105 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotSearch(t *testing.T) {
+	all, err := SnapshotSearch(form.NewPhotoSearch(""))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, all)
+
+	found := make(map[string]bool)
+
+	for _, uid := range all {
+		assert.False(t, found[uid])
+		found[uid] = true
+	}
+
+	favorites, err := SnapshotSearch(form.NewPhotoSearch("favorite:true"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Less(t, len(favorites), len(all))
+}
+
+func TestSnapshotPhotos(t *testing.T) {
+	result, err := SnapshotPhotos([]string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0xxx", "pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"}, result)
+}
+
+func TestSnapshotByUID(t *testing.T) {
+	m := entity.NewSnapshot("Yearbook", "", []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"})
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("existing", func(t *testing.T) {
+		result, err := SnapshotByUID(m.SnapshotUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Yearbook", result.SnapshotTitle)
+		assert.Equal(t, 2, result.PhotoCount)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := SnapshotByUID("st9lxuqxpogaaxxx")
+		assert.Error(t, err)
+	})
+	t.Run("list", func(t *testing.T) {
+		results, err := Snapshots()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+	t.Run("files", func(t *testing.T) {
+		files, err := SnapshotFiles(m.SnapshotUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range files {
+			assert.True(t, f.FilePrimary)
+		}
+	})
+	t.Run("search", func(t *testing.T) {
+		f := form.PhotoSearch{Snapshot: m.SnapshotUID, Count: 10}
+		results, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.Contains(t, []string{"pt9jtdre2lvl0yh8", "pt9jtdre2lvl0yh7"}, r.PhotoUID)
+		}
+	})
+	t.Run("shared by link", func(t *testing.T) {
+		link := entity.Link{ShareUID: m.SnapshotUID}
+
+		assert.True(t, LinkSharesPhoto(link, "pt9jtdre2lvl0yh7"))
+		assert.False(t, LinkSharesPhoto(link, "pt9jtdre2lvl0y11"))
+	})
+}
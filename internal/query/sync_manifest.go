@@ -0,0 +1,289 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SyncPhoto represents a changed photo and the hashes of its files.
+type SyncPhoto struct {
+	UID    string   `json:"UID"`
+	Hashes []string `json:"Hashes"`
+}
+
+// SyncAlbum represents an album with changed metadata or photos.
+type SyncAlbum struct {
+	UID     string   `json:"UID"`
+	Deleted bool     `json:"Deleted,omitempty"`
+	Photos  []string `json:"Photos"`
+}
+
+// SyncManifest contains the changes since the last sync. Large manifests are split into pages: if HasMore
+// is true, clients request the next page with Cursor and otherwise use Until as "since" value next time.
+type SyncManifest struct {
+	Since   time.Time   `json:"Since"`
+	Until   time.Time   `json:"Until"`
+	HasMore bool        `json:"HasMore"`
+	Cursor  string      `json:"Cursor,omitempty"`
+	Added   []SyncPhoto `json:"Added"`
+	Updated []SyncPhoto `json:"Updated"`
+	Deleted []string    `json:"Deleted"`
+	Albums  []SyncAlbum `json:"Albums"`
+}
+
+// syncCursor represents the position of the next manifest page. Photos are listed before albums.
+type syncCursor struct {
+	Until   time.Time
+	Albums  bool
+	AfterID uint
+}
+
+// String returns the cursor as string, e.g. "1600000000000000000.p.1000123".
+func (c syncCursor) String() string {
+	phase := "p"
+
+	if c.Albums {
+		phase = "a"
+	}
+
+	return fmt.Sprintf("%d.%s.%d", c.Until.UnixNano(), phase, c.AfterID)
+}
+
+// parseSyncCursor parses a cursor returned by a previous request, see syncCursor.String.
+func parseSyncCursor(s string) (c syncCursor, err error) {
+	parts := strings.Split(s, ".")
+
+	if len(parts) != 3 || parts[1] != "p" && parts[1] != "a" {
+		return c, fmt.Errorf("invalid cursor %s", txt.Quote(s))
+	}
+
+	until, err := strconv.ParseInt(parts[0], 10, 64)
+
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor %s", txt.Quote(s))
+	}
+
+	afterID, err := strconv.ParseUint(parts[2], 10, 64)
+
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor %s", txt.Quote(s))
+	}
+
+	return syncCursor{Until: time.Unix(0, until).UTC(), Albums: parts[1] == "a", AfterID: uint(afterID)}, nil
+}
+
+// syncPhotoRow represents a photo row in the sync manifest query result.
+type syncPhotoRow struct {
+	ID        uint
+	PhotoUID  string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// syncFileRow represents a file row in the sync manifest query result.
+type syncFileRow struct {
+	PhotoID  uint
+	FileHash string
+}
+
+// syncAlbumRow represents an album row in the sync manifest query result.
+type syncAlbumRow struct {
+	ID        uint
+	AlbumUID  string
+	DeletedAt *time.Time
+}
+
+// syncAlbumPhotoRow represents an album photo row in the sync manifest query result.
+type syncAlbumPhotoRow struct {
+	AlbumUID string
+	PhotoUID string
+}
+
+// NewSyncManifest returns a compact list of up to limit photos and albums that changed since the given time,
+// starting at the cursor returned with the previous page, if any.
+func NewSyncManifest(since time.Time, cursor string, limit int) (result SyncManifest, err error) {
+	result = SyncManifest{
+		Since:   since,
+		Until:   time.Now().UTC(),
+		Added:   []SyncPhoto{},
+		Updated: []SyncPhoto{},
+		Deleted: []string{},
+		Albums:  []SyncAlbum{},
+	}
+
+	pos := syncCursor{Until: result.Until}
+
+	if cursor != "" {
+		if pos, err = parseSyncCursor(cursor); err != nil {
+			return result, err
+		}
+
+		result.Until = pos.Until
+	}
+
+	if limit < 1 {
+		return result, fmt.Errorf("invalid limit %d", limit)
+	}
+
+	if !pos.Albums {
+		var photos []syncPhotoRow
+
+		if err := UnscopedDb().Table("photos").
+			Select("photos.id, photos.photo_uid, photos.created_at, photos.deleted_at").
+			Where(`photos.created_at > ? OR photos.updated_at > ? OR photos.deleted_at > ? 
+			OR photos.id IN (SELECT photo_id FROM files WHERE updated_at > ? OR deleted_at > ?)`,
+				since, since, since, since, since).
+			Where("photos.id > ?", pos.AfterID).
+			Order("photos.id").Limit(limit + 1).
+			Scan(&photos).Error; err != nil {
+			return result, err
+		}
+
+		if len(photos) > limit {
+			photos = photos[:limit]
+			result.HasMore = true
+			result.Cursor = syncCursor{Until: result.Until, AfterID: photos[limit-1].ID}.String()
+		}
+
+		hashes, err := syncFileHashes(photos)
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, row := range photos {
+			p := SyncPhoto{UID: row.PhotoUID, Hashes: hashes[row.ID]}
+
+			if p.Hashes == nil {
+				p.Hashes = []string{}
+			}
+
+			switch {
+			case row.DeletedAt != nil:
+				if row.CreatedAt.Before(since) || row.CreatedAt.Equal(since) {
+					result.Deleted = append(result.Deleted, p.UID)
+				}
+			case row.CreatedAt.After(since):
+				result.Added = append(result.Added, p)
+			default:
+				result.Updated = append(result.Updated, p)
+			}
+		}
+
+		pos = syncCursor{Until: result.Until, Albums: true}
+		limit -= len(photos)
+
+		if result.HasMore {
+			return result, nil
+		} else if limit == 0 {
+			result.HasMore = true
+			result.Cursor = pos.String()
+			return result, nil
+		}
+	}
+
+	var albums []syncAlbumRow
+
+	if err := UnscopedDb().Table("albums").
+		Select("albums.id, albums.album_uid, albums.deleted_at").
+		Where(`albums.updated_at > ? OR albums.deleted_at > ? 
+		OR albums.album_uid IN (SELECT album_uid FROM photos_albums WHERE created_at > ? OR updated_at > ?)`,
+			since, since, since, since).
+		Where("albums.id > ?", pos.AfterID).
+		Order("albums.id").Limit(limit + 1).
+		Scan(&albums).Error; err != nil {
+		return result, err
+	}
+
+	if len(albums) > limit {
+		albums = albums[:limit]
+		result.HasMore = true
+		result.Cursor = syncCursor{Until: result.Until, Albums: true, AfterID: albums[limit-1].ID}.String()
+	}
+
+	members, err := syncAlbumPhotos(albums)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, row := range albums {
+		a := SyncAlbum{UID: row.AlbumUID, Deleted: row.DeletedAt != nil, Photos: []string{}}
+
+		if !a.Deleted && members[row.AlbumUID] != nil {
+			a.Photos = members[row.AlbumUID]
+		}
+
+		result.Albums = append(result.Albums, a)
+	}
+
+	return result, nil
+}
+
+// syncFileHashes returns the hashes of existing files by photo id, primary files first.
+func syncFileHashes(photos []syncPhotoRow) (result map[uint][]string, err error) {
+	result = make(map[uint][]string, len(photos))
+
+	if len(photos) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, len(photos))
+
+	for i, p := range photos {
+		ids[i] = p.ID
+	}
+
+	var files []syncFileRow
+
+	if err := UnscopedDb().Table("files").
+		Select("files.photo_id, files.file_hash").
+		Where("files.photo_id IN (?) AND files.deleted_at IS NULL AND files.file_missing = 0", ids).
+		Order("files.photo_id, files.file_primary DESC, files.id").
+		Scan(&files).Error; err != nil {
+		return result, err
+	}
+
+	for _, f := range files {
+		if f.FileHash != "" {
+			result[f.PhotoID] = append(result[f.PhotoID], f.FileHash)
+		}
+	}
+
+	return result, nil
+}
+
+// syncAlbumPhotos returns the UIDs of visible photos by album UID.
+func syncAlbumPhotos(albums []syncAlbumRow) (result map[string][]string, err error) {
+	result = make(map[string][]string, len(albums))
+
+	if len(albums) == 0 {
+		return result, nil
+	}
+
+	uids := make([]string, len(albums))
+
+	for i, a := range albums {
+		uids[i] = a.AlbumUID
+	}
+
+	var rows []syncAlbumPhotoRow
+
+	if err := UnscopedDb().Table("photos_albums").
+		Select("photos_albums.album_uid, photos_albums.photo_uid").
+		Where("photos_albums.album_uid IN (?) AND photos_albums.hidden = 0", uids).
+		Order("photos_albums.album_uid, photos_albums.photo_uid").
+		Scan(&rows).Error; err != nil {
+		return result, err
+	}
+
+	for _, r := range rows {
+		result[r.AlbumUID] = append(result[r.AlbumUID], r.PhotoUID)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSyncManifest(t *testing.T) {
+	t.Run("full sync", func(t *testing.T) {
+		result, err := NewSyncManifest(time.Time{}, "", 10000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 3, len(result.Added))
+		assert.Empty(t, result.Updated)
+		assert.Empty(t, result.Deleted)
+		assert.LessOrEqual(t, 1, len(result.Albums))
+		assert.True(t, result.Until.After(result.Since))
+	})
+	t.Run("no changes", func(t *testing.T) {
+		result, err := NewSyncManifest(time.Now().Add(time.Hour), "", 10000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result.Added)
+		assert.Empty(t, result.Updated)
+		assert.Empty(t, result.Deleted)
+		assert.Empty(t, result.Albums)
+	})
+	t.Run("pages", func(t *testing.T) {
+		all, err := NewSyncManifest(time.Time{}, "", 10000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var added, albums int
+		var until time.Time
+		cursor := ""
+
+		for i := 0; i < 1000; i++ {
+			result, err := NewSyncManifest(time.Time{}, cursor, 2)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.LessOrEqual(t, len(result.Added)+len(result.Updated)+len(result.Deleted)+len(result.Albums), 2)
+
+			if cursor == "" {
+				until = result.Until
+			} else {
+				assert.True(t, until.Equal(result.Until))
+			}
+
+			added += len(result.Added)
+			albums += len(result.Albums)
+
+			if !result.HasMore {
+				assert.Empty(t, result.Cursor)
+				break
+			}
+
+			cursor = result.Cursor
+		}
+
+		assert.Equal(t, len(all.Added), added)
+		assert.Equal(t, len(all.Albums), albums)
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := NewSyncManifest(time.Time{}, "1600000000000000000.x.1", 10)
+		assert.Error(t, err)
+	})
+}
+
+func TestSyncCursor(t *testing.T) {
+	c := syncCursor{Until: time.Unix(1600000000, 123).UTC(), Albums: true, AfterID: 42}
+
+	assert.Equal(t, "1600000000000000123.a.42", c.String())
+
+	result, err := parseSyncCursor(c.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, c, result)
+
+	_, err = parseSyncCursor("")
+	assert.Error(t, err)
+}
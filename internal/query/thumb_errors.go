@@ -0,0 +1,36 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// ThumbErrors returns thumbnails that could not be rendered, most failures first. Only thumbnails that
+// aren't retried automatically anymore are returned if permanent is set.
+func ThumbErrors(f form.ThumbErrors) (results []entity.ThumbError, err error) {
+	results = []entity.ThumbError{}
+
+	s := Db()
+
+	if f.Permanent {
+		s = s.Where("error_count >= ?", entity.ThumbErrorLimit)
+	}
+
+	err = s.Order("error_count DESC, updated_at DESC, id DESC").Limit(f.Count).Offset(f.Offset).Find(&results).Error
+
+	return results, err
+}
+
+// ThumbError returns the failure recorded for a thumbnail type and file hash.
+func ThumbError(fileHash, thumbType string) (result entity.ThumbError, err error) {
+	err = Db().Where("file_hash = ? AND thumb_type = ?", fileHash, thumbType).First(&result).Error
+
+	return result, err
+}
+
+// ThumbErrorByID returns the thumbnail error with the given id.
+func ThumbErrorByID(id uint) (result entity.ThumbError, err error) {
+	err = Db().Where("id = ?", id).First(&result).Error
+
+	return result, err
+}
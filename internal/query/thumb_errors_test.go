@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbErrors(t *testing.T) {
+	m, err := entity.SaveThumbError("ad9168fa6acc5c5c2965ddf6ec465ca42fd8182", "fit_720", "2020/broken/truncated.jpg", "unexpected EOF")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		results, err := ThumbErrors(form.ThumbErrors{Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(results))
+	})
+	t.Run("permanent", func(t *testing.T) {
+		results, err := ThumbErrors(form.ThumbErrors{Permanent: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.True(t, r.Permanent())
+		}
+	})
+	t.Run("hash and type", func(t *testing.T) {
+		result, err := ThumbError(m.FileHash, "fit_720")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.ID, result.ID)
+
+		_, err = ThumbError(m.FileHash, "tile_50")
+		assert.Error(t, err)
+	})
+	t.Run("id", func(t *testing.T) {
+		result, err := ThumbErrorByID(m.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2020/broken/truncated.jpg", result.FileName)
+
+		_, err = ThumbErrorByID(999999)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SearchTimeout is the max execution time of search queries, disabled if 0. The limit is set with
+// an optimizer hint that is supported by MySQL 5.7+, and ignored by other databases.
+var SearchTimeout time.Duration
+
+// ErrSearchTimeout is returned if a search query was interrupted because it exceeded the timeout.
+var ErrSearchTimeout = errors.New("search took too long, please try a more specific query")
+
+// timeoutHint returns the optimizer hint that limits the execution time of SELECT statements.
+func timeoutHint() string {
+	if SearchTimeout <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("/*+ MAX_EXECUTION_TIME(%d) */ ", SearchTimeout.Milliseconds())
+}
+
+// countSelect returns the select list for counting search results, including the timeout hint.
+func countSelect() string {
+	return timeoutHint() + "COUNT(*)"
+}
+
+// searchCount counts the rows matched by a search query. Unlike Count, it keeps the timeout hint,
+// as counting all matches is often the most expensive part of a search.
+func searchCount(s *gorm.DB, total *int) error {
+	return searchErr(s.Select(countSelect()).Row().Scan(total))
+}
+
+// searchErr returns ErrSearchTimeout if the database interrupted a query because of the timeout.
+func searchErr(err error) error {
+	if err != nil && strings.Contains(err.Error(), "maximum statement execution time exceeded") {
+		log.Warnf("query: %s", err)
+		return ErrSearchTimeout
+	}
+
+	return err
+}
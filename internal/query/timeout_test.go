@@ -0,0 +1,58 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutHint(t *testing.T) {
+	defer func() { SearchTimeout = 0 }()
+
+	assert.Equal(t, "", timeoutHint())
+
+	SearchTimeout = 30 * time.Second
+
+	assert.Equal(t, "/*+ MAX_EXECUTION_TIME(30000) */ ", timeoutHint())
+}
+
+func TestSearchCount(t *testing.T) {
+	defer func() { SearchTimeout = 0 }()
+
+	assert.Equal(t, "COUNT(*)", countSelect())
+
+	SearchTimeout = 30 * time.Second
+
+	assert.Equal(t, "/*+ MAX_EXECUTION_TIME(30000) */ COUNT(*)", countSelect())
+
+	var total int
+
+	if err := searchCount(Db().Table("albums").Where("albums.deleted_at IS NULL"), &total); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, total)
+}
+
+func TestSearchErr(t *testing.T) {
+	assert.Nil(t, searchErr(nil))
+	assert.Equal(t, ErrSearchTimeout, searchErr(errors.New("Error 3024: Query execution was interrupted, maximum statement execution time exceeded")))
+
+	err := errors.New("Error 1054: Unknown column")
+
+	assert.Equal(t, err, searchErr(err))
+}
+
+func TestProcesses(t *testing.T) {
+	results, err := Processes()
+
+	assert.NoError(t, err)
+
+	for _, p := range results {
+		assert.NotEqual(t, "Sleep", p.Command)
+	}
+
+	assert.Error(t, KillQuery(0, false))
+}
@@ -0,0 +1,994 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: photoprism.proto
+
+package rpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SearchRequest struct {
+	Query                string   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Offset               int32    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Order                string   `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{0}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+type SearchResponse struct {
+	Photos               []*Photo `protobuf:"bytes,1,rep,name=photos,proto3" json:"photos,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{1}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetPhotos() []*Photo {
+	if m != nil {
+		return m.Photos
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type PhotoRequest struct {
+	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PhotoRequest) Reset()         { *m = PhotoRequest{} }
+func (m *PhotoRequest) String() string { return proto.CompactTextString(m) }
+func (*PhotoRequest) ProtoMessage()    {}
+func (*PhotoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{2}
+}
+
+func (m *PhotoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PhotoRequest.Unmarshal(m, b)
+}
+func (m *PhotoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PhotoRequest.Marshal(b, m, deterministic)
+}
+func (m *PhotoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhotoRequest.Merge(m, src)
+}
+func (m *PhotoRequest) XXX_Size() int {
+	return xxx_messageInfo_PhotoRequest.Size(m)
+}
+func (m *PhotoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhotoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PhotoRequest proto.InternalMessageInfo
+
+func (m *PhotoRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+type Photo struct {
+	Uid                  string               `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Type                 string               `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Title                string               `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description          string               `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	TakenAt              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=taken_at,json=takenAt,proto3" json:"taken_at,omitempty"`
+	TimeZone             string               `protobuf:"bytes,6,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Path                 string               `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	Name                 string               `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	Year                 int32                `protobuf:"varint,9,opt,name=year,proto3" json:"year,omitempty"`
+	Month                int32                `protobuf:"varint,10,opt,name=month,proto3" json:"month,omitempty"`
+	Country              string               `protobuf:"bytes,11,opt,name=country,proto3" json:"country,omitempty"`
+	Lat                  float64              `protobuf:"fixed64,12,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng                  float64              `protobuf:"fixed64,13,opt,name=lng,proto3" json:"lng,omitempty"`
+	Altitude             int32                `protobuf:"varint,14,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Favorite             bool                 `protobuf:"varint,15,opt,name=favorite,proto3" json:"favorite,omitempty"`
+	Private              bool                 `protobuf:"varint,16,opt,name=private,proto3" json:"private,omitempty"`
+	CameraMake           string               `protobuf:"bytes,17,opt,name=camera_make,json=cameraMake,proto3" json:"camera_make,omitempty"`
+	CameraModel          string               `protobuf:"bytes,18,opt,name=camera_model,json=cameraModel,proto3" json:"camera_model,omitempty"`
+	CameraSerial         string               `protobuf:"bytes,19,opt,name=camera_serial,json=cameraSerial,proto3" json:"camera_serial,omitempty"`
+	Quality              int32                `protobuf:"varint,20,opt,name=quality,proto3" json:"quality,omitempty"`
+	Files                []*File              `protobuf:"bytes,21,rep,name=files,proto3" json:"files,omitempty"`
+	Direction            float32              `protobuf:"fixed32,22,opt,name=direction,proto3" json:"direction,omitempty"`
+	Speed                float32              `protobuf:"fixed32,23,opt,name=speed,proto3" json:"speed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Photo) Reset()         { *m = Photo{} }
+func (m *Photo) String() string { return proto.CompactTextString(m) }
+func (*Photo) ProtoMessage()    {}
+func (*Photo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{3}
+}
+
+func (m *Photo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Photo.Unmarshal(m, b)
+}
+func (m *Photo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Photo.Marshal(b, m, deterministic)
+}
+func (m *Photo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Photo.Merge(m, src)
+}
+func (m *Photo) XXX_Size() int {
+	return xxx_messageInfo_Photo.Size(m)
+}
+func (m *Photo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Photo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Photo proto.InternalMessageInfo
+
+func (m *Photo) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *Photo) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Photo) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Photo) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Photo) GetTakenAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.TakenAt
+	}
+	return nil
+}
+
+func (m *Photo) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *Photo) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Photo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Photo) GetYear() int32 {
+	if m != nil {
+		return m.Year
+	}
+	return 0
+}
+
+func (m *Photo) GetMonth() int32 {
+	if m != nil {
+		return m.Month
+	}
+	return 0
+}
+
+func (m *Photo) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *Photo) GetLat() float64 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Photo) GetLng() float64 {
+	if m != nil {
+		return m.Lng
+	}
+	return 0
+}
+
+func (m *Photo) GetAltitude() int32 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *Photo) GetFavorite() bool {
+	if m != nil {
+		return m.Favorite
+	}
+	return false
+}
+
+func (m *Photo) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *Photo) GetCameraMake() string {
+	if m != nil {
+		return m.CameraMake
+	}
+	return ""
+}
+
+func (m *Photo) GetCameraModel() string {
+	if m != nil {
+		return m.CameraModel
+	}
+	return ""
+}
+
+func (m *Photo) GetCameraSerial() string {
+	if m != nil {
+		return m.CameraSerial
+	}
+	return ""
+}
+
+func (m *Photo) GetQuality() int32 {
+	if m != nil {
+		return m.Quality
+	}
+	return 0
+}
+
+func (m *Photo) GetFiles() []*File {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *Photo) GetDirection() float32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+func (m *Photo) GetSpeed() float32 {
+	if m != nil {
+		return m.Speed
+	}
+	return 0
+}
+
+type File struct {
+	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Root                 string   `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	Hash                 string   `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Size                 int64    `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Type                 string   `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	Mime                 string   `protobuf:"bytes,7,opt,name=mime,proto3" json:"mime,omitempty"`
+	Width                int32    `protobuf:"varint,8,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
+	Primary              bool     `protobuf:"varint,10,opt,name=primary,proto3" json:"primary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *File) Reset()         { *m = File{} }
+func (m *File) String() string { return proto.CompactTextString(m) }
+func (*File) ProtoMessage()    {}
+func (*File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{4}
+}
+
+func (m *File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_File.Unmarshal(m, b)
+}
+func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+}
+func (m *File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_File.Merge(m, src)
+}
+func (m *File) XXX_Size() int {
+	return xxx_messageInfo_File.Size(m)
+}
+func (m *File) XXX_DiscardUnknown() {
+	xxx_messageInfo_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_File proto.InternalMessageInfo
+
+func (m *File) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *File) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *File) GetRoot() string {
+	if m != nil {
+		return m.Root
+	}
+	return ""
+}
+
+func (m *File) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *File) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *File) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *File) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *File) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *File) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *File) GetPrimary() bool {
+	if m != nil {
+		return m.Primary
+	}
+	return false
+}
+
+type FileRequest struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileRequest) Reset()         { *m = FileRequest{} }
+func (m *FileRequest) String() string { return proto.CompactTextString(m) }
+func (*FileRequest) ProtoMessage()    {}
+func (*FileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{5}
+}
+
+func (m *FileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileRequest.Unmarshal(m, b)
+}
+func (m *FileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileRequest.Marshal(b, m, deterministic)
+}
+func (m *FileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileRequest.Merge(m, src)
+}
+func (m *FileRequest) XXX_Size() int {
+	return xxx_messageInfo_FileRequest.Size(m)
+}
+func (m *FileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileRequest proto.InternalMessageInfo
+
+func (m *FileRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type FileChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileChunk) Reset()         { *m = FileChunk{} }
+func (m *FileChunk) String() string { return proto.CompactTextString(m) }
+func (*FileChunk) ProtoMessage()    {}
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{6}
+}
+
+func (m *FileChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChunk.Unmarshal(m, b)
+}
+func (m *FileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChunk.Marshal(b, m, deterministic)
+}
+func (m *FileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChunk.Merge(m, src)
+}
+func (m *FileChunk) XXX_Size() int {
+	return xxx_messageInfo_FileChunk.Size(m)
+}
+func (m *FileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChunk proto.InternalMessageInfo
+
+func (m *FileChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *FileChunk) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	// Topics like "photos.*" or "index.*", all supported topics if empty.
+	Topics               []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{7}
+}
+
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+type Event struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// JSON encoded event data.
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95efa174877c4dcc, []int{8}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Event) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SearchRequest)(nil), "photoprism.SearchRequest")
+	proto.RegisterType((*SearchResponse)(nil), "photoprism.SearchResponse")
+	proto.RegisterType((*PhotoRequest)(nil), "photoprism.PhotoRequest")
+	proto.RegisterType((*Photo)(nil), "photoprism.Photo")
+	proto.RegisterType((*File)(nil), "photoprism.File")
+	proto.RegisterType((*FileRequest)(nil), "photoprism.FileRequest")
+	proto.RegisterType((*FileChunk)(nil), "photoprism.FileChunk")
+	proto.RegisterType((*SubscribeRequest)(nil), "photoprism.SubscribeRequest")
+	proto.RegisterType((*Event)(nil), "photoprism.Event")
+}
+
+func init() { proto.RegisterFile("photoprism.proto", fileDescriptor_95efa174877c4dcc) }
+
+var fileDescriptor_95efa174877c4dcc = []byte{
+	// 795 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0x4d, 0x8f, 0xdb, 0x36,
+	0x10, 0x85, 0xbc, 0xb6, 0xd7, 0x1a, 0xef, 0xa6, 0x0e, 0x9b, 0x6c, 0x58, 0x37, 0x40, 0x14, 0x15,
+	0x28, 0xdc, 0x02, 0xb5, 0x83, 0x2d, 0x8a, 0xdc, 0x52, 0xb4, 0x45, 0xdb, 0x53, 0x81, 0x54, 0xee,
+	0x29, 0x97, 0x05, 0x2d, 0x8d, 0x2d, 0xc2, 0x92, 0xa8, 0xa5, 0xa8, 0x2d, 0x9c, 0x1f, 0xd0, 0xdf,
+	0xd8, 0xdf, 0xd2, 0x53, 0xc1, 0x21, 0xe5, 0x8f, 0x78, 0x6f, 0xf3, 0xde, 0x8c, 0x86, 0xc3, 0xc7,
+	0xa7, 0x81, 0x49, 0x9d, 0x2b, 0xa3, 0x6a, 0x2d, 0x9b, 0x72, 0x5e, 0x6b, 0x65, 0x14, 0x83, 0x03,
+	0x33, 0x7d, 0xb5, 0x51, 0x6a, 0x53, 0xe0, 0x82, 0x32, 0xab, 0x76, 0xbd, 0x30, 0xb2, 0xc4, 0xc6,
+	0x88, 0xb2, 0x76, 0xc5, 0xb1, 0x84, 0xeb, 0x25, 0x0a, 0x9d, 0xe6, 0x09, 0xde, 0xb7, 0xd8, 0x18,
+	0xf6, 0x0c, 0x06, 0xf7, 0x2d, 0xea, 0x1d, 0x0f, 0xa2, 0x60, 0x16, 0x26, 0x0e, 0x58, 0x36, 0x55,
+	0x6d, 0x65, 0x78, 0x2f, 0x0a, 0x66, 0x83, 0xc4, 0x01, 0x76, 0x03, 0x43, 0xb5, 0x5e, 0x37, 0x68,
+	0xf8, 0x05, 0xd1, 0x1e, 0xd9, 0x6a, 0xa5, 0x33, 0xd4, 0xbc, 0xef, 0x7a, 0x10, 0x88, 0xff, 0x84,
+	0x27, 0xdd, 0x51, 0x4d, 0xad, 0xaa, 0x06, 0xd9, 0x37, 0x30, 0xa4, 0x59, 0x1b, 0x1e, 0x44, 0x17,
+	0xb3, 0xf1, 0xed, 0xd3, 0xf9, 0xd1, 0x65, 0xde, 0xdb, 0x30, 0xf1, 0x05, 0x8f, 0x0f, 0x10, 0x47,
+	0x70, 0xe5, 0xca, 0xfc, 0xf0, 0x13, 0xb8, 0x68, 0x65, 0xe6, 0x47, 0xb7, 0x61, 0xfc, 0x5f, 0x1f,
+	0x06, 0x54, 0x72, 0x9e, 0x63, 0x0c, 0xfa, 0x66, 0x57, 0x23, 0xb5, 0x0c, 0x13, 0x8a, 0xed, 0x39,
+	0x46, 0x9a, 0x02, 0xe9, 0x46, 0x61, 0xe2, 0x00, 0x8b, 0x60, 0x9c, 0x61, 0x93, 0x6a, 0x59, 0x1b,
+	0xa9, 0x2a, 0x7f, 0xad, 0x63, 0x8a, 0xfd, 0x00, 0x23, 0x23, 0xb6, 0x58, 0xdd, 0x09, 0xc3, 0x07,
+	0x51, 0x30, 0x1b, 0xdf, 0x4e, 0xe7, 0x4e, 0xfb, 0x79, 0xa7, 0xfd, 0xfc, 0xaf, 0x4e, 0xfb, 0xe4,
+	0x92, 0x6a, 0x7f, 0x32, 0xec, 0x4b, 0x08, 0xed, 0x8b, 0xdc, 0x7d, 0x54, 0x15, 0xf2, 0x21, 0xb5,
+	0x1d, 0x59, 0xe2, 0x83, 0xaa, 0xd0, 0xce, 0x57, 0x0b, 0x93, 0xf3, 0x4b, 0x37, 0x9f, 0x8d, 0x2d,
+	0x57, 0x89, 0x12, 0xf9, 0xc8, 0x71, 0x36, 0xb6, 0xdc, 0x0e, 0x85, 0xe6, 0x21, 0x49, 0x43, 0xb1,
+	0xbd, 0x47, 0xa9, 0x2a, 0x93, 0x73, 0x70, 0x7a, 0x11, 0x60, 0x1c, 0x2e, 0x49, 0x38, 0xbd, 0xe3,
+	0x63, 0x6a, 0xd0, 0x41, 0xab, 0x4e, 0x21, 0x0c, 0xbf, 0x8a, 0x82, 0x59, 0x90, 0xd8, 0x90, 0x98,
+	0x6a, 0xc3, 0xaf, 0x3d, 0x53, 0x6d, 0xd8, 0x14, 0x46, 0xa2, 0x30, 0xd2, 0xb4, 0x19, 0xf2, 0x27,
+	0xd4, 0x76, 0x8f, 0x6d, 0x6e, 0x2d, 0x1e, 0x94, 0x96, 0x06, 0xf9, 0x67, 0x51, 0x30, 0x1b, 0x25,
+	0x7b, 0x6c, 0x4f, 0xad, 0xb5, 0x7c, 0x10, 0x06, 0xf9, 0x84, 0x52, 0x1d, 0x64, 0xaf, 0x60, 0x9c,
+	0x8a, 0x12, 0xb5, 0xb8, 0x2b, 0xc5, 0x16, 0xf9, 0x53, 0x9a, 0x09, 0x1c, 0xf5, 0x87, 0xd8, 0x22,
+	0x7b, 0x0d, 0x57, 0x5d, 0x81, 0xca, 0xb0, 0xe0, 0xcc, 0x29, 0xef, 0x2b, 0x2c, 0xc5, 0xbe, 0x82,
+	0x6b, 0x5f, 0xd2, 0xa0, 0x96, 0xa2, 0xe0, 0x9f, 0x53, 0x8d, 0xff, 0x6e, 0x49, 0x9c, 0x1d, 0xe1,
+	0xbe, 0x15, 0x85, 0x34, 0x3b, 0xfe, 0x8c, 0x26, 0xef, 0x20, 0xfb, 0x1a, 0x06, 0x6b, 0x59, 0x60,
+	0xc3, 0x9f, 0x93, 0x05, 0x27, 0xc7, 0x16, 0xfc, 0x4d, 0x16, 0x98, 0xb8, 0x34, 0x7b, 0x09, 0x61,
+	0x26, 0x35, 0xa6, 0x64, 0x80, 0x9b, 0x28, 0x98, 0xf5, 0x92, 0x03, 0x61, 0xe5, 0x6e, 0x6a, 0xc4,
+	0x8c, 0xbf, 0xa0, 0x8c, 0x03, 0xf1, 0xbf, 0x01, 0xf4, 0x6d, 0x8f, 0xc7, 0xbd, 0x47, 0xef, 0xd8,
+	0x3b, 0x7d, 0x47, 0xad, 0x94, 0xf1, 0xd6, 0xa3, 0xd8, 0x72, 0xb9, 0x68, 0x72, 0x6f, 0x39, 0x8a,
+	0x2d, 0xd7, 0xc8, 0x8f, 0x48, 0x3e, 0xbb, 0x48, 0x28, 0xde, 0x7b, 0x79, 0x78, 0xe4, 0x65, 0x06,
+	0xfd, 0x52, 0x96, 0xd8, 0xf9, 0xc7, 0xc6, 0x76, 0xd0, 0xbf, 0x65, 0x66, 0x72, 0x32, 0xd0, 0x20,
+	0x71, 0xc0, 0xfe, 0xc8, 0x39, 0xca, 0x4d, 0x6e, 0xbc, 0x87, 0x3c, 0xf2, 0x2f, 0x57, 0x0a, 0xbd,
+	0xe3, 0xb0, 0x7f, 0x39, 0x0b, 0xe3, 0xd7, 0x30, 0x26, 0x75, 0xfc, 0x8f, 0xd7, 0x8d, 0x19, 0x1c,
+	0xc6, 0x8c, 0xdf, 0x42, 0x68, 0x4b, 0x7e, 0xc9, 0xdb, 0x6a, 0x6b, 0x0b, 0x32, 0x61, 0x04, 0x15,
+	0x5c, 0x25, 0x14, 0x1f, 0xad, 0x8f, 0x1e, 0xdd, 0xc4, 0xa3, 0xf8, 0x5b, 0x98, 0x2c, 0xdb, 0x95,
+	0xfd, 0xb7, 0x56, 0xfb, 0x03, 0x6e, 0x60, 0x68, 0x54, 0x2d, 0x53, 0xb7, 0x2a, 0xc2, 0xc4, 0xa3,
+	0x78, 0x01, 0x83, 0x5f, 0x1f, 0xb0, 0x32, 0x7b, 0x41, 0x83, 0x53, 0x41, 0xe9, 0xd0, 0xde, 0xe1,
+	0xd0, 0xdb, 0x7f, 0x7a, 0x00, 0xb4, 0x10, 0xde, 0xdb, 0x27, 0x66, 0x3f, 0xc2, 0xd0, 0x2d, 0x25,
+	0xf6, 0xc5, 0xf1, 0xcb, 0x9f, 0xec, 0xc4, 0xe9, 0xf4, 0xb1, 0x94, 0xdf, 0x61, 0x6f, 0x61, 0xf4,
+	0x3b, 0x1a, 0xea, 0xc8, 0xf8, 0xf9, 0xfe, 0xf2, 0x1d, 0xce, 0x37, 0x1b, 0x7b, 0x07, 0xb0, 0x34,
+	0x1a, 0x45, 0x49, 0x0e, 0x79, 0x71, 0xe6, 0x3b, 0xff, 0xe5, 0xf3, 0x4f, 0x13, 0xa4, 0xe7, 0x9b,
+	0x80, 0xbd, 0x83, 0x70, 0xaf, 0x12, 0x7b, 0x79, 0x32, 0xe1, 0x27, 0xe2, 0x9d, 0x9e, 0x4e, 0x72,
+	0xbd, 0x09, 0x7e, 0x5e, 0x7c, 0xf8, 0x6e, 0x23, 0x4d, 0xde, 0xae, 0xe6, 0xa9, 0x2a, 0x17, 0x87,
+	0x82, 0xe3, 0x50, 0x56, 0x06, 0x75, 0x25, 0x8a, 0x85, 0xae, 0xd3, 0xd5, 0x90, 0x16, 0xd9, 0xf7,
+	0xff, 0x0f, 0x00, 0x1b, 0x80, 0xfe, 0x25, 0x72, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PhotoPrismClient is the client API for PhotoPrism service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PhotoPrismClient interface {
+	// Search returns photos matching the query, see the search API for supported filters.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// GetPhoto returns the metadata of a photo including all files.
+	GetPhoto(ctx context.Context, in *PhotoRequest, opts ...grpc.CallOption) (*Photo, error)
+	// StreamFile streams the original file with the given hash in chunks.
+	StreamFile(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (PhotoPrism_StreamFileClient, error)
+	// Subscribe streams events like index progress and entity updates until the client disconnects.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (PhotoPrism_SubscribeClient, error)
+}
+
+type photoPrismClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPhotoPrismClient(cc *grpc.ClientConn) PhotoPrismClient {
+	return &photoPrismClient{cc}
+}
+
+func (c *photoPrismClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/photoprism.PhotoPrism/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *photoPrismClient) GetPhoto(ctx context.Context, in *PhotoRequest, opts ...grpc.CallOption) (*Photo, error) {
+	out := new(Photo)
+	err := c.cc.Invoke(ctx, "/photoprism.PhotoPrism/GetPhoto", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *photoPrismClient) StreamFile(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (PhotoPrism_StreamFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PhotoPrism_serviceDesc.Streams[0], "/photoprism.PhotoPrism/StreamFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &photoPrismStreamFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PhotoPrism_StreamFileClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type photoPrismStreamFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *photoPrismStreamFileClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *photoPrismClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (PhotoPrism_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PhotoPrism_serviceDesc.Streams[1], "/photoprism.PhotoPrism/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &photoPrismSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PhotoPrism_SubscribeClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type photoPrismSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *photoPrismSubscribeClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PhotoPrismServer is the server API for PhotoPrism service.
+type PhotoPrismServer interface {
+	// Search returns photos matching the query, see the search API for supported filters.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// GetPhoto returns the metadata of a photo including all files.
+	GetPhoto(context.Context, *PhotoRequest) (*Photo, error)
+	// StreamFile streams the original file with the given hash in chunks.
+	StreamFile(*FileRequest, PhotoPrism_StreamFileServer) error
+	// Subscribe streams events like index progress and entity updates until the client disconnects.
+	Subscribe(*SubscribeRequest, PhotoPrism_SubscribeServer) error
+}
+
+// UnimplementedPhotoPrismServer can be embedded to have forward compatible implementations.
+type UnimplementedPhotoPrismServer struct {
+}
+
+func (*UnimplementedPhotoPrismServer) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedPhotoPrismServer) GetPhoto(ctx context.Context, req *PhotoRequest) (*Photo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPhoto not implemented")
+}
+func (*UnimplementedPhotoPrismServer) StreamFile(req *FileRequest, srv PhotoPrism_StreamFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFile not implemented")
+}
+func (*UnimplementedPhotoPrismServer) Subscribe(req *SubscribeRequest, srv PhotoPrism_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterPhotoPrismServer(s *grpc.Server, srv PhotoPrismServer) {
+	s.RegisterService(&_PhotoPrism_serviceDesc, srv)
+}
+
+func _PhotoPrism_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotoPrismServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/photoprism.PhotoPrism/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotoPrismServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhotoPrism_GetPhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PhotoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotoPrismServer).GetPhoto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/photoprism.PhotoPrism/GetPhoto",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotoPrismServer).GetPhoto(ctx, req.(*PhotoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhotoPrism_StreamFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PhotoPrismServer).StreamFile(m, &photoPrismStreamFileServer{stream})
+}
+
+type PhotoPrism_StreamFileServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type photoPrismStreamFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *photoPrismStreamFileServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PhotoPrism_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PhotoPrismServer).Subscribe(m, &photoPrismSubscribeServer{stream})
+}
+
+type PhotoPrism_SubscribeServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type photoPrismSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *photoPrismSubscribeServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _PhotoPrism_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "photoprism.PhotoPrism",
+	HandlerType: (*PhotoPrismServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _PhotoPrism_Search_Handler,
+		},
+		{
+			MethodName: "GetPhoto",
+			Handler:    _PhotoPrism_GetPhoto_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFile",
+			Handler:       _PhotoPrism_StreamFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _PhotoPrism_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "photoprism.proto",
+}
@@ -0,0 +1,65 @@
+/*
+Package rpc provides a gRPC API for high-throughput integrations like bulk analytics pipelines
+and native mobile sync.
+
+Supported operations are photo search, fetching metadata, streaming original files, and subscribing
+to events. Messages are defined in photoprism.proto, which can be used to generate clients in other
+languages. The Go code in photoprism.pb.go is generated with protoc-gen-go, see "go generate".
+The server is disabled unless a port is configured with --grpc-port.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package rpc
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. photoprism.proto
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Start the gRPC server if a port is configured.
+func Start(ctx context.Context, conf *config.Config) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Error(err)
+		}
+	}()
+
+	if conf.GrpcServerPort() <= 0 {
+		return
+	}
+
+	addr := fmt.Sprintf("%s:%d", conf.HttpServerHost(), conf.GrpcServerPort())
+
+	lis, err := net.Listen("tcp", addr)
+
+	if err != nil {
+		log.Errorf("grpc: %s", err)
+		return
+	}
+
+	server := NewServer(conf)
+
+	go func() {
+		log.Infof("starting grpc server at %s", addr)
+
+		if err := server.Serve(lis); err != nil {
+			log.Errorf("grpc server closed unexpect: %s", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("shutting down grpc server")
+
+	// Event subscriptions don't end by themselves, so connections are closed immediately.
+	server.Stop()
+}
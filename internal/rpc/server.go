@@ -0,0 +1,344 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultCount = 100
+	MaxCount     = 1000
+	ChunkSize    = 256 * 1024
+)
+
+// SessionCheckInterval is how often the session of an event subscription is checked again.
+var SessionCheckInterval = 30 * time.Second
+
+// Topics contains the event topics clients can subscribe to.
+var Topics = []string{"log.*", "notify.*", "index.*", "upload.*", "import.*", "config.*", "count.*", "photos.*", "albums.*", "labels.*", "sync.*"}
+
+// Server implements the PhotoPrism service.
+type Server struct {
+	conf *config.Config
+}
+
+// NewServer returns a gRPC server with the PhotoPrism service registered.
+func NewServer(conf *config.Config) *grpc.Server {
+	s := &Server{conf: conf}
+
+	result := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authorize(ctx); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorize(ss.Context()); err != nil {
+				return err
+			}
+
+			return handler(srv, ss)
+		}),
+	)
+
+	RegisterPhotoPrismServer(result, s)
+
+	return result
+}
+
+// authorize returns an error unless the site is public or the "x-session-token" metadata
+// contains a valid session token. Guests only have access to shared links.
+func (s *Server) authorize(ctx context.Context) error {
+	if s.conf.Public() {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	tokens := md.Get("x-session-token")
+
+	if len(tokens) == 0 {
+		return status.Error(codes.Unauthenticated, config.ErrUnauthorized.Error())
+	}
+
+	data, ok := service.Session().Get(tokens[0])
+
+	if !ok || sessionRole(data) == config.RoleGuest {
+		return status.Error(codes.Unauthenticated, config.ErrUnauthorized.Error())
+	}
+
+	return nil
+}
+
+// sessionRole returns the role of the session user.
+func sessionRole(data interface{}) string {
+	var values map[string]interface{}
+
+	switch d := data.(type) {
+	case gin.H:
+		values = d
+	case map[string]interface{}:
+		values = d
+	default:
+		return ""
+	}
+
+	role, _ := values["Role"].(string)
+
+	return role
+}
+
+// Search returns photos matching the query.
+func (s *Server) Search(ctx context.Context, in *SearchRequest) (*SearchResponse, error) {
+	f := form.PhotoSearch{
+		Query:  in.Query,
+		Count:  int(in.Count),
+		Offset: int(in.Offset),
+		Order:  in.Order,
+	}
+
+	if f.Count <= 0 {
+		f.Count = DefaultCount
+	} else if f.Count > MaxCount {
+		f.Count = MaxCount
+	}
+
+	results, count, err := query.PhotoSearch(f)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &SearchResponse{Photos: make([]*Photo, len(results)), Count: int32(count)}
+
+	for i, r := range results {
+		resp.Photos[i] = photoResult(r)
+	}
+
+	return resp, nil
+}
+
+// GetPhoto returns the metadata of a photo including all files.
+func (s *Server) GetPhoto(ctx context.Context, in *PhotoRequest) (*Photo, error) {
+	p, err := query.PhotoPreloadByUID(in.Uid)
+
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "photo not found")
+	}
+
+	return photoEntity(p), nil
+}
+
+// StreamFile streams the original file with the given hash in chunks.
+func (s *Server) StreamFile(in *FileRequest, stream PhotoPrism_StreamFileServer) error {
+	f, err := query.FileByHash(in.Hash)
+
+	if err != nil {
+		return status.Error(codes.NotFound, "file not found")
+	}
+
+	fileName, err := photoprism.OriginalFileName(s.conf, f)
+
+	if err != nil {
+		log.Errorf("grpc: %s", err)
+		return status.Error(codes.NotFound, "file not found")
+	}
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		log.Errorf("grpc: %s", err)
+		return status.Error(codes.NotFound, "file not found")
+	}
+
+	defer file.Close()
+
+	buf := make([]byte, ChunkSize)
+	var offset int64
+
+	for {
+		n, err := file.Read(buf)
+
+		if n > 0 {
+			if err := stream.Send(&FileChunk{Data: buf[:n], Offset: offset}); err != nil {
+				return err
+			}
+
+			offset += int64(n)
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			log.Errorf("grpc: %s", err)
+			return status.Error(codes.Internal, "failed reading file")
+		}
+	}
+}
+
+// Subscribe streams events until the client disconnects or the session is no longer valid.
+func (s *Server) Subscribe(in *SubscribeRequest, stream PhotoPrism_SubscribeServer) error {
+	topics := Topics
+
+	if len(in.Topics) > 0 {
+		for _, topic := range in.Topics {
+			if !validTopic(topic) {
+				return status.Errorf(codes.InvalidArgument, "unknown topic %s", topic)
+			}
+		}
+
+		topics = in.Topics
+	}
+
+	sub := event.Subscribe(topics...)
+	defer event.Unsubscribe(sub)
+
+	ticker := time.NewTicker(SessionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+			// Sessions may expire or be deleted while the stream is open.
+			if err := s.authorize(stream.Context()); err != nil {
+				return err
+			}
+		case msg := <-sub.Receiver:
+			data, err := json.Marshal(msg.Fields)
+
+			if err != nil {
+				log.Errorf("grpc: %s", err)
+				continue
+			}
+
+			if err := stream.Send(&Event{Name: msg.Name, Data: data}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// validTopic returns true if the topic belongs to a supported event category, e.g. "photos.updated".
+func validTopic(topic string) bool {
+	category := strings.SplitN(topic, ".", 2)[0] + ".*"
+
+	for _, t := range Topics {
+		if t == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// photoResult converts a search result.
+func photoResult(r query.PhotoResult) *Photo {
+	takenAt, _ := ptypes.TimestampProto(r.TakenAt)
+
+	result := &Photo{
+		Uid:         r.PhotoUID,
+		Type:        r.PhotoType,
+		Title:       r.PhotoTitle,
+		Description: r.PhotoDescription,
+		TakenAt:     takenAt,
+		TimeZone:    r.TimeZone,
+		Path:        r.PhotoPath,
+		Name:        r.PhotoName,
+		Year:        int32(r.PhotoYear),
+		Month:       int32(r.PhotoMonth),
+		Country:     r.PhotoCountry,
+		Lat:         float64(r.PhotoLat),
+		Lng:         float64(r.PhotoLng),
+		Altitude:    int32(r.PhotoAltitude),
+		Direction:   r.PhotoDirection,
+		Speed:       r.PhotoSpeed,
+		Favorite:    r.PhotoFavorite,
+		Private:     r.PhotoPrivate,
+		CameraMake:  r.CameraMake,
+		CameraModel: r.CameraModel,
+		Quality:     int32(r.PhotoQuality),
+	}
+
+	for _, f := range r.Files {
+		result.Files = append(result.Files, file(f))
+	}
+
+	return result
+}
+
+// photoEntity converts a photo entity with preloaded files.
+func photoEntity(p entity.Photo) *Photo {
+	takenAt, _ := ptypes.TimestampProto(p.TakenAt)
+
+	result := &Photo{
+		Uid:          p.PhotoUID,
+		Type:         p.PhotoType,
+		Title:        p.PhotoTitle,
+		Description:  p.PhotoDescription,
+		TakenAt:      takenAt,
+		TimeZone:     p.TimeZone,
+		Path:         p.PhotoPath,
+		Name:         p.PhotoName,
+		Year:         int32(p.PhotoYear),
+		Month:        int32(p.PhotoMonth),
+		Country:      p.PhotoCountry,
+		Lat:          float64(p.PhotoLat),
+		Lng:          float64(p.PhotoLng),
+		Altitude:     int32(p.PhotoAltitude),
+		Direction:    p.PhotoDirection,
+		Speed:        p.PhotoSpeed,
+		Favorite:     p.PhotoFavorite,
+		Private:      p.PhotoPrivate,
+		CameraSerial: p.CameraSerial,
+		Quality:      int32(p.PhotoQuality),
+	}
+
+	if p.Camera != nil {
+		result.CameraMake = p.Camera.CameraMake
+		result.CameraModel = p.Camera.CameraModel
+	}
+
+	for _, f := range p.Files {
+		result.Files = append(result.Files, file(f))
+	}
+
+	return result
+}
+
+// file converts a file entity.
+func file(f entity.File) *File {
+	return &File{
+		Uid:     f.FileUID,
+		Name:    f.FileName,
+		Root:    f.FileRoot,
+		Hash:    f.FileHash,
+		Size:    f.FileSize,
+		Type:    f.FileType,
+		Mime:    f.FileMime,
+		Width:   int32(f.FileWidth),
+		Height:  int32(f.FileHeight),
+		Primary: f.FilePrimary,
+	}
+}
@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var client PhotoPrismClient
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	conf := config.TestConfig()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		panic(err)
+	}
+
+	server := NewServer(conf)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+
+	if err != nil {
+		panic(err)
+	}
+
+	client = NewPhotoPrismClient(cc)
+
+	code := m.Run()
+
+	_ = cc.Close()
+	server.Stop()
+	_ = conf.CloseDb()
+
+	os.Exit(code)
+}
+
+func TestServer_Search(t *testing.T) {
+	t.Run("default count", func(t *testing.T) {
+		resp, err := client.Search(context.Background(), &SearchRequest{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(resp.Photos), DefaultCount)
+		assert.Equal(t, int32(len(resp.Photos)), resp.Count)
+	})
+
+	t.Run("count and offset", func(t *testing.T) {
+		resp, err := client.Search(context.Background(), &SearchRequest{Count: 2, Offset: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(resp.Photos), 2)
+	})
+}
+
+func TestServer_GetPhoto(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		photo, err := client.GetPhoto(context.Background(), &PhotoRequest{Uid: "pt9jtdre2lvl0yh7"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photo.Uid)
+		assert.Equal(t, "photo description lake", photo.Description)
+		assert.Equal(t, int64(1199145600), photo.TakenAt.Seconds)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetPhoto(context.Background(), &PhotoRequest{Uid: "xxx"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_StreamFile(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		stream, err := client.StreamFile(context.Background(), &FileRequest{Hash: "xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	t.Run("unknown topic", func(t *testing.T) {
+		stream, err := client.Subscribe(context.Background(), &SubscribeRequest{Topics: []string{"foo.bar"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestValidTopic(t *testing.T) {
+	assert.True(t, validTopic("photos.updated"))
+	assert.True(t, validTopic("index.*"))
+	assert.False(t, validTopic("foo.bar"))
+	assert.False(t, validTopic(""))
+}
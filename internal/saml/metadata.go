@@ -0,0 +1,49 @@
+package saml
+
+import (
+	"encoding/xml"
+)
+
+type entityDescriptor struct {
+	XMLName         xml.Name        `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string          `xml:"entityID,attr"`
+	SPSSODescriptor spSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool              `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool              `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string            `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormat               string            `xml:"NameIDFormat"`
+	AssertionConsumerService   []indexedEndpoint `xml:"AssertionConsumerService"`
+}
+
+type indexedEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+	Index    int    `xml:"index,attr"`
+}
+
+// Metadata returns the XML encoded service provider metadata for registration with the identity provider.
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	m := entityDescriptor{
+		EntityID: sp.EntityID,
+		SPSSODescriptor: spSSODescriptor{
+			AuthnRequestsSigned:        false,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: NamespaceProtocol,
+			NameIDFormat:               NameIDUnspecified,
+			AssertionConsumerService: []indexedEndpoint{
+				{Binding: BindingPost, Location: sp.AcsURL, Index: 1},
+			},
+		},
+	}
+
+	out, err := xml.MarshalIndent(m, "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,21 @@
+package saml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceProvider_Metadata(t *testing.T) {
+	sp, _ := testProvider(t)
+
+	result, err := sp.Metadata()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(result), `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://demo.photoprism.org/api/v1/saml/metadata">`)
+	assert.Contains(t, string(result), `Location="https://demo.photoprism.org/api/v1/saml/acs"`)
+	assert.Contains(t, string(result), `WantAssertionsSigned="true"`)
+}
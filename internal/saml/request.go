@@ -0,0 +1,100 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type authnRequest struct {
+	XMLName                     xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	Destination                 string   `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	Issuer                      issuer   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+}
+
+type issuer struct {
+	Value string `xml:",chardata"`
+}
+
+// NewRequestID returns a random ID for an authentication request.
+func NewRequestID() string {
+	b := make([]byte, 20)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	// IDs must not start with a digit.
+	return "id-" + hex.EncodeToString(b)
+}
+
+// AuthnRequestURL returns the identity provider URL to redirect users to and the ID of the
+// authentication request, which must match the InResponseTo value of the response.
+func (sp *ServiceProvider) AuthnRequestURL(relayState string) (redirect string, id string, err error) {
+	id = NewRequestID()
+
+	req := authnRequest{
+		ID:                          id,
+		Version:                     "2.0",
+		IssueInstant:                sp.now().Format(time.RFC3339),
+		Destination:                 sp.IdpURL,
+		AssertionConsumerServiceURL: sp.AcsURL,
+		ProtocolBinding:             BindingPost,
+		Issuer:                      issuer{Value: sp.EntityID},
+	}
+
+	out, err := xml.Marshal(req)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	var buf bytes.Buffer
+
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if _, err := w.Write(out); err != nil {
+		return "", "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", "", err
+	}
+
+	u, err := url.Parse(sp.IdpURL)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	q := url.Values{}
+	q.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+
+	if relayState != "" {
+		q.Set("RelayState", relayState)
+	}
+
+	// Keep existing query parameters of the identity provider URL.
+	if u.RawQuery != "" && !strings.HasSuffix(u.RawQuery, "&") {
+		u.RawQuery += "&"
+	}
+
+	u.RawQuery += q.Encode()
+
+	return u.String(), id, nil
+}
@@ -0,0 +1,58 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequestID(t *testing.T) {
+	id := NewRequestID()
+
+	assert.True(t, strings.HasPrefix(id, "id-"))
+	assert.Len(t, id, 43)
+	assert.NotEqual(t, id, NewRequestID())
+}
+
+func TestServiceProvider_AuthnRequestURL(t *testing.T) {
+	sp, _ := testProvider(t)
+	sp.IdpURL = "https://idp.example.com/sso?tenant=photos"
+
+	redirect, id, err := sp.AuthnRequestURL("/albums")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(redirect)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "idp.example.com", u.Host)
+	assert.Equal(t, "photos", u.Query().Get("tenant"))
+	assert.Equal(t, "/albums", u.Query().Get("RelayState"))
+
+	data, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xml, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(data)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(xml), `ID="`+id+`"`)
+	assert.Contains(t, string(xml), `AssertionConsumerServiceURL="https://demo.photoprism.org/api/v1/saml/acs"`)
+	assert.Contains(t, string(xml), `>https://demo.photoprism.org/api/v1/saml/metadata</Issuer>`)
+}
@@ -0,0 +1,268 @@
+package saml
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const methodBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+type response struct {
+	XMLName     xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	Destination string   `xml:"Destination,attr"`
+	Status      struct {
+		StatusCode struct {
+			Value string `xml:"Value,attr"`
+		} `xml:"StatusCode"`
+		StatusMessage string `xml:"StatusMessage"`
+	} `xml:"Status"`
+}
+
+type assertion struct {
+	XMLName xml.Name `xml:"Assertion"`
+	ID      string   `xml:"ID,attr"`
+	Subject struct {
+		NameID               string `xml:"NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				InResponseTo string    `xml:"InResponseTo,attr"`
+				Recipient    string    `xml:"Recipient,attr"`
+				NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+			} `xml:"SubjectConfirmationData"`
+		} `xml:"SubjectConfirmation"`
+	} `xml:"Subject"`
+	Conditions struct {
+		NotBefore            time.Time `xml:"NotBefore,attr"`
+		NotOnOrAfter         time.Time `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"Audience"`
+		} `xml:"AudienceRestriction"`
+	} `xml:"Conditions"`
+	Attributes []struct {
+		Name         string   `xml:"Name,attr"`
+		FriendlyName string   `xml:"FriendlyName,attr"`
+		Values       []string `xml:"AttributeValue"`
+	} `xml:"AttributeStatement>Attribute"`
+}
+
+// Assertion contains the verified identity of an authenticated user.
+type Assertion struct {
+	ID           string
+	NameID       string
+	InResponseTo string
+	Expires      time.Time
+	Attributes   map[string][]string
+}
+
+// Attribute returns the first value of an attribute or an empty string if it doesn't exist.
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// HasValue returns true if the attribute contains the value.
+func (a *Assertion) HasValue(name, value string) bool {
+	for _, v := range a.Attributes[name] {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseResponse verifies a base64 encoded response received via HTTP-POST binding and returns the assertion it contains.
+// Callers must check that InResponseTo matches a pending request and that the assertion ID wasn't used before.
+func (sp *ServiceProvider) ParseResponse(samlResponse string) (*Assertion, error) {
+	data, err := base64.StdEncoding.DecodeString(samlResponse)
+
+	if err != nil {
+		return nil, fmt.Errorf("saml: %s", err)
+	}
+
+	doc := etree.NewDocument()
+
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("saml: %s", err)
+	}
+
+	root := doc.Root()
+
+	if root == nil || root.Tag != "Response" {
+		return nil, errors.New("saml: response expected")
+	}
+
+	var resp response
+
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("saml: %s", err)
+	}
+
+	if resp.Status.StatusCode.Value != StatusSuccess {
+		return nil, fmt.Errorf("saml: authentication failed (%s)", resp.Status.StatusCode.Value)
+	}
+
+	if resp.Destination != "" && resp.Destination != sp.AcsURL {
+		return nil, fmt.Errorf("saml: wrong destination %s", resp.Destination)
+	}
+
+	if len(root.SelectElements("EncryptedAssertion")) > 0 {
+		return nil, errors.New("saml: encrypted assertions are not supported")
+	}
+
+	if len(root.SelectElements("Assertion")) != 1 {
+		return nil, errors.New("saml: response must contain exactly one assertion")
+	}
+
+	el, err := sp.verify(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sp.assertion(el)
+}
+
+// verify returns the signed assertion element, either signed directly or as part of a signed response.
+func (sp *ServiceProvider) verify(root *etree.Element) (*etree.Element, error) {
+	ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: sp.IdpCerts})
+	ctx.Clock = dsig.NewFakeClockAt(sp.now())
+
+	signed, err := ctx.Validate(root)
+
+	if err == dsig.ErrMissingSignature {
+		if signed, err = ctx.Validate(withNamespaces(root.SelectElement("Assertion"), root)); err != nil {
+			return nil, fmt.Errorf("saml: %s", err)
+		}
+
+		return signed, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("saml: %s", err)
+	}
+
+	// Only use elements covered by the signature.
+	if el := signed.SelectElement("Assertion"); el != nil {
+		return el, nil
+	}
+
+	return nil, errors.New("saml: assertion not found")
+}
+
+// withNamespaces returns a copy of el including the namespace declarations of its parent.
+func withNamespaces(el, parent *etree.Element) *etree.Element {
+	result := el.Copy()
+
+	for _, attr := range parent.Attr {
+		if attr.Space != "xmlns" && (attr.Space != "" || attr.Key != "xmlns") {
+			continue
+		}
+
+		if result.SelectAttr(attr.FullKey()) == nil {
+			result.CreateAttr(attr.FullKey(), attr.Value)
+		}
+	}
+
+	return result
+}
+
+// assertion decodes and checks the conditions of a verified assertion element.
+func (sp *ServiceProvider) assertion(el *etree.Element) (*Assertion, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(el.Copy())
+
+	data, err := doc.WriteToBytes()
+
+	if err != nil {
+		return nil, fmt.Errorf("saml: %s", err)
+	}
+
+	var a assertion
+
+	if err := xml.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("saml: %s", err)
+	}
+
+	now := sp.now()
+
+	if !a.Conditions.NotBefore.IsZero() && now.Add(MaxClockSkew).Before(a.Conditions.NotBefore) {
+		return nil, errors.New("saml: assertion not yet valid")
+	}
+
+	if !a.Conditions.NotOnOrAfter.IsZero() && !now.Add(-MaxClockSkew).Before(a.Conditions.NotOnOrAfter) {
+		return nil, errors.New("saml: assertion expired")
+	}
+
+	for _, r := range a.Conditions.AudienceRestrictions {
+		found := false
+
+		for _, audience := range r.Audiences {
+			if audience == sp.EntityID {
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, errors.New("saml: audience mismatch")
+		}
+	}
+
+	result := &Assertion{
+		ID:         a.ID,
+		NameID:     a.Subject.NameID,
+		Expires:    a.Conditions.NotOnOrAfter,
+		Attributes: make(map[string][]string),
+	}
+
+	confirmed := false
+
+	for _, c := range a.Subject.SubjectConfirmations {
+		if c.Method != methodBearer {
+			continue
+		}
+
+		if c.Data.Recipient != "" && c.Data.Recipient != sp.AcsURL {
+			continue
+		}
+
+		if c.Data.NotOnOrAfter.IsZero() || !now.Add(-MaxClockSkew).Before(c.Data.NotOnOrAfter) {
+			continue
+		}
+
+		confirmed = true
+		result.InResponseTo = c.Data.InResponseTo
+
+		if result.Expires.IsZero() || c.Data.NotOnOrAfter.Before(result.Expires) {
+			result.Expires = c.Data.NotOnOrAfter
+		}
+
+		break
+	}
+
+	if !confirmed {
+		return nil, errors.New("saml: subject confirmation failed")
+	}
+
+	if result.NameID == "" {
+		return nil, errors.New("saml: name id missing")
+	}
+
+	for _, attr := range a.Attributes {
+		result.Attributes[attr.Name] = append(result.Attributes[attr.Name], attr.Values...)
+
+		if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+			result.Attributes[attr.FriendlyName] = append(result.Attributes[attr.FriendlyName], attr.Values...)
+		}
+	}
+
+	return result, nil
+}
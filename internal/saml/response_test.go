@@ -0,0 +1,162 @@
+package saml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+)
+
+const testResponse = `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_resp1" Version="2.0" IssueInstant="%[1]s" Destination="https://demo.photoprism.org/api/v1/saml/acs" InResponseTo="id-123">
+  <saml:Issuer>https://idp.example.com</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  <saml:Assertion ID="_assert1" Version="2.0" IssueInstant="%[1]s">
+    <saml:Issuer>https://idp.example.com</saml:Issuer>
+    <saml:Subject>
+      <saml:NameID>%[3]s</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData InResponseTo="id-123" Recipient="https://demo.photoprism.org/api/v1/saml/acs" NotOnOrAfter="%[2]s"/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="%[1]s" NotOnOrAfter="%[2]s">
+      <saml:AudienceRestriction><saml:Audience>%[4]s</saml:Audience></saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.3" FriendlyName="mail"><saml:AttributeValue>bender@example.com</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="Role"><saml:AttributeValue>user</saml:AttributeValue><saml:AttributeValue>admin</saml:AttributeValue></saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>
+</samlp:Response>`
+
+type testOptions struct {
+	signResponse  bool
+	signAssertion bool
+	nameID        string
+	audience      string
+	expires       time.Time
+}
+
+// testSAMLResponse returns a base64 encoded response signed with the given key store.
+func testSAMLResponse(t *testing.T, ks dsig.X509KeyStore, opt testOptions) string {
+	now := time.Now().UTC()
+
+	if opt.expires.IsZero() {
+		opt.expires = now.Add(5 * time.Minute)
+	}
+
+	if opt.audience == "" {
+		opt.audience = "https://demo.photoprism.org/api/v1/saml/metadata"
+	}
+
+	doc := etree.NewDocument()
+
+	if err := doc.ReadFromString(fmt.Sprintf(testResponse, now.Format(time.RFC3339), opt.expires.Format(time.RFC3339), opt.nameID, opt.audience)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := dsig.NewDefaultSigningContext(ks)
+
+	if opt.signAssertion {
+		el := doc.Root().SelectElement("Assertion")
+
+		signed, err := ctx.SignEnveloped(withNamespaces(el, doc.Root()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc.Root().RemoveChild(el)
+		doc.Root().AddChild(signed)
+	}
+
+	if opt.signResponse {
+		signed, err := ctx.SignEnveloped(doc.Root())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc.SetRoot(signed)
+	}
+
+	data, err := doc.WriteToBytes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestServiceProvider_ParseResponse(t *testing.T) {
+	sp, ks := testProvider(t)
+
+	t.Run("signed response", func(t *testing.T) {
+		a, err := sp.ParseResponse(testSAMLResponse(t, ks, testOptions{signResponse: true, nameID: "bender"}))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "_assert1", a.ID)
+		assert.Equal(t, "bender", a.NameID)
+		assert.Equal(t, "id-123", a.InResponseTo)
+		assert.Equal(t, "bender@example.com", a.Attribute("mail"))
+		assert.Equal(t, "bender@example.com", a.Attribute("urn:oid:0.9.2342.19200300.100.1.3"))
+		assert.True(t, a.HasValue("Role", "admin"))
+		assert.False(t, a.HasValue("Role", "guest"))
+	})
+	t.Run("signed assertion", func(t *testing.T) {
+		a, err := sp.ParseResponse(testSAMLResponse(t, ks, testOptions{signAssertion: true, nameID: "bender"}))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "bender", a.NameID)
+	})
+	t.Run("not signed", func(t *testing.T) {
+		_, err := sp.ParseResponse(testSAMLResponse(t, ks, testOptions{nameID: "bender"}))
+
+		assert.Error(t, err)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := sp.ParseResponse(testSAMLResponse(t, dsig.RandomKeyStoreForTest(), testOptions{signResponse: true, nameID: "bender"}))
+
+		assert.Error(t, err)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		resp := testSAMLResponse(t, ks, testOptions{signAssertion: true, nameID: "bender"})
+		data, _ := base64.StdEncoding.DecodeString(resp)
+		tampered := strings.Replace(string(data), ">bender<", ">admin<", 1)
+
+		_, err := sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(tampered)))
+
+		assert.Error(t, err)
+	})
+	t.Run("audience mismatch", func(t *testing.T) {
+		_, err := sp.ParseResponse(testSAMLResponse(t, ks, testOptions{signResponse: true, nameID: "bender", audience: "https://other.example.com"}))
+
+		assert.EqualError(t, err, "saml: audience mismatch")
+	})
+	t.Run("expired", func(t *testing.T) {
+		_, err := sp.ParseResponse(testSAMLResponse(t, ks, testOptions{signResponse: true, nameID: "bender", expires: time.Now().Add(-time.Hour)}))
+
+		assert.EqualError(t, err, "saml: assertion expired")
+	})
+	t.Run("name id missing", func(t *testing.T) {
+		_, err := sp.ParseResponse(testSAMLResponse(t, ks, testOptions{signResponse: true}))
+
+		assert.EqualError(t, err, "saml: name id missing")
+	})
+	t.Run("invalid base64", func(t *testing.T) {
+		_, err := sp.ParseResponse("%%%")
+
+		assert.Error(t, err)
+	})
+}
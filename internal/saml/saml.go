@@ -0,0 +1,85 @@
+/*
+This package implements a SAML 2.0 service provider for single sign-on with external identity providers.
+
+Authentication requests are sent using the HTTP-Redirect binding, responses are expected via HTTP-POST
+and must be signed by the identity provider, either the response itself or the assertion it contains.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+)
+
+const (
+	NamespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	NamespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	NamespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	BindingRedirect    = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingPost        = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	NameIDUnspecified  = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	StatusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+)
+
+// MaxClockSkew is the tolerated time difference between service and identity provider.
+var MaxClockSkew = 3 * time.Minute
+
+// ServiceProvider represents a SAML service provider trusting a single identity provider.
+type ServiceProvider struct {
+	EntityID string
+	AcsURL   string
+	IdpURL   string
+	IdpCerts []*x509.Certificate
+	Now      func() time.Time
+}
+
+// NewServiceProvider returns a new service provider, idpCert must contain the PEM encoded
+// signing certificate(s) of the identity provider.
+func NewServiceProvider(entityID, acsURL, idpURL string, idpCert []byte) (*ServiceProvider, error) {
+	if entityID == "" || acsURL == "" || idpURL == "" {
+		return nil, errors.New("saml: entity id, acs and idp url required")
+	}
+
+	var certs []*x509.Certificate
+
+	for block, rest := pem.Decode(idpCert); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("saml: no idp certificate found")
+	}
+
+	return &ServiceProvider{
+		EntityID: entityID,
+		AcsURL:   acsURL,
+		IdpURL:   idpURL,
+		IdpCerts: certs,
+		Now:      time.Now,
+	}, nil
+}
+
+// now returns the current time in UTC.
+func (sp *ServiceProvider) now() time.Time {
+	if sp.Now == nil {
+		return time.Now().UTC()
+	}
+
+	return sp.Now().UTC()
+}
@@ -0,0 +1,47 @@
+package saml
+
+import (
+	"encoding/pem"
+	"testing"
+
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProvider returns a service provider and the key store of the identity provider for testing.
+func testProvider(t *testing.T) (*ServiceProvider, dsig.X509KeyStore) {
+	ks := dsig.RandomKeyStoreForTest()
+
+	_, cert, err := ks.GetKeyPair()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sp, err := NewServiceProvider("https://demo.photoprism.org/api/v1/saml/metadata", "https://demo.photoprism.org/api/v1/saml/acs", "https://idp.example.com/sso", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sp, ks
+}
+
+func TestNewServiceProvider(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sp, _ := testProvider(t)
+
+		assert.Len(t, sp.IdpCerts, 1)
+		assert.Equal(t, "https://idp.example.com/sso", sp.IdpURL)
+	})
+	t.Run("no certificate", func(t *testing.T) {
+		_, err := NewServiceProvider("https://demo.photoprism.org/api/v1/saml/metadata", "https://demo.photoprism.org/api/v1/saml/acs", "https://idp.example.com/sso", []byte("foo"))
+
+		assert.EqualError(t, err, "saml: no idp certificate found")
+	})
+	t.Run("missing url", func(t *testing.T) {
+		_, err := NewServiceProvider("https://demo.photoprism.org/api/v1/saml/metadata", "", "https://idp.example.com/sso", nil)
+
+		assert.EqualError(t, err, "saml: entity id, acs and idp url required")
+	})
+}
@@ -0,0 +1,74 @@
+package secret
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeySize is the size of the master key in bytes.
+const KeySize = 32
+
+// NewKey returns a new random master key.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// ParseKey returns the master key for a base64 encoded 32 byte key, e.g. generated with "openssl rand -base64 32".
+// Passphrases are rejected, as they would result in keys that are easy to guess.
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+
+	if err != nil || len(key) != KeySize {
+		return nil, ErrKeyFormat
+	}
+
+	return key, nil
+}
+
+// ReadKeyFile reads the master key from a file.
+func ReadKeyFile(fileName string) ([]byte, error) {
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseKey(string(data))
+}
+
+// WriteKeyFile creates a key file with a new random master key that only the owner can read.
+func WriteKeyFile(fileName string) ([]byte, error) {
+	key, err := NewKey()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.WriteString(base64.StdEncoding.EncodeToString(key) + "\n"); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return key, f.Close()
+}
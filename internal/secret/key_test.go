@@ -0,0 +1,67 @@
+package secret
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKey(t *testing.T) {
+	key, err := NewKey()
+
+	assert.Nil(t, err)
+	assert.Len(t, key, KeySize)
+}
+
+func TestParseKey(t *testing.T) {
+	t.Run("base64", func(t *testing.T) {
+		key, _ := NewKey()
+		result, err := ParseKey(base64.StdEncoding.EncodeToString(key) + "\n")
+
+		assert.Nil(t, err)
+		assert.Equal(t, key, result)
+	})
+	t.Run("passphrase", func(t *testing.T) {
+		_, err := ParseKey("photoprism")
+		assert.Equal(t, ErrKeyFormat, err)
+	})
+	t.Run("too short", func(t *testing.T) {
+		_, err := ParseKey(base64.StdEncoding.EncodeToString([]byte("short")))
+		assert.Equal(t, ErrKeyFormat, err)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := ParseKey("")
+		assert.Equal(t, ErrKeyFormat, err)
+	})
+}
+
+func TestWriteKeyFile(t *testing.T) {
+	fileName := filepath.Join(os.TempDir(), "photoprism-secret-test", "secret.key")
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	key, err := WriteKeyFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	found, err := ReadKeyFile(fileName)
+
+	assert.Nil(t, err)
+	assert.Equal(t, key, found)
+
+	_, err = WriteKeyFile(fileName)
+
+	assert.True(t, os.IsExist(err))
+}
@@ -0,0 +1,125 @@
+/*
+Package secret encrypts credentials like remote account passwords before they are stored.
+Share link passwords are only compared and therefore stored as bcrypt hash instead, see entity.Link.
+
+Values are encrypted with AES-256-GCM using a master key that is configured via environment or key file.
+Encrypted values start with Prefix, so that existing plaintext values can still be read and migrated later.
+*/
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Prefix marks encrypted values, the version allows to change the algorithm later.
+const Prefix = "enc:v1:"
+
+var (
+	ErrNoKey      = errors.New("secret: master key missing")
+	ErrInvalidKey = errors.New("secret: invalid master key")
+	ErrKeyFormat  = errors.New("secret: master key must be 32 bytes, base64 encoded")
+	ErrDecrypt    = errors.New("secret: can't decrypt value")
+)
+
+var (
+	aead  cipher.AEAD
+	mutex sync.RWMutex
+)
+
+// SetKey sets the 32 byte master key used to encrypt and decrypt values.
+func SetKey(key []byte) error {
+	if len(key) != KeySize {
+		return ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	aead = gcm
+
+	return nil
+}
+
+// HasKey returns true if a master key has been set.
+func HasKey() bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return aead != nil
+}
+
+// Encrypted returns true if the value is encrypted.
+func Encrypted(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
+
+// Encrypt encrypts a value. Empty and already encrypted values are returned unchanged.
+func Encrypt(s string) (string, error) {
+	if s == "" || Encrypted(s) {
+		return s, nil
+	}
+
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if aead == nil {
+		return "", ErrNoKey
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	data := aead.Seal(nonce, nonce, []byte(s), nil)
+
+	return Prefix + base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt decrypts a value. Plaintext values without Prefix are returned unchanged.
+func Decrypt(s string) (string, error) {
+	if !Encrypted(s) {
+		return s, nil
+	}
+
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if aead == nil {
+		return "", ErrNoKey
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(s, Prefix))
+
+	if err != nil || len(data) < aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+
+	if err != nil {
+		return "", ErrDecrypt
+	}
+
+	return string(plain), nil
+}
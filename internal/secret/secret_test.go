@@ -0,0 +1,98 @@
+package secret
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testKey is the 32 byte master key used in tests.
+var testKey = []byte("photoprism-test-master-key-32byt")
+
+func TestMain(m *testing.M) {
+	if err := SetKey(testKey); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestSetKey(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, ErrInvalidKey, SetKey([]byte("short")))
+		assert.True(t, HasKey())
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		enc, err := Encrypt("foobar")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, Encrypted(enc))
+		assert.NotContains(t, enc, "foobar")
+
+		other, err := Encrypt("foobar")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, enc, other)
+	})
+	t.Run("empty", func(t *testing.T) {
+		enc, err := Encrypt("")
+		assert.Nil(t, err)
+		assert.Equal(t, "", enc)
+	})
+	t.Run("already encrypted", func(t *testing.T) {
+		enc, err := Encrypt(Prefix + "abc")
+		assert.Nil(t, err)
+		assert.Equal(t, Prefix+"abc", enc)
+	})
+}
+
+func TestDecrypt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		enc, err := Encrypt("foobar")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		plain, err := Decrypt(enc)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "foobar", plain)
+	})
+	t.Run("plaintext", func(t *testing.T) {
+		plain, err := Decrypt("foobar")
+		assert.Nil(t, err)
+		assert.Equal(t, "foobar", plain)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Decrypt(Prefix + "abc")
+		assert.Equal(t, ErrDecrypt, err)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		enc, err := Encrypt("foobar")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := SetKey([]byte("other-test-master-key-32-bytes!!")); err != nil {
+			t.Fatal(err)
+		}
+
+		defer SetKey(testKey)
+
+		_, err = Decrypt(enc)
+
+		assert.Equal(t, ErrDecrypt, err)
+	})
+}
@@ -0,0 +1,97 @@
+package semantic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Encoder maps images and text to embeddings in the same vector space.
+type Encoder interface {
+	Image(jpeg []byte) (Embedding, error)
+	Text(text string) (Embedding, error)
+}
+
+// Embedding represents an embedding returned by an encoder and the name of the model used.
+type Embedding struct {
+	Vector Vector `json:"embedding"`
+	Model  string `json:"model"`
+}
+
+// Client requests embeddings from a CLIP encoder service with a simple web API:
+//
+//   POST {url}/image with a JPEG image as request body
+//   POST {url}/text with {"text": "..."} as request body
+//
+// Both return {"embedding": [0.1, ...], "model": "..."}.
+type Client struct {
+	Url    string
+	Key    string
+	Client *http.Client
+}
+
+// NewClient returns a new encoder service client.
+func NewClient(url, key string) *Client {
+	return &Client{Url: strings.TrimRight(url, "/"), Key: key, Client: &http.Client{Timeout: time.Minute}}
+}
+
+// Image returns the embedding of a JPEG image.
+func (c *Client) Image(jpeg []byte) (Embedding, error) {
+	return c.post("image", "image/jpeg", jpeg)
+}
+
+// Text returns the embedding of a text, e.g. a search query.
+func (c *Client) Text(text string) (Embedding, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+
+	if err != nil {
+		return Embedding{}, err
+	}
+
+	return c.post("text", "application/json", body)
+}
+
+// post sends a request to the encoder service and decodes the embedding in the response.
+func (c *Client) post(endpoint, contentType string, body []byte) (result Embedding, err error) {
+	req, err := http.NewRequest(http.MethodPost, c.Url+"/"+endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return result, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if c.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Key)
+	}
+
+	resp, err := c.Client.Do(req)
+
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+
+		return result, fmt.Errorf("semantic: can't connect to encoder (%s)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("semantic: encoder returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, fmt.Errorf("semantic: invalid encoder response (%s)", err)
+	}
+
+	if len(result.Vector) == 0 {
+		return result, ErrEmpty
+	}
+
+	return result, nil
+}
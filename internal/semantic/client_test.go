@@ -0,0 +1,61 @@
+package semantic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/image":
+			assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+			assert.Equal(t, []byte("jpeg"), body)
+			_, _ = w.Write([]byte(`{"embedding": [1, 0], "model": "ViT-B-32"}`))
+		case "/text":
+			var req map[string]string
+			assert.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "red car in the snow", req["text"])
+			_, _ = w.Write([]byte(`{"embedding": [0, 1], "model": "ViT-B-32"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	c := NewClient(server.URL+"/", "secret")
+
+	t.Run("Image", func(t *testing.T) {
+		result, err := c.Image([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Vector{1, 0}, result.Vector)
+		assert.Equal(t, "ViT-B-32", result.Model)
+	})
+	t.Run("Text", func(t *testing.T) {
+		result, err := c.Text("red car in the snow")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Vector{0, 1}, result.Vector)
+	})
+	t.Run("Error", func(t *testing.T) {
+		_, err := NewClient(server.URL+"/invalid", "secret").Text("car")
+		assert.EqualError(t, err, "semantic: encoder returned status 404")
+	})
+}
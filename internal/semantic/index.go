@@ -0,0 +1,400 @@
+package semantic
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// TrainMin is the default number of vectors required to train the index, searches are exact below.
+	TrainMin = 10000
+
+	trainIterations = 10
+	trainSample     = 64 // Max training samples per list.
+	minLists        = 16
+	maxLists        = 1024
+	minProbes       = 8
+)
+
+// Match represents a search result.
+type Match struct {
+	UID   string  `json:"UID"`
+	Score float32 `json:"Score"`
+}
+
+// Index is an approximate nearest neighbor index of normalized vectors keyed by photo UID.
+//
+// Vectors are partitioned into lists by their nearest centroid (inverted file index), and searches only
+// compare the query with vectors in the lists of the centroids nearest to it. Centroids are trained with
+// k-means once the index contains TrainMin vectors and retrained in the background whenever its size has
+// doubled since, new vectors are assigned to the nearest existing centroid in the meantime.
+type Index struct {
+	TrainMin int
+
+	mu        sync.RWMutex
+	dim       int
+	vectors   map[string]Vector
+	assigned  map[string]int
+	centroids []Vector
+	lists     []map[string]bool
+	trainedAt int
+	training  bool
+}
+
+// NewIndex returns a new, empty index.
+func NewIndex() *Index {
+	return &Index{
+		TrainMin: TrainMin,
+		vectors:  make(map[string]Vector),
+		assigned: make(map[string]int),
+	}
+}
+
+// Len returns the number of vectors in the index.
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.vectors)
+}
+
+// Dim returns the number of vector dimensions, or 0 if the index is empty.
+func (idx *Index) Dim() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.dim
+}
+
+// Contains returns true if the index contains a vector for the UID.
+func (idx *Index) Contains(uid string) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	_, ok := idx.vectors[uid]
+
+	return ok
+}
+
+// Add adds or replaces the vector of a UID, all vectors must have the same number of dimensions.
+func (idx *Index) Add(uid string, v Vector) error {
+	if len(v) == 0 {
+		return ErrEmpty
+	}
+
+	v = v.Normalize()
+
+	idx.mu.Lock()
+
+	if len(idx.vectors) > 0 && len(v) != idx.dim {
+		idx.mu.Unlock()
+		return ErrDimension
+	}
+
+	idx.remove(uid)
+	idx.dim = len(v)
+	idx.vectors[uid] = v
+
+	if len(idx.centroids) > 0 {
+		idx.assign(uid, v)
+	}
+
+	train := !idx.training && len(idx.vectors) >= idx.TrainMin && len(idx.vectors) >= 2*idx.trainedAt
+
+	if train {
+		idx.training = true
+	}
+
+	idx.mu.Unlock()
+
+	if train {
+		go idx.train()
+	}
+
+	return nil
+}
+
+// Remove removes the vector of a UID if it exists.
+func (idx *Index) Remove(uid string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(uid)
+}
+
+// Clear removes all vectors and centroids.
+func (idx *Index) Clear() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.dim = 0
+	idx.vectors = make(map[string]Vector)
+	idx.assigned = make(map[string]int)
+	idx.centroids = nil
+	idx.lists = nil
+	idx.trainedAt = 0
+}
+
+// Search returns up to limit UIDs with vectors most similar to the query, ordered by descending score.
+func (idx *Index) Search(q Vector, limit int, minScore float32) []Match {
+	q = q.Normalize()
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var results []Match
+
+	if len(q) != idx.dim || limit <= 0 {
+		return results
+	}
+
+	score := func(uid string) {
+		if s := q.Dot(idx.vectors[uid]); s >= minScore {
+			results = append(results, Match{UID: uid, Score: s})
+		}
+	}
+
+	if len(idx.centroids) == 0 {
+		for uid := range idx.vectors {
+			score(uid)
+		}
+	} else {
+		for _, c := range idx.probe(q) {
+			for uid := range idx.lists[c] {
+				score(uid)
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].UID < results[j].UID
+		}
+
+		return results[i].Score > results[j].Score
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// Train trains the index centroids, searches are exact until the index has been trained.
+func (idx *Index) Train() {
+	idx.mu.Lock()
+
+	if idx.training {
+		idx.mu.Unlock()
+		return
+	}
+
+	idx.training = true
+	idx.mu.Unlock()
+
+	idx.train()
+}
+
+// train computes new centroids on a snapshot of the index vectors without blocking searches and updates,
+// and swaps them in once complete. Vectors added in the meantime are assigned before swapping.
+func (idx *Index) train() {
+	start := time.Now()
+
+	idx.mu.RLock()
+	snapshot := make(map[string]Vector, len(idx.vectors))
+
+	for uid, v := range idx.vectors {
+		snapshot[uid] = v
+	}
+
+	idx.mu.RUnlock()
+
+	centroids := kmeans(snapshot, numLists(len(snapshot)))
+
+	if len(centroids) == 0 {
+		idx.mu.Lock()
+		idx.training = false
+		idx.mu.Unlock()
+		return
+	}
+
+	assigned := make(map[string]int, len(snapshot))
+
+	for uid, v := range snapshot {
+		assigned[uid] = nearest(centroids, v)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.training = false
+
+	if len(idx.vectors) == 0 || len(centroids[0]) != idx.dim {
+		return
+	}
+
+	idx.centroids = centroids
+	idx.lists = make([]map[string]bool, len(centroids))
+	idx.assigned = make(map[string]int, len(idx.vectors))
+	idx.trainedAt = len(snapshot)
+
+	for i := range idx.lists {
+		idx.lists[i] = make(map[string]bool)
+	}
+
+	for uid, v := range idx.vectors {
+		if c, ok := assigned[uid]; ok && &snapshot[uid][0] == &v[0] {
+			idx.assigned[uid] = c
+			idx.lists[c][uid] = true
+		} else {
+			idx.assign(uid, v)
+		}
+	}
+
+	log.Debugf("semantic: trained index with %d lists for %d vectors [%s]", len(centroids), len(snapshot), time.Since(start))
+}
+
+// remove removes a vector, the caller must hold the write lock.
+func (idx *Index) remove(uid string) {
+	if c, ok := idx.assigned[uid]; ok {
+		delete(idx.lists[c], uid)
+		delete(idx.assigned, uid)
+	}
+
+	delete(idx.vectors, uid)
+
+	// Start over if empty, so that vectors with different dimensions can be added.
+	if len(idx.vectors) == 0 {
+		idx.dim = 0
+		idx.centroids = nil
+		idx.lists = nil
+		idx.trainedAt = 0
+	}
+}
+
+// assign adds a vector to the list of its nearest centroid, the caller must hold the write lock.
+func (idx *Index) assign(uid string, v Vector) {
+	c := nearest(idx.centroids, v)
+	idx.assigned[uid] = c
+	idx.lists[c][uid] = true
+}
+
+// probe returns the lists to search for a query vector.
+func (idx *Index) probe(q Vector) []int {
+	n := len(idx.centroids) / 8
+
+	if n < minProbes {
+		n = minProbes
+	}
+
+	lists := make([]int, len(idx.centroids))
+	scores := make([]float32, len(idx.centroids))
+
+	for i, c := range idx.centroids {
+		lists[i] = i
+		scores[i] = q.Dot(c)
+	}
+
+	sort.Slice(lists, func(i, j int) bool {
+		return scores[lists[i]] > scores[lists[j]]
+	})
+
+	if n > len(lists) {
+		n = len(lists)
+	}
+
+	return lists[:n]
+}
+
+// numLists returns the number of lists for an index of size n.
+func numLists(n int) int {
+	result := int(math.Sqrt(float64(n)))
+
+	if result < minLists {
+		return minLists
+	} else if result > maxLists {
+		return maxLists
+	}
+
+	return result
+}
+
+// nearest returns the index of the centroid most similar to a vector.
+func nearest(centroids []Vector, v Vector) (result int) {
+	var best float32 = -2
+
+	for i, c := range centroids {
+		if s := v.Dot(c); s > best {
+			best = s
+			result = i
+		}
+	}
+
+	return result
+}
+
+// kmeans returns k normalized centroids computed with spherical k-means on a sample of the vectors.
+func kmeans(vectors map[string]Vector, k int) []Vector {
+	uids := make([]string, 0, len(vectors))
+
+	for uid := range vectors {
+		uids = append(uids, uid)
+	}
+
+	if len(uids) < k {
+		return nil
+	}
+
+	// Sort and shuffle with a fixed seed, so that results are reproducible.
+	sort.Strings(uids)
+	r := rand.New(rand.NewSource(1))
+	r.Shuffle(len(uids), func(i, j int) { uids[i], uids[j] = uids[j], uids[i] })
+
+	if len(uids) > k*trainSample {
+		uids = uids[:k*trainSample]
+	}
+
+	sample := make([]Vector, len(uids))
+
+	for i, uid := range uids {
+		sample[i] = vectors[uid]
+	}
+
+	dim := len(sample[0])
+	centroids := make([]Vector, k)
+
+	for i := range centroids {
+		centroids[i] = sample[i]
+	}
+
+	for iter := 0; iter < trainIterations; iter++ {
+		sums := make([]Vector, k)
+		counts := make([]int, k)
+
+		for i := range sums {
+			sums[i] = make(Vector, dim)
+		}
+
+		for _, v := range sample {
+			c := nearest(centroids, v)
+			counts[c]++
+
+			for j, x := range v {
+				sums[c][j] += x
+			}
+		}
+
+		for i := range centroids {
+			// Keep the previous centroid of empty lists.
+			if counts[i] > 0 {
+				centroids[i] = sums[i].Normalize()
+			}
+		}
+	}
+
+	return centroids
+}
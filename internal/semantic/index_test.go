@@ -0,0 +1,118 @@
+package semantic
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testVectors returns n random vectors in clusters around k random centers.
+func testVectors(n, k, dim int) map[string]Vector {
+	r := rand.New(rand.NewSource(42))
+	centers := make([]Vector, k)
+
+	for i := range centers {
+		centers[i] = make(Vector, dim)
+
+		for j := range centers[i] {
+			centers[i][j] = float32(r.NormFloat64())
+		}
+	}
+
+	result := make(map[string]Vector, n)
+
+	for i := 0; i < n; i++ {
+		v := make(Vector, dim)
+
+		for j, x := range centers[i%k] {
+			v[j] = x + 0.3*float32(r.NormFloat64())
+		}
+
+		result[fmt.Sprintf("pt%06d", i)] = v
+	}
+
+	return result
+}
+
+func TestIndex_Add(t *testing.T) {
+	idx := NewIndex()
+
+	assert.NoError(t, idx.Add("pt1", Vector{1, 0}))
+	assert.NoError(t, idx.Add("pt2", Vector{0, 2}))
+	assert.Equal(t, ErrEmpty, idx.Add("pt3", Vector{}))
+	assert.Equal(t, ErrDimension, idx.Add("pt3", Vector{1, 0, 0}))
+	assert.Equal(t, 2, idx.Len())
+	assert.Equal(t, 2, idx.Dim())
+	assert.True(t, idx.Contains("pt2"))
+
+	idx.Remove("pt1")
+	idx.Remove("pt2")
+
+	assert.Equal(t, 0, idx.Len())
+	assert.NoError(t, idx.Add("pt3", Vector{1, 0, 0}))
+	assert.Equal(t, 3, idx.Dim())
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Run("exact", func(t *testing.T) {
+		idx := NewIndex()
+
+		assert.NoError(t, idx.Add("pt1", Vector{1, 0}))
+		assert.NoError(t, idx.Add("pt2", Vector{1, 1}))
+		assert.NoError(t, idx.Add("pt3", Vector{-1, 0}))
+
+		results := idx.Search(Vector{2, 0}, 10, 0.5)
+
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "pt1", results[0].UID)
+			assert.InDelta(t, 1, results[0].Score, 0.0001)
+			assert.Equal(t, "pt2", results[1].UID)
+		}
+
+		assert.Len(t, idx.Search(Vector{2, 0}, 1, 0), 1)
+		assert.Empty(t, idx.Search(Vector{2, 0, 0}, 10, 0))
+	})
+	t.Run("trained", func(t *testing.T) {
+		vectors := testVectors(4000, 50, 32)
+		idx := NewIndex()
+		idx.TrainMin = 100000
+
+		for uid, v := range vectors {
+			assert.NoError(t, idx.Add(uid, v))
+		}
+
+		idx.Train()
+
+		assert.Len(t, idx.centroids, numLists(4000))
+
+		// Vectors added after training are assigned to existing lists.
+		assert.NoError(t, idx.Add("pt999999", vectors["pt000007"]))
+
+		found := 0
+
+		for i := 0; i < 100; i++ {
+			uid := fmt.Sprintf("pt%06d", i*37)
+			results := idx.Search(vectors[uid], 10, 0)
+
+			if len(results) > 0 && results[0].UID == uid || len(results) > 1 && results[1].UID == uid {
+				found++
+			}
+		}
+
+		assert.GreaterOrEqual(t, found, 95)
+
+		results := idx.Search(vectors["pt000007"], 2, 0)
+
+		if assert.Len(t, results, 2) {
+			assert.ElementsMatch(t, []string{"pt000007", "pt999999"}, []string{results[0].UID, results[1].UID})
+		}
+	})
+}
+
+func TestNumLists(t *testing.T) {
+	assert.Equal(t, 16, numLists(10))
+	assert.Equal(t, 100, numLists(10000))
+	assert.Equal(t, 1024, numLists(10000000))
+}
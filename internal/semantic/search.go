@@ -0,0 +1,77 @@
+package semantic
+
+import (
+	"strings"
+	"sync"
+)
+
+// Default is the index of photo embeddings used for search.
+var Default = NewIndex()
+
+var encoder struct {
+	sync.RWMutex
+	Encoder
+}
+
+// SetEncoder sets the encoder used to compute embeddings, semantic search is disabled if nil.
+func SetEncoder(e Encoder) {
+	encoder.Lock()
+	defer encoder.Unlock()
+
+	encoder.Encoder = e
+}
+
+// Enabled returns true if an encoder is set.
+func Enabled() bool {
+	encoder.RLock()
+	defer encoder.RUnlock()
+
+	return encoder.Encoder != nil
+}
+
+// EncodeImage returns the embedding of a JPEG image.
+func EncodeImage(jpeg []byte) (Embedding, error) {
+	encoder.RLock()
+	e := encoder.Encoder
+	encoder.RUnlock()
+
+	if e == nil {
+		return Embedding{}, ErrDisabled
+	}
+
+	return e.Image(jpeg)
+}
+
+// Search returns up to limit photo UIDs matching a natural language description, e.g. "red car in the snow",
+// ordered by descending score.
+func Search(text string, limit int) ([]Match, error) {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return nil, nil
+	}
+
+	encoder.RLock()
+	e := encoder.Encoder
+	encoder.RUnlock()
+
+	if e == nil {
+		return nil, ErrDisabled
+	}
+
+	if limit <= 0 || limit > MaxResults {
+		limit = MaxResults
+	}
+
+	q, err := e.Text(text)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dim := Default.Dim(); dim > 0 && dim != len(q.Vector) {
+		return nil, ErrDimension
+	}
+
+	return Default.Search(q.Vector, limit, MinScore), nil
+}
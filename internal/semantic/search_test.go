@@ -0,0 +1,67 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEncoder struct{}
+
+func (testEncoder) Image(jpeg []byte) (Embedding, error) {
+	return Embedding{Vector: Vector{1, 0}, Model: "test"}, nil
+}
+
+func (testEncoder) Text(text string) (Embedding, error) {
+	if text == "car" {
+		return Embedding{Vector: Vector{1, 0.1}, Model: "test"}, nil
+	}
+
+	return Embedding{Vector: Vector{0, 1}, Model: "test"}, nil
+}
+
+func TestSearch(t *testing.T) {
+	SetEncoder(nil)
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.False(t, Enabled())
+
+		_, err := Search("car", 10)
+		assert.Equal(t, ErrDisabled, err)
+	})
+
+	SetEncoder(testEncoder{})
+	defer SetEncoder(nil)
+
+	Default.Clear()
+	defer Default.Clear()
+
+	e, err := EncodeImage([]byte("jpeg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, Default.Add("pt1", e.Vector))
+
+	t.Run("car", func(t *testing.T) {
+		results, err := Search("car", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "pt1", results[0].UID)
+		}
+	})
+	t.Run("snow", func(t *testing.T) {
+		results, err := Search("snow", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
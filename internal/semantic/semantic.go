@@ -0,0 +1,105 @@
+/*
+This package provides natural language search for photos, e.g. "red car in the snow", using CLIP-style embeddings.
+
+Images and search text are mapped to vectors in the same embedding space by an external encoder service, see Client,
+so that the cosine similarity of a text and an image vector indicates how well the text describes the image.
+Image embeddings are kept in an approximate nearest neighbor index that is updated incrementally as photos
+are indexed, see Index.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package semantic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+const (
+	// MinScore is the minimum cosine similarity of search results, text and image embeddings of matching
+	// pairs are typically much less similar than two images.
+	MinScore = 0.2
+
+	// MaxResults is the maximum number of photos returned by a search.
+	MaxResults = 1000
+)
+
+var (
+	ErrDisabled  = errors.New("semantic: search not enabled")
+	ErrEmpty     = errors.New("semantic: empty embedding")
+	ErrDimension = errors.New("semantic: embedding dimensions don't match, photos must be indexed again")
+)
+
+// Vector represents an embedding.
+type Vector []float32
+
+// Normalize returns a copy of the vector scaled to unit length, so that the dot product of two
+// normalized vectors is their cosine similarity.
+func (v Vector) Normalize() Vector {
+	var sum float64
+
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+
+	result := make(Vector, len(v))
+
+	if sum == 0 {
+		return result
+	}
+
+	norm := float32(math.Sqrt(sum))
+
+	for i, x := range v {
+		result[i] = x / norm
+	}
+
+	return result
+}
+
+// Dot returns the dot product of two vectors with the same length.
+func (v Vector) Dot(o Vector) (result float32) {
+	for i := range v {
+		result += v[i] * o[i]
+	}
+
+	return result
+}
+
+// Bytes returns the vector encoded as little-endian float32 values for storage.
+func (v Vector) Bytes() []byte {
+	result := make([]byte, 4*len(v))
+
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(result[4*i:], math.Float32bits(x))
+	}
+
+	return result
+}
+
+// NewVector decodes a vector from little-endian float32 values, see Vector.Bytes().
+func NewVector(b []byte) (Vector, error) {
+	if len(b) == 0 {
+		return nil, ErrEmpty
+	}
+
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("semantic: invalid embedding size %d", len(b))
+	}
+
+	result := make(Vector, len(b)/4)
+
+	for i := range result {
+		result[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVector_Normalize(t *testing.T) {
+	t.Run("3-4", func(t *testing.T) {
+		v := Vector{3, 4}.Normalize()
+		assert.InDelta(t, 0.6, v[0], 0.0001)
+		assert.InDelta(t, 0.8, v[1], 0.0001)
+		assert.InDelta(t, 1, v.Dot(v), 0.0001)
+	})
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, Vector{0, 0}, Vector{0, 0}.Normalize())
+	})
+}
+
+func TestVector_Dot(t *testing.T) {
+	assert.Equal(t, float32(11), Vector{1, 2}.Dot(Vector{3, 4}))
+}
+
+func TestNewVector(t *testing.T) {
+	t.Run("bytes", func(t *testing.T) {
+		v := Vector{0.5, -1.25, 3}
+
+		result, err := NewVector(v.Bytes())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, v, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := NewVector(nil)
+		assert.Equal(t, ErrEmpty, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewVector([]byte{1, 2, 3})
+		assert.EqualError(t, err, "semantic: invalid embedding size 3")
+	})
+}
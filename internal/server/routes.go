@@ -16,12 +16,15 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	router.Static("/static", conf.HttpStaticPath())
 
 	// JSON-REST API Version 1
-	v1 := router.Group("/api/v1")
+	v1 := router.Group("/api/v1", api.Policy(conf))
 	{
 		api.GetStatus(v1, conf)
 
 		api.CreateSession(v1, conf)
 		api.DeleteSession(v1, conf)
+		api.SamlMetadata(v1, conf)
+		api.SamlLogin(v1, conf)
+		api.SamlAcs(v1, conf)
 
 		api.GetPreview(v1, conf)
 		api.GetThumbnail(v1, conf)
@@ -31,10 +34,19 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DownloadZip(v1, conf)
 
 		api.GetGeo(v1, conf)
+		api.GetGeoHeatmap(v1, conf)
 		api.GetPhoto(v1, conf)
 		api.GetPhotoYaml(v1, conf)
 		api.UpdatePhoto(v1, conf)
+		api.UnlockPhoto(v1, conf)
 		api.GetPhotos(v1, conf)
+		api.GetSearchHistory(v1, conf)
+		api.ClearSearchHistory(v1, conf)
+		api.DeleteSearchHistory(v1, conf)
+		api.PinSearch(v1, conf)
+		api.UnpinSearch(v1, conf)
+		api.RunSearch(v1, conf)
+		api.GetSearchSuggestions(v1, conf)
 		api.GetPhotoDownload(v1, conf)
 		api.LinkPhoto(v1, conf)
 		api.LikePhoto(v1, conf)
@@ -45,6 +57,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetMomentsTime(v1, conf)
 		api.GetFile(v1, conf)
 		api.LinkFile(v1, conf)
+		api.FilePrecheck(v1, conf)
 		api.SetPhotoPrimary(v1, conf)
 
 		api.GetLabels(v1, conf)
@@ -52,6 +65,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.LinkLabel(v1, conf)
 		api.LikeLabel(v1, conf)
 		api.DislikeLabel(v1, conf)
+		api.SetLabelCover(v1, conf)
+		api.ResetLabelCover(v1, conf)
 		api.LabelThumbnail(v1, conf)
 
 		api.GetFoldersOriginals(v1, conf)
@@ -59,28 +74,139 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 		api.Upload(v1, conf)
 		api.StartImport(v1, conf)
+		api.ImportUrl(v1, conf)
 		api.CancelImport(v1, conf)
 		api.StartIndexing(v1, conf)
 		api.CancelIndexing(v1, conf)
+		api.PauseIndexing(v1, conf)
+		api.ResumeIndexing(v1, conf)
+		api.GetMissingFiles(v1, conf)
+		api.PurgeMissingFiles(v1, conf)
+		api.RelocateMissingFiles(v1, conf)
+		api.GetIndexErrors(v1, conf)
+		api.RetryIndexError(v1, conf)
+		api.IgnoreIndexError(v1, conf)
+		api.GetThumbErrors(v1, conf)
+		api.DeleteThumbError(v1, conf)
+		api.GetQuarantine(v1, conf)
+		api.ReleaseQuarantine(v1, conf)
+		api.DeleteQuarantine(v1, conf)
+		api.GetCheckpoints(v1, conf)
+		api.CreateCheckpoint(v1, conf)
+		api.GetCheckpointDiff(v1, conf)
+		api.RestoreCheckpoint(v1, conf)
+		api.DeleteCheckpoint(v1, conf)
+		api.GetNSFWReview(v1, conf)
+		api.ApproveNSFW(v1, conf)
+		api.GetGeometryReview(v1, conf)
+		api.ApplyGeometryFix(v1, conf)
+		api.DismissGeometryFix(v1, conf)
+		api.GetDuplicates(v1, conf)
+		api.ResolveDuplicates(v1, conf)
+		api.UndoDuplicates(v1, conf)
 
 		api.BatchPhotosArchive(v1, conf)
 		api.BatchPhotosRestore(v1, conf)
 		api.BatchPhotosPrivate(v1, conf)
+		api.BatchPhotosLicense(v1, conf)
+		api.BatchPhotosSubjects(v1, conf)
+		api.BatchPhotosDate(v1, conf)
+		api.BatchPhotosRotate(v1, conf)
 		api.BatchAlbumsDelete(v1, conf)
 		api.BatchLabelsDelete(v1, conf)
 
+		api.GetSyncManifest(v1, conf)
+		api.ReportDisplaySize(v1, conf)
+		api.GetThumbStats(v1, conf)
+		api.GetUsageStats(v1, conf)
+
 		api.GetAlbum(v1, conf)
+		api.AddPhotoToAlbum(v1, conf)
 		api.CreateAlbum(v1, conf)
 		api.UpdateAlbum(v1, conf)
+		api.MoveAlbum(v1, conf)
+		api.GetAlbumChildren(v1, conf)
+		api.GetAlbumStory(v1, conf)
+		api.UpdateAlbumStory(v1, conf)
 		api.DeleteAlbum(v1, conf)
+		api.MergeAlbums(v1, conf)
+		api.CloneAlbum(v1, conf)
 		api.DownloadAlbum(v1, conf)
+		api.GetAlbumFeed(v1, conf)
+		api.GetAlbumTrack(v1, conf)
+		api.GetAlbumEvents(v1, conf)
+		api.CreateAlbumArchive(v1, conf)
+		api.GetArchive(v1, conf)
+		api.DownloadArchive(v1, conf)
+		api.AlbumDownloadEstimate(v1, conf)
+		api.CreateAlbumHighlights(v1, conf)
 		api.GetAlbums(v1, conf)
 		api.LinkAlbum(v1, conf)
 		api.LikeAlbum(v1, conf)
 		api.DislikeAlbum(v1, conf)
 		api.AlbumThumbnail(v1, conf)
+		api.SetAlbumCover(v1, conf)
+		api.ResetAlbumCover(v1, conf)
 		api.AddPhotosToAlbum(v1, conf)
 		api.RemovePhotosFromAlbum(v1, conf)
+		api.SetAlbumPhotoOrder(v1, conf)
+		api.UpdateAlbumCaption(v1, conf)
+		api.ExportAlbumCsv(v1, conf)
+		api.ImportAlbumCsv(v1, conf)
+		api.GetAlbumReactions(v1, conf)
+		api.GetAlbumTimeline(v1, conf)
+		api.DownloadAlbumPrints(v1, conf)
+		api.OrderAlbumPrints(v1, conf)
+
+		api.GetSnapshots(v1, conf)
+		api.GetSnapshot(v1, conf)
+		api.CreateSnapshot(v1, conf)
+		api.DeleteSnapshot(v1, conf)
+		api.LinkSnapshot(v1, conf)
+		api.DownloadSnapshot(v1, conf)
+		api.ShareWithChat(v1, conf)
+
+		api.GetPresets(v1, conf)
+		api.CreatePreset(v1, conf)
+		api.UpdatePreset(v1, conf)
+		api.DeletePreset(v1, conf)
+		api.ApplyPreset(v1, conf)
+
+		api.GetAlbumRules(v1, conf)
+		api.CreateAlbumRule(v1, conf)
+		api.UpdateAlbumRule(v1, conf)
+		api.DeleteAlbumRule(v1, conf)
+
+		api.GetDevices(v1, conf)
+		api.GetDevice(v1, conf)
+		api.CreateDevice(v1, conf)
+		api.UpdateDevice(v1, conf)
+		api.DeleteDevice(v1, conf)
+
+		api.UpdateLinkUrl(v1, conf)
+		api.UpdateLinkScope(v1, conf)
+		api.UpdateLinkRestrictions(v1, conf)
+		api.RenewLink(v1, conf)
+		api.UpdateLinkRenewal(v1, conf)
+		api.DeleteLink(v1, conf)
+		api.UnlockShare(v1, conf)
+		api.GetShareAlbums(v1, conf)
+		api.GetShareStory(v1, conf)
+		api.DownloadShare(v1, conf)
+		api.GetShareCredits(v1, conf)
+		api.GetShareThumbnail(v1, conf)
+		api.GetSharePreview(v1, conf)
+		api.GetGuestReactions(v1, conf)
+		api.AddGuestReaction(v1, conf)
+		api.HideGuestReaction(v1, conf)
+		api.ShowGuestReaction(v1, conf)
+		api.DeleteGuestReaction(v1, conf)
+		api.CreateGuest(v1, conf)
+		api.CreateGuestSession(v1, conf)
+		api.GetGuest(v1, conf)
+		api.GetGuests(v1, conf)
+		api.UpgradeGuest(v1, conf)
+		api.DeleteGuest(v1, conf)
 
 		api.GetAccounts(v1, conf)
 		api.GetAccount(v1, conf)
@@ -90,12 +216,51 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteAccount(v1, conf)
 		api.UpdateAccount(v1, conf)
 
+		api.GetQueries(v1, conf)
+		api.CancelQuery(v1, conf)
+		api.CancelJob(v1, conf)
+
 		api.GetSettings(v1, conf)
 		api.SaveSettings(v1, conf)
 
 		api.GetSvg(v1)
 
 		api.Websocket(v1, conf)
+
+		api.GetOpenApi(v1, conf, router.Routes)
+	}
+
+	// JSON-REST API Version 2, a stable contract for external integrations
+	v2 := router.Group("/api/v2", api.Policy(conf))
+	{
+		api.GetPhotosV2(v2, conf)
+		api.GetPhotoV2(v2, conf)
+		api.GetAlbumsV2(v2, conf)
+		api.GetAlbumV2(v2, conf)
+		api.GetAlbumPhotosV2(v2, conf)
+		api.GetOpenApiV2(v2, conf, router.Routes)
+	}
+
+	// Stable API for mobile backup apps
+	backup := router.Group("/api/backup/v1", api.Policy(conf))
+	{
+		api.BackupCheck(backup, conf)
+		api.BackupCreateBatch(backup, conf)
+		api.BackupGetBatch(backup, conf)
+		api.BackupCreateUpload(backup, conf)
+		api.BackupGetUpload(backup, conf)
+		api.BackupWriteUpload(backup, conf)
+		api.BackupCommitBatch(backup, conf)
+	}
+
+	// Immich API subset for its mobile app, see api/immich.go
+	immich := router.Group("/immich/api", api.ImmichAuth(), api.Policy(conf))
+	{
+		api.ImmichPing(immich, conf)
+		api.ImmichVersion(immich, conf)
+		api.ImmichLogin(immich, conf)
+		api.ImmichUploadCheck(immich, conf)
+		api.ImmichUpload(immich, conf)
 	}
 
 	// WebDAV server for file management / sharing
@@ -121,6 +286,12 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		log.Info("webdav: disabled (no password set)")
 	}
 
+	// HTML page with preview meta tags for share links
+	api.SharePage(router.Group("/"), conf)
+
+	// Permalinks of photos and albums
+	api.Permalink(router.Group("/"), conf)
+
 	// Default HTML page (client-side routing implemented via Vue.js)
 	router.NoRoute(func(c *gin.Context) {
 		clientConfig := conf.PublicClientConfig()
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	registerRoutes(router, config.TestConfig())
+
+	t.Run("mutating routes are mapped by the policy", func(t *testing.T) {
+		for _, r := range router.Routes() {
+			if !strings.HasPrefix(r.Path, "/api/") && !strings.HasPrefix(r.Path, "/immich/api/") {
+				continue
+			}
+
+			switch r.Method {
+			case "POST", "PUT", "PATCH", "DELETE":
+				if route := r.Method + " " + r.Path; !api.RouteMapped(route) {
+					t.Errorf("%s is not mapped in api.routePermissions or api.openRoutes", route)
+				}
+			}
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/api"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 )
@@ -27,7 +28,10 @@ func Start(ctx context.Context, conf *config.Config) {
 	}
 
 	router := gin.New()
-	router.Use(Logger(), Recovery())
+
+	// Client addresses are resolved by the API, which only trusts forwarding headers of configured proxies.
+	router.ForwardedByClientIP = false
+	router.Use(Logger(), Recovery(), api.ShareDomain(conf))
 
 	// Set template directory
 	router.LoadHTMLGlob(conf.HttpTemplatesPath() + "/*")
@@ -2,14 +2,21 @@ package server
 
 import (
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ingest"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"golang.org/x/net/webdav"
 )
 
 // ANY /webdav/*
-func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
+func WebDAV(root string, router *gin.RouterGroup, conf *config.Config) {
 	if router == nil {
 		log.Error("webdav: router is nil")
 		return
@@ -20,7 +27,7 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 		return
 	}
 
-	f := webdav.Dir(path)
+	f := webdav.Dir(root)
 
 	srv := &webdav.Handler{
 		Prefix:     router.BasePath(),
@@ -39,7 +46,31 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 		w := c.Writer
 		r := c.Request
 
+		// Clients like PhotoSync send the original modification time, which is used as date of
+		// files without Exif data when indexing.
+		mtime := ingest.Mtime(r.Header.Get("X-OC-Mtime"))
+
+		if r.Method == http.MethodPut && !mtime.IsZero() {
+			w.Header().Set("X-OC-Mtime", "accepted")
+		}
+
 		srv.ServeHTTP(w, r)
+
+		// Verify uploaded files, see upload-quarantine.
+		if r.Method == http.MethodPut && (w.Status() == http.StatusCreated || w.Status() == http.StatusNoContent) {
+			name := strings.TrimPrefix(r.URL.Path, srv.Prefix)
+			fileName := filepath.Join(root, filepath.FromSlash(path.Clean("/"+name)))
+
+			if !mtime.IsZero() {
+				if err := os.Chtimes(fileName, mtime, mtime); err != nil {
+					log.Warnf("webdav: %s", err)
+				}
+			}
+
+			if _, err := photoprism.VerifyUpload(conf, fileName, entity.QuarantineWebDAV, c.GetString(gin.AuthUserKey)); err != nil {
+				log.Errorf("webdav: %s", err)
+			}
+		}
 	}
 
 	router.Handle("OPTIONS", "/*path", handler)
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/archive"
+)
+
+var onceArchives sync.Once
+
+func initArchives() {
+	services.Archives = archive.NewJobs(filepath.Join(Config().TempPath(), "archive"), Config().TempLimit(), Config().Workers())
+}
+
+func Archives() *archive.Jobs {
+	onceArchives.Do(initArchives)
+
+	return services.Archives
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/backup"
+)
+
+var onceBackup sync.Once
+
+func initBackup() {
+	services.Backup = backup.NewStore(Config().BackupPath())
+}
+
+func Backup() *backup.Store {
+	onceBackup.Do(initBackup)
+
+	return services.Backup
+}
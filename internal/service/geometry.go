@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceGeometry sync.Once
+
+func initGeometry() {
+	services.Geometry = photoprism.NewGeometry(Config(), Classify(), Index())
+}
+
+func Geometry() *photoprism.Geometry {
+	onceGeometry.Do(initGeometry)
+
+	return services.Geometry
+}
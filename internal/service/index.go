@@ -9,6 +9,8 @@ import (
 var onceIndex sync.Once
 
 func initIndex() {
+	Semantic()
+
 	services.Index = photoprism.NewIndex(Config(), Classify(), NsfwDetector(), Convert())
 }
 
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/ingest"
+)
+
+var onceIngest sync.Once
+
+func initIngest() {
+	services.Ingest = ingest.New(filepath.Join(Config().CachePath(), "ingest.json"))
+}
+
+// Ingest returns the metadata of files uploaded by third-party backup apps that haven't been imported yet.
+func Ingest() *ingest.Queue {
+	onceIngest.Do(initIngest)
+
+	return services.Ingest
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/semantic"
+)
+
+var onceSemantic sync.Once
+
+func initSemantic() {
+	if !Config().SemanticSearch() {
+		return
+	}
+
+	semantic.SetEncoder(semantic.NewClient(Config().SemanticServiceUrl(), Config().SemanticServiceKey()))
+
+	if err := photoprism.LoadEmbeddings(); err != nil {
+		log.Errorf("semantic: %s", err)
+	}
+}
+
+// Semantic returns the index of photo embeddings for natural language search, which is empty if disabled.
+func Semantic() *semantic.Index {
+	onceSemantic.Do(initSemantic)
+
+	return semantic.Default
+}
@@ -2,27 +2,39 @@ package service
 
 import (
 	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/archive"
+	"github.com/photoprism/photoprism/internal/backup"
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/ingest"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/internal/thumbcache"
 )
 
+var log = event.Log
+
 var conf *config.Config
 
 var services struct {
-	Cache    *gc.Cache
-	Classify *classify.TensorFlow
-	Convert  *photoprism.Convert
-	Import   *photoprism.Import
-	Index    *photoprism.Index
-	Purge    *photoprism.Purge
-	Nsfw     *nsfw.Detector
-	Query    *query.Query
-	Resample *photoprism.Resample
-	Session  *session.Session
+	Archives   *archive.Jobs
+	Backup     *backup.Store
+	Cache      *gc.Cache
+	Classify   *classify.TensorFlow
+	Convert    *photoprism.Convert
+	Geometry   *photoprism.Geometry
+	Import     *photoprism.Import
+	Index      *photoprism.Index
+	Ingest     *ingest.Queue
+	Purge      *photoprism.Purge
+	Nsfw       *nsfw.Detector
+	Query      *query.Query
+	Resample   *photoprism.Resample
+	Session    *session.Session
+	ThumbCache *thumbcache.Cache
 }
 
 func SetConfig(c *config.Config) {
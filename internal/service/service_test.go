@@ -4,11 +4,14 @@ import (
 	"os"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/backup"
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/ingest"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/internal/thumbcache"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -38,6 +41,10 @@ func TestConvert(t *testing.T) {
 	assert.IsType(t, &photoprism.Convert{}, Convert())
 }
 
+func TestBackup(t *testing.T) {
+	assert.IsType(t, &backup.Store{}, Backup())
+}
+
 func TestImport(t *testing.T) {
 	assert.IsType(t, &photoprism.Import{}, Import())
 }
@@ -65,3 +72,11 @@ func TestResample(t *testing.T) {
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestIngest(t *testing.T) {
+	assert.IsType(t, &ingest.Queue{}, Ingest())
+}
+
+func TestThumbCache(t *testing.T) {
+	assert.IsType(t, &thumbcache.Cache{}, ThumbCache())
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/thumbcache"
+)
+
+var onceThumbCache sync.Once
+
+func initThumbCache() {
+	services.ThumbCache = thumbcache.New(Cache(), filepath.Join(Config().CachePath(), "thumbcache.json"))
+}
+
+// ThumbCache returns the cache of album and label cover thumbnails.
+func ThumbCache() *thumbcache.Cache {
+	onceThumbCache.Do(initThumbCache)
+
+	return services.ThumbCache
+}
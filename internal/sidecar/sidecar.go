@@ -0,0 +1,223 @@
+/*
+Package sidecar resolves where JSON, YAML and XMP sidecar files of originals are stored.
+
+Sidecar files may be written next to originals, into a hidden .photoprism sub directory, into a
+parallel folder tree outside the originals folder, or not at all. The strategy can be configured
+per top-level folder (storage root), e.g. to keep sidecars of a read-only NAS share in a separate tree.
+Existing sidecar files are found regardless of the strategy they were created with.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package sidecar
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var log = event.Log
+
+// Strategy specifies where new sidecar files are written.
+type Strategy string
+
+const (
+	Inline Strategy = "inline" // Same directory as the original.
+	Hidden Strategy = "hidden" // Hidden .photoprism sub directory.
+	Tree   Strategy = "tree"   // Parallel folder tree outside the originals folder.
+	None   Strategy = "none"   // Sidecar files are not written.
+)
+
+// Strategies maps folders relative to the originals path to sidecar strategies.
+type Strategies map[string]Strategy
+
+var (
+	OriginalsPath = ""
+	TreePath      = ""
+	Default       = Hidden
+	Roots         = Strategies{}
+)
+
+// ParseStrategy returns the strategy for a string, an empty string is the default strategy.
+func ParseStrategy(s string) (Strategy, error) {
+	switch result := Strategy(strings.ToLower(strings.TrimSpace(s))); result {
+	case Inline, Hidden, Tree, None:
+		return result, nil
+	case "":
+		return Default, nil
+	default:
+		return Default, fmt.Errorf("sidecar: unknown strategy %s", s)
+	}
+}
+
+// ParseRoots parses a comma separated list of folder strategies like "nas=tree,scans=none".
+func ParseRoots(s string) (Strategies, error) {
+	result := make(Strategies)
+
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+
+		if len(parts) != 2 {
+			return result, fmt.Errorf("sidecar: invalid folder strategy %s, expected folder=strategy", strings.TrimSpace(item))
+		}
+
+		root := cleanRoot(parts[0])
+
+		if root == "" {
+			return result, fmt.Errorf("sidecar: folder missing in %s", strings.TrimSpace(item))
+		}
+
+		strategy, err := ParseStrategy(parts[1])
+
+		if err != nil {
+			return result, err
+		} else if strings.TrimSpace(parts[1]) == "" {
+			return result, fmt.Errorf("sidecar: strategy missing in %s", strings.TrimSpace(item))
+		}
+
+		result[root] = strategy
+	}
+
+	return result, nil
+}
+
+// String returns the strategies as comma separated list, sorted by folder.
+func (s Strategies) String() string {
+	roots := make([]string, 0, len(s))
+
+	for root := range s {
+		roots = append(roots, root)
+	}
+
+	sort.Strings(roots)
+
+	for i, root := range roots {
+		roots[i] = fmt.Sprintf("%s=%s", root, s[root])
+	}
+
+	return strings.Join(roots, ",")
+}
+
+// cleanRoot returns a folder name relative to the originals path with forward slashes.
+func cleanRoot(s string) string {
+	return strings.Trim(filepath.ToSlash(filepath.Clean("/"+strings.TrimSpace(s))), "/")
+}
+
+// relativeDir returns the directory relative to the originals path, ok is false if it's outside.
+func relativeDir(dir string) (rel string, ok bool) {
+	if OriginalsPath == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(OriginalsPath, dir)
+
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+
+	rel = filepath.ToSlash(rel)
+
+	// Sidecar files of files in hidden sub directories belong to the parent directory.
+	if path := strings.TrimSuffix(rel, "/"+fs.HiddenPath); path != rel {
+		rel = path
+	} else if rel == fs.HiddenPath {
+		rel = "."
+	}
+
+	if rel == "." {
+		return "", true
+	}
+
+	return rel, true
+}
+
+// StrategyFor returns the strategy for sidecar files of originals in the given directory.
+// The longest matching folder in Roots wins. Parallel trees are not supported outside
+// the originals path, so that sidecar files are not written for these.
+func StrategyFor(dir string) Strategy {
+	rel, ok := relativeDir(dir)
+
+	result := Default
+	match := -1
+
+	for root, strategy := range Roots {
+		if (rel == root || strings.HasPrefix(rel, root+"/")) && len(root) > match {
+			result = strategy
+			match = len(root)
+		}
+	}
+
+	if result == Tree && (!ok || TreePath == "") {
+		return None
+	}
+
+	return result
+}
+
+// Dir returns the directory sidecar files of originals in the given directory are written to,
+// or an empty string if they shouldn't be written.
+func Dir(dir string) string {
+	switch StrategyFor(dir) {
+	case Inline:
+		return dir
+	case Hidden:
+		if filepath.Base(dir) == fs.HiddenPath {
+			return dir
+		}
+
+		return filepath.Join(dir, fs.HiddenPath)
+	case Tree:
+		rel, _ := relativeDir(dir)
+		return filepath.Join(TreePath, filepath.FromSlash(rel))
+	default:
+		return ""
+	}
+}
+
+// FileName returns the name of a new sidecar file with the given extension and creates
+// the directory if needed. An empty string is returned if no sidecar file should be written.
+func FileName(fileName, fileExt string, stripSequence bool) string {
+	dir := Dir(filepath.Dir(fileName))
+
+	if dir == "" {
+		return ""
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		log.Errorf("sidecar: %s", err)
+		return ""
+	}
+
+	return filepath.Join(dir, fs.Base(fileName, stripSequence)) + fileExt
+}
+
+// Find returns the name of an existing sidecar file of the given type, regardless of the
+// strategy it was created with, or an empty string if there is none.
+func Find(fileName string, fileType fs.FileType, stripSequence bool) string {
+	if result := fileType.FindSub(fileName, fs.HiddenPath, stripSequence); result != "" {
+		return result
+	}
+
+	if TreePath == "" {
+		return ""
+	}
+
+	rel, ok := relativeDir(filepath.Dir(fileName))
+
+	if !ok {
+		return ""
+	}
+
+	return fileType.Find(filepath.Join(TreePath, filepath.FromSlash(rel), filepath.Base(fileName)), stripSequence)
+}
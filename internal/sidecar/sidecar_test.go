@@ -0,0 +1,166 @@
+package sidecar
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(t *testing.T, strategy Strategy, roots Strategies) (originals string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "sidecar")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	originals = filepath.Join(dir, "originals")
+
+	if err := os.MkdirAll(filepath.Join(originals, "nas", "2020"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	OriginalsPath = originals
+	TreePath = filepath.Join(dir, "sidecar")
+	Default = strategy
+	Roots = roots
+
+	return originals, func() {
+		OriginalsPath = ""
+		TreePath = ""
+		Default = Hidden
+		Roots = Strategies{}
+		os.RemoveAll(dir)
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	t.Run("tree", func(t *testing.T) {
+		result, err := ParseStrategy(" Tree ")
+		assert.Nil(t, err)
+		assert.Equal(t, Tree, result)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := ParseStrategy("")
+		assert.Nil(t, err)
+		assert.Equal(t, Default, result)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := ParseStrategy("cloud")
+		assert.EqualError(t, err, "sidecar: unknown strategy cloud")
+	})
+}
+
+func TestParseRoots(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		result, err := ParseRoots("nas=tree, /scans/old/ = none,")
+		assert.Nil(t, err)
+		assert.Equal(t, Strategies{"nas": Tree, "scans/old": None}, result)
+		assert.Equal(t, "nas=tree,scans/old=none", result.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := ParseRoots("")
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("no strategy", func(t *testing.T) {
+		_, err := ParseRoots("nas")
+		assert.EqualError(t, err, "sidecar: invalid folder strategy nas, expected folder=strategy")
+
+		_, err = ParseRoots("nas=")
+		assert.EqualError(t, err, "sidecar: strategy missing in nas=")
+	})
+
+	t.Run("no folder", func(t *testing.T) {
+		_, err := ParseRoots("/=tree")
+		assert.EqualError(t, err, "sidecar: folder missing in /=tree")
+	})
+}
+
+func TestStrategyFor(t *testing.T) {
+	originals, cleanup := setup(t, Hidden, Strategies{"nas": Tree, "nas/2020": None, "scans": Inline})
+	defer cleanup()
+
+	assert.Equal(t, Hidden, StrategyFor(originals))
+	assert.Equal(t, Hidden, StrategyFor(filepath.Join(originals, "nasty")))
+	assert.Equal(t, Tree, StrategyFor(filepath.Join(originals, "nas")))
+	assert.Equal(t, Tree, StrategyFor(filepath.Join(originals, "nas", fs.HiddenPath)))
+	assert.Equal(t, None, StrategyFor(filepath.Join(originals, "nas", "2020", "summer")))
+	assert.Equal(t, Inline, StrategyFor(filepath.Join(originals, "scans")))
+
+	t.Run("outside originals", func(t *testing.T) {
+		Default = Tree
+		defer func() { Default = Hidden }()
+
+		assert.Equal(t, None, StrategyFor(filepath.Join(filepath.Dir(originals), "import")))
+		assert.Equal(t, Tree, StrategyFor(originals))
+	})
+}
+
+func TestDir(t *testing.T) {
+	originals, cleanup := setup(t, Inline, Strategies{"nas": Tree, "nas/2020": None, "hidden": Hidden})
+	defer cleanup()
+
+	assert.Equal(t, filepath.Join(originals, "photos"), Dir(filepath.Join(originals, "photos")))
+	assert.Equal(t, filepath.Join(originals, "hidden", fs.HiddenPath), Dir(filepath.Join(originals, "hidden")))
+	assert.Equal(t, filepath.Join(originals, "hidden", fs.HiddenPath), Dir(filepath.Join(originals, "hidden", fs.HiddenPath)))
+	assert.Equal(t, filepath.Join(TreePath, "nas", "2019"), Dir(filepath.Join(originals, "nas", "2019")))
+	assert.Equal(t, filepath.Join(TreePath, "nas", "2019"), Dir(filepath.Join(originals, "nas", "2019", fs.HiddenPath)))
+	assert.Equal(t, "", Dir(filepath.Join(originals, "nas", "2020")))
+}
+
+func TestFileName(t *testing.T) {
+	originals, cleanup := setup(t, Hidden, Strategies{"nas": Tree, "nas/2020": None})
+	defer cleanup()
+
+	t.Run("hidden", func(t *testing.T) {
+		result := FileName(filepath.Join(originals, "IMG_1234.jpg"), fs.YamlExt, false)
+		assert.Equal(t, filepath.Join(originals, fs.HiddenPath, "IMG_1234.yml"), result)
+		assert.DirExists(t, filepath.Join(originals, fs.HiddenPath))
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		result := FileName(filepath.Join(originals, "nas", "2019", "IMG_1234 (2).jpg"), ".json", true)
+		assert.Equal(t, filepath.Join(TreePath, "nas", "2019", "IMG_1234.json"), result)
+		assert.DirExists(t, filepath.Join(TreePath, "nas", "2019"))
+		assert.False(t, fs.PathExists(filepath.Join(originals, "nas", "2019")))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, "", FileName(filepath.Join(originals, "nas", "2020", "IMG_1234.jpg"), ".json", false))
+	})
+}
+
+func TestFind(t *testing.T) {
+	originals, cleanup := setup(t, Tree, Strategies{})
+	defer cleanup()
+
+	fileName := filepath.Join(originals, "nas", "2020", "IMG_1234.jpg")
+
+	assert.Equal(t, "", Find(fileName, fs.TypeJson, false))
+
+	treeName := FileName(fileName, ".json", false)
+
+	if err := ioutil.WriteFile(treeName, []byte("[]"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, treeName, Find(fileName, fs.TypeJson, false))
+
+	// Sidecar files next to the original take precedence.
+	inlineName := filepath.Join(originals, "nas", "2020", "IMG_1234.json")
+
+	if err := ioutil.WriteFile(inlineName, []byte("[]"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, inlineName, Find(fileName, fs.TypeJson, false))
+	assert.Equal(t, "", Find(filepath.Join(originals, "nas", "2020", "IMG_5678.jpg"), fs.TypeJson, false))
+}
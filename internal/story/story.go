@@ -0,0 +1,70 @@
+/*
+This package renders album stories, Markdown documents that turn an album into a narrated travelogue.
+
+Photos are embedded by UID using the regular image syntax, e.g. ![At the beach](photo:pt9jtdre2lvl0yh7).
+Raw HTML is not rendered, so that stories can safely be shown on public share pages.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package story
+
+import (
+	"html/template"
+	"regexp"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// MaxLength is the maximum length of a story in bytes.
+const MaxLength = 65535
+
+// Embed returns the image url of an embedded photo, ok is false if the photo must not be shown.
+type Embed func(photoUID string) (src string, ok bool)
+
+var embedRegexp = regexp.MustCompile(`!\[([^\]]*)\]\(photo:([a-z0-9]+)\)`)
+
+// Photos returns the UIDs of all embedded photos in the order they appear.
+func Photos(markdown string) (result []string) {
+	seen := make(map[string]bool)
+
+	for _, m := range embedRegexp.FindAllStringSubmatch(markdown, -1) {
+		if uid := m[2]; !seen[uid] {
+			seen[uid] = true
+			result = append(result, uid)
+		}
+	}
+
+	return result
+}
+
+// Render returns the story as HTML. Embedded photos are replaced by their image url, or removed if
+// embed returns false or is nil.
+func Render(markdown string, embed Embed) template.HTML {
+	if markdown == "" {
+		return ""
+	}
+
+	markdown = embedRegexp.ReplaceAllStringFunc(markdown, func(s string) string {
+		m := embedRegexp.FindStringSubmatch(s)
+
+		if embed == nil {
+			return ""
+		}
+
+		src, ok := embed(m[2])
+
+		if !ok {
+			return ""
+		}
+
+		return "![" + m[1] + "](" + src + ")"
+	})
+
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML | blackfriday.Safelink | blackfriday.NofollowLinks | blackfriday.NoreferrerLinks | blackfriday.HrefTargetBlank,
+	})
+
+	return template.HTML(blackfriday.Run([]byte(markdown), blackfriday.WithRenderer(renderer)))
+}
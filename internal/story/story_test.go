@@ -0,0 +1,47 @@
+package story
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotos(t *testing.T) {
+	markdown := "# Day 1\n\n![Beach](photo:pt9jtdre2lvl0yh7)\n\n![Sunset](photo:pt9jtdre2lvl0yh8) ![Again](photo:pt9jtdre2lvl0yh7)"
+
+	assert.Equal(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, Photos(markdown))
+	assert.Empty(t, Photos("No photos"))
+}
+
+func TestRender(t *testing.T) {
+	embed := func(uid string) (string, bool) {
+		if uid == "pt9jtdre2lvl0yh7" {
+			return "/api/v1/t/abc/public/fit_1280", true
+		}
+
+		return "", false
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		result := Render("# Day 1\n\nWe arrived *late*.", nil)
+
+		assert.Contains(t, string(result), "<h1>Day 1</h1>")
+		assert.Contains(t, string(result), "<em>late</em>")
+	})
+	t.Run("embeds", func(t *testing.T) {
+		result := Render("![Beach](photo:pt9jtdre2lvl0yh7)\n\n![Private](photo:pt9jtdre2lvl0yh8)", embed)
+
+		assert.Contains(t, string(result), `<img src="/api/v1/t/abc/public/fit_1280" alt="Beach"`)
+		assert.NotContains(t, string(result), "Private")
+		assert.NotContains(t, string(result), "photo:")
+	})
+	t.Run("raw html", func(t *testing.T) {
+		result := Render("Hello <script>alert(1)</script> [link](javascript:alert(1))", nil)
+
+		assert.NotContains(t, string(result), "<script>")
+		assert.NotContains(t, string(result), `href="javascript:`)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", string(Render("", embed)))
+	})
+}
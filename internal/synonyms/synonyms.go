@@ -0,0 +1,236 @@
+/*
+Package synonyms translates search keywords to the English names of classifier labels, so that
+non-English users can search in their own language, e.g. "hund" also finds photos labeled "dog".
+
+Synonyms are defined in language packs, which are YAML files named after the language code
+like "de.yml". Each entry maps an English label or keyword to a list of words in that language:
+
+	dog: [hund, hunde, welpe]
+
+The language of a query is detected automatically based on the words found in the enabled packs.
+It is used to resolve words that exist in more than one language.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package synonyms
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/list"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"gopkg.in/yaml.v2"
+)
+
+var log = event.Log
+
+// Default contains the synonyms used for search.
+var Default = make(Index)
+
+// Pack maps English keywords to words in another language.
+type Pack struct {
+	Lang  string
+	Words map[string][]string
+}
+
+// LoadPack loads a language pack from a YAML file, the file name must be the language code.
+func LoadPack(fileName string) (Pack, error) {
+	result := Pack{
+		Lang:  strings.ToLower(strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))),
+		Words: make(map[string][]string),
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	if err := yaml.Unmarshal(data, &result.Words); err != nil {
+		return result, fmt.Errorf("synonyms: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	return result, nil
+}
+
+// Synonym represents the English keyword for a word in another language.
+type Synonym struct {
+	Lang    string
+	Keyword string
+}
+
+// Index maps lowercase words to English keywords.
+type Index map[string][]Synonym
+
+// Load returns an index of the language packs found in the given directories. Only packs for the
+// given languages are loaded, unless the list is empty.
+func Load(dirs []string, langs []string) Index {
+	result := make(Index)
+
+	for _, dir := range dirs {
+		fileNames, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+
+		if err != nil {
+			log.Errorf("synonyms: %s", err)
+			continue
+		}
+
+		for _, fileName := range fileNames {
+			p, err := LoadPack(fileName)
+
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+
+			if len(langs) > 0 && !list.Contains(langs, p.Lang) {
+				continue
+			}
+
+			result.Add(p)
+
+			log.Debugf("synonyms: added %d keywords from %s", len(p.Words), txt.Quote(fileName))
+		}
+	}
+
+	return result
+}
+
+// Add adds the words of a language pack to the index.
+func (idx Index) Add(p Pack) {
+	for keyword, words := range p.Words {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+
+		if keyword == "" {
+			continue
+		}
+
+		for _, w := range words {
+			w = strings.ToLower(strings.TrimSpace(w))
+
+			if w == "" || w == keyword {
+				continue
+			}
+
+			idx[w] = append(idx[w], Synonym{Lang: p.Lang, Keyword: keyword})
+		}
+	}
+}
+
+// Detect returns the language most words belong to, or an empty string if it is unclear.
+func (idx Index) Detect(words []string) string {
+	counts := make(map[string]int)
+
+	for _, w := range words {
+		langs := make(map[string]bool)
+
+		for _, s := range idx[strings.ToLower(w)] {
+			langs[s.Lang] = true
+		}
+
+		for lang := range langs {
+			counts[lang]++
+		}
+	}
+
+	result, max, tie := "", 0, false
+
+	for lang, n := range counts {
+		if n > max {
+			result, max, tie = lang, n, false
+		} else if n == max {
+			tie = true
+		}
+	}
+
+	if tie {
+		return ""
+	}
+
+	return result
+}
+
+// Expand adds the English keywords for all words in a search query, e.g. "hund" becomes "hund dog".
+func (idx Index) Expand(query string) string {
+	if len(idx) == 0 {
+		return query
+	}
+
+	var words []string
+
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		if w = strings.Trim(w, ".,;:!?\"'()"); w != "" {
+			words = append(words, w)
+		}
+	}
+
+	lang := idx.Detect(words)
+	found := make(map[string]bool)
+	var keywords []string
+
+	for _, w := range words {
+		found[w] = true
+	}
+
+	for _, w := range words {
+		synonyms := idx[w]
+
+		// Use the detected language for words that exist in several languages.
+		if lang != "" && hasLang(synonyms, lang) {
+			synonyms = filterLang(synonyms, lang)
+		}
+
+		for _, s := range synonyms {
+			// Multi-word keywords match both as label slug and as separate words.
+			terms := strings.Fields(s.Keyword)
+
+			if len(terms) > 1 {
+				terms = append(terms, strings.Join(terms, "-"))
+			}
+
+			for _, t := range terms {
+				if !found[t] {
+					found[t] = true
+					keywords = append(keywords, t)
+				}
+			}
+		}
+	}
+
+	if len(keywords) == 0 {
+		return query
+	}
+
+	sort.Strings(keywords)
+
+	return strings.TrimSpace(query) + " " + strings.Join(keywords, " ")
+}
+
+// hasLang returns true if at least one synonym belongs to the language.
+func hasLang(synonyms []Synonym, lang string) bool {
+	for _, s := range synonyms {
+		if s.Lang == lang {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterLang returns the synonyms that belong to the language.
+func filterLang(synonyms []Synonym, lang string) (result []Synonym) {
+	for _, s := range synonyms {
+		if s.Lang == lang {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
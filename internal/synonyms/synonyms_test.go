@@ -0,0 +1,109 @@
+package synonyms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIndex() Index {
+	idx := make(Index)
+
+	idx.Add(Pack{Lang: "de", Words: map[string][]string{
+		"dog":   {"Hund", "hunde"},
+		"cat":   {"katze"},
+		"skirt": {"rock"},
+		"bench": {"bank"},
+	}})
+
+	idx.Add(Pack{Lang: "fr", Words: map[string][]string{
+		"dog":        {"chien"},
+		"guinea pig": {"cobaye"},
+		"bench":      {"banc"},
+	}})
+
+	idx.Add(Pack{Lang: "nl", Words: map[string][]string{
+		"couch": {"bank"},
+		"cat":   {"kat"},
+	}})
+
+	return idx
+}
+
+func TestLoadPack(t *testing.T) {
+	t.Run("de", func(t *testing.T) {
+		p, err := LoadPack("../../assets/resources/synonyms/de.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de", p.Lang)
+		assert.Contains(t, p.Words["dog"], "hund")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := LoadPack("xx.yml")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		idx := Load([]string{"../../assets/resources/synonyms", "not-existing"}, nil)
+
+		assert.Equal(t, "dog", idx["hund"][0].Keyword)
+		assert.Equal(t, "dog", idx["chien"][0].Keyword)
+	})
+
+	t.Run("fr", func(t *testing.T) {
+		idx := Load([]string{"../../assets/resources/synonyms"}, []string{"fr"})
+
+		assert.Empty(t, idx["hund"])
+		assert.Equal(t, "dog", idx["chien"][0].Keyword)
+	})
+}
+
+func TestIndex_Detect(t *testing.T) {
+	idx := testIndex()
+
+	assert.Equal(t, "de", idx.Detect([]string{"hund", "katze"}))
+	assert.Equal(t, "fr", idx.Detect([]string{"chien"}))
+	assert.Equal(t, "", idx.Detect([]string{"dog"}))
+	assert.Equal(t, "", idx.Detect([]string{"bank"}))
+	assert.Equal(t, "", idx.Detect(nil))
+}
+
+func TestIndex_Expand(t *testing.T) {
+	idx := testIndex()
+
+	t.Run("german", func(t *testing.T) {
+		assert.Equal(t, "Hund dog", idx.Expand("Hund"))
+	})
+
+	t.Run("english", func(t *testing.T) {
+		assert.Equal(t, "dog", idx.Expand("dog"))
+	})
+
+	t.Run("multi word keyword", func(t *testing.T) {
+		assert.Equal(t, "cobaye guinea guinea-pig pig", idx.Expand("cobaye"))
+	})
+
+	t.Run("detected language", func(t *testing.T) {
+		assert.Equal(t, "katze bank bench cat", idx.Expand("katze bank"))
+		assert.Equal(t, "kat bank cat couch", idx.Expand("kat bank"))
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		assert.Equal(t, "bank bench couch", idx.Expand("bank"))
+	})
+
+	t.Run("punctuation", func(t *testing.T) {
+		assert.Equal(t, "hund, katze cat dog", idx.Expand("hund, katze"))
+	})
+
+	t.Run("empty index", func(t *testing.T) {
+		assert.Equal(t, "hund", make(Index).Expand("hund"))
+	})
+}
@@ -0,0 +1,88 @@
+package thumb
+
+// Adaptive sizing needs at least AdaptiveMinReports display size reports, sizes requested by less than
+// AdaptiveMinShare of all reports are rendered on demand instead of in advance.
+const (
+	AdaptiveMinReports = 100
+	AdaptiveMinShare   = 0.01
+	AdaptiveMinSize    = 720
+)
+
+// BytesPerPixel is the approximate size of a JPEG thumbnail pixel, used to estimate disk usage.
+const BytesPerPixel = 0.3
+
+// FitTypes lists the thumbnail types used to display photos, ordered by size.
+var FitTypes = []string{"fit_720", "fit_1280", "fit_1920", "fit_2048", "fit_2560", "fit_3840"}
+
+// Fit returns the smallest thumbnail type that covers the given display size in pixels.
+func Fit(width, height int) string {
+	for _, name := range FitTypes {
+		t := Types[name]
+
+		if width <= t.Width && height <= t.Height {
+			return name
+		}
+	}
+
+	return FitTypes[len(FitTypes)-1]
+}
+
+// MaxLength returns the longer side of the thumbnail type.
+func (t Type) MaxLength() int {
+	if t.Width > t.Height {
+		return t.Width
+	}
+
+	return t.Height
+}
+
+// AdaptiveSize returns the size limit for thumbnails rendered in advance based on the number of reports
+// per thumbnail type. The result is never larger than maxSize and stays at maxSize without enough reports.
+func AdaptiveSize(reports map[string]int, maxSize int) int {
+	total := 0
+
+	for _, n := range reports {
+		total += n
+	}
+
+	if total < AdaptiveMinReports {
+		return maxSize
+	}
+
+	result := AdaptiveMinSize
+
+	for _, name := range FitTypes {
+		if float64(reports[name])/float64(total) < AdaptiveMinShare {
+			continue
+		}
+
+		if size := Types[name].MaxLength(); size > result {
+			result = size
+		}
+	}
+
+	if result > maxSize {
+		return maxSize
+	}
+
+	return result
+}
+
+// EstimateBytes returns the approximate file size of a thumbnail for an image with the given dimensions.
+func (t Type) EstimateBytes(width, height int) int64 {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+
+	scale := 1.0
+
+	if r := float64(t.Width) / float64(width); r < scale {
+		scale = r
+	}
+
+	if r := float64(t.Height) / float64(height); r < scale {
+		scale = r
+	}
+
+	return int64(float64(width) * scale * float64(height) * scale * BytesPerPixel)
+}
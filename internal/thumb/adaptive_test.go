@@ -0,0 +1,42 @@
+package thumb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, "fit_720", Fit(640, 480))
+	assert.Equal(t, "fit_1280", Fit(1280, 800))
+	assert.Equal(t, "fit_1920", Fit(1920, 1080))
+	assert.Equal(t, "fit_2048", Fit(1600, 1600))
+	assert.Equal(t, "fit_3840", Fit(3840, 2160))
+	assert.Equal(t, "fit_3840", Fit(8000, 6000))
+}
+
+func TestType_MaxLength(t *testing.T) {
+	assert.Equal(t, 2560, Types["fit_2560"].MaxLength())
+	assert.Equal(t, 224, Types["tile_224"].MaxLength())
+}
+
+func TestAdaptiveSize(t *testing.T) {
+	t.Run("not enough reports", func(t *testing.T) {
+		assert.Equal(t, 3840, AdaptiveSize(map[string]int{"fit_720": 10}, 3840))
+	})
+	t.Run("small displays", func(t *testing.T) {
+		assert.Equal(t, 1920, AdaptiveSize(map[string]int{"fit_720": 500, "fit_1920": 300, "fit_3840": 2}, 3840))
+	})
+	t.Run("max size", func(t *testing.T) {
+		assert.Equal(t, 2048, AdaptiveSize(map[string]int{"fit_1280": 50, "fit_3840": 50}, 2048))
+	})
+	t.Run("min size", func(t *testing.T) {
+		assert.Equal(t, 720, AdaptiveSize(map[string]int{"fit_720": 200}, 3840))
+	})
+}
+
+func TestType_EstimateBytes(t *testing.T) {
+	assert.Equal(t, int64(0), Types["fit_720"].EstimateBytes(0, 0))
+	assert.Equal(t, int64(720*540*BytesPerPixel), Types["fit_720"].EstimateBytes(4000, 3000))
+	assert.Equal(t, int64(400*300*BytesPerPixel), Types["fit_720"].EstimateBytes(400, 300))
+}
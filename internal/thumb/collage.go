@@ -0,0 +1,58 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+)
+
+// CollageGap is the space between images in pixels.
+const CollageGap = 4
+
+// collageCells returns the rectangles in which up to four images are drawn:
+// one image fills the whole collage, two are placed side by side, three get a large cell on the
+// left and two small cells on the right, and four or more are arranged in a 2x2 grid.
+func collageCells(count, width, height int) (cells []image.Rectangle) {
+	halfWidth := (width - CollageGap) / 2
+	halfHeight := (height - CollageGap) / 2
+	right := halfWidth + CollageGap
+	bottom := halfHeight + CollageGap
+
+	switch {
+	case count <= 0:
+		return cells
+	case count == 1:
+		return []image.Rectangle{image.Rect(0, 0, width, height)}
+	case count == 2:
+		return []image.Rectangle{
+			image.Rect(0, 0, halfWidth, height),
+			image.Rect(right, 0, width, height),
+		}
+	case count == 3:
+		return []image.Rectangle{
+			image.Rect(0, 0, halfWidth, height),
+			image.Rect(right, 0, width, halfHeight),
+			image.Rect(right, bottom, width, height),
+		}
+	default:
+		return []image.Rectangle{
+			image.Rect(0, 0, halfWidth, halfHeight),
+			image.Rect(right, 0, width, halfHeight),
+			image.Rect(0, bottom, halfWidth, height),
+			image.Rect(right, bottom, width, height),
+		}
+	}
+}
+
+// Collage returns an image of the given size showing up to four images, each cropped to fill its cell.
+func Collage(images []image.Image, width, height int) image.Image {
+	result := imaging.New(width, height, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	for i, cell := range collageCells(len(images), width, height) {
+		img := imaging.Fill(images[i], cell.Dx(), cell.Dy(), imaging.Center, imaging.Lanczos)
+		result = imaging.Paste(result, img, cell.Min)
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollageCells(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		assert.Empty(t, collageCells(0, 1200, 630))
+	})
+	t.Run("one", func(t *testing.T) {
+		assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 1200, 630)}, collageCells(1, 1200, 630))
+	})
+	t.Run("two", func(t *testing.T) {
+		cells := collageCells(2, 1200, 630)
+
+		assert.Len(t, cells, 2)
+		assert.Equal(t, image.Rect(0, 0, 598, 630), cells[0])
+		assert.Equal(t, image.Rect(602, 0, 1200, 630), cells[1])
+	})
+	t.Run("three", func(t *testing.T) {
+		cells := collageCells(3, 1200, 630)
+
+		assert.Len(t, cells, 3)
+		assert.Equal(t, 630, cells[0].Dy())
+		assert.Equal(t, 313, cells[1].Dy())
+		assert.Equal(t, 630, cells[2].Max.Y)
+	})
+	t.Run("many", func(t *testing.T) {
+		assert.Len(t, collageCells(12, 1200, 630), 4)
+	})
+}
+
+func TestCollage(t *testing.T) {
+	red := imaging.New(300, 200, color.NRGBA{R: 255, A: 255})
+	blue := imaging.New(200, 300, color.NRGBA{B: 255, A: 255})
+
+	t.Run("empty", func(t *testing.T) {
+		result := Collage(nil, 1200, 630)
+
+		assert.Equal(t, image.Rect(0, 0, 1200, 630), result.Bounds())
+		assert.Equal(t, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, result.At(600, 315))
+	})
+	t.Run("two", func(t *testing.T) {
+		result := Collage([]image.Image{red, blue}, 1200, 630)
+
+		assert.Equal(t, image.Rect(0, 0, 1200, 630), result.Bounds())
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, result.At(100, 100))
+		assert.Equal(t, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, result.At(600, 100))
+		assert.Equal(t, color.NRGBA{B: 255, A: 255}, result.At(1100, 500))
+	})
+}
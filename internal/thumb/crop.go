@@ -0,0 +1,124 @@
+package thumb
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var ErrInvalidCrop = errors.New("thumb: invalid crop, expected x,y,w,h with values between 0 and 1")
+
+// Crop represents an image region relative to the image size, e.g. the face of a person.
+type Crop struct {
+	X float64
+	Y float64
+	W float64
+	H float64
+}
+
+// ParseCrop parses an image region in the format "x,y,w,h", e.g. "0.25,0.1,0.2,0.3".
+func ParseCrop(s string) (result Crop, err error) {
+	values := strings.Split(strings.TrimSpace(s), ",")
+
+	if len(values) != 4 {
+		return result, ErrInvalidCrop
+	}
+
+	var v [4]float64
+
+	for i, value := range values {
+		if v[i], err = strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return Crop{}, ErrInvalidCrop
+		}
+	}
+
+	result = Crop{X: v[0], Y: v[1], W: v[2], H: v[3]}
+
+	if !result.Valid() {
+		return Crop{}, ErrInvalidCrop
+	}
+
+	return result, nil
+}
+
+// Valid returns true if the region has a size and is within the image.
+func (c Crop) Valid() bool {
+	return c.X >= 0 && c.Y >= 0 && c.W > 0 && c.H > 0 && c.X+c.W <= 1 && c.Y+c.H <= 1
+}
+
+// String returns the region in the format "x,y,w,h".
+func (c Crop) String() string {
+	return fmt.Sprintf("%.3f,%.3f,%.3f,%.3f", c.X, c.Y, c.W, c.H)
+}
+
+// Rect returns the region in pixels for an image of the given size.
+func (c Crop) Rect(width, height int) image.Rectangle {
+	x := int(c.X * float64(width))
+	y := int(c.Y * float64(height))
+
+	return image.Rect(x, y, x+int(c.W*float64(width)), y+int(c.H*float64(height)))
+}
+
+// CropFilename returns the cache file name of a square thumbnail showing an image region.
+func CropFilename(hash, thumbPath string, size int, crop Crop) (string, error) {
+	if InvalidSize(size) {
+		return "", fmt.Errorf("resample: size exceeds limit (%d)", size)
+	}
+
+	if len(hash) < 4 {
+		return "", fmt.Errorf("resample: file hash is empty or too short (%s)", txt.Quote(hash))
+	}
+
+	if !crop.Valid() {
+		return "", ErrInvalidCrop
+	}
+
+	p := path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3])
+
+	if err := os.MkdirAll(p, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	region := fmt.Sprintf("%03d%03d%03d%03d", int(crop.X*1000), int(crop.Y*1000), int(crop.W*1000), int(crop.H*1000))
+
+	return fmt.Sprintf("%s/%s_%dx%d_crop_%s.%s", p, hash, size, size, region, fs.TypeJpeg), nil
+}
+
+// FromCrop returns a square thumbnail showing an image region, it's created if not cached yet.
+func FromCrop(imageFilename, hash, thumbPath string, size int, crop Crop) (fileName string, err error) {
+	fileName, err = CropFilename(hash, thumbPath, size, crop)
+
+	if err != nil {
+		return "", err
+	}
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	img, err := imaging.Open(imageFilename, imaging.AutoOrientation(true))
+
+	if err != nil {
+		log.Errorf("resample: can't open %s (%s)", txt.Quote(imageFilename), err.Error())
+		return "", err
+	}
+
+	bounds := img.Bounds()
+	region := imaging.Crop(img, crop.Rect(bounds.Dx(), bounds.Dy()))
+	result := imaging.Fill(region, size, size, imaging.Center, Filter.Imaging())
+
+	if err := imaging.Save(result, fileName, imaging.JPEGQuality(JpegQuality)); err != nil {
+		log.Errorf("resample: failed to save %s", txt.Quote(fileName))
+		return "", err
+	}
+
+	return fileName, nil
+}
@@ -0,0 +1,75 @@
+package thumb
+
+import (
+	"image"
+	"os"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCrop(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		crop, err := ParseCrop("0.25, 0.1,0.2,0.3")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Crop{X: 0.25, Y: 0.1, W: 0.2, H: 0.3}, crop)
+		assert.Equal(t, "0.250,0.100,0.200,0.300", crop.String())
+	})
+	t.Run("outside image", func(t *testing.T) {
+		_, err := ParseCrop("0.9,0.1,0.2,0.3")
+		assert.Equal(t, ErrInvalidCrop, err)
+	})
+	t.Run("empty region", func(t *testing.T) {
+		_, err := ParseCrop("0.5,0.5,0,0.3")
+		assert.Equal(t, ErrInvalidCrop, err)
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := ParseCrop("0.5,0.5,0.1")
+		assert.Equal(t, ErrInvalidCrop, err)
+
+		_, err = ParseCrop("a,b,c,d")
+		assert.Equal(t, ErrInvalidCrop, err)
+	})
+}
+
+func TestCrop_Rect(t *testing.T) {
+	crop := Crop{X: 0.25, Y: 0.5, W: 0.5, H: 0.25}
+
+	assert.Equal(t, image.Rect(100, 150, 300, 225), crop.Rect(400, 300))
+}
+
+func TestFromCrop(t *testing.T) {
+	t.Run("example.jpg", func(t *testing.T) {
+		crop := Crop{X: 0.25, Y: 0.25, W: 0.5, H: 0.5}
+		dst := "testdata/1/2/3/123456789098765432_160x160_crop_250250500500.jpg"
+
+		fileName, err := FromCrop("testdata/example.jpg", "123456789098765432", "testdata", 160, crop)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(dst)
+
+		assert.Equal(t, dst, fileName)
+
+		img, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 160, img.Bounds().Dx())
+		assert.Equal(t, 160, img.Bounds().Dy())
+	})
+	t.Run("invalid crop", func(t *testing.T) {
+		_, err := FromCrop("testdata/example.jpg", "123456789098765432", "testdata", 160, Crop{})
+		assert.Equal(t, ErrInvalidCrop, err)
+	})
+	t.Run("file missing", func(t *testing.T) {
+		_, err := FromCrop("testdata/xxx.jpg", "123456789098765432", "testdata", 160, Crop{X: 0, Y: 0, W: 1, H: 1})
+		assert.Error(t, err)
+	})
+}
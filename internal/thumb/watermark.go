@@ -0,0 +1,194 @@
+package thumb
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Watermark positions.
+const (
+	WatermarkCenter      = "center"
+	WatermarkTopLeft     = "top-left"
+	WatermarkTopRight    = "top-right"
+	WatermarkBottomLeft  = "bottom-left"
+	WatermarkBottomRight = "bottom-right"
+)
+
+// WatermarkOpacity is the default watermark opacity.
+const WatermarkOpacity = 0.5
+
+// Watermark represents a text or PNG logo overlay for shared thumbnails.
+type Watermark struct {
+	Text     string
+	Image    string
+	Position string
+	Opacity  float64
+}
+
+// Empty returns true if neither text nor image are set.
+func (w Watermark) Empty() bool {
+	return w.Text == "" && w.Image == ""
+}
+
+// Hash returns a short checksum of the watermark settings for use in file names.
+func (w Watermark) Hash() string {
+	h := sha1.New()
+	_, _ = fmt.Fprintf(h, "%s|%s|%s|%.2f", w.Text, w.Image, w.position(), w.opacity())
+	return hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// position returns the watermark position with bottom right as default.
+func (w Watermark) position() string {
+	switch w.Position {
+	case WatermarkCenter, WatermarkTopLeft, WatermarkTopRight, WatermarkBottomLeft:
+		return w.Position
+	default:
+		return WatermarkBottomRight
+	}
+}
+
+// opacity returns the watermark opacity between 0 and 1.
+func (w Watermark) opacity() float64 {
+	if w.Opacity <= 0 {
+		return WatermarkOpacity
+	} else if w.Opacity > 1 {
+		return 1
+	}
+
+	return w.Opacity
+}
+
+// textImage renders the watermark text in white with a dark shadow on a transparent background.
+func (w Watermark) textImage() image.Image {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, w.Text).Ceil() + 4
+	img := image.NewRGBA(image.Rect(0, 0, width, face.Height+4))
+
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(color.RGBA{A: 255}), Face: face, Dot: fixed.P(3, face.Ascent+3)}
+	d.DrawString(w.Text)
+
+	d.Src = image.NewUniform(color.White)
+	d.Dot = fixed.P(2, face.Ascent+2)
+	d.DrawString(w.Text)
+
+	return img
+}
+
+// overlay returns the watermark image scaled for a background of the given size.
+func (w Watermark) overlay(width, height int) (result image.Image, err error) {
+	var maxWidth int
+
+	if w.Image != "" {
+		if result, err = imaging.Open(w.Image); err != nil {
+			return result, fmt.Errorf("watermark: can't open %s (%s)", txt.Quote(filepath.Base(w.Image)), err)
+		}
+
+		maxWidth = width / 4
+	} else {
+		result = w.textImage()
+		maxWidth = width / 2
+	}
+
+	b := result.Bounds()
+
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return result, fmt.Errorf("watermark: image is empty")
+	}
+
+	scaledWidth := maxWidth
+	scaledHeight := b.Dy() * maxWidth / b.Dx()
+
+	if scaledHeight > height/4 {
+		scaledHeight = height / 4
+		scaledWidth = b.Dx() * scaledHeight / b.Dy()
+	}
+
+	if scaledWidth < 1 || scaledHeight < 1 {
+		return result, fmt.Errorf("watermark: image too small")
+	}
+
+	return imaging.Resize(result, scaledWidth, scaledHeight, imaging.Linear), nil
+}
+
+// Apply returns a copy of the image with the watermark drawn on top.
+func (w Watermark) Apply(img image.Image) (result image.Image, err error) {
+	if w.Empty() {
+		return img, nil
+	}
+
+	b := img.Bounds()
+	mark, err := w.overlay(b.Dx(), b.Dy())
+
+	if err != nil {
+		return img, err
+	}
+
+	m := mark.Bounds()
+	margin := b.Dx() / 50
+
+	if b.Dy() < b.Dx() {
+		margin = b.Dy() / 50
+	}
+
+	var pos image.Point
+
+	switch w.position() {
+	case WatermarkCenter:
+		pos = image.Pt((b.Dx()-m.Dx())/2, (b.Dy()-m.Dy())/2)
+	case WatermarkTopLeft:
+		pos = image.Pt(margin, margin)
+	case WatermarkTopRight:
+		pos = image.Pt(b.Dx()-m.Dx()-margin, margin)
+	case WatermarkBottomLeft:
+		pos = image.Pt(margin, b.Dy()-m.Dy()-margin)
+	default:
+		pos = image.Pt(b.Dx()-m.Dx()-margin, b.Dy()-m.Dy()-margin)
+	}
+
+	return imaging.Overlay(img, mark, pos.Add(b.Min), w.opacity()), nil
+}
+
+// Watermarked returns the file name of a watermarked copy of an existing thumbnail, creating it if needed.
+func Watermarked(thumbName string, w Watermark) (fileName string, err error) {
+	if w.Empty() {
+		return thumbName, nil
+	}
+
+	ext := filepath.Ext(thumbName)
+	fileName = fmt.Sprintf("%s_wm%s%s", strings.TrimSuffix(thumbName, ext), w.Hash(), ext)
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	img, err := imaging.Open(thumbName)
+
+	if err != nil {
+		return "", fmt.Errorf("watermark: can't open %s (%s)", txt.Quote(filepath.Base(thumbName)), err)
+	}
+
+	result, err := w.Apply(img)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := imaging.Save(result, fileName, imaging.JPEGQuality(JpegQuality)); err != nil {
+		log.Errorf("watermark: failed to save %s", txt.Quote(filepath.Base(fileName)))
+		return "", err
+	}
+
+	return fileName, nil
+}
@@ -0,0 +1,121 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatermark_Empty(t *testing.T) {
+	assert.True(t, Watermark{}.Empty())
+	assert.False(t, Watermark{Text: "Proof"}.Empty())
+	assert.False(t, Watermark{Image: "logo.png"}.Empty())
+}
+
+func TestWatermark_Hash(t *testing.T) {
+	a := Watermark{Text: "Proof"}
+	b := Watermark{Text: "Proof", Position: WatermarkBottomRight, Opacity: WatermarkOpacity}
+	c := Watermark{Text: "Proof", Position: WatermarkCenter}
+
+	assert.Len(t, a.Hash(), 8)
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.NotEqual(t, a.Hash(), c.Hash())
+}
+
+func TestWatermark_Apply(t *testing.T) {
+	img := imaging.New(400, 200, color.Black)
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := Watermark{}.Apply(img)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, img, result)
+	})
+	t.Run("bottom right", func(t *testing.T) {
+		result, err := Watermark{Text: "Proof", Opacity: 1}.Apply(img)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, img.Bounds(), result.Bounds())
+
+		changed := false
+
+		for x := 200; x < 400 && !changed; x++ {
+			for y := 100; y < 200; y++ {
+				if r, _, _, _ := result.At(x, y).RGBA(); r > 0 {
+					changed = true
+					break
+				}
+			}
+		}
+
+		assert.True(t, changed)
+
+		r, _, _, _ := result.At(10, 10).RGBA()
+		assert.Equal(t, uint32(0), r)
+	})
+	t.Run("image not found", func(t *testing.T) {
+		_, err := Watermark{Image: "testdata/xxx.png"}.Apply(img)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWatermarked(t *testing.T) {
+	thumbName := filepath.Join(os.TempDir(), "photoprism-watermark-test.jpg")
+
+	if err := imaging.Save(imaging.New(300, 200, color.Black), thumbName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(thumbName)
+
+	t.Run("text", func(t *testing.T) {
+		fileName, err := Watermarked(thumbName, Watermark{Text: "Proof", Position: WatermarkCenter})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		assert.NotEqual(t, thumbName, fileName)
+		assert.True(t, fs.FileExists(fileName))
+
+		img, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, image.Rect(0, 0, 300, 200), img.Bounds())
+	})
+	t.Run("logo", func(t *testing.T) {
+		fileName, err := Watermarked(thumbName, Watermark{Image: "testdata/example.png", Opacity: 0.3})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		assert.True(t, fs.FileExists(fileName))
+	})
+	t.Run("empty", func(t *testing.T) {
+		fileName, err := Watermarked(thumbName, Watermark{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, thumbName, fileName)
+	})
+}
@@ -0,0 +1,228 @@
+/*
+This package keeps album and label cover thumbnails in memory and persists the metadata of cached
+entries to disk, so that the most recently accessed covers can be loaded again after a restart instead
+of being regenerated by many concurrent requests.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package thumbcache
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// MaxEntries is the max number of entries whose metadata is kept on disk.
+const MaxEntries = 10000
+
+// WarmUpLimit is the default number of thumbnails loaded into memory on startup, see WarmUp.
+const WarmUpLimit = 500
+
+// Entry contains the metadata of a cached thumbnail.
+type Entry struct {
+	FileName   string        `json:"file"`
+	Expiration time.Duration `json:"expiration"`
+	Accessed   time.Time     `json:"accessed"`
+}
+
+// Cache is a write-behind thumbnail cache, see Run.
+type Cache struct {
+	cache    *gc.Cache
+	fileName string
+	entries  map[string]Entry
+	dirty    bool
+	mutex    sync.Mutex
+}
+
+// New returns a thumbnail cache that stores image data in the given memory cache and the metadata of
+// entries in fileName. Metadata saved by a previous instance is loaded if it exists.
+func New(cache *gc.Cache, fileName string) *Cache {
+	c := &Cache{
+		cache:    cache,
+		fileName: fileName,
+		entries:  make(map[string]Entry),
+	}
+
+	if fileName == "" {
+		return c
+	}
+
+	if data, err := ioutil.ReadFile(fileName); err != nil {
+		log.Debugf("thumbcache: %s", err)
+	} else if err := json.Unmarshal(data, &c.entries); err != nil {
+		log.Errorf("thumbcache: %s", err)
+	}
+
+	return c
+}
+
+// Get returns the cached thumbnail data for key.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	data, ok := c.cache.Get(key)
+
+	if !ok {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, found := c.entries[key]; found {
+		e.Accessed = time.Now().UTC()
+		c.entries[key] = e
+		c.dirty = true
+	}
+
+	return data.([]byte), true
+}
+
+// Set caches thumbnail data read from fileName, so that it can be loaded from there again after a restart.
+func (c *Cache) Set(key, fileName string, data []byte, expiration time.Duration) {
+	c.cache.Set(key, data, expiration)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = Entry{FileName: fileName, Expiration: expiration, Accessed: time.Now().UTC()}
+	c.dirty = true
+}
+
+// Delete removes a thumbnail from the cache, e.g. after an album cover was changed.
+func (c *Cache) Delete(key string) {
+	c.cache.Delete(key)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, found := c.entries[key]; found {
+		delete(c.entries, key)
+		c.dirty = true
+	}
+}
+
+// recent returns the keys of all entries, most recently accessed first.
+func (c *Cache) recent() []string {
+	keys := make([]string, 0, len(c.entries))
+
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].Accessed.After(c.entries[keys[j]].Accessed)
+	})
+
+	return keys
+}
+
+// Save writes the metadata of cached entries to disk if it has changed. Only the MaxEntries most recently
+// accessed entries are kept.
+func (c *Cache) Save() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.dirty || c.fileName == "" {
+		return nil
+	}
+
+	if keys := c.recent(); len(keys) > MaxEntries {
+		for _, key := range keys[MaxEntries:] {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.Marshal(c.entries)
+
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that a crash can't leave incomplete metadata behind.
+	tmpName := c.fileName + ".tmp"
+
+	if err := ioutil.WriteFile(tmpName, data, 0600); err != nil {
+		return err
+	} else if err := os.Rename(tmpName, c.fileName); err != nil {
+		return err
+	}
+
+	c.dirty = false
+
+	return nil
+}
+
+// WarmUp loads up to limit of the most recently accessed thumbnails into memory and returns their number.
+// Entries whose files no longer exist are removed.
+func (c *Cache) WarmUp(limit int) (loaded int) {
+	c.mutex.Lock()
+	keys := c.recent()
+	c.mutex.Unlock()
+
+	for _, key := range keys {
+		if loaded >= limit {
+			break
+		}
+
+		c.mutex.Lock()
+		e, found := c.entries[key]
+		c.mutex.Unlock()
+
+		if !found {
+			continue
+		} else if _, cached := c.cache.Get(key); cached {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(e.FileName)
+
+		if err != nil {
+			log.Debugf("thumbcache: %s", err)
+
+			c.mutex.Lock()
+			delete(c.entries, key)
+			c.dirty = true
+			c.mutex.Unlock()
+
+			continue
+		}
+
+		c.cache.Set(key, data, e.Expiration)
+		loaded++
+	}
+
+	return loaded
+}
+
+// Run saves changed metadata in the given interval until the context is canceled, and once more before
+// it returns, so that writing to disk doesn't slow down requests.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := c.Save(); err != nil {
+				log.Errorf("thumbcache: %s", err)
+			}
+
+			return
+		case <-ticker.C:
+			if err := c.Save(); err != nil {
+				log.Errorf("thumbcache: %s", err)
+			}
+		}
+	}
+}
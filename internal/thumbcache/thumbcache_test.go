@@ -0,0 +1,106 @@
+package thumbcache
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "thumbcache")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestCache_Get(t *testing.T) {
+	c := New(gc.New(time.Hour, time.Hour), "")
+
+	_, ok := c.Get("album-thumbnail:at9lxuqxpogaaba8:tile_500")
+	assert.False(t, ok)
+
+	c.Set("album-thumbnail:at9lxuqxpogaaba8:tile_500", "/tmp/thumb.jpg", []byte("jpeg"), time.Hour)
+
+	data, ok := c.Get("album-thumbnail:at9lxuqxpogaaba8:tile_500")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("jpeg"), data)
+
+	c.Delete("album-thumbnail:at9lxuqxpogaaba8:tile_500")
+
+	_, ok = c.Get("album-thumbnail:at9lxuqxpogaaba8:tile_500")
+	assert.False(t, ok)
+	assert.Empty(t, c.entries)
+}
+
+func TestCache_WarmUp(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "thumbcache.json")
+	thumbs := []string{filepath.Join(dir, "1.jpg"), filepath.Join(dir, "2.jpg"), filepath.Join(dir, "3.jpg")}
+
+	for _, name := range thumbs[:2] {
+		if err := ioutil.WriteFile(name, []byte(filepath.Base(name)), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := New(gc.New(time.Hour, time.Hour), fileName)
+	c.Set("album-thumbnail:1:tile_500", thumbs[0], []byte("1.jpg"), time.Hour)
+	c.Set("album-thumbnail:2:tile_500", thumbs[1], []byte("2.jpg"), time.Hour)
+	c.Set("album-thumbnail:3:tile_500", thumbs[2], []byte("3.jpg"), time.Hour)
+
+	// Make the first entry the most recently accessed.
+	c.entries["album-thumbnail:1:tile_500"] = Entry{FileName: thumbs[0], Expiration: time.Hour, Accessed: time.Now().Add(time.Minute)}
+
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, fileName)
+
+	t.Run("limit", func(t *testing.T) {
+		restarted := New(gc.New(time.Hour, time.Hour), fileName)
+		assert.Len(t, restarted.entries, 3)
+		assert.Equal(t, 1, restarted.WarmUp(1))
+
+		data, ok := restarted.Get("album-thumbnail:1:tile_500")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1.jpg"), data)
+
+		_, ok = restarted.Get("album-thumbnail:2:tile_500")
+		assert.False(t, ok)
+	})
+	t.Run("missing files", func(t *testing.T) {
+		restarted := New(gc.New(time.Hour, time.Hour), fileName)
+		assert.Equal(t, 2, restarted.WarmUp(10))
+		assert.Len(t, restarted.entries, 2)
+		assert.True(t, restarted.dirty)
+	})
+}
+
+func TestCache_Run(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "thumbcache.json")
+	c := New(gc.New(time.Hour, time.Hour), fileName)
+	c.Set("label-thumbnail:lt9k3pw1wowuy3c2:tile_224", filepath.Join(dir, "1.jpg"), []byte("jpeg"), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c.Run(ctx, time.Hour)
+
+	assert.FileExists(t, fileName)
+	assert.False(t, c.dirty)
+}
@@ -0,0 +1,140 @@
+package tier
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Dir represents a cold storage folder, e.g. on a NAS or a mounted bucket.
+type Dir struct {
+	root string
+}
+
+// NewDir returns a cold storage folder, which is created if it doesn't exist.
+func NewDir(root string) (*Dir, error) {
+	root = fs.Abs(root)
+
+	if root == "" {
+		return nil, fmt.Errorf("tier: invalid folder name")
+	}
+
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("tier: %s", err)
+	}
+
+	return &Dir{root: root}, nil
+}
+
+// newDir returns a cold storage folder for file:// URIs.
+func newDir(uri *url.URL) (Store, error) {
+	return NewDir(uri.Path)
+}
+
+// fileName returns the absolute file name in cold storage.
+func (d *Dir) fileName(name string) (string, error) {
+	name, err := cleanName(name)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(d.root, filepath.FromSlash(name)), nil
+}
+
+// Put copies a local file to cold storage.
+func (d *Dir) Put(src, name string) error {
+	dest, err := d.fileName(name)
+
+	if err != nil {
+		return err
+	}
+
+	return copyFile(src, dest)
+}
+
+// Fetch copies a file from cold storage to a local destination.
+func (d *Dir) Fetch(name, dest string) error {
+	src, err := d.fileName(name)
+
+	if err != nil {
+		return err
+	}
+
+	if !fs.FileExists(src) {
+		return ErrNotFound
+	}
+
+	return copyFile(src, dest)
+}
+
+// Delete removes a file from cold storage.
+func (d *Dir) Delete(name string) error {
+	fileName, err := d.fileName(name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// String returns the cold storage folder name.
+func (d *Dir) String() string {
+	return d.root
+}
+
+// copyFile copies a file using a temporary name, so that incomplete copies never replace the destination.
+func copyFile(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	info, err := in.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	tmpName := fmt.Sprintf("%s.%s.tmp", dest, rnd.Token(6))
+
+	out, err := os.OpenFile(tmpName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	written, err := io.Copy(out, in)
+
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil && written != info.Size() {
+		err = fmt.Errorf("tier: copied %d of %d bytes", written, info.Size())
+	}
+
+	if err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, dest)
+}
@@ -0,0 +1,60 @@
+package tier
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tier")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "originals", "IMG_1234.jpg")
+
+	if err := os.MkdirAll(filepath.Dir(src), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(src, []byte("jpeg data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewDir(filepath.Join(dir, "cold"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("put", func(t *testing.T) {
+		assert.NoError(t, store.Put(src, "2020/05/IMG_1234.jpg"))
+		assert.FileExists(t, filepath.Join(dir, "cold", "2020", "05", "IMG_1234.jpg"))
+	})
+	t.Run("fetch", func(t *testing.T) {
+		dest := filepath.Join(dir, "cache", "IMG_1234.jpg")
+
+		assert.NoError(t, store.Fetch("2020/05/IMG_1234.jpg", dest))
+
+		data, err := ioutil.ReadFile(dest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg data", string(data))
+	})
+	t.Run("fetch missing", func(t *testing.T) {
+		err := store.Fetch("2020/05/IMG_9999.jpg", filepath.Join(dir, "cache", "IMG_9999.jpg"))
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert.NoError(t, store.Delete("2020/05/IMG_1234.jpg"))
+		assert.NoError(t, store.Delete("2020/05/IMG_1234.jpg"))
+		assert.Equal(t, ErrNotFound, store.Fetch("2020/05/IMG_1234.jpg", filepath.Join(dir, "cache", "x.jpg")))
+	})
+}
@@ -0,0 +1,81 @@
+package tier
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+)
+
+// WebDAV represents a cold storage folder on a WebDAV server.
+type WebDAV struct {
+	client webdav.Client
+	url    string
+}
+
+// newWebDAV returns a WebDAV store for webdav:// and webdavs:// URIs, using http and https respectively.
+func newWebDAV(uri *url.URL) (Store, error) {
+	if uri.Host == "" {
+		return nil, fmt.Errorf("tier: webdav host missing")
+	}
+
+	scheme := "http"
+
+	if uri.Scheme == "webdavs" {
+		scheme = "https"
+	}
+
+	u := url.URL{Scheme: scheme, Host: uri.Host, Path: uri.Path}
+
+	var user, pass string
+
+	if uri.User != nil {
+		user = uri.User.Username()
+		pass, _ = uri.User.Password()
+	}
+
+	return &WebDAV{client: webdav.New(u.String(), user, pass), url: u.String()}, nil
+}
+
+// Put uploads a local file to the WebDAV server.
+func (w *WebDAV) Put(src, name string) error {
+	name, err := cleanName(name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := w.client.CreateDir(path.Dir(name)); err != nil {
+		return err
+	}
+
+	return w.client.Upload(src, name)
+}
+
+// Fetch downloads a file from the WebDAV server.
+func (w *WebDAV) Fetch(name, dest string) error {
+	name, err := cleanName(name)
+
+	if err != nil {
+		return err
+	}
+
+	return w.client.Download(name, dest, true)
+}
+
+// Delete removes a file from the WebDAV server.
+func (w *WebDAV) Delete(name string) error {
+	name, err := cleanName(name)
+
+	if err != nil {
+		return err
+	}
+
+	return w.client.Delete(name)
+}
+
+// String returns the server URL without credentials.
+func (w *WebDAV) String() string {
+	return w.url
+}
@@ -0,0 +1,74 @@
+package video
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PosterScene selects the most representative of the first PosterSceneFrames frames as poster instead of
+// using a fixed offset, so that black or blurry frames at the beginning of a video are skipped.
+const PosterScene = "scene"
+
+// PosterSceneFrames is the number of frames compared to find a representative poster frame.
+const PosterSceneFrames = 300
+
+// PosterOffset parses the poster setting, either "scene", seconds like "1.5", a Go duration like "2s",
+// or a timestamp like "00:00:02". The offset is limited to half the duration of the video if known.
+func PosterOffset(s string, duration time.Duration) (offset time.Duration, scene bool) {
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	switch {
+	case s == PosterScene:
+		scene = true
+	case strings.Contains(s, ":"):
+		var h, m int
+		var sec float64
+
+		if _, err := fmt.Sscanf(s, "%d:%d:%g", &h, &m, &sec); err == nil {
+			offset = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second))
+		}
+	default:
+		if sec, err := strconv.ParseFloat(s, 64); err == nil {
+			offset = time.Duration(sec * float64(time.Second))
+		} else if d, err := time.ParseDuration(s); err == nil {
+			offset = d
+		}
+	}
+
+	if offset < time.Millisecond {
+		offset = time.Millisecond
+	}
+
+	if duration > 0 && offset > duration/2 {
+		offset = duration / 2
+	}
+
+	return offset, scene
+}
+
+// timestamp formats a duration as ffmpeg timestamp, e.g. "00:00:01.500".
+func timestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// PosterArgs returns the ffmpeg arguments for extracting a poster frame as JPEG, the optional filter
+// is applied before selecting a representative frame, see Filter.
+func PosterArgs(fileName, jpegName string, offset time.Duration, scene bool, filter string) []string {
+	if scene {
+		if filter != "" {
+			filter += ","
+		}
+
+		return []string{"-i", fileName, "-vf", filter + fmt.Sprintf("thumbnail=%d", PosterSceneFrames), "-frames:v", "1", jpegName}
+	}
+
+	if filter != "" {
+		return []string{"-ss", timestamp(offset), "-i", fileName, "-vf", filter, "-vframes", "1", jpegName}
+	}
+
+	return []string{"-ss", timestamp(offset), "-i", fileName, "-vframes", "1", jpegName}
+}
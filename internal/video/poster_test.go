@@ -0,0 +1,56 @@
+package video
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosterOffset(t *testing.T) {
+	t.Run("seconds", func(t *testing.T) {
+		offset, scene := PosterOffset("1.5", 0)
+		assert.Equal(t, 1500*time.Millisecond, offset)
+		assert.False(t, scene)
+	})
+	t.Run("duration", func(t *testing.T) {
+		offset, _ := PosterOffset("2s", time.Minute)
+		assert.Equal(t, 2*time.Second, offset)
+	})
+	t.Run("timestamp", func(t *testing.T) {
+		offset, _ := PosterOffset("00:01:02.5", 0)
+		assert.Equal(t, time.Minute+2500*time.Millisecond, offset)
+	})
+	t.Run("scene", func(t *testing.T) {
+		_, scene := PosterOffset("Scene", 0)
+		assert.True(t, scene)
+	})
+	t.Run("empty", func(t *testing.T) {
+		offset, scene := PosterOffset("", 0)
+		assert.Equal(t, time.Millisecond, offset)
+		assert.False(t, scene)
+	})
+	t.Run("short video", func(t *testing.T) {
+		offset, _ := PosterOffset("3", 2*time.Second)
+		assert.Equal(t, time.Second, offset)
+	})
+}
+
+func TestPosterArgs(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		args := PosterArgs("video.mp4", "video.jpg", 61500*time.Millisecond, false, "")
+		assert.Equal(t, []string{"-ss", "00:01:01.500", "-i", "video.mp4", "-vframes", "1", "video.jpg"}, args)
+	})
+	t.Run("scene", func(t *testing.T) {
+		args := PosterArgs("video.mp4", "video.jpg", 0, true, "")
+		assert.Equal(t, []string{"-i", "video.mp4", "-vf", "thumbnail=300", "-frames:v", "1", "video.jpg"}, args)
+	})
+	t.Run("filter", func(t *testing.T) {
+		args := PosterArgs("video.mts", "video.jpg", time.Second, false, "yadif")
+		assert.Equal(t, []string{"-ss", "00:00:01.000", "-i", "video.mts", "-vf", "yadif", "-vframes", "1", "video.jpg"}, args)
+	})
+	t.Run("scene filter", func(t *testing.T) {
+		args := PosterArgs("video.mts", "video.jpg", 0, true, "yadif")
+		assert.Equal(t, []string{"-i", "video.mts", "-vf", "yadif,thumbnail=300", "-frames:v", "1", "video.jpg"}, args)
+	})
+}
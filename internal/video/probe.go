@@ -0,0 +1,134 @@
+package video
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// Info contains the technical properties of a video, width and height are the display size.
+type Info struct {
+	Duration   time.Duration
+	Codec      string
+	Width      int
+	Height     int
+	Rotation   int
+	Interlaced bool
+	Anamorphic bool
+}
+
+// probeResult represents the JSON output of ffprobe.
+type probeResult struct {
+	Streams []struct {
+		CodecType  string            `json:"codec_type"`
+		CodecName  string            `json:"codec_name"`
+		Width      int               `json:"width"`
+		Height     int               `json:"height"`
+		FieldOrder string            `json:"field_order"`
+		SampleAR   string            `json:"sample_aspect_ratio"`
+		Duration   string            `json:"duration"`
+		Tags       map[string]string `json:"tags"`
+	} `json:"streams"`
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+// seconds converts a duration in seconds like "12.345" to time.Duration.
+func seconds(s string) time.Duration {
+	sec, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || sec < 0 {
+		return 0
+	}
+
+	return time.Duration(sec * float64(time.Second))
+}
+
+// interlaced returns true if the ffprobe field order indicates interlaced video, e.g. "tt" or "bb".
+func interlaced(fieldOrder string) bool {
+	switch fieldOrder {
+	case "tt", "bb", "tb", "bt":
+		return true
+	default:
+		return false
+	}
+}
+
+// displayWidth returns the width of a video with non-square pixels when displayed,
+// e.g. 768 for 720 pixels and a sample aspect ratio of "16:15" like PAL DV.
+func displayWidth(width int, sampleAR string) int {
+	var num, den int
+
+	if _, err := fmt.Sscanf(sampleAR, "%d:%d", &num, &den); err != nil || num <= 0 || den <= 0 || num == den {
+		return width
+	}
+
+	// Round to an even number, as required by most codecs.
+	return int(math.Round(float64(width*num)/float64(den)/2)) * 2
+}
+
+// ParseProbe returns the properties of the first video stream found in the JSON output of ffprobe.
+func ParseProbe(data []byte) (info Info, err error) {
+	var result probeResult
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return info, fmt.Errorf("video: can't parse probe result (%s)", err)
+	}
+
+	for _, s := range result.Streams {
+		if s.CodecType != "video" {
+			continue
+		}
+
+		info.Codec = s.CodecName
+		info.Width = s.Width
+		info.Height = s.Height
+		info.Duration = seconds(s.Duration)
+		info.Interlaced = interlaced(s.FieldOrder)
+
+		if w := displayWidth(s.Width, s.SampleAR); w != s.Width {
+			info.Width = w
+			info.Anamorphic = true
+		}
+
+		if r, err := strconv.Atoi(s.Tags["rotate"]); err == nil {
+			info.Rotation = r
+		}
+
+		break
+	}
+
+	if info.Codec == "" {
+		return info, fmt.Errorf("video: no video stream found")
+	}
+
+	if info.Duration == 0 {
+		info.Duration = seconds(result.Format.Duration)
+	}
+
+	// Rotated videos are displayed with swapped width and height.
+	if info.Rotation == 90 || info.Rotation == -90 || info.Rotation == 270 {
+		info.Width, info.Height = info.Height, info.Width
+	}
+
+	return info, nil
+}
+
+// Probe runs ffprobe and returns the properties of the first video stream.
+func Probe(ffprobeBin, fileName string) (info Info, err error) {
+	if ffprobeBin == "" {
+		return info, fmt.Errorf("video: ffprobe not found")
+	}
+
+	out, err := exec.Command(ffprobeBin, "-v", "quiet", "-print_format", "json", "-show_format", "-show_streams", fileName).Output()
+
+	if err != nil {
+		return info, fmt.Errorf("video: ffprobe failed (%s)", err)
+	}
+
+	return ParseProbe(out)
+}
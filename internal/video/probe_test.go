@@ -0,0 +1,82 @@
+package video
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProbe(t *testing.T) {
+	t.Run("rotated", func(t *testing.T) {
+		data, err := ioutil.ReadFile("testdata/probe.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := ParseProbe(data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "hevc", info.Codec)
+		assert.Equal(t, 1080, info.Width)
+		assert.Equal(t, 1920, info.Height)
+		assert.Equal(t, 90, info.Rotation)
+		assert.Equal(t, 15015*time.Millisecond, info.Duration)
+	})
+	t.Run("format duration", func(t *testing.T) {
+		info, err := ParseProbe([]byte(`{"streams": [{"codec_type": "video", "codec_name": "h264", "width": 640, "height": 480}], "format": {"duration": "2.5"}}`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "h264", info.Codec)
+		assert.Equal(t, 640, info.Width)
+		assert.Equal(t, 2500*time.Millisecond, info.Duration)
+	})
+	t.Run("dv", func(t *testing.T) {
+		info, err := ParseProbe([]byte(`{"streams": [{"codec_type": "video", "codec_name": "dvvideo", "width": 720, "height": 576, "field_order": "bb", "sample_aspect_ratio": "16:15", "duration": "60"}]}`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dvvideo", info.Codec)
+		assert.Equal(t, 768, info.Width)
+		assert.Equal(t, 576, info.Height)
+		assert.True(t, info.Interlaced)
+		assert.True(t, info.Anamorphic)
+	})
+	t.Run("square pixels", func(t *testing.T) {
+		info, err := ParseProbe([]byte(`{"streams": [{"codec_type": "video", "codec_name": "h264", "width": 1920, "height": 1080, "field_order": "progressive", "sample_aspect_ratio": "1:1"}]}`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1920, info.Width)
+		assert.False(t, info.Interlaced)
+		assert.False(t, info.Anamorphic)
+	})
+	t.Run("no video", func(t *testing.T) {
+		_, err := ParseProbe([]byte(`{"streams": [{"codec_type": "audio", "codec_name": "aac"}]}`))
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseProbe([]byte(`xxx`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestProbe(t *testing.T) {
+	_, err := Probe("", "testdata/probe.json")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package video
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Legacy contains video formats that can't be played in browsers and are transcoded to MP4,
+// typically interlaced camcorder footage with non-square pixels.
+var Legacy = map[fs.FileType]bool{
+	fs.TypeAvi:   true,
+	fs.TypeAVCHD: true,
+	fs.TypeDV:    true,
+}
+
+// Filter returns the ffmpeg video filter needed to display the video correctly: interlaced video is
+// deinterlaced and non-square pixels are scaled to the display aspect ratio. The result is empty if
+// no filter is needed.
+func Filter(info Info) string {
+	var filters []string
+
+	if info.Interlaced {
+		filters = append(filters, "yadif")
+	}
+
+	if info.Anamorphic {
+		filters = append(filters, "scale=trunc(iw*sar/2)*2:ih", "setsar=1")
+	}
+
+	return strings.Join(filters, ",")
+}
+
+// TranscodeArgs returns the ffmpeg arguments for transcoding a video to MP4 with H.264 video and
+// AAC audio, so that it can be played in all browsers.
+func TranscodeArgs(fileName, mp4Name string, info Info) []string {
+	args := []string{"-i", fileName}
+
+	if filter := Filter(info); filter != "" {
+		args = append(args, "-vf", filter)
+	}
+
+	return append(args,
+		"-c:v", "libx264", "-preset", "fast", "-crf", "23", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k",
+		"-map_metadata", "0", "-movflags", "+faststart",
+		"-f", "mp4", mp4Name)
+}
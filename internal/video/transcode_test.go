@@ -0,0 +1,41 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacy(t *testing.T) {
+	assert.True(t, Legacy[fs.TypeAVCHD])
+	assert.True(t, Legacy[fs.TypeDV])
+	assert.True(t, Legacy[fs.TypeAvi])
+	assert.False(t, Legacy[fs.TypeMP4])
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("progressive", func(t *testing.T) {
+		assert.Equal(t, "", Filter(Info{Width: 1920, Height: 1080}))
+	})
+	t.Run("interlaced", func(t *testing.T) {
+		assert.Equal(t, "yadif", Filter(Info{Width: 1920, Height: 1080, Interlaced: true}))
+	})
+	t.Run("dv", func(t *testing.T) {
+		assert.Equal(t, "yadif,scale=trunc(iw*sar/2)*2:ih,setsar=1", Filter(Info{Width: 768, Height: 576, Interlaced: true, Anamorphic: true}))
+	})
+}
+
+func TestTranscodeArgs(t *testing.T) {
+	t.Run("interlaced", func(t *testing.T) {
+		args := TranscodeArgs("00001.MTS", "00001.mp4", Info{Interlaced: true})
+		assert.Equal(t, []string{"-i", "00001.MTS", "-vf", "yadif"}, args[:4])
+		assert.Equal(t, "00001.mp4", args[len(args)-1])
+		assert.Contains(t, args, "libx264")
+	})
+	t.Run("progressive", func(t *testing.T) {
+		args := TranscodeArgs("clip.avi", "clip.mp4", Info{})
+		assert.NotContains(t, args, "-vf")
+		assert.Equal(t, "clip.mp4", args[len(args)-1])
+	})
+}
@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Checkpoints represents a worker that periodically saves library metadata in checkpoints.
+type Checkpoints struct {
+	conf *config.Config
+}
+
+// NewCheckpoints returns a new checkpoint worker.
+func NewCheckpoints(conf *config.Config) *Checkpoints {
+	return &Checkpoints{conf: conf}
+}
+
+// Start creates a new checkpoint if the latest checkpoint is older than the configured interval.
+func (worker *Checkpoints) Start() error {
+	interval := worker.conf.CheckpointInterval()
+
+	if interval <= 0 {
+		return nil
+	}
+
+	latest, err := query.Checkpoints(1, 0)
+
+	if err != nil {
+		return err
+	}
+
+	if len(latest) > 0 && time.Since(latest[0].CreatedAt) < interval {
+		return nil
+	}
+
+	_, err = photoprism.CreateCheckpoint(worker.conf, "Scheduled")
+
+	return err
+}
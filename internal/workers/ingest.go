@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"io/ioutil"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ingest"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// Ingest represents a worker that imports files uploaded by third-party backup apps like Immich.
+type Ingest struct {
+	conf *config.Config
+}
+
+// NewIngest returns a new ingest worker.
+func NewIngest(conf *config.Config) *Ingest {
+	return &Ingest{conf: conf}
+}
+
+// Start imports the files in the ingest folder, if any, and applies the metadata sent by backup apps
+// to the imported photos.
+func (worker *Ingest) Start() error {
+	q := service.Ingest()
+
+	if n := q.Expire(ingest.MaxAge); n > 0 {
+		log.Infof("ingest: discarded metadata of %d files that were not imported", n)
+	}
+
+	ingestPath := worker.conf.IngestPath()
+
+	if files, err := ioutil.ReadDir(ingestPath); err == nil && len(files) > 0 {
+		opt := photoprism.ImportOptionsMove(ingestPath)
+		opt.Scheduled = true
+
+		service.Import().Start(opt)
+	}
+
+	if q.Len() == 0 {
+		return nil
+	}
+
+	if n := photoprism.ApplyIngest(q); n > 0 {
+		log.Infof("ingest: applied metadata to %d photos", n)
+	}
+
+	return nil
+}
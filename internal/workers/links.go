@@ -0,0 +1,83 @@
+package workers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mail"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Links represents a worker that renews share links with an auto-renewal period before they expire,
+// and reminds the owner of all other links that are about to expire.
+type Links struct {
+	conf *config.Config
+}
+
+// NewLinks returns a new share link worker.
+func NewLinks(conf *config.Config) *Links {
+	return &Links{conf: conf}
+}
+
+// Start renews and reminds of share links expiring within the configured reminder period.
+func (worker *Links) Start() error {
+	within := worker.conf.ShareReminder()
+
+	if within <= 0 {
+		return nil
+	}
+
+	links, err := query.LinksExpiring(within)
+
+	if err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if link.LinkRenewal > 0 {
+			if err := link.Renew(time.Duration(link.LinkRenewal) * time.Second); err != nil {
+				log.Errorf("share: %s", err)
+				continue
+			}
+
+			log.Infof("share: renewed link %s until %s", link.LinkToken, link.LinkExpires.Format("2006-01-02"))
+
+			event.Publish("links.renewed", event.Data{"token": link.LinkToken, "expires": link.LinkExpires})
+
+			continue
+		}
+
+		if link.LinkNotified != nil {
+			continue
+		}
+
+		worker.remind(link)
+	}
+
+	return nil
+}
+
+// remind notifies the owner that a link is about to expire, by email if configured.
+func (worker *Links) remind(link entity.Link) {
+	url := link.URL(worker.conf.Url())
+	msg := fmt.Sprintf("share link %s expires on %s", url, link.LinkExpires.Format("2006-01-02"))
+
+	event.Warning(msg)
+	event.Publish("links.expiring", event.Data{"token": link.LinkToken, "url": url, "expires": link.LinkExpires})
+
+	if to := worker.conf.NotifyEmail(); to != "" {
+		body := fmt.Sprintf("The %s.\n\nYou can renew it in the sharing settings, or enable auto-renewal for long-lived shares.\n", msg)
+
+		if err := mail.Send(worker.conf.SmtpUrl(), to, "Share link expires soon", body); err != nil {
+			log.Errorf("share: %s", err)
+		}
+	}
+
+	// Reminders are only sent once, even if sending the email failed.
+	if err := link.SetNotified(); err != nil {
+		log.Errorf("share: %s", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 )
@@ -37,7 +38,13 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 			return false, nil
 		}
 
-		fileName := path.Join(worker.conf.OriginalsPath(), file.FileName)
+		fileName, err := photoprism.OriginalFileName(worker.conf, file)
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
+
 		remoteName := path.Join(a.SyncPath, file.FileName)
 		remoteDir := filepath.Dir(remoteName)
 
@@ -6,14 +6,22 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
 
+// MountInterval is the interval in which the availability of the originals folder is checked.
+const MountInterval = 30 * time.Second
+
 // Start runs PhotoPrism background workers every wakeup interval.
 func Start(conf *config.Config) {
 	ticker := time.NewTicker(conf.WakeupInterval())
+	mountTicker := time.NewTicker(MountInterval)
+
+	StartMount(conf)
 
 	go func() {
 		for {
@@ -21,14 +29,22 @@ func Start(conf *config.Config) {
 			case <-stop:
 				log.Info("shutting down workers")
 				ticker.Stop()
+				mountTicker.Stop()
 				mutex.PrismWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
 				return
+			case <-mountTicker.C:
+				StartMount(conf)
 			case <-ticker.C:
 				StartPrism(conf)
 				StartShare(conf)
 				StartSync(conf)
+				StartTiering(conf)
+				StartGeometry(conf)
+				StartLinks(conf)
+				StartCheckpoints(conf)
+				StartIngest(conf)
 			}
 		}
 	}()
@@ -51,6 +67,64 @@ func StartPrism(conf *config.Config) {
 	}
 }
 
+// StartTiering moves old originals to cold storage once, if enabled and within the index schedule.
+func StartTiering(conf *config.Config) {
+	if !conf.ColdTiering() || !conf.IndexSchedule().Contains(time.Now()) || mutex.WorkersBusy() {
+		return
+	}
+
+	go func() {
+		worker := photoprism.NewTiering(conf)
+		if _, err := worker.Start(photoprism.TieringOptions{}); err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// StartGeometry checks new photos for a wrong orientation and tilted horizon once, if enabled and within the index schedule.
+func StartGeometry(conf *config.Config) {
+	if !conf.DetectGeometry() || !conf.IndexSchedule().Contains(time.Now()) || mutex.WorkersBusy() {
+		return
+	}
+
+	go func() {
+		if _, err := service.Geometry().Start(); err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// StartLinks renews and reminds of expiring share links once.
+func StartLinks(conf *config.Config) {
+	go func() {
+		if err := NewLinks(conf).Start(); err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// StartCheckpoints saves library metadata in a new checkpoint once, if enabled and the latest is too old.
+func StartCheckpoints(conf *config.Config) {
+	go func() {
+		if err := NewCheckpoints(conf).Start(); err != nil {
+			log.Errorf("checkpoint: %s", err)
+		}
+	}()
+}
+
+// StartIngest imports files uploaded by third-party backup apps once, unless other workers are busy.
+func StartIngest(conf *config.Config) {
+	if conf.ReadOnly() || mutex.WorkersBusy() {
+		return
+	}
+
+	go func() {
+		if err := NewIngest(conf).Start(); err != nil {
+			log.Errorf("ingest: %s", err)
+		}
+	}()
+}
+
 // StartShare runs the share worker once.
 func StartShare(conf *config.Config) {
 	if !mutex.ShareWorker.Busy() {
@@ -63,6 +137,11 @@ func StartShare(conf *config.Config) {
 	}
 }
 
+// StartMount checks the availability of the originals folder once.
+func StartMount(conf *config.Config) {
+	go photoprism.OriginalsAvailable(conf)
+}
+
 // StartShare runs the sync worker once.
 func StartSync(conf *config.Config) {
 	if !mutex.SyncWorker.Busy() {
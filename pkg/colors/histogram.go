@@ -0,0 +1,105 @@
+package colors
+
+import (
+	"fmt"
+	"image"
+)
+
+// Exposure classes that can be used as search filter.
+const (
+	ExposureUnder  = "under"
+	ExposureOver   = "over"
+	ExposureNormal = "normal"
+)
+
+// ExposureLimit is the percentage of clipped pixels above which an image is considered under- or overexposed.
+const ExposureLimit = 20
+
+// Histogram contains the number of pixels in 16 sRGB luma ranges, from black to white.
+type Histogram [16]uint32
+
+// NewHistogram returns the luma histogram of an image.
+func NewHistogram(img image.Image) (h Histogram) {
+	bounds := img.Bounds()
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+
+			// Rec. 709 luma of the gamma encoded sRGB values, 0-255.
+			luma := (2126*(r>>8) + 7152*(g>>8) + 722*(b>>8)) / 10000
+
+			h[luma>>4]++
+		}
+	}
+
+	return h
+}
+
+// Total returns the total number of pixels.
+func (h Histogram) Total() (total uint32) {
+	for _, n := range h {
+		total += n
+	}
+
+	return total
+}
+
+// percent returns the percentage of pixels in a range.
+func (h Histogram) percent(n uint32) uint8 {
+	total := h.Total()
+
+	if total == 0 {
+		return 0
+	}
+
+	return uint8((uint64(n)*100 + uint64(total)/2) / uint64(total))
+}
+
+// Under returns the percentage of pixels that are almost black.
+func (h Histogram) Under() uint8 {
+	return h.percent(h[0])
+}
+
+// Over returns the percentage of pixels that are almost white.
+func (h Histogram) Over() uint8 {
+	return h.percent(h[len(h)-1])
+}
+
+// Exposure returns the exposure class based on the percentage of clipped pixels.
+func (h Histogram) Exposure() string {
+	return Exposure(h.Under(), h.Over())
+}
+
+// Exposure returns the exposure class for the percentages of under- and overexposed pixels.
+func Exposure(under, over uint8) string {
+	switch {
+	case under > ExposureLimit && under >= over:
+		return ExposureUnder
+	case over > ExposureLimit:
+		return ExposureOver
+	default:
+		return ExposureNormal
+	}
+}
+
+// Hex returns a summary of the histogram as hex encoded string, with values relative to the largest range.
+func (h Histogram) Hex() (result string) {
+	var max uint32
+
+	for _, n := range h {
+		if n > max {
+			max = n
+		}
+	}
+
+	for _, n := range h {
+		if max == 0 {
+			result += "0"
+		} else {
+			result += fmt.Sprintf("%X", (n*15+max/2)/max)
+		}
+	}
+
+	return result
+}
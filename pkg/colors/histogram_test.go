@@ -0,0 +1,61 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(colors ...color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, len(colors), 1))
+
+	for x, c := range colors {
+		img.Set(x, 0, c)
+	}
+
+	return img
+}
+
+var (
+	black = color.RGBA{A: 255}
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	gray  = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+)
+
+func TestNewHistogram(t *testing.T) {
+	h := NewHistogram(testImage(black, white, gray, gray))
+
+	assert.Equal(t, uint32(4), h.Total())
+	assert.Equal(t, uint32(1), h[0])
+	assert.Equal(t, uint32(2), h[8])
+	assert.Equal(t, uint32(1), h[15])
+}
+
+func TestHistogram_Under(t *testing.T) {
+	assert.Equal(t, uint8(75), NewHistogram(testImage(black, black, black, gray)).Under())
+	assert.Equal(t, uint8(0), Histogram{}.Under())
+}
+
+func TestHistogram_Over(t *testing.T) {
+	assert.Equal(t, uint8(50), NewHistogram(testImage(white, gray)).Over())
+	assert.Equal(t, uint8(0), NewHistogram(testImage(black, gray)).Over())
+}
+
+func TestHistogram_Exposure(t *testing.T) {
+	assert.Equal(t, ExposureUnder, NewHistogram(testImage(black, black, gray)).Exposure())
+	assert.Equal(t, ExposureOver, NewHistogram(testImage(white, white, gray)).Exposure())
+	assert.Equal(t, ExposureNormal, NewHistogram(testImage(black, white, gray, gray, gray, gray, gray, gray)).Exposure())
+}
+
+func TestExposure(t *testing.T) {
+	assert.Equal(t, ExposureUnder, Exposure(60, 30))
+	assert.Equal(t, ExposureOver, Exposure(30, 60))
+	assert.Equal(t, ExposureNormal, Exposure(20, 20))
+}
+
+func TestHistogram_Hex(t *testing.T) {
+	assert.Equal(t, "F0000000F000000F", NewHistogram(testImage(black, white, gray)).Hex())
+	assert.Equal(t, "0000000000000000", Histogram{}.Hex())
+}
@@ -0,0 +1,63 @@
+package colors
+
+import (
+	"image"
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Limits used to detect monochrome images, see Monochrome().
+const (
+	// MonoNeutral is the HCL chroma up to which a pixel is considered neutral gray.
+	MonoNeutral = 0.06
+	// MonoColored is the max percentage of colored pixels in a black-and-white image.
+	MonoColored = 2
+	// MonoToned is the max average chroma of colored pixels in toned images, e.g. sepia prints.
+	MonoToned = 0.25
+	// MonoHue is the min mean resultant length of the hue angles of toned images, 1 means a single hue.
+	MonoHue = 0.97
+)
+
+// Monochrome returns true if an image is black-and-white, or toned with a single hue like sepia and
+// cyanotype prints. Scanned film often has a slight color cast, so pixels with very low chroma are
+// considered neutral.
+func Monochrome(img image.Image) bool {
+	bounds := img.Bounds()
+
+	var pixels, colored int
+	var chromaSum, sinSum, cosSum float64
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			rgb := colorful.Color{R: float64(r) / 0xffff, G: float64(g) / 0xffff, B: float64(b) / 0xffff}
+			h, c, _ := rgb.Hcl()
+
+			pixels++
+
+			if c <= MonoNeutral {
+				continue
+			}
+
+			rad := h * math.Pi / 180
+
+			colored++
+			chromaSum += c
+			sinSum += math.Sin(rad)
+			cosSum += math.Cos(rad)
+		}
+	}
+
+	if pixels == 0 {
+		return false
+	}
+
+	if colored*100 <= pixels*MonoColored {
+		return true
+	}
+
+	n := float64(colored)
+
+	return chromaSum/n <= MonoToned && math.Hypot(sinSum, cosSum)/n >= MonoHue
+}
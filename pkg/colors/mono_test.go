@@ -0,0 +1,35 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonochrome(t *testing.T) {
+	t.Run("gray", func(t *testing.T) {
+		assert.True(t, Monochrome(testImage(black, white, gray, gray)))
+	})
+	t.Run("sepia", func(t *testing.T) {
+		dark := color.RGBA{R: 74, G: 58, B: 40, A: 255}
+		light := color.RGBA{R: 178, G: 153, B: 122, A: 255}
+		assert.True(t, Monochrome(testImage(dark, light, light, white)))
+	})
+	t.Run("color cast", func(t *testing.T) {
+		cast := color.RGBA{R: 130, G: 128, B: 126, A: 255}
+		assert.True(t, Monochrome(testImage(black, cast, cast, white)))
+	})
+	t.Run("colorful", func(t *testing.T) {
+		red := color.RGBA{R: 200, G: 30, B: 30, A: 255}
+		blue := color.RGBA{R: 30, G: 60, B: 200, A: 255}
+		assert.False(t, Monochrome(testImage(red, blue, gray, white)))
+	})
+	t.Run("vivid single hue", func(t *testing.T) {
+		red := color.RGBA{R: 220, G: 20, B: 20, A: 255}
+		assert.False(t, Monochrome(testImage(red, red, red, white)))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.False(t, Monochrome(testImage()))
+	})
+}
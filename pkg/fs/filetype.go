@@ -22,6 +22,8 @@ const (
 	TypeMov      FileType = "mov"  // Video files.
 	TypeMP4      FileType = "mp4"
 	TypeAvi      FileType = "avi"
+	TypeAVCHD    FileType = "mts"
+	TypeDV       FileType = "dv"
 	TypeXMP      FileType = "xmp"  // Adobe XMP sidecar file (XML).
 	TypeAAE      FileType = "aae"  // Apple sidecar file (XML).
 	TypeXML      FileType = "xml"  // XML metadata / config / sidecar file.
@@ -56,6 +58,9 @@ var FileExt = FileExtensions{
 	".mov":  TypeMov,
 	".avi":  TypeAvi,
 	".mp4":  TypeMP4,
+	".mts":  TypeAVCHD,
+	".m2ts": TypeAVCHD,
+	".dv":   TypeDV,
 	".yml":  TypeYaml,
 	".yaml": TypeYaml,
 	".jpg":  TypeJpeg,
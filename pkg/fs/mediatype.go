@@ -21,6 +21,8 @@ var MediaTypes = map[FileType]MediaType{
 	TypeAvi:      MediaVideo,
 	TypeMP4:      MediaVideo,
 	TypeMov:      MediaVideo,
+	TypeAVCHD:    MediaVideo,
+	TypeDV:       MediaVideo,
 	TypeXMP:      MediaSidecar,
 	TypeXML:      MediaSidecar,
 	TypeAAE:      MediaSidecar,
@@ -22,6 +22,12 @@ func TestGetMediaType(t *testing.T) {
 		assert.Equal(t, MediaVideo, result)
 	})
 
+	t.Run("camcorder", func(t *testing.T) {
+		assert.Equal(t, MediaVideo, GetMediaType("/00001.MTS"))
+		assert.Equal(t, MediaVideo, GetMediaType("/00001.m2ts"))
+		assert.Equal(t, MediaVideo, GetMediaType("/tape.dv"))
+	})
+
 	t.Run("sidecar", func(t *testing.T) {
 		result := GetMediaType("/IMG_4120.AAE")
 		assert.Equal(t, MediaSidecar, result)
@@ -0,0 +1,34 @@
+package fs
+
+import (
+	"os"
+	"time"
+)
+
+// Available returns true if a directory exists and is not empty. Since network mounts may block
+// indefinitely after the connection dropped, false is also returned if it can't be read within the timeout.
+func Available(path string, timeout time.Duration) bool {
+	result := make(chan bool, 1)
+
+	go func() {
+		f, err := os.Open(path)
+
+		if err != nil {
+			result <- false
+			return
+		}
+
+		defer f.Close()
+
+		names, err := f.Readdirnames(1)
+
+		result <- err == nil && len(names) > 0
+	}()
+
+	select {
+	case ok := <-result:
+		return ok
+	case <-time.After(timeout):
+		return false
+	}
+}
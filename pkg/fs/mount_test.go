@@ -0,0 +1,29 @@
+package fs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailable(t *testing.T) {
+	t.Run("testdata", func(t *testing.T) {
+		assert.True(t, Available("./testdata", time.Second))
+	})
+	t.Run("not existing", func(t *testing.T) {
+		assert.False(t, Available("./testdata/xxx", time.Second))
+	})
+	t.Run("empty", func(t *testing.T) {
+		dir := os.TempDir() + "/TestAvailable"
+
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		assert.False(t, Available(dir, time.Second))
+	})
+}
@@ -0,0 +1,19 @@
+/*
+Package list provides functions for working with lists of strings.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package list
+
+// Contains returns true if the list contains the string.
+func Contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,19 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContains(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		assert.True(t, Contains([]string{"de", "en"}, "en"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.False(t, Contains([]string{"de", "en"}, "fr"))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.False(t, Contains(nil, ""))
+	})
+}
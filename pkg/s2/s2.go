@@ -16,6 +16,9 @@ import (
 	gs2 "github.com/golang/geo/s2"
 )
 
+// EarthRadius is the mean radius of the earth in km.
+const EarthRadius = 6371.0
+
 // Default cell level, see https://s2geometry.io/resources/s2cell_statistics.html.
 var DefaultLevel = 21
 
@@ -63,6 +66,11 @@ func IsZero(lat, lng float64) bool {
 	return lat == 0.0 && lng == 0.0
 }
 
+// Distance returns the great-circle distance between two coordinates in km.
+func Distance(latA, lngA, latB, lngB float64) float64 {
+	return gs2.LatLngFromDegrees(latA, lngA).Distance(gs2.LatLngFromDegrees(latB, lngB)).Radians() * EarthRadius
+}
+
 // Range returns a token range for finding nearby locations.
 func Range(token string, levelUp int) (min, max string) {
 	c := gs2.CellIDFromToken(token)
@@ -132,6 +132,18 @@ func TestIsZero(t *testing.T) {
 	})
 }
 
+func TestDistance(t *testing.T) {
+	t.Run("berlin_paris", func(t *testing.T) {
+		assert.InDelta(t, 878.0, Distance(52.5200, 13.4050, 48.8566, 2.3522), 2.0)
+	})
+	t.Run("date_line", func(t *testing.T) {
+		assert.InDelta(t, 22.2, Distance(0, 179.9, 0, -179.9), 0.1)
+	})
+	t.Run("same", func(t *testing.T) {
+		assert.Equal(t, 0.0, Distance(52.5200, 13.4050, 52.5200, 13.4050))
+	})
+}
+
 func TestRange(t *testing.T) {
 	t.Run("valid_1", func(t *testing.T) {
 		min, max := Range("4799e370ca54c8b9", 1)
@@ -0,0 +1,8 @@
+// +build !linux,!darwin,!freebsd,!openbsd,!netbsd
+
+package sysinfo
+
+// DiskFree returns the number of bytes available on the file system of path, or 0 if unknown.
+func DiskFree(path string) uint64 {
+	return 0
+}
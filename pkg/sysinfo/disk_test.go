@@ -0,0 +1,22 @@
+package sysinfo
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskFree(t *testing.T) {
+	t.Run("temp", func(t *testing.T) {
+		if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+			t.Skip("not supported")
+		}
+
+		assert.Less(t, uint64(0), DiskFree(os.TempDir()))
+	})
+	t.Run("not existing", func(t *testing.T) {
+		assert.Equal(t, uint64(0), DiskFree("/xxx/yyy"))
+	})
+}
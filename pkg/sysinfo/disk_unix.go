@@ -0,0 +1,16 @@
+// +build linux darwin freebsd openbsd netbsd
+
+package sysinfo
+
+import "syscall"
+
+// DiskFree returns the number of bytes available to unprivileged users on the file system of path.
+func DiskFree(path string) uint64 {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize)
+}
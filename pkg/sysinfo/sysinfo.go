@@ -0,0 +1,177 @@
+/*
+Package sysinfo reads memory and CPU limits of the host or container, so that workers can be scaled accordingly.
+
+Limits set by cgroups v1 and v2 take precedence over host resources. Functions return zero if a value is unknown,
+e.g. on operating systems other than Linux.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package sysinfo
+
+import (
+	"bufio"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Paths of the proc and cgroup file systems, can be changed for testing.
+var (
+	ProcPath   = "/proc"
+	CgroupPath = "/sys/fs/cgroup"
+)
+
+// MB is the number of bytes in a megabyte.
+const MB = 1024 * 1024
+
+// readUint reads the first number in a file, returns 0 if it doesn't exist or has no limit.
+func readUint(fileName string) uint64 {
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(data))
+
+	if len(fields) == 0 {
+		return 0
+	}
+
+	n, err := strconv.ParseUint(fields[0], 10, 64)
+
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
+
+// meminfo returns a value from /proc/meminfo in bytes.
+func meminfo(key string) uint64 {
+	f, err := os.Open(filepath.Join(ProcPath, "meminfo"))
+
+	if err != nil {
+		return 0
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 2 || fields[0] != key+":" {
+			continue
+		}
+
+		n, err := strconv.ParseUint(fields[1], 10, 64)
+
+		if err != nil {
+			return 0
+		}
+
+		return n * 1024
+	}
+
+	return 0
+}
+
+// cgroupMemory returns the cgroup memory limit and usage in bytes.
+func cgroupMemory() (limit, usage uint64) {
+	// cgroups v2
+	if limit = readUint(filepath.Join(CgroupPath, "memory.max")); limit > 0 {
+		return limit, readUint(filepath.Join(CgroupPath, "memory.current"))
+	}
+
+	// cgroups v1, unlimited is reported as a very large number.
+	if limit = readUint(filepath.Join(CgroupPath, "memory", "memory.limit_in_bytes")); limit > 0 && limit < math.MaxInt64/2 {
+		return limit, readUint(filepath.Join(CgroupPath, "memory", "memory.usage_in_bytes"))
+	}
+
+	return 0, 0
+}
+
+// MemoryTotal returns the total memory in bytes, taking cgroup limits into account.
+func MemoryTotal() uint64 {
+	total := meminfo("MemTotal")
+
+	if limit, _ := cgroupMemory(); limit > 0 && (total == 0 || limit < total) {
+		return limit
+	}
+
+	return total
+}
+
+// MemoryAvailable returns the memory available for new allocations in bytes, taking cgroup limits into account.
+func MemoryAvailable() uint64 {
+	available := meminfo("MemAvailable")
+
+	if limit, usage := cgroupMemory(); limit > 0 {
+		free := uint64(0)
+
+		if usage < limit {
+			free = limit - usage
+		}
+
+		if available == 0 || free < available {
+			return free
+		}
+	}
+
+	return available
+}
+
+// CPUs returns the number of usable CPU cores, taking cgroup CPU quotas into account.
+func CPUs() int {
+	numCPU := runtime.NumCPU()
+	quota, period := 0.0, 0.0
+
+	// cgroups v2, e.g. "200000 100000" or "max 100000"
+	if data, err := ioutil.ReadFile(filepath.Join(CgroupPath, "cpu.max")); err == nil {
+		if fields := strings.Fields(string(data)); len(fields) == 2 {
+			quota, _ = strconv.ParseFloat(fields[0], 64)
+			period, _ = strconv.ParseFloat(fields[1], 64)
+		}
+	} else {
+		// cgroups v1, unlimited is reported as -1.
+		quota = float64(readUint(filepath.Join(CgroupPath, "cpu", "cpu.cfs_quota_us")))
+		period = float64(readUint(filepath.Join(CgroupPath, "cpu", "cpu.cfs_period_us")))
+	}
+
+	if quota <= 0 || period <= 0 {
+		return numCPU
+	}
+
+	if n := int(math.Ceil(quota / period)); n < numCPU {
+		return n
+	}
+
+	return numCPU
+}
+
+// Load returns the average number of runnable processes in the last minute.
+func Load() float64 {
+	data, err := ioutil.ReadFile(filepath.Join(ProcPath, "loadavg"))
+
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(data))
+
+	if len(fields) == 0 {
+		return 0
+	}
+
+	load, _ := strconv.ParseFloat(fields[0], 64)
+
+	return load
+}
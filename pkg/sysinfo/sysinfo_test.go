@@ -0,0 +1,121 @@
+package sysinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSystem creates proc and cgroup files for testing and returns a function to restore the default paths.
+func fakeSystem(t *testing.T, files map[string]string) func() {
+	dir, err := ioutil.TempDir("", "sysinfo")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		fileName := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	procPath, cgroupPath := ProcPath, CgroupPath
+	ProcPath, CgroupPath = filepath.Join(dir, "proc"), filepath.Join(dir, "cgroup")
+
+	return func() {
+		ProcPath, CgroupPath = procPath, cgroupPath
+		os.RemoveAll(dir)
+	}
+}
+
+const testMeminfo = `MemTotal:        2048000 kB
+MemFree:          100000 kB
+MemAvailable:    1024000 kB
+`
+
+func TestMemoryTotal(t *testing.T) {
+	t.Run("host", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"proc/meminfo": testMeminfo})()
+		assert.Equal(t, uint64(2048000*1024), MemoryTotal())
+	})
+	t.Run("cgroup v2", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"proc/meminfo": testMeminfo, "cgroup/memory.max": "536870912\n"})()
+		assert.Equal(t, uint64(512*MB), MemoryTotal())
+	})
+	t.Run("cgroup v2 unlimited", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"proc/meminfo": testMeminfo, "cgroup/memory.max": "max\n"})()
+		assert.Equal(t, uint64(2048000*1024), MemoryTotal())
+	})
+	t.Run("cgroup v1 unlimited", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"proc/meminfo": testMeminfo, "cgroup/memory/memory.limit_in_bytes": "9223372036854771712\n"})()
+		assert.Equal(t, uint64(2048000*1024), MemoryTotal())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{})()
+		assert.Equal(t, uint64(0), MemoryTotal())
+	})
+}
+
+func TestMemoryAvailable(t *testing.T) {
+	t.Run("host", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"proc/meminfo": testMeminfo})()
+		assert.Equal(t, uint64(1024000*1024), MemoryAvailable())
+	})
+	t.Run("cgroup v1", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{
+			"proc/meminfo":                        testMeminfo,
+			"cgroup/memory/memory.limit_in_bytes": "536870912\n",
+			"cgroup/memory/memory.usage_in_bytes": "402653184\n",
+		})()
+		assert.Equal(t, uint64(128*MB), MemoryAvailable())
+	})
+	t.Run("cgroup v2 exceeded", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{
+			"proc/meminfo":          testMeminfo,
+			"cgroup/memory.max":     "536870912\n",
+			"cgroup/memory.current": "636870912\n",
+		})()
+		assert.Equal(t, uint64(0), MemoryAvailable())
+	})
+}
+
+func TestCPUs(t *testing.T) {
+	t.Run("host", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{})()
+		assert.Equal(t, runtime.NumCPU(), CPUs())
+	})
+	t.Run("cgroup v2", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"cgroup/cpu.max": "50000 100000\n"})()
+		assert.Equal(t, 1, CPUs())
+	})
+	t.Run("cgroup v2 unlimited", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"cgroup/cpu.max": "max 100000\n"})()
+		assert.Equal(t, runtime.NumCPU(), CPUs())
+	})
+	t.Run("cgroup v1", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"cgroup/cpu/cpu.cfs_quota_us": "100000\n", "cgroup/cpu/cpu.cfs_period_us": "100000\n"})()
+		assert.Equal(t, 1, CPUs())
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{"proc/loadavg": "1.50 0.80 0.40 2/345 12345\n"})()
+		assert.Equal(t, 1.5, Load())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		defer fakeSystem(t, map[string]string{})()
+		assert.Equal(t, 0.0, Load())
+	})
+}
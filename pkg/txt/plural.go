@@ -0,0 +1,12 @@
+package txt
+
+import "fmt"
+
+// Plural returns the count followed by the singular or plural noun, e.g. "1 photo" or "2 photos".
+func Plural(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+
+	return fmt.Sprintf("%d %s", count, plural)
+}
@@ -0,0 +1,19 @@
+package txt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlural(t *testing.T) {
+	t.Run("one", func(t *testing.T) {
+		assert.Equal(t, "1 photo", Plural(1, "photo", "photos"))
+	})
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, "0 photos", Plural(0, "photo", "photos"))
+	})
+	t.Run("many", func(t *testing.T) {
+		assert.Equal(t, "12 photos", Plural(12, "photo", "photos"))
+	})
+}
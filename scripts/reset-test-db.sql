@@ -16,3 +16,5 @@ DROP DATABASE IF EXISTS service;
 CREATE DATABASE IF NOT EXISTS service;
 DROP DATABASE IF EXISTS workers;
 CREATE DATABASE IF NOT EXISTS workers;
+DROP DATABASE IF EXISTS migrate;
+CREATE DATABASE IF NOT EXISTS migrate;